@@ -94,6 +94,12 @@ BCCSP:
 		{
 			ProviderName: "SW",
 		},
+		{
+			ProviderName: "GM",
+			GmOpts: &GmOpts{
+				Ephemeral: true,
+			},
+		},
 		jsonBCCSP,
 		yamlBCCSP,
 	}
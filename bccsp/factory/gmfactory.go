@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GMFactoryName is the name of the factory of the BCCSP implementation
+	// complying with the Chinese national cryptographic standards (SM2, SM3 and SM4)
+	GMFactoryName = "GM"
+)
+
+// GMFactory is the factory of the GM BCCSP.
+// The GM BCCSP is the software-based BCCSP with SM3 as default hash function,
+// hence it supports the SM algorithms on top of the standard ones.
+type GMFactory struct{}
+
+// Name returns the name of this factory
+func (f *GMFactory) Name() string {
+	return GMFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *GMFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.GmOpts == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	gmOpts := config.GmOpts
+
+	var ks bccsp.KeyStore
+	if gmOpts.Ephemeral == true {
+		ks = sw.NewDummyKeyStore()
+	} else if gmOpts.FileKeystore != nil {
+		pwd, err := gmOpts.FileKeystore.password()
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to initialize gm key store")
+		}
+		fks, err := sw.NewFileBasedKeyStore(pwd, gmOpts.FileKeystore.KeyStorePath, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to initialize gm key store")
+		}
+		ks = fks
+	} else {
+		// Default to DummyKeystore
+		ks = sw.NewDummyKeyStore()
+	}
+
+	return sw.NewWithParams(256, "SM3", ks)
+}
+
+// GmOpts contains options for the GMFactory
+type GmOpts struct {
+	// Keystore Options
+	Ephemeral     bool               `mapstructure:"tempkeys,omitempty" json:"tempkeys,omitempty"`
+	FileKeystore  *FileKeystoreOpts  `mapstructure:"filekeystore,omitempty" json:"filekeystore,omitempty" yaml:"FileKeyStore"`
+	DummyKeystore *DummyKeystoreOpts `mapstructure:"dummykeystore,omitempty" json:"dummykeystore,omitempty"`
+}
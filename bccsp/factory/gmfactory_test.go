@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGMFactoryName(t *testing.T) {
+	f := &GMFactory{}
+	assert.Equal(t, f.Name(), GMFactoryName)
+}
+
+func TestGMFactoryGetInvalidArgs(t *testing.T) {
+	f := &GMFactory{}
+
+	_, err := f.Get(nil)
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+}
+
+func TestGMFactoryGet(t *testing.T) {
+	f := &GMFactory{}
+
+	csp, err := f.Get(&FactoryOpts{GmOpts: &GmOpts{}})
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+
+	// SM3 is the default hash function
+	expected := sm3.Sum([]byte("msg"))
+	h, err := csp.Hash([]byte("msg"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected[:], h)
+
+	ksPath, err := ioutil.TempDir("", "gmfactory")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+	csp, err = f.Get(&FactoryOpts{GmOpts: &GmOpts{FileKeystore: &FileKeystoreOpts{KeyStorePath: ksPath}}})
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.SM2KeyGenOpts{})
+	assert.NoError(t, err)
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), k2.SKI())
+}
+
+func TestGetBCCSPFromOptsGM(t *testing.T) {
+	csp, err := GetBCCSPFromOpts(&FactoryOpts{ProviderName: "GM", GmOpts: &GmOpts{Ephemeral: true}})
+	assert.NoError(t, err)
+	assert.NotNil(t, csp)
+
+	_, err = GetBCCSPFromOpts(&FactoryOpts{ProviderName: "GM"})
+	assert.EqualError(t, err, "Could not initialize BCCSP GM: Invalid config. It must not be nil.")
+}
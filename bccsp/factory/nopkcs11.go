@@ -19,14 +19,17 @@ package factory
 
 import (
 	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/remote"
 	"github.com/pkg/errors"
 )
 
 // FactoryOpts holds configuration information used to initialize factory implementations
 type FactoryOpts struct {
-	ProviderName string      `mapstructure:"default" json:"default" yaml:"Default"`
-	SwOpts       *SwOpts     `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
-	PluginOpts   *PluginOpts `mapstructure:"PLUGIN,omitempty" json:"PLUGIN,omitempty" yaml:"PluginOpts"`
+	ProviderName string             `mapstructure:"default" json:"default" yaml:"Default"`
+	SwOpts       *SwOpts            `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	GmOpts       *GmOpts            `mapstructure:"GM,omitempty" json:"GM,omitempty" yaml:"GmOpts"`
+	PluginOpts   *PluginOpts        `mapstructure:"PLUGIN,omitempty" json:"PLUGIN,omitempty" yaml:"PluginOpts"`
+	RemoteOpts   *remote.RemoteOpts `mapstructure:"REMOTE,omitempty" json:"REMOTE,omitempty" yaml:"RemoteOpts"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -60,6 +63,24 @@ func InitFactories(config *FactoryOpts) error {
 			}
 		}
 
+		// GM BCCSP
+		if config.GmOpts != nil {
+			f := &GMFactory{}
+			err := initBCCSP(f, config)
+			if err != nil {
+				factoriesInitError = errors.Wrapf(err, "Failed initializing GM.BCCSP %s", factoriesInitError)
+			}
+		}
+
+		// Remote signer BCCSP
+		if config.RemoteOpts != nil {
+			f := &RemoteFactory{}
+			err := initBCCSP(f, config)
+			if err != nil {
+				factoriesInitError = errors.Wrapf(err, "Failed initializing REMOTE.BCCSP %s", factoriesInitError)
+			}
+		}
+
 		// BCCSP Plugin
 		if config.PluginOpts != nil {
 			f := &PluginFactory{}
@@ -85,6 +106,10 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "GM":
+		f = &GMFactory{}
+	case "REMOTE":
+		f = &RemoteFactory{}
 	case "PLUGIN":
 		f = &PluginFactory{}
 	default:
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ReadPassword reads a password from the environment variable env, from
+// file, or from the terminal if prompt is set, in this order of precedence.
+// The trailing newline of a password file is ignored. It returns a nil
+// password if neither of them is set.
+func ReadPassword(env, file string, prompt bool, description string) ([]byte, error) {
+	switch {
+	case env != "":
+		pwd, ok := os.LookupEnv(env)
+		if !ok || pwd == "" {
+			return nil, errors.Errorf("the environment variable %s with the password of the %s is not set", env, description)
+		}
+		return []byte(pwd), nil
+
+	case file != "":
+		pwd, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading the password of the %s", description)
+		}
+		pwd = bytes.TrimRight(pwd, "\r\n")
+		if len(pwd) == 0 {
+			return nil, errors.Errorf("the password file %s of the %s is empty", file, description)
+		}
+		return pwd, nil
+
+	case prompt:
+		fd := int(os.Stdin.Fd())
+		if !terminal.IsTerminal(fd) {
+			return nil, errors.Errorf("cannot prompt for the password of the %s, the standard input is not a terminal", description)
+		}
+		fmt.Fprintf(os.Stderr, "Enter the password of the %s: ", description)
+		pwd, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading the password of the %s", description)
+		}
+		if len(pwd) == 0 {
+			return nil, errors.Errorf("the password of the %s is empty", description)
+		}
+		return pwd, nil
+	}
+
+	return nil, nil
+}
+
+// password returns the password encrypting the private keys of the key store,
+// which is read once with ReadPassword and kept in Password. It returns a nil
+// password if the key store isn't encrypted.
+func (o *FileKeystoreOpts) password() ([]byte, error) {
+	if len(o.Password) != 0 {
+		return o.Password, nil
+	}
+
+	pwd, err := ReadPassword(o.PasswordEnv, o.PasswordFile, o.PasswordPrompt, fmt.Sprintf("key store at %s", o.KeyStorePath))
+	if err != nil {
+		return nil, err
+	}
+	o.Password = pwd
+	return pwd, nil
+}
@@ -20,6 +20,7 @@ package factory
 import (
 	"github.com/sinochem-tech/fabric/bccsp"
 	"github.com/sinochem-tech/fabric/bccsp/pkcs11"
+	"github.com/sinochem-tech/fabric/bccsp/remote"
 	"github.com/pkg/errors"
 )
 
@@ -27,7 +28,9 @@ import (
 type FactoryOpts struct {
 	ProviderName string             `mapstructure:"default" json:"default" yaml:"Default"`
 	SwOpts       *SwOpts            `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
+	GmOpts       *GmOpts            `mapstructure:"GM,omitempty" json:"GM,omitempty" yaml:"GmOpts"`
 	PluginOpts   *PluginOpts        `mapstructure:"PLUGIN,omitempty" json:"PLUGIN,omitempty" yaml:"PluginOpts"`
+	RemoteOpts   *remote.RemoteOpts `mapstructure:"REMOTE,omitempty" json:"REMOTE,omitempty" yaml:"RemoteOpts"`
 	Pkcs11Opts   *pkcs11.PKCS11Opts `mapstructure:"PKCS11,omitempty" json:"PKCS11,omitempty" yaml:"PKCS11"`
 }
 
@@ -69,6 +72,15 @@ func setFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// GM BCCSP
+	if config.GmOpts != nil {
+		f := &GMFactory{}
+		err := initBCCSP(f, config)
+		if err != nil {
+			factoriesInitError = errors.Wrapf(err, "Failed initializing GM.BCCSP %s", factoriesInitError)
+		}
+	}
+
 	// PKCS11-Based BCCSP
 	if config.Pkcs11Opts != nil {
 		f := &PKCS11Factory{}
@@ -78,6 +90,15 @@ func setFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// Remote signer BCCSP
+	if config.RemoteOpts != nil {
+		f := &RemoteFactory{}
+		err := initBCCSP(f, config)
+		if err != nil {
+			factoriesInitError = errors.Wrapf(err, "Failed initializing REMOTE.BCCSP %s", factoriesInitError)
+		}
+	}
+
 	// BCCSP Plugin
 	if config.PluginOpts != nil {
 		f := &PluginFactory{}
@@ -102,8 +123,12 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.ProviderName {
 	case "SW":
 		f = &SWFactory{}
+	case "GM":
+		f = &GMFactory{}
 	case "PKCS11":
 		f = &PKCS11Factory{}
+	case "REMOTE":
+		f = &RemoteFactory{}
 	case "PLUGIN":
 		f = &PluginFactory{}
 	default:
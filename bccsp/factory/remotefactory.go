@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/remote"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RemoteFactoryName is the name of the factory of the BCCSP
+	// delegating the signatures to a remote signing service
+	RemoteFactoryName = "REMOTE"
+)
+
+// RemoteFactory is the factory of the remote signer BCCSP.
+// The private keys of the remote signer BCCSP never leave the
+// remote signing service, such as a KMS, it is connected to.
+type RemoteFactory struct{}
+
+// Name returns the name of this factory
+func (f *RemoteFactory) Name() string {
+	return RemoteFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *RemoteFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.RemoteOpts == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	// The keys held locally are either public or ephemeral
+	return remote.New(*config.RemoteOpts, sw.NewDummyKeyStore())
+}
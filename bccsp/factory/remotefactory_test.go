@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/sinochem-tech/fabric/bccsp/remote"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteFactoryName(t *testing.T) {
+	f := &RemoteFactory{}
+	assert.Equal(t, f.Name(), RemoteFactoryName)
+}
+
+func TestRemoteFactoryGetInvalidArgs(t *testing.T) {
+	f := &RemoteFactory{}
+
+	_, err := f.Get(nil)
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	assert.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{RemoteOpts: &remote.RemoteOpts{}})
+	assert.EqualError(t, err, "Invalid address. It must not be empty.")
+
+	_, err = GetBCCSPFromOpts(&FactoryOpts{ProviderName: "REMOTE", RemoteOpts: &remote.RemoteOpts{Address: "localhost:7060"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Could not initialize BCCSP REMOTE")
+}
@@ -47,7 +47,11 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 	if swOpts.Ephemeral == true {
 		ks = sw.NewDummyKeyStore()
 	} else if swOpts.FileKeystore != nil {
-		fks, err := sw.NewFileBasedKeyStore(nil, swOpts.FileKeystore.KeyStorePath, false)
+		pwd, err := swOpts.FileKeystore.password()
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed to initialize software key store")
+		}
+		fks, err := sw.NewFileBasedKeyStore(pwd, swOpts.FileKeystore.KeyStorePath, false)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Failed to initialize software key store")
 		}
@@ -75,6 +79,18 @@ type SwOpts struct {
 // Pluggable Keystores, could add JKS, P12, etc..
 type FileKeystoreOpts struct {
 	KeyStorePath string `mapstructure:"keystore" yaml:"KeyStore"`
+
+	// The private keys of the key store are encrypted with a password, which is
+	// read from the environment variable PasswordEnv, from the file PasswordFile,
+	// or from the terminal if PasswordPrompt is set. The key store isn't
+	// encrypted if neither of them is set.
+	PasswordEnv    string `mapstructure:"passwordenv,omitempty" json:"passwordenv,omitempty" yaml:"PasswordEnv,omitempty"`
+	PasswordFile   string `mapstructure:"passwordfile,omitempty" json:"passwordfile,omitempty" yaml:"PasswordFile,omitempty"`
+	PasswordPrompt bool   `mapstructure:"passwordprompt,omitempty" json:"passwordprompt,omitempty" yaml:"PasswordPrompt,omitempty"`
+
+	// Password is the password of the key store, once read.
+	// It can also be set directly when not reading it from the configuration.
+	Password []byte `mapstructure:"-" json:"-" yaml:"-"`
 }
 
 type DummyKeystoreOpts struct{}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"crypto/elliptic"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+var (
+	// OIDNamedCurveP256Sm2 identifies the SM2 curve
+	OIDNamedCurveP256Sm2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+	// OIDSignatureSM2WithSM3 identifies SM2 signatures over SM3 digests
+	OIDSignatureSM2WithSM3 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}
+
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+)
+
+type publicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+func sm2AlgorithmIdentifier() (pkix.AlgorithmIdentifier, error) {
+	params, err := asn1.Marshal(OIDNamedCurveP256Sm2)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}, nil
+}
+
+// isSM2AlgorithmIdentifier returns whether the given algorithm identifier denotes an SM2 public key
+func isSM2AlgorithmIdentifier(ai pkix.AlgorithmIdentifier) bool {
+	if !ai.Algorithm.Equal(oidPublicKeyECDSA) {
+		return false
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ai.Parameters.FullBytes, &curve); err != nil {
+		return false
+	}
+	return curve.Equal(OIDNamedCurveP256Sm2)
+}
+
+// MarshalPKIXPublicKey converts an SM2 public key to PKIX, ASN.1 DER form
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	if pub == nil || pub.X == nil || pub.Y == nil {
+		return nil, errors.New("sm2: invalid public key")
+	}
+	ai, err := sm2AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	point := elliptic.Marshal(P256Sm2(), pub.X, pub.Y)
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: ai,
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+// ParsePKIXPublicKey parses an SM2 public key in PKIX, ASN.1 DER form
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var pki publicKeyInfo
+	rest, err := asn1.Unmarshal(der, &pki)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("sm2: trailing data after public key")
+	}
+	return parsePublicKey(pki)
+}
+
+func parsePublicKey(pki publicKeyInfo) (*PublicKey, error) {
+	if !isSM2AlgorithmIdentifier(pki.Algorithm) {
+		return nil, errors.New("sm2: not an SM2 public key")
+	}
+	x, y := elliptic.Unmarshal(P256Sm2(), pki.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.New("sm2: invalid public key point")
+	}
+	return &PublicKey{Curve: P256Sm2(), X: x, Y: y}, nil
+}
+
+// MarshalECPrivateKey converts an SM2 private key to SEC 1, ASN.1 DER form
+func MarshalECPrivateKey(priv *PrivateKey) ([]byte, error) {
+	if priv == nil || priv.D == nil {
+		return nil, errors.New("sm2: invalid private key")
+	}
+	return marshalECPrivateKey(priv, OIDNamedCurveP256Sm2)
+}
+
+func marshalECPrivateKey(priv *PrivateKey, oid asn1.ObjectIdentifier) ([]byte, error) {
+	return asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    padded(priv.D, (P256Sm2().Params().N.BitLen()+7)/8),
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: elliptic.Marshal(P256Sm2(), priv.X, priv.Y)},
+	})
+}
+
+// ParseECPrivateKey parses an SM2 private key in SEC 1, ASN.1 DER form
+func ParseECPrivateKey(der []byte) (*PrivateKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	}
+	if key.Version != 1 {
+		return nil, errors.New("sm2: unknown EC private key version")
+	}
+	if len(key.NamedCurveOID) != 0 && !key.NamedCurveOID.Equal(OIDNamedCurveP256Sm2) {
+		return nil, errors.New("sm2: not an SM2 private key")
+	}
+	return privateKeyFromScalar(key.PrivateKey)
+}
+
+func privateKeyFromScalar(scalar []byte) (*PrivateKey, error) {
+	c := P256Sm2()
+	d := new(big.Int).SetBytes(scalar)
+	if d.Sign() <= 0 || d.Cmp(new(big.Int).Sub(c.Params().N, one)) >= 0 {
+		return nil, errors.New("sm2: invalid private key value")
+	}
+	priv := &PrivateKey{D: d}
+	priv.Curve = c
+	priv.X, priv.Y = c.ScalarBaseMult(d.Bytes())
+	return priv, nil
+}
+
+// MarshalPKCS8PrivateKey converts an SM2 private key to PKCS #8, ASN.1 DER form
+func MarshalPKCS8PrivateKey(priv *PrivateKey) ([]byte, error) {
+	if priv == nil || priv.D == nil {
+		return nil, errors.New("sm2: invalid private key")
+	}
+	ai, err := sm2AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	// The curve is already identified by the algorithm identifier
+	ecKey, err := marshalECPrivateKey(priv, nil)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Version:    0,
+		Algo:       ai,
+		PrivateKey: ecKey,
+	})
+}
+
+// ParsePKCS8PrivateKey parses an SM2 private key in PKCS #8, ASN.1 DER form
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	}
+	if !isSM2AlgorithmIdentifier(key.Algo) {
+		return nil, errors.New("sm2: not an SM2 private key")
+	}
+	return ParseECPrivateKey(key.PrivateKey)
+}
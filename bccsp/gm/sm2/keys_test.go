@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPKIXPublicKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := MarshalPKIXPublicKey(&priv.PublicKey)
+	assert.NoError(t, err)
+	pub, err := ParsePKIXPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.X, pub.X)
+	assert.Equal(t, priv.Y, pub.Y)
+
+	_, err = ParsePKIXPublicKey(append(der, 0))
+	assert.EqualError(t, err, "sm2: trailing data after public key")
+
+	_, err = MarshalPKIXPublicKey(nil)
+	assert.EqualError(t, err, "sm2: invalid public key")
+
+	// ECDSA keys aren't SM2 keys
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	assert.NoError(t, err)
+	_, err = ParsePKIXPublicKey(ecDER)
+	assert.EqualError(t, err, "sm2: not an SM2 public key")
+}
+
+func TestECPrivateKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := MarshalECPrivateKey(priv)
+	assert.NoError(t, err)
+	parsed, err := ParseECPrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.D, parsed.D)
+	assert.Equal(t, priv.X, parsed.X)
+	assert.Equal(t, priv.Y, parsed.Y)
+
+	_, err = MarshalECPrivateKey(nil)
+	assert.EqualError(t, err, "sm2: invalid private key")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	assert.NoError(t, err)
+	_, err = ParseECPrivateKey(ecDER)
+	assert.EqualError(t, err, "sm2: not an SM2 private key")
+}
+
+func TestPKCS8PrivateKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+	parsed, err := ParsePKCS8PrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.D, parsed.D)
+	assert.Equal(t, priv.X, parsed.X)
+
+	// The x509 package doesn't recognize it
+	_, err = x509.ParsePKCS8PrivateKey(der)
+	assert.Error(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	assert.NoError(t, err)
+	_, err = ParsePKCS8PrivateKey(ecDER)
+	assert.EqualError(t, err, "sm2: not an SM2 private key")
+}
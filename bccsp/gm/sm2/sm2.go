@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sm2 implements the SM2 elliptic curve digital signature algorithm
+// as defined in GM/T 0003-2012, together with the encodings of SM2 keys and
+// the handling of SM2-signed X.509 certificates.
+// The curve arithmetic and the signature scheme are provided by
+// github.com/tjfoc/gmsm/sm2, whose curve implementation is constant time.
+package sm2
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+
+	tjsm2 "github.com/tjfoc/gmsm/sm2"
+)
+
+// DefaultUID is the user identity used when computing
+// the Z value of a public key, unless specified otherwise
+var DefaultUID = []byte("1234567812345678")
+
+var one = big.NewInt(1)
+
+// P256Sm2 returns the curve recommended by GM/T 0003-2012 for SM2
+func P256Sm2() elliptic.Curve {
+	return tjsm2.P256Sm2()
+}
+
+// PublicKey represents an SM2 public key
+type PublicKey = tjsm2.PublicKey
+
+// PrivateKey represents an SM2 private key.
+// Its Sign method implements crypto.Signer: it treats its input as
+// the message to be signed, and signs it along with the Z value of
+// the public key computed for DefaultUID, as GM/T 0003-2012 mandates.
+type PrivateKey = tjsm2.PrivateKey
+
+type sm2Signature struct {
+	R, S *big.Int
+}
+
+// GenerateKey generates a public and private key pair over the SM2 curve
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	if rand == nil {
+		return nil, errors.New("sm2: invalid source of randomness")
+	}
+	return tjsm2.GenerateKey(rand)
+}
+
+// Sign signs msg with the private key for the user identity uid, and returns
+// the signature as a pair of integers. The signed value is the SM3 hash of
+// the Z value of the public key followed by msg.
+func Sign(rand io.Reader, priv *PrivateKey, uid, msg []byte) (r, s *big.Int, err error) {
+	if priv == nil || priv.D == nil || priv.Curve == nil {
+		return nil, nil, errors.New("sm2: invalid private key")
+	}
+	if rand == nil {
+		return nil, nil, errors.New("sm2: invalid source of randomness")
+	}
+	return tjsm2.Sm2Sign(priv, msg, uid, rand)
+}
+
+// Verify verifies the signature of msg with the public key
+// for the user identity uid
+func Verify(pub *PublicKey, uid, msg []byte, r, s *big.Int) bool {
+	if pub == nil || pub.Curve == nil || pub.X == nil || pub.Y == nil || r == nil || s == nil {
+		return false
+	}
+	return tjsm2.Sm2Verify(pub, msg, uid, r, s)
+}
+
+// MarshalSignature encodes the given signature in ASN.1
+func MarshalSignature(r, s *big.Int) ([]byte, error) {
+	return asn1.Marshal(sm2Signature{R: r, S: s})
+}
+
+// UnmarshalSignature decodes the given ASN.1 signature
+func UnmarshalSignature(raw []byte) (r, s *big.Int, err error) {
+	sig := &sm2Signature{}
+	rest, err := asn1.Unmarshal(raw, sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rest) != 0 {
+		return nil, nil, errors.New("sm2: trailing data after signature")
+	}
+	if sig.R == nil || sig.S == nil || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, nil, errors.New("sm2: invalid signature")
+	}
+	return sig.R, sig.S, nil
+}
+
+// ZA computes the Z value of the public key for the given user identity,
+// namely the SM3 hash of the identity, the curve parameters and the public key
+func ZA(pub *PublicKey, uid []byte) ([]byte, error) {
+	return tjsm2.ZA(pub, uid)
+}
+
+func padded(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	res := make([]byte, size)
+	copy(res[size-len(b):], b)
+	return res
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurve(t *testing.T) {
+	c := P256Sm2()
+	assert.True(t, c.IsOnCurve(c.Params().Gx, c.Params().Gy))
+	// The order of the base point is n
+	x, y := c.ScalarBaseMult(c.Params().N.Bytes())
+	assert.Equal(t, 0, x.Sign())
+	assert.Equal(t, 0, y.Sign())
+}
+
+func fromHex(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// TestKnownAnswer checks the signature example over the recommended
+// curve of GM/T 0003.5-2012, appendix A.2
+func TestKnownAnswer(t *testing.T) {
+	msg := []byte("message digest")
+	uid := []byte("1234567812345678")
+	d := fromHex("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	k := fromHex("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21")
+
+	priv, err := privateKeyFromScalar(d.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex("09F9DF311E5421A150DD7D161E4BC5C672179FAD1833FC076BB08FF356F35020"), priv.X)
+	assert.Equal(t, fromHex("CCEA490CE26775A52DC6EA718CC1AA600AED05FBF35E084A6632F6072DA9AD13"), priv.Y)
+
+	za, err := ZA(&priv.PublicKey, uid)
+	assert.NoError(t, err)
+	assert.Equal(t, "b2e14c5c79c6df5b85f4fe7ed8db7a262b9da7e07ccb0ea9f4747b8ccda8a4f3", hex.EncodeToString(za))
+	e := sm3.New()
+	e.Write(za)
+	e.Write(msg)
+	assert.Equal(t, "f0b43e94ba45accaace692ed534382eb17e6ab5a19ce7b31f4486fdfc0d28640", hex.EncodeToString(e.Sum(nil)))
+
+	// The random scalar is drawn as 1 + (b mod (n-1)), with b made of 40 random bytes
+	b := make([]byte, 40)
+	kMinusOne := new(big.Int).Sub(k, one).Bytes()
+	copy(b[len(b)-len(kMinusOne):], kMinusOne)
+	r, s, err := Sign(bytes.NewReader(b), priv, uid, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex("F5A03B0648D2C4630EEAC513E1BB81A15944DA3827D5B74143AC7EACEEE720B3"), r)
+	assert.Equal(t, fromHex("B1B6AA29DF212FD8763182BC0D421CA1BB9038FD1F7F42D4840B69C485BBC1AA"), s)
+
+	assert.True(t, Verify(&priv.PublicKey, uid, msg, r, s))
+	assert.False(t, Verify(&priv.PublicKey, uid, []byte("message digesT"), r, s))
+}
+
+func TestSignVerify(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, priv.Curve.IsOnCurve(priv.X, priv.Y))
+
+	msg := []byte("hello world")
+	r, s, err := Sign(rand.Reader, priv, DefaultUID, msg)
+	assert.NoError(t, err)
+	assert.True(t, Verify(&priv.PublicKey, DefaultUID, msg, r, s))
+
+	// A different message doesn't verify
+	assert.False(t, Verify(&priv.PublicKey, DefaultUID, []byte("hello world!"), r, s))
+
+	// A different user identity doesn't verify
+	assert.False(t, Verify(&priv.PublicKey, []byte("another identity"), msg, r, s))
+
+	// A different key doesn't verify
+	priv2, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, Verify(&priv2.PublicKey, DefaultUID, msg, r, s))
+
+	// Out of range values don't verify
+	assert.False(t, Verify(&priv.PublicKey, DefaultUID, msg, big.NewInt(0), s))
+	assert.False(t, Verify(&priv.PublicKey, DefaultUID, msg, r, priv.Curve.Params().N))
+	assert.False(t, Verify(nil, DefaultUID, msg, r, s))
+}
+
+func TestSigner(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, &priv.PublicKey, priv.Public())
+
+	// The signer signs the message along with the Z value for the default user identity
+	msg := []byte("hello world")
+	sig, err := priv.Sign(rand.Reader, msg, nil)
+	assert.NoError(t, err)
+
+	r, s, err := UnmarshalSignature(sig)
+	assert.NoError(t, err)
+	assert.True(t, Verify(&priv.PublicKey, DefaultUID, msg, r, s))
+
+	_, _, err = UnmarshalSignature(append(sig, 0))
+	assert.EqualError(t, err, "sm2: trailing data after signature")
+	_, _, err = UnmarshalSignature([]byte{0, 1, 2})
+	assert.Error(t, err)
+	raw, err := MarshalSignature(big.NewInt(-1), big.NewInt(1))
+	assert.NoError(t, err)
+	_, _, err = UnmarshalSignature(raw)
+	assert.EqualError(t, err, "sm2: invalid signature")
+}
+
+func TestSignInvalidKey(t *testing.T) {
+	_, _, err := Sign(rand.Reader, nil, DefaultUID, []byte{1})
+	assert.EqualError(t, err, "sm2: invalid private key")
+}
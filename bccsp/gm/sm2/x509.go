@@ -0,0 +1,425 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
+)
+
+// maxChainLength bounds the length of the certification chains built by VerifyCertificate
+const maxChainLength = 10
+
+type validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+type certificate struct {
+	Raw                asn1.RawContent
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           validity
+	Subject            asn1.RawValue
+	PublicKey          publicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+// IsSM2SignedCert returns whether the given certificate is signed with SM2
+func IsSM2SignedCert(cert *x509.Certificate) bool {
+	if cert == nil || cert.SignatureAlgorithm != x509.UnknownSignatureAlgorithm {
+		return false
+	}
+	var c certificate
+	if _, err := asn1.Unmarshal(cert.Raw, &c); err != nil {
+		return false
+	}
+	return c.SignatureAlgorithm.Algorithm.Equal(OIDSignatureSM2WithSM3)
+}
+
+// ParseCertificate parses a single certificate from the given ASN.1 DER data.
+// Unlike x509.ParseCertificate, it also parses certificates of SM2 public keys,
+// in which case the PublicKey of the returned certificate is a *PublicKey.
+func ParseCertificate(der []byte) (*x509.Certificate, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err == nil {
+		return cert, nil
+	}
+
+	var c certificate
+	if _, err2 := asn1.Unmarshal(der, &c); err2 != nil || !isSM2AlgorithmIdentifier(c.TBSCertificate.PublicKey.Algorithm) {
+		return nil, err
+	}
+	pub, err := parsePublicKey(c.TBSCertificate.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	rawTBS := c.TBSCertificate.Raw
+	rawPublicKey := c.TBSCertificate.PublicKey.Raw
+
+	// The x509 package doesn't recognize the SM2 curve, therefore we have it parse
+	// the certificate with the public key algorithm replaced by an unknown one,
+	// and then restore the public key along with the raw contents
+	c.Raw = nil
+	c.TBSCertificate.Raw = nil
+	c.TBSCertificate.PublicKey = publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: OIDNamedCurveP256Sm2},
+		PublicKey: c.TBSCertificate.PublicKey.PublicKey,
+	}
+	placeholder, err := asn1.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	cert, err = x509.ParseCertificate(placeholder)
+	if err != nil {
+		return nil, err
+	}
+	cert.Raw = der
+	cert.RawTBSCertificate = rawTBS
+	cert.RawSubjectPublicKeyInfo = rawPublicKey
+	cert.PublicKey = pub
+	return cert, nil
+}
+
+// CreateCertificate creates a new certificate of the given SM2 public key based on
+// the template, signed by the parent with the given signer, which has to be an SM2 signer.
+// It returns the certificate in DER encoding.
+func CreateCertificate(rand io.Reader, template, parent *x509.Certificate, pub *PublicKey, signer crypto.Signer) ([]byte, error) {
+	if _, isSM2 := signer.Public().(*PublicKey); !isSM2 {
+		return nil, errors.New("sm2: the signer of the certificate must be an SM2 signer")
+	}
+	spki, err := MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	// Have the x509 package compose the certificate with a throwaway key,
+	// and then replace the public key and the signature with the SM2 ones
+	throwawayKey, err := ecdsa.GenerateKey(elliptic.P256(), rand)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := *template
+	tmpl.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+	if tmpl.IsCA && len(tmpl.SubjectKeyId) == 0 {
+		ski := sm3.Sum(elliptic.Marshal(pub.Curve, pub.X, pub.Y))
+		tmpl.SubjectKeyId = ski[:]
+	}
+	issuer := *parent
+	issuer.PublicKey = nil
+	der, err := x509.CreateCertificate(rand, &tmpl, &issuer, &throwawayKey.PublicKey, throwawayKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var c certificate
+	if _, err := asn1.Unmarshal(der, &c); err != nil {
+		return nil, err
+	}
+	c.Raw = nil
+	c.TBSCertificate.Raw = nil
+	c.TBSCertificate.PublicKey = publicKeyInfo{Raw: spki}
+	c.TBSCertificate.SignatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: OIDSignatureSM2WithSM3}
+	tbs, err := asn1.Marshal(c.TBSCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signer signs the TBS certificate along with the Z value of its public key
+	signature, err := signer.Sign(rand, tbs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.TBSCertificate.Raw = tbs
+	c.SignatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: OIDSignatureSM2WithSM3}
+	c.SignatureValue = asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)}
+	return asn1.Marshal(c)
+}
+
+// CheckSignature verifies that signature is a valid SM2 signature over signed
+// from the given public key, as done for certificates and CRLs
+func CheckSignature(publicKey crypto.PublicKey, signed, signature []byte) error {
+	pub, isSM2 := publicKey.(*PublicKey)
+	if !isSM2 {
+		return x509.ErrUnsupportedAlgorithm
+	}
+	r, s, err := UnmarshalSignature(signature)
+	if err != nil {
+		return err
+	}
+	if !Verify(pub, DefaultUID, signed, r, s) {
+		return errors.New("sm2: verification failure")
+	}
+	return nil
+}
+
+// CheckSignatureFrom verifies that the signature on cert is a valid signature from parent.
+// Certificates that aren't signed with SM2 are verified by the x509 package.
+func CheckSignatureFrom(cert, parent *x509.Certificate) error {
+	if !IsSM2SignedCert(cert) {
+		return cert.CheckSignatureFrom(parent)
+	}
+	if (parent.Version == 3 && !parent.BasicConstraintsValid) || (parent.BasicConstraintsValid && !parent.IsCA) {
+		return x509.ConstraintViolationError{}
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return x509.ConstraintViolationError{}
+	}
+	return CheckSignature(parent.PublicKey, cert.RawTBSCertificate, cert.Signature)
+}
+
+// CheckCRLSignature checks that the signature in crl is from parent.
+// CRLs that aren't signed with SM2 are verified by the x509 package.
+func CheckCRLSignature(parent *x509.Certificate, crl *pkix.CertificateList) error {
+	if !crl.SignatureAlgorithm.Algorithm.Equal(OIDSignatureSM2WithSM3) {
+		return parent.CheckCRLSignature(crl)
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&x509.KeyUsageCRLSign == 0 {
+		return x509.ConstraintViolationError{}
+	}
+	return CheckSignature(parent.PublicKey, crl.TBSCertList.Raw, crl.SignatureValue.RightAlign())
+}
+
+// VerifyOptions contains parameters for VerifyCertificate
+type VerifyOptions struct {
+	Roots         []*x509.Certificate
+	Intermediates []*x509.Certificate
+	// CurrentTime is used to check the validity of all certificates
+	// in the chain. If zero, the current time is used.
+	CurrentTime time.Time
+}
+
+// VerifyCertificate attempts to verify cert by building one or more chains from cert
+// to one of opts.Roots, using certificates in opts.Intermediates if needed.
+// If successful, it returns one or more chains where the first element
+// of the chain is cert and the last element is from opts.Roots.
+// As x509.Certificate.Verify does, it rejects chains with certificates that
+// carry unhandled critical extensions, that exceed the maximum path length
+// of a CA, or whose leaf has names that violate the name constraints of a CA.
+func VerifyCertificate(cert *x509.Certificate, opts VerifyOptions) ([][]*x509.Certificate, error) {
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if len(cert.UnhandledCriticalExtensions) > 0 {
+		return nil, x509.UnhandledCriticalExtension{}
+	}
+	if err := checkValidity(cert, now); err != nil {
+		return nil, err
+	}
+	for _, root := range opts.Roots {
+		if cert.Equal(root) {
+			return [][]*x509.Certificate{{cert}}, nil
+		}
+	}
+	chains, err := buildChains(cert, []*x509.Certificate{cert}, opts, now)
+	if len(chains) == 0 {
+		if err != nil {
+			return nil, err
+		}
+		return nil, x509.UnknownAuthorityError{Cert: cert}
+	}
+	return chains, nil
+}
+
+// buildChains extends chain, whose last element is cert, up to the roots.
+// If no chain can be built, it returns the last error met while checking
+// the constraints of the candidate parents, if any.
+func buildChains(cert *x509.Certificate, chain []*x509.Certificate, opts VerifyOptions, now time.Time) ([][]*x509.Certificate, error) {
+	if len(chain) >= maxChainLength {
+		return nil, nil
+	}
+	var chains [][]*x509.Certificate
+	var lastErr error
+	for _, root := range opts.Roots {
+		if !isParent(cert, root, now) {
+			continue
+		}
+		if err := checkChainConstraints(chain, root); err != nil {
+			lastErr = err
+			continue
+		}
+		chains = append(chains, appendToFreshChain(chain, root))
+	}
+	for _, intermediate := range opts.Intermediates {
+		if inChain(intermediate, chain) || !isParent(cert, intermediate, now) {
+			continue
+		}
+		if err := checkChainConstraints(chain, intermediate); err != nil {
+			lastErr = err
+			continue
+		}
+		childChains, err := buildChains(intermediate, appendToFreshChain(chain, intermediate), opts, now)
+		if err != nil {
+			lastErr = err
+		}
+		chains = append(chains, childChains...)
+	}
+	return chains, lastErr
+}
+
+// checkChainConstraints checks whether the CA certificate ca
+// can be appended to chain, whose first element is the leaf
+func checkChainConstraints(chain []*x509.Certificate, ca *x509.Certificate) error {
+	if len(ca.UnhandledCriticalExtensions) > 0 {
+		return x509.UnhandledCriticalExtension{}
+	}
+	// The certificates of the chain other than the leaf are intermediate CAs
+	if ca.BasicConstraintsValid && ca.MaxPathLen >= 0 && len(chain)-1 > ca.MaxPathLen {
+		return x509.CertificateInvalidError{Cert: ca, Reason: x509.TooManyIntermediates}
+	}
+	return checkNameConstraints(chain[0], ca)
+}
+
+// checkNameConstraints checks the names of the leaf against
+// the permitted and excluded subtrees of the CA certificate ca
+func checkNameConstraints(leaf, ca *x509.Certificate) error {
+	notAuthorized := x509.CertificateInvalidError{Cert: leaf, Reason: x509.CANotAuthorizedForThisName}
+	for _, name := range leaf.DNSNames {
+		if !satisfiesConstraints(name, ca.PermittedDNSDomains, ca.ExcludedDNSDomains, matchDomainConstraint) {
+			return notAuthorized
+		}
+	}
+	for _, email := range leaf.EmailAddresses {
+		if !satisfiesConstraints(email, ca.PermittedEmailAddresses, ca.ExcludedEmailAddresses, matchEmailConstraint) {
+			return notAuthorized
+		}
+	}
+	for _, ip := range leaf.IPAddresses {
+		if !satisfiesIPConstraints(ip, ca.PermittedIPRanges, ca.ExcludedIPRanges) {
+			return notAuthorized
+		}
+	}
+	for _, uri := range leaf.URIs {
+		host := uri.Hostname()
+		// URI constraints only apply to host names
+		if (len(ca.PermittedURIDomains) > 0 || len(ca.ExcludedURIDomains) > 0) && (host == "" || net.ParseIP(host) != nil) {
+			return notAuthorized
+		}
+		if !satisfiesConstraints(host, ca.PermittedURIDomains, ca.ExcludedURIDomains, matchDomainConstraint) {
+			return notAuthorized
+		}
+	}
+	return nil
+}
+
+func satisfiesConstraints(name string, permitted, excluded []string, match func(name, constraint string) bool) bool {
+	for _, constraint := range excluded {
+		if match(name, constraint) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, constraint := range permitted {
+		if match(name, constraint) {
+			return true
+		}
+	}
+	return false
+}
+
+func satisfiesIPConstraints(ip net.IP, permitted, excluded []*net.IPNet) bool {
+	for _, constraint := range excluded {
+		if constraint.Contains(ip) {
+			return false
+		}
+	}
+	if len(permitted) == 0 {
+		return true
+	}
+	for _, constraint := range permitted {
+		if constraint.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDomainConstraint returns whether domain is within the subtree of constraint.
+// A constraint that starts with a period only matches subdomains.
+func matchDomainConstraint(domain, constraint string) bool {
+	if constraint == "" {
+		return true
+	}
+	domain, constraint = strings.ToLower(domain), strings.ToLower(constraint)
+	if strings.HasPrefix(constraint, ".") {
+		return strings.HasSuffix(domain, constraint)
+	}
+	return domain == constraint || strings.HasSuffix(domain, "."+constraint)
+}
+
+// matchEmailConstraint returns whether the mailbox email is within the subtree of constraint,
+// which is either a full mailbox or a domain that the host of the mailbox has to be in
+func matchEmailConstraint(email, constraint string) bool {
+	if strings.Contains(constraint, "@") {
+		return strings.EqualFold(email, constraint)
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return matchDomainConstraint(email[at+1:], constraint)
+}
+
+func isParent(cert, candidate *x509.Certificate, now time.Time) bool {
+	if !bytes.Equal(cert.RawIssuer, candidate.RawSubject) {
+		return false
+	}
+	if checkValidity(candidate, now) != nil {
+		return false
+	}
+	return CheckSignatureFrom(cert, candidate) == nil
+}
+
+func checkValidity(cert *x509.Certificate, now time.Time) error {
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return x509.CertificateInvalidError{Cert: cert, Reason: x509.Expired}
+	}
+	return nil
+}
+
+func inChain(cert *x509.Certificate, chain []*x509.Certificate) bool {
+	for _, c := range chain {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendToFreshChain(chain []*x509.Certificate, cert *x509.Certificate) []*x509.Certificate {
+	n := make([]*x509.Certificate, len(chain)+1)
+	copy(n, chain)
+	n[len(chain)] = cert
+	return n
+}
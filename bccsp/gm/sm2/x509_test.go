@@ -0,0 +1,276 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTemplate(cn string, isCA bool) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+	}
+	if isCA {
+		tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	return tmpl
+}
+
+func newCert(t *testing.T, cn string, isCA bool, parent *x509.Certificate, signer *PrivateKey) (*x509.Certificate, *PrivateKey) {
+	return newCertFromTemplate(t, newTemplate(cn, isCA), parent, signer)
+}
+
+func newCertFromTemplate(t *testing.T, tmpl *x509.Certificate, parent *x509.Certificate, signer *PrivateKey) (*x509.Certificate, *PrivateKey) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	if parent == nil {
+		parent, signer = tmpl, priv
+	}
+	der, err := CreateCertificate(rand.Reader, tmpl, parent, &priv.PublicKey, signer)
+	assert.NoError(t, err)
+	cert, err := ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, priv
+}
+
+func TestCreateAndParseCertificate(t *testing.T) {
+	root, rootKey := newCert(t, "root", true, nil, nil)
+	assert.True(t, IsSM2SignedCert(root))
+	assert.Equal(t, "root", root.Subject.CommonName)
+	assert.Equal(t, "root", root.Issuer.CommonName)
+	assert.True(t, root.IsCA)
+	assert.NotEmpty(t, root.SubjectKeyId)
+	assert.Equal(t, &rootKey.PublicKey, root.PublicKey)
+	assert.NoError(t, CheckSignatureFrom(root, root))
+
+	leaf, leafKey := newCert(t, "leaf", false, root, rootKey)
+	assert.True(t, IsSM2SignedCert(leaf))
+	assert.Equal(t, "root", leaf.Issuer.CommonName)
+	assert.Equal(t, root.SubjectKeyId, leaf.AuthorityKeyId)
+	assert.Equal(t, &leafKey.PublicKey, leaf.PublicKey)
+	assert.NoError(t, CheckSignatureFrom(leaf, root))
+
+	// The raw contents are preserved
+	reparsed, err := ParseCertificate(leaf.Raw)
+	assert.NoError(t, err)
+	assert.True(t, reparsed.Equal(leaf))
+	pub, err := ParsePKIXPublicKey(leaf.RawSubjectPublicKeyInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, &leafKey.PublicKey, pub)
+
+	// A leaf can't sign certificates
+	other, _ := newCert(t, "other", false, leaf, leafKey)
+	assert.IsType(t, x509.ConstraintViolationError{}, CheckSignatureFrom(other, leaf))
+
+	// A different CA didn't sign the leaf
+	root2, _ := newCert(t, "root", true, nil, nil)
+	assert.Error(t, CheckSignatureFrom(leaf, root2))
+
+	// ECDSA signers are refused
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, err = CreateCertificate(rand.Reader, newTemplate("x", false), root, &leafKey.PublicKey, ecKey)
+	assert.EqualError(t, err, "sm2: the signer of the certificate must be an SM2 signer")
+
+	// Garbage isn't parsed
+	_, err = ParseCertificate([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestIsSM2SignedCert(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := newTemplate("ecdsa", true)
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &ecKey.PublicKey, ecKey)
+	assert.NoError(t, err)
+	cert, err := ParseCertificate(der)
+	assert.NoError(t, err)
+	assert.False(t, IsSM2SignedCert(cert))
+	assert.False(t, IsSM2SignedCert(nil))
+
+	// Non SM2 certificates are verified by the x509 package
+	assert.NoError(t, CheckSignatureFrom(cert, cert))
+	chains, err := VerifyCertificate(cert, VerifyOptions{Roots: []*x509.Certificate{cert}})
+	assert.NoError(t, err)
+	assert.Len(t, chains, 1)
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	root, rootKey := newCert(t, "root", true, nil, nil)
+	intermediate, intermediateKey := newCert(t, "intermediate", true, root, rootKey)
+	leaf, _ := newCert(t, "leaf", false, intermediate, intermediateKey)
+
+	opts := VerifyOptions{
+		Roots:         []*x509.Certificate{root},
+		Intermediates: []*x509.Certificate{intermediate},
+	}
+	chains, err := VerifyCertificate(leaf, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*x509.Certificate{{leaf, intermediate, root}}, chains)
+
+	// Roots verify on their own
+	chains, err = VerifyCertificate(root, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*x509.Certificate{{root}}, chains)
+
+	// Without the intermediate no chain can be built
+	_, err = VerifyCertificate(leaf, VerifyOptions{Roots: []*x509.Certificate{root}})
+	assert.IsType(t, x509.UnknownAuthorityError{}, err)
+
+	// Expired certificates don't verify
+	opts.CurrentTime = time.Now().Add(2 * time.Hour)
+	_, err = VerifyCertificate(leaf, opts)
+	assert.IsType(t, x509.CertificateInvalidError{}, err)
+}
+
+func TestVerifyCertificateConstraints(t *testing.T) {
+	t.Run("MaxPathLen", func(t *testing.T) {
+		tmpl := newTemplate("root", true)
+		tmpl.MaxPathLen, tmpl.MaxPathLenZero = 0, true
+		root, rootKey := newCertFromTemplate(t, tmpl, nil, nil)
+		intermediate, intermediateKey := newCert(t, "intermediate", true, root, rootKey)
+		leaf, _ := newCert(t, "leaf", false, intermediate, intermediateKey)
+		opts := VerifyOptions{
+			Roots:         []*x509.Certificate{root},
+			Intermediates: []*x509.Certificate{intermediate},
+		}
+
+		// The root can only issue end entity certificates
+		_, err := VerifyCertificate(intermediate, opts)
+		assert.NoError(t, err)
+		_, err = VerifyCertificate(leaf, opts)
+		assert.Equal(t, x509.TooManyIntermediates, err.(x509.CertificateInvalidError).Reason)
+	})
+
+	t.Run("UnhandledCriticalExtensions", func(t *testing.T) {
+		critical := pkix.Extension{Id: asn1.ObjectIdentifier{1, 2, 3, 4}, Critical: true, Value: []byte{5, 0}}
+		root, rootKey := newCert(t, "root", true, nil, nil)
+		tmpl := newTemplate("intermediate", true)
+		tmpl.ExtraExtensions = []pkix.Extension{critical}
+		intermediate, intermediateKey := newCertFromTemplate(t, tmpl, root, rootKey)
+		assert.NotEmpty(t, intermediate.UnhandledCriticalExtensions)
+		leaf, _ := newCert(t, "leaf", false, intermediate, intermediateKey)
+		tmpl = newTemplate("leaf", false)
+		tmpl.ExtraExtensions = []pkix.Extension{critical}
+		criticalLeaf, _ := newCertFromTemplate(t, tmpl, root, rootKey)
+		opts := VerifyOptions{
+			Roots:         []*x509.Certificate{root},
+			Intermediates: []*x509.Certificate{intermediate},
+		}
+
+		_, err := VerifyCertificate(criticalLeaf, opts)
+		assert.Equal(t, x509.UnhandledCriticalExtension{}, err)
+		_, err = VerifyCertificate(leaf, opts)
+		assert.Equal(t, x509.UnhandledCriticalExtension{}, err)
+	})
+
+	t.Run("NameConstraints", func(t *testing.T) {
+		tmpl := newTemplate("root", true)
+		tmpl.PermittedDNSDomains = []string{".example.com"}
+		tmpl.ExcludedDNSDomains = []string{"bad.example.com"}
+		tmpl.PermittedEmailAddresses = []string{"example.com"}
+		_, tmpl.PermittedIPRanges = mustParseCIDR("10.0.0.0/8")
+		tmpl.PermittedURIDomains = []string{".example.com"}
+		root, rootKey := newCertFromTemplate(t, tmpl, nil, nil)
+		intermediate, intermediateKey := newCert(t, "intermediate", true, root, rootKey)
+		opts := VerifyOptions{
+			Roots:         []*x509.Certificate{root},
+			Intermediates: []*x509.Certificate{intermediate},
+		}
+
+		for _, tc := range []struct {
+			name   string
+			setup  func(*x509.Certificate)
+			failed bool
+		}{
+			{name: "permitted", setup: func(c *x509.Certificate) {
+				c.DNSNames = []string{"peer0.example.com"}
+				c.EmailAddresses = []string{"admin@example.com"}
+				c.IPAddresses = []net.IP{net.ParseIP("10.1.2.3")}
+				c.URIs = []*url.URL{{Scheme: "spiffe", Host: "org.example.com"}}
+			}},
+			{name: "not permitted DNS name", setup: func(c *x509.Certificate) { c.DNSNames = []string{"peer0.example.org"} }, failed: true},
+			{name: "excluded DNS name", setup: func(c *x509.Certificate) { c.DNSNames = []string{"bad.example.com"} }, failed: true},
+			{name: "not permitted email", setup: func(c *x509.Certificate) { c.EmailAddresses = []string{"admin@example.org"} }, failed: true},
+			{name: "not permitted IP", setup: func(c *x509.Certificate) { c.IPAddresses = []net.IP{net.ParseIP("192.168.0.1")} }, failed: true},
+			{name: "not permitted URI", setup: func(c *x509.Certificate) { c.URIs = []*url.URL{{Scheme: "spiffe", Host: "example.org"}} }, failed: true},
+			{name: "URI without host", setup: func(c *x509.Certificate) { c.URIs = []*url.URL{{Scheme: "urn", Opaque: "example.com"}} }, failed: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				tmpl := newTemplate("leaf", false)
+				tc.setup(tmpl)
+				leaf, _ := newCertFromTemplate(t, tmpl, intermediate, intermediateKey)
+				_, err := VerifyCertificate(leaf, opts)
+				if !tc.failed {
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, x509.CANotAuthorizedForThisName, err.(x509.CertificateInvalidError).Reason)
+			})
+		}
+	})
+}
+
+func mustParseCIDR(s string) (net.IP, []*net.IPNet) {
+	ip, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return ip, []*net.IPNet{ipNet}
+}
+
+func TestCheckCRLSignature(t *testing.T) {
+	root, rootKey := newCert(t, "root", true, nil, nil)
+	root2, _ := newCert(t, "root", true, nil, nil)
+
+	tbs := pkix.TBSCertificateList{
+		Version:             1,
+		Signature:           pkix.AlgorithmIdentifier{Algorithm: OIDSignatureSM2WithSM3},
+		Issuer:              root.Subject.ToRDNSequence(),
+		ThisUpdate:          time.Now().UTC(),
+		NextUpdate:          time.Now().Add(time.Hour).UTC(),
+		RevokedCertificates: []pkix.RevokedCertificate{{SerialNumber: big.NewInt(7), RevocationTime: time.Now().UTC()}},
+	}
+	crl := signCRL(t, tbs, rootKey)
+	assert.NoError(t, CheckCRLSignature(root, crl))
+	assert.Error(t, CheckCRLSignature(root2, crl))
+}
+
+func signCRL(t *testing.T, tbs pkix.TBSCertificateList, signer *PrivateKey) *pkix.CertificateList {
+	rawTBS, err := asn1.Marshal(tbs)
+	assert.NoError(t, err)
+	sig, err := signer.Sign(rand.Reader, rawTBS, nil)
+	assert.NoError(t, err)
+	der, err := asn1.Marshal(pkix.CertificateList{
+		TBSCertList:        tbs,
+		SignatureAlgorithm: tbs.Signature,
+		SignatureValue:     asn1.BitString{Bytes: sig, BitLength: 8 * len(sig)},
+	})
+	assert.NoError(t, err)
+	crl, err := x509.ParseCRL(der)
+	assert.NoError(t, err)
+	return crl
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSum(t *testing.T) {
+	// Test vectors of GM/T 0004-2012, appendix A
+	for _, tst := range []struct {
+		msg      string
+		expected string
+	}{
+		{
+			msg:      "abc",
+			expected: "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0",
+		},
+		{
+			msg:      strings.Repeat("abcd", 16),
+			expected: "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732",
+		},
+	} {
+		sum := Sum([]byte(tst.msg))
+		assert.Equal(t, tst.expected, hex.EncodeToString(sum[:]))
+	}
+}
+
+func TestHash(t *testing.T) {
+	msg := []byte(strings.Repeat("hyperledger fabric", 10))
+	expected := Sum(msg)
+
+	// Writing in chunks of any size yields the same checksum
+	for _, chunkSize := range []int{1, 7, BlockSize, BlockSize + 1} {
+		h := New()
+		for i := 0; i < len(msg); i += chunkSize {
+			end := i + chunkSize
+			if end > len(msg) {
+				end = len(msg)
+			}
+			h.Write(msg[i:end])
+		}
+		assert.Equal(t, expected[:], h.Sum(nil))
+	}
+
+	// Summing doesn't change the state of the hash
+	h := New()
+	h.Write(msg[:10])
+	h.Sum(nil)
+	h.Write(msg[10:])
+	assert.Equal(t, expected[:], h.Sum(nil))
+
+	// Resetting the hash brings it back to its initial state
+	h.Reset()
+	h.Write([]byte("abc"))
+	sum := Sum([]byte("abc"))
+	assert.Equal(t, sum[:], h.Sum(nil))
+	assert.Equal(t, Size, h.Size())
+	assert.Equal(t, BlockSize, h.BlockSize())
+}
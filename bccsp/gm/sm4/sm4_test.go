@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm4
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCipher(t *testing.T) {
+	// Test vector of GM/T 0002-2012, appendix A
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	plaintext := key
+
+	block, err := NewCipher(key)
+	assert.NoError(t, err)
+	assert.Equal(t, BlockSize, block.BlockSize())
+
+	ciphertext := make([]byte, BlockSize)
+	block.Encrypt(ciphertext, plaintext)
+	assert.Equal(t, "681edf34d206965e86b3e94f536e4246", hex.EncodeToString(ciphertext))
+
+	decrypted := make([]byte, BlockSize)
+	block.Decrypt(decrypted, ciphertext)
+	assert.Equal(t, plaintext, decrypted)
+
+	// Encrypting in place works as well
+	buf := append([]byte{}, plaintext...)
+	block.Encrypt(buf, buf)
+	assert.Equal(t, ciphertext, buf)
+}
+
+func TestCipherIterated(t *testing.T) {
+	// Test vector of GM/T 0002-2012, appendix A: 1000000 encryptions
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	block, err := NewCipher(key)
+	assert.NoError(t, err)
+	buf := append([]byte{}, key...)
+	for i := 0; i < 1000000; i++ {
+		block.Encrypt(buf, buf)
+	}
+	assert.Equal(t, "595298c7c6fd271f0402f804c33d3f66", hex.EncodeToString(buf))
+}
+
+func TestNewCipherInvalidKey(t *testing.T) {
+	_, err := NewCipher(make([]byte, 15))
+	assert.EqualError(t, err, "sm4: invalid key size 15")
+}
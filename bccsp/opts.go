@@ -80,6 +80,16 @@ const (
 	// SHA3_384
 	SHA3_384 = "SHA3_384"
 
+	// ED25519 Edwards-curve Digital Signature Algorithm over Curve25519
+	ED25519 = "ED25519"
+
+	// SM2 elliptic curve signature algorithm of the GM/T 0003-2012 standard
+	SM2 = "SM2"
+	// SM3 hash algorithm of the GM/T 0004-2012 standard
+	SM3 = "SM3"
+	// SM4 block cipher of the GM/T 0002-2012 standard
+	SM4 = "SM4"
+
 	// X509Certificate Label for X509 certificate related operation
 	X509Certificate = "X509Certificate"
 )
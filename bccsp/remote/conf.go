@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// RemoteOpts contains the options of the remote signer BCCSP
+type RemoteOpts struct {
+	// Default algorithms of the software BCCSP that performs
+	// all the operations that don't involve remote keys
+	SecLevel   int    `mapstructure:"security" json:"security" yaml:"Security"`
+	HashFamily string `mapstructure:"hash" json:"hash" yaml:"Hash"`
+
+	// Address is the host:port of the remote signing service
+	Address string `mapstructure:"address" json:"address" yaml:"Address"`
+	// ServerNameOverride overrides the host name expected in the
+	// TLS certificate of the remote signing service
+	ServerNameOverride string `mapstructure:"servernameoverride,omitempty" json:"servernameoverride,omitempty" yaml:"ServerNameOverride"`
+	// Timeout is the timeout of the requests to the remote signing service
+	Timeout time.Duration `mapstructure:"timeout,omitempty" json:"timeout,omitempty" yaml:"Timeout"`
+	// TLS contains the files the mutual TLS authentication
+	// with the remote signing service is configured from
+	TLS *TLSOpts `mapstructure:"tls" json:"tls" yaml:"TLS"`
+}
+
+// TLSOpts contains the paths of the files the mutual TLS
+// authentication with the remote signing service is configured from
+type TLSOpts struct {
+	// Cert is the PEM encoded client certificate
+	Cert string `mapstructure:"cert" json:"cert" yaml:"Cert"`
+	// Key is the PEM encoded private key of the client certificate
+	Key string `mapstructure:"key" json:"key" yaml:"Key"`
+	// RootCAs are the PEM encoded CA certificates the certificate of
+	// the remote signing service is verified against
+	RootCAs []string `mapstructure:"rootcas" json:"rootcas" yaml:"RootCAs"`
+}
+
+func (opts *RemoteOpts) tlsConfig() (*tls.Config, error) {
+	if opts.TLS == nil || opts.TLS.Cert == "" || opts.TLS.Key == "" {
+		return nil, errors.New("Invalid TLS options. A client certificate and key are required.")
+	}
+	if len(opts.TLS.RootCAs) == 0 {
+		return nil, errors.New("Invalid TLS options. At least a root CA is required.")
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.TLS.Cert, opts.TLS.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed loading the client TLS certificate")
+	}
+
+	rootCAs := x509.NewCertPool()
+	for _, file := range opts.TLS.RootCAs {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed reading root CA %s", file)
+		}
+		if !rootCAs.AppendCertsFromPEM(raw) {
+			return nil, errors.Errorf("No certificate found in root CA %s", file)
+		}
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+		ServerName:   opts.ServerNameOverride,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,215 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"sync"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/common/flogging"
+	"github.com/sinochem-tech/fabric/protos/remotesigner"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("bccsp_remote")
+
+// New returns a BCCSP that delegates the signatures with private keys to a
+// remote signing service, reached over mutually authenticated gRPC.
+// All the other operations are performed by a software BCCSP using the given key store.
+func New(opts RemoteOpts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	if opts.Address == "" {
+		return nil, errors.New("Invalid address. It must not be empty.")
+	}
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(opts.Address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed connecting to the remote signer at %s", opts.Address)
+	}
+
+	return NewWithClient(opts, keyStore, remotesigner.NewRemoteSignerClient(conn))
+}
+
+// NewWithClient returns a BCCSP that delegates the signatures with
+// private keys to the remote signing service reached through the given client
+func NewWithClient(opts RemoteOpts, keyStore bccsp.KeyStore, client remotesigner.RemoteSignerClient) (bccsp.BCCSP, error) {
+	// Check KeyStore
+	if keyStore == nil {
+		return nil, errors.New("Invalid bccsp.KeyStore instance. It must be different from nil.")
+	}
+
+	swCSP, err := sw.NewWithParams(opts.SecLevel, opts.HashFamily, keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	return &impl{
+		BCCSP:   swCSP,
+		client:  client,
+		timeout: timeout,
+		keys:    make(map[string]*remoteKey),
+		metrics: newSignerMetrics(),
+	}, nil
+}
+
+type impl struct {
+	// BCCSP is the software BCCSP that performs all
+	// the operations that don't involve remote keys
+	bccsp.BCCSP
+
+	client  remotesigner.RemoteSignerClient
+	timeout time.Duration
+	metrics *signerMetrics
+
+	// keys caches the handles of the remote keys. Since the SKI of a key
+	// is derived from its public key, the cached handles never get stale.
+	lock sync.RWMutex
+	keys map[string]*remoteKey
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *impl) GetKey(ski []byte) (bccsp.Key, error) {
+	if len(ski) == 0 {
+		return nil, errors.New("Invalid SKI. Cannot be of zero length.")
+	}
+
+	csp.lock.RLock()
+	k, cached := csp.keys[string(ski)]
+	csp.lock.RUnlock()
+	if cached {
+		return k, nil
+	}
+
+	k, err := csp.getRemoteKey(ski)
+	if err == nil {
+		csp.lock.Lock()
+		csp.keys[string(ski)] = k
+		csp.lock.Unlock()
+		return k, nil
+	}
+	if status.Code(errors.Cause(err)) != codes.NotFound {
+		return nil, err
+	}
+
+	// The key might be a local one, such as a public or an ephemeral key
+	logger.Debugf("Key [%x] not found in the remote signer, looking it up locally", ski)
+	return csp.BCCSP.GetKey(ski)
+}
+
+func (csp *impl) getRemoteKey(ski []byte) (*remoteKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := csp.client.GetKey(ctx, &remotesigner.GetKeyRequest{Ski: ski})
+	csp.metrics.observe("get_key", start, err)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed getting key [%x] from the remote signer", ski)
+	}
+
+	pub, err := utils.DERToPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed parsing the public key returned by the remote signer")
+	}
+
+	var opts bccsp.KeyImportOpts
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		opts = &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true}
+	case *sm2.PublicKey:
+		opts = &bccsp.SM2GoPublicKeyImportOpts{Temporary: true}
+	case ed25519.PublicKey:
+		opts = &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true}
+	case *rsa.PublicKey:
+		opts = &bccsp.RSAGoPublicKeyImportOpts{Temporary: true}
+	default:
+		return nil, errors.Errorf("Unsupported public key type [%T] returned by the remote signer", pub)
+	}
+	pk, err := csp.BCCSP.KeyImport(pub, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed importing the public key returned by the remote signer")
+	}
+	if !bytes.Equal(pk.SKI(), ski) {
+		return nil, errors.Errorf("The remote signer returned a public key with SKI [%x] instead of [%x]", pk.SKI(), ski)
+	}
+
+	ecdsaPub, _ := pub.(*ecdsa.PublicKey)
+	return &remoteKey{ski: ski, pub: pk, ecdsaPub: ecdsaPub}, nil
+}
+
+// Sign signs digest using key k.
+// The signatures with remote keys are delegated to the remote signer,
+// and are verified locally before being returned.
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	rk, isRemote := k.(*remoteKey)
+	if !isRemote {
+		return csp.BCCSP.Sign(k, digest, opts)
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty.")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := csp.client.Sign(ctx, &remotesigner.SignRequest{Ski: rk.ski, Digest: digest})
+	csp.metrics.observe("sign", start, err)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed signing with key [%x] of the remote signer", rk.ski)
+	}
+
+	signature := resp.Signature
+	if rk.ecdsaPub != nil {
+		// Fabric only accepts ECDSA signatures in their low-S form
+		signature, err = utils.SignatureToLowS(rk.ecdsaPub, signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid signature returned by the remote signer for key [%x]", rk.ski)
+		}
+	}
+
+	valid, err := csp.BCCSP.Verify(rk.pub, signature, digest, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed verifying the signature returned by the remote signer for key [%x]", rk.ski)
+	}
+	if !valid {
+		return nil, errors.Errorf("Invalid signature returned by the remote signer for key [%x]", rk.ski)
+	}
+
+	return signature, nil
+}
+
+// Verify verifies signature against key k and digest.
+// The signatures of remote keys are verified locally with their public keys.
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	if rk, isRemote := k.(*remoteKey); isRemote {
+		k = rk.pub
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
@@ -0,0 +1,412 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/common/crypto/tlsgen"
+	"github.com/sinochem-tech/fabric/common/metrics"
+	"github.com/sinochem-tech/fabric/protos/remotesigner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// testScope records the metrics reported under it
+type testScope struct {
+	*testMetrics
+	name string
+}
+
+type testMetrics struct {
+	lock       sync.Mutex
+	counters   map[string]int64
+	histograms map[string][]time.Duration
+}
+
+func newTestScope() *testScope {
+	return &testScope{testMetrics: &testMetrics{
+		counters:   make(map[string]int64),
+		histograms: make(map[string][]time.Duration),
+	}}
+}
+
+func (s *testScope) child(name string) *testScope {
+	return &testScope{testMetrics: s.testMetrics, name: s.name + name}
+}
+
+func (s *testScope) Counter(name string) metrics.Counter { return &testMetric{s, s.name + "." + name} }
+func (s *testScope) Gauge(name string) metrics.Gauge     { return &testMetric{s, s.name + "." + name} }
+func (s *testScope) Histogram(name string, buckets []time.Duration) metrics.Histogram {
+	return &testMetric{s, s.name + "." + name}
+}
+func (s *testScope) Tagged(tags map[string]string) metrics.Scope {
+	return s.child(":" + tags["operation"])
+}
+func (s *testScope) SubScope(name string) metrics.Scope { return s.child(name) }
+func (s *testScope) Close() error                       { return nil }
+func (s *testScope) Start() error                       { return nil }
+
+func (s *testScope) counter(name string) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.counters[name]
+}
+
+func (s *testScope) histogram(name string) []time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.histograms[name]
+}
+
+type testMetric struct {
+	scope *testScope
+	name  string
+}
+
+func (m *testMetric) Inc(delta int64) {
+	m.scope.lock.Lock()
+	defer m.scope.lock.Unlock()
+	m.scope.counters[m.name] += delta
+}
+
+func (m *testMetric) Update(value float64) {}
+
+func (m *testMetric) RecordDuration(value time.Duration) {
+	m.scope.lock.Lock()
+	defer m.scope.lock.Unlock()
+	m.scope.histograms[m.name] = append(m.scope.histograms[m.name], value)
+}
+
+// tamperingClient serves the requests with the given server,
+// and tampers with the signatures it returns
+type tamperingClient struct {
+	remotesigner.RemoteSignerServer
+	tamper func(signature []byte) []byte
+}
+
+func (c *tamperingClient) GetKey(ctx context.Context, req *remotesigner.GetKeyRequest, _ ...grpc.CallOption) (*remotesigner.GetKeyResponse, error) {
+	return c.RemoteSignerServer.GetKey(ctx, req)
+}
+
+func (c *tamperingClient) Sign(ctx context.Context, req *remotesigner.SignRequest, _ ...grpc.CallOption) (*remotesigner.SignResponse, error) {
+	resp, err := c.RemoteSignerServer.Sign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &remotesigner.SignResponse{Signature: c.tamper(resp.Signature)}, nil
+}
+
+type testSigner struct {
+	address string
+	csp     bccsp.BCCSP
+	dir     string
+	ca      tlsgen.CA
+	stop    func()
+}
+
+// newTestSigner serves the reference remote signing service,
+// requiring the clients to authenticate with a certificate issued by its CA
+func newTestSigner(t *testing.T) *testSigner {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	assert.NoError(t, err)
+
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, "keystore"), false)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	cert, err := tls.X509KeyPair(serverKeyPair.Cert, serverKeyPair.Key)
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(ca.CertBytes())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})))
+	remotesigner.RegisterRemoteSignerServer(server, NewServer(csp))
+	go server.Serve(listener)
+
+	return &testSigner{
+		address: listener.Addr().String(),
+		csp:     csp,
+		dir:     dir,
+		ca:      ca,
+		stop: func() {
+			server.Stop()
+			os.RemoveAll(dir)
+		},
+	}
+}
+
+// clientOpts returns the options of a client authenticating
+// with a certificate issued by the given CA
+func (s *testSigner) clientOpts(t *testing.T, ca tlsgen.CA) RemoteOpts {
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	assert.NoError(t, err)
+	dir, err := ioutil.TempDir(s.dir, "client")
+	assert.NoError(t, err)
+	opts := RemoteOpts{
+		SecLevel:   256,
+		HashFamily: "SHA2",
+		Address:    s.address,
+		Timeout:    time.Second,
+		TLS: &TLSOpts{
+			Cert:    filepath.Join(dir, "cert.pem"),
+			Key:     filepath.Join(dir, "key.pem"),
+			RootCAs: []string{filepath.Join(dir, "ca.pem")},
+		},
+	}
+	assert.NoError(t, ioutil.WriteFile(opts.TLS.Cert, clientKeyPair.Cert, 0600))
+	assert.NoError(t, ioutil.WriteFile(opts.TLS.Key, clientKeyPair.Key, 0600))
+	assert.NoError(t, ioutil.WriteFile(opts.TLS.RootCAs[0], s.ca.CertBytes(), 0600))
+	return opts
+}
+
+func TestRemoteSigner(t *testing.T) {
+	signer := newTestSigner(t)
+	defer signer.stop()
+
+	privKey, err := signer.csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	csp, err := New(signer.clientOpts(t, signer.ca), sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	scope := newTestScope()
+	csp.(*impl).metrics.scope = func() metrics.Scope { return scope }
+
+	// The private key is held by the remote signer
+	k, err := csp.GetKey(privKey.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	assert.Equal(t, privKey.SKI(), k.SKI())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	expectedPK, err := privKey.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPK.SKI(), pk.SKI())
+
+	// The key handle is cached
+	_, err = csp.GetKey(privKey.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), scope.counter("bccsp_remote:get_key.requests"))
+
+	// Signatures are delegated to the remote signer, and verified locally
+	digest := sha256.Sum256([]byte("hello world"))
+	signature, err := csp.Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = signer.csp.Verify(expectedPK, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, int64(1), scope.counter("bccsp_remote:sign.requests"))
+	assert.Len(t, scope.histogram("bccsp_remote:sign.latency"), 1)
+
+	_, err = csp.Sign(k, nil, nil)
+	assert.EqualError(t, err, "Invalid digest. Cannot be empty.")
+
+	// Keys unknown to the remote signer are looked up locally
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), scope.counter("bccsp_remote:get_key.failures"))
+	_, err = csp.GetKey(nil)
+	assert.Error(t, err)
+
+	// Local keys are handled by the software BCCSP
+	localKey, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	signature, err = csp.Sign(localKey, digest[:], nil)
+	assert.NoError(t, err)
+	valid, err = csp.Verify(localKey, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, int64(1), scope.counter("bccsp_remote:sign.requests"))
+}
+
+func TestRemoteSignerAuthentication(t *testing.T) {
+	signer := newTestSigner(t)
+	defer signer.stop()
+
+	privKey, err := signer.csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	// Clients with a certificate of another CA are refused
+	otherCA, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	csp, err := New(signer.clientOpts(t, otherCA), sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	_, err = csp.GetKey(privKey.SKI())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed getting key")
+
+	// Clients must authenticate
+	opts := signer.clientOpts(t, signer.ca)
+	opts.TLS.Key = ""
+	_, err = New(opts, sw.NewDummyKeyStore())
+	assert.EqualError(t, err, "Invalid TLS options. A client certificate and key are required.")
+	opts = signer.clientOpts(t, signer.ca)
+	opts.TLS.RootCAs = nil
+	_, err = New(opts, sw.NewDummyKeyStore())
+	assert.EqualError(t, err, "Invalid TLS options. At least a root CA is required.")
+	opts = signer.clientOpts(t, signer.ca)
+	opts.TLS.RootCAs = []string{opts.TLS.Key}
+	_, err = New(opts, sw.NewDummyKeyStore())
+	assert.Error(t, err)
+	opts.Address = ""
+	_, err = New(opts, sw.NewDummyKeyStore())
+	assert.EqualError(t, err, "Invalid address. It must not be empty.")
+}
+
+func TestRemoteSignerSignatureChecks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ks, err := sw.NewFileBasedKeyStore(nil, dir, false)
+	assert.NoError(t, err)
+	signerCSP, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	privKey, err := signerCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	client := &tamperingClient{RemoteSignerServer: NewServer(signerCSP)}
+	csp, err := NewWithClient(RemoteOpts{SecLevel: 256, HashFamily: "SHA2"}, sw.NewDummyKeyStore(), client)
+	assert.NoError(t, err)
+	k, err := csp.GetKey(privKey.SKI())
+	assert.NoError(t, err)
+	pub := k.(*remoteKey).ecdsaPub
+	assert.NotNil(t, pub)
+	digest := sha256.Sum256([]byte("hello world"))
+
+	// High-S signatures are normalized
+	client.tamper = func(signature []byte) []byte {
+		r, s, err := utils.UnmarshalECDSASignature(signature)
+		assert.NoError(t, err)
+		highS, err := utils.MarshalECDSASignature(r, new(big.Int).Sub(pub.Params().N, s))
+		assert.NoError(t, err)
+		return highS
+	}
+	signature, err := csp.Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+	_, s, err := utils.UnmarshalECDSASignature(signature)
+	assert.NoError(t, err)
+	lowS, err := utils.IsLowS(pub, s)
+	assert.NoError(t, err)
+	assert.True(t, lowS)
+	valid, err := csp.Verify(k, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// Signatures that don't verify are refused
+	client.tamper = func(signature []byte) []byte {
+		r, s, err := utils.UnmarshalECDSASignature(signature)
+		assert.NoError(t, err)
+		forged, err := utils.MarshalECDSASignature(r, new(big.Int).Add(s, big.NewInt(1)))
+		assert.NoError(t, err)
+		return forged
+	}
+	_, err = csp.Sign(k, digest[:], nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signature returned by the remote signer")
+
+	// Malformed signatures are refused
+	client.tamper = func(signature []byte) []byte {
+		return []byte{1, 2, 3}
+	}
+	_, err = csp.Sign(k, digest[:], nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signature returned by the remote signer")
+}
+
+func TestSignerMetrics(t *testing.T) {
+	// Nothing is reported until the metrics are initialized
+	m := newSignerMetrics()
+	assert.NotPanics(t, func() { m.observe("sign", time.Now(), nil) })
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	err = metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conn.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	})
+	assert.NoError(t, err)
+	go metrics.Start()
+	defer metrics.Shutdown()
+
+	m.observe("sign", time.Now(), nil)
+	m.observe("verify", time.Now(), errors.New("verification failed"))
+	expected := map[string]bool{
+		"hyperledger_fabric.bccsp_remote.requests.operation-sign:1|c":   false,
+		"hyperledger_fabric.bccsp_remote.failures.operation-verify:1|c": false,
+	}
+	buf := make([]byte, 2048)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for len(expected) > 0 {
+		n, _, err := conn.ReadFrom(buf)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for metric := range expected {
+			if strings.Contains(string(buf[:n]), metric) {
+				delete(expected, metric)
+			}
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	ks := sw.NewDummyKeyStore()
+	csp, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	s := NewServer(csp)
+
+	_, err = s.GetKey(context.Background(), &remotesigner.GetKeyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.GetKey(context.Background(), &remotesigner.GetKeyRequest{Ski: []byte{1, 2, 3}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.Sign(context.Background(), &remotesigner.SignRequest{Ski: []byte{1, 2, 3}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.Sign(context.Background(), &remotesigner.SignRequest{Ski: []byte{1, 2, 3}, Digest: []byte{1}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
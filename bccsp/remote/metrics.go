@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"time"
+
+	"github.com/sinochem-tech/fabric/common/metrics"
+)
+
+// latencyBuckets are the upper bounds of the latency histogram buckets,
+// from 1ms to about 4s
+var latencyBuckets = func() []time.Duration {
+	buckets := make([]time.Duration, 13)
+	for i := range buckets {
+		buckets[i] = time.Millisecond << uint(i)
+	}
+	return buckets
+}()
+
+// signerMetrics reports the latency and the outcome of the requests to the remote signer
+type signerMetrics struct {
+	// scope returns the scope the metrics are reported under, if any.
+	// It is resolved at every request since the BCCSP is usually
+	// initialized before the metrics.
+	scope func() metrics.Scope
+}
+
+func newSignerMetrics() *signerMetrics {
+	return &signerMetrics{
+		scope: func() metrics.Scope {
+			return metrics.RootScope
+		},
+	}
+}
+
+func (m *signerMetrics) observe(operation string, start time.Time, err error) {
+	scope := m.scope()
+	if scope == nil {
+		return
+	}
+
+	scope = scope.SubScope("bccsp_remote").Tagged(map[string]string{"operation": operation})
+	scope.Histogram("latency", latencyBuckets).RecordDuration(time.Since(start))
+	if err != nil {
+		scope.Counter("failures").Inc(1)
+		return
+	}
+	scope.Counter("requests").Inc(1)
+}
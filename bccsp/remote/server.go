@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/protos/remotesigner"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is a reference implementation of the remote signing service,
+// which signs with the private keys held by a BCCSP.
+// It is meant to be served by a gRPC server requiring
+// and verifying the TLS certificates of its clients.
+type Server struct {
+	csp bccsp.BCCSP
+}
+
+// NewServer returns a remote signing service signing with the private keys of the given BCCSP
+func NewServer(csp bccsp.BCCSP) *Server {
+	return &Server{csp: csp}
+}
+
+// GetKey returns the public key of the private key with the given subject key identifier.
+func (s *Server) GetKey(_ context.Context, req *remotesigner.GetKeyRequest) (*remotesigner.GetKeyResponse, error) {
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := k.PublicKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed getting the public key of key [%x]: %s", req.Ski, err)
+	}
+	raw, err := pk.Bytes()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed marshaling the public key of key [%x]: %s", req.Ski, err)
+	}
+
+	return &remotesigner.GetKeyResponse{PublicKey: raw}, nil
+}
+
+// Sign signs a digest with the private key with the given subject key identifier.
+func (s *Server) Sign(_ context.Context, req *remotesigner.SignRequest) (*remotesigner.SignResponse, error) {
+	if len(req.Digest) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty digest")
+	}
+
+	k, err := s.getPrivateKey(req.Ski)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.csp.Sign(k, req.Digest, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed signing with key [%x]: %s", req.Ski, err)
+	}
+
+	return &remotesigner.SignResponse{Signature: signature}, nil
+}
+
+func (s *Server) getPrivateKey(ski []byte) (bccsp.Key, error) {
+	if len(ski) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty SKI")
+	}
+
+	k, err := s.csp.GetKey(ski)
+	if err != nil || !k.Private() {
+		return nil, status.Errorf(codes.NotFound, "private key [%x] not found", ski)
+	}
+
+	return k, nil
+}
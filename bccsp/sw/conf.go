@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -38,6 +39,8 @@ func (conf *config) setSecurityLevel(securityLevel int, hashFamily string) (err
 		err = conf.setSecurityLevelSHA2(securityLevel)
 	case "SHA3":
 		err = conf.setSecurityLevelSHA3(securityLevel)
+	case "SM3":
+		err = conf.setSecurityLevelSM3(securityLevel)
 	default:
 		err = fmt.Errorf("Hash Family not supported [%s]", hashFamily)
 	}
@@ -79,3 +82,16 @@ func (conf *config) setSecurityLevelSHA3(level int) (err error) {
 	}
 	return
 }
+
+func (conf *config) setSecurityLevelSM3(level int) (err error) {
+	switch level {
+	case 256:
+		conf.ellipticCurve = elliptic.P256()
+		conf.hashFunction = sm3.New
+		conf.rsaBitLength = 2048
+		conf.aesBitLength = 32
+	default:
+		err = fmt.Errorf("Security level not supported [%d]", level)
+	}
+	return
+}
@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyED25519(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signED25519(priv, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := verifyED25519(pub, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifyED25519(pub, sigma, []byte("hello world!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = verifyED25519(pub, sigma[1:], msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid signature length [63], expected [64]")
+}
+
+func TestED25519KeyGenSignVerify(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	// The key is in the keystore
+	k2, err := provider.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &ed25519PrivateKey{}, k2)
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	digest, err := provider.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	signature, err := provider.Sign(k, digest, nil)
+	assert.NoError(t, err)
+
+	valid, err := provider.Verify(k, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = provider.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	// From PKIX
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := provider.KeyImport(raw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+	pk3, err := provider.GetKey(pk.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &ed25519PublicKey{}, pk3)
+
+	// From the go public key
+	pk4, err := provider.KeyImport(pk.(*ed25519PublicKey).pubKey, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk4.SKI())
+
+	// From the private key in PKCS#8
+	der, err := x509.MarshalPKCS8PrivateKey(k.(*ed25519PrivateKey).privKey)
+	assert.NoError(t, err)
+	k2, err := provider.KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	// ECDSA keys are refused
+	ecdsaKey, err := provider.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	ecdsaPK, err := ecdsaKey.PublicKey()
+	assert.NoError(t, err)
+	raw, err = ecdsaPK.Bytes()
+	assert.NoError(t, err)
+	_, err = provider.KeyImport(raw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Failed casting to Ed25519 public key. Invalid raw material.")
+	_, err = provider.KeyImport(&ecdsaKey.(*ecdsaPrivateKey).privKey.PublicKey, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+	ecdsaDER, err := x509.MarshalPKCS8PrivateKey(ecdsaKey.(*ecdsaPrivateKey).privKey)
+	assert.NoError(t, err)
+	_, err = provider.KeyImport(ecdsaDER, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Failed casting to Ed25519 private key. Invalid raw material.")
+}
+
+func TestKeyImportFromX509ED25519PublicKey(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	priv := k.(*ed25519PrivateKey).privKey
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ed25519"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	pk, err := provider.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &ed25519PublicKey{}, pk)
+	assert.Equal(t, k.SKI(), pk.SKI())
+}
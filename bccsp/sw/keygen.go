@@ -18,12 +18,15 @@ package sw
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
 
 	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm4"
 )
 
 type ecdsaKeyGenerator struct {
@@ -65,3 +68,36 @@ func (kg *rsaKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error
 
 	return &rsaPrivateKey{lowLevelKey}, nil
 }
+
+type sm2KeyGenerator struct{}
+
+func (kg *sm2KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	privKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating SM2 key [%s]", err)
+	}
+
+	return &sm2PrivateKey{privKey}, nil
+}
+
+type ed25519KeyGenerator struct{}
+
+func (kg *ed25519KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating Ed25519 key [%s]", err)
+	}
+
+	return &ed25519PrivateKey{privKey}, nil
+}
+
+type sm4KeyGenerator struct{}
+
+func (kg *sm4KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	lowLevelKey, err := GetRandomBytes(sm4.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating SM4 key [%s]", err)
+	}
+
+	return &sm4PrivateKey{lowLevelKey, false}, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySM2(t *testing.T) {
+	t.Parallel()
+
+	lowLevelKey, err := sm2.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signSM2(lowLevelKey, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := verifySM2(&lowLevelKey.PublicKey, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifySM2(&lowLevelKey.PublicKey, sigma, []byte("hello world!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = verifySM2(&lowLevelKey.PublicKey, nil, msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed unmashalling signature [")
+}
+
+func TestSM2KeyGenSignVerify(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.SM2KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.False(t, pk.Private())
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	// The key is in the keystore
+	k2, err := provider.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &sm2PrivateKey{}, k2)
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	digest, err := provider.Hash([]byte("Hello World"), &bccsp.SM3Opts{})
+	assert.NoError(t, err)
+	signature, err := provider.Sign(k, digest, nil)
+	assert.NoError(t, err)
+
+	valid, err := provider.Verify(k, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = provider.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSM2KeyImport(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.SM2KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	// From PKIX
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := provider.KeyImport(raw, &bccsp.SM2PKIXPublicKeyImportOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+	pk3, err := provider.GetKey(pk.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &sm2PublicKey{}, pk3)
+
+	// From the go public key
+	pk4, err := provider.KeyImport(pk.(*sm2PublicKey).pubKey, &bccsp.SM2GoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk4.SKI())
+
+	// From the private key in DER
+	der, err := sm2.MarshalPKCS8PrivateKey(k.(*sm2PrivateKey).privKey)
+	assert.NoError(t, err)
+	k2, err := provider.KeyImport(der, &bccsp.SM2PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), k2.SKI())
+
+	// ECDSA keys are refused
+	ecdsaKey, err := provider.KeyGen(&bccsp.ECDSAKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	ecdsaPK, err := ecdsaKey.PublicKey()
+	assert.NoError(t, err)
+	raw, err = ecdsaPK.Bytes()
+	assert.NoError(t, err)
+	_, err = provider.KeyImport(raw, &bccsp.SM2PKIXPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Failed casting to SM2 public key. Invalid raw material.")
+	_, err = provider.KeyImport(&ecdsaKey.(*ecdsaPrivateKey).privKey.PublicKey, &bccsp.SM2GoPublicKeyImportOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected *sm2.PublicKey.")
+}
+
+func TestKeyImportFromX509SM2PublicKey(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.SM2KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	priv := k.(*sm2PrivateKey).privKey
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sm2"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := sm2.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	assert.NoError(t, err)
+	cert, err := utils.DERToX509Certificate(der)
+	assert.NoError(t, err)
+
+	pk, err := provider.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &sm2PublicKey{}, pk)
+	assert.Equal(t, k.SKI(), pk.SKI())
+}
+
+func TestSM3HashFamily(t *testing.T) {
+	t.Parallel()
+
+	ks := NewDummyKeyStore()
+	provider, err := NewWithParams(256, "SM3", ks)
+	assert.NoError(t, err)
+
+	msg := []byte("abc")
+	expected := sm3.Sum(msg)
+	h, err := provider.Hash(msg, &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected[:], h)
+	h, err = provider.Hash(msg, &bccsp.SM3Opts{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected[:], h)
+
+	_, err = NewWithParams(384, "SM3", ks)
+	assert.Error(t, err)
+}
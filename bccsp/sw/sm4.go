@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm4"
+)
+
+// Notice that SM4 has the same block size as AES, hence
+// pkcs7Padding and pkcs7UnPadding apply to it as well.
+
+func sm4CBCEncryptWithRand(prng io.Reader, key, s []byte) ([]byte, error) {
+	iv := make([]byte, sm4.BlockSize)
+	if _, err := io.ReadFull(prng, iv); err != nil {
+		return nil, err
+	}
+
+	return sm4CBCEncryptWithIV(iv, key, s)
+}
+
+func sm4CBCEncryptWithIV(IV []byte, key, s []byte) ([]byte, error) {
+	if len(s)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid plaintext. It must be a multiple of the block size")
+	}
+
+	if len(IV) != sm4.BlockSize {
+		return nil, errors.New("Invalid IV. It must have length the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := make([]byte, sm4.BlockSize+len(s))
+	copy(ciphertext[:sm4.BlockSize], IV)
+
+	mode := cipher.NewCBCEncrypter(block, IV)
+	mode.CryptBlocks(ciphertext[sm4.BlockSize:], s)
+
+	return ciphertext, nil
+}
+
+func sm4CBCDecrypt(key, src []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(src) < sm4.BlockSize {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+	iv := src[:sm4.BlockSize]
+	src = src[sm4.BlockSize:]
+
+	if len(src)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+
+	mode := cipher.NewCBCDecrypter(block, iv)
+
+	mode.CryptBlocks(src, src)
+
+	return src, nil
+}
+
+// SM4CBCPKCS7Encrypt combines CBC encryption and PKCS7 padding
+func SM4CBCPKCS7Encrypt(key, src []byte) ([]byte, error) {
+	return SM4CBCPKCS7EncryptWithRand(rand.Reader, key, src)
+}
+
+// SM4CBCPKCS7EncryptWithRand combines CBC encryption and PKCS7 padding using as prng the passed to the function
+func SM4CBCPKCS7EncryptWithRand(prng io.Reader, key, src []byte) ([]byte, error) {
+	// First pad
+	tmp := pkcs7Padding(src)
+
+	// Then encrypt
+	return sm4CBCEncryptWithRand(prng, key, tmp)
+}
+
+// SM4CBCPKCS7EncryptWithIV combines CBC encryption and PKCS7 padding, the IV used is the one passed to the function
+func SM4CBCPKCS7EncryptWithIV(IV []byte, key, src []byte) ([]byte, error) {
+	// First pad
+	tmp := pkcs7Padding(src)
+
+	// Then encrypt
+	return sm4CBCEncryptWithIV(IV, key, tmp)
+}
+
+// SM4CBCPKCS7Decrypt combines CBC decryption and PKCS7 unpadding
+func SM4CBCPKCS7Decrypt(key, src []byte) ([]byte, error) {
+	// First decrypt
+	pt, err := sm4CBCDecrypt(key, src)
+	if err == nil {
+		return pkcs7UnPadding(pt)
+	}
+	return nil, err
+}
+
+type sm4cbcpkcs7Encryptor struct{}
+
+func (e *sm4cbcpkcs7Encryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
+	switch o := opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts:
+		// SM4 in CBC mode with PKCS7 padding
+
+		if len(o.IV) != 0 && o.PRNG != nil {
+			return nil, errors.New("Invalid options. Either IV or PRNG should be different from nil, or both nil.")
+		}
+
+		if len(o.IV) != 0 {
+			// Encrypt with the passed IV
+			return SM4CBCPKCS7EncryptWithIV(o.IV, k.(*sm4PrivateKey).privKey, plaintext)
+		} else if o.PRNG != nil {
+			// Encrypt with PRNG
+			return SM4CBCPKCS7EncryptWithRand(o.PRNG, k.(*sm4PrivateKey).privKey, plaintext)
+		}
+		return SM4CBCPKCS7Encrypt(k.(*sm4PrivateKey).privKey, plaintext)
+	case bccsp.SM4CBCPKCS7ModeOpts:
+		return e.Encrypt(k, plaintext, &o)
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
+}
+
+type sm4cbcpkcs7Decryptor struct{}
+
+func (*sm4cbcpkcs7Decryptor) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) (plaintext []byte, err error) {
+	// check for mode
+	switch opts.(type) {
+	case *bccsp.SM4CBCPKCS7ModeOpts, bccsp.SM4CBCPKCS7ModeOpts:
+		// SM4 in CBC mode with PKCS7 padding
+		return SM4CBCPKCS7Decrypt(k.(*sm4PrivateKey).privKey, ciphertext)
+	default:
+		return nil, fmt.Errorf("Mode not recognized [%s]", opts)
+	}
+}
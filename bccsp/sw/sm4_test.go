@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSM4CBCPKCS7EncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, sm4.KeySize)
+	iv := bytes.Repeat([]byte{0x01}, sm4.BlockSize)
+	msg := []byte("a message with a length that is not a multiple of the block size")
+
+	ct, err := SM4CBCPKCS7EncryptWithIV(iv, key, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, iv, ct[:sm4.BlockSize])
+	assert.Equal(t, 0, len(ct)%sm4.BlockSize)
+
+	pt, err := SM4CBCPKCS7Decrypt(key, ct)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	_, err = SM4CBCPKCS7EncryptWithIV(iv[1:], key, msg)
+	assert.EqualError(t, err, "Invalid IV. It must have length the block size")
+
+	_, err = SM4CBCPKCS7Decrypt(key, ct[:sm4.BlockSize+1])
+	assert.EqualError(t, err, "Invalid ciphertext. It must be a multiple of the block size")
+
+	_, err = SM4CBCPKCS7Encrypt(key[1:], msg)
+	assert.EqualError(t, err, "sm4: invalid key size 15")
+}
+
+func TestSM4KeyGenEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	k, err := provider.KeyGen(&bccsp.SM4KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.True(t, k.Symmetric())
+	_, err = k.PublicKey()
+	assert.Error(t, err)
+
+	// The key is stored in the keystore as an SM4 key
+	k2, err := provider.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.IsType(t, &sm4PrivateKey{}, k2)
+
+	msg := []byte("Hello World")
+	ct, err := provider.Encrypt(k, msg, &bccsp.SM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	pt, err := provider.Decrypt(k2, ct, bccsp.SM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	ct, err = provider.Encrypt(k, msg, bccsp.SM4CBCPKCS7ModeOpts{IV: make([]byte, sm4.BlockSize)})
+	assert.NoError(t, err)
+	pt, err = provider.Decrypt(k, ct, &bccsp.SM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, pt)
+
+	_, err = provider.Encrypt(k, msg, &bccsp.SM4CBCPKCS7ModeOpts{IV: make([]byte, sm4.BlockSize), PRNG: bytes.NewReader(nil)})
+	assert.Error(t, err)
+	_, err = provider.Encrypt(k, msg, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.Error(t, err)
+}
+
+func TestSM4KeyImport(t *testing.T) {
+	t.Parallel()
+	provider, _, cleanup := currentTestConfig.Provider(t)
+	defer cleanup()
+
+	raw, err := GetRandomBytes(sm4.KeySize)
+	assert.NoError(t, err)
+	k, err := provider.KeyImport(raw, &bccsp.SM4ImportKeyOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.IsType(t, &sm4PrivateKey{}, k)
+
+	_, err = provider.KeyImport(raw[1:], &bccsp.SM4ImportKeyOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Invalid Key Length [15]. Must be 16 bytes")
+	_, err = provider.KeyImport("raw", &bccsp.SM4ImportKeyOpts{Temporary: true})
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected byte array.")
+}
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -26,6 +27,8 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 )
 
 // struct to hold info required for PKCS#8
@@ -142,44 +145,90 @@ func PrivateKeyToPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: raw,
 			},
 		), nil
+	case *sm2.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 private key. It must be different from nil.")
+		}
+		raw, err := sm2.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling SM2 key to asn1 [%s]", err)
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: raw,
+			},
+		), nil
+	case ed25519.PrivateKey:
+		if len(k) == 0 {
+			return nil, errors.New("Invalid ed25519 private key. It must be different from nil.")
+		}
+		raw, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling Ed25519 key to asn1 [%s]", err)
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: raw,
+			},
+		), nil
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey or *rsa.PrivateKey")
 	}
 }
 
-// PrivateKeyToEncryptedPEM converts a private key to an encrypted PEM
+// PrivateKeyToEncryptedPEM converts a private key to an encrypted PEM.
+// The private key is converted to PKCS#8 format, and encrypted with
+// the password as specified by EncryptPKCS8.
 func PrivateKeyToEncryptedPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 	if privateKey == nil {
 		return nil, errors.New("Invalid private key. It must be different from nil.")
 	}
 
+	var raw []byte
+	var err error
 	switch k := privateKey.(type) {
 	case *ecdsa.PrivateKey:
 		if k == nil {
 			return nil, errors.New("Invalid ecdsa private key. It must be different from nil.")
 		}
-		raw, err := x509.MarshalECPrivateKey(k)
-
-		if err != nil {
-			return nil, err
+		raw, err = x509.MarshalPKCS8PrivateKey(k)
+	case *rsa.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid rsa private key. It must be different from nil.")
 		}
-
-		block, err := x509.EncryptPEMBlock(
-			rand.Reader,
-			"PRIVATE KEY",
-			raw,
-			pwd,
-			x509.PEMCipherAES256)
-
-		if err != nil {
-			return nil, err
+		raw, err = x509.MarshalPKCS8PrivateKey(k)
+	case *sm2.PrivateKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 private key. It must be different from nil.")
 		}
-
-		return pem.EncodeToMemory(block), nil
-
+		raw, err = sm2.MarshalPKCS8PrivateKey(k)
+	case ed25519.PrivateKey:
+		if len(k) == 0 {
+			return nil, errors.New("Invalid ed25519 private key. It must be different from nil.")
+		}
+		raw, err = x509.MarshalPKCS8PrivateKey(k)
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey, *rsa.PrivateKey, *sm2.PrivateKey or ed25519.PrivateKey")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := EncryptPKCS8(raw, pwd)
+	if err != nil {
+		return nil, err
 	}
+
+	return pem.EncodeToMemory(
+		&pem.Block{
+			Type:  "ENCRYPTED PRIVATE KEY",
+			Bytes: encrypted,
+		},
+	), nil
 }
 
 // DERToPrivateKey unmarshals a der to private key
@@ -191,7 +240,7 @@ func DERToPrivateKey(der []byte) (key interface{}, err error) {
 
 	if key, err = x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key.(type) {
-		case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return
 		default:
 			return nil, errors.New("Found unknown private key type in PKCS#8 wrapping")
@@ -202,6 +251,15 @@ func DERToPrivateKey(der []byte) (key interface{}, err error) {
 		return
 	}
 
+	// The x509 package doesn't recognize the SM2 curve
+	if key, err = sm2.ParsePKCS8PrivateKey(der); err == nil {
+		return
+	}
+
+	if key, err = sm2.ParseECPrivateKey(der); err == nil {
+		return
+	}
+
 	return nil, errors.New("Invalid key type. The DER must contain an rsa.PrivateKey or ecdsa.PrivateKey")
 }
 
@@ -217,6 +275,16 @@ func PEMtoPrivateKey(raw []byte, pwd []byte) (interface{}, error) {
 
 	// TODO: derive from header the type of the key
 
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		decrypted, err := DecryptPKCS8(block.Bytes, pwd)
+		if err != nil {
+			return nil, err
+		}
+
+		return DERToPrivateKey(decrypted)
+	}
+
+	// Keys encrypted with the legacy PEM encryption of RFC 1423
 	if x509.IsEncryptedPEMBlock(block) {
 		if len(pwd) == 0 {
 			return nil, errors.New("Encrypted Key. Need a password")
@@ -251,6 +319,11 @@ func PEMtoAES(raw []byte, pwd []byte) ([]byte, error) {
 		return nil, fmt.Errorf("Failed decoding PEM. Block must be different from nil. [% x]", raw)
 	}
 
+	if block.Type == "ENCRYPTED AES PRIVATE KEY" {
+		return DecryptPKCS8(block.Bytes, pwd)
+	}
+
+	// Keys encrypted with the legacy PEM encryption of RFC 1423
 	if x509.IsEncryptedPEMBlock(block) {
 		if len(pwd) == 0 {
 			return nil, errors.New("Encrypted Key. Password must be different fom nil")
@@ -271,7 +344,8 @@ func AEStoPEM(raw []byte) []byte {
 	return pem.EncodeToMemory(&pem.Block{Type: "AES PRIVATE KEY", Bytes: raw})
 }
 
-// AEStoEncryptedPEM encapsulates an AES key in the encrypted PEM format
+// AEStoEncryptedPEM encapsulates an AES key in the encrypted PEM format.
+// The key is encrypted with the password as a private key by EncryptPKCS8.
 func AEStoEncryptedPEM(raw []byte, pwd []byte) ([]byte, error) {
 	if len(raw) == 0 {
 		return nil, errors.New("Invalid aes key. It must be different from nil")
@@ -280,18 +354,12 @@ func AEStoEncryptedPEM(raw []byte, pwd []byte) ([]byte, error) {
 		return AEStoPEM(raw), nil
 	}
 
-	block, err := x509.EncryptPEMBlock(
-		rand.Reader,
-		"AES PRIVATE KEY",
-		raw,
-		pwd,
-		x509.PEMCipherAES256)
-
+	encrypted, err := EncryptPKCS8(raw, pwd)
 	if err != nil {
 		return nil, err
 	}
 
-	return pem.EncodeToMemory(block), nil
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED AES PRIVATE KEY", Bytes: encrypted}), nil
 }
 
 // PublicKeyToPEM marshals a public key to the pem format
@@ -335,6 +403,36 @@ func PublicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: PubASN1,
 			},
 		), nil
+	case *sm2.PublicKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 public key. It must be different from nil.")
+		}
+		PubASN1, err := sm2.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: PubASN1,
+			},
+		), nil
+	case ed25519.PublicKey:
+		if len(k) == 0 {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
+		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: PubASN1,
+			},
+		), nil
 
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
@@ -370,6 +468,28 @@ func PublicKeyToDER(publicKey interface{}) ([]byte, error) {
 
 		return PubASN1, nil
 
+	case *sm2.PublicKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 public key. It must be different from nil.")
+		}
+		PubASN1, err := sm2.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return PubASN1, nil
+
+	case ed25519.PublicKey:
+		if len(k) == 0 {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
+		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return PubASN1, nil
+
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
 	}
@@ -407,6 +527,50 @@ func PublicKeyToEncryptedPEM(publicKey interface{}, pwd []byte) ([]byte, error)
 
 		return pem.EncodeToMemory(block), nil
 
+	case *sm2.PublicKey:
+		if k == nil {
+			return nil, errors.New("Invalid sm2 public key. It must be different from nil.")
+		}
+		raw, err := sm2.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := x509.EncryptPEMBlock(
+			rand.Reader,
+			"PUBLIC KEY",
+			raw,
+			pwd,
+			x509.PEMCipherAES256)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(block), nil
+
+	case ed25519.PublicKey:
+		if len(k) == 0 {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
+		raw, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := x509.EncryptPEMBlock(
+			rand.Reader,
+			"PUBLIC KEY",
+			raw,
+			pwd,
+			x509.PEMCipherAES256)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(block), nil
+
 	default:
 		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey")
 	}
@@ -454,6 +618,12 @@ func DERToPublicKey(raw []byte) (pub interface{}, err error) {
 	}
 
 	key, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		// The x509 package doesn't recognize the SM2 curve
+		if sm2Key, sm2Err := sm2.ParsePKIXPublicKey(raw); sm2Err == nil {
+			return sm2Key, nil
+		}
+	}
 
 	return key, err
 }
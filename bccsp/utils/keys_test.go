@@ -18,6 +18,7 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -318,6 +319,51 @@ func TestECDSAKeys(t *testing.T) {
 	}
 }
 
+func TestED25519Keys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	// Private Key PEM format
+	pemKey, err := PrivateKeyToPEM(priv, nil)
+	assert.NoError(t, err)
+	keyFromPEM, err := PEMtoPrivateKey(pemKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, priv, keyFromPEM)
+
+	// Encrypted Private Key PEM format
+	encPEM, err := PrivateKeyToPEM(priv, []byte("passwd"))
+	assert.NoError(t, err)
+	keyFromPEM, err = PEMtoPrivateKey(encPEM, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, priv, keyFromPEM)
+
+	// Public Key PEM format
+	pemKey, err = PublicKeyToPEM(pub, nil)
+	assert.NoError(t, err)
+	pubFromPEM, err := PEMtoPublicKey(pemKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubFromPEM)
+
+	// Encrypted Public Key PEM format
+	encPEM, err = PublicKeyToPEM(pub, []byte("passwd"))
+	assert.NoError(t, err)
+	pubFromPEM, err = PEMtoPublicKey(encPEM, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubFromPEM)
+
+	// Public Key DER format
+	der, err := PublicKeyToDER(pub)
+	assert.NoError(t, err)
+	pubFromDER, err := DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, pubFromDER)
+
+	_, err = PrivateKeyToPEM(ed25519.PrivateKey(nil), nil)
+	assert.Error(t, err)
+	_, err = PublicKeyToDER(ed25519.PublicKey(nil))
+	assert.Error(t, err)
+}
+
 func TestAESKey(t *testing.T) {
 	k := []byte{0, 1, 2, 3, 4, 5}
 	pem := AEStoPEM(k)
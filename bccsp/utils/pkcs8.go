@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"hash"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Parameters of the scrypt key derivation function used to encrypt
+// private keys. They are the defaults of OpenSSL, whose memory limit
+// they fit in, and cost 16MB of memory per derivation.
+const (
+	scryptN       = 1 << 14
+	scryptR       = 8
+	scryptP       = 1
+	scryptSaltLen = 16
+)
+
+var (
+	oidPBES2          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidScrypt         = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+	oidAES128CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC      = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is the EncryptedPrivateKeyInfo structure of RFC 5958
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params are the parameters of the PBES2 encryption scheme of RFC 8018
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// scryptParams are the parameters of the scrypt key derivation function of RFC 7914
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// pbkdf2Params are the parameters of the PBKDF2 key derivation function of RFC 8018
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// EncryptPKCS8 encrypts the given DER encoded PKCS#8 private key with the
+// password, and returns the DER encoded EncryptedPrivateKeyInfo. The key is
+// encrypted with AES-256-CBC under the PBES2 scheme, with a key derived from
+// the password by scrypt.
+func EncryptPKCS8(der, pwd []byte) ([]byte, error) {
+	if len(der) == 0 {
+		return nil, errors.New("invalid private key, it must be different from nil")
+	}
+	if len(pwd) == 0 {
+		return nil, errors.New("invalid password, it must be different from nil")
+	}
+
+	salt := make([]byte, scryptSaltLen)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed generating the salt")
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, errors.Wrap(err, "failed generating the IV")
+	}
+
+	key, err := scrypt.Key(pwd, salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed deriving the encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	// PKCS#7 padding
+	padding := aes.BlockSize - len(der)%aes.BlockSize
+	ciphertext := append(append([]byte{}, der...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+
+	kdfParams, err := asn1.Marshal(scryptParams{
+		Salt:                     salt,
+		CostParameter:            scryptN,
+		BlockSize:                scryptR,
+		ParallelizationParameter: scryptP,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidScrypt, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		EncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData:       ciphertext,
+	})
+}
+
+// DecryptPKCS8 decrypts the given DER encoded EncryptedPrivateKeyInfo with
+// the password, and returns the DER encoded PKCS#8 private key. Besides the
+// scrypt key derivation function, it supports PBKDF2, which is what OpenSSL
+// uses by default, with AES-CBC encryption under the PBES2 scheme.
+func DecryptPKCS8(der, pwd []byte) ([]byte, error) {
+	if len(pwd) == 0 {
+		return nil, errors.New("Encrypted Key. Need a password")
+	}
+
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "failed parsing the encrypted private key")
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after the encrypted private key")
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, errors.Errorf("unsupported encryption scheme %v, only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, errors.Wrap(err, "failed parsing the PBES2 parameters")
+	}
+
+	var keyLen int
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLen = 16
+	case alg.Equal(oidAES192CBC):
+		keyLen = 24
+	case alg.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, errors.Errorf("unsupported encryption algorithm %v", alg)
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, errors.Wrap(err, "failed parsing the IV")
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, errors.Errorf("invalid IV length %d", len(iv))
+	}
+
+	key, err := deriveKey(params.KeyDerivationFunc, pwd, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := info.EncryptedData
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid length of the encrypted private key")
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	// A wrong password is almost always detected by a wrong padding
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, errors.New("failed decrypting the private key, the password is wrong")
+	}
+	for _, b := range plaintext[len(plaintext)-padding:] {
+		if int(b) != padding {
+			return nil, errors.New("failed decrypting the private key, the password is wrong")
+		}
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+func deriveKey(kdf pkix.AlgorithmIdentifier, pwd []byte, keyLen int) ([]byte, error) {
+	switch {
+	case kdf.Algorithm.Equal(oidScrypt):
+		var params scryptParams
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.Wrap(err, "failed parsing the scrypt parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.Errorf("invalid key length %d", params.KeyLength)
+		}
+		// Bound the memory a malicious key file can make us allocate
+		if params.CostParameter > 1<<20 || params.BlockSize > 32 || params.ParallelizationParameter > 16 {
+			return nil, errors.New("the scrypt parameters are too large")
+		}
+		key, err := scrypt.Key(pwd, params.Salt, params.CostParameter, params.BlockSize, params.ParallelizationParameter, keyLen)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed deriving the decryption key")
+		}
+		return key, nil
+
+	case kdf.Algorithm.Equal(oidPBKDF2):
+		var params pbkdf2Params
+		if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+			return nil, errors.Wrap(err, "failed parsing the PBKDF2 parameters")
+		}
+		if params.KeyLength != 0 && params.KeyLength != keyLen {
+			return nil, errors.Errorf("invalid key length %d", params.KeyLength)
+		}
+		var h func() hash.Hash
+		switch prf := params.PRF.Algorithm; {
+		case len(prf) == 0, prf.Equal(oidHMACWithSHA1):
+			h = sha1.New
+		case prf.Equal(oidHMACWithSHA256):
+			h = sha256.New
+		case prf.Equal(oidHMACWithSHA512):
+			h = sha512.New
+		default:
+			return nil, errors.Errorf("unsupported PBKDF2 pseudorandom function %v", prf)
+		}
+		if params.IterationCount < 1 {
+			return nil, errors.Errorf("invalid iteration count %d", params.IterationCount)
+		}
+		return pbkdf2.Key(pwd, params.Salt, params.IterationCount, keyLen, h), nil
+
+	default:
+		return nil, errors.Errorf("unsupported key derivation function %v", kdf.Algorithm)
+	}
+}
@@ -18,9 +18,12 @@ package utils
 
 import (
 	"crypto/x509"
+
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 )
 
-// DERToX509Certificate converts der to x509
+// DERToX509Certificate converts der to x509.
+// Certificates of SM2 public keys are supported as well.
 func DERToX509Certificate(asn1Data []byte) (*x509.Certificate, error) {
-	return x509.ParseCertificate(asn1Data)
+	return sm2.ParseCertificate(asn1Data)
 }
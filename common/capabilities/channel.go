@@ -16,12 +16,16 @@ const (
 
 	// ChannelV1_1 is the capabilties string for standard new non-backwards compatible fabric v1.1 channel capabilities.
 	ChannelV1_1 = "V1_1"
+
+	// ChannelV1_3 is the capabilties string for standard new non-backwards compatible fabric v1.3 channel capabilities.
+	ChannelV1_3 = "V1_3"
 )
 
 // ChannelProvider provides capabilities information for channel level config.
 type ChannelProvider struct {
 	*registry
 	v11 bool
+	v13 bool
 }
 
 // NewChannelProvider creates a channel capabilities provider.
@@ -29,6 +33,7 @@ func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider
 	cp := &ChannelProvider{}
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11 = capabilities[ChannelV1_1]
+	_, cp.v13 = capabilities[ChannelV1_3]
 	return cp
 }
 
@@ -43,6 +48,8 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 	// Add new capability names here
 	case ChannelV1_1:
 		return true
+	case ChannelV1_3:
+		return true
 	default:
 		return false
 	}
@@ -51,6 +58,8 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 // MSPVersion returns the level of MSP support required by this channel.
 func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 	switch {
+	case cp.v13:
+		return msp.MSPv1_3
 	case cp.v11:
 		return msp.MSPv1_1
 	default:
@@ -45,14 +45,50 @@ const (
 	// RoleOrderer = "orderer" TODO
 )
 
+// PrincipalAttrs is the keyword of the principals requiring
+// identities with a set of attributes
+const PrincipalAttrs = "attrs"
+
 var (
 	regex = regexp.MustCompile(
 		fmt.Sprintf("^([[:alnum:].-]+)([.])(%s|%s|%s|%s)$",
 			RoleAdmin, RoleMember, RoleClient, RolePeer),
 	)
-	regexErr = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
+	regexAttrs = regexp.MustCompile(
+		fmt.Sprintf("^([[:alnum:].-]+)([.])%s[(]([^()']+)[)]$", PrincipalAttrs),
+	)
+	regexAttr = regexp.MustCompile("^[[:space:]]*([[:alnum:]._-]+)[[:space:]]*=[[:space:]]*([^=[:space:]]+)[[:space:]]*$")
+	regexErr  = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
 )
 
+// isPrincipal returns whether the string is one of the principals
+// of the policy language
+func isPrincipal(s string) bool {
+	return regex.MatchString(s) || regexAttrs.MatchString(s)
+}
+
+// attrsPrincipal builds the MSPPrincipal requiring the attributes
+// in the given attribute-based principal string
+func attrsPrincipal(s string) (*msp.MSPPrincipal, error) {
+	subm := regexAttrs.FindStringSubmatch(s)
+	if len(subm) != 4 {
+		return nil, fmt.Errorf("Error parsing principal %s", s)
+	}
+
+	mspAttrs := &msp.MSPAttributes{MspIdentifier: subm[1]}
+	for _, attr := range strings.Split(subm[3], ",") {
+		nv := regexAttr.FindStringSubmatch(attr)
+		if len(nv) != 3 {
+			return nil, fmt.Errorf("Error parsing attribute %s of principal %s", strings.TrimSpace(attr), s)
+		}
+		mspAttrs.Attributes = append(mspAttrs.Attributes, &msp.MSPAttributes_Attribute{Name: nv[1], Value: nv[2]})
+	}
+
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTES,
+		Principal:               utils.MarshalOrPanic(mspAttrs)}, nil
+}
+
 // a stub function - it returns the same string as it's passed.
 // This will be evaluated by second/third passes to convert to a proto policy
 func outof(args ...interface{}) (interface{}, error) {
@@ -77,7 +113,7 @@ func outof(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -105,7 +141,7 @@ func firstPass(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -163,6 +199,19 @@ func secondPass(args ...interface{}) (interface{}, error) {
 		   <MSP_ID> . <ROLE>, where MSP_ID is the MSP identifier
 		   and ROLE is either a member, an admin, a client, a peer or an orderer*/
 		case string:
+			/* attribute-based principals are formed as
+			   <MSP_ID> . attrs(<NAME>=<VALUE>[, <NAME>=<VALUE>]) */
+			if regexAttrs.MatchString(t) {
+				p, err := attrsPrincipal(t)
+				if err != nil {
+					return nil, err
+				}
+				ctx.principals = append(ctx.principals, p)
+				policies = append(policies, SignedBy(int32(ctx.IDNum)))
+				ctx.IDNum++
+				continue
+			}
+
 			/* split the string */
 			subm := regex.FindAllStringSubmatch(t, -1)
 			if subm == nil || len(subm) != 1 || len(subm[0]) != 4 {
@@ -237,10 +286,16 @@ func newContext() *context {
 //
 // ORG.ROLE
 //
+// or:
+//
+// ORG.attrs(NAME=VALUE[, NAME=VALUE])
+//
 // where:
 //	- ORG is a string (representing the MSP identifier)
 //	- ROLE takes the value of any of the RoleXXX constants representing
 //    the required role
+//	- NAME and VALUE are the name and the value of an attribute the
+//    identity must have in its certificate, as issued by the Fabric CA
 func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 	// first we translate the and/or business into outof gates
 	intermediate, err := govaluate.NewEvaluableExpressionWithFunctions(
@@ -233,3 +233,49 @@ func TestBadStringsNoPanic(t *testing.T) {
 	_, err = FromString("OR('A.member', Bmember)")
 	assert.Error(t, err)
 }
+
+func TestAttributes(t *testing.T) {
+	p1, err := FromString("AND('A.attrs(role=auditor, dept=finance)', OR('B.member', 'MSP.WITH.DOTS.attrs(hf.Type=client)'))")
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "B"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTES,
+		Principal: utils.MarshalOrPanic(&msp.MSPAttributes{MspIdentifier: "MSP.WITH.DOTS", Attributes: []*msp.MSPAttributes_Attribute{
+			{Name: "hf.Type", Value: "client"},
+		}})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTES,
+		Principal: utils.MarshalOrPanic(&msp.MSPAttributes{MspIdentifier: "A", Attributes: []*msp.MSPAttributes_Attribute{
+			{Name: "role", Value: "auditor"},
+			{Name: "dept", Value: "finance"},
+		}})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule: NOutOf(2, []*common.SignaturePolicy{
+			SignedBy(2),
+			NOutOf(1, []*common.SignaturePolicy{SignedBy(0), SignedBy(1)}),
+		}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestBadAttributes(t *testing.T) {
+	_, err := FromString("OR('A.attrs(role)')")
+	assert.EqualError(t, err, "Error parsing attribute role of principal A.attrs(role)")
+	_, err = FromString("OR('A.attrs(role=auditor,)')")
+	assert.Error(t, err)
+	_, err = FromString("OR('A.attrs(role=audi tor)')")
+	assert.Error(t, err)
+	_, err = FromString("OR('A.attrs()')")
+	assert.Error(t, err)
+}
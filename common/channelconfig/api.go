@@ -143,6 +143,10 @@ type ApplicationCapabilities interface {
 	// of transactions (as introduced in v1.2).
 	V1_2Validation() bool
 
+	// V1_3Validation returns true if this channel is configured to perform stricter validation
+	// of transactions (as introduced in v1.3).
+	V1_3Validation() bool
+
 	// MetadataLifecycle indicates whether the peer should use the deprecated and problematic
 	// v1.0/v1.1 lifecycle, or whether it should use the newer per channel peer local chaincode
 	// metadata package approach planned for release with Fabric v1.2
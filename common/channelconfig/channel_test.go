@@ -55,6 +55,12 @@ func TestHashingAlgorithm(t *testing.T) {
 
 	assert.Equal(t, reflect.ValueOf(util.ComputeSHA3256).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
 		"Unexpected hashing algorithm returned")
+
+	cc = &ChannelConfig{protos: &ChannelProtos{HashingAlgorithm: &cb.HashingAlgorithm{Name: bccsp.SM3}}}
+	assert.NoError(t, cc.validateHashingAlgorithm(), "Allowed hashing algorith SM3 supplied")
+
+	assert.Equal(t, reflect.ValueOf(util.ComputeSM3).Pointer(), reflect.ValueOf(cc.HashingAlgorithm()).Pointer(),
+		"Unexpected hashing algorithm returned")
 }
 
 func TestBlockDataHashingStructure(t *testing.T) {
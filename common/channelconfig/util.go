@@ -69,13 +69,19 @@ func ConsortiumValue(name string) *StandardConfigValue {
 	}
 }
 
-// HashingAlgorithm returns the only currently valid hashing algorithm.
+// HashingAlgorithmValue returns the default hashing algorithm.
 // It is a value for the /Channel group.
 func HashingAlgorithmValue() *StandardConfigValue {
+	return NamedHashingAlgorithmValue(defaultHashingAlgorithm)
+}
+
+// NamedHashingAlgorithmValue returns the hashing algorithm with the given name,
+// one of SHA256, SHA3_256 or SM3. It is a value for the /Channel group.
+func NamedHashingAlgorithmValue(name string) *StandardConfigValue {
 	return &StandardConfigValue{
 		key: HashingAlgorithmKey,
 		value: &cb.HashingAlgorithm{
-			Name: defaultHashingAlgorithm,
+			Name: name,
 		},
 	}
 }
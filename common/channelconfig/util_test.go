@@ -31,6 +31,7 @@ func basicTest(t *testing.T, sv *StandardConfigValue) {
 func TestUtilsBasic(t *testing.T) {
 	basicTest(t, ConsortiumValue("foo"))
 	basicTest(t, HashingAlgorithmValue())
+	basicTest(t, NamedHashingAlgorithmValue("SM3"))
 	basicTest(t, BlockDataHashingStructureValue())
 	basicTest(t, OrdererAddressesValue([]string{"foo:1", "bar:2"}))
 	basicTest(t, ConsensusTypeValue("foo"))
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package frost
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+)
+
+// This file implements the element and scalar encodings of the
+// FROST(Ed25519, SHA-512) ciphersuite (RFC 9591, section 6.5) on top of
+// the constant time edwards25519 group of filippo.io/edwards25519.
+
+// scalarMinusOne is L - 1, where L is the order of the prime subgroup
+var scalarMinusOne = new(edwards25519.Scalar).Negate(identifierScalar(1))
+
+// decodePoint decodes the canonical encoding of an element of the prime order
+// subgroup, other than the identity. The elements of small order, or with a
+// small order component, are refused as they would let a participant bias the
+// group commitment or forge signature shares.
+func decodePoint(enc []byte) (*edwards25519.Point, error) {
+	if len(enc) != 32 {
+		return nil, errors.Errorf("invalid point encoding length %d", len(enc))
+	}
+	P, err := new(edwards25519.Point).SetBytes(enc)
+	if err != nil || !bytes.Equal(P.Bytes(), enc) {
+		return nil, errors.New("invalid point encoding")
+	}
+	if P.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, errors.New("invalid point, it is the identity element")
+	}
+	// [L]P = [L-1]P + P is the identity only if P belongs to the prime order
+	// subgroup. Since the cofactor and L are coprime, it also refuses the
+	// points of small order.
+	LP := new(edwards25519.Point).ScalarMult(scalarMinusOne, P)
+	if LP.Add(LP, P).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return nil, errors.New("invalid point, it is not in the prime order subgroup")
+	}
+	return P, nil
+}
+
+// decodeScalar decodes a canonical 32 bytes little-endian scalar
+func decodeScalar(enc []byte) (*edwards25519.Scalar, error) {
+	if len(enc) != 32 {
+		return nil, errors.Errorf("invalid scalar encoding length %d", len(enc))
+	}
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(enc)
+	if err != nil {
+		return nil, errors.New("invalid scalar encoding")
+	}
+	return s, nil
+}
+
+// scalarFromHash reduces a 64 bytes little-endian hash modulo the group order
+func scalarFromHash(h []byte) *edwards25519.Scalar {
+	s, err := new(edwards25519.Scalar).SetUniformBytes(h)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// identifierScalar returns the scalar of the identifier of a participant
+func identifierScalar(identifier uint16) *edwards25519.Scalar {
+	enc := make([]byte, 32)
+	binary.LittleEndian.PutUint16(enc, identifier)
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(enc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,401 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package frost implements FROST(Ed25519, SHA-512), the two-round threshold
+// Schnorr signature scheme of RFC 9591. The signatures produced by t out of
+// the n holders of the shares of a key are standard Ed25519 signatures,
+// hence they are verified as any other signature of the key.
+package frost
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"io"
+	"sort"
+
+	"filippo.io/edwards25519"
+	"github.com/pkg/errors"
+)
+
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// VerificationShare is the public counterpart of the secret share of a participant
+type VerificationShare struct {
+	Identifier  uint16 `json:"identifier"`
+	PublicShare []byte `json:"public_share"`
+}
+
+// PublicKeyPackage contains the public information about the shares of a key
+type PublicKeyPackage struct {
+	// Threshold is the number of participants required to sign
+	Threshold int `json:"threshold"`
+	// GroupPublicKey is the Ed25519 public key the signatures are verified with
+	GroupPublicKey ed25519.PublicKey `json:"group_public_key"`
+	// VerificationShares are the public shares of all the participants
+	VerificationShares []VerificationShare `json:"verification_shares"`
+}
+
+// KeyShare is the share of a key held by a participant
+type KeyShare struct {
+	// Identifier identifies the participant, starting from 1
+	Identifier uint16 `json:"identifier"`
+	// SecretShare is the secret share of the participant
+	SecretShare []byte `json:"secret_share"`
+	PublicKeyPackage
+}
+
+// Nonces are the secret nonces a participant generates in the first
+// round of a signing session. They must be used to sign only once.
+type Nonces struct {
+	Identifier uint16 `json:"identifier"`
+	Hiding     []byte `json:"hiding"`
+	Binding    []byte `json:"binding"`
+}
+
+// Commitment is the public commitment to the nonces of a participant
+type Commitment struct {
+	Identifier uint16 `json:"identifier"`
+	Hiding     []byte `json:"hiding"`
+	Binding    []byte `json:"binding"`
+}
+
+// SigningPackage is the message to sign along with
+// the commitments of the participants signing it
+type SigningPackage struct {
+	Message     []byte       `json:"message"`
+	Commitments []Commitment `json:"commitments"`
+}
+
+// SignatureShare is the share of a signature computed by a participant
+type SignatureShare struct {
+	Identifier uint16 `json:"identifier"`
+	Share      []byte `json:"share"`
+}
+
+// SplitKey splits an Ed25519 private key into n shares, any threshold of which are
+// required to sign. The private key should be destroyed once the shares are distributed.
+func SplitKey(priv ed25519.PrivateKey, threshold, n int, rand io.Reader) ([]*KeyShare, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid Ed25519 private key")
+	}
+	if threshold < 2 || threshold > n {
+		return nil, errors.Errorf("invalid threshold %d, it must be at least 2 and at most the number of shares %d", threshold, n)
+	}
+	if n > 1<<16-1 {
+		return nil, errors.Errorf("too many shares %d", n)
+	}
+
+	// The secret scalar of an Ed25519 key is derived from its seed (RFC 8032, section 5.1.5)
+	h := sha512.Sum512(priv.Seed())
+	secret, err := new(edwards25519.Scalar).SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed deriving the secret scalar")
+	}
+
+	// f(x) = secret + a_1 x + ... + a_{t-1} x^{t-1}
+	coefficients := []*edwards25519.Scalar{secret}
+	for i := 1; i < threshold; i++ {
+		a, err := randomScalar(rand)
+		if err != nil {
+			return nil, err
+		}
+		coefficients = append(coefficients, a)
+	}
+
+	pub := PublicKeyPackage{
+		Threshold:      threshold,
+		GroupPublicKey: ed25519.PublicKey(new(edwards25519.Point).ScalarBaseMult(secret).Bytes()),
+	}
+	if !bytes.Equal(pub.GroupPublicKey, priv.Public().(ed25519.PublicKey)) {
+		return nil, errors.New("invalid Ed25519 private key")
+	}
+
+	secretShares := make([]*edwards25519.Scalar, n)
+	for i := 1; i <= n; i++ {
+		x := identifierScalar(uint16(i))
+		y := edwards25519.NewScalar()
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			y.MultiplyAdd(y, x, coefficients[j])
+		}
+		secretShares[i-1] = y
+		pub.VerificationShares = append(pub.VerificationShares, VerificationShare{
+			Identifier:  uint16(i),
+			PublicShare: new(edwards25519.Point).ScalarBaseMult(y).Bytes(),
+		})
+	}
+
+	shares := make([]*KeyShare, n)
+	for i := range secretShares {
+		shares[i] = &KeyShare{
+			Identifier:       uint16(i + 1),
+			SecretShare:      secretShares[i].Bytes(),
+			PublicKeyPackage: pub,
+		}
+	}
+	return shares, nil
+}
+
+// Validate checks that the secret share matches the verification share of the participant
+func (ks *KeyShare) Validate() error {
+	secret, err := decodeScalar(ks.SecretShare)
+	if err != nil {
+		return errors.WithMessage(err, "invalid secret share")
+	}
+	publicShare, err := ks.verificationShare(ks.Identifier)
+	if err != nil {
+		return err
+	}
+	if new(edwards25519.Point).ScalarBaseMult(secret).Equal(publicShare) != 1 {
+		return errors.Errorf("the secret share doesn't match the verification share of participant %d", ks.Identifier)
+	}
+	return nil
+}
+
+func (pub *PublicKeyPackage) verificationShare(identifier uint16) (*edwards25519.Point, error) {
+	for _, vs := range pub.VerificationShares {
+		if vs.Identifier == identifier {
+			return decodePoint(vs.PublicShare)
+		}
+	}
+	return nil, errors.Errorf("participant %d is unknown", identifier)
+}
+
+// Commit generates the nonces of a participant for a signing session,
+// along with their commitment to be sent to the coordinator of the session.
+func Commit(ks *KeyShare, rand io.Reader) (*Nonces, *Commitment, error) {
+	secret, err := decodeScalar(ks.SecretShare)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "invalid secret share")
+	}
+
+	hiding, err := generateNonce(secret, rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	binding, err := generateNonce(secret, rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonces := &Nonces{Identifier: ks.Identifier, Hiding: hiding.Bytes(), Binding: binding.Bytes()}
+	commitment := &Commitment{
+		Identifier: ks.Identifier,
+		Hiding:     new(edwards25519.Point).ScalarBaseMult(hiding).Bytes(),
+		Binding:    new(edwards25519.Point).ScalarBaseMult(binding).Bytes(),
+	}
+	return nonces, commitment, nil
+}
+
+// Sign computes the signature share of a participant, using the nonces it committed to
+func Sign(ks *KeyShare, nonces *Nonces, pkg *SigningPackage) (*SignatureShare, error) {
+	if nonces.Identifier != ks.Identifier {
+		return nil, errors.Errorf("the nonces belong to participant %d, not to participant %d", nonces.Identifier, ks.Identifier)
+	}
+	secret, err := decodeScalar(ks.SecretShare)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid secret share")
+	}
+	hiding, err := decodeScalar(nonces.Hiding)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid hiding nonce")
+	}
+	binding, err := decodeScalar(nonces.Binding)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid binding nonce")
+	}
+
+	session, err := newSession(&ks.PublicKeyPackage, pkg)
+	if err != nil {
+		return nil, err
+	}
+	commitment, exists := session.commitments[ks.Identifier]
+	if !exists {
+		return nil, errors.Errorf("participant %d is not part of the signing session", ks.Identifier)
+	}
+	if new(edwards25519.Point).ScalarBaseMult(hiding).Equal(commitment.hiding) != 1 ||
+		new(edwards25519.Point).ScalarBaseMult(binding).Equal(commitment.binding) != 1 {
+		return nil, errors.New("the nonces don't match the commitment in the signing package")
+	}
+
+	// z_i = d_i + e_i * rho_i + lambda_i * s_i * c
+	z := new(edwards25519.Scalar).MultiplyAdd(binding, session.bindingFactors[ks.Identifier], hiding)
+	lsc := new(edwards25519.Scalar).Multiply(session.lagrangeCoefficient(ks.Identifier), secret)
+	z.MultiplyAdd(lsc, session.challenge, z)
+
+	return &SignatureShare{Identifier: ks.Identifier, Share: z.Bytes()}, nil
+}
+
+// Aggregate verifies the signature shares of all the participants of the signing
+// session and combines them into an Ed25519 signature of the message
+func Aggregate(pub *PublicKeyPackage, pkg *SigningPackage, shares []*SignatureShare) ([]byte, error) {
+	session, err := newSession(pub, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	z := edwards25519.NewScalar()
+	signed := make(map[uint16]bool)
+	for _, share := range shares {
+		if signed[share.Identifier] {
+			return nil, errors.Errorf("duplicate signature share of participant %d", share.Identifier)
+		}
+		signed[share.Identifier] = true
+
+		commitment, exists := session.commitments[share.Identifier]
+		if !exists {
+			return nil, errors.Errorf("participant %d is not part of the signing session", share.Identifier)
+		}
+		zi, err := decodeScalar(share.Share)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid signature share")
+		}
+		publicShare, err := pub.verificationShare(share.Identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		// z_i * G == D_i + rho_i * E_i + (c * lambda_i) * Y_i
+		commitmentShare := new(edwards25519.Point).ScalarMult(session.bindingFactors[share.Identifier], commitment.binding)
+		commitmentShare.Add(commitmentShare, commitment.hiding)
+		cl := new(edwards25519.Scalar).Multiply(session.challenge, session.lagrangeCoefficient(share.Identifier))
+		expected := new(edwards25519.Point).ScalarMult(cl, publicShare)
+		expected.Add(expected, commitmentShare)
+		if new(edwards25519.Point).ScalarBaseMult(zi).Equal(expected) != 1 {
+			return nil, errors.Errorf("the signature share of participant %d is invalid", share.Identifier)
+		}
+
+		z.Add(z, zi)
+	}
+	if len(signed) != len(session.commitments) {
+		return nil, errors.Errorf("%d signature shares were provided, but %d participants committed", len(signed), len(session.commitments))
+	}
+
+	signature := append(session.groupCommitment.Bytes(), z.Bytes()...)
+	if !ed25519.Verify(pub.GroupPublicKey, pkg.Message, signature) {
+		return nil, errors.New("the aggregated signature is invalid")
+	}
+	return signature, nil
+}
+
+type sessionCommitment struct {
+	hiding, binding *edwards25519.Point
+}
+
+// session holds the values derived from a signing package
+type session struct {
+	identifiers     []uint16
+	commitments     map[uint16]sessionCommitment
+	bindingFactors  map[uint16]*edwards25519.Scalar
+	groupCommitment *edwards25519.Point
+	challenge       *edwards25519.Scalar
+}
+
+func newSession(pub *PublicKeyPackage, pkg *SigningPackage) (*session, error) {
+	if len(pkg.Commitments) < pub.Threshold {
+		return nil, errors.Errorf("%d participants committed, but %d are required", len(pkg.Commitments), pub.Threshold)
+	}
+	groupPublicKey, err := decodePoint(pub.GroupPublicKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid group public key")
+	}
+
+	s := &session{
+		commitments:    make(map[uint16]sessionCommitment),
+		bindingFactors: make(map[uint16]*edwards25519.Scalar),
+	}
+
+	// The commitments are encoded sorted by identifier
+	commitments := append([]Commitment(nil), pkg.Commitments...)
+	sort.Slice(commitments, func(i, j int) bool {
+		return commitments[i].Identifier < commitments[j].Identifier
+	})
+	var encodedCommitments []byte
+	for _, c := range commitments {
+		if _, exists := s.commitments[c.Identifier]; exists {
+			return nil, errors.Errorf("duplicate commitment of participant %d", c.Identifier)
+		}
+		if _, err := pub.verificationShare(c.Identifier); err != nil {
+			return nil, err
+		}
+		hiding, err := decodePoint(c.Hiding)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid hiding commitment")
+		}
+		binding, err := decodePoint(c.Binding)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid binding commitment")
+		}
+		s.identifiers = append(s.identifiers, c.Identifier)
+		s.commitments[c.Identifier] = sessionCommitment{hiding: hiding, binding: binding}
+		encodedCommitments = append(encodedCommitments, identifierScalar(c.Identifier).Bytes()...)
+		encodedCommitments = append(encodedCommitments, c.Hiding...)
+		encodedCommitments = append(encodedCommitments, c.Binding...)
+	}
+
+	// rho_i = H1(PK || H4(msg) || H5(commitments) || i)
+	msgHash := hash("msg", pkg.Message)
+	commitmentsHash := hash("com", encodedCommitments)
+	prefix := append(append(append([]byte{}, pub.GroupPublicKey...), msgHash...), commitmentsHash...)
+	s.groupCommitment = edwards25519.NewIdentityPoint()
+	for _, id := range s.identifiers {
+		rho := scalarFromHash(hash("rho", append(append([]byte{}, prefix...), identifierScalar(id).Bytes()...)))
+		s.bindingFactors[id] = rho
+		c := s.commitments[id]
+		commitmentShare := new(edwards25519.Point).ScalarMult(rho, c.binding)
+		s.groupCommitment.Add(s.groupCommitment, commitmentShare.Add(commitmentShare, c.hiding))
+	}
+
+	// c = H2(R || PK || msg), as in Ed25519
+	h := sha512.New()
+	h.Write(s.groupCommitment.Bytes())
+	h.Write(groupPublicKey.Bytes())
+	h.Write(pkg.Message)
+	s.challenge = scalarFromHash(h.Sum(nil))
+
+	return s, nil
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient of
+// the participant over the participants of the session
+func (s *session) lagrangeCoefficient(identifier uint16) *edwards25519.Scalar {
+	x := identifierScalar(identifier)
+	num, den := identifierScalar(1), identifierScalar(1)
+	for _, id := range s.identifiers {
+		if id == identifier {
+			continue
+		}
+		xj := identifierScalar(id)
+		num.Multiply(num, xj)
+		den.Multiply(den, new(edwards25519.Scalar).Subtract(xj, x))
+	}
+	return num.Multiply(num, new(edwards25519.Scalar).Invert(den))
+}
+
+// hash is the SHA-512 hash of the message, prefixed with the context string and a tag
+func hash(tag string, msg []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(contextString + tag))
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// generateNonce returns H3(random_bytes(32) || secret)
+func generateNonce(secret *edwards25519.Scalar, rand io.Reader) (*edwards25519.Scalar, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := io.ReadFull(rand, randomBytes); err != nil {
+		return nil, errors.Wrap(err, "failed generating nonce")
+	}
+	return scalarFromHash(hash("nonce", append(randomBytes, secret.Bytes()...))), nil
+}
+
+func randomScalar(rand io.Reader) (*edwards25519.Scalar, error) {
+	raw := make([]byte, 64)
+	if _, err := io.ReadFull(rand, raw); err != nil {
+		return nil, errors.Wrap(err, "failed generating random scalar")
+	}
+	return scalarFromHash(raw), nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdwards25519(t *testing.T) {
+	// Encoding round trip
+	P := new(edwards25519.Point).ScalarBaseMult(identifierScalar(12345))
+	Q, err := decodePoint(P.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, P.Equal(Q))
+	assert.Equal(t, 1, new(edwards25519.Point).ScalarMult(identifierScalar(2), P).Equal(new(edwards25519.Point).Add(P, P)))
+	assert.Equal(t, 0, new(edwards25519.Point).ScalarMult(identifierScalar(3), P).Equal(new(edwards25519.Point).Add(P, P)))
+
+	_, err = decodePoint(make([]byte, 31))
+	assert.Error(t, err)
+	_, err = decodeScalar(make([]byte, 31))
+	assert.Error(t, err)
+	// L, the order of the prime subgroup, isn't a canonical scalar
+	L, _ := hex.DecodeString("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	_, err = decodeScalar(L)
+	assert.EqualError(t, err, "invalid scalar encoding")
+
+	// The public keys of the standard library are points of the group
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	A, err := decodePoint(pub)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(pub), A.Bytes())
+	shares, err := SplitKey(priv, 2, 2, rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, pub, shares[0].GroupPublicKey)
+}
+
+// smallOrderPoints are the encodings of the points of small order
+var smallOrderPoints = []string{
+	// order 1
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	// order 2
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	// order 4
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"0000000000000000000000000000000000000000000000000000000000000080",
+	// order 8
+	"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+	"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a",
+	"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85",
+	"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa",
+}
+
+func TestDecodePoint(t *testing.T) {
+	for _, enc := range smallOrderPoints {
+		raw, err := hex.DecodeString(enc)
+		assert.NoError(t, err)
+		_, err = decodePoint(raw)
+		assert.Error(t, err, "the point %s of small order should be refused", enc)
+	}
+
+	// Points with a small order component aren't in the prime order subgroup
+	P := new(edwards25519.Point).ScalarBaseMult(identifierScalar(12345))
+	raw, _ := hex.DecodeString(smallOrderPoints[4])
+	T, err := new(edwards25519.Point).SetBytes(raw)
+	assert.NoError(t, err)
+	_, err = decodePoint(new(edwards25519.Point).Add(P, T).Bytes())
+	assert.EqualError(t, err, "invalid point, it is not in the prime order subgroup")
+
+	// Non canonical encodings are refused: p + 1 encodes y = 1 as well
+	nonCanonical, _ := hex.DecodeString("eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	_, err = decodePoint(nonCanonical)
+	assert.EqualError(t, err, "invalid point encoding")
+	// The identity with the sign bit set
+	nonCanonical, _ = hex.DecodeString("0100000000000000000000000000000000000000000000000000000000000080")
+	_, err = decodePoint(nonCanonical)
+	assert.EqualError(t, err, "invalid point encoding")
+}
+
+func TestSmallOrderCommitment(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	shares, err := SplitKey(priv, 2, 3, rand.Reader)
+	assert.NoError(t, err)
+
+	pkg := &SigningPackage{Message: []byte("hello world")}
+	var nonces []*Nonces
+	for _, share := range shares[:2] {
+		n, c, err := Commit(share, rand.Reader)
+		assert.NoError(t, err)
+		nonces = append(nonces, n)
+		pkg.Commitments = append(pkg.Commitments, *c)
+	}
+
+	// A participant adds a point of order 8 to its binding commitment
+	raw, _ := hex.DecodeString(smallOrderPoints[4])
+	T, err := new(edwards25519.Point).SetBytes(raw)
+	assert.NoError(t, err)
+	binding, err := new(edwards25519.Point).SetBytes(pkg.Commitments[1].Binding)
+	assert.NoError(t, err)
+	pkg.Commitments[1].Binding = binding.Add(binding, T).Bytes()
+
+	_, err = Sign(shares[0], nonces[0], pkg)
+	assert.EqualError(t, err, "invalid binding commitment: invalid point, it is not in the prime order subgroup")
+	_, err = Aggregate(&shares[0].PublicKeyPackage, pkg, nil)
+	assert.EqualError(t, err, "invalid binding commitment: invalid point, it is not in the prime order subgroup")
+
+	// The identity isn't a valid commitment either
+	pkg.Commitments[1].Binding = edwards25519.NewIdentityPoint().Bytes()
+	_, err = Sign(shares[0], nonces[0], pkg)
+	assert.EqualError(t, err, "invalid binding commitment: invalid point, it is the identity element")
+}
+
+func sign(t *testing.T, shares []*KeyShare, msg []byte) ([]byte, error) {
+	pkg := &SigningPackage{Message: msg}
+	var nonces []*Nonces
+	for _, share := range shares {
+		n, c, err := Commit(share, rand.Reader)
+		assert.NoError(t, err)
+		nonces = append(nonces, n)
+		pkg.Commitments = append(pkg.Commitments, *c)
+	}
+
+	var signatureShares []*SignatureShare
+	for i, share := range shares {
+		s, err := Sign(share, nonces[i], pkg)
+		if err != nil {
+			return nil, err
+		}
+		signatureShares = append(signatureShares, s)
+	}
+	return Aggregate(&shares[0].PublicKeyPackage, pkg, signatureShares)
+}
+
+func TestThresholdSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	shares, err := SplitKey(priv, 2, 3, rand.Reader)
+	assert.NoError(t, err)
+	assert.Len(t, shares, 3)
+	for _, share := range shares {
+		assert.NoError(t, share.Validate())
+		assert.Equal(t, pub, share.GroupPublicKey)
+		assert.Equal(t, 2, share.Threshold)
+	}
+
+	// Any 2 of the 3 participants produce Ed25519 signatures of the key
+	msg := []byte("hello world")
+	for _, signers := range [][]*KeyShare{
+		{shares[0], shares[1]},
+		{shares[1], shares[2]},
+		{shares[2], shares[0]},
+		shares,
+	} {
+		signature, err := sign(t, signers, msg)
+		assert.NoError(t, err)
+		assert.True(t, ed25519.Verify(pub, msg, signature))
+	}
+
+	// A single participant can't sign
+	_, err = sign(t, shares[:1], msg)
+	assert.EqualError(t, err, "1 participants committed, but 2 are required")
+}
+
+func TestThresholdSignatureFailures(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	_, err = SplitKey(priv, 1, 3, rand.Reader)
+	assert.EqualError(t, err, "invalid threshold 1, it must be at least 2 and at most the number of shares 3")
+	_, err = SplitKey(priv, 4, 3, rand.Reader)
+	assert.Error(t, err)
+	_, err = SplitKey(priv[:10], 2, 3, rand.Reader)
+	assert.EqualError(t, err, "invalid Ed25519 private key")
+
+	shares, err := SplitKey(priv, 2, 3, rand.Reader)
+	assert.NoError(t, err)
+
+	// A tampered share doesn't match its verification share
+	tampered := *shares[0]
+	tampered.SecretShare = shares[1].SecretShare
+	assert.EqualError(t, tampered.Validate(), "the secret share doesn't match the verification share of participant 1")
+
+	msg := []byte("hello world")
+	pkg := &SigningPackage{Message: msg}
+	nonces := make([]*Nonces, 3)
+	for i, share := range shares {
+		n, c, err := Commit(share, rand.Reader)
+		assert.NoError(t, err)
+		nonces[i] = n
+		pkg.Commitments = append(pkg.Commitments, *c)
+	}
+	signatureShares := make([]*SignatureShare, 3)
+	for i, share := range shares {
+		signatureShares[i], err = Sign(share, nonces[i], pkg)
+		assert.NoError(t, err)
+	}
+
+	// The nonces must be the ones committed to
+	_, err = Sign(shares[0], nonces[1], pkg)
+	assert.EqualError(t, err, "the nonces belong to participant 2, not to participant 1")
+	swapped := *nonces[0]
+	swapped.Hiding, swapped.Binding = nonces[0].Binding, nonces[0].Hiding
+	_, err = Sign(shares[0], &swapped, pkg)
+	assert.EqualError(t, err, "the nonces don't match the commitment in the signing package")
+
+	// All the participants that committed must sign
+	_, err = Aggregate(&shares[0].PublicKeyPackage, pkg, signatureShares[:2])
+	assert.EqualError(t, err, "2 signature shares were provided, but 3 participants committed")
+	_, err = Aggregate(&shares[0].PublicKeyPackage, pkg, append(signatureShares[:2:2], signatureShares[0]))
+	assert.EqualError(t, err, "duplicate signature share of participant 1")
+
+	// Invalid signature shares are detected
+	forged := *signatureShares[1]
+	forged.Share = signatureShares[2].Share
+	_, err = Aggregate(&shares[0].PublicKeyPackage, pkg, []*SignatureShare{signatureShares[0], &forged, signatureShares[2]})
+	assert.EqualError(t, err, "the signature share of participant 2 is invalid")
+
+	// Signature shares are bound to the message
+	other := &SigningPackage{Message: []byte("goodbye"), Commitments: pkg.Commitments}
+	_, err = Aggregate(&shares[0].PublicKeyPackage, other, signatureShares)
+	assert.Error(t, err)
+
+	// Participants that didn't commit can't sign
+	pkg.Commitments = pkg.Commitments[:2]
+	_, err = Sign(shares[2], nonces[2], pkg)
+	assert.EqualError(t, err, "participant 3 is not part of the signing session")
+
+	// Commitments must be of known and distinct participants
+	pkg.Commitments = append(pkg.Commitments, pkg.Commitments[0])
+	_, err = Sign(shares[0], nonces[0], pkg)
+	assert.EqualError(t, err, "duplicate commitment of participant 1")
+	pkg.Commitments[2].Identifier = 42
+	_, err = Sign(shares[0], nonces[0], pkg)
+	assert.EqualError(t, err, "participant 42 is unknown")
+
+	signature, err := Aggregate(&shares[0].PublicKeyPackage, &SigningPackage{Message: msg, Commitments: pkg.Commitments[:2]}, signatureShares[:2])
+	assert.Error(t, err)
+	assert.Nil(t, signature)
+}
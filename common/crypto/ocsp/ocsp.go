@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ocsp verifies the OCSP responses (RFC 6960) parsed by
+// golang.org/x/crypto/ocsp. On top of it, it verifies the responses that
+// issuers sign with SM2, which the x509 package can't verify, and requires
+// delegated responders to be authorized to sign OCSP responses.
+package ocsp
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"golang.org/x/crypto/ocsp"
+)
+
+// ParseResponse parses the DER-encoded OCSP response about the status of cert,
+// and verifies that it's signed either by issuer, or by a delegated responder
+// certified by issuer. The certificates of delegated responders must be
+// parsable by the x509 package, therefore they can't hold SM2 keys.
+func ParseResponse(der []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	if cert == nil || issuer == nil {
+		return nil, errors.New("ocsp: nil certificate or issuer")
+	}
+
+	if _, isSM2 := issuer.PublicKey.(*sm2.PublicKey); !isSM2 {
+		resp, err := ocsp.ParseResponseForCert(der, cert, issuer)
+		if err != nil {
+			return nil, err
+		}
+		return resp, checkResponder(resp, issuer)
+	}
+
+	// Without the issuer, only the signature from an embedded responder
+	// certificate is verified, the signature of the issuer is verified here
+	resp, err := ocsp.ParseResponseForCert(der, cert, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Certificate != nil {
+		if err := sm2.CheckSignatureFrom(resp.Certificate, issuer); err != nil {
+			return nil, errors.Wrap(err, "ocsp: the responder certificate is not issued by the issuer")
+		}
+	} else if err := sm2.CheckSignature(issuer.PublicKey, resp.TBSResponseData, resp.Signature); err != nil {
+		return nil, errors.Wrap(err, "ocsp: bad signature on OCSP response")
+	}
+	return resp, checkResponder(resp, issuer)
+}
+
+// checkResponder checks that a delegated responder is authorized to sign OCSP responses
+func checkResponder(resp *ocsp.Response, issuer *x509.Certificate) error {
+	if resp.Certificate == nil || resp.Certificate.Equal(issuer) {
+		return nil
+	}
+	for _, usage := range resp.Certificate.ExtKeyUsage {
+		if usage == x509.ExtKeyUsageOCSPSigning {
+			return nil
+		}
+	}
+	return errors.New("ocsp: the responder certificate is not authorized to sign OCSP responses")
+}
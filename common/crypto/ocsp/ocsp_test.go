@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ocsp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, cn string, isCA bool, extKeyUsage []x509.ExtKeyUsage, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           extKeyUsage,
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func TestParseResponse(t *testing.T) {
+	ca := newTestCert(t, "ca", true, nil, nil)
+	leaf := newTestCert(t, "leaf", false, nil, ca)
+	otherLeaf := newTestCert(t, "other", false, nil, ca)
+	responder := newTestCert(t, "responder", false, []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}, ca)
+	notResponder := newTestCert(t, "not-responder", false, nil, ca)
+	otherCA := newTestCert(t, "ca", true, nil, nil)
+
+	now := time.Now().Truncate(time.Second)
+	template := ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: leaf.cert.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(time.Hour),
+	}
+
+	// Signed by the issuer
+	der, err := ocsp.CreateResponse(ca.cert, ca.cert, template, ca.key)
+	assert.NoError(t, err)
+	resp, err := ParseResponse(der, leaf.cert, ca.cert)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.Good, resp.Status)
+	assert.Equal(t, leaf.cert.SerialNumber, resp.SerialNumber)
+	assert.Nil(t, resp.Certificate)
+
+	// The response doesn't tell about other certificates
+	_, err = ParseResponse(der, otherLeaf.cert, ca.cert)
+	assert.Error(t, err)
+
+	// The response must be signed by the issuer
+	_, err = ParseResponse(der, leaf.cert, otherCA.cert)
+	assert.Error(t, err)
+
+	// Signed by a delegated responder
+	template.Status = ocsp.Revoked
+	template.RevokedAt = now.Add(-time.Minute)
+	template.Certificate = responder.cert
+	der, err = ocsp.CreateResponse(ca.cert, responder.cert, template, responder.key)
+	assert.NoError(t, err)
+	resp, err = ParseResponse(der, leaf.cert, ca.cert)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, resp.Status)
+	assert.True(t, responder.cert.Equal(resp.Certificate))
+
+	// The delegated responder must be authorized to sign OCSP responses
+	template.Certificate = notResponder.cert
+	der, err = ocsp.CreateResponse(ca.cert, notResponder.cert, template, notResponder.key)
+	assert.NoError(t, err)
+	_, err = ParseResponse(der, leaf.cert, ca.cert)
+	assert.EqualError(t, err, "ocsp: the responder certificate is not authorized to sign OCSP responses")
+
+	// Bad input
+	_, err = ParseResponse(der, nil, ca.cert)
+	assert.EqualError(t, err, "ocsp: nil certificate or issuer")
+	_, err = ParseResponse(ocsp.TryLaterErrorResponse, leaf.cert, ca.cert)
+	assert.Equal(t, ocsp.ResponseError{Status: ocsp.TryLater}, err)
+}
+
+func TestParseSM2Response(t *testing.T) {
+	key, err := sm2.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := sm2.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	ca, err := sm2.ParseCertificate(der)
+	assert.NoError(t, err)
+	leaf := &x509.Certificate{SerialNumber: big.NewInt(2)}
+
+	now := time.Now().Truncate(time.Second)
+	resp, err := ParseResponse(sm2SignedResponse(t, ca, key, ocsp.Response{
+		Status:       ocsp.Revoked,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(time.Hour),
+		RevokedAt:    now,
+	}), leaf, ca)
+	assert.NoError(t, err)
+	assert.Equal(t, ocsp.Revoked, resp.Status)
+
+	// The response must be signed by the SM2 key of the issuer
+	otherKey, err := sm2.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, err = ParseResponse(sm2SignedResponse(t, ca, otherKey, ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: leaf.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(time.Hour),
+	}), leaf, ca)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ocsp: bad signature on OCSP response")
+}
+
+// sm2SignedResponse returns the response with the given template signed by
+// the SM2 key. x/crypto/ocsp can't sign with SM2, therefore the response is
+// signed with a throwaway ECDSA key, and then signed again with the SM2 key.
+func sm2SignedResponse(t *testing.T, issuer *x509.Certificate, key *sm2.PrivateKey, template ocsp.Response) []byte {
+	throwawayKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := ocsp.CreateResponse(issuer, issuer, template, throwawayKey)
+	assert.NoError(t, err)
+
+	var resp struct {
+		Status   asn1.Enumerated
+		Response struct {
+			ResponseType asn1.ObjectIdentifier
+			Response     []byte
+		} `asn1:"explicit,tag:0"`
+	}
+	_, err = asn1.Unmarshal(der, &resp)
+	assert.NoError(t, err)
+	var basicResp struct {
+		TBSResponseData    asn1.RawValue
+		SignatureAlgorithm pkix.AlgorithmIdentifier
+		Signature          asn1.BitString
+	}
+	_, err = asn1.Unmarshal(resp.Response.Response, &basicResp)
+	assert.NoError(t, err)
+
+	signature, err := key.Sign(rand.Reader, basicResp.TBSResponseData.FullBytes, nil)
+	assert.NoError(t, err)
+	basicResp.SignatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: sm2.OIDSignatureSM2WithSM3}
+	basicResp.Signature = asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)}
+	resp.Response.Response, err = asn1.Marshal(basicResp)
+	assert.NoError(t, err)
+	der, err = asn1.Marshal(resp)
+	assert.NoError(t, err)
+	return der
+}
@@ -282,7 +282,12 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		block2send := block
+		if seekInfo.ContentType == ab.SeekInfo_HEADER_WITH_SIG {
+			block2send = &cb.Block{Header: block.Header, Metadata: block.Metadata}
+		}
+
+		if err := srv.SendBlockResponse(block2send); err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return err
 		}
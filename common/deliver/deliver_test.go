@@ -273,6 +273,29 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when seek info requests only the headers of the blocks", func() {
+			BeforeEach(func() {
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Data:     &cb.BlockData{Data: [][]byte{[]byte("tx")}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}, cb.Status_SUCCESS)
+				seekInfo = &ab.SeekInfo{Start: &ab.SeekPosition{}, Stop: seekOldest, ContentType: ab.SeekInfo_HEADER_WITH_SIG}
+			})
+
+			It("sends the blocks without their data", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b).To(Equal(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}))
+			})
+		})
+
 		Context("when seek info is configured to stop at the oldest block", func() {
 			BeforeEach(func() {
 				seekInfo = &ab.SeekInfo{Start: &ab.SeekPosition{}, Stop: seekOldest}
@@ -34,6 +34,14 @@ func NewSigner() crypto.LocalSigner {
 	return &mspSigner{}
 }
 
+// NewSignerSupport returns a new instance of the msp-based SignerSupport,
+// which signs with, and serializes, the current default signing identity of
+// the local msp, so that it follows the reloads of the latter.
+// It assumes that the local msp has been already initialized.
+func NewSignerSupport() crypto.SignerSupport {
+	return &mspSigner{}
+}
+
 // NewSignatureHeader creates a SignatureHeader with the correct signing identity and a valid nonce
 func (s *mspSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
 	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
@@ -72,3 +80,13 @@ func (s *mspSigner) Sign(message []byte) ([]byte, error) {
 
 	return signature, nil
 }
+
+// Serialize returns the serialized default signing identity of the local msp
+func (s *mspSigner) Serialize() ([]byte, error) {
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
+	}
+
+	return signer.Serialize()
+}
@@ -62,3 +62,16 @@ func TestMspSigner_Sign(t *testing.T) {
 	err = mspIdentity.Verify(msg, sigma)
 	assert.NoError(t, err, "Failed verifiing signature")
 }
+
+func TestMspSigner_Serialize(t *testing.T) {
+	signer := NewSignerSupport()
+
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err, "Failed serializing signer")
+
+	mspIdentity, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	assert.NoError(t, err, "Failed getting default MSP Identity")
+	identityRaw, err := mspIdentity.Serialize()
+	assert.NoError(t, err, "Failed serializing default identity")
+	assert.Equal(t, identityRaw, serialized, "Signer must serialize the local default signer identity")
+}
@@ -9,7 +9,7 @@ package metadata
 // Variables defined by the Makefile and passed in with ldflags
 var Version string = "latest"
 var CommitSHA string = "development build"
-var BaseVersion string = "0.4.8"
+var BaseVersion string = "0.4.20"
 var BaseDockerLabel string = "org.hyperledger.fabric"
 var DockerNamespace string = "hyperledger"
 var BaseDockerNamespace string = "hyperledger"
@@ -13,6 +13,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/uber-go/tally"
+	promreporter "github.com/uber-go/tally/prometheus"
 )
 
 const (
@@ -145,9 +146,17 @@ func (g *noOpGauge) Update(v float64) {
 
 }
 
+type noOpHistogram struct {
+}
+
+func (h *noOpHistogram) RecordDuration(v time.Duration) {
+
+}
+
 type noOpScope struct {
-	counter *noOpCounter
-	gauge   *noOpGauge
+	counter   *noOpCounter
+	gauge     *noOpGauge
+	histogram *noOpHistogram
 }
 
 func (s *noOpScope) Counter(name string) Counter {
@@ -158,6 +167,10 @@ func (s *noOpScope) Gauge(name string) Gauge {
 	return s.gauge
 }
 
+func (s *noOpScope) Histogram(name string, buckets []time.Duration) Histogram {
+	return s.histogram
+}
+
 func (s *noOpScope) Tagged(tags map[string]string) Scope {
 	return s
 }
@@ -176,8 +189,9 @@ func (s *noOpScope) Start() error {
 
 func newNoOpScope() Scope {
 	return &noOpScope{
-		counter: &noOpCounter{},
-		gauge:   &noOpGauge{},
+		counter:   &noOpCounter{},
+		gauge:     &noOpGauge{},
+		histogram: &noOpHistogram{},
 	}
 }
 
@@ -198,12 +212,15 @@ func create(opts Opts) (rootScope Scope, e error) {
 
 		var reporter tally.StatsReporter
 		var cachedReporter tally.CachedStatsReporter
+		separator := tally.DefaultSeparator
 		if opts.Reporter == statsdReporterType {
 			reporter, e = newStatsdReporter(opts.StatsdReporterOpts)
 		}
 
 		if opts.Reporter == promReporterType {
 			cachedReporter, e = newPromReporter(opts.PromReporterOpts)
+			// prometheus metric names may not contain dots
+			separator = promreporter.DefaultSeparator
 		}
 
 		if e != nil {
@@ -213,6 +230,7 @@ func create(opts Opts) (rootScope Scope, e error) {
 		rootScope = newRootScope(
 			tally.ScopeOptions{
 				Prefix:         namespace,
+				Separator:      separator,
 				Reporter:       reporter,
 				CachedReporter: cachedReporter,
 			}, opts.Interval)
@@ -52,6 +52,23 @@ func TestStartSuccessProm(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPromMetricNames(t *testing.T) {
+	t.Parallel()
+	opts := Opts{
+		Enabled:  true,
+		Reporter: promReporterType,
+		Interval: 1 * time.Second,
+		PromReporterOpts: PromReporterOpts{
+			ListenAddress: "127.0.0.1:0",
+		}}
+	s, err := create(opts)
+	assert.NoError(t, err)
+	defer s.Close()
+	assert.NotPanics(t, func() {
+		s.SubScope("bccsp_remote").Tagged(map[string]string{"operation": "sign"}).Counter("requests").Inc(1)
+	})
+}
+
 func TestStartDisabled(t *testing.T) {
 	t.Parallel()
 	opts := Opts{
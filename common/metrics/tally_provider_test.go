@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"strings"
@@ -53,11 +54,13 @@ func (m *testFloatValue) ReportGauge(value float64) {
 type testStatsReporter struct {
 	cg sync.WaitGroup
 	gg sync.WaitGroup
+	hg sync.WaitGroup
 
 	scope Scope
 
-	counters map[string]*testIntValue
-	gauges   map[string]*testFloatValue
+	counters   map[string]*testIntValue
+	gauges     map[string]*testFloatValue
+	histograms map[string]map[time.Duration]int64
 
 	flushes int32
 }
@@ -65,8 +68,9 @@ type testStatsReporter struct {
 // newTestStatsReporter returns a new TestStatsReporter
 func newTestStatsReporter() *testStatsReporter {
 	return &testStatsReporter{
-		counters: make(map[string]*testIntValue),
-		gauges:   make(map[string]*testFloatValue)}
+		counters:   make(map[string]*testIntValue),
+		gauges:     make(map[string]*testFloatValue),
+		histograms: make(map[string]map[time.Duration]int64)}
 }
 
 func (r *testStatsReporter) WaitAll() {
@@ -151,7 +155,11 @@ func (r *testStatsReporter) ReportHistogramDurationSamples(
 	bucketUpperBound time.Duration,
 	samples int64,
 ) {
-
+	if r.histograms[name] == nil {
+		r.histograms[name] = make(map[time.Duration]int64)
+	}
+	r.histograms[name][bucketUpperBound] = samples
+	r.hg.Done()
 }
 
 func (r *testStatsReporter) Capabilities() tally.Capabilities {
@@ -225,6 +233,28 @@ func TestGauge(t *testing.T) {
 	assert.Equal(t, float64(1.33), r.gauges[namespace+".foo"].val)
 }
 
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	r := newTestStatsReporter()
+	opts := tally.ScopeOptions{
+		Prefix:    namespace,
+		Separator: tally.DefaultSeparator,
+		Reporter:  r}
+
+	s := newRootScope(opts, 1*time.Second)
+	go s.Start()
+	defer s.Close()
+	buckets := []time.Duration{time.Millisecond, 10 * time.Millisecond}
+	r.hg.Add(2)
+	s.Histogram("foo", buckets).RecordDuration(5 * time.Millisecond)
+	s.Histogram("foo", buckets).RecordDuration(7 * time.Millisecond)
+	s.Histogram("foo", buckets).RecordDuration(time.Second)
+	r.hg.Wait()
+
+	assert.Equal(t, int64(2), r.histograms[namespace+".foo"][10*time.Millisecond])
+	assert.Equal(t, int64(1), r.histograms[namespace+".foo"][time.Duration(math.MaxInt64)])
+}
+
 func TestMultiGaugeReport(t *testing.T) {
 	t.Parallel()
 	r := newTestStatsReporter()
@@ -43,6 +43,7 @@ type MockApplicationCapabilities struct {
 	CollectionUpgradeRv          bool
 	V1_1ValidationRv             bool
 	V1_2ValidationRv             bool
+	V1_3ValidationRv             bool
 	MetadataLifecycleRv          bool
 	KeyLevelEndorsementRv        bool
 }
@@ -75,6 +76,10 @@ func (mac *MockApplicationCapabilities) V1_2Validation() bool {
 	return mac.V1_2ValidationRv
 }
 
+func (mac *MockApplicationCapabilities) V1_3Validation() bool {
+	return mac.V1_3ValidationRv
+}
+
 func (mac *MockApplicationCapabilities) MetadataLifecycle() bool {
 	return mac.MetadataLifecycleRv
 }
@@ -147,7 +147,11 @@ func NewChannelGroup(conf *genesisconfig.Profile) (*cb.ConfigGroup, error) {
 		}
 	}
 
-	addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	if conf.HashingAlgorithm != "" {
+		addValue(channelGroup, channelconfig.NamedHashingAlgorithmValue(conf.HashingAlgorithm), channelconfig.AdminsPolicyKey)
+	} else {
+		addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	}
 	addValue(channelGroup, channelconfig.BlockDataHashingStructureValue(), channelconfig.AdminsPolicyKey)
 	addValue(channelGroup, channelconfig.OrdererAddressesValue(conf.Orderer.Addresses), ordererAdminsPolicyName)
 
@@ -224,6 +224,18 @@ func TestNewChannelGroup(t *testing.T) {
 		assert.NotNil(t, group)
 	})
 
+	t.Run("Hashing algorithm", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+		config.HashingAlgorithm = "SM3"
+		group, err := NewChannelGroup(config)
+		assert.NoError(t, err)
+		assert.NotNil(t, group)
+		hashingAlgorithm := &cb.HashingAlgorithm{}
+		err = proto.Unmarshal(group.Values[channelconfig.HashingAlgorithmKey].Value, hashingAlgorithm)
+		assert.NoError(t, err)
+		assert.Equal(t, "SM3", hashingAlgorithm.Name)
+	})
+
 	t.Run("Channel missing policies", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
 		config.Policies = nil
@@ -111,6 +111,14 @@ func checkPolicyPrincipals(group *cb.ConfigGroup, basePath string, mspMap map[st
 					continue
 				}
 				mspID = ou.MspIdentifier
+			case mspprotos.MSPPrincipal_ATTRIBUTES:
+				attrs := &mspprotos.MSPAttributes{}
+				err = proto.Unmarshal(identity.Principal, attrs)
+				if err != nil {
+					appendError(fmt.Sprintf("value of identities array at index %d is of type ATTRIBUTES, but could not be unmarshaled to msp.MSPAttributes: %s", i, err))
+					continue
+				}
+				mspID = attrs.MspIdentifier
 			default:
 				continue
 			}
@@ -7,12 +7,14 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"net"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/ca"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/csp"
 	"github.com/stretchr/testify/assert"
@@ -101,6 +103,83 @@ func TestNewCA(t *testing.T) {
 
 }
 
+func TestNewCAWithSM2(t *testing.T) {
+
+	caDir := filepath.Join(testDir, "ca")
+	certDir := filepath.Join(testDir, "certs")
+	defer cleanup(testDir)
+
+	rootCA, err := ca.NewCAWithKeyAlgorithm(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.SM2)
+	assert.NoError(t, err, "Error generating CA")
+	assert.IsType(t, &sm2.PublicKey{}, rootCA.SignCert.PublicKey)
+	assert.Equal(t, csp.SM2, rootCA.KeyAlgorithm())
+	assert.True(t, sm2.IsSM2SignedCert(rootCA.SignCert))
+	assert.NoError(t, sm2.CheckSignatureFrom(rootCA.SignCert, rootCA.SignCert))
+
+	// sign an SM2 certificate
+	priv, _, err := csp.GeneratePrivateKeyWithAlgorithm(certDir, csp.SM2)
+	assert.NoError(t, err, "Failed to generate private key")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, pubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.Equal(t, pubKey, cert.PublicKey)
+	assert.NoError(t, sm2.CheckSignatureFrom(cert, rootCA.SignCert))
+
+	loadedCert, err := ca.LoadCertificateECDSA(certDir)
+	assert.NoError(t, err)
+	assert.Equal(t, cert.Raw, loadedCert.Raw)
+
+	// an SM2 CA can't sign ECDSA keys
+	ecPriv, _, err := csp.GeneratePrivateKey(certDir)
+	assert.NoError(t, err, "Failed to generate private key")
+	ecPubKey, err := csp.GetECPublicKey(ecPriv)
+	assert.NoError(t, err, "Failed to get public key")
+	_, err = rootCA.SignCertificate(certDir, testName2, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.Error(t, err)
+
+	// unknown key algorithms are rejected
+	_, err = ca.NewCAWithKeyAlgorithm(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "DSA")
+	assert.Error(t, err)
+}
+
+func TestNewCAWithED25519(t *testing.T) {
+
+	caDir := filepath.Join(testDir, "ca")
+	certDir := filepath.Join(testDir, "certs")
+	defer cleanup(testDir)
+
+	rootCA, err := ca.NewCAWithKeyAlgorithm(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+	assert.IsType(t, ed25519.PublicKey{}, rootCA.SignCert.PublicKey)
+	assert.Equal(t, csp.ED25519, rootCA.KeyAlgorithm())
+	assert.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+	assert.NoError(t, rootCA.SignCert.CheckSignatureFrom(rootCA.SignCert))
+
+	// sign an Ed25519 certificate
+	priv, _, err := csp.GeneratePrivateKeyWithAlgorithm(certDir, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate private key")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err, "Failed to get public key")
+	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, pubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.Equal(t, pubKey, cert.PublicKey)
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+
+	// an Ed25519 CA can sign ECDSA keys as well
+	ecPriv, _, err := csp.GeneratePrivateKey(certDir)
+	assert.NoError(t, err, "Failed to generate private key")
+	ecPubKey, err := csp.GetECPublicKey(ecPriv)
+	assert.NoError(t, err, "Failed to get public key")
+	cert, err = rootCA.SignCertificate(certDir, testName2, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	assert.NoError(t, err, "Failed to generate signed certificate")
+	assert.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+}
+
 func TestGenerateSignCertificate(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
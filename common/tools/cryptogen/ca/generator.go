@@ -7,7 +7,7 @@ package ca
 
 import (
 	"crypto"
-	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/sinochem-tech/fabric/bccsp/utils"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/csp"
 )
@@ -40,17 +41,23 @@ type CA struct {
 // NewCA creates an instance of CA and saves the signing key pair in
 // baseDir/name
 func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode string) (*CA, error) {
+	return NewCAWithKeyAlgorithm(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode, csp.ECDSA)
+}
+
+// NewCAWithKeyAlgorithm creates an instance of CA whose signing key pair,
+// saved in baseDir/name, is generated with the given key algorithm
+func NewCAWithKeyAlgorithm(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode, keyAlgorithm string) (*CA, error) {
 
 	var response error
 	var ca *CA
 
 	err := os.MkdirAll(baseDir, 0755)
 	if err == nil {
-		priv, signer, err := csp.GeneratePrivateKey(baseDir)
+		priv, signer, err := csp.GeneratePrivateKeyWithAlgorithm(baseDir, keyAlgorithm)
 		response = err
 		if err == nil {
 			// get public signing certificate
-			ecPubKey, err := csp.GetECPublicKey(priv)
+			pubKey, err := csp.GetPublicKey(priv)
 			response = err
 			if err == nil {
 				template := x509Template()
@@ -69,8 +76,8 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 				template.Subject = subject
 				template.SubjectKeyId = priv.SKI()
 
-				x509Cert, err := genCertificateECDSA(baseDir, name, &template, &template,
-					ecPubKey, signer)
+				x509Cert, err := genCertificate(baseDir, name, &template, &template,
+					pubKey, signer)
 				response = err
 				if err == nil {
 					ca = &CA{
@@ -91,9 +98,24 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 	return ca, response
 }
 
+// KeyAlgorithm returns the algorithm of the signing key pair of the CA
+func (ca *CA) KeyAlgorithm() string {
+	if ca.SignCert == nil {
+		return csp.ECDSA
+	}
+	switch ca.SignCert.PublicKey.(type) {
+	case *sm2.PublicKey:
+		return csp.SM2
+	case ed25519.PublicKey:
+		return csp.ED25519
+	default:
+		return csp.ECDSA
+	}
+}
+
 // SignCertificate creates a signed certificate based on a built-in template
 // and saves it in baseDir/name
-func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub *ecdsa.PublicKey,
+func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub crypto.PublicKey,
 	ku x509.KeyUsage, eku []x509.ExtKeyUsage) (*x509.Certificate, error) {
 
 	template := x509Template()
@@ -117,7 +139,7 @@ func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub *ecd
 		}
 	}
 
-	cert, err := genCertificateECDSA(baseDir, name, &template, ca.SignCert,
+	cert, err := genCertificate(baseDir, name, &template, ca.SignCert,
 		pub, ca.Signer)
 
 	if err != nil {
@@ -184,12 +206,18 @@ func x509Template() x509.Certificate {
 
 }
 
-// generate a signed X509 certificate using ECDSA
-func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificate, pub *ecdsa.PublicKey,
-	priv interface{}) (*x509.Certificate, error) {
+// generate a signed X509 certificate using ECDSA, SM2 or Ed25519
+func genCertificate(baseDir, name string, template, parent *x509.Certificate, pub crypto.PublicKey,
+	priv crypto.Signer) (*x509.Certificate, error) {
 
 	//create the x509 public cert
-	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	var certBytes []byte
+	var err error
+	if sm2PubKey, isSM2 := pub.(*sm2.PublicKey); isSM2 {
+		certBytes, err = sm2.CreateCertificate(rand.Reader, template, parent, sm2PubKey, priv)
+	} else {
+		certBytes, err = x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -207,14 +235,14 @@ func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificat
 		return nil, err
 	}
 
-	x509Cert, err := x509.ParseCertificate(certBytes)
+	x509Cert, err := sm2.ParseCertificate(certBytes)
 	if err != nil {
 		return nil, err
 	}
 	return x509Cert, nil
 }
 
-// LoadCertificateECDSA load a ecdsa cert from a file in cert path
+// LoadCertificateECDSA load a ecdsa, sm2 or ed25519 cert from a file in cert path
 func LoadCertificateECDSA(certPath string) (*x509.Certificate, error) {
 	var cert *x509.Certificate
 	var err error
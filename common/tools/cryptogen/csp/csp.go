@@ -8,6 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
@@ -17,31 +18,72 @@ import (
 
 	"github.com/sinochem-tech/fabric/bccsp"
 	"github.com/sinochem-tech/fabric/bccsp/factory"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/sinochem-tech/fabric/bccsp/signer"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/pkg/errors"
 )
 
-// LoadPrivateKey loads a private key from file in keystorePath
-func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
-	var err error
-	var priv bccsp.Key
-	var s crypto.Signer
+const (
+	// ECDSA is the default key algorithm, using the P-256 curve
+	ECDSA = "ECDSA"
+	// SM2 is the key algorithm of the Chinese national cryptography
+	SM2 = "SM2"
+	// ED25519 is the Edwards-curve signature algorithm over Curve25519
+	ED25519 = "ED25519"
+)
+
+// keystorePassword is the password encrypting the private keys
+// generated by GeneratePrivateKeyWithAlgorithm
+var keystorePassword []byte
+
+// SetKeystorePassword sets the password encrypting the private keys generated
+// by GeneratePrivateKeyWithAlgorithm, and decrypting those loaded by
+// LoadPrivateKey. The private keys are stored in clear if it is nil.
+func SetKeystorePassword(pwd []byte) {
+	keystorePassword = pwd
+}
 
-	opts := &factory.FactoryOpts{
-		ProviderName: "SW",
-		SwOpts: &factory.SwOpts{
-			HashFamily: "SHA2",
-			SecLevel:   256,
+// getBCCSP returns a BCCSP storing its keys in keystorePath that supports
+// the given key algorithm, and encrypts them with pwd if not nil
+func getBCCSP(keystorePath, keyAlgorithm string, pwd []byte) (bccsp.BCCSP, error) {
+	var opts *factory.FactoryOpts
+	switch keyAlgorithm {
+	case ECDSA, ED25519:
+		opts = &factory.FactoryOpts{
+			ProviderName: "SW",
+			SwOpts: &factory.SwOpts{
+				HashFamily: "SHA2",
+				SecLevel:   256,
 
-			FileKeystore: &factory.FileKeystoreOpts{
-				KeyStorePath: keystorePath,
+				FileKeystore: &factory.FileKeystoreOpts{
+					KeyStorePath: keystorePath,
+					Password:     pwd,
+				},
 			},
-		},
+		}
+	case SM2:
+		opts = &factory.FactoryOpts{
+			ProviderName: "GM",
+			GmOpts: &factory.GmOpts{
+				FileKeystore: &factory.FileKeystoreOpts{
+					KeyStorePath: keystorePath,
+					Password:     pwd,
+				},
+			},
+		}
+	default:
+		return nil, errors.Errorf("unsupported key algorithm [%s]", keyAlgorithm)
 	}
 
-	csp, err := factory.GetBCCSPFromOpts(opts)
-	if err != nil {
-		return nil, nil, err
-	}
+	return factory.GetBCCSPFromOpts(opts)
+}
+
+// LoadPrivateKey loads a private key from file in keystorePath
+func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
+	var err error
+	var priv bccsp.Key
+	var s crypto.Signer
 
 	walkFunc := func(path string, info os.FileInfo, err error) error {
 		if strings.HasSuffix(path, "_sk") {
@@ -51,7 +93,37 @@ func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
 			}
 
 			block, _ := pem.Decode(rawKey)
-			priv, err = csp.KeyImport(block.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+			if block == nil {
+				return errors.Errorf("%s: wrong PEM encoding", path)
+			}
+			der := block.Bytes
+			if block.Type == "ENCRYPTED PRIVATE KEY" {
+				der, err = utils.DecryptPKCS8(block.Bytes, keystorePassword)
+				if err != nil {
+					return errors.WithMessage(err, path)
+				}
+			}
+
+			// the algorithm of the key determines the import options
+			keyAlgorithm := ECDSA
+			var opts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+			if key, err := utils.DERToPrivateKey(der); err == nil {
+				switch key.(type) {
+				case *sm2.PrivateKey:
+					keyAlgorithm = SM2
+					opts = &bccsp.SM2PrivateKeyImportOpts{Temporary: true}
+				case ed25519.PrivateKey:
+					keyAlgorithm = ED25519
+					opts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+				}
+			}
+
+			csp, err := getBCCSP(keystorePath, keyAlgorithm, nil)
+			if err != nil {
+				return err
+			}
+
+			priv, err = csp.KeyImport(der, opts)
 			if err != nil {
 				return err
 			}
@@ -74,29 +146,40 @@ func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
 	return priv, s, err
 }
 
-// GeneratePrivateKey creates a private key and stores it in keystorePath
+// GeneratePrivateKey creates an ECDSA private key and stores it in keystorePath.
+// The private key is always stored in clear, as TLS private keys must be.
 func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 	crypto.Signer, error) {
+	return generatePrivateKey(keystorePath, ECDSA, nil)
+}
+
+// GeneratePrivateKeyWithAlgorithm creates a private key for the given
+// key algorithm and stores it in keystorePath, encrypted with the
+// password set by SetKeystorePassword if any
+func GeneratePrivateKeyWithAlgorithm(keystorePath, keyAlgorithm string) (bccsp.Key,
+	crypto.Signer, error) {
+	return generatePrivateKey(keystorePath, keyAlgorithm, keystorePassword)
+}
+
+func generatePrivateKey(keystorePath, keyAlgorithm string, pwd []byte) (bccsp.Key,
+	crypto.Signer, error) {
 
 	var err error
 	var priv bccsp.Key
 	var s crypto.Signer
 
-	opts := &factory.FactoryOpts{
-		ProviderName: "SW",
-		SwOpts: &factory.SwOpts{
-			HashFamily: "SHA2",
-			SecLevel:   256,
-
-			FileKeystore: &factory.FileKeystoreOpts{
-				KeyStorePath: keystorePath,
-			},
-		},
+	var keyGenOpts bccsp.KeyGenOpts = &bccsp.ECDSAP256KeyGenOpts{Temporary: false}
+	switch keyAlgorithm {
+	case SM2:
+		keyGenOpts = &bccsp.SM2KeyGenOpts{Temporary: false}
+	case ED25519:
+		keyGenOpts = &bccsp.ED25519KeyGenOpts{Temporary: false}
 	}
-	csp, err := factory.GetBCCSPFromOpts(opts)
+
+	csp, err := getBCCSP(keystorePath, keyAlgorithm, pwd)
 	if err == nil {
 		// generate a key
-		priv, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+		priv, err = csp.KeyGen(keyGenOpts)
 		if err == nil {
 			// create a crypto.Signer
 			s, err = signer.New(csp, priv)
@@ -124,3 +207,70 @@ func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
 	}
 	return ecPubKey.(*ecdsa.PublicKey), nil
 }
+
+// GetPublicKey returns the public key of priv, which is
+// an *ecdsa.PublicKey, an *sm2.PublicKey or an ed25519.PublicKey
+func GetPublicKey(priv bccsp.Key) (crypto.PublicKey, error) {
+
+	// get the public key
+	pubKey, err := priv.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	// marshal to bytes
+	pubKeyBytes, err := pubKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// unmarshal using pkix
+	return utils.DERToPublicKey(pubKeyBytes)
+}
+
+// EncryptPrivateKeys encrypts the private keys stored in the keystores below
+// dir, that is the files whose name ends with _sk, with newPwd. Private keys
+// already encrypted are decrypted with oldPwd first. The private keys are
+// stored in clear if newPwd is nil. None of the private keys is rewritten
+// unless all of them are decrypted. It returns the paths of the private keys.
+func EncryptPrivateKeys(dir string, oldPwd, newPwd []byte) ([]string, error) {
+	var paths []string
+	var keys []interface{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, "_sk") {
+			return nil
+		}
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		key, err := utils.PEMtoPrivateKey(raw, oldPwd)
+		if err != nil {
+			return errors.Wrapf(err, "failed decrypting the private key %s", path)
+		}
+		paths = append(paths, path)
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, path := range paths {
+		raw, err := utils.PrivateKeyToPEM(keys[i], newPwd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed encrypting the private key %s", path)
+		}
+		// Replace the private key atomically
+		tmp := path + ".tmp"
+		if err := ioutil.WriteFile(tmp, raw, 0600); err != nil {
+			return nil, errors.Wrapf(err, "failed writing the private key %s", path)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			os.Remove(tmp)
+			return nil, errors.Wrapf(err, "failed writing the private key %s", path)
+		}
+	}
+	return paths, nil
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/sinochem-tech/fabric/bccsp/factory"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/ca"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/csp"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/metadata"
@@ -68,6 +69,7 @@ type OrgSpec struct {
 	Name          string       `yaml:"Name"`
 	Domain        string       `yaml:"Domain"`
 	EnableNodeOUs bool         `yaml:"EnableNodeOUs"`
+	KeyAlgorithm  string       `yaml:"KeyAlgorithm"`
 	CA            NodeSpec     `yaml:"CA"`
 	Template      NodeTemplate `yaml:"Template"`
 	Specs         []NodeSpec   `yaml:"Specs"`
@@ -107,6 +109,16 @@ PeerOrgs:
     Domain: org1.example.com
     EnableNodeOUs: false
 
+    # ---------------------------------------------------------------------------
+    # "KeyAlgorithm"
+    # ---------------------------------------------------------------------------
+    # The algorithm of the signing keys of the organization: ECDSA (default),
+    # SM2 or ED25519.  SM2 organizations get an MSP config.yaml selecting SM3
+    # hashing.  TLS keys are always ECDSA.  The channels of SM2 and ED25519
+    # organizations require the V1_3 channel capability.
+    # ---------------------------------------------------------------------------
+    # KeyAlgorithm: SM2
+
     # ---------------------------------------------------------------------------
     # "CA"
     # ---------------------------------------------------------------------------
@@ -210,11 +222,33 @@ var (
 	ext           = app.Command("extend", "Extend existing network")
 	inputDir      = ext.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
 	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	rekey             = app.Command("rekey", "Encrypt the private keys of existing keystores with the keystore password")
+	rekeyDir          = rekey.Flag("input", "The directory below which the keystores are").Default("crypto-config").String()
+	oldPasswordEnv    = rekey.Flag("old-keystore-password-env", "The environment variable with the current password of the keystores, if encrypted").String()
+	oldPasswordFile   = rekey.Flag("old-keystore-password-file", "The file with the current password of the keystores, if encrypted").String()
+	oldPasswordPrompt = rekey.Flag("old-keystore-password-prompt", "Prompt for the current password of the keystores, if encrypted").Bool()
+	decrypt           = rekey.Flag("decrypt", "Store the private keys in clear instead").Bool()
+
+	// The private keys of the keystores, including those of the CAs, are
+	// encrypted with the keystore password. TLS private keys never are.
+	passwordEnv    = app.Flag("keystore-password-env", "The environment variable with the password encrypting the private keys of the keystores").String()
+	passwordFile   = app.Flag("keystore-password-file", "The file with the password encrypting the private keys of the keystores").String()
+	passwordPrompt = app.Flag("keystore-password-prompt", "Prompt for the password encrypting the private keys of the keystores").Bool()
 )
 
 func main() {
 	kingpin.Version("0.0.1")
-	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	pwd, err := factory.ReadPassword(*passwordEnv, *passwordFile, *passwordPrompt, "keystores")
+	if err != nil {
+		fmt.Printf("Error reading the keystore password: %s\n", err)
+		os.Exit(1)
+	}
+	csp.SetKeystorePassword(pwd)
+
+	switch command {
 
 	// "generate" command
 	case gen.FullCommand():
@@ -223,6 +257,9 @@ func main() {
 	case ext.FullCommand():
 		extend()
 
+	case rekey.FullCommand():
+		rekeyKeystores(pwd)
+
 		// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -488,6 +525,14 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		orgSpec.Specs[idx] = spec
 	}
 
+	switch orgSpec.KeyAlgorithm {
+	case "":
+		orgSpec.KeyAlgorithm = csp.ECDSA
+	case csp.ECDSA, csp.SM2, csp.ED25519:
+	default:
+		return fmt.Errorf("unsupported key algorithm %s for organization %s", orgSpec.KeyAlgorithm, orgSpec.Name)
+	}
+
 	// Process the CA node-spec in the same manner
 	if len(orgSpec.CA.Hostname) == 0 {
 		orgSpec.CA.Hostname = "ca"
@@ -514,7 +559,7 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCAWithKeyAlgorithm(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -623,7 +668,7 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCAWithKeyAlgorithm(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -693,12 +738,41 @@ func copyFile(src, dst string) error {
 	return cerr
 }
 
+func rekeyKeystores(pwd []byte) {
+	if len(pwd) == 0 && !*decrypt {
+		fmt.Println("Error: the keystore password must be set, unless decrypting the private keys")
+		os.Exit(1)
+	}
+	if *decrypt {
+		pwd = nil
+	}
+
+	oldPwd, err := factory.ReadPassword(*oldPasswordEnv, *oldPasswordFile, *oldPasswordPrompt, "current keystores")
+	if err != nil {
+		fmt.Printf("Error reading the current keystore password: %s\n", err)
+		os.Exit(1)
+	}
+
+	paths, err := csp.EncryptPrivateKeys(*rekeyDir, oldPwd, pwd)
+	if err != nil {
+		fmt.Printf("Error encrypting the private keys below %s: %s\n", *rekeyDir, err)
+		os.Exit(1)
+	}
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
 
 func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
-	_, signer, _ := csp.LoadPrivateKey(caDir)
+	_, signer, err := csp.LoadPrivateKey(caDir)
+	if err != nil {
+		fmt.Printf("Error loading the private key of the CA in %s: %s\n", caDir, err)
+		os.Exit(1)
+	}
 	cert, _ := ca.LoadCertificateECDSA(caDir)
 
 	return &ca.CA{
@@ -60,14 +60,14 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	// get keystore path
 	keystore := filepath.Join(mspDir, "keystore")
 
-	// generate private key
-	priv, _, err := csp.GeneratePrivateKey(keystore)
+	// generate private key with the same algorithm as the signing CA
+	priv, _, err := csp.GeneratePrivateKeyWithAlgorithm(keystore, signCA.KeyAlgorithm())
 	if err != nil {
 		return err
 	}
 
 	// get public key
-	ecPubKey, err := csp.GetECPublicKey(priv)
+	pubKey, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -77,7 +77,7 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 		ous = []string{nodeOUMap[nodeType]}
 	}
 	cert, err := signCA.SignCertificate(filepath.Join(mspDir, "signcerts"),
-		name, ous, nil, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		name, ous, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	if err != nil {
 		return err
 	}
@@ -96,8 +96,9 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	}
 
 	// generate config.yaml if required
-	if nodeOUs && nodeType == PEER {
-		exportConfig(mspDir, "cacerts/"+x509Filename(signCA.Name), true)
+	err = exportConfig(mspDir, "cacerts/"+x509Filename(signCA.Name), nodeOUs && nodeType == PEER, signCA.KeyAlgorithm())
+	if err != nil {
+		return err
 	}
 
 	// the signing identity goes into admincerts.
@@ -175,8 +176,9 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs b
 	}
 
 	// generate config.yaml if required
-	if nodeOUs {
-		exportConfig(baseDir, "cacerts/"+x509Filename(signCA.Name), true)
+	err = exportConfig(baseDir, "cacerts/"+x509Filename(signCA.Name), nodeOUs, signCA.KeyAlgorithm())
+	if err != nil {
+		return err
 	}
 
 	// create a throwaway cert to act as an admin cert
@@ -186,13 +188,20 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs b
 	// of unit tests
 	factory.InitFactories(nil)
 	bcsp := factory.GetDefault()
-	priv, err := bcsp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
-	ecPubKey, err := csp.GetECPublicKey(priv)
+	var keyGenOpts bccsp.KeyGenOpts = &bccsp.ECDSAP256KeyGenOpts{Temporary: true}
+	switch signCA.KeyAlgorithm() {
+	case csp.SM2:
+		keyGenOpts = &bccsp.SM2KeyGenOpts{Temporary: true}
+	case csp.ED25519:
+		keyGenOpts = &bccsp.ED25519KeyGenOpts{Temporary: true}
+	}
+	priv, err := bcsp.KeyGen(keyGenOpts)
+	pubKey, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
 	_, err = signCA.SignCertificate(filepath.Join(baseDir, "admincerts"), signCA.Name,
-		nil, nil, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		nil, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	if err != nil {
 		return err
 	}
@@ -249,9 +258,10 @@ func pemExport(path, pemType string, bytes []byte) error {
 	return pem.Encode(file, &pem.Block{Type: pemType, Bytes: bytes})
 }
 
-func exportConfig(mspDir, caFile string, enable bool) error {
-	var config = &fabricmsp.Configuration{
-		NodeOUs: &fabricmsp.NodeOUs{
+func exportConfig(mspDir, caFile string, enable bool, keyAlgorithm string) error {
+	var config = &fabricmsp.Configuration{}
+	if enable {
+		config.NodeOUs = &fabricmsp.NodeOUs{
 			Enable: enable,
 			ClientOUIdentifier: &fabricmsp.OrganizationalUnitIdentifiersConfiguration{
 				Certificate:                  caFile,
@@ -261,7 +271,18 @@ func exportConfig(mspDir, caFile string, enable bool) error {
 				Certificate:                  caFile,
 				OrganizationalUnitIdentifier: PEEROU,
 			},
-		},
+		}
+	}
+	// SM2 identities are paired with SM3 hashing
+	if keyAlgorithm == csp.SM2 {
+		config.CryptoConfig = &fabricmsp.CryptoConfig{
+			SignatureHashFamily:            bccsp.SM3,
+			IdentityIdentifierHashFunction: bccsp.SM3,
+		}
+	}
+	if config.NodeOUs == nil && config.CryptoConfig == nil {
+		// nothing to export
+		return nil
 	}
 
 	configBytes, err := yaml.Marshal(config)
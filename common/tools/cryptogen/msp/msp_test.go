@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package msp_test
 
 import (
+	"crypto/x509"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -14,7 +15,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/ca"
+	"github.com/sinochem-tech/fabric/common/tools/cryptogen/csp"
 	"github.com/sinochem-tech/fabric/common/tools/cryptogen/msp"
 	fabricmsp "github.com/sinochem-tech/fabric/msp"
 )
@@ -127,6 +131,96 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 }
 
+func TestGenerateLocalMSPWithSM2(t *testing.T) {
+
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+
+	// generate an SM2 signing CA and an ECDSA TLS CA
+	signCA, err := ca.NewCAWithKeyAlgorithm(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.SM2)
+	assert.NoError(t, err, "Error generating CA")
+	assert.Equal(t, csp.SM2, signCA.KeyAlgorithm())
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+	assert.Equal(t, csp.ECDSA, tlsCA.KeyAlgorithm())
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, false)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	// the signing certificate holds an SM2 key, and the MSP uses SM3
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.IsType(t, &sm2.PublicKey{}, cert.PublicKey)
+	assert.Equal(t, true, checkForFile(filepath.Join(mspDir, "config.yaml")),
+		"Expected to find the MSP config.yaml")
+
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_3}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	msg := []byte("hello")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.NoError(t, id.Validate())
+}
+
+func TestGenerateLocalMSPWithED25519(t *testing.T) {
+
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	mspDir := filepath.Join(testDir, "msp")
+
+	// generate an Ed25519 signing CA and an ECDSA TLS CA
+	signCA, err := ca.NewCAWithKeyAlgorithm(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	assert.NoError(t, err, "Error generating CA")
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, false)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.Equal(t, x509.PureEd25519, cert.SignatureAlgorithm)
+
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_3}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	msg := []byte("hello")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.NoError(t, id.Validate())
+
+	// the verifying MSP can be set up as well
+	err = msp.GenerateVerifyingMSP(filepath.Join(testDir, "verifying"), signCA, tlsCA, false)
+	assert.NoError(t, err, "Failed to generate verifying MSP")
+	testMSPConfig, err = fabricmsp.GetVerifyingMspConfig(filepath.Join(testDir, "verifying"), testName, fabricmsp.ProviderTypeToString(fabricmsp.FABRIC))
+	assert.NoError(t, err, "Error parsing verifying MSP config")
+	testMSP, err = fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_3}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	assert.NoError(t, testMSP.Setup(testMSPConfig))
+}
+
 func TestGenerateVerifyingMSP(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
@@ -182,7 +276,7 @@ func TestExportConfig(t *testing.T) {
 		t.Fatalf("failed to create test directory: [%s]", err)
 	}
 
-	err = msp.ExportConfig(path, caFile, true)
+	err = msp.ExportConfig(path, caFile, true, csp.ECDSA)
 	assert.NoError(t, err)
 
 	configBytes, err := ioutil.ReadFile(configFile)
@@ -200,6 +294,24 @@ func TestExportConfig(t *testing.T) {
 	assert.Equal(t, msp.CLIENTOU, config.NodeOUs.ClientOUIdentifier.OrganizationalUnitIdentifier)
 	assert.Equal(t, caFile, config.NodeOUs.PeerOUIdentifier.Certificate)
 	assert.Equal(t, msp.PEEROU, config.NodeOUs.PeerOUIdentifier.OrganizationalUnitIdentifier)
+	assert.Nil(t, config.CryptoConfig)
+
+	err = msp.ExportConfig(path, caFile, false, csp.SM2)
+	assert.NoError(t, err)
+
+	configBytes, err = ioutil.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("failed to read config file: [%s]", err)
+	}
+
+	config = &fabricmsp.Configuration{}
+	err = yaml.Unmarshal(configBytes, config)
+	if err != nil {
+		t.Fatalf("failed to unmarshal config: [%s]", err)
+	}
+	assert.Nil(t, config.NodeOUs)
+	assert.Equal(t, bccsp.SM3, config.CryptoConfig.SignatureHashFamily)
+	assert.Equal(t, bccsp.SM3, config.CryptoConfig.IdentityIdentifierHashFunction)
 }
 
 func cleanup(dir string) {
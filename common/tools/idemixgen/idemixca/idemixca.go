@@ -43,8 +43,30 @@ func GenerateIssuerKey() ([]byte, []byte, error) {
 // It generates a fresh user secret and issues a credential
 // with four attributes (described above) using the CA's key pair.
 func GenerateSignerConfig(isAdmin bool, ouString string, enrollmentId string, revocationHandle int, key *idemix.IssuerKey, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+
+	// A CRI with "ALG_NO_REVOCATION" is created, use GenerateSignerConfigWithCRI
+	// together with a RevocationState to have the signer prove it is not revoked
+	cri, err := idemix.CreateCRI(revKey, []*FP256BN.BIG{FP256BN.NewBIGint(revocationHandle)}, 0, idemix.ALG_NO_REVOCATION, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateSignerConfigWithCRI(isAdmin, ouString, enrollmentId, revocationHandle, key, cri)
+}
+
+// GenerateSignerConfigWithCRI creates a new signer config like GenerateSignerConfig,
+// using the given credential revocation information.
+func GenerateSignerConfigWithCRI(isAdmin bool, ouString string, enrollmentId string, revocationHandle int, key *idemix.IssuerKey, cri *idemix.CredentialRevocationInformation) ([]byte, error) {
 	attrs := make([]*FP256BN.BIG, 4)
 
+	if cri == nil {
+		return nil, errors.Errorf("the credential revocation information is nil")
+	}
+
 	if ouString == "" {
 		return nil, errors.Errorf("the OU attribute value is empty")
 	}
@@ -81,11 +103,6 @@ func GenerateSignerConfig(isAdmin bool, ouString string, enrollmentId string, re
 		return nil, errors.WithMessage(err, "failed to marshal credential")
 	}
 
-	// NOTE currently, idemixca creates CRI's with "ALG_NO_REVOCATION"
-	cri, err := idemix.CreateCRI(revKey, []*FP256BN.BIG{FP256BN.NewBIGint(revocationHandle)}, 0, idemix.ALG_NO_REVOCATION, rng)
-	if err != nil {
-		return nil, err
-	}
 	criBytes, err := proto.Marshal(cri)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to marshal CRI")
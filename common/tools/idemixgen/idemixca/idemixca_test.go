@@ -70,6 +70,116 @@ func TestIdemixCa(t *testing.T) {
 	assert.EqualError(t, err, "the enrollment id value is empty")
 }
 
+func TestRevocation(t *testing.T) {
+	cleanup()
+
+	isk, ipkBytes, err := GenerateIssuerKey()
+	assert.NoError(t, err)
+	ipk := &idemix.IssuerPublicKey{}
+	assert.NoError(t, proto.Unmarshal(ipkBytes, ipk))
+	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
+
+	revocationkey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	encodedRevocationPK, err := x509.MarshalPKIXPublicKey(revocationkey.Public())
+	assert.NoError(t, err)
+	pemEncodedRevocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedRevocationPK})
+	assert.NoError(t, writeVerifierToFile(ipkBytes, pemEncodedRevocationPK))
+
+	state, err := UnmarshalRevocationState(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, state.Epoch)
+	state.AddIssued(1)
+	state.AddIssued(2)
+	state.AddIssued(1)
+	assert.Equal(t, []int{1, 2}, state.Issued)
+
+	// epoch 1: nothing is revoked
+	cri, err := state.NewEpoch(revocationkey)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.Epoch)
+	assert.Equal(t, int64(1), cri.Epoch)
+	assert.NoError(t, writeCRIToFile(cri))
+
+	conf, err := GenerateSignerConfigWithCRI(false, "OU1", "enrollmentid1", 1, key, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
+
+	// a verifier in epoch 1 accepts the identity of the signer
+	signerMSP, err := getMSP()
+	assert.NoError(t, err)
+	signer, err := signerMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	serializedID, err := signer.Serialize()
+	assert.NoError(t, err)
+	cleanupSigner()
+	verifierMSP, err := getMSP()
+	assert.NoError(t, err)
+	id, err := verifierMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	// epoch 2: handle 1 is revoked
+	assert.EqualError(t, state.Revoke(3), "no credential with revocation handle 3 was issued")
+	assert.NoError(t, state.Revoke(1))
+	assert.EqualError(t, state.Revoke(1), "revocation handle 1 is already revoked")
+
+	raw, err := state.Marshal()
+	assert.NoError(t, err)
+	state, err = UnmarshalRevocationState(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, state.Revoked)
+
+	cri, err = state.NewEpoch(revocationkey)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cri.Epoch)
+	assert.NoError(t, writeCRIToFile(cri))
+
+	// the identity of the previous epoch is no longer valid
+	verifierMSP, err = getMSP()
+	assert.NoError(t, err)
+	id, err = verifierMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	assert.Error(t, id.Validate())
+
+	// without the V1_3 capability, the revocation information of the config is ignored,
+	// and the identities with a non-revocation proof can't be validated at all, like by the
+	// peers of earlier versions
+	verifierMSP, err = getMSPWithVersion(m.MSPv1_1)
+	assert.NoError(t, err)
+	id, err = verifierMSP.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	err = id.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "revocation algorithm 1 is not supported without revocation")
+
+	// and the revoked signer cannot prove it isn't revoked in the new epoch
+	assert.NoError(t, writeSignerToFile(conf))
+	err = setupMSP()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is revoked in epoch 2")
+
+	// also by a local MSP of an earlier version, which signs with the revocation
+	// information of the config rather than with the one of the credential
+	_, err = getMSPWithVersion(m.MSPv1_1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is revoked in epoch 2")
+
+	// while the unrevoked one can
+	conf, err = GenerateSignerConfigWithCRI(false, "OU1", "enrollmentid2", 2, key, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
+
+	_, err = UnmarshalRevocationState([]byte("not json"))
+	assert.Error(t, err)
+	_, err = GenerateSignerConfigWithCRI(false, "OU1", "enrollmentid2", 2, key, nil)
+	assert.EqualError(t, err, "the credential revocation information is nil")
+}
+
 func cleanup() error {
 	// clean up any previous files
 	err := os.RemoveAll(testDir)
@@ -108,19 +218,37 @@ func writeSignerToFile(signerBytes []byte) error {
 	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirUser, m.IdemixConfigFileSigner), signerBytes, 0644)
 }
 
+func writeCRIToFile(cri *idemix.CredentialRevocationInformation) error {
+	criBytes, err := proto.Marshal(cri)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileRevocationInfo), criBytes, 0644)
+}
+
 // setupMSP tests whether we can successfully setup an idemix msp
 // with the generated config bytes
 func setupMSP() error {
-	// setup an idemix msp from the test directory
-	msp, err := m.New(&m.IdemixNewOpts{NewBaseOpts: m.NewBaseOpts{Version: m.MSPv1_1}})
+	_, err := getMSP()
+	return err
+}
+
+// getMSP sets up an idemix msp from the test directory
+func getMSP() (m.MSP, error) {
+	return getMSPWithVersion(m.MSPv1_3)
+}
+
+// getMSPWithVersion sets up an idemix msp of the given version from the test directory
+func getMSPWithVersion(version m.MSPVersion) (m.MSP, error) {
+	msp, err := m.New(&m.IdemixNewOpts{NewBaseOpts: m.NewBaseOpts{Version: version}})
 	if err != nil {
-		return errors.Wrap(err, "Getting MSP failed")
+		return nil, errors.Wrap(err, "Getting MSP failed")
 	}
 	mspConfig, err := m.GetIdemixMspConfig(testDir, "TestName")
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return msp.Setup(mspConfig)
+	return msp, msp.Setup(mspConfig)
 }
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemixca
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"sort"
+
+	"github.com/sinochem-tech/fabric-amcl/amcl/FP256BN"
+	"github.com/sinochem-tech/fabric/idemix"
+	"github.com/pkg/errors"
+)
+
+// RevocationState keeps track of the revocation handles that the CA issued
+// credentials for, the ones that are revoked, and the current epoch.
+// An epoch of 0 means that no revocation is used yet.
+type RevocationState struct {
+	Epoch   int   `json:"epoch"`
+	Issued  []int `json:"issued"`
+	Revoked []int `json:"revoked"`
+}
+
+// UnmarshalRevocationState parses a revocation state serialized with Marshal.
+// Empty input yields a fresh state, without revocation.
+func UnmarshalRevocationState(raw []byte) (*RevocationState, error) {
+	state := &RevocationState{}
+	if len(raw) == 0 {
+		return state, nil
+	}
+	err := json.Unmarshal(raw, state)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal revocation state")
+	}
+	return state, nil
+}
+
+// Marshal serializes the revocation state
+func (s *RevocationState) Marshal() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// AddIssued records that a credential with the given revocation handle was issued
+func (s *RevocationState) AddIssued(revocationHandle int) {
+	if !contains(s.Issued, revocationHandle) {
+		s.Issued = append(s.Issued, revocationHandle)
+		sort.Ints(s.Issued)
+	}
+}
+
+// Revoke records that the given revocation handle is revoked. The revocation
+// takes effect once a new epoch is published with NewEpoch.
+func (s *RevocationState) Revoke(revocationHandle int) error {
+	if !contains(s.Issued, revocationHandle) {
+		return errors.Errorf("no credential with revocation handle %d was issued", revocationHandle)
+	}
+	if contains(s.Revoked, revocationHandle) {
+		return errors.Errorf("revocation handle %d is already revoked", revocationHandle)
+	}
+	s.Revoked = append(s.Revoked, revocationHandle)
+	sort.Ints(s.Revoked)
+	return nil
+}
+
+// NewEpoch moves to the next epoch and creates the credential revocation information
+// for it, with which the holders of credentials that aren't revoked prove that they are not revoked.
+func (s *RevocationState) NewEpoch(revKey *ecdsa.PrivateKey) (*idemix.CredentialRevocationInformation, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+
+	var unrevokedHandles []*FP256BN.BIG
+	for _, rh := range s.Issued {
+		if !contains(s.Revoked, rh) {
+			unrevokedHandles = append(unrevokedHandles, FP256BN.NewBIGint(rh))
+		}
+	}
+
+	cri, err := idemix.CreateCRI(revKey, unrevokedHandles, s.Epoch+1, idemix.ALG_PLAIN_SIGNATURE, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create CRI")
+	}
+	s.Epoch++
+
+	return cri, nil
+}
+
+func contains(handles []int, revocationHandle int) bool {
+	for _, rh := range handles {
+		if rh == revocationHandle {
+			return true
+		}
+	}
+	return false
+}
@@ -35,6 +35,7 @@ const (
 	IdemixDirIssuer             = "ca"
 	IdemixConfigIssuerSecretKey = "IssuerSecretKey"
 	IdemixConfigRevocationKey   = "RevocationKey"
+	IdemixConfigRevocationState = "RevocationState"
 )
 
 // command line flags
@@ -50,6 +51,11 @@ var (
 	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
 	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The handle used to revoke this signer").Short('r').Int()
 
+	genRevoke                 = app.Command("revoke", "Revoke the credential with a certain revocation handle, effective from the next epoch")
+	genRevokeRevocationHandle = genRevoke.Flag("revocationHandle", "The revocation handle of the credential to revoke").Short('r').Required().Int()
+
+	genNewEpoch = app.Command("new-epoch", "Publish the credential revocation information for a new epoch")
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -89,15 +95,45 @@ func main() {
 		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipk)
 
 	case genSignerConfig.FullCommand():
-		config, err := idemixca.GenerateSignerConfig(*genCredIsAdmin, *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(), readRevocationKey())
-		handleError(err)
-
 		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser)
 		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
 
+		state := readRevocationState()
+		state.AddIssued(*genCredRevocationHandle)
+
+		var config []byte
+		var err error
+		if state.Epoch == 0 {
+			config, err = idemixca.GenerateSignerConfig(*genCredIsAdmin, *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(), readRevocationKey())
+			handleError(err)
+		} else {
+			// Revocation is in use, so a new epoch is needed for the new credential
+			// to be part of the credential revocation information
+			cri, err := state.NewEpoch(readRevocationKey())
+			handleError(err)
+			config, err = idemixca.GenerateSignerConfigWithCRI(*genCredIsAdmin, *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(), cri)
+			handleError(err)
+			writeCRI(cri)
+		}
+
 		// Write config to file
 		handleError(os.Mkdir(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
 		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
+		writeRevocationState(state)
+
+	case genRevoke.FullCommand():
+		state := readRevocationState()
+		handleError(state.Revoke(*genRevokeRevocationHandle))
+		writeRevocationState(state)
+		fmt.Printf("Revocation handle %d will be revoked from the next epoch on, publish it with \"new-epoch\"\n", *genRevokeRevocationHandle)
+
+	case genNewEpoch.FullCommand():
+		state := readRevocationState()
+		cri, err := state.NewEpoch(readRevocationKey())
+		handleError(err)
+		writeCRI(cri)
+		writeRevocationState(state)
+		fmt.Printf("Published the credential revocation information for epoch %d\n", state.Epoch)
 
 	case version.FullCommand():
 		printVersion()
@@ -149,6 +185,34 @@ func readRevocationKey() *ecdsa.PrivateKey {
 	return key
 }
 
+// readRevocationState reads the revocation state of the CA, which is empty
+// if no credentials were recorded yet
+func readRevocationState() *idemixca.RevocationState {
+	path := filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigRevocationState)
+	raw, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		handleError(errors.Wrapf(err, "failed to open revocation state file: %s", path))
+	}
+	state, err := idemixca.UnmarshalRevocationState(raw)
+	handleError(err)
+
+	return state
+}
+
+func writeRevocationState(state *idemixca.RevocationState) {
+	raw, err := state.Marshal()
+	handleError(err)
+	writeFile(filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigRevocationState), raw)
+}
+
+// writeCRI writes the credential revocation information to the verifier MSP config,
+// from where it is distributed via the MSP config
+func writeCRI(cri *idemix.CredentialRevocationInformation) {
+	criBytes, err := proto.Marshal(cri)
+	handleError(err)
+	writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationInfo), criBytes)
+}
+
 // checkDirectoryNotExists checks whether a directory with the given path already exists and exits if this is the case
 func checkDirectoryNotExists(path string, errorMessage string) {
 	_, err := os.Stat(path)
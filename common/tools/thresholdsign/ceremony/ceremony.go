@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ceremony implements the steps of a threshold signing ceremony of a
+// channel configuration update, exchanging files between the custodians of the
+// shares of an admin key and the coordinator of the ceremony.
+package ceremony
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/common/crypto"
+	"github.com/sinochem-tech/fabric/common/crypto/frost"
+	"github.com/sinochem-tech/fabric/common/util"
+	cb "github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	putils "github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// PublicKeyFile is the name of the file holding the public information about the shares
+const PublicKeyFile = "public.json"
+
+// ShareFile returns the name of the file holding the share of the given participant
+func ShareFile(identifier uint16) string {
+	return fmt.Sprintf("share-%d.json", identifier)
+}
+
+// SigningRequest is what the custodians are asked to sign, along with the
+// commitments of the custodians taking part in the signing session
+type SigningRequest struct {
+	// HashFamily is the hash family of the MSP of the admin, the
+	// digest of the signed bytes is computed with
+	HashFamily string `json:"hash_family"`
+	// SignatureHeader is the signature header of the configuration
+	// signature, which identifies the admin
+	SignatureHeader []byte `json:"signature_header"`
+	// ConfigUpdate is the configuration update to sign
+	ConfigUpdate []byte `json:"config_update"`
+	// Commitments are the commitments of the custodians taking part in the signing session
+	Commitments []frost.Commitment `json:"commitments"`
+}
+
+// SplitKey splits the Ed25519 private key of an admin into the given number of
+// shares, any threshold of which are required to sign on behalf of the admin.
+// The shares are written to the output directory, along with their public information.
+func SplitKey(keyFile string, threshold, shares int, outputDir string) error {
+	raw, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading private key %s", keyFile)
+	}
+	key, err := utils.PEMtoPrivateKey(raw, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed parsing private key")
+	}
+	priv, isEd25519 := key.(ed25519.PrivateKey)
+	if !isEd25519 {
+		return errors.Errorf("the private key is a %T, only Ed25519 keys can be split", key)
+	}
+
+	keyShares, err := frost.SplitKey(priv, threshold, shares, rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return errors.Wrapf(err, "failed creating directory %s", outputDir)
+	}
+	for _, share := range keyShares {
+		if err := writeJSON(filepath.Join(outputDir, ShareFile(share.Identifier)), share, 0600); err != nil {
+			return err
+		}
+	}
+	return writeJSON(filepath.Join(outputDir, PublicKeyFile), keyShares[0].PublicKeyPackage, 0644)
+}
+
+// Commit runs the first round of a signing session for a custodian, writing its secret
+// nonces and the commitment to send to the coordinator of the session
+func Commit(shareFile, noncesFile, commitmentFile string) error {
+	share, err := readShare(shareFile)
+	if err != nil {
+		return err
+	}
+
+	nonces, commitment, err := frost.Commit(share, rand.Reader)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(noncesFile, nonces, 0600); err != nil {
+		return err
+	}
+	return writeJSON(commitmentFile, commitment, 0644)
+}
+
+// Prepare creates the request to sign a configuration update on behalf of an admin,
+// whose certificate must certify the public key the shares are of
+func Prepare(configTxFile, publicKeyFile, mspID, certFile, hashFamily string, commitmentFiles []string, requestFile string) error {
+	configUpdateEnv, _, err := readConfigTx(configTxFile)
+	if err != nil {
+		return err
+	}
+
+	pub := &frost.PublicKeyPackage{}
+	if err := readJSON(publicKeyFile, pub); err != nil {
+		return err
+	}
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading certificate %s", certFile)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return errors.Errorf("no PEM content in certificate %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrapf(err, "failed parsing certificate %s", certFile)
+	}
+	if certKey, isEd25519 := cert.PublicKey.(ed25519.PublicKey); !isEd25519 || !bytes.Equal(certKey, pub.GroupPublicKey) {
+		return errors.New("the certificate doesn't certify the public key the shares are of")
+	}
+	if _, err := hashOpt(hashFamily); err != nil {
+		return err
+	}
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: certPEM})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the identity of the admin")
+	}
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return err
+	}
+	sigHeader, err := proto.Marshal(&cb.SignatureHeader{Creator: creator, Nonce: nonce})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the signature header")
+	}
+
+	request := &SigningRequest{
+		HashFamily:      hashFamily,
+		SignatureHeader: sigHeader,
+		ConfigUpdate:    configUpdateEnv.ConfigUpdate,
+	}
+	for _, file := range commitmentFiles {
+		commitment := frost.Commitment{}
+		if err := readJSON(file, &commitment); err != nil {
+			return err
+		}
+		request.Commitments = append(request.Commitments, commitment)
+	}
+	if len(request.Commitments) < pub.Threshold {
+		return errors.Errorf("%d commitments were provided, but %d custodians are required to sign", len(request.Commitments), pub.Threshold)
+	}
+
+	return writeJSON(requestFile, request, 0644)
+}
+
+// Sign runs the second round of a signing session for a custodian, writing the
+// signature share to send to the coordinator of the session. The nonces of the
+// custodian are deleted, since signing twice with the same nonces leaks the share.
+func Sign(shareFile, noncesFile, requestFile, signatureShareFile string) error {
+	share, err := readShare(shareFile)
+	if err != nil {
+		return err
+	}
+	nonces := &frost.Nonces{}
+	if err := readJSON(noncesFile, nonces); err != nil {
+		return err
+	}
+	request := &SigningRequest{}
+	if err := readJSON(requestFile, request); err != nil {
+		return err
+	}
+	pkg, err := request.signingPackage()
+	if err != nil {
+		return err
+	}
+
+	// The nonces can't be reused even if signing fails, since
+	// the signing request might have been crafted to leak them
+	if err := os.Remove(noncesFile); err != nil {
+		return errors.Wrapf(err, "failed deleting nonces %s", noncesFile)
+	}
+
+	signatureShare, err := frost.Sign(share, nonces, pkg)
+	if err != nil {
+		return err
+	}
+	return writeJSON(signatureShareFile, signatureShare, 0644)
+}
+
+// Aggregate combines the signature shares of the custodians into the signature of the admin,
+// and adds it to the signatures of the configuration update, which is written to the output file
+func Aggregate(requestFile, publicKeyFile string, signatureShareFiles []string, configTxFile, outputFile string) error {
+	request := &SigningRequest{}
+	if err := readJSON(requestFile, request); err != nil {
+		return err
+	}
+	pub := &frost.PublicKeyPackage{}
+	if err := readJSON(publicKeyFile, pub); err != nil {
+		return err
+	}
+	var shares []*frost.SignatureShare
+	for _, file := range signatureShareFiles {
+		share := &frost.SignatureShare{}
+		if err := readJSON(file, share); err != nil {
+			return err
+		}
+		shares = append(shares, share)
+	}
+
+	configUpdateEnv, env, err := readConfigTx(configTxFile)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(configUpdateEnv.ConfigUpdate, request.ConfigUpdate) {
+		return errors.New("the configuration update differs from the one of the signing request")
+	}
+
+	pkg, err := request.signingPackage()
+	if err != nil {
+		return err
+	}
+	signature, err := frost.Aggregate(pub, pkg, shares)
+	if err != nil {
+		return err
+	}
+
+	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, &cb.ConfigSignature{
+		SignatureHeader: request.SignatureHeader,
+		Signature:       signature,
+	})
+	payload, err := putils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	payload.Data, err = proto.Marshal(configUpdateEnv)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the configuration update envelope")
+	}
+	rawPayload, err := proto.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the payload")
+	}
+	// The envelope is signed again by whoever submits the configuration update
+	rawEnv, err := proto.Marshal(&cb.Envelope{Payload: rawPayload})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the envelope")
+	}
+
+	return ioutil.WriteFile(outputFile, rawEnv, 0644)
+}
+
+// signingPackage returns the FROST signing package of the request,
+// whose message is the digest of the signed bytes
+func (r *SigningRequest) signingPackage() (*frost.SigningPackage, error) {
+	opt, err := hashOpt(r.HashFamily)
+	if err != nil {
+		return nil, err
+	}
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, err
+	}
+	digest, err := csp.Hash(util.ConcatenateBytes(r.SignatureHeader, r.ConfigUpdate), opt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing digest")
+	}
+	return &frost.SigningPackage{Message: digest, Commitments: r.Commitments}, nil
+}
+
+// hashOpt returns the hash function the MSPs use for the given hash family
+func hashOpt(hashFamily string) (bccsp.HashOpts, error) {
+	switch hashFamily {
+	case bccsp.SHA2:
+		return bccsp.GetHashOpt(bccsp.SHA256)
+	case bccsp.SHA3:
+		return bccsp.GetHashOpt(bccsp.SHA3_256)
+	case bccsp.SM3:
+		return bccsp.GetHashOpt(bccsp.SM3)
+	}
+	return nil, errors.Errorf("hash family not recognized [%s]", hashFamily)
+}
+
+func readConfigTx(file string) (*cb.ConfigUpdateEnvelope, *cb.Envelope, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed reading configuration update %s", file)
+	}
+	env, err := putils.UnmarshalEnvelope(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	if _, err := putils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG_UPDATE, configUpdateEnv); err != nil {
+		return nil, nil, errors.WithMessage(err, fmt.Sprintf("invalid configuration update %s", file))
+	}
+	return configUpdateEnv, env, nil
+}
+
+func readShare(file string) (*frost.KeyShare, error) {
+	share := &frost.KeyShare{}
+	if err := readJSON(file, share); err != nil {
+		return nil, err
+	}
+	if err := share.Validate(); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid share %s", file))
+	}
+	return share, nil
+}
+
+func readJSON(file string, v interface{}) error {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading %s", file)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return errors.Wrapf(err, "failed parsing %s", file)
+	}
+	return nil
+}
+
+func writeJSON(file string, v interface{}, perm os.FileMode) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed marshaling %s", file)
+	}
+	if err := ioutil.WriteFile(file, raw, perm); err != nil {
+		return errors.Wrapf(err, "failed writing %s", file)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ceremony
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	putils "github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup writes an Ed25519 admin key and certificate and a configuration update to the directory
+func setup(t *testing.T, dir string) (keyFile, certFile, configTxFile string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	keyFile = filepath.Join(dir, "admin_sk")
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Admin@org1.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err = x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(t, err)
+	certFile = filepath.Join(dir, "admin.pem")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+
+	configUpdate := putils.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: "mychannel"})
+	env, err := putils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "mychannel", nil, &cb.ConfigUpdateEnvelope{ConfigUpdate: configUpdate}, 0, 0)
+	require.NoError(t, err)
+	configTxFile = filepath.Join(dir, "update.tx")
+	require.NoError(t, ioutil.WriteFile(configTxFile, putils.MarshalOrPanic(env), 0644))
+
+	return keyFile, certFile, configTxFile
+}
+
+func TestCeremony(t *testing.T) {
+	dir, err := ioutil.TempDir("", "thresholdsign")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keyFile, certFile, configTxFile := setup(t, dir)
+	sharesDir := filepath.Join(dir, "shares")
+	require.NoError(t, SplitKey(keyFile, 2, 3, sharesDir))
+	publicFile := filepath.Join(sharesDir, PublicKeyFile)
+
+	// The shares are only readable by their owner
+	info, err := os.Stat(filepath.Join(sharesDir, ShareFile(1)))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Custodians 1 and 3 take part in the signing session
+	file := func(name string, i int) string {
+		return filepath.Join(dir, fmt.Sprintf("%s-%d.json", name, i))
+	}
+	custodians := []int{1, 3}
+	var commitments []string
+	for _, i := range custodians {
+		require.NoError(t, Commit(filepath.Join(sharesDir, ShareFile(uint16(i))), file("nonces", i), file("commitment", i)))
+		commitments = append(commitments, file("commitment", i))
+	}
+
+	requestFile := filepath.Join(dir, "request.json")
+	err = Prepare(configTxFile, publicFile, "Org1MSP", certFile, "SHA2", commitments[:1], requestFile)
+	assert.EqualError(t, err, "1 commitments were provided, but 2 custodians are required to sign")
+	err = Prepare(configTxFile, publicFile, "Org1MSP", certFile, "MD5", commitments, requestFile)
+	assert.EqualError(t, err, "hash family not recognized [MD5]")
+	otherDir := filepath.Join(dir, "other")
+	require.NoError(t, os.Mkdir(otherDir, 0755))
+	_, otherCert, _ := setup(t, otherDir)
+	err = Prepare(configTxFile, publicFile, "Org1MSP", otherCert, "SHA2", commitments, requestFile)
+	assert.EqualError(t, err, "the certificate doesn't certify the public key the shares are of")
+	require.NoError(t, Prepare(configTxFile, publicFile, "Org1MSP", certFile, "SHA2", commitments, requestFile))
+
+	var signatures []string
+	for _, i := range custodians {
+		require.NoError(t, Sign(filepath.Join(sharesDir, ShareFile(uint16(i))), file("nonces", i), requestFile, file("signature", i)))
+		signatures = append(signatures, file("signature", i))
+	}
+
+	// The nonces are deleted once used, so they can't be reused
+	_, err = os.Stat(file("nonces", 1))
+	assert.True(t, os.IsNotExist(err))
+	err = Sign(filepath.Join(sharesDir, ShareFile(1)), file("nonces", 1), requestFile, file("signature", 1))
+	assert.Error(t, err)
+
+	outputFile := filepath.Join(dir, "signed.tx")
+	err = Aggregate(requestFile, publicFile, signatures[:1], configTxFile, outputFile)
+	assert.EqualError(t, err, "1 signature shares were provided, but 2 participants committed")
+	require.NoError(t, Aggregate(requestFile, publicFile, signatures, configTxFile, outputFile))
+
+	// The configuration update carries a signature of the admin that verifies like the ones of the MSPs
+	configUpdateEnv, _, err := readConfigTx(outputFile)
+	require.NoError(t, err)
+	require.Len(t, configUpdateEnv.Signatures, 1)
+	configSig := configUpdateEnv.Signatures[0]
+
+	sigHeader := &cb.SignatureHeader{}
+	require.NoError(t, proto.Unmarshal(configSig.SignatureHeader, sigHeader))
+	creator := &msp.SerializedIdentity{}
+	require.NoError(t, proto.Unmarshal(sigHeader.Creator, creator))
+	assert.Equal(t, "Org1MSP", creator.Mspid)
+	block, _ := pem.Decode(creator.IdBytes)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	digest := sha256.Sum256(append(append([]byte{}, configSig.SignatureHeader...), configUpdateEnv.ConfigUpdate...))
+	assert.True(t, ed25519.Verify(cert.PublicKey.(ed25519.PublicKey), digest[:], configSig.Signature))
+}
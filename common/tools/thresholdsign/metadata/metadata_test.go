@@ -0,0 +1,26 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metadata_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/common/tools/thresholdsign/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVersionInfo(t *testing.T) {
+	testSHA := "abcdefg"
+	metadata.CommitSHA = testSHA
+
+	expected := fmt.Sprintf("%s:\n Version: %s\n Commit SHA: %s\n Go version: %s\n OS/Arch: %s",
+		metadata.ProgramName, metadata.Version, testSHA, runtime.Version(),
+		fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH))
+	assert.Equal(t, expected, metadata.GetVersionInfo())
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// thresholdsign is a command line tool that splits the Ed25519 key of an
+// org admin into shares held by several custodians, and runs the rounds
+// of the threshold signing of channel configuration updates offline,
+// exchanging files between the custodians and a coordinator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sinochem-tech/fabric/common/tools/thresholdsign/ceremony"
+	"github.com/sinochem-tech/fabric/common/tools/thresholdsign/metadata"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("thresholdsign", "Utility for signing channel configuration updates on behalf of an admin with a threshold of key shares")
+
+	split          = app.Command("split", "Split the Ed25519 private key of an admin into shares")
+	splitKey       = split.Flag("key", "The PEM encoded private key to split").Short('k').Required().String()
+	splitThreshold = split.Flag("threshold", "The number of shares required to sign").Short('t').Required().Int()
+	splitShares    = split.Flag("shares", "The number of shares").Short('n').Required().Int()
+	splitOutput    = split.Flag("output", "The output directory in which to place the shares").Short('o').Default("shares").String()
+
+	commit           = app.Command("commit", "Generate the nonces and the commitment of a custodian for a signing session")
+	commitShare      = commit.Flag("share", "The share of the custodian").Short('s').Required().String()
+	commitNonces     = commit.Flag("nonces", "The file in which to keep the secret nonces").Default("nonces.json").String()
+	commitCommitment = commit.Flag("commitment", "The file in which to place the commitment for the coordinator").Short('c').Default("commitment.json").String()
+
+	prepare            = app.Command("prepare", "Create the signing request of a configuration update from the commitments of the custodians")
+	prepareConfigTx    = prepare.Flag("configtx", "The configuration update envelope to sign").Short('f').Required().String()
+	preparePublic      = prepare.Flag("public", "The public information of the shares").Short('p').Default(ceremony.PublicKeyFile).String()
+	prepareMSPID       = prepare.Flag("mspid", "The MSP ID of the admin").Short('m').Required().String()
+	prepareCert        = prepare.Flag("cert", "The PEM encoded certificate of the admin").Required().String()
+	prepareHashFamily  = prepare.Flag("hash-family", "The hash family of the MSP of the admin").Default("SHA2").Enum("SHA2", "SHA3", "SM3")
+	prepareCommitments = prepare.Flag("commitment", "The commitment of a custodian taking part in the signing session, repeated for each custodian").Short('c').Required().Strings()
+	prepareRequest     = prepare.Flag("request", "The file in which to place the signing request").Short('r').Default("request.json").String()
+
+	sign          = app.Command("sign", "Sign a signing request with the share of a custodian")
+	signShare     = sign.Flag("share", "The share of the custodian").Short('s').Required().String()
+	signNonces    = sign.Flag("nonces", "The secret nonces of the custodian, deleted once used").Default("nonces.json").String()
+	signRequest   = sign.Flag("request", "The signing request").Short('r').Default("request.json").String()
+	signSignature = sign.Flag("signature", "The file in which to place the signature share for the coordinator").Default("signature.json").String()
+
+	aggregate           = app.Command("aggregate", "Combine the signature shares of the custodians and add the signature to the configuration update")
+	aggregateRequest    = aggregate.Flag("request", "The signing request").Short('r').Default("request.json").String()
+	aggregatePublic     = aggregate.Flag("public", "The public information of the shares").Short('p').Default(ceremony.PublicKeyFile).String()
+	aggregateSignatures = aggregate.Flag("signature", "The signature share of a custodian, repeated for each custodian").Required().Strings()
+	aggregateConfigTx   = aggregate.Flag("configtx", "The configuration update envelope to add the signature to").Short('f').Required().String()
+	aggregateOutput     = aggregate.Flag("output", "The file in which to place the signed configuration update envelope").Short('o').Required().String()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	case split.FullCommand():
+		handleError(ceremony.SplitKey(*splitKey, *splitThreshold, *splitShares, *splitOutput))
+		fmt.Printf("Split the key into %d shares, %d of which are required to sign\n", *splitShares, *splitThreshold)
+
+	case commit.FullCommand():
+		handleError(ceremony.Commit(*commitShare, *commitNonces, *commitCommitment))
+
+	case prepare.FullCommand():
+		handleError(ceremony.Prepare(*prepareConfigTx, *preparePublic, *prepareMSPID, *prepareCert, *prepareHashFamily, *prepareCommitments, *prepareRequest))
+
+	case sign.FullCommand():
+		handleError(ceremony.Sign(*signShare, *signNonces, *signRequest, *signSignature))
+
+	case aggregate.FullCommand():
+		handleError(ceremony.Aggregate(*aggregateRequest, *aggregatePublic, *aggregateSignatures, *aggregateConfigTx, *aggregateOutput))
+
+	case version.FullCommand():
+		printVersion()
+	}
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
+
+func handleError(err error) {
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -59,6 +59,15 @@ func ComputeSHA3256(data []byte) (hash []byte) {
 	return
 }
 
+// ComputeSM3 returns SM3 on data
+func ComputeSM3(data []byte) (hash []byte) {
+	hash, err := factory.GetDefault().Hash(data, &bccsp.SM3Opts{})
+	if err != nil {
+		panic(fmt.Errorf("Failed computing SM3 on [% x]", data))
+	}
+	return
+}
+
 // GenerateBytesUUID returns a UUID based on RFC 4122 returning the generated bytes
 func GenerateBytesUUID() []byte {
 	uuid := make([]byte, 16)
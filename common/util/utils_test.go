@@ -45,6 +45,15 @@ func TestComputeSHA3256(t *testing.T) {
 	}
 }
 
+func TestComputeSM3(t *testing.T) {
+	if bytes.Compare(ComputeSM3([]byte("foobar")), ComputeSM3([]byte("foobar"))) != 0 {
+		t.Fatalf("Expected hashes to match, but they did not match")
+	}
+	if bytes.Compare(ComputeSM3([]byte("foobar1")), ComputeSM3([]byte("foobar2"))) == 0 {
+		t.Fatalf("Expected hashed to be different, but they match")
+	}
+}
+
 func TestUUIDGeneration(t *testing.T) {
 	uuid := GenerateUUID()
 	if len(uuid) != 36 {
@@ -113,6 +113,11 @@ type peerLedger interface {
 	ledger.PeerLedger
 }
 
+//go:generate counterfeiter -o mock/transfer_verifier.go --fake-name TransferVerifier . transferVerifier
+type transferVerifier interface {
+	chaincode.TransferVerifier
+}
+
 // NOTE: These are getting generated into the "fake" package to avoid import cycles. We need to revisit this.
 
 //go:generate counterfeiter -o fake/launch_registry.go --fake-name LaunchRegistry . launchRegistry
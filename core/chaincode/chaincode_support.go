@@ -41,7 +41,10 @@ type ChaincodeSupport struct {
 	ACLProvider     ACLProvider
 	HandlerRegistry *HandlerRegistry
 	Launcher        Launcher
-	sccp            sysccprovider.SystemChaincodeProvider
+	// TransferVerifier verifies proofs of cross-channel transfers;
+	// when nil, chaincodes can't consume transfers
+	TransferVerifier TransferVerifier
+	sccp             sysccprovider.SystemChaincodeProvider
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -149,6 +152,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(ctxt context.Context, stream c
 		QueryResponseBuilder:       &QueryResponseGenerator{MaxResultLimit: 100},
 		UUIDGenerator:              UUIDGeneratorFunc(util.GenerateUUID),
 		LedgerGetter:               peer.Default,
+		TransferVerifier:           cs.TransferVerifier,
 	}
 
 	return handler.ProcessStream(stream)
@@ -187,6 +191,10 @@ func (cs *ChaincodeSupport) Execute(ctxt context.Context, cccid *ccprovider.CCCo
 	if resp.ChaincodeEvent != nil {
 		resp.ChaincodeEvent.ChaincodeId = cccid.Name
 		resp.ChaincodeEvent.TxId = cccid.TxID
+		for _, event := range resp.ChaincodeEvent.Events {
+			event.ChaincodeId = cccid.Name
+			event.TxId = cccid.TxID
+		}
 	}
 
 	switch resp.Type {
@@ -19,6 +19,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/aclmgmt/resources"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
 	"github.com/sinochem-tech/fabric/core/common/sysccprovider"
+	"github.com/sinochem-tech/fabric/core/common/xchannel"
 	"github.com/sinochem-tech/fabric/core/container/ccintf"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	pb "github.com/sinochem-tech/fabric/protos/peer"
@@ -134,6 +135,8 @@ type Handler struct {
 	LedgerGetter LedgerGetter
 	// UUIDGenerator is used to generate UUIDs
 	UUIDGenerator UUIDGenerator
+	// TransferVerifier is used to verify proofs of cross-channel transfers
+	TransferVerifier TransferVerifier
 
 	// state holds the current handler state. It will be created, established, or
 	// ready.
@@ -192,9 +195,15 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleDelState)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
+	case pb.ChaincodeMessage_CREATE_TRANSFER:
+		go h.HandleTransaction(msg, h.HandleCreateTransfer)
+	case pb.ChaincodeMessage_CONSUME_TRANSFER:
+		go h.HandleTransaction(msg, h.HandleConsumeTransfer)
 
 	case pb.ChaincodeMessage_GET_STATE:
 		go h.HandleTransaction(msg, h.HandleGetState)
+	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH:
+		go h.HandleTransaction(msg, h.HandleGetPrivateDataHash)
 	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
 		go h.HandleTransaction(msg, h.HandleGetStateByRange)
 	case pb.ChaincodeMessage_GET_QUERY_RESULT:
@@ -551,6 +560,31 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to ledger to get the hash of a private data value
+func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getState := &pb.GetState{}
+	err := proto.Unmarshal(msg.Payload, getState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if !isCollectionSet(getState.Collection) {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] getting private data hash for chaincode %s, collection %s, key %s, channel %s", shorttxid(msg.Txid), chaincodeName, getState.Collection, getState.Key, txContext.ChainID)
+
+	res, err := txContext.TXSimulator.GetPrivateDataHash(chaincodeName, getState.Collection, getState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if res == nil {
+		chaincodeLogger.Debugf("[%s] No private data hash associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles query to ledger to rage query state
 func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	getStateByRange := &pb.GetStateByRange{}
@@ -791,6 +825,80 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles requests to transfer data to a chaincode of another channel
+func (h *Handler) HandleCreateTransfer(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	transfer := &pb.CrossChannelTransfer{}
+	err := proto.Unmarshal(msg.Payload, transfer)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if transfer.TargetChannel == "" || transfer.TargetChaincode == "" {
+		return nil, errors.New("target channel and chaincode must be set")
+	}
+	if transfer.TargetChannel == txContext.ChainID {
+		return nil, errors.Errorf("target channel must differ from channel %s", txContext.ChainID)
+	}
+
+	chaincodeName := h.ChaincodeName()
+	transfer.SourceChannel = txContext.ChainID
+	transfer.SourceChaincode = chaincodeName
+	transfer.TxId = msg.Txid
+	chaincodeLogger.Debugf("[%s] creating transfer from chaincode %s to chaincode %s on channel %s", shorttxid(msg.Txid), chaincodeName, transfer.TargetChaincode, transfer.TargetChannel)
+
+	transferBytes, err := proto.Marshal(transfer)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+	err = txContext.TXSimulator.SetState(chaincodeName, xchannel.TransferKey(transfer.TargetChannel, transfer.TargetChaincode, transfer.TxId), transferBytes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// Handles requests to consume a transfer from a chaincode of another channel
+func (h *Handler) HandleConsumeTransfer(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	proof := &pb.CrossChannelTransferProof{}
+	err := proto.Unmarshal(msg.Payload, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+	if h.TransferVerifier == nil {
+		return nil, errors.New("cross-channel transfers are not supported")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	transfer, err := h.TransferVerifier.VerifyTransfer(txContext.ChainID, chaincodeName, proof)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid transfer proof")
+	}
+
+	// Reading the marker adds it to the read set, so concurrent
+	// consumptions of the same transfer are invalidated on commit
+	key := xchannel.ConsumedTransferKey(transfer.SourceChannel, transfer.TxId)
+	consumed, err := txContext.TXSimulator.GetState(chaincodeName, key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if consumed != nil {
+		return nil, errors.Errorf("transfer %s from channel %s has already been consumed", transfer.TxId, transfer.SourceChannel)
+	}
+
+	// The marker holds the proof, which is verified again on validation
+	err = txContext.TXSimulator.SetState(chaincodeName, key, msg.Payload)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	transferBytes, err := proto.Marshal(transfer)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+	chaincodeLogger.Debugf("[%s] chaincode %s consumed transfer %s from channel %s", shorttxid(msg.Txid), chaincodeName, transfer.TxId, transfer.SourceChannel)
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: transferBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
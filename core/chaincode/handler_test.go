@@ -700,6 +700,90 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetPrivateDataHash", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *pb.GetState
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetState{
+				Collection: "collection-name",
+				Key:        "get-private-data-hash-key",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeTxSimulator.GetPrivateDataHashReturns([]byte("get-private-data-hash-response"), nil)
+		})
+
+		It("calls GetPrivateDataHash on the transaction simulator", func() {
+			_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.GetPrivateDataHashCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.GetPrivateDataHashArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("get-private-data-hash-key"))
+		})
+
+		It("returns the response message from GetPrivateDataHash", func() {
+			resp, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("get-private-data-hash-response"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: peer.GetState: wiretype end group for non-group"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("collection must not be an empty string"))
+				Expect(fakeTxSimulator.GetPrivateDataHashCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when GetPrivateDataHash fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetPrivateDataHashReturns(nil, errors.New("onion rings"))
+			})
+
+			It("returns the error from GetPrivateDataHash", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("onion rings"))
+			})
+		})
+	})
+
 	Describe("HandleGetStateByRange", func() {
 		var (
 			incomingMessage       *pb.ChaincodeMessage
@@ -1351,6 +1435,259 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleCreateTransfer", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.CrossChannelTransfer
+
+		BeforeEach(func() {
+			request = &pb.CrossChannelTransfer{
+				TargetChannel:   "target-channel-id",
+				TargetChaincode: "target-cc-name",
+				Payload:         []byte("transfer-payload"),
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_CREATE_TRANSFER,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("returns a response message", func() {
+			resp, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		It("records the transfer in the namespace of the chaincode", func() {
+			_, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+			ccname, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("\x00xchannel~transfer\x00target-channel-id\x00target-cc-name\x00tx-id\x00"))
+
+			transfer := &pb.CrossChannelTransfer{}
+			err = proto.Unmarshal(value, transfer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transfer).To(Equal(&pb.CrossChannelTransfer{
+				SourceChannel:   "channel-id",
+				SourceChaincode: "cc-instance-name",
+				TargetChannel:   "target-channel-id",
+				TargetChaincode: "target-cc-name",
+				TxId:            "tx-id",
+				Payload:         []byte("transfer-payload"),
+			}))
+		})
+
+		Context("when unmarshaling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: peer.CrossChannelTransfer: wiretype end group for non-group"))
+			})
+		})
+
+		Context("when the target chaincode is missing", func() {
+			BeforeEach(func() {
+				request.TargetChaincode = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("target channel and chaincode must be set"))
+			})
+		})
+
+		Context("when the target channel is the channel of the transaction", func() {
+			BeforeEach(func() {
+				request.TargetChannel = "channel-id"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("target channel must differ from channel channel-id"))
+			})
+		})
+
+		Context("when SetState fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.SetStateReturns(errors.New("king-kong"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleCreateTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("king-kong"))
+			})
+		})
+	})
+
+	Describe("HandleConsumeTransfer", func() {
+		var (
+			incomingMessage      *pb.ChaincodeMessage
+			fakeTransferVerifier *mock.TransferVerifier
+			proof                *pb.CrossChannelTransferProof
+			transfer             *pb.CrossChannelTransfer
+			consumedKey          string
+		)
+
+		BeforeEach(func() {
+			proof = &pb.CrossChannelTransferProof{
+				Block: []byte("block-bytes"),
+				TxId:  "source-tx-id",
+			}
+			payload, err := proto.Marshal(proof)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_CONSUME_TRANSFER,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			transfer = &pb.CrossChannelTransfer{
+				SourceChannel:   "source-channel-id",
+				SourceChaincode: "source-cc-name",
+				TargetChannel:   "channel-id",
+				TargetChaincode: "cc-instance-name",
+				TxId:            "source-tx-id",
+				Payload:         []byte("transfer-payload"),
+			}
+			consumedKey = "\x00xchannel~consumed\x00source-channel-id\x00source-tx-id\x00"
+
+			fakeTransferVerifier = &mock.TransferVerifier{}
+			fakeTransferVerifier.VerifyTransferReturns(transfer, nil)
+			handler.TransferVerifier = fakeTransferVerifier
+		})
+
+		It("verifies the proof for the chaincode", func() {
+			_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTransferVerifier.VerifyTransferCallCount()).To(Equal(1))
+			channelID, ccname, p := fakeTransferVerifier.VerifyTransferArgsForCall(0)
+			Expect(channelID).To(Equal("channel-id"))
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(proto.Equal(p, proof)).To(BeTrue())
+		})
+
+		It("marks the transfer as consumed with the proof", func() {
+			_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(1))
+			ccname, key := fakeTxSimulator.GetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal(consumedKey))
+
+			Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+			ccname, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal(consumedKey))
+			Expect(value).To(Equal(incomingMessage.Payload))
+		})
+
+		It("returns a response message with the transfer", func() {
+			resp, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedPayload, err := proto.Marshal(transfer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   expectedPayload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshaling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: peer.CrossChannelTransferProof: wiretype end group for non-group"))
+			})
+		})
+
+		Context("when the handler has no transfer verifier", func() {
+			BeforeEach(func() {
+				handler.TransferVerifier = nil
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("cross-channel transfers are not supported"))
+			})
+		})
+
+		Context("when the proof is invalid", func() {
+			BeforeEach(func() {
+				fakeTransferVerifier.VerifyTransferReturns(nil, errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("invalid transfer proof: mango"))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transfer has already been consumed", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateReturns([]byte("consumed"), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("transfer source-tx-id from channel source-channel-id has already been consumed"))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when GetState fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateReturns(nil, errors.New("papaya"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("papaya"))
+			})
+		})
+
+		Context("when SetState fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.SetStateReturns(errors.New("guava"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleConsumeTransfer(incomingMessage, txContext)
+				Expect(err).To(MatchError("guava"))
+			})
+		})
+	})
+
 	Describe("HandleInvokeChaincode", func() {
 		var (
 			expectedSignedProp      *pb.SignedProposal
@@ -22,6 +22,21 @@ type HistoryQueryExecutor struct {
 		result1 commonledger.ResultsIterator
 		result2 error
 	}
+	GetStateAtBlockStub        func(namespace string, key string, blockNum uint64) ([]byte, error)
+	getStateAtBlockMutex       sync.RWMutex
+	getStateAtBlockArgsForCall []struct {
+		namespace string
+		key       string
+		blockNum  uint64
+	}
+	getStateAtBlockReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAtBlockReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -78,11 +93,66 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 c
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetStateAtBlock(namespace string, key string, blockNum uint64) ([]byte, error) {
+	fake.getStateAtBlockMutex.Lock()
+	ret, specificReturn := fake.getStateAtBlockReturnsOnCall[len(fake.getStateAtBlockArgsForCall)]
+	fake.getStateAtBlockArgsForCall = append(fake.getStateAtBlockArgsForCall, struct {
+		namespace string
+		key       string
+		blockNum  uint64
+	}{namespace, key, blockNum})
+	fake.recordInvocation("GetStateAtBlock", []interface{}{namespace, key, blockNum})
+	fake.getStateAtBlockMutex.Unlock()
+	if fake.GetStateAtBlockStub != nil {
+		return fake.GetStateAtBlockStub(namespace, key, blockNum)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateAtBlockReturns.result1, fake.getStateAtBlockReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtBlockCallCount() int {
+	fake.getStateAtBlockMutex.RLock()
+	defer fake.getStateAtBlockMutex.RUnlock()
+	return len(fake.getStateAtBlockArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtBlockArgsForCall(i int) (string, string, uint64) {
+	fake.getStateAtBlockMutex.RLock()
+	defer fake.getStateAtBlockMutex.RUnlock()
+	return fake.getStateAtBlockArgsForCall[i].namespace, fake.getStateAtBlockArgsForCall[i].key, fake.getStateAtBlockArgsForCall[i].blockNum
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtBlockReturns(result1 []byte, result2 error) {
+	fake.GetStateAtBlockStub = nil
+	fake.getStateAtBlockReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAtBlockReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.GetStateAtBlockStub = nil
+	if fake.getStateAtBlockReturnsOnCall == nil {
+		fake.getStateAtBlockReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAtBlockReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getStateAtBlockMutex.RLock()
+	defer fake.getStateAtBlockMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -221,6 +221,64 @@ type PeerLedger struct {
 		result1 ledger.ConfigHistoryRetriever
 		result2 error
 	}
+	GetMissingPvtDataInfoForBlocksBelowStub        func(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error)
+	getMissingPvtDataInfoForBlocksBelowMutex       sync.RWMutex
+	getMissingPvtDataInfoForBlocksBelowArgsForCall []struct {
+		blockNum  uint64
+		maxBlocks int
+	}
+	getMissingPvtDataInfoForBlocksBelowReturns struct {
+		result1 ledger.MissingPvtDataInfo
+		result2 error
+	}
+	getMissingPvtDataInfoForBlocksBelowReturnsOnCall map[int]struct {
+		result1 ledger.MissingPvtDataInfo
+		result2 error
+	}
+	CommitPvtDataOfOldBlocksStub        func(blocksPvtData []*ledger.BlockPvtData) error
+	commitPvtDataOfOldBlocksMutex       sync.RWMutex
+	commitPvtDataOfOldBlocksArgsForCall []struct {
+		blocksPvtData []*ledger.BlockPvtData
+	}
+	commitPvtDataOfOldBlocksReturns struct {
+		result1 error
+	}
+	commitPvtDataOfOldBlocksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RecordMissingPvtDataOfOldBlocksStub        func(missingPvtData ledger.MissingPvtDataInfo) error
+	recordMissingPvtDataOfOldBlocksMutex       sync.RWMutex
+	recordMissingPvtDataOfOldBlocksArgsForCall []struct {
+		missingPvtData ledger.MissingPvtDataInfo
+	}
+	recordMissingPvtDataOfOldBlocksReturns struct {
+		result1 error
+	}
+	recordMissingPvtDataOfOldBlocksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPvtDataBackfillHeightStub        func() (uint64, error)
+	getPvtDataBackfillHeightMutex       sync.RWMutex
+	getPvtDataBackfillHeightArgsForCall []struct{}
+	getPvtDataBackfillHeightReturns     struct {
+		result1 uint64
+		result2 error
+	}
+	getPvtDataBackfillHeightReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	SetPvtDataBackfillHeightStub        func(height uint64) error
+	setPvtDataBackfillHeightMutex       sync.RWMutex
+	setPvtDataBackfillHeightArgsForCall []struct {
+		height uint64
+	}
+	setPvtDataBackfillHeightReturns struct {
+		result1 error
+	}
+	setPvtDataBackfillHeightReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1066,6 +1124,250 @@ func (fake *PeerLedger) GetConfigHistoryRetrieverReturnsOnCall(i int, result1 le
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	fake.getMissingPvtDataInfoForBlocksBelowMutex.Lock()
+	ret, specificReturn := fake.getMissingPvtDataInfoForBlocksBelowReturnsOnCall[len(fake.getMissingPvtDataInfoForBlocksBelowArgsForCall)]
+	fake.getMissingPvtDataInfoForBlocksBelowArgsForCall = append(fake.getMissingPvtDataInfoForBlocksBelowArgsForCall, struct {
+		blockNum  uint64
+		maxBlocks int
+	}{blockNum, maxBlocks})
+	fake.recordInvocation("GetMissingPvtDataInfoForBlocksBelow", []interface{}{blockNum, maxBlocks})
+	fake.getMissingPvtDataInfoForBlocksBelowMutex.Unlock()
+	if fake.GetMissingPvtDataInfoForBlocksBelowStub != nil {
+		return fake.GetMissingPvtDataInfoForBlocksBelowStub(blockNum, maxBlocks)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getMissingPvtDataInfoForBlocksBelowReturns.result1, fake.getMissingPvtDataInfoForBlocksBelowReturns.result2
+}
+
+func (fake *PeerLedger) GetMissingPvtDataInfoForBlocksBelowCallCount() int {
+	fake.getMissingPvtDataInfoForBlocksBelowMutex.RLock()
+	defer fake.getMissingPvtDataInfoForBlocksBelowMutex.RUnlock()
+	return len(fake.getMissingPvtDataInfoForBlocksBelowArgsForCall)
+}
+
+func (fake *PeerLedger) GetMissingPvtDataInfoForBlocksBelowArgsForCall(i int) (uint64, int) {
+	fake.getMissingPvtDataInfoForBlocksBelowMutex.RLock()
+	defer fake.getMissingPvtDataInfoForBlocksBelowMutex.RUnlock()
+	return fake.getMissingPvtDataInfoForBlocksBelowArgsForCall[i].blockNum, fake.getMissingPvtDataInfoForBlocksBelowArgsForCall[i].maxBlocks
+}
+
+func (fake *PeerLedger) GetMissingPvtDataInfoForBlocksBelowReturns(result1 ledger.MissingPvtDataInfo, result2 error) {
+	fake.GetMissingPvtDataInfoForBlocksBelowStub = nil
+	fake.getMissingPvtDataInfoForBlocksBelowReturns = struct {
+		result1 ledger.MissingPvtDataInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetMissingPvtDataInfoForBlocksBelowReturnsOnCall(i int, result1 ledger.MissingPvtDataInfo, result2 error) {
+	fake.GetMissingPvtDataInfoForBlocksBelowStub = nil
+	if fake.getMissingPvtDataInfoForBlocksBelowReturnsOnCall == nil {
+		fake.getMissingPvtDataInfoForBlocksBelowReturnsOnCall = make(map[int]struct {
+			result1 ledger.MissingPvtDataInfo
+			result2 error
+		})
+	}
+	fake.getMissingPvtDataInfoForBlocksBelowReturnsOnCall[i] = struct {
+		result1 ledger.MissingPvtDataInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	var blocksPvtDataCopy []*ledger.BlockPvtData
+	if blocksPvtData != nil {
+		blocksPvtDataCopy = make([]*ledger.BlockPvtData, len(blocksPvtData))
+		copy(blocksPvtDataCopy, blocksPvtData)
+	}
+	fake.commitPvtDataOfOldBlocksMutex.Lock()
+	ret, specificReturn := fake.commitPvtDataOfOldBlocksReturnsOnCall[len(fake.commitPvtDataOfOldBlocksArgsForCall)]
+	fake.commitPvtDataOfOldBlocksArgsForCall = append(fake.commitPvtDataOfOldBlocksArgsForCall, struct {
+		blocksPvtData []*ledger.BlockPvtData
+	}{blocksPvtDataCopy})
+	fake.recordInvocation("CommitPvtDataOfOldBlocks", []interface{}{blocksPvtDataCopy})
+	fake.commitPvtDataOfOldBlocksMutex.Unlock()
+	if fake.CommitPvtDataOfOldBlocksStub != nil {
+		return fake.CommitPvtDataOfOldBlocksStub(blocksPvtData)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.commitPvtDataOfOldBlocksReturns.result1
+}
+
+func (fake *PeerLedger) CommitPvtDataOfOldBlocksCallCount() int {
+	fake.commitPvtDataOfOldBlocksMutex.RLock()
+	defer fake.commitPvtDataOfOldBlocksMutex.RUnlock()
+	return len(fake.commitPvtDataOfOldBlocksArgsForCall)
+}
+
+func (fake *PeerLedger) CommitPvtDataOfOldBlocksArgsForCall(i int) []*ledger.BlockPvtData {
+	fake.commitPvtDataOfOldBlocksMutex.RLock()
+	defer fake.commitPvtDataOfOldBlocksMutex.RUnlock()
+	return fake.commitPvtDataOfOldBlocksArgsForCall[i].blocksPvtData
+}
+
+func (fake *PeerLedger) CommitPvtDataOfOldBlocksReturns(result1 error) {
+	fake.CommitPvtDataOfOldBlocksStub = nil
+	fake.commitPvtDataOfOldBlocksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) CommitPvtDataOfOldBlocksReturnsOnCall(i int, result1 error) {
+	fake.CommitPvtDataOfOldBlocksStub = nil
+	if fake.commitPvtDataOfOldBlocksReturnsOnCall == nil {
+		fake.commitPvtDataOfOldBlocksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.commitPvtDataOfOldBlocksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	fake.recordMissingPvtDataOfOldBlocksMutex.Lock()
+	ret, specificReturn := fake.recordMissingPvtDataOfOldBlocksReturnsOnCall[len(fake.recordMissingPvtDataOfOldBlocksArgsForCall)]
+	fake.recordMissingPvtDataOfOldBlocksArgsForCall = append(fake.recordMissingPvtDataOfOldBlocksArgsForCall, struct {
+		missingPvtData ledger.MissingPvtDataInfo
+	}{missingPvtData})
+	fake.recordInvocation("RecordMissingPvtDataOfOldBlocks", []interface{}{missingPvtData})
+	fake.recordMissingPvtDataOfOldBlocksMutex.Unlock()
+	if fake.RecordMissingPvtDataOfOldBlocksStub != nil {
+		return fake.RecordMissingPvtDataOfOldBlocksStub(missingPvtData)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordMissingPvtDataOfOldBlocksReturns.result1
+}
+
+func (fake *PeerLedger) RecordMissingPvtDataOfOldBlocksCallCount() int {
+	fake.recordMissingPvtDataOfOldBlocksMutex.RLock()
+	defer fake.recordMissingPvtDataOfOldBlocksMutex.RUnlock()
+	return len(fake.recordMissingPvtDataOfOldBlocksArgsForCall)
+}
+
+func (fake *PeerLedger) RecordMissingPvtDataOfOldBlocksArgsForCall(i int) ledger.MissingPvtDataInfo {
+	fake.recordMissingPvtDataOfOldBlocksMutex.RLock()
+	defer fake.recordMissingPvtDataOfOldBlocksMutex.RUnlock()
+	return fake.recordMissingPvtDataOfOldBlocksArgsForCall[i].missingPvtData
+}
+
+func (fake *PeerLedger) RecordMissingPvtDataOfOldBlocksReturns(result1 error) {
+	fake.RecordMissingPvtDataOfOldBlocksStub = nil
+	fake.recordMissingPvtDataOfOldBlocksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) RecordMissingPvtDataOfOldBlocksReturnsOnCall(i int, result1 error) {
+	fake.RecordMissingPvtDataOfOldBlocksStub = nil
+	if fake.recordMissingPvtDataOfOldBlocksReturnsOnCall == nil {
+		fake.recordMissingPvtDataOfOldBlocksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordMissingPvtDataOfOldBlocksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) GetPvtDataBackfillHeight() (uint64, error) {
+	fake.getPvtDataBackfillHeightMutex.Lock()
+	ret, specificReturn := fake.getPvtDataBackfillHeightReturnsOnCall[len(fake.getPvtDataBackfillHeightArgsForCall)]
+	fake.getPvtDataBackfillHeightArgsForCall = append(fake.getPvtDataBackfillHeightArgsForCall, struct{}{})
+	fake.recordInvocation("GetPvtDataBackfillHeight", []interface{}{})
+	fake.getPvtDataBackfillHeightMutex.Unlock()
+	if fake.GetPvtDataBackfillHeightStub != nil {
+		return fake.GetPvtDataBackfillHeightStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getPvtDataBackfillHeightReturns.result1, fake.getPvtDataBackfillHeightReturns.result2
+}
+
+func (fake *PeerLedger) GetPvtDataBackfillHeightCallCount() int {
+	fake.getPvtDataBackfillHeightMutex.RLock()
+	defer fake.getPvtDataBackfillHeightMutex.RUnlock()
+	return len(fake.getPvtDataBackfillHeightArgsForCall)
+}
+
+func (fake *PeerLedger) GetPvtDataBackfillHeightReturns(result1 uint64, result2 error) {
+	fake.GetPvtDataBackfillHeightStub = nil
+	fake.getPvtDataBackfillHeightReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetPvtDataBackfillHeightReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.GetPvtDataBackfillHeightStub = nil
+	if fake.getPvtDataBackfillHeightReturnsOnCall == nil {
+		fake.getPvtDataBackfillHeightReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.getPvtDataBackfillHeightReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) SetPvtDataBackfillHeight(height uint64) error {
+	fake.setPvtDataBackfillHeightMutex.Lock()
+	ret, specificReturn := fake.setPvtDataBackfillHeightReturnsOnCall[len(fake.setPvtDataBackfillHeightArgsForCall)]
+	fake.setPvtDataBackfillHeightArgsForCall = append(fake.setPvtDataBackfillHeightArgsForCall, struct {
+		height uint64
+	}{height})
+	fake.recordInvocation("SetPvtDataBackfillHeight", []interface{}{height})
+	fake.setPvtDataBackfillHeightMutex.Unlock()
+	if fake.SetPvtDataBackfillHeightStub != nil {
+		return fake.SetPvtDataBackfillHeightStub(height)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setPvtDataBackfillHeightReturns.result1
+}
+
+func (fake *PeerLedger) SetPvtDataBackfillHeightCallCount() int {
+	fake.setPvtDataBackfillHeightMutex.RLock()
+	defer fake.setPvtDataBackfillHeightMutex.RUnlock()
+	return len(fake.setPvtDataBackfillHeightArgsForCall)
+}
+
+func (fake *PeerLedger) SetPvtDataBackfillHeightArgsForCall(i int) uint64 {
+	fake.setPvtDataBackfillHeightMutex.RLock()
+	defer fake.setPvtDataBackfillHeightMutex.RUnlock()
+	return fake.setPvtDataBackfillHeightArgsForCall[i].height
+}
+
+func (fake *PeerLedger) SetPvtDataBackfillHeightReturns(result1 error) {
+	fake.SetPvtDataBackfillHeightStub = nil
+	fake.setPvtDataBackfillHeightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) SetPvtDataBackfillHeightReturnsOnCall(i int, result1 error) {
+	fake.SetPvtDataBackfillHeightStub = nil
+	if fake.setPvtDataBackfillHeightReturnsOnCall == nil {
+		fake.setPvtDataBackfillHeightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPvtDataBackfillHeightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1105,6 +1407,16 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.getConfigHistoryRetrieverMutex.RLock()
 	defer fake.getConfigHistoryRetrieverMutex.RUnlock()
+	fake.getMissingPvtDataInfoForBlocksBelowMutex.RLock()
+	defer fake.getMissingPvtDataInfoForBlocksBelowMutex.RUnlock()
+	fake.commitPvtDataOfOldBlocksMutex.RLock()
+	defer fake.commitPvtDataOfOldBlocksMutex.RUnlock()
+	fake.recordMissingPvtDataOfOldBlocksMutex.RLock()
+	defer fake.recordMissingPvtDataOfOldBlocksMutex.RUnlock()
+	fake.getPvtDataBackfillHeightMutex.RLock()
+	defer fake.getPvtDataBackfillHeightMutex.RUnlock()
+	fake.setPvtDataBackfillHeightMutex.RLock()
+	defer fake.setPvtDataBackfillHeightMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
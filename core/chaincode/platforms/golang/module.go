@@ -0,0 +1,281 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	goModFile         = "go.mod"
+	goSumFile         = "go.sum"
+	vendorDir         = "vendor"
+	vendorModulesFile = "vendor/modules.txt"
+)
+
+// moduleFiles are the files of a module-based chaincode, in addition to the 'includeFileTypes'
+var moduleFiles = map[string]bool{
+	goModFile:     true,
+	goSumFile:     true,
+	"modules.txt": true,
+}
+
+// isModule returns whether the chaincode package at gopath/src/pkg is the root of a Go module
+func isModule(gopath, pkg string) (bool, error) {
+	return pathExists(filepath.Join(gopath, "src", pkg, goModFile))
+}
+
+// findModuleSource collects the source files of the module rooted at gopath/src/pkg,
+// including its vendored dependencies. Directories ignored by the go tool and nested
+// modules are not part of the module, and are skipped.
+func findModuleSource(gopath, pkg string) (SourceMap, error) {
+	sources := make(SourceMap)
+	tld := filepath.Join(gopath, "src", pkg)
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path == tld || isMetadataDir(path, tld) {
+				return nil
+			}
+			name := info.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" {
+				logger.Debugf("skipping dir: %s", path)
+				return filepath.SkipDir
+			}
+			// Nested modules aren't part of the module, unlike the vendored ones
+			if strings.HasPrefix(path, filepath.Join(tld, vendorDir)) {
+				return nil
+			}
+			nested, err := pathExists(filepath.Join(path, goModFile))
+			if err != nil {
+				return err
+			}
+			if nested {
+				logger.Debugf("skipping nested module: %s", path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !includeFileTypes[filepath.Ext(path)] && !moduleFiles[info.Name()] {
+			return nil
+		}
+
+		name, err := filepath.Rel(gopath, path)
+		if err != nil {
+			return errors.Errorf("error obtaining relative path for %s: %s", path, err)
+		}
+
+		sources[name] = SourceDescriptor{Name: name, Path: path, IsMetadata: isMetadataDir(path, tld), Info: info}
+
+		return nil
+	}
+
+	if err := filepath.Walk(tld, walkFn); err != nil {
+		return nil, errors.Errorf("Error walking directory: %s", err)
+	}
+
+	return sources, nil
+}
+
+// getModuleSources returns the files to package for the module-based chaincode
+// described by code, after making sure all of its dependencies are vendored
+func getModuleSources(code *CodeDescriptor) (Sources, error) {
+	sourceMap, err := findModuleSource(code.Gopath, code.Pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := path.Join("src", code.Pkg) + "/"
+	contents := make(map[string][]byte)
+	files := make(Sources, 0, len(sourceMap))
+	for _, file := range sourceMap {
+		files = append(files, file)
+		if file.IsMetadata {
+			continue
+		}
+		name := strings.TrimPrefix(filepath.ToSlash(file.Name), prefix)
+		if !isModuleValidationFile(name) {
+			continue
+		}
+		contents[name], err = ioutil.ReadFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateModule(code.Pkg, contents); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// isModuleValidationFile returns whether the file with the given path, relative
+// to the root of the module, is needed for validating the module
+func isModuleValidationFile(name string) bool {
+	return name == goModFile || name == vendorModulesFile || filepath.Ext(name) == ".go"
+}
+
+// validateModule checks that the module-based chaincode package pkg, given as a map
+// of file paths relative to the root of the module to their contents, is buildable
+// without fetching anything: the module path must match the chaincode path, and every
+// package imported by the module or by its vendored dependencies must be vendored.
+func validateModule(pkg string, files map[string][]byte) error {
+	goMod, exists := files[goModFile]
+	if !exists {
+		return errors.Errorf("%s not found in chaincode package %s", goModFile, pkg)
+	}
+
+	modulePath := parseModulePath(goMod)
+	if modulePath == "" {
+		return errors.Errorf("no module directive found in %s of chaincode package %s", goModFile, pkg)
+	}
+	// The chaincode is built out of its location in the GOPATH layout of the package
+	if modulePath != pkg {
+		return errors.Errorf("module path %s does not match chaincode path %s", modulePath, pkg)
+	}
+
+	pkgDirs := make(map[string]bool)
+	for name := range files {
+		if filepath.Ext(name) == ".go" && !strings.HasSuffix(name, "_test.go") {
+			pkgDirs[path.Dir(name)] = true
+		}
+	}
+
+	if modules, exists := files[vendorModulesFile]; exists {
+		scanner := bufio.NewScanner(bytes.NewReader(modules))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if !pkgDirs[path.Join(vendorDir, line)] {
+				return errors.Errorf("package %s listed in %s is not vendored", line, vendorModulesFile)
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	for name, contents := range files {
+		if filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, contents, parser.ImportsOnly|parser.ParseComments)
+		if err != nil {
+			return errors.Wrapf(err, "failed parsing %s", name)
+		}
+		if isIgnored(f) {
+			continue
+		}
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return errors.Wrapf(err, "invalid import in %s", name)
+			}
+			var dir string
+			switch {
+			case importPath == modulePath:
+				dir = "."
+			case strings.HasPrefix(importPath, modulePath+"/"):
+				dir = strings.TrimPrefix(importPath, modulePath+"/")
+			case isStandardImport(importPath):
+				continue
+			default:
+				dir = path.Join(vendorDir, importPath)
+			}
+			if !pkgDirs[dir] {
+				return errors.Errorf("missing dependency %s imported by %s, make sure dependencies are vendored with 'go mod vendor'", importPath, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseModulePath returns the path declared by the module directive of the given go.mod
+func parseModulePath(goMod []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(goMod))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		if modulePath, err := strconv.Unquote(fields[1]); err == nil {
+			return modulePath
+		}
+		return fields[1]
+	}
+	return ""
+}
+
+// isStandardImport returns whether the given import path belongs to the standard library,
+// whose import paths, unlike the ones of any other package, lack a dot in their first element
+func isStandardImport(importPath string) bool {
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+// isIgnored returns whether the given file is excluded from every build by a build constraint
+func isIgnored(f *ast.File) bool {
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if text == "+build ignore" || text == "go:build ignore" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isModulePackage returns whether the given code package holds a module-based chaincode
+func isModulePackage(codePackage []byte, pkg string) (bool, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(codePackage))
+	if err != nil {
+		return false, errors.Wrap(err, "failure opening codepackage gzip stream")
+	}
+	tr := tar.NewReader(gr)
+
+	goModPath := path.Join("src", pkg, goModFile)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, errors.Wrap(err, "failure reading codepackage tar stream")
+		}
+		if strings.TrimPrefix(header.Name, "/") == goModPath {
+			return true, nil
+		}
+	}
+}
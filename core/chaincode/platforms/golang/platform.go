@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -143,6 +144,18 @@ func (goPlatform *Platform) ValidateDeploymentSpec(cds *pb.ChaincodeDeploymentSp
 	// resilient in enforcing constraints. However, we should still do our best to keep as much
 	// garbage out of the system as possible.
 	re := regexp.MustCompile(`(/)?src/.*`)
+
+	// The files of the chaincode package itself are kept, for validating
+	// module-based chaincodes once the whole tarball has been scanned
+	var pkgname, pkgPrefix string
+	if cds.ChaincodeSpec != nil && cds.ChaincodeSpec.ChaincodeId != nil {
+		if name, err := decodeUrl(cds.ChaincodeSpec); err == nil {
+			pkgname = name
+			pkgPrefix = path.Join("src", pkgname) + "/"
+		}
+	}
+	pkgFiles := make(map[string][]byte)
+
 	is := bytes.NewReader(cds.CodePackage)
 	gr, err := gzip.NewReader(is)
 	if err != nil {
@@ -176,6 +189,26 @@ func (goPlatform *Platform) ValidateDeploymentSpec(cds *pb.ChaincodeDeploymentSp
 		if header.Mode&^0100666 != 0 {
 			return fmt.Errorf("illegal file mode detected for file %s: %o", header.Name, header.Mode)
 		}
+
+		name := strings.TrimPrefix(header.Name, "/")
+		if pkgPrefix == "" || !strings.HasPrefix(name, pkgPrefix) {
+			continue
+		}
+		name = strings.TrimPrefix(name, pkgPrefix)
+		if !isModuleValidationFile(name) {
+			continue
+		}
+		pkgFiles[name], err = ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failure reading file %s: %s", header.Name, err)
+		}
+	}
+
+	// --------------------------------------------------------------------------------------
+	// Check that module-based chaincodes carry all of their dependencies
+	// --------------------------------------------------------------------------------------
+	if _, isModule := pkgFiles[goModFile]; isModule {
+		return validateModule(pkgname, pkgFiles)
 	}
 
 	return nil
@@ -265,6 +298,21 @@ func (goPlatform *Platform) GetDeploymentPayload(spec *pb.ChaincodeSpec) ([]byte
 		defer code.Cleanup()
 	}
 
+	// --------------------------------------------------------------------------------------
+	// Module-based chaincodes carry their dependencies along, as vendored by 'go mod vendor'
+	// --------------------------------------------------------------------------------------
+	module, err := isModule(code.Gopath, code.Pkg)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for %s: %s", goModFile, err)
+	}
+	if module {
+		files, err := getModuleSources(code)
+		if err != nil {
+			return nil, err
+		}
+		return generateCodePackage(spec, code.Pkg, files)
+	}
+
 	// --------------------------------------------------------------------------------------
 	// Update our environment for the purposes of executing go-list directives
 	// --------------------------------------------------------------------------------------
@@ -414,6 +462,15 @@ func (goPlatform *Platform) GetDeploymentPayload(spec *pb.ChaincodeSpec) ([]byte
 	// --------------------------------------------------------------------------------------
 	vendorDependencies(code.Pkg, files)
 
+	return generateCodePackage(spec, code.Pkg, files)
+}
+
+// generateCodePackage writes the given files out as a .tar.gz code package.
+// The entries are sorted and their headers stripped of anything specific to
+// the local system, so that packaging the same sources always yields the same bytes.
+func generateCodePackage(spec *pb.ChaincodeSpec, pkg string, files Sources) ([]byte, error) {
+	var err error
+
 	// --------------------------------------------------------------------------------------
 	// Sort on the filename so the tarball at least looks sane in terms of package grouping
 	// --------------------------------------------------------------------------------------
@@ -436,7 +493,7 @@ func (goPlatform *Platform) GetDeploymentPayload(spec *pb.ChaincodeSpec) ([]byte
 		// updated file.Name:   META-INF/statedb/couchdb/indexes/indexOwner.json
 		if file.IsMetadata {
 
-			file.Name, err = filepath.Rel(filepath.Join("src", code.Pkg), file.Name)
+			file.Name, err = filepath.Rel(filepath.Join("src", pkg), file.Name)
 			if err != nil {
 				return nil, fmt.Errorf("This error was caused by bad packaging of the metadata.  The file [%s] is marked as MetaFile, however not located under META-INF   Error:[%s]", file.Name, err)
 			}
@@ -506,6 +563,16 @@ func getLDFlagsOpts() string {
 	return staticLDFlagsOpts
 }
 
+// getBuildCmd returns the command building the chaincode package pkgname within the build container.
+// Module-based chaincodes are built solely out of the code package, which holds all of their
+// dependencies, so that the binary doesn't depend on the packages provided by the image.
+func getBuildCmd(pkgname, gotags, ldflagsOpt string, module bool) string {
+	if module {
+		return fmt.Sprintf("GOPATH=/chaincode/input GO111MODULE=off go build -tags \"%s\" %s -gcflags \"all=-trimpath=/chaincode/input\" -asmflags \"all=-trimpath=/chaincode/input\" -o /chaincode/output/chaincode %s", gotags, ldflagsOpt, pkgname)
+	}
+	return fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build -tags \"%s\" %s -o /chaincode/output/chaincode %s", gotags, ldflagsOpt, pkgname)
+}
+
 func (goPlatform *Platform) GenerateDockerBuild(cds *pb.ChaincodeDeploymentSpec, tw *tar.Writer) error {
 	spec := cds.ChaincodeSpec
 
@@ -524,10 +591,15 @@ func (goPlatform *Platform) GenerateDockerBuild(cds *pb.ChaincodeDeploymentSpec,
 	}
 	logger.Infof("building chaincode with tags: %s", gotags)
 
+	module, err := isModulePackage(cds.CodePackage, pkgname)
+	if err != nil {
+		return err
+	}
+
 	codepackage := bytes.NewReader(cds.CodePackage)
 	binpackage := bytes.NewBuffer(nil)
 	err = util.DockerBuild(util.DockerBuildOptions{
-		Cmd:          fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build -tags \"%s\" %s -o /chaincode/output/chaincode %s", gotags, ldflagsOpt, pkgname),
+		Cmd:          getBuildCmd(pkgname, gotags, ldflagsOpt, module),
 		InputStream:  codepackage,
 		OutputStream: binpackage,
 	})
@@ -1,3 +1,4 @@
+//go:build go1.9
 // +build go1.9
 
 /*
@@ -294,6 +295,8 @@ func TestGetDeploymentPayload(t *testing.T) {
 		{gopath: testdataPath, spec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/BadMetadataUnexpectedFolderContent"}}, succ: false},
 		{gopath: testdataPath, spec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/BadMetadataIgnoreHiddenFile"}}, succ: true},
 		{gopath: testdataPath, spec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/empty/"}}, succ: false},
+		{gopath: testdataPath, spec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/Module"}}, succ: true},
+		{gopath: testdataPath, spec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/ModuleMissingDep"}}, succ: false},
 	}
 
 	for _, tst := range tests {
@@ -307,7 +310,191 @@ func TestGetDeploymentPayload(t *testing.T) {
 	}
 }
 
-//TestGetLDFlagsOpts tests handling of chaincode.golang.dynamicLink
+func TestGetDeploymentPayloadModule(t *testing.T) {
+	testdataPath, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+	reset := updateGopath(t, testdataPath)
+	defer reset()
+
+	platform := &Platform{}
+	spec := &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: "chaincodes/Module"}}
+
+	payload, err := platform.GetDeploymentPayload(spec)
+	require.NoError(t, err)
+
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			break
+		}
+		assert.Empty(t, header.Uname)
+		assert.Empty(t, header.Gname)
+		names = append(names, header.Name)
+	}
+	assert.Equal(t, []string{
+		"src/chaincodes/Module/directdep/core.go",
+		"src/chaincodes/Module/go.mod",
+		"src/chaincodes/Module/main.go",
+		"src/chaincodes/Module/vendor/example.com/indirectdep/core.go",
+		"src/chaincodes/Module/vendor/modules.txt",
+	}, names)
+
+	cds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: payload}
+	assert.NoError(t, platform.ValidateDeploymentSpec(cds))
+	module, err := isModulePackage(payload, "chaincodes/Module")
+	assert.NoError(t, err)
+	assert.True(t, module)
+
+	// Packaging the same sources again must yield the same bytes,
+	// regardless of the modification times of the files
+	mainFile := filepath.Join(testdataPath, "src", "chaincodes", "Module", "main.go")
+	info, err := os.Stat(mainFile)
+	require.NoError(t, err)
+	later := info.ModTime().Add(time.Hour)
+	require.NoError(t, os.Chtimes(mainFile, later, later))
+	defer os.Chtimes(mainFile, info.ModTime(), info.ModTime())
+
+	payload2, err := platform.GetDeploymentPayload(spec)
+	require.NoError(t, err)
+	assert.Equal(t, payload, payload2)
+}
+
+func generateFakeModuleCDS(t *testing.T, path string, files map[string]string) *pb.ChaincodeDeploymentSpec {
+	codePackage := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(codePackage)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		err := writeBytesToPackage(name, []byte(contents), 0100644, tw)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "Test Chaincode", Path: path}},
+		CodePackage:   codePackage.Bytes(),
+	}
+}
+
+func TestValidateCDSModule(t *testing.T) {
+	platform := &Platform{}
+
+	files := map[string]string{
+		"src/example.com/cc/go.mod":                      "module example.com/cc\n",
+		"src/example.com/cc/main.go":                     "package main\nimport _ \"example.com/dep\"\n",
+		"src/example.com/cc/vendor/modules.txt":          "# example.com/dep v1.0.0\nexample.com/dep\n",
+		"src/example.com/cc/vendor/example.com/dep/a.go": "package dep\n",
+	}
+	cds := generateFakeModuleCDS(t, "example.com/cc", files)
+	assert.NoError(t, platform.ValidateDeploymentSpec(cds))
+
+	delete(files, "src/example.com/cc/vendor/example.com/dep/a.go")
+	cds = generateFakeModuleCDS(t, "example.com/cc", files)
+	err := platform.ValidateDeploymentSpec(cds)
+	assert.EqualError(t, err, "package example.com/dep listed in vendor/modules.txt is not vendored")
+
+	delete(files, "src/example.com/cc/vendor/modules.txt")
+	cds = generateFakeModuleCDS(t, "example.com/cc", files)
+	err = platform.ValidateDeploymentSpec(cds)
+	assert.EqualError(t, err, "missing dependency example.com/dep imported by main.go, make sure dependencies are vendored with 'go mod vendor'")
+}
+
+func TestValidateModule(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		err   string
+	}{
+		{
+			name:  "NoGoMod",
+			files: map[string][]byte{"main.go": []byte("package main\n")},
+			err:   "go.mod not found in chaincode package example.com/cc",
+		},
+		{
+			name:  "NoModuleDirective",
+			files: map[string][]byte{"go.mod": []byte("require example.com/dep v1.0.0\n")},
+			err:   "no module directive found in go.mod of chaincode package example.com/cc",
+		},
+		{
+			name:  "ModulePathMismatch",
+			files: map[string][]byte{"go.mod": []byte("module example.com/other\n")},
+			err:   "module path example.com/other does not match chaincode path example.com/cc",
+		},
+		{
+			name: "StandardLibraryAndModulePackages",
+			files: map[string][]byte{
+				"go.mod":     []byte("module \"example.com/cc\" // the chaincode\n"),
+				"main.go":    []byte("package main\nimport (\n\"fmt\"\n\"example.com/cc/sub\"\n)\n"),
+				"sub/sub.go": []byte("package sub\nimport \"C\"\n"),
+			},
+		},
+		{
+			name: "MissingModulePackage",
+			files: map[string][]byte{
+				"go.mod":  []byte("module example.com/cc\n"),
+				"main.go": []byte("package main\nimport \"example.com/cc/sub\"\n"),
+			},
+			err: "missing dependency example.com/cc/sub imported by main.go, make sure dependencies are vendored with 'go mod vendor'",
+		},
+		{
+			name: "MissingTransitiveDependency",
+			files: map[string][]byte{
+				"go.mod":                        []byte("module example.com/cc\n"),
+				"main.go":                       []byte("package main\nimport \"example.com/dep\"\n"),
+				"vendor/example.com/dep/a.go":   []byte("package dep\nimport \"example.com/transitive\"\n"),
+				"vendor/example.com/dep/b.go":   []byte("package dep\n"),
+				"vendor/example.com/dep/a_test": []byte("not go"),
+			},
+			err: "missing dependency example.com/transitive imported by vendor/example.com/dep/a.go, make sure dependencies are vendored with 'go mod vendor'",
+		},
+		{
+			name: "IgnoredAndTestFiles",
+			files: map[string][]byte{
+				"go.mod":       []byte("module example.com/cc\n"),
+				"main.go":      []byte("package main\n"),
+				"tools.go":     []byte("// +build ignore\n\npackage main\nimport _ \"example.com/tool\"\n"),
+				"main_test.go": []byte("package main\nimport _ \"example.com/testdep\"\n"),
+			},
+		},
+		{
+			name: "Unparsable",
+			files: map[string][]byte{
+				"go.mod":  []byte("module example.com/cc\n"),
+				"main.go": []byte("not go"),
+			},
+			err: "failed parsing main.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateModule("example.com/cc", tt.files)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestGetBuildCmd(t *testing.T) {
+	cmd := getBuildCmd("example.com/cc", " experimental", staticLDFlagsOpts, false)
+	assert.Equal(t, "GOPATH=/chaincode/input:$GOPATH go build -tags \" experimental\" "+staticLDFlagsOpts+" -o /chaincode/output/chaincode example.com/cc", cmd)
+
+	cmd = getBuildCmd("example.com/cc", "", staticLDFlagsOpts, true)
+	assert.Contains(t, cmd, "GOPATH=/chaincode/input ")
+	assert.NotContains(t, cmd, "$GOPATH")
+	assert.Contains(t, cmd, "-trimpath=/chaincode/input")
+	assert.True(t, strings.HasSuffix(cmd, " example.com/cc"))
+}
+
+// TestGetLDFlagsOpts tests handling of chaincode.golang.dynamicLink
 func TestGetLDFlagsOpts(t *testing.T) {
 	viper.Set("chaincode.golang.dynamicLink", true)
 	if getLDFlagsOpts() != dynamicLDFlagsOpts {
@@ -319,7 +506,7 @@ func TestGetLDFlagsOpts(t *testing.T) {
 	}
 }
 
-//TestGenerateDockerBuild goes through the functions needed to do docker build
+// TestGenerateDockerBuild goes through the functions needed to do docker build
 func TestGenerateDockerBuild(t *testing.T) {
 	defaultGopath := os.Getenv("GOPATH")
 	testdataPath, err := filepath.Abs("testdata")
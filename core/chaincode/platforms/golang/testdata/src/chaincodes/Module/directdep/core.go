@@ -0,0 +1,17 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * See chaincodes/Module/main.go for details
+ */
+package directdep
+
+import (
+	"example.com/indirectdep"
+)
+
+func PointlessFunction() {
+	// delegate to our vendored dependency
+	indirectdep.PointlessFunction()
+}
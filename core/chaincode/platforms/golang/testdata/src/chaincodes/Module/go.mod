@@ -0,0 +1,3 @@
+module chaincodes/Module
+
+require example.com/indirectdep v1.0.0
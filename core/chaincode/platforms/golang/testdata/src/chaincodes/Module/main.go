@@ -0,0 +1,19 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * The purpose of this test code is to prove that the system properly packages
+ * up module-based chaincodes, along with their vendored dependencies.
+ *
+ */
+
+package main
+
+import (
+	"chaincodes/Module/directdep"
+)
+
+func main() {
+	directdep.PointlessFunction()
+}
@@ -0,0 +1,3 @@
+module chaincodes/ModuleMissingDep
+
+require example.com/indirectdep v1.0.0
@@ -0,0 +1,18 @@
+/*
+ * Copyright IBM Corp. All Rights Reserved.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * A module-based chaincode whose dependencies are not vendored
+ *
+ */
+
+package main
+
+import (
+	"example.com/indirectdep"
+)
+
+func main() {
+	indirectdep.PointlessFunction()
+}
@@ -391,6 +391,24 @@ func (stub *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte,
 	return stub.handler.handleInvokeChaincode(chaincodeName, args, stub.ChannelId, stub.TxID)
 }
 
+// CreateCrossChannelTransfer documentation can be found in interfaces.go
+func (stub *ChaincodeStub) CreateCrossChannelTransfer(channel, chaincodeName string, payload []byte) error {
+	if channel == "" || chaincodeName == "" {
+		return errors.New("channel and chaincode name must not be empty")
+	}
+	transfer := &pb.CrossChannelTransfer{TargetChannel: channel, TargetChaincode: chaincodeName, Payload: payload}
+	return stub.handler.handleCreateTransfer(transfer, stub.ChannelId, stub.TxID)
+}
+
+// ConsumeCrossChannelTransfer documentation can be found in interfaces.go
+func (stub *ChaincodeStub) ConsumeCrossChannelTransfer(proof []byte) (*pb.CrossChannelTransfer, error) {
+	transferProof := &pb.CrossChannelTransferProof{}
+	if err := proto.Unmarshal(proof, transferProof); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal transfer proof")
+	}
+	return stub.handler.handleConsumeTransfer(transferProof, stub.ChannelId, stub.TxID)
+}
+
 // --------- State functions ----------
 
 // GetState documentation can be found in interfaces.go
@@ -438,6 +456,14 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetPrivateDataHash documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	return stub.handler.handleGetPrivateDataHash(collection, key, stub.ChannelId, stub.TxID)
+}
+
 // PutPrivateData documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
 	if collection == "" {
@@ -805,7 +831,15 @@ func (stub *ChaincodeStub) SetEvent(name string, payload []byte) error {
 	if name == "" {
 		return errors.New("event name can not be nil string")
 	}
-	stub.chaincodeEvent = &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	event := &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	if stub.chaincodeEvent == nil {
+		stub.chaincodeEvent = event
+		return nil
+	}
+	// The last event is kept at the top level for consumers
+	// unaware of multiple events
+	events := append(stub.chaincodeEvent.AllEvents(), event)
+	stub.chaincodeEvent = &pb.ChaincodeEvent{EventName: name, Payload: payload, Events: events}
 	return nil
 }
 
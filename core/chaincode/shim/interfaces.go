@@ -76,6 +76,31 @@ type ChaincodeStubInterface interface {
 	// If `channel` is empty, the caller's channel is assumed.
 	InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response
 
+	// CreateCrossChannelTransfer records the transfer of the given `payload`
+	// to the chaincode `chaincodeName` on the channel `channel`, which must
+	// differ from the caller's channel. The transfer is recorded in the state
+	// of the calling chaincode along with the rest of the transaction's writes,
+	// and takes effect only once the transaction is committed as valid.
+	// The target chaincode can then consume the transfer with
+	// ConsumeCrossChannelTransfer, given a proof of the transaction.
+	CreateCrossChannelTransfer(channel, chaincodeName string, payload []byte) error
+
+	// ConsumeCrossChannelTransfer verifies the given `proof` of a transfer to
+	// the calling chaincode, created by a chaincode on another channel with
+	// CreateCrossChannelTransfer, and returns the transfer.
+	// The proof is a marshaled CrossChannelTransferProof, carrying the block
+	// of the source channel that contains the transaction which created the
+	// transfer, the last config block of the source channel, the block that
+	// defines the source chaincode, the headers linking them, and qscc
+	// attestations that the transaction has been committed as valid. The proof
+	// is verified on its own, against the block validation policy of the
+	// caller's channel, so the peer doesn't need to have joined the source
+	// channel. A transfer can only be consumed once: consuming it adds a record
+	// holding the proof to the state of the calling chaincode, the proof is
+	// verified again on validation, and the transaction fails validation if the
+	// transfer has been consumed by a concurrent transaction.
+	ConsumeCrossChannelTransfer(proof []byte) (*pb.CrossChannelTransfer, error)
+
 	// GetState returns the value of the specified `key` from the
 	// ledger. Note that GetState doesn't read data from the writeset, which
 	// has not been committed to the ledger. In other words, GetState doesn't
@@ -167,6 +192,14 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetPrivateDataHash returns the hash of the value of the specified `key` from
+	// the specified `collection`. Unlike GetPrivateData, it can be called by any
+	// chaincode on any peer of the channel, including the peers of organizations
+	// that are not members of the `collection`, which allows chaincodes to verify
+	// claims about private data without having access to it. Like GetPrivateData,
+	// it doesn't consider data modified by PutPrivateData that has not been committed.
+	GetPrivateDataHash(collection, key string) ([]byte, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -175,7 +208,10 @@ type ChaincodeStubInterface interface {
 	// transaction is validated and successfully committed. Simple keys must not be
 	// an empty string and must not start with null character (0x00), in order to
 	// avoid range query collisions with composite keys, which internally get
-	// prefixed with 0x00 as composite key namespace.
+	// prefixed with 0x00 as composite key namespace. Besides the collections of
+	// its collection config, a chaincode may use, for every org, the implicit
+	// collection `_implicit_org_<MSPID>`, whose only member is the org, on the
+	// channels with the V1_3 application capability.
 	PutPrivateData(collection string, key string, value []byte) error
 
 	// DelState records the specified `key` to be deleted in the private writeset of
@@ -258,7 +294,11 @@ type ChaincodeStubInterface interface {
 	// SetEvent allows the chaincode to set an event on the response to the
 	// proposal to be included as part of a transaction. The event will be
 	// available within the transaction in the committed block regardless of the
-	// validity of the transaction.
+	// validity of the transaction. SetEvent can be called several times to set
+	// multiple events, which are delivered in the order they were set. For
+	// consumers unaware of multiple events, the last event set is also kept in
+	// the single event fields of the ChaincodeEvent, so that its payload is
+	// included twice in the transaction when several events are set.
 	SetEvent(name string, payload []byte) error
 }
 
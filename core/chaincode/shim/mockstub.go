@@ -160,6 +160,16 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 	return m[key], nil
 }
 
+// GetPrivateDataHash returns the hash of the value of the given key in the given
+// collection, or nil if the key doesn't exist
+func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, err := stub.GetPrivateData(collection, key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	return util.ComputeSHA256(value), nil
+}
+
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
 	m, in := stub.PvtState[collection]
 	if !in {
@@ -325,6 +335,16 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
+// Not implemented
+func (stub *MockStub) CreateCrossChannelTransfer(channel, chaincodeName string, payload []byte) error {
+	return errors.New("not implemented")
+}
+
+// Not implemented
+func (stub *MockStub) ConsumeCrossChannelTransfer(proof []byte) (*pb.CrossChannelTransfer, error) {
+	return nil, errors.New("not implemented")
+}
+
 // Not implemented
 func (stub *MockStub) GetCreator() ([]byte, error) {
 	return nil, nil
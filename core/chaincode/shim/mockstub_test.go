@@ -23,7 +23,9 @@ import (
 	"testing"
 
 	"github.com/sinochem-tech/fabric/common/flogging"
+	"github.com/sinochem-tech/fabric/common/util"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMockStateRangeQueryIterator(t *testing.T) {
@@ -223,6 +225,21 @@ func TestGetStateByPartialCompositeKeyCollision(t *testing.T) {
 	}
 }
 
+func TestMockStubGetPrivateDataHash(t *testing.T) {
+	stub := NewMockStub("GetPrivateDataHash", nil)
+	stub.MockTransactionStart("init")
+	stub.PutPrivateData("coll", "key", []byte("value"))
+	stub.MockTransactionEnd("init")
+
+	hash, err := stub.GetPrivateDataHash("coll", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte("value")), hash)
+
+	hash, err = stub.GetPrivateDataHash("coll", "other-key")
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+}
+
 func TestGetTxTimestamp(t *testing.T) {
 	stub := NewMockStub("GetTxTimestamp", nil)
 	stub.MockTransactionStart("init")
@@ -93,6 +93,12 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.historyq(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
+	} else if function == "xtransfer" {
+		return t.xtransfer(stub, args)
+	} else if function == "xconsume" {
+		return t.xconsume(stub, args)
+	} else if function == "pvthash" {
+		return t.pvthash(stub, args)
 	}
 
 	return Error("Invalid invoke function name. Expecting \"invoke\" \"delete\" \"query\"")
@@ -297,6 +303,47 @@ func (t *shimTestCC) richq(stub ChaincodeStubInterface, args []string) pb.Respon
 }
 
 // rangeq calls range query
+// xtransfer transfers a payload to a chaincode of another channel
+func (t *shimTestCC) xtransfer(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 3 {
+		return Error("Incorrect number of arguments. Expecting 3")
+	}
+
+	if err := stub.CreateCrossChannelTransfer(args[0], args[1], []byte(args[2])); err != nil {
+		return Error(err.Error())
+	}
+
+	return Success(nil)
+}
+
+// xconsume consumes a transfer from a chaincode of another channel
+func (t *shimTestCC) xconsume(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	transfer, err := stub.ConsumeCrossChannelTransfer([]byte(args[0]))
+	if err != nil {
+		return Error(err.Error())
+	}
+
+	return Success(transfer.Payload)
+}
+
+// pvthash returns the hash of a private data value
+func (t *shimTestCC) pvthash(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	hash, err := stub.GetPrivateDataHash(args[0], args[1])
+	if err != nil {
+		return Error(err.Error())
+	}
+
+	return Success(hash)
+}
+
 func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Response {
 	if len(args) < 1 {
 		return Error("Incorrect number of arguments. Expecting 1")
@@ -468,6 +515,30 @@ func TestNilEventName(t *testing.T) {
 
 }
 
+func TestMultipleEvents(t *testing.T) {
+	stub := ChaincodeStub{}
+	err := stub.SetEvent("first", []byte("first payload"))
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "first", Payload: []byte("first payload")}, stub.chaincodeEvent)
+
+	err = stub.SetEvent("second", []byte("second payload"))
+	assert.NoError(t, err)
+	err = stub.SetEvent("third", []byte("third payload"))
+	assert.NoError(t, err)
+
+	// The last event is duplicated at the top level for legacy consumers
+	assert.Equal(t, "third", stub.chaincodeEvent.EventName)
+	assert.Equal(t, []byte("third payload"), stub.chaincodeEvent.Payload)
+	last := stub.chaincodeEvent.Events[len(stub.chaincodeEvent.Events)-1]
+	assert.Equal(t, stub.chaincodeEvent.EventName, last.EventName)
+	assert.Equal(t, stub.chaincodeEvent.Payload, last.Payload)
+	assert.Equal(t, []*pb.ChaincodeEvent{
+		{EventName: "first", Payload: []byte("first payload")},
+		{EventName: "second", Payload: []byte("second payload")},
+		{EventName: "third", Payload: []byte("third payload")},
+	}, stub.chaincodeEvent.AllEvents())
+}
+
 type testCase struct {
 	name         string
 	ccLogLevel   string
@@ -833,6 +904,86 @@ func TestInvoke(t *testing.T) {
 
 	//wait for done
 	processDone(t, done, false)
+
+	//cross-channel transfer
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_CREATE_TRANSFER, Txid: "9", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "9", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "9", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("xtransfer"), []byte("otherchannel"), []byte("othercc"), []byte("100")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "9", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//cross-channel transfer error
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_CREATE_TRANSFER, Txid: "9a", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "9a", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "9a", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("xtransfer"), []byte("otherchannel"), []byte("othercc"), []byte("100")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "9a", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//cross-channel transfer consumption
+	proof := utils.MarshalOrPanic(&pb.CrossChannelTransferProof{Block: []byte("block"), TxId: "9"})
+	transfer := &pb.CrossChannelTransfer{SourceChannel: "otherchannel", SourceChaincode: "othercc", TargetChannel: channelId, TargetChaincode: ccname, TxId: "9", Payload: []byte("100")}
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_CONSUME_TRANSFER, Txid: "10", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: utils.MarshalOrPanic(transfer), Txid: "10", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "10", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("xconsume"), proof}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "10", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//cross-channel transfer consumption error
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_CONSUME_TRANSFER, Txid: "10a", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "10a", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "10a", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("xconsume"), proof}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "10a", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//private data hash
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH, Txid: "11", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("hash"), Txid: "11", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "11", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("pvthash"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "11", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//private data hash error
+	respSet = &mockpeer.MockResponseSet{errorFunc, errorFunc, []*mockpeer.MockResponse{
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH, Txid: "11a", ChannelId: channelId}, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "11a", ChannelId: channelId}},
+		{&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "11a", ChannelId: channelId}, nil}}}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("pvthash"), []byte("coll"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "11a", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
 }
 
 func TestStartInProc(t *testing.T) {
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"github.com/sinochem-tech/fabric/common/policies"
+	"github.com/sinochem-tech/fabric/core/common/xchannel"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TransferVerifier verifies proofs of cross-channel transfers.
+type TransferVerifier interface {
+	// VerifyTransfer verifies that the given proof attests a transfer to the
+	// given chaincode of the given channel, and returns the transfer
+	VerifyTransfer(channelID, chaincodeName string, proof *pb.CrossChannelTransferProof) (*pb.CrossChannelTransfer, error)
+}
+
+// TransferProofVerifier verifies proofs of cross-channel transfers on their
+// own, so the peer doesn't need to have joined the source channel. The block
+// of the proof must satisfy the block validation policy of the target channel.
+type TransferProofVerifier struct {
+	// PolicyManagerGetter is used to get the block validation
+	// policy of the target channel
+	PolicyManagerGetter policies.ChannelPolicyManagerGetter
+}
+
+// VerifyTransfer verifies the proof with xchannel.VerifyTransfer, against the block
+// validation policy of the given channel.
+func (v *TransferProofVerifier) VerifyTransfer(channelID, chaincodeName string, proof *pb.CrossChannelTransferProof) (*pb.CrossChannelTransfer, error) {
+	cpm, _ := v.PolicyManagerGetter.Manager(channelID)
+	if cpm == nil {
+		return nil, errors.Errorf("could not acquire policy manager for channel %s", channelID)
+	}
+	policy, ok := cpm.GetPolicy(policies.BlockValidation)
+	if !ok {
+		return nil, errors.Errorf("block validation policy of channel %s not found", channelID)
+	}
+	return xchannel.VerifyTransfer(channelID, chaincodeName, proof, policy)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	mockpolicies "github.com/sinochem-tech/fabric/common/mocks/policies"
+	"github.com/sinochem-tech/fabric/common/policies"
+	"github.com/sinochem-tech/fabric/core/chaincode"
+	policymocks "github.com/sinochem-tech/fabric/core/policy/mocks"
+	cb "github.com/sinochem-tech/fabric/protos/common"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("TransferProofVerifier", func() {
+	var (
+		blockPolicy *mockpolicies.Policy
+		proof       *pb.CrossChannelTransferProof
+
+		verifier *chaincode.TransferProofVerifier
+	)
+
+	marshal := func(msg proto.Message) []byte {
+		b, err := proto.Marshal(msg)
+		Expect(err).NotTo(HaveOccurred())
+		return b
+	}
+
+	BeforeEach(func() {
+		env := &cb.Envelope{
+			Payload: marshal(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader: marshal(&cb.ChannelHeader{
+						Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+						ChannelId: "source-channel-id",
+						TxId:      "source-tx-id",
+					}),
+				},
+			}),
+		}
+		block := &cb.Block{
+			Header: &cb.BlockHeader{Number: 7},
+			Data:   &cb.BlockData{Data: [][]byte{marshal(env)}},
+		}
+		block.Header.DataHash = block.Data.Hash()
+		block.Metadata = &cb.BlockMetadata{
+			Metadata: [][]byte{
+				marshal(&cb.Metadata{
+					Signatures: []*cb.MetadataSignature{{
+						SignatureHeader: marshal(&cb.SignatureHeader{Creator: []byte("orderer")}),
+						Signature:       []byte("orderer-signature"),
+					}},
+				}),
+				{}, {}, {},
+			},
+		}
+		proof = &pb.CrossChannelTransferProof{
+			Block: marshal(block),
+			TxId:  "source-tx-id",
+		}
+
+		blockPolicy = &mockpolicies.Policy{Err: errors.New("banana")}
+		verifier = &chaincode.TransferProofVerifier{
+			PolicyManagerGetter: &policymocks.MockChannelPolicyManagerGetter{
+				Managers: map[string]policies.Manager{
+					"channel-id": &mockpolicies.Manager{
+						PolicyMap: map[string]policies.Policy{policies.BlockValidation: blockPolicy},
+					},
+				},
+			},
+		}
+	})
+
+	It("verifies the proof against the block validation policy of the target channel", func() {
+		_, err := verifier.VerifyTransfer("channel-id", "cc-name", proof)
+		Expect(err).To(MatchError("block is not signed by the ordering service of channel channel-id: banana"))
+	})
+
+	Context("when the proof is invalid", func() {
+		BeforeEach(func() {
+			proof.Block = []byte("this-is-a-bogus-block")
+		})
+
+		It("returns an error", func() {
+			_, err := verifier.VerifyTransfer("channel-id", "cc-name", proof)
+			Expect(err).To(MatchError(ContainSubstring("failed unmarshaling block")))
+		})
+	})
+
+	Context("when the policy manager of the channel can't be found", func() {
+		It("returns an error", func() {
+			_, err := verifier.VerifyTransfer("another-channel-id", "cc-name", proof)
+			Expect(err).To(MatchError("could not acquire policy manager for channel another-channel-id"))
+		})
+	})
+
+	Context("when the channel has no block validation policy", func() {
+		BeforeEach(func() {
+			verifier.PolicyManagerGetter = &policymocks.MockChannelPolicyManagerGetter{
+				Managers: map[string]policies.Manager{
+					"channel-id": &mockpolicies.Manager{PolicyMap: map[string]policies.Policy{}},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := verifier.VerifyTransfer("channel-id", "cc-name", proof)
+			Expect(err).To(MatchError("block validation policy of channel channel-id not found"))
+		})
+	})
+})
@@ -51,6 +51,26 @@ type Committer interface {
 	// GetConfigHistoryRetriever returns the ConfigHistoryRetriever
 	GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error)
 
+	// GetMissingPvtDataInfoForBlocksBelow returns the private data that was missing at the
+	// commit of the most recent blocks lower than blockNum, for at most maxBlocks blocks
+	GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error)
+
+	// CommitPvtDataOfOldBlocks commits the private data that was missing
+	// at the commit of already committed blocks
+	CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error
+
+	// RecordMissingPvtDataOfOldBlocks records the given private data of
+	// already committed blocks as missing
+	RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error
+
+	// GetPvtDataBackfillHeight returns the height up to which the
+	// blocks were inspected by the private data back-fill
+	GetPvtDataBackfillHeight() (uint64, error)
+
+	// SetPvtDataBackfillHeight records the height up to which the
+	// blocks were inspected by the private data back-fill
+	SetPvtDataBackfillHeight(height uint64) error
+
 	// Closes committing service
 	Close()
 }
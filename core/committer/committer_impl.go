@@ -52,6 +52,16 @@ type PeerLedgerSupport interface {
 
 	GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, error)
 
+	GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error)
+
+	CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error
+
+	RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error
+
+	GetPvtDataBackfillHeight() (uint64, error)
+
+	SetPvtDataBackfillHeight(height uint64) error
+
 	Close()
 }
 
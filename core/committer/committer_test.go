@@ -37,6 +37,34 @@ func (m *mockLedger) GetConfigHistoryRetriever() (ledger2.ConfigHistoryRetriever
 	return args.Get(0).(ledger2.ConfigHistoryRetriever), args.Error(1)
 }
 
+func (m *mockLedger) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger2.MissingPvtDataInfo, error) {
+	args := m.Called(blockNum, maxBlocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(ledger2.MissingPvtDataInfo), args.Error(1)
+}
+
+func (m *mockLedger) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger2.BlockPvtData) error {
+	args := m.Called(blocksPvtData)
+	return args.Error(0)
+}
+
+func (m *mockLedger) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger2.MissingPvtDataInfo) error {
+	args := m.Called(missingPvtData)
+	return args.Error(0)
+}
+
+func (m *mockLedger) GetPvtDataBackfillHeight() (uint64, error) {
+	args := m.Called()
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *mockLedger) SetPvtDataBackfillHeight(height uint64) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
 func (m *mockLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	info := &common.BlockchainInfo{
 		Height:            m.height,
@@ -133,3 +133,17 @@ func (_m *Capabilities) V1_2Validation() bool {
 
 	return r0
 }
+
+// V1_3Validation provides a mock function with given fields:
+func (_m *Capabilities) V1_3Validation() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/handlers/validation/api"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/capabilities"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/identities"
+	validationpolicies "github.com/sinochem-tech/fabric/core/handlers/validation/api/policies"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/state"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/msp"
@@ -72,20 +73,23 @@ type PluginValidator struct {
 	PluginMapper
 	QueryExecutorCreator
 	msp.IdentityDeserializer
-	capabilities Capabilities
+	capabilities           Capabilities
+	channelPolicyEvaluator validationpolicies.ChannelPolicyEvaluator
 }
 
 //go:generate mockery -dir ../../handlers/validation/api/capabilities/ -name Capabilities -case underscore -output mocks/
 //go:generate mockery -dir ../../../msp/ -name IdentityDeserializer -case underscore -output mocks/
+//go:generate mockery -dir ../../handlers/validation/api/policies/ -name ChannelPolicyEvaluator -case underscore -output mocks/
 
 // NewPluginValidator creates a new PluginValidator
-func NewPluginValidator(pm PluginMapper, qec QueryExecutorCreator, deserializer msp.IdentityDeserializer, capabilities Capabilities) *PluginValidator {
+func NewPluginValidator(pm PluginMapper, qec QueryExecutorCreator, deserializer msp.IdentityDeserializer, capabilities Capabilities, channelPolicyEvaluator validationpolicies.ChannelPolicyEvaluator) *PluginValidator {
 	return &PluginValidator{
-		capabilities:         capabilities,
-		pluginChannelMapping: make(map[PluginName]*pluginsByChannel),
-		PluginMapper:         pm,
-		QueryExecutorCreator: qec,
-		IdentityDeserializer: deserializer,
+		capabilities:           capabilities,
+		channelPolicyEvaluator: channelPolicyEvaluator,
+		pluginChannelMapping:   make(map[PluginName]*pluginsByChannel),
+		PluginMapper:           pm,
+		QueryExecutorCreator:   qec,
+		IdentityDeserializer:   deserializer,
 	}
 }
 
@@ -168,7 +172,7 @@ func (pbc *pluginsByChannel) createPluginIfAbsent(channel string) (validation.Pl
 func (pbc *pluginsByChannel) initPlugin(plugin validation.Plugin, channel string) (validation.Plugin, error) {
 	pe := &PolicyEvaluator{IdentityDeserializer: pbc.pv.IdentityDeserializer}
 	sf := &StateFetcherImpl{QueryExecutorCreator: pbc.pv}
-	if err := plugin.Init(pe, sf, pbc.pv.capabilities); err != nil {
+	if err := plugin.Init(pe, sf, pbc.pv.capabilities, pbc.pv.channelPolicyEvaluator); err != nil {
 		return nil, errors.Wrap(err, "failed initializing plugin")
 	}
 	return plugin, nil
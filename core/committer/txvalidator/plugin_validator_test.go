@@ -32,7 +32,7 @@ func TestValidateWithPlugin(t *testing.T) {
 	qec := &mocks.QueryExecutorCreator{}
 	deserializer := &mocks.IdentityDeserializer{}
 	capabilites := &mocks.Capabilities{}
-	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites)
+	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites, &mocks.ChannelPolicyEvaluator{})
 	ctx := &txvalidator.Context{
 		Namespace: "mycc",
 		VSCCName:  "vscc",
@@ -45,7 +45,7 @@ func TestValidateWithPlugin(t *testing.T) {
 	// Scenario II: The plugin initialization fails
 	factory := &mocks.PluginFactory{}
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("foo")).Once()
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("foo")).Once()
 	factory.On("New").Return(plugin)
 	pm["vscc"] = factory
 	err = v.ValidateWithPlugin(ctx)
@@ -53,7 +53,7 @@ func TestValidateWithPlugin(t *testing.T) {
 
 	// Scenario III: The plugin initialization succeeds but an execution error occurs.
 	// The plugin should pass the error as is.
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	validationErr := &validation.ExecutionFailureError{
 		Reason: "bar",
 	}
@@ -62,7 +62,7 @@ func TestValidateWithPlugin(t *testing.T) {
 	assert.Equal(t, validationErr, err)
 
 	// Scenario IV: The plugin initialization succeeds and the validation passes
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	err = v.ValidateWithPlugin(ctx)
 	assert.NoError(t, err)
@@ -101,7 +101,7 @@ func TestSamplePlugin(t *testing.T) {
 
 	txnData, _ := proto.Marshal(&transaction)
 
-	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites)
+	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites, &mocks.ChannelPolicyEvaluator{})
 	acceptAllPolicyBytes, _ := proto.Marshal(cauthdsl.AcceptAllPolicy)
 	ctx := &txvalidator.Context{
 		Namespace: "mycc",
@@ -14,6 +14,7 @@ import (
 	"github.com/sinochem-tech/fabric/common/configtx"
 	commonerrors "github.com/sinochem-tech/fabric/common/errors"
 	"github.com/sinochem-tech/fabric/common/flogging"
+	"github.com/sinochem-tech/fabric/common/policies"
 	"github.com/sinochem-tech/fabric/core/common/sysccprovider"
 	"github.com/sinochem-tech/fabric/core/common/validation"
 	"github.com/sinochem-tech/fabric/core/ledger"
@@ -51,6 +52,9 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// PolicyManager returns the policy manager of this channel
+	PolicyManager() policies.Manager
 }
 
 //Validator interface which defines API to validate block transactions
@@ -100,7 +104,7 @@ type blockValidationResult struct {
 // NewTxValidator creates new transactions validator
 func NewTxValidator(support Support, sccp sysccprovider.SystemChaincodeProvider, pm PluginMapper) *TxValidator {
 	// Encapsulates interface implementation
-	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicCapabilities{support: support})
+	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicCapabilities{support: support}, &dynamicChannelPolicyEvaluator{support: support})
 	return &TxValidator{
 		Support: support,
 		Vscc:    newVSCCValidator(support, sccp, pluginValidator)}
@@ -626,3 +630,19 @@ func (ds *dynamicCapabilities) V1_1Validation() bool {
 func (ds *dynamicCapabilities) V1_2Validation() bool {
 	return ds.support.Capabilities().V1_2Validation()
 }
+
+func (ds *dynamicCapabilities) V1_3Validation() bool {
+	return ds.support.Capabilities().V1_3Validation()
+}
+
+type dynamicChannelPolicyEvaluator struct {
+	support Support
+}
+
+func (pe *dynamicChannelPolicyEvaluator) EvaluateChannelPolicy(policyName string, signatureSet []*common.SignedData) error {
+	policy, ok := pe.support.PolicyManager().GetPolicy(policyName)
+	if !ok {
+		return errors.Errorf("policy %s not found", policyName)
+	}
+	return policy.Evaluate(signatureSet)
+}
@@ -50,7 +50,7 @@ func signedByAnyMember(ids []string) []byte {
 
 func setupLedgerAndValidator(t *testing.T) (ledger.PeerLedger, txvalidator.Validator) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	return setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
 }
@@ -216,7 +216,7 @@ func TestInvokeOK(t *testing.T) {
 
 func TestInvokeNoRWSet(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	t.Run("Pre-1.2Capability", func(t *testing.T) {
 		l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
@@ -258,7 +258,7 @@ func TestInvokeNoRWSet(t *testing.T) {
 
 func TestChaincodeEvent(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	t.Run("PreV1.2", func(t *testing.T) {
@@ -365,12 +365,76 @@ func TestChaincodeEvent(t *testing.T) {
 			assert.NoError(t, err)
 			assertValid(b, t)
 		})
+
+		t.Run("MisMatchedNameInEventsPre1.3Capability", func(t *testing.T) {
+			l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true}, plugin)
+			defer ledgermgmt.CleanupTestEnv()
+			defer l.Close()
+
+			ccID := "mycc"
+
+			putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+			// Only the last event is checked, as peers unaware of multiple events do
+			ccEvent := &peer.ChaincodeEvent{
+				ChaincodeId: ccID,
+				Events:      []*peer.ChaincodeEvent{{ChaincodeId: "wrong"}, {ChaincodeId: ccID}},
+			}
+			tx := getEnv(ccID, utils.MarshalOrPanic(ccEvent), createRWset(t), t)
+			b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+			err := v.Validate(b)
+			assert.NoError(t, err)
+			assertValid(b, t)
+		})
+
+		t.Run("MisMatchedNameInEvents", func(t *testing.T) {
+			l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, V1_3ValidationRv: true}, plugin)
+			defer ledgermgmt.CleanupTestEnv()
+			defer l.Close()
+
+			ccID := "mycc"
+
+			putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+			ccEvent := &peer.ChaincodeEvent{
+				ChaincodeId: ccID,
+				Events:      []*peer.ChaincodeEvent{{ChaincodeId: "wrong"}, {ChaincodeId: ccID}},
+			}
+			tx := getEnv(ccID, utils.MarshalOrPanic(ccEvent), createRWset(t), t)
+			b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+			err := v.Validate(b)
+			assert.NoError(t, err)
+			assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+		})
+
+		t.Run("GoodPathMultipleEvents", func(t *testing.T) {
+			l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, V1_3ValidationRv: true}, plugin)
+			defer ledgermgmt.CleanupTestEnv()
+			defer l.Close()
+
+			ccID := "mycc"
+
+			putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+			ccEvent := &peer.ChaincodeEvent{
+				ChaincodeId: ccID,
+				Events:      []*peer.ChaincodeEvent{{ChaincodeId: ccID}, {ChaincodeId: ccID}},
+			}
+			tx := getEnv(ccID, utils.MarshalOrPanic(ccEvent), createRWset(t), t)
+			b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+			err := v.Validate(b)
+			assert.NoError(t, err)
+			assertValid(b, t)
+		})
 	})
 }
 
 func TestInvokeOKPvtDataOnly(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
 	defer ledgermgmt.CleanupTestEnv()
@@ -402,6 +466,49 @@ func TestInvokeOKPvtDataOnly(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestInvokeImplicitCollections(t *testing.T) {
+	plugin := &mocks.Plugin{}
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ccID := "mycc"
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet(ccID, "_implicit_org_SampleOrg", "somekey", []byte("value"))
+	rwset, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := rwset.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	t.Run("V1_2", func(t *testing.T) {
+		// the peers of a V1_2 channel don't resolve the implicit collections
+		l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{PrivateChannelDataRv: true, V1_2ValidationRv: true}, plugin)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+	})
+
+	t.Run("V1_3", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{PrivateChannelDataRv: true, V1_2ValidationRv: true, V1_3ValidationRv: true}, plugin)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+	})
+}
+
 func TestInvokeOKSCC(t *testing.T) {
 	l, v := setupLedgerAndValidator(t)
 	defer ledgermgmt.CleanupTestEnv()
@@ -690,6 +797,34 @@ func (m *mockLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever,
 	return args.Get(0).(ledger.ConfigHistoryRetriever), nil
 }
 
+func (m *mockLedger) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	args := m.Called(blockNum, maxBlocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(ledger.MissingPvtDataInfo), args.Error(1)
+}
+
+func (m *mockLedger) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	args := m.Called(blocksPvtData)
+	return args.Error(0)
+}
+
+func (m *mockLedger) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	args := m.Called(missingPvtData)
+	return args.Error(0)
+}
+
+func (m *mockLedger) GetPvtDataBackfillHeight() (uint64, error) {
+	args := m.Called()
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *mockLedger) SetPvtDataBackfillHeight(height uint64) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
@@ -726,6 +861,11 @@ func (exec *mockQueryExecutor) GetPrivateData(namespace, collection, key string)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	args := exec.Called(namespace, collection, key)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	args := exec.Called(namespace, collection, keys)
 	return args.Get(0).([][]byte), args.Error(1)
@@ -917,7 +1057,7 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	factory := &mocks.PluginFactory{}
 	plugin := &mocks.Plugin{}
 	factory.On("New").Return(plugin)
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid tx"))
 	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
 	validator := txvalidator.NewTxValidator(vcs, mp, pm)
@@ -968,7 +1108,7 @@ func createMockLedger(t *testing.T, ccID string) *mockLedger {
 
 func TestValidationPluginExecutionError(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
 	defer ledgermgmt.CleanupTestEnv()
@@ -17,10 +17,12 @@ import (
 	commonerrors "github.com/sinochem-tech/fabric/common/errors"
 	coreUtil "github.com/sinochem-tech/fabric/common/util"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/common/sysccprovider"
 	"github.com/sinochem-tech/fabric/core/handlers/validation/api"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
 	"github.com/sinochem-tech/fabric/protos/peer"
 	"github.com/sinochem-tech/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -107,6 +109,15 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		return err, peer.TxValidationCode_INVALID_OTHER_REASON
 	}
 
+	// The implicit collections are only resolved by the peers
+	// of the channels with the V1_3 application capability
+	if usesImplicitCollections(txRWSet) && !v.support.Capabilities().V1_3Validation() {
+		if err = v.validateImplicitCollectionsDeclared(respPayload.Results); err != nil {
+			logger.Errorf("VSCCValidateTx for txId = %s refused implicit collections: %+v", chdr.TxId, err)
+			return err, peer.TxValidationCode_INVALID_OTHER_REASON
+		}
+	}
+
 	var wrNamespace []string
 	alwaysEnforceOriginalNamespace := v.support.Capabilities().V1_2Validation()
 	if alwaysEnforceOriginalNamespace {
@@ -119,6 +130,15 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			if ccEvent.ChaincodeId != ccID {
 				return errors.Errorf("chaincode event chaincode id does not match chaincode action chaincode id"), peer.TxValidationCode_INVALID_OTHER_REASON
 			}
+			// The events set before the last one are only checked once
+			// all the peers of the channel are aware of multiple events
+			if v.support.Capabilities().V1_3Validation() {
+				for _, event := range ccEvent.Events {
+					if event.ChaincodeId != ccID {
+						return errors.Errorf("chaincode event chaincode id does not match chaincode action chaincode id"), peer.TxValidationCode_INVALID_OTHER_REASON
+					}
+				}
+			}
 		}
 	}
 
@@ -271,6 +291,43 @@ func (v *VsccValidatorImpl) VSCCValidateTxForCC(ctx *Context) error {
 	return &commonerrors.VSCCEndorsementPolicyError{Err: err}
 }
 
+// usesImplicitCollections returns whether the given read write set uses collections named as implicit collections
+func usesImplicitCollections(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, ns := range txRWSet.NsRwSets {
+		for _, coll := range ns.CollHashedRwSets {
+			if isImplicit, _ := privdata.MspIDIfImplicitCollection(coll.CollectionName); isImplicit {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateImplicitCollectionsDeclared returns an error if the given read write set uses
+// implicit collections that the collection configs of their chaincodes don't declare
+func (v *VsccValidatorImpl) validateImplicitCollectionsDeclared(results []byte) error {
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(results, txRWSet); err != nil {
+		return errors.Wrap(err, "unmarshalling TxReadWriteSet failed")
+	}
+
+	l := v.support.Ledger()
+	if l == nil {
+		return errors.New("nil ledger instance")
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return errors.WithMessage(err, "could not retrieve QueryExecutor")
+	}
+	defer qe.Done()
+
+	err = privdata.ValidateImplicitCollectionsDeclared(txRWSet, qe)
+	if _, undeclared := err.(privdata.UndeclaredImplicitCollectionError); err != nil && !undeclared {
+		return &commonerrors.VSCCInfoLookupFailureError{Reason: fmt.Sprintf("Could not retrieve collection configs, error %s", err)}
+	}
+	return err
+}
+
 func (v *VsccValidatorImpl) getCDataForCC(chid, ccid string) (ccprovider.ChaincodeDefinition, error) {
 	l := v.support.Ledger()
 	if l == nil {
@@ -9,6 +9,7 @@ package privdata
 import (
 	"strings"
 
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
 	"github.com/sinochem-tech/fabric/protos/common"
 )
 
@@ -99,8 +100,25 @@ const (
 	// collectionSuffix is the suffix of the KVS key storing the
 	// collections of a chaincode
 	collectionSuffix = "collection"
+	// LSCCNamespace is the namespace of the KVS keys storing the collections of the chaincodes
+	LSCCNamespace = "lscc"
+	// ImplicitCollectionNamePrefix is the prefix of the names of the implicit
+	// collections; every chaincode has, for every org, an implicit collection
+	// whose only member is the org, without declaring it in its collection config
+	ImplicitCollectionNamePrefix = "_implicit_org_"
 )
 
+// ImplicitCollectionDisseminationPolicy specifies the dissemination
+// of the private data of the implicit collections
+type ImplicitCollectionDisseminationPolicy struct {
+	// RequiredPeerCount is the minimum number of peers of the org
+	// that the private data must be disseminated to
+	RequiredPeerCount int
+	// MaxPeerCount is the maximum number of peers of the org
+	// that the private data is disseminated to
+	MaxPeerCount int
+}
+
 // BuildCollectionKVSKey returns the KVS key string for a chaincode, given its name and version
 func BuildCollectionKVSKey(ccname string) string {
 	return ccname + collectionSeparator + collectionSuffix
@@ -110,3 +128,49 @@ func BuildCollectionKVSKey(ccname string) string {
 func IsCollectionConfigKey(key string) bool {
 	return strings.Contains(key, collectionSeparator)
 }
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the org with the given MSP ID
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return ImplicitCollectionNamePrefix + mspID
+}
+
+// MspIDIfImplicitCollection returns whether the collection with the given name is
+// an implicit collection and, if it is, the MSP ID of the org that is its only member
+func MspIDIfImplicitCollection(collectionName string) (isImplicit bool, mspID string) {
+	if !strings.HasPrefix(collectionName, ImplicitCollectionNamePrefix) {
+		return false, ""
+	}
+	mspID = strings.TrimPrefix(collectionName, ImplicitCollectionNamePrefix)
+	return mspID != "", mspID
+}
+
+// GenerateImplicitCollectionForOrg returns the configuration of the implicit collection of the
+// org with the given MSP ID: its member policy is satisfied by any member of the org, its data
+// never expires, and it's disseminated according to the given dissemination policy
+func GenerateImplicitCollectionForOrg(mspID string, policy ImplicitCollectionDisseminationPolicy) *common.StaticCollectionConfig {
+	requiredPeerCount := int32(policy.RequiredPeerCount)
+	maxPeerCount := int32(policy.MaxPeerCount)
+	if maxPeerCount < requiredPeerCount {
+		maxPeerCount = requiredPeerCount
+	}
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByAnyMember([]string{mspID}),
+			},
+		},
+		RequiredPeerCount: requiredPeerCount,
+		MaximumPeerCount:  maxPeerCount,
+	}
+}
+
+// ImplicitCollectionConfigForOrg returns the configuration of the implicit collection
+// of the org with the given MSP ID, wrapped as a generic collection configuration
+func ImplicitCollectionConfigForOrg(mspID string, policy ImplicitCollectionDisseminationPolicy) *common.CollectionConfig {
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: GenerateImplicitCollectionForOrg(mspID, policy),
+		},
+	}
+}
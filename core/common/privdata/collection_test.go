@@ -9,6 +9,7 @@ package privdata
 import (
 	"testing"
 
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +27,38 @@ func TestIsCollectionConfigKey(t *testing.T) {
 	isCollection = IsCollectionConfigKey("chaincodeKey~collection")
 	assert.True(t, isCollection, "key with tilda is a collection key and should have returned true")
 }
+
+func TestImplicitCollectionName(t *testing.T) {
+	name := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", name)
+
+	isImplicit, mspID := MspIDIfImplicitCollection(name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	isImplicit, _ = MspIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+
+	isImplicit, _ = MspIDIfImplicitCollection(ImplicitCollectionNamePrefix)
+	assert.False(t, isImplicit)
+}
+
+func TestGenerateImplicitCollectionForOrg(t *testing.T) {
+	conf := GenerateImplicitCollectionForOrg("Org1MSP", ImplicitCollectionDisseminationPolicy{MaxPeerCount: 1})
+	assert.Equal(t, "_implicit_org_Org1MSP", conf.Name)
+	assert.Equal(t, cauthdsl.SignedByAnyMember([]string{"Org1MSP"}), conf.MemberOrgsPolicy.GetSignaturePolicy())
+	assert.Equal(t, int32(0), conf.RequiredPeerCount)
+	assert.Equal(t, int32(1), conf.MaximumPeerCount)
+	assert.Equal(t, uint64(0), conf.BlockToLive)
+
+	conf = GenerateImplicitCollectionForOrg("Org1MSP", ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 2, MaxPeerCount: 3})
+	assert.Equal(t, int32(2), conf.RequiredPeerCount)
+	assert.Equal(t, int32(3), conf.MaximumPeerCount)
+
+	// the maximum peer count can't be lower than the required one
+	policy := ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 2, MaxPeerCount: 1}
+	conf = GenerateImplicitCollectionForOrg("Org1MSP", policy)
+	assert.Equal(t, int32(2), conf.MaximumPeerCount)
+
+	assert.Equal(t, conf, ImplicitCollectionConfigForOrg("Org1MSP", policy).GetStaticCollectionConfig())
+}
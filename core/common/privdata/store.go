@@ -13,6 +13,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/msp"
 	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
 	"github.com/pkg/errors"
 )
 
@@ -38,16 +39,26 @@ func (f NoSuchCollectionError) Error() string {
 	return fmt.Sprintf("collection %s/%s/%s could not be found", f.Channel, f.Namespace, f.Collection)
 }
 
+// UndeclaredImplicitCollectionError is returned when an implicit collection
+// is used on a channel without the V1_3 application capability
+type UndeclaredImplicitCollectionError common.CollectionCriteria
+
+func (f UndeclaredImplicitCollectionError) Error() string {
+	return fmt.Sprintf("implicit collection %s of chaincode %s requires the V1_3 application capability", f.Collection, f.Namespace)
+}
+
 type simpleCollectionStore struct {
-	s Support
+	s                        Support
+	implicitCollectionPolicy ImplicitCollectionDisseminationPolicy
 }
 
 // NewSimpleCollectionStore returns a collection stored backed
 // by a ledger supplied by the specified ledgerGetter with
 // an internal name formed as specified by the supplied
-// collectionNamer function
-func NewSimpleCollectionStore(s Support) CollectionStore {
-	return &simpleCollectionStore{s}
+// collectionNamer function. The private data of the implicit
+// collections is disseminated according to the given policy
+func NewSimpleCollectionStore(s Support, implicitCollectionPolicy ImplicitCollectionDisseminationPolicy) CollectionStore {
+	return &simpleCollectionStore{s: s, implicitCollectionPolicy: implicitCollectionPolicy}
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.CollectionCriteria) (*common.CollectionConfigPackage, error) {
@@ -61,7 +72,7 @@ func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.Collec
 
 // RetrieveCollectionConfigPackageFromState retrieves the collection config package from the given key from the given state
 func RetrieveCollectionConfigPackageFromState(cc common.CollectionCriteria, state State) (*common.CollectionConfigPackage, error) {
-	cb, err := state.GetState("lscc", BuildCollectionKVSKey(cc.Namespace))
+	cb, err := state.GetState(LSCCNamespace, BuildCollectionKVSKey(cc.Namespace))
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection for collection criteria %#v", cc))
 	}
@@ -75,6 +86,34 @@ func RetrieveCollectionConfigPackageFromState(cc common.CollectionCriteria, stat
 	return conf, nil
 }
 
+// ValidateImplicitCollectionsDeclared returns an error if the given read write set uses an implicit
+// collection that the collection config of its chaincode, retrieved from the given state, doesn't
+// declare. Such collections are only resolved on channels with the V1_3 application capability.
+func ValidateImplicitCollectionsDeclared(txRWSet *rwset.TxReadWriteSet, state State) error {
+	for _, nsRWSet := range txRWSet.GetNsRwset() {
+		var declared map[string]struct{}
+		for _, collRWSet := range nsRWSet.CollectionHashedRwset {
+			if isImplicit, _ := MspIDIfImplicitCollection(collRWSet.CollectionName); !isImplicit {
+				continue
+			}
+			if declared == nil {
+				conf, err := RetrieveCollectionConfigPackageFromState(common.CollectionCriteria{Namespace: nsRWSet.Namespace}, state)
+				if _, noConfig := err.(NoSuchCollectionError); err != nil && !noConfig {
+					return err
+				}
+				declared = make(map[string]struct{})
+				for _, cconf := range conf.GetConfig() {
+					declared[cconf.GetStaticCollectionConfig().GetName()] = struct{}{}
+				}
+			}
+			if _, found := declared[collRWSet.CollectionName]; !found {
+				return UndeclaredImplicitCollectionError{Namespace: nsRWSet.Namespace, Collection: collRWSet.CollectionName}
+			}
+		}
+	}
+	return nil
+}
+
 // ParseCollectionConfig parses the collection configuration from the given serialized representation
 func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, error) {
 	collections := &common.CollectionConfigPackage{}
@@ -87,8 +126,13 @@ func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, er
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCriteria) (*common.StaticCollectionConfig, error) {
+	isImplicit, mspID := MspIDIfImplicitCollection(cc.Collection)
 	collections, err := c.retrieveCollectionConfigPackage(cc)
 	if err != nil {
+		// implicit collections exist even if the chaincode has no collection config
+		if _, noConfig := err.(NoSuchCollectionError); noConfig && isImplicit {
+			return GenerateImplicitCollectionForOrg(mspID, c.implicitCollectionPolicy), nil
+		}
 		return nil, err
 	}
 	if collections == nil {
@@ -104,6 +148,11 @@ func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCri
 			return nil, errors.New("unexpected collection type")
 		}
 	}
+	// a collection declared by the chaincode takes precedence over the implicit collection
+	// of the same name, since such a name could be declared before it was reserved
+	if isImplicit {
+		return GenerateImplicitCollectionForOrg(mspID, c.implicitCollectionPolicy), nil
+	}
 	return nil, NoSuchCollectionError(cc)
 }
 
@@ -128,6 +177,7 @@ func (c *simpleCollectionStore) RetrieveCollectionAccessPolicy(cc common.Collect
 	return c.retrieveSimpleCollection(cc)
 }
 
+// RetrieveCollectionConfigPackage retrieves the collections declared by the chaincode, which exclude the implicit ones
 func (c *simpleCollectionStore) RetrieveCollectionConfigPackage(cc common.CollectionCriteria) (*common.CollectionConfigPackage, error) {
 	return c.retrieveCollectionConfigPackage(cc)
 }
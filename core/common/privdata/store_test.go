@@ -15,6 +15,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/msp"
 	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb/errors"
 )
@@ -35,7 +36,7 @@ func (c *mockStoreSupport) GetIdentityDeserializer(chainID string) msp.IdentityD
 func TestCollectionStore(t *testing.T) {
 	wState := make(map[string]map[string][]byte)
 	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{wState}}
-	cs := NewSimpleCollectionStore(support)
+	cs := NewSimpleCollectionStore(support, ImplicitCollectionDisseminationPolicy{})
 	assert.NotNil(t, cs)
 
 	support.QErr = errors.New("")
@@ -94,3 +95,87 @@ func TestCollectionStore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, ccc)
 }
+
+func TestCollectionStoreImplicitCollection(t *testing.T) {
+	// implicit collections are resolved without any collection config of the chaincode
+	wState := map[string]map[string][]byte{"lscc": {}}
+	policy := ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 1, MaxPeerCount: 2}
+	cs := NewSimpleCollectionStore(&mockStoreSupport{Qe: &lm.MockQueryExecutor{wState}}, policy)
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: ImplicitCollectionNameForOrg("Org1MSP")}
+
+	c, err := cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "_implicit_org_Org1MSP", c.CollectionID())
+	assert.Equal(t, []string{"Org1MSP"}, c.MemberOrgs())
+
+	ca, err := cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ca.RequiredPeerCount())
+	assert.Equal(t, 2, ca.MaximumPeerCount())
+
+	pc, err := cs.RetrieveCollectionPersistenceConfigs(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pc.BlockToLive())
+
+	// the implicit collections aren't part of the declared collections of the chaincode
+	_, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.Equal(t, NoSuchCollectionError(ccr), err)
+
+	// and they exist along with the declared collections
+	cc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{
+		Name:             "mycollection",
+		MemberOrgsPolicy: createCollectionPolicyConfig(cauthdsl.SignedByAnyMember([]string{"Org2MSP"})),
+	}}}
+	ccpBytes, err := proto.Marshal(&common.CollectionConfigPackage{Config: []*common.CollectionConfig{cc}})
+	assert.NoError(t, err)
+	wState["lscc"][BuildCollectionKVSKey(ccr.Namespace)] = ccpBytes
+	c, err = cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP"}, c.MemberOrgs())
+
+	// unless the chaincode declares a collection of the same name
+	cc.GetStaticCollectionConfig().Name = ccr.Collection
+	cc.GetStaticCollectionConfig().BlockToLive = 10
+	ccpBytes, err = proto.Marshal(&common.CollectionConfigPackage{Config: []*common.CollectionConfig{cc}})
+	assert.NoError(t, err)
+	wState["lscc"][BuildCollectionKVSKey(ccr.Namespace)] = ccpBytes
+	c, err = cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Org2MSP"}, c.MemberOrgs())
+	pc, err = cs.RetrieveCollectionPersistenceConfigs(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), pc.BlockToLive())
+}
+
+func TestValidateImplicitCollectionsDeclared(t *testing.T) {
+	wState := map[string]map[string][]byte{"lscc": {}}
+	qe := &lm.MockQueryExecutor{wState}
+	txRWSet := &rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace: "cc",
+				CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{
+					{CollectionName: "mycollection"},
+					{CollectionName: ImplicitCollectionNameForOrg("Org1MSP")},
+				},
+			},
+		},
+	}
+
+	// implicit collections that the chaincode doesn't declare are refused
+	err := ValidateImplicitCollectionsDeclared(txRWSet, qe)
+	assert.EqualError(t, err, "implicit collection _implicit_org_Org1MSP of chaincode cc requires the V1_3 application capability")
+	cc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: "mycollection"}}}
+	ccpBytes, err := proto.Marshal(&common.CollectionConfigPackage{Config: []*common.CollectionConfig{cc}})
+	assert.NoError(t, err)
+	wState["lscc"][BuildCollectionKVSKey("cc")] = ccpBytes
+	assert.Error(t, ValidateImplicitCollectionsDeclared(txRWSet, qe))
+
+	// while the declared ones are accepted
+	cc.GetStaticCollectionConfig().Name = ImplicitCollectionNameForOrg("Org1MSP")
+	ccpBytes, err = proto.Marshal(&common.CollectionConfigPackage{Config: []*common.CollectionConfig{cc}})
+	assert.NoError(t, err)
+	wState["lscc"][BuildCollectionKVSKey("cc")] = ccpBytes
+	assert.NoError(t, ValidateImplicitCollectionsDeclared(txRWSet, qe))
+	assert.NoError(t, ValidateImplicitCollectionsDeclared(&rwset.TxReadWriteSet{}, qe))
+}
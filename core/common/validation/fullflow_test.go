@@ -190,6 +190,27 @@ func TestGoodPath(t *testing.T) {
 		t.Fatal("Simulation results are different")
 		return
 	}
+
+	// with the v1.3 validation, the revocation status of the creator is
+	// checked at the time of the transaction, which therefore must be valid
+	_, txResult = ValidateTransaction(tx, &config.MockApplicationCapabilities{V1_3ValidationRv: true})
+	assert.Equal(t, peer.TxValidationCode_VALID, txResult)
+
+	noTimestamp, err := getProposal(util.GetTestChainID())
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(noTimestamp.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	chdr.Timestamp = nil
+	hdr.ChannelHeader = utils.MarshalOrPanic(chdr)
+	noTimestamp.Header = utils.MarshalOrPanic(hdr)
+	presp, err = utils.CreateProposalResponse(noTimestamp.Header, noTimestamp.Payload, response, simRes, nil, getChaincodeID(), nil, signer)
+	assert.NoError(t, err)
+	tx, err = utils.CreateSignedTx(noTimestamp, signer, presp)
+	assert.NoError(t, err)
+	_, txResult = ValidateTransaction(tx, &config.MockApplicationCapabilities{V1_3ValidationRv: true})
+	assert.Equal(t, peer.TxValidationCode_BAD_CREATOR_SIGNATURE, txResult)
 }
 
 func TestTXWithTwoActionsRejected(t *testing.T) {
@@ -457,7 +478,7 @@ func TestInvocationsBadArgs(t *testing.T) {
 	assert.Error(t, err)
 	err = validateSignatureHeader(&common.SignatureHeader{Nonce: []byte("a")})
 	assert.Error(t, err)
-	err = checkSignatureFromCreator(nil, nil, nil, "")
+	err = checkSignatureFromCreator(nil, nil, nil, "", nil)
 	assert.Error(t, err)
 	_, _, _, err = ValidateProposalMessage(nil)
 	assert.Error(t, err)
@@ -20,8 +20,10 @@ import (
 	"bytes"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sinochem-tech/fabric/common/channelconfig"
 	"github.com/sinochem-tech/fabric/common/flogging"
+	mspi "github.com/sinochem-tech/fabric/msp"
 	mspmgmt "github.com/sinochem-tech/fabric/msp/mgmt"
 	"github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/msp"
@@ -98,8 +100,9 @@ func ValidateProposalMessage(signedProp *pb.SignedProposal) (*pb.Proposal, *comm
 		return nil, nil, nil, err
 	}
 
-	// validate the signature
-	err = checkSignatureFromCreator(shdr.Creator, signedProp.Signature, signedProp.ProposalBytes, chdr.ChannelId)
+	// validate the signature, and check online whether the creator has been revoked,
+	// since the outcome of the endorsement doesn't need to be deterministic
+	err = checkSignatureFromCreator(shdr.Creator, signedProp.Signature, signedProp.ProposalBytes, chdr.ChannelId, mspi.CheckRevocationOnline)
 	if err != nil {
 		// log the exact message on the peer but return a generic error message to
 		// avoid malicious users scanning for channels
@@ -147,10 +150,16 @@ func ValidateProposalMessage(signedProp *pb.SignedProposal) (*pb.Proposal, *comm
 	}
 }
 
+// revocationCheck checks the revocation status of the creator
+// of a message beyond the revocation lists of its MSP
+type revocationCheck func(creator mspi.Identity) error
+
 // given a creator, a message and a signature,
 // this function returns nil if the creator
-// is a valid cert and the signature is valid
-func checkSignatureFromCreator(creatorBytes []byte, sig []byte, msg []byte, ChainID string) error {
+// is a valid cert and the signature is valid.
+// If checkRevocation is not nil, the creator
+// must also pass it.
+func checkSignatureFromCreator(creatorBytes []byte, sig []byte, msg []byte, ChainID string, checkRevocation revocationCheck) error {
 	putilsLogger.Debugf("begin")
 
 	// check for nil argument
@@ -177,6 +186,13 @@ func checkSignatureFromCreator(creatorBytes []byte, sig []byte, msg []byte, Chai
 		return errors.WithMessage(err, "creator certificate is not valid")
 	}
 
+	if checkRevocation != nil {
+		err = checkRevocation(creator)
+		if err != nil {
+			return errors.WithMessage(err, "creator certificate is not valid")
+		}
+	}
+
 	putilsLogger.Debugf("creator is valid")
 
 	// validate the signature
@@ -190,6 +206,28 @@ func checkSignatureFromCreator(creatorBytes []byte, sig []byte, msg []byte, Chai
 	return nil
 }
 
+// CheckCreatorRevocationOnline checks online whether the creator of the given
+// envelope has been revoked. It is meant for the requests served by the peer,
+// such as deliver requests, whose outcome doesn't need to be deterministic.
+func CheckCreatorRevocationOnline(env *common.Envelope, ChainID string) error {
+	signedData, err := env.AsSignedData()
+	if err != nil {
+		return err
+	}
+
+	mspObj := mspmgmt.GetIdentityDeserializer(ChainID)
+	if mspObj == nil {
+		return errors.Errorf("could not get msp for channel [%s]", ChainID)
+	}
+
+	creator, err := mspObj.DeserializeIdentity(signedData[0].Identity)
+	if err != nil {
+		return errors.WithMessage(err, "MSP error")
+	}
+
+	return errors.WithMessage(mspi.CheckRevocationOnline(creator), "creator certificate is not valid")
+}
+
 // checks for a valid SignatureHeader
 func validateSignatureHeader(sHdr *common.SignatureHeader) error {
 	// check for nil argument
@@ -395,8 +433,20 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		return nil, pb.TxValidationCode_BAD_COMMON_HEADER
 	}
 
-	// validate the signature in the envelope
-	err = checkSignatureFromCreator(shdr.Creator, e.Signature, e.Payload, chdr.ChannelId)
+	// validate the signature in the envelope. Since the outcome of the validation
+	// has to be the same on every peer, the revocation status of the creator is only
+	// checked against the OCSP response stapled to its identity, at the time of the transaction
+	var checkRevocation revocationCheck
+	if c.V1_3Validation() {
+		checkRevocation = func(creator mspi.Identity) error {
+			txTime, err := ptypes.Timestamp(chdr.Timestamp)
+			if err != nil {
+				return errors.Wrap(err, "invalid transaction timestamp")
+			}
+			return mspi.CheckStapledRevocationStatus(creator, txTime)
+		}
+	}
+	err = checkSignatureFromCreator(shdr.Creator, e.Signature, e.Payload, chdr.ChannelId, checkRevocation)
 	if err != nil {
 		putilsLogger.Errorf("checkSignatureFromCreator returns err %s", err)
 		return nil, pb.TxValidationCode_BAD_CREATOR_SIGNATURE
@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/msp"
 	"github.com/sinochem-tech/fabric/msp/mgmt"
 	"github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/peer"
@@ -80,16 +82,28 @@ func TestCheckSignatureFromCreator(t *testing.T) {
 	assert.NoError(t, err, "validateCommonHeader returns err %s", err)
 
 	// validate the signature in the envelope
-	err = checkSignatureFromCreator(shdr.Creator, env.Signature, env.Payload, chdr.ChannelId)
+	err = checkSignatureFromCreator(shdr.Creator, env.Signature, env.Payload, chdr.ChannelId, nil)
 	assert.NoError(t, err, "checkSignatureFromCreator returns err %s", err)
 
 	// corrupt the creator
-	err = checkSignatureFromCreator([]byte("junk"), env.Signature, env.Payload, chdr.ChannelId)
+	err = checkSignatureFromCreator([]byte("junk"), env.Signature, env.Payload, chdr.ChannelId, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "MSP error: could not deserialize")
 
 	// check nonexistent channel
-	err = checkSignatureFromCreator(shdr.Creator, env.Signature, env.Payload, "junkchannel")
+	err = checkSignatureFromCreator(shdr.Creator, env.Signature, env.Payload, "junkchannel", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MSP error: channel doesn't exist")
+
+	// the creator has to pass the revocation check, if any
+	err = checkSignatureFromCreator(shdr.Creator, env.Signature, env.Payload, chdr.ChannelId, func(creator msp.Identity) error {
+		return errors.New("The certificate has been revoked")
+	})
+	assert.EqualError(t, err, "creator certificate is not valid: The certificate has been revoked")
+
+	// the test MSP doesn't check revocation online
+	assert.NoError(t, CheckCreatorRevocationOnline(env, chdr.ChannelId))
+	err = CheckCreatorRevocationOnline(env, "junkchannel")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "MSP error: channel doesn't exist")
 }
@@ -0,0 +1,526 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package xchannel verifies the proofs of the cross-channel transfers
+// between chaincodes. A proof is verified on its own, against the policies
+// of the source channel it carries and the block validation policy of the
+// target channel, so the verifying peer doesn't need to have joined the
+// source channel.
+package xchannel
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
+	"github.com/sinochem-tech/fabric/common/channelconfig"
+	"github.com/sinochem-tech/fabric/common/policies"
+	"github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/common/ccprovider"
+	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
+	mspproto "github.com/sinochem-tech/fabric/protos/msp"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// transferObjectType is the object type of the composite keys
+	// cross-channel transfers are recorded under, in the namespace
+	// of the source chaincode
+	transferObjectType = "xchannel~transfer"
+	// consumedObjectType is the object type of the composite keys
+	// consumed cross-channel transfers are recorded under, in the namespace
+	// of the target chaincode
+	consumedObjectType = "xchannel~consumed"
+)
+
+// compositeKey builds a composite key the same way the shim does, so the keys
+// written by the peer never collide with the simple keys of the chaincode
+func compositeKey(objectType string, attributes ...string) string {
+	key := "\x00" + objectType + "\x00"
+	for _, attribute := range attributes {
+		key += attribute + "\x00"
+	}
+	return key
+}
+
+// TransferKey returns the key the transfer to the given chaincode
+// created by the given transaction is recorded under
+func TransferKey(targetChannel, targetChaincode, txID string) string {
+	return compositeKey(transferObjectType, targetChannel, targetChaincode, txID)
+}
+
+// ConsumedTransferKey returns the key marking the transfer created by
+// the given transaction of the given channel as consumed
+func ConsumedTransferKey(sourceChannel, txID string) string {
+	return compositeKey(consumedObjectType, sourceChannel, txID)
+}
+
+// IsConsumedTransferKey returns whether the given key marks a transfer as consumed
+func IsConsumedTransferKey(key string) bool {
+	return strings.HasPrefix(key, compositeKey(consumedObjectType))
+}
+
+// VerifyConsumedTransfer verifies that the given write of the given chaincode of
+// the given channel marks a transfer as consumed, and holds a valid proof of
+// that transfer.
+func VerifyConsumedTransfer(channelID, chaincodeName string, write *kvrwset.KVWrite, blockValidationPolicy policies.Policy) error {
+	if write.IsDelete {
+		return errors.Errorf("consumed transfer %s can't be deleted", write.Key)
+	}
+	proof := &pb.CrossChannelTransferProof{}
+	if err := proto.Unmarshal(write.Value, proof); err != nil {
+		return errors.Wrap(err, "failed unmarshaling transfer proof")
+	}
+	transfer, err := VerifyTransfer(channelID, chaincodeName, proof, blockValidationPolicy)
+	if err != nil {
+		return err
+	}
+	if write.Key != ConsumedTransferKey(transfer.SourceChannel, transfer.TxId) {
+		return errors.Errorf("key %s does not mark transfer %s from channel %s as consumed", write.Key, transfer.TxId, transfer.SourceChannel)
+	}
+	return nil
+}
+
+// VerifyTransfer verifies that the given proof attests a transfer to the given
+// chaincode of the given channel, and returns the transfer.
+//
+// The block of the proof must be signed by an ordering service that satisfies
+// the given block validation policy of the target channel. Through the hash
+// chain of the block headers, the block vouches for the configuration block
+// of the source channel and for the block holding the definition of the source
+// chaincode read by the transaction. The block signatures are then verified
+// against the block validation policy of the source channel, while the
+// endorsements of the transaction, along with the attestations that the
+// transaction has been committed as valid, must satisfy the endorsement policy
+// of the source chaincode, evaluated with the MSPs of the source channel.
+func VerifyTransfer(channelID, chaincodeName string, proof *pb.CrossChannelTransferProof, blockValidationPolicy policies.Policy) (*pb.CrossChannelTransfer, error) {
+	block, err := unmarshalBlock(proof.Block)
+	if err != nil {
+		return nil, err
+	}
+	sourceChannel, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting channel of block")
+	}
+	if sourceChannel == channelID {
+		return nil, errors.Errorf("transfer must originate from another channel than %s", channelID)
+	}
+	if err := verifyBlockSignatures(block, blockValidationPolicy); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("block is not signed by the ordering service of channel %s", channelID))
+	}
+
+	env, err := findTransaction(block, proof.TxId)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := endorsedActions(env)
+	if err != nil {
+		return nil, err
+	}
+	namespace, value, action, err := findTransfer(actions, TransferKey(channelID, chaincodeName, proof.TxId))
+	if err != nil {
+		return nil, err
+	}
+	transfer := &pb.CrossChannelTransfer{}
+	if err := proto.Unmarshal(value, transfer); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling transfer")
+	}
+	if transfer.SourceChannel != sourceChannel || transfer.SourceChaincode != namespace ||
+		transfer.TargetChannel != channelID || transfer.TargetChaincode != chaincodeName || transfer.TxId != proof.TxId {
+		return nil, errors.New("transfer does not match the transaction that created it")
+	}
+
+	configBlock, err := unmarshalBlock(proof.ConfigBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block")
+	}
+	lastConfig, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting last config index of block")
+	}
+	if configBlock.Header.Number != lastConfig {
+		return nil, errors.Errorf("config block %d is not the last config block %d of block %d", configBlock.Header.Number, lastConfig, block.Header.Number)
+	}
+
+	definitionVersion, err := definitionVersion(action, namespace)
+	if err != nil {
+		return nil, err
+	}
+	definitionBlock, err := unmarshalBlock(proof.ChaincodeDefinitionBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid chaincode definition block")
+	}
+	if definitionBlock.Header.Number != definitionVersion.BlockNum {
+		return nil, errors.Errorf("chaincode definition block %d is not block %d read by the transaction", definitionBlock.Header.Number, definitionVersion.BlockNum)
+	}
+
+	if err := verifyChain(block, proof.Headers, configBlock, definitionBlock); err != nil {
+		return nil, errors.WithMessage(err, "failed verifying chain of blocks")
+	}
+
+	bundle, err := configBundle(configBlock, sourceChannel)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block")
+	}
+	sourceBlockValidationPolicy, ok := bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return nil, errors.Errorf("block validation policy of channel %s not found", sourceChannel)
+	}
+	if err := verifyBlockSignatures(block, sourceBlockValidationPolicy); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("block is not signed by the ordering service of channel %s", sourceChannel))
+	}
+
+	cd, err := chaincodeDefinition(definitionBlock, definitionVersion.TxNum, namespace)
+	if err != nil {
+		return nil, err
+	}
+	endorsementPolicy, _, err := cauthdsl.NewPolicyProvider(bundle.MSPManager()).NewPolicy(cd.Policy)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid endorsement policy")
+	}
+
+	endorsements := newSignatureSet()
+	for _, endorsement := range action.Endorsements {
+		if err := endorsements.add(action.ProposalResponsePayload, endorsement); err != nil {
+			return nil, err
+		}
+	}
+	if err := endorsementPolicy.Evaluate(endorsements.signedData); err != nil {
+		return nil, errors.WithMessage(err, "endorsement policy of source chaincode not satisfied")
+	}
+
+	if err := verifyAttestations(proof.Attestations, env, endorsementPolicy); err != nil {
+		return nil, errors.WithMessage(err, "commit of transaction not attested")
+	}
+
+	return transfer, nil
+}
+
+// unmarshalBlock unmarshals a block, and verifies that it's consistent with its header
+func unmarshalBlock(blockBytes []byte) (*common.Block, error) {
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling block")
+	}
+	if block.Header == nil || block.Data == nil {
+		return nil, errors.New("invalid block: missing header or data")
+	}
+	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+		return nil, errors.Errorf("header data hash of block %d does not match block data", block.Header.Number)
+	}
+	return block, nil
+}
+
+// verifyBlockSignatures verifies that the signatures of the block
+// and of its last config index satisfy the given policy
+func verifyBlockSignatures(block *common.Block, policy policies.Policy) error {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_LAST_CONFIG) {
+		return errors.New("block has no metadata")
+	}
+	for _, index := range []common.BlockMetadataIndex{common.BlockMetadataIndex_SIGNATURES, common.BlockMetadataIndex_LAST_CONFIG} {
+		metadata, err := utils.GetMetadataFromBlock(block, index)
+		if err != nil {
+			return errors.WithMessage(err, "failed getting block metadata")
+		}
+		signatureSet := []*common.SignedData{}
+		for _, metadataSignature := range metadata.Signatures {
+			shdr, err := utils.GetSignatureHeader(metadataSignature.SignatureHeader)
+			if err != nil {
+				return errors.WithMessage(err, "failed unmarshaling signature header")
+			}
+			signatureSet = append(signatureSet, &common.SignedData{
+				Identity:  shdr.Creator,
+				Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+				Signature: metadataSignature.Signature,
+			})
+		}
+		if err := policy.Evaluate(signatureSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyChain verifies that the given ancestors precede the block, through
+// the hash chain of the given headers of the blocks in between
+func verifyChain(block *common.Block, headers [][]byte, ancestors ...*common.Block) error {
+	oldest := block.Header.Number
+	for _, ancestor := range ancestors {
+		if ancestor.Header.Number >= block.Header.Number {
+			return errors.Errorf("block %d does not precede block %d", ancestor.Header.Number, block.Header.Number)
+		}
+		if ancestor.Header.Number < oldest {
+			oldest = ancestor.Header.Number
+		}
+	}
+
+	chain := []*common.BlockHeader{}
+	for _, ancestor := range ancestors {
+		if ancestor.Header.Number == oldest {
+			chain = append(chain, ancestor.Header)
+			break
+		}
+	}
+	for _, headerBytes := range headers {
+		header := &common.BlockHeader{}
+		if err := proto.Unmarshal(headerBytes, header); err != nil {
+			return errors.Wrap(err, "failed unmarshaling block header")
+		}
+		chain = append(chain, header)
+	}
+	chain = append(chain, block.Header)
+
+	for i := 1; i < len(chain); i++ {
+		if chain[i].Number != chain[i-1].Number+1 {
+			return errors.Errorf("header of block %d is missing", chain[i-1].Number+1)
+		}
+		if !bytes.Equal(chain[i].PreviousHash, chain[i-1].Hash()) {
+			return errors.Errorf("block %d does not follow block %d", chain[i].Number, chain[i-1].Number)
+		}
+	}
+
+	for _, ancestor := range ancestors {
+		if !bytes.Equal(chain[ancestor.Header.Number-oldest].Hash(), ancestor.Header.Hash()) {
+			return errors.Errorf("block %d is not an ancestor of block %d", ancestor.Header.Number, block.Header.Number)
+		}
+	}
+	return nil
+}
+
+// configBundle returns the bundle of the configuration of the given channel held by the given block
+func configBundle(block *common.Block, channelID string) (*channelconfig.Bundle, error) {
+	if !utils.IsConfigBlock(block) {
+		return nil, errors.Errorf("block %d is not a config block", block.Header.Number)
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.ConfigtxValidator().ChainID() != channelID {
+		return nil, errors.Errorf("config block belongs to channel %s instead of %s", bundle.ConfigtxValidator().ChainID(), channelID)
+	}
+	return bundle, nil
+}
+
+// findTransaction returns the endorser transaction with the given ID in the block
+func findTransaction(block *common.Block, txID string) (*common.Envelope, error) {
+	for _, data := range block.Data.Data {
+		env, chdr, err := envelope(data)
+		if err != nil {
+			return nil, err
+		}
+		if chdr.TxId == txID && chdr.Type == int32(common.HeaderType_ENDORSER_TRANSACTION) {
+			return env, nil
+		}
+	}
+	return nil, errors.Errorf("transaction %s not found in block", txID)
+}
+
+// envelope unmarshals the given data of a block as an envelope,
+// and returns it along with its channel header
+func envelope(data []byte) (*common.Envelope, *common.ChannelHeader, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed getting envelope from block")
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed getting channel header")
+	}
+	return env, chdr, nil
+}
+
+// endorsedAction is an action of an endorser transaction along with its read-write set
+type endorsedAction struct {
+	*pb.ChaincodeEndorsedAction
+	rwSet *rwsetutil.TxRwSet
+}
+
+// endorsedActions returns the actions of the given endorser transaction
+func endorsedActions(env *common.Envelope) ([]*endorsedAction, error) {
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	actions := []*endorsedAction{}
+	for _, txAction := range tx.Actions {
+		cap, err := utils.GetChaincodeActionPayload(txAction.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if cap.Action == nil {
+			continue
+		}
+		prp, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+		if err != nil {
+			return nil, err
+		}
+		ccAction, err := utils.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			return nil, err
+		}
+		rwSet := &rwsetutil.TxRwSet{}
+		if err := rwSet.FromProtoBytes(ccAction.Results); err != nil {
+			return nil, errors.Wrap(err, "failed unmarshaling read-write set")
+		}
+		actions = append(actions, &endorsedAction{ChaincodeEndorsedAction: cap.Action, rwSet: rwSet})
+	}
+	return actions, nil
+}
+
+// findTransfer looks up the write of the given key in the given actions, and
+// returns the namespace and value of the write, along with the action that
+// contains it
+func findTransfer(actions []*endorsedAction, key string) (string, []byte, *endorsedAction, error) {
+	for _, action := range actions {
+		for _, nsRWSet := range action.rwSet.NsRwSets {
+			for _, write := range nsRWSet.KvRwSet.Writes {
+				if write.Key == key && !write.IsDelete {
+					return nsRWSet.NameSpace, write.Value, action, nil
+				}
+			}
+		}
+	}
+	return "", nil, nil, errors.New("transaction does not create the transfer")
+}
+
+// definitionVersion returns the version of the definition of the given
+// chaincode that the given action read from lscc
+func definitionVersion(action *endorsedAction, chaincodeName string) (*kvrwset.Version, error) {
+	for _, nsRWSet := range action.rwSet.NsRwSets {
+		if nsRWSet.NameSpace != "lscc" {
+			continue
+		}
+		for _, read := range nsRWSet.KvRwSet.Reads {
+			if read.Key == chaincodeName && read.Version != nil {
+				return read.Version, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("transaction does not read the definition of chaincode %s", chaincodeName)
+}
+
+// chaincodeDefinition returns the definition of the given chaincode
+// written to lscc by the transaction at the given position of the block
+func chaincodeDefinition(block *common.Block, txNum uint64, chaincodeName string) (*ccprovider.ChaincodeData, error) {
+	if txNum >= uint64(len(block.Data.Data)) {
+		return nil, errors.Errorf("block %d has no transaction at position %d", block.Header.Number, txNum)
+	}
+	env, chdr, err := envelope(block.Data.Data[txNum])
+	if err != nil {
+		return nil, err
+	}
+	if chdr.Type != int32(common.HeaderType_ENDORSER_TRANSACTION) {
+		return nil, errors.Errorf("transaction %d of block %d is not an endorser transaction", txNum, block.Header.Number)
+	}
+	actions, err := endorsedActions(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		for _, nsRWSet := range action.rwSet.NsRwSets {
+			if nsRWSet.NameSpace != "lscc" {
+				continue
+			}
+			for _, write := range nsRWSet.KvRwSet.Writes {
+				if write.Key != chaincodeName || write.IsDelete {
+					continue
+				}
+				cd := &ccprovider.ChaincodeData{}
+				if err := proto.Unmarshal(write.Value, cd); err != nil {
+					return nil, errors.Wrap(err, "failed unmarshaling chaincode definition")
+				}
+				return cd, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("transaction %d of block %d does not define chaincode %s", txNum, block.Header.Number, chaincodeName)
+}
+
+// verifyAttestations verifies that the given attestations, which are responses
+// of qscc GetTransactionByID, attest that the given transaction has been
+// committed as valid, and satisfy the given policy
+func verifyAttestations(attestations [][]byte, env *common.Envelope, policy policies.Policy) error {
+	signatureSet := newSignatureSet()
+	for _, attestation := range attestations {
+		resp := &pb.ProposalResponse{}
+		if err := proto.Unmarshal(attestation, resp); err != nil {
+			return errors.Wrap(err, "failed unmarshaling attestation")
+		}
+		if resp.Endorsement == nil {
+			return errors.New("attestation is not endorsed")
+		}
+		prp, err := utils.GetProposalResponsePayload(resp.Payload)
+		if err != nil {
+			return err
+		}
+		ccAction, err := utils.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			return err
+		}
+		if ccAction.ChaincodeId.GetName() != "qscc" || ccAction.Response.GetStatus() != int32(common.Status_SUCCESS) {
+			return errors.New("attestation is not a successful response of qscc")
+		}
+		processedTx := &pb.ProcessedTransaction{}
+		if err := proto.Unmarshal(ccAction.Response.Payload, processedTx); err != nil {
+			return errors.Wrap(err, "failed unmarshaling attested transaction")
+		}
+		if !proto.Equal(processedTx.TransactionEnvelope, env) {
+			return errors.New("attestation is about another transaction")
+		}
+		if processedTx.ValidationCode != int32(pb.TxValidationCode_VALID) {
+			return errors.Errorf("transaction has been committed as %s", pb.TxValidationCode(processedTx.ValidationCode))
+		}
+		if err := signatureSet.add(resp.Payload, resp.Endorsement); err != nil {
+			return err
+		}
+	}
+	return policy.Evaluate(signatureSet.signedData)
+}
+
+// signatureSet is the signature set of endorsements, which ignores duplicated
+// endorsers the same way the default validation plugin does
+type signatureSet struct {
+	signedData []*common.SignedData
+	endorsers  map[string]struct{}
+}
+
+func newSignatureSet() *signatureSet {
+	return &signatureSet{endorsers: make(map[string]struct{})}
+}
+
+// add adds the given endorsement of the given payload to the signature set
+func (s *signatureSet) add(payload []byte, endorsement *pb.Endorsement) error {
+	serializedIdentity := &mspproto.SerializedIdentity{}
+	if err := proto.Unmarshal(endorsement.Endorser, serializedIdentity); err != nil {
+		return errors.Wrap(err, "failed unmarshaling endorser")
+	}
+	identity := serializedIdentity.Mspid + string(serializedIdentity.IdBytes)
+	if _, exists := s.endorsers[identity]; exists {
+		return nil
+	}
+	s.endorsers[identity] = struct{}{}
+	s.signedData = append(s.signedData, &common.SignedData{
+		Data:      util.ConcatenateBytes(payload, endorsement.Endorser),
+		Identity:  endorsement.Endorser,
+		Signature: endorsement.Signature,
+	})
+	return nil
+}
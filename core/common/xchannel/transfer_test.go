@@ -0,0 +1,385 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package xchannel
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
+	configtxtest "github.com/sinochem-tech/fabric/common/configtx/test"
+	mockpolicies "github.com/sinochem-tech/fabric/common/mocks/policies"
+	"github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/common/ccprovider"
+	"github.com/sinochem-tech/fabric/msp"
+	"github.com/sinochem-tech/fabric/msp/mgmt"
+	msptesttools "github.com/sinochem-tech/fabric/msp/mgmt/testtools"
+	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		fmt.Printf("Failed setting up the local MSP: %s", err)
+		os.Exit(-1)
+	}
+	os.Exit(m.Run())
+}
+
+// sourceChannel builds the blocks of a source channel, signed by the
+// identity of the local MSP, which is both an orderer and an endorser
+type sourceChannel struct {
+	t        *testing.T
+	signer   msp.SigningIdentity
+	endorser []byte
+	blocks   []*common.Block
+}
+
+func newSourceChannel(t *testing.T) *sourceChannel {
+	genesisBlock, err := configtxtest.MakeGenesisBlock("source-channel")
+	require.NoError(t, err)
+	signer := mgmt.GetLocalSigningIdentityOrPanic()
+	endorser, err := signer.Serialize()
+	require.NoError(t, err)
+	return &sourceChannel{
+		t:        t,
+		signer:   signer,
+		endorser: endorser,
+		blocks:   []*common.Block{genesisBlock},
+	}
+}
+
+func (c *sourceChannel) sign(msg []byte) []byte {
+	signature, err := c.signer.Sign(msg)
+	require.NoError(c.t, err)
+	return signature
+}
+
+// endorsement endorses the given proposal response payload
+func (c *sourceChannel) endorsement(prp []byte) *pb.Endorsement {
+	return &pb.Endorsement{
+		Endorser:  c.endorser,
+		Signature: c.sign(util.ConcatenateBytes(prp, c.endorser)),
+	}
+}
+
+// transaction builds an endorsed transaction with the given read-write sets
+func (c *sourceChannel) transaction(txID string, nsRWSets ...*rwset.NsReadWriteSet) *common.Envelope {
+	results := utils.MarshalOrPanic(&rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset:   nsRWSets,
+	})
+	prp := utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+		ProposalHash: []byte("proposal-hash"),
+		Extension:    utils.MarshalOrPanic(&pb.ChaincodeAction{Results: results}),
+	})
+	cap := utils.MarshalOrPanic(&pb.ChaincodeActionPayload{
+		Action: &pb.ChaincodeEndorsedAction{
+			ProposalResponsePayload: prp,
+			Endorsements:            []*pb.Endorsement{c.endorsement(prp), c.endorsement(prp)},
+		},
+	})
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "source-channel",
+					TxId:      txID,
+				}),
+			},
+			Data: utils.MarshalOrPanic(&pb.Transaction{
+				Actions: []*pb.TransactionAction{{Payload: cap}},
+			}),
+		}),
+	}
+}
+
+// commit appends a block carrying the given transactions, signed by the ordering service
+func (c *sourceChannel) commit(envs ...*common.Envelope) *common.Block {
+	previous := c.blocks[len(c.blocks)-1]
+	block := common.NewBlock(previous.Header.Number+1, previous.Header.Hash())
+	for _, env := range envs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	c.signMetadata(block, common.BlockMetadataIndex_SIGNATURES, nil)
+	c.signMetadata(block, common.BlockMetadataIndex_LAST_CONFIG, utils.MarshalOrPanic(&common.LastConfig{Index: 0}))
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+func (c *sourceChannel) signMetadata(block *common.Block, index common.BlockMetadataIndex, value []byte) {
+	shdr := utils.MarshalOrPanic(&common.SignatureHeader{Creator: c.endorser, Nonce: []byte("nonce")})
+	block.Metadata.Metadata[index] = utils.MarshalOrPanic(&common.Metadata{
+		Value: value,
+		Signatures: []*common.MetadataSignature{{
+			SignatureHeader: shdr,
+			Signature:       c.sign(util.ConcatenateBytes(value, shdr, block.Header.Bytes())),
+		}},
+	})
+}
+
+// attestation is the response of qscc GetTransactionByID for the given transaction
+func (c *sourceChannel) attestation(env *common.Envelope, code pb.TxValidationCode) []byte {
+	prp := utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+		ProposalHash: []byte("proposal-hash"),
+		Extension: utils.MarshalOrPanic(&pb.ChaincodeAction{
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Response: &pb.Response{
+				Status: 200,
+				Payload: utils.MarshalOrPanic(&pb.ProcessedTransaction{
+					TransactionEnvelope: env,
+					ValidationCode:      int32(code),
+				}),
+			},
+		}),
+	})
+	return utils.MarshalOrPanic(&pb.ProposalResponse{
+		Payload:     prp,
+		Endorsement: c.endorsement(prp),
+	})
+}
+
+func nsRWSet(namespace string, kvRWSet *kvrwset.KVRWSet) *rwset.NsReadWriteSet {
+	return &rwset.NsReadWriteSet{Namespace: namespace, Rwset: utils.MarshalOrPanic(kvRWSet)}
+}
+
+func definitionWrite(policy *common.SignaturePolicyEnvelope) *rwset.NsReadWriteSet {
+	return nsRWSet("lscc", &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{{
+			Key: "source-cc",
+			Value: utils.MarshalOrPanic(&ccprovider.ChaincodeData{
+				Name:    "source-cc",
+				Version: "1.0",
+				Policy:  utils.MarshalOrPanic(policy),
+			}),
+		}},
+	})
+}
+
+type testTransfer struct {
+	source   *sourceChannel
+	transfer *pb.CrossChannelTransfer
+	env      *common.Envelope
+	proof    *pb.CrossChannelTransferProof
+}
+
+// newTestTransfer builds a source channel, on which block 1 instantiates
+// the source chaincode, and block 2 creates a transfer to target-cc
+func newTestTransfer(t *testing.T) *testTransfer {
+	source := newSourceChannel(t)
+	definitionBlock := source.commit(source.transaction("deploy-tx", definitionWrite(cauthdsl.SignedByMspMember("SampleOrg"))))
+
+	transfer := &pb.CrossChannelTransfer{
+		SourceChannel:   "source-channel",
+		SourceChaincode: "source-cc",
+		TargetChannel:   "target-channel",
+		TargetChaincode: "target-cc",
+		TxId:            "transfer-tx",
+		Payload:         []byte("transfer-payload"),
+	}
+	env := source.transaction("transfer-tx",
+		nsRWSet("lscc", &kvrwset.KVRWSet{
+			Reads: []*kvrwset.KVRead{{Key: "source-cc", Version: &kvrwset.Version{BlockNum: 1, TxNum: 0}}},
+		}),
+		nsRWSet("source-cc", &kvrwset.KVRWSet{
+			Writes: []*kvrwset.KVWrite{{
+				Key:   TransferKey("target-channel", "target-cc", "transfer-tx"),
+				Value: utils.MarshalOrPanic(transfer),
+			}},
+		}),
+	)
+	block := source.commit(env)
+
+	return &testTransfer{
+		source:   source,
+		transfer: transfer,
+		env:      env,
+		proof: &pb.CrossChannelTransferProof{
+			Block:                    utils.MarshalOrPanic(block),
+			TxId:                     "transfer-tx",
+			ConfigBlock:              utils.MarshalOrPanic(source.blocks[0]),
+			ChaincodeDefinitionBlock: utils.MarshalOrPanic(definitionBlock),
+			Headers:                  [][]byte{utils.MarshalOrPanic(definitionBlock.Header)},
+			Attestations:             [][]byte{source.attestation(env, pb.TxValidationCode_VALID)},
+		},
+	}
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, "\x00xchannel~transfer\x00channel\x00cc\x00tx-id\x00", TransferKey("channel", "cc", "tx-id"))
+	assert.Equal(t, "\x00xchannel~consumed\x00channel\x00tx-id\x00", ConsumedTransferKey("channel", "tx-id"))
+	assert.True(t, IsConsumedTransferKey(ConsumedTransferKey("channel", "tx-id")))
+	assert.False(t, IsConsumedTransferKey(TransferKey("channel", "cc", "tx-id")))
+	assert.False(t, IsConsumedTransferKey("xchannel~consumed"))
+}
+
+func TestVerifyTransfer(t *testing.T) {
+	tt := newTestTransfer(t)
+	transfer, err := VerifyTransfer("target-channel", "target-cc", tt.proof, &mockpolicies.Policy{})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(tt.transfer, transfer))
+}
+
+func TestVerifyTransferFailures(t *testing.T) {
+	for _, testCase := range []struct {
+		name          string
+		channelID     string
+		chaincodeName string
+		targetPolicy  *mockpolicies.Policy
+		tamper        func(tt *testTransfer)
+		expectedErr   string
+	}{
+		{
+			name:        "bogus block",
+			tamper:      func(tt *testTransfer) { tt.proof.Block = []byte("bogus-block") },
+			expectedErr: "failed unmarshaling block",
+		},
+		{
+			name:        "same channel",
+			channelID:   "source-channel",
+			expectedErr: "transfer must originate from another channel than source-channel",
+		},
+		{
+			name: "block data not matching the header",
+			tamper: func(tt *testTransfer) {
+				block := tt.source.blocks[2]
+				block.Data.Data = append(block.Data.Data, []byte("another-tx"))
+				tt.proof.Block = utils.MarshalOrPanic(block)
+			},
+			expectedErr: "header data hash of block 2 does not match block data",
+		},
+		{
+			name:         "block not signed by the ordering service of the target channel",
+			targetPolicy: &mockpolicies.Policy{Err: errors.New("banana")},
+			expectedErr:  "block is not signed by the ordering service of channel target-channel: banana",
+		},
+		{
+			name:        "missing transaction",
+			tamper:      func(tt *testTransfer) { tt.proof.TxId = "another-tx" },
+			expectedErr: "transaction another-tx not found in block",
+		},
+		{
+			name:          "transfer to another chaincode",
+			chaincodeName: "another-cc",
+			expectedErr:   "transaction does not create the transfer",
+		},
+		{
+			name: "config block not being the last config block",
+			tamper: func(tt *testTransfer) {
+				tt.proof.ConfigBlock = tt.proof.ChaincodeDefinitionBlock
+			},
+			expectedErr: "config block 1 is not the last config block 0 of block 2",
+		},
+		{
+			name: "chaincode definition block not read by the transaction",
+			tamper: func(tt *testTransfer) {
+				tt.proof.ChaincodeDefinitionBlock = tt.proof.ConfigBlock
+			},
+			expectedErr: "chaincode definition block 0 is not block 1 read by the transaction",
+		},
+		{
+			name:        "missing header",
+			tamper:      func(tt *testTransfer) { tt.proof.Headers = nil },
+			expectedErr: "failed verifying chain of blocks: header of block 1 is missing",
+		},
+		{
+			name: "forged chaincode definition",
+			tamper: func(tt *testTransfer) {
+				forged := tt.source.blocks[1]
+				forged.Data.Data = [][]byte{utils.MarshalOrPanic(tt.source.transaction("deploy-tx", definitionWrite(cauthdsl.AcceptAllPolicy)))}
+				forged.Header.DataHash = forged.Data.Hash()
+				tt.proof.ChaincodeDefinitionBlock = utils.MarshalOrPanic(forged)
+			},
+			expectedErr: "failed verifying chain of blocks: block 1 is not an ancestor of block 2",
+		},
+		{
+			name: "endorsement policy not satisfied",
+			tamper: func(tt *testTransfer) {
+				tt.source.blocks = tt.source.blocks[:1]
+				definitionBlock := tt.source.commit(tt.source.transaction("deploy-tx", definitionWrite(cauthdsl.SignedByMspMember("AnotherOrg"))))
+				block := tt.source.commit(tt.env)
+				tt.proof.Block = utils.MarshalOrPanic(block)
+				tt.proof.ChaincodeDefinitionBlock = utils.MarshalOrPanic(definitionBlock)
+				tt.proof.Headers = [][]byte{utils.MarshalOrPanic(definitionBlock.Header)}
+			},
+			expectedErr: "endorsement policy of source chaincode not satisfied",
+		},
+		{
+			name:        "no attestation",
+			tamper:      func(tt *testTransfer) { tt.proof.Attestations = nil },
+			expectedErr: "commit of transaction not attested",
+		},
+		{
+			name: "transaction committed as invalid",
+			tamper: func(tt *testTransfer) {
+				tt.proof.Attestations = [][]byte{tt.source.attestation(tt.env, pb.TxValidationCode_MVCC_READ_CONFLICT)}
+			},
+			expectedErr: "commit of transaction not attested: transaction has been committed as MVCC_READ_CONFLICT",
+		},
+		{
+			name: "attestation of another transaction",
+			tamper: func(tt *testTransfer) {
+				tt.proof.Attestations = [][]byte{tt.source.attestation(tt.source.transaction("another-tx"), pb.TxValidationCode_VALID)}
+			},
+			expectedErr: "commit of transaction not attested: attestation is about another transaction",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			tt := newTestTransfer(t)
+			if testCase.tamper != nil {
+				testCase.tamper(tt)
+			}
+			channelID, chaincodeName, targetPolicy := "target-channel", "target-cc", &mockpolicies.Policy{}
+			if testCase.channelID != "" {
+				channelID = testCase.channelID
+			}
+			if testCase.chaincodeName != "" {
+				chaincodeName = testCase.chaincodeName
+			}
+			if testCase.targetPolicy != nil {
+				targetPolicy = testCase.targetPolicy
+			}
+			_, err := VerifyTransfer(channelID, chaincodeName, tt.proof, targetPolicy)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expectedErr)
+		})
+	}
+}
+
+func TestVerifyConsumedTransfer(t *testing.T) {
+	tt := newTestTransfer(t)
+	write := &kvrwset.KVWrite{
+		Key:   ConsumedTransferKey("source-channel", "transfer-tx"),
+		Value: utils.MarshalOrPanic(tt.proof),
+	}
+	assert.NoError(t, VerifyConsumedTransfer("target-channel", "target-cc", write, &mockpolicies.Policy{}))
+
+	err := VerifyConsumedTransfer("target-channel", "another-cc", write, &mockpolicies.Policy{})
+	assert.EqualError(t, err, "transaction does not create the transfer")
+
+	write.Key = ConsumedTransferKey("source-channel", "another-tx")
+	err = VerifyConsumedTransfer("target-channel", "target-cc", write, &mockpolicies.Policy{})
+	assert.EqualError(t, err, fmt.Sprintf("key %s does not mark transfer transfer-tx from channel source-channel as consumed", write.Key))
+
+	write.Value = []byte("bogus-proof")
+	err = VerifyConsumedTransfer("target-channel", "target-cc", write, &mockpolicies.Policy{})
+	assert.Contains(t, err.Error(), "failed unmarshaling transfer proof")
+
+	write.IsDelete = true
+	err = VerifyConsumedTransfer("target-channel", "target-cc", write, &mockpolicies.Policy{})
+	assert.EqualError(t, err, fmt.Sprintf("consumed transfer %s can't be deleted", write.Key))
+}
@@ -192,6 +192,9 @@ func WriteStreamToPackage(is io.Reader, localpath string, packagepath string, tw
 	header.Mode = 0100644
 	header.Uid = 500
 	header.Gid = 500
+	// FileInfoHeader looks the owner names up on the local system
+	header.Uname = ""
+	header.Gname = ""
 
 	if err = tw.WriteHeader(header); err != nil {
 		return fmt.Errorf("Error write header for (path: %s, oldname:%s,newname:%s,sz:%d) : %s", localpath, oldname, packagepath, header.Size, err)
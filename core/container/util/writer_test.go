@@ -123,6 +123,10 @@ func Test_WriteStreamToPackage(t *testing.T) {
 	header, err := tr.Next()
 	assert.NoError(t, err, "Error getting the file from the tar")
 	assert.Equal(t, filename, header.Name, "filename read from archive does not match what was added")
+	assert.Empty(t, header.Uname, "owner name should not be taken from the local system")
+	assert.Empty(t, header.Gname, "group name should not be taken from the local system")
+	assert.Equal(t, 500, header.Uid)
+	assert.Equal(t, 500, header.Gid)
 
 	b1 := make([]byte, 5)
 	n, err := tr.Read(b1)
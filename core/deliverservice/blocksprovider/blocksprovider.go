@@ -52,6 +52,10 @@ type BlocksProvider interface {
 	// UpdateClientEndpoints update endpoints
 	UpdateOrderingEndpoints(endpoints []string)
 
+	// DeliveredHeight returns the height of the ledger according to the
+	// blocks delivered so far, or 0 if no block was delivered yet
+	DeliveredHeight() uint64
+
 	// Stop shutdowns blocks provider and stops delivering new blocks
 	Stop()
 }
@@ -97,6 +101,9 @@ type blocksProviderImpl struct {
 
 	done int32
 
+	// height is the sequence number of the last block delivered plus 1
+	height uint64
+
 	wrongStatusThreshold int
 }
 
@@ -176,6 +183,7 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				logger.Errorf("[%s] Error verifying block with sequnce number %d, due to %s", b.chainID, seqNum, err)
 				continue
 			}
+			atomic.StoreUint64(&b.height, seqNum+1)
 
 			numberOfPeers := len(b.gossip.PeersOfChannel(gossipcommon.ChainID(b.chainID)))
 			// Create payload with a block received
@@ -207,6 +215,12 @@ func (b *blocksProviderImpl) Stop() {
 	b.client.Close()
 }
 
+// DeliveredHeight returns the height of the ledger according to the
+// blocks delivered so far, or 0 if no block was delivered yet
+func (b *blocksProviderImpl) DeliveredHeight() uint64 {
+	return atomic.LoadUint64(&b.height)
+}
+
 // UpdateOrderingEndpoints update endpoints of ordering service
 func (b *blocksProviderImpl) UpdateOrderingEndpoints(endpoints []string) {
 	if !b.isEndpointsUpdated(endpoints) {
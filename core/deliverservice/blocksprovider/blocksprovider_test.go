@@ -83,6 +83,11 @@ func makeTestCase(ledgerHeight uint64, mcs api.MessageCryptoService, shouldSucce
 		time.Sleep(time.Second)
 
 		assertDelivery(t, gossipServiceAdapter, deliverer, shouldSucceed)
+		if shouldSucceed {
+			assert.True(t, provider.DeliveredHeight() > ledgerHeight)
+		} else {
+			assert.Zero(t, provider.DeliveredHeight())
+		}
 	}
 }
 
@@ -74,6 +74,7 @@ type DeliverService interface {
 type deliverServiceImpl struct {
 	conf           *Config
 	blockProviders map[string]blocksprovider.BlocksProvider
+	lagMonitors    map[string]*lagMonitor
 	lock           sync.RWMutex
 	stopping       bool
 }
@@ -105,6 +106,7 @@ func NewDeliverService(conf *Config) (DeliverService, error) {
 	ds := &deliverServiceImpl{
 		conf:           conf,
 		blockProviders: make(map[string]blocksprovider.BlocksProvider),
+		lagMonitors:    make(map[string]*lagMonitor),
 	}
 	if err := ds.validateConfiguration(); err != nil {
 		return nil, err
@@ -163,6 +165,17 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		client := d.newClient(chainID, ledgerInfo)
 		logger.Debug("This peer will pass blocks from orderer service to other peers for channel", chainID)
 		d.blockProviders[chainID] = blocksprovider.NewBlocksProvider(chainID, client, d.conf.Gossip, d.conf.CryptoSvc)
+		if isLagMonitorEnabled() {
+			if verifier, isVerifier := d.conf.CryptoSvc.(HeaderVerifier); isVerifier {
+				logger.Debug("Monitoring the lag of the blocks delivered behind the ordering service for channel", chainID)
+				d.lagMonitors[chainID] = newLagMonitor(chainID, client, d.blockProviders[chainID], ledgerInfo, verifier, func(endpoint string) headersStream {
+					return d.newHeadersClient(chainID, endpoint)
+				})
+				d.lagMonitors[chainID].start()
+			} else {
+				logger.Warning("Block headers can't be verified, not monitoring the lag of the blocks delivered for channel", chainID)
+			}
+		}
 		go d.launchBlockProvider(chainID, finalizer)
 	}
 	return nil
@@ -190,6 +203,10 @@ func (d *deliverServiceImpl) StopDeliverForChannel(chainID string) error {
 		return errors.New(errMsg)
 	}
 	if client, exist := d.blockProviders[chainID]; exist {
+		if monitor, exists := d.lagMonitors[chainID]; exists {
+			monitor.stop()
+			delete(d.lagMonitors, chainID)
+		}
 		client.Stop()
 		delete(d.blockProviders, chainID)
 		logger.Debug("This peer will stop pass blocks from orderer service to other peers")
@@ -208,14 +225,15 @@ func (d *deliverServiceImpl) Stop() {
 	// Marking flag to indicate the shutdown of the delivery service
 	d.stopping = true
 
+	for _, monitor := range d.lagMonitors {
+		monitor.stop()
+	}
 	for _, client := range d.blockProviders {
 		client.Stop()
 	}
 }
 
 func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocksprovider.LedgerInfo) *broadcastClient {
-	reconnectBackoffThreshold := getReConnectBackoffThreshold()
-	reconnectTotalTimeThreshold := getReConnectTotalTimeThreshold()
 	requester := &blocksRequester{
 		tls:     viper.GetBool("peer.tls.enabled"),
 		chainID: chainID,
@@ -223,7 +241,32 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
 		return requester.RequestBlocks(ledgerInfoProvider)
 	}
-	backoffPolicy := func(attemptNum int, elapsedTime time.Duration) (time.Duration, bool) {
+	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, newBackoffPolicy())
+	requester.client = bClient
+	return bClient
+}
+
+// newHeadersClient creates a client which pulls the headers of
+// the blocks of the given channel from the given endpoint
+func (d *deliverServiceImpl) newHeadersClient(chainID string, endpoint string) *broadcastClient {
+	requester := &blocksRequester{
+		tls:     viper.GetBool("peer.tls.enabled"),
+		chainID: chainID,
+	}
+	broadcastSetup := func(bd blocksprovider.BlocksDeliverer) error {
+		return requester.RequestHeaders()
+	}
+	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), []string{endpoint})
+	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, newBackoffPolicy())
+	requester.client = bClient
+	return bClient
+}
+
+func newBackoffPolicy() retryPolicy {
+	reconnectBackoffThreshold := getReConnectBackoffThreshold()
+	reconnectTotalTimeThreshold := getReConnectTotalTimeThreshold()
+	return func(attemptNum int, elapsedTime time.Duration) (time.Duration, bool) {
 		if elapsedTime > reconnectTotalTimeThreshold {
 			return 0, false
 		}
@@ -231,10 +274,6 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reconnectBackoffThreshold)), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
-	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
-	requester.client = bClient
-	return bClient
 }
 
 func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
@@ -22,6 +22,7 @@ import (
 	"github.com/sinochem-tech/fabric/gossip/api"
 	"github.com/sinochem-tech/fabric/gossip/common"
 	"github.com/sinochem-tech/fabric/msp/mgmt/testtools"
+	cb "github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/orderer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -63,6 +64,10 @@ func (*mockMCS) VerifyBlock(chainID common.ChainID, seqNum uint64, signedBlock [
 	return nil
 }
 
+func (*mockMCS) VerifyHeader(chainID string, header *cb.BlockHeader, metadata *cb.BlockMetadata) error {
+	return nil
+}
+
 func (*mockMCS) Sign(msg []byte) ([]byte, error) {
 	return msg, nil
 }
@@ -247,6 +252,78 @@ func TestDeliverServiceFailover(t *testing.T) {
 	service.Stop()
 }
 
+func TestDeliverServiceLagMonitor(t *testing.T) {
+	defer ensureNoGoroutineLeak(t)()
+	// Scenario: bring up 2 ordering service instances, and enable the lag monitor.
+	// The instance the client doesn't pull blocks from advances beyond the threshold.
+	// Client is expected to switch to the other instance, and to ask for a block sequence
+	// that is the next block after the last block it got from the instance that lags behind.
+	viper.Set("peer.deliveryclient.lagMonitor.enabled", true)
+	viper.Set("peer.deliveryclient.lagMonitor.threshold", 5)
+	viper.Set("peer.deliveryclient.lagMonitor.checkInterval", time.Millisecond*200)
+	defer func() {
+		viper.Set("peer.deliveryclient.lagMonitor.enabled", nil)
+		viper.Set("peer.deliveryclient.lagMonitor.threshold", nil)
+		viper.Set("peer.deliveryclient.lagMonitor.checkInterval", nil)
+	}()
+
+	os1 := mocks.NewOrderer(5617, t)
+	os2 := mocks.NewOrderer(5618, t)
+
+	time.Sleep(time.Second)
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
+
+	service, err := NewDeliverService(&Config{
+		Endpoints:   []string{"localhost:5617", "localhost:5618"},
+		Gossip:      gossipServiceAdapter,
+		CryptoSvc:   &mockMCS{},
+		ABCFactory:  DefaultABCFactory,
+		ConnFactory: DefaultConnectionFactory,
+	})
+	assert.NoError(t, err)
+	li := &mocks.MockLedgerInfo{Height: uint64(100)}
+	os1.SetNextExpectedSeek(uint64(100))
+	os2.SetNextExpectedSeek(uint64(100))
+
+	err = service.StartDeliverForChannel("TEST_CHAINID", li, func() {})
+	assert.NoError(t, err, "can't start delivery")
+
+	// Each instance has a stream of block headers, and one of them also has a stream of blocks
+	waitUntil := time.Now().Add(time.Second * 5)
+	for os1.ConnCount()+os2.ConnCount() != 3 && time.Now().Before(waitUntil) {
+		time.Sleep(time.Millisecond * 100)
+	}
+	assert.Equal(t, 3, os1.ConnCount()+os2.ConnCount())
+	source, other := os1, os2
+	if os2.ConnCount() == 2 {
+		source, other = os2, os1
+	}
+
+	go source.SendBlock(uint64(100))
+	assertBlockDissemination(100, gossipServiceAdapter.GossipBlockDisseminations, t)
+	atomic.StoreUint64(&li.Height, uint64(101))
+	os1.SetNextExpectedSeek(uint64(101))
+	os2.SetNextExpectedSeek(uint64(101))
+
+	// The other instance advances but within the threshold, no switch is expected
+	other.SendHeader(uint64(105))
+	time.Sleep(time.Second)
+	go source.SendBlock(uint64(101))
+	assertBlockDissemination(101, gossipServiceAdapter.GossipBlockDisseminations, t)
+	atomic.StoreUint64(&li.Height, uint64(102))
+	os1.SetNextExpectedSeek(uint64(102))
+	os2.SetNextExpectedSeek(uint64(102))
+
+	// The other instance advances beyond the threshold, the client is expected to switch to it
+	other.SendHeader(uint64(110))
+	go other.SendBlock(uint64(102))
+	assertBlockDissemination(102, gossipServiceAdapter.GossipBlockDisseminations, t)
+
+	service.Stop()
+	os1.Shutdown()
+	os2.Shutdown()
+}
+
 func TestDeliverServiceUpdateEndpoints(t *testing.T) {
 	// TODO: Add test case to check the endpoints update
 	// Case: Start service with given ordering service endpoint
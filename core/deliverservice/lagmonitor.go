@@ -0,0 +1,258 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sinochem-tech/fabric/core/deliverservice/blocksprovider"
+	"github.com/sinochem-tech/fabric/gossip/util"
+	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/orderer"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultLagThreshold     = 10
+	defaultLagCheckInterval = time.Second * 10
+)
+
+func isLagMonitorEnabled() bool {
+	return viper.GetBool("peer.deliveryclient.lagMonitor.enabled")
+}
+
+func getLagThreshold() uint64 {
+	if !viper.IsSet("peer.deliveryclient.lagMonitor.threshold") {
+		return defaultLagThreshold
+	}
+	return uint64(viper.GetInt("peer.deliveryclient.lagMonitor.threshold"))
+}
+
+func getLagCheckInterval() time.Duration {
+	return util.GetDurationOrDefault("peer.deliveryclient.lagMonitor.checkInterval", defaultLagCheckInterval)
+}
+
+// headersStream is a stream of block headers from an ordering service node
+type headersStream interface {
+	// Recv retrieves a response from the ordering service node
+	Recv() (*orderer.DeliverResponse, error)
+
+	// Close closes the stream and its underlying connection
+	Close()
+}
+
+// HeaderVerifier verifies the signatures of block headers
+type HeaderVerifier interface {
+	// VerifyHeader returns nil if the header of a block of the given channel is properly
+	// signed, according to the signatures in the given metadata of the block
+	VerifyHeader(chainID string, header *common.BlockHeader, metadata *common.BlockMetadata) error
+}
+
+// blocksSource is the client the blocks are pulled with
+type blocksSource interface {
+	// GetEndpoints returns the ordering service endpoints
+	GetEndpoints() []string
+
+	// Disconnect disconnects from the remote node and disable reconnect to current endpoint for predefined period of time
+	Disconnect(disableEndpoint bool)
+}
+
+// lagMonitor tracks the heights of the ordering service nodes through streams of block
+// headers, and makes the blocks provider switch to another ordering service node
+// when the blocks it delivers fall behind the heights of the other nodes.
+// Only headers whose signatures satisfy the block validation policy of the channel
+// are taken into account, so a node can't make the peer switch away from
+// the other nodes by advertising heights the ordering service didn't reach
+type lagMonitor struct {
+	chainID    string
+	source     blocksSource
+	provider   blocksprovider.BlocksProvider
+	ledgerInfo blocksprovider.LedgerInfo
+	verifier   HeaderVerifier
+	newStream  func(endpoint string) headersStream
+	threshold  uint64
+	interval   time.Duration
+
+	lock    sync.Mutex
+	heights map[string]uint64
+	streams map[string]headersStream
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	stopWG   sync.WaitGroup
+}
+
+func newLagMonitor(chainID string, source blocksSource, provider blocksprovider.BlocksProvider,
+	ledgerInfo blocksprovider.LedgerInfo, verifier HeaderVerifier, newStream func(endpoint string) headersStream) *lagMonitor {
+	return &lagMonitor{
+		chainID:    chainID,
+		source:     source,
+		provider:   provider,
+		ledgerInfo: ledgerInfo,
+		verifier:   verifier,
+		newStream:  newStream,
+		threshold:  getLagThreshold(),
+		interval:   getLagCheckInterval(),
+		heights:    make(map[string]uint64),
+		streams:    make(map[string]headersStream),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// start starts monitoring the heights of the ordering service nodes
+func (m *lagMonitor) start() {
+	m.stopWG.Add(1)
+	go m.run()
+}
+
+// stop stops the monitoring, and closes the streams of block headers
+func (m *lagMonitor) stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+		m.stopWG.Wait()
+	})
+}
+
+func (m *lagMonitor) run() {
+	defer m.stopWG.Done()
+	defer m.closeStreams()
+	m.syncStreams()
+	// Give the blocks provider a check interval to catch up after a switch
+	skipCheck := false
+	for {
+		select {
+		case <-m.stopChan:
+			return
+		case <-time.After(m.interval):
+		}
+		m.syncStreams()
+		if skipCheck {
+			skipCheck = false
+			continue
+		}
+		skipCheck = m.checkLag()
+	}
+}
+
+// checkLag switches to another ordering service node if the blocks delivered lag behind the
+// heights of the ordering service nodes by more than the threshold, and returns whether it did
+func (m *lagMonitor) checkLag() bool {
+	height := m.provider.DeliveredHeight()
+	if ledgerHeight, err := m.ledgerInfo.LedgerHeight(); err == nil && ledgerHeight > height {
+		height = ledgerHeight
+	}
+	endpoint, maxHeight := m.maxHeight()
+	if maxHeight <= height+m.threshold {
+		return false
+	}
+	logger.Warningf("[%s] Blocks delivered lag behind the ordering service: height is %d while %s is at height %d, "+
+		"switching to another ordering service node", m.chainID, height, endpoint, maxHeight)
+	m.source.Disconnect(true)
+	return true
+}
+
+// maxHeight returns the ordering service node with the highest height, and its height
+func (m *lagMonitor) maxHeight() (string, uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var maxEndpoint string
+	var maxHeight uint64
+	for endpoint, height := range m.heights {
+		if height > maxHeight {
+			maxEndpoint, maxHeight = endpoint, height
+		}
+	}
+	return maxEndpoint, maxHeight
+}
+
+// syncStreams opens streams of block headers from ordering service nodes
+// that don't have one, and closes the streams of nodes which were removed
+func (m *lagMonitor) syncStreams() {
+	endpoints := m.source.GetEndpoints()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for endpoint, stream := range m.streams {
+		if !util.Contains(endpoint, endpoints) {
+			stream.Close()
+			delete(m.streams, endpoint)
+			delete(m.heights, endpoint)
+		}
+	}
+	for _, endpoint := range endpoints {
+		if _, exists := m.streams[endpoint]; exists {
+			continue
+		}
+		stream := m.newStream(endpoint)
+		m.streams[endpoint] = stream
+		m.stopWG.Add(1)
+		go m.pullHeaders(endpoint, stream)
+	}
+}
+
+func (m *lagMonitor) closeStreams() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for endpoint, stream := range m.streams {
+		stream.Close()
+		delete(m.streams, endpoint)
+	}
+}
+
+// pullHeaders updates the height of the given ordering service node with the headers it sends,
+// until the stream fails or the node sends a header that isn't properly signed.
+// The stream is then re-opened in the next check interval
+func (m *lagMonitor) pullHeaders(endpoint string, stream headersStream) {
+	defer m.stopWG.Done()
+	defer m.removeStream(endpoint, stream)
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			logger.Debugf("[%s] Failed receiving block headers from %s: %s", m.chainID, endpoint, err)
+			return
+		}
+		switch t := msg.Type.(type) {
+		case *orderer.DeliverResponse_Block:
+			if t.Block.GetHeader() == nil || !m.isHigher(endpoint, t.Block.Header.Number+1) {
+				continue
+			}
+			if err := m.verifier.VerifyHeader(m.chainID, t.Block.Header, t.Block.Metadata); err != nil {
+				logger.Warningf("[%s] Got block header %d which isn't properly signed from %s: %s",
+					m.chainID, t.Block.Header.Number, endpoint, err)
+				return
+			}
+			m.updateHeight(endpoint, t.Block.Header.Number+1)
+		case *orderer.DeliverResponse_Status:
+			logger.Warningf("[%s] Got status %v while receiving block headers from %s", m.chainID, t.Status, endpoint)
+			return
+		}
+	}
+}
+
+// isHigher returns whether the given height is higher than the known height of the given ordering service node
+func (m *lagMonitor) isHigher(endpoint string, height uint64) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return height > m.heights[endpoint]
+}
+
+func (m *lagMonitor) updateHeight(endpoint string, height uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.streams[endpoint]; exists && height > m.heights[endpoint] {
+		m.heights[endpoint] = height
+	}
+}
+
+func (m *lagMonitor) removeStream(endpoint string, stream headersStream) {
+	stream.Close()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.streams[endpoint] == stream {
+		delete(m.streams, endpoint)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliverclient
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/core/deliverservice/mocks"
+	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/orderer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHeadersStream struct {
+	responses chan *orderer.DeliverResponse
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newMockHeadersStream() *mockHeadersStream {
+	return &mockHeadersStream{
+		responses: make(chan *orderer.DeliverResponse, 10),
+		closed:    make(chan struct{}),
+	}
+}
+
+func (s *mockHeadersStream) Recv() (*orderer.DeliverResponse, error) {
+	select {
+	case resp := <-s.responses:
+		return resp, nil
+	case <-s.closed:
+		return nil, errors.New("closing")
+	}
+}
+
+func (s *mockHeadersStream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+func (s *mockHeadersStream) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *mockHeadersStream) sendHeader(seq uint64) {
+	s.responses <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Block{Block: &common.Block{
+			Header:   &common.BlockHeader{Number: seq},
+			Metadata: &common.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+		}},
+	}
+}
+
+func (s *mockHeadersStream) sendForgedHeader(seq uint64) {
+	s.responses <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Block{Block: &common.Block{Header: &common.BlockHeader{Number: seq}}},
+	}
+}
+
+// mockHeaderVerifier deems headers properly signed if they have metadata
+type mockHeaderVerifier struct {
+	sync.Mutex
+	verified int
+}
+
+func (v *mockHeaderVerifier) VerifyHeader(chainID string, header *common.BlockHeader, metadata *common.BlockMetadata) error {
+	v.Lock()
+	defer v.Unlock()
+	v.verified++
+	if metadata == nil {
+		return errors.New("no signatures")
+	}
+	return nil
+}
+
+func (v *mockHeaderVerifier) verifiedCount() int {
+	v.Lock()
+	defer v.Unlock()
+	return v.verified
+}
+
+type mockBlocksSource struct {
+	sync.Mutex
+	endpoints   []string
+	disconnects int
+}
+
+func (s *mockBlocksSource) GetEndpoints() []string {
+	s.Lock()
+	defer s.Unlock()
+	return s.endpoints
+}
+
+func (s *mockBlocksSource) Disconnect(disableEndpoint bool) {
+	s.Lock()
+	defer s.Unlock()
+	if disableEndpoint {
+		s.disconnects++
+	}
+}
+
+func (s *mockBlocksSource) disconnectCount() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.disconnects
+}
+
+type mockBlocksProvider struct {
+	height uint64
+}
+
+func (*mockBlocksProvider) DeliverBlocks() {}
+
+func (*mockBlocksProvider) UpdateOrderingEndpoints(endpoints []string) {}
+
+func (p *mockBlocksProvider) DeliveredHeight() uint64 {
+	return p.height
+}
+
+func (*mockBlocksProvider) Stop() {}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second * 5)
+	for !condition() {
+		if time.Now().After(deadline) {
+			assert.Fail(t, "condition wasn't met in time")
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func newTestLagMonitor(source *mockBlocksSource, height uint64) (*lagMonitor, map[string]chan *mockHeadersStream) {
+	streams := make(map[string]chan *mockHeadersStream)
+	for _, endpoint := range []string{"a", "b", "c"} {
+		streams[endpoint] = make(chan *mockHeadersStream, 10)
+	}
+	m := newLagMonitor("testchannel", source, &mockBlocksProvider{height: height}, &mocks.MockLedgerInfo{Height: 1}, &mockHeaderVerifier{},
+		func(endpoint string) headersStream {
+			stream := newMockHeadersStream()
+			streams[endpoint] <- stream
+			return stream
+		})
+	return m, streams
+}
+
+func TestLagMonitorConfig(t *testing.T) {
+	assert.False(t, isLagMonitorEnabled())
+	assert.Equal(t, uint64(defaultLagThreshold), getLagThreshold())
+	assert.Equal(t, defaultLagCheckInterval, getLagCheckInterval())
+
+	viper.Set("peer.deliveryclient.lagMonitor.threshold", 3)
+	defer viper.Set("peer.deliveryclient.lagMonitor.threshold", nil)
+	assert.Equal(t, uint64(3), getLagThreshold())
+}
+
+func TestLagMonitorCheckLag(t *testing.T) {
+	source := &mockBlocksSource{endpoints: []string{"a", "b"}}
+	m, streams := newTestLagMonitor(source, 100)
+	m.threshold = 5
+	m.syncStreams()
+	defer m.closeStreams()
+	streamA, streamB := <-streams["a"], <-streams["b"]
+
+	// Heights within the threshold don't trigger a switch
+	streamA.sendHeader(100)
+	streamB.sendHeader(104)
+	waitFor(t, func() bool {
+		_, height := m.maxHeight()
+		return height == 105
+	})
+	assert.False(t, m.checkLag())
+	assert.Equal(t, 0, source.disconnectCount())
+
+	// A height beyond the threshold triggers a switch
+	streamA.sendHeader(105)
+	waitFor(t, func() bool {
+		endpoint, height := m.maxHeight()
+		return endpoint == "a" && height == 106
+	})
+	assert.True(t, m.checkLag())
+	assert.Equal(t, 1, source.disconnectCount())
+}
+
+func TestLagMonitorForgedHeaders(t *testing.T) {
+	source := &mockBlocksSource{endpoints: []string{"a", "b"}}
+	m, streams := newTestLagMonitor(source, 100)
+	m.threshold = 5
+	m.syncStreams()
+	defer m.closeStreams()
+	streamA, streamB := <-streams["a"], <-streams["b"]
+
+	// b lies about its height, and its headers aren't properly signed
+	streamA.sendHeader(100)
+	streamB.sendForgedHeader(1000)
+	waitFor(t, streamB.isClosed)
+	waitFor(t, func() bool {
+		_, height := m.maxHeight()
+		return height == 101
+	})
+	assert.False(t, m.checkLag())
+	assert.Equal(t, 0, source.disconnectCount())
+
+	// The stream of b is re-opened, but b keeps lying, hence no switch takes place
+	m.syncStreams()
+	streamB = <-streams["b"]
+	streamB.sendForgedHeader(1000)
+	waitFor(t, streamB.isClosed)
+	assert.False(t, m.checkLag())
+	assert.Equal(t, 0, source.disconnectCount())
+
+	// Headers that don't advance the known height aren't verified
+	verifier := m.verifier.(*mockHeaderVerifier)
+	verified := verifier.verifiedCount()
+	streamA.sendHeader(99)
+	streamA.sendHeader(100)
+	streamA.sendHeader(101)
+	waitFor(t, func() bool {
+		_, height := m.maxHeight()
+		return height == 102
+	})
+	assert.Equal(t, verified+1, verifier.verifiedCount())
+}
+
+func TestLagMonitorStreams(t *testing.T) {
+	source := &mockBlocksSource{endpoints: []string{"a", "b"}}
+	m, streams := newTestLagMonitor(source, 100)
+	m.syncStreams()
+	streamA, streamB := <-streams["a"], <-streams["b"]
+	streamB.sendHeader(200)
+	waitFor(t, func() bool {
+		_, height := m.maxHeight()
+		return height == 201
+	})
+
+	// A stream that fails is re-opened
+	streamA.responses <- &orderer.DeliverResponse{Type: &orderer.DeliverResponse_Status{Status: common.Status_SERVICE_UNAVAILABLE}}
+	waitFor(t, streamA.isClosed)
+	m.syncStreams()
+	streamA = <-streams["a"]
+
+	// Streams of removed endpoints are closed, and their heights are discarded
+	source.Lock()
+	source.endpoints = []string{"a", "c"}
+	source.Unlock()
+	m.syncStreams()
+	streamC := <-streams["c"]
+	assert.True(t, streamB.isClosed())
+	_, height := m.maxHeight()
+	assert.Equal(t, uint64(0), height)
+
+	// Stopping the monitor closes all streams
+	m.start()
+	m.stop()
+	assert.True(t, streamA.isClosed())
+	assert.True(t, streamC.isClosed())
+	assert.Len(t, streams["a"], 0)
+}
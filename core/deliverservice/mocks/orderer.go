@@ -25,6 +25,7 @@ type Orderer struct {
 	nextExpectedSeek uint64
 	t                *testing.T
 	blockChannel     chan uint64
+	headerChannel    chan uint64
 	stopChan         chan struct{}
 	failFlag         int32
 	connCount        uint32
@@ -41,6 +42,7 @@ func NewOrderer(port int, t *testing.T) *Orderer {
 		t:                t,
 		nextExpectedSeek: uint64(1),
 		blockChannel:     make(chan uint64, 1),
+		headerChannel:    make(chan uint64, 1),
 		stopChan:         make(chan struct{}, 1),
 	}
 	orderer.RegisterAtomicBroadcastServer(srv, o)
@@ -91,6 +93,12 @@ func (o *Orderer) SendBlock(seq uint64) {
 	o.blockChannel <- seq
 }
 
+// SendHeader sends the header of the block with the given
+// sequence number to the clients that requested headers only
+func (o *Orderer) SendHeader(seq uint64) {
+	o.headerChannel <- seq
+}
+
 func (o *Orderer) Deliver(stream orderer.AtomicBroadcast_DeliverServer) error {
 	atomic.AddUint32(&o.connCount, 1)
 	defer atomic.AddUint32(&o.connCount, ^uint32(0))
@@ -105,6 +113,9 @@ func (o *Orderer) Deliver(stream orderer.AtomicBroadcast_DeliverServer) error {
 	proto.Unmarshal(envlp.Payload, payload)
 	seekInfo := &orderer.SeekInfo{}
 	proto.Unmarshal(payload.Data, seekInfo)
+	if seekInfo.ContentType == orderer.SeekInfo_HEADER_WITH_SIG {
+		return o.deliverHeaders(stream)
+	}
 	assert.True(o.t, seekInfo.Behavior == orderer.SeekInfo_BLOCK_UNTIL_READY)
 	assert.Equal(o.t, atomic.LoadUint64(&o.nextExpectedSeek), seekInfo.Start.GetSpecified().Number)
 
@@ -122,6 +133,17 @@ func (o *Orderer) Deliver(stream orderer.AtomicBroadcast_DeliverServer) error {
 
 }
 
+func (o *Orderer) deliverHeaders(stream orderer.AtomicBroadcast_DeliverServer) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case seq := <-o.headerChannel:
+			o.sendBlock(stream, seq)
+		}
+	}
+}
+
 func statusUnavailable() *orderer.DeliverResponse {
 	return &orderer.DeliverResponse{
 		Type: &orderer.DeliverResponse_Status{
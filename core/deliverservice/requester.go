@@ -56,6 +56,18 @@ func (b *blocksRequester) RequestBlocks(ledgerInfoProvider blocksprovider.Ledger
 	return nil
 }
 
+// RequestHeaders requests the headers of the newest block and of the blocks that follow it
+func (b *blocksRequester) RequestHeaders() error {
+	logger.Debugf("Starting deliver of block headers with newest block for channel %s", b.chainID)
+	seekInfo := &orderer.SeekInfo{
+		Start:       &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+		Stop:        &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
+		Behavior:    orderer.SeekInfo_BLOCK_UNTIL_READY,
+		ContentType: orderer.SeekInfo_HEADER_WITH_SIG,
+	}
+	return b.seek(seekInfo)
+}
+
 func (b *blocksRequester) getTLSCertHash() []byte {
 	if b.tls {
 		return util.ComputeSHA256(comm.GetCredentialSupport().GetClientCertificate().Certificate[0])
@@ -69,16 +81,7 @@ func (b *blocksRequester) seekOldest() error {
 		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
 		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
 	}
-
-	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
-	msgVersion := int32(0)
-	epoch := uint64(0)
-	tlsCertHash := b.getTLSCertHash()
-	env, err := utils.CreateSignedEnvelopeWithTLSBinding(common.HeaderType_DELIVER_SEEK_INFO, b.chainID, localmsp.NewSigner(), seekInfo, msgVersion, epoch, tlsCertHash)
-	if err != nil {
-		return err
-	}
-	return b.client.Send(env)
+	return b.seek(seekInfo)
 }
 
 func (b *blocksRequester) seekLatestFromCommitter(height uint64) error {
@@ -87,7 +90,10 @@ func (b *blocksRequester) seekLatestFromCommitter(height uint64) error {
 		Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: math.MaxUint64}}},
 		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
 	}
+	return b.seek(seekInfo)
+}
 
+func (b *blocksRequester) seek(seekInfo *orderer.SeekInfo) error {
 	//TODO- epoch and msgVersion may need to be obtained for nowfollowing usage in orderer/configupdate/configupdate.go
 	msgVersion := int32(0)
 	epoch := uint64(0)
@@ -17,6 +17,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/chaincode"
 	"github.com/sinochem-tech/fabric/core/chaincode/shim"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/common/validation"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/protos/common"
@@ -97,6 +98,10 @@ type Endorser struct {
 	distributePrivateData privateDataDistributor
 	s                     Support
 	PvtRWSetAssembler
+	// QueryCache memoizes the results of read-only invocations, if set
+	QueryCache *QueryCache
+	// LoadTracker tracks the load of the endorser, if set
+	LoadTracker *LoadTracker
 }
 
 // validateResult provides the result of endorseProposal verification
@@ -279,6 +284,17 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 		version = util.GetSysCCVersion()
 	}
 
+	// serve read-only queries that were already simulated at this height from the cache
+	var cacheLookup *queryCacheLookup
+	if _, historic := txsim.(*historicTxSimulator); !historic && txsim != nil && e.QueryCache.isCacheable(chainID, cid.Name) {
+		cacheLookup = e.lookupQueryCache(chainID, txid, cid.Name, version, prop, cis)
+		if cacheLookup != nil && cacheLookup.result != nil {
+			endorserLogger.Debugf("[%s][%s] serving chaincode %s from the query cache", chainID, shorttxid(txid), cid)
+			txsim.Done()
+			return cdLedger, cacheLookup.result.res, cacheLookup.result.simRes, nil, nil
+		}
+	}
+
 	// ---3. execute the proposal and get simulation results
 	var simResult *ledger.TxSimulationResults
 	var pubSimResBytes []byte
@@ -296,6 +312,15 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 			return nil, nil, nil, nil, err
 		}
 
+		// the implicit collections are only resolved by the
+		// peers of channels with the V1_3 application capability
+		if !e.implicitCollectionsEnabled(chainID) {
+			if err = privdata.ValidateImplicitCollectionsDeclared(simResult.PubSimulationResults, txsim); err != nil {
+				txsim.Done()
+				return nil, nil, nil, nil, err
+			}
+		}
+
 		if simResult.PvtSimulationResults != nil {
 			if cid.Name == "lscc" {
 				// TODO: remove once we can store collection configuration outside of LSCC
@@ -329,10 +354,30 @@ func (e *Endorser) SimulateProposal(ctx context.Context, chainID string, txid st
 		if pubSimResBytes, err = simResult.GetPubSimulationBytes(); err != nil {
 			return nil, nil, nil, nil, err
 		}
+
+		if cacheLookup != nil && res.Status == shim.OK && ccevent == nil && e.QueryCache.isReadOnly(simResult) {
+			cacheLookup.store(res, pubSimResBytes)
+		}
 	}
 	return cdLedger, res, pubSimResBytes, ccevent, nil
 }
 
+// lookupQueryCache looks up the query cache for the result of the given invocation.
+// It returns nil if the invocation cannot be served from the cache.
+func (e *Endorser) lookupQueryCache(chainID string, txid string, ccName string, version string, prop *pb.Proposal, cis *pb.ChaincodeInvocationSpec) *queryCacheLookup {
+	height, err := e.s.GetLedgerHeight(chainID)
+	if err != nil {
+		endorserLogger.Warningf("[%s][%s] failed obtaining ledger height, bypassing the query cache: %v", chainID, shorttxid(txid), err)
+		return nil
+	}
+	cacheLookup, err := e.QueryCache.lookup(chainID, ccName, version, height, prop, cis)
+	if err != nil {
+		endorserLogger.Debugf("[%s][%s] bypassing the query cache: %v", chainID, shorttxid(txid), err)
+		return nil
+	}
+	return cacheLookup
+}
+
 // endorse the proposal by calling the ESCC
 func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid string, signedProp *pb.SignedProposal, proposal *pb.Proposal, response *pb.Response, simRes []byte, event *pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator, cd ccprovider.ChaincodeDefinition) (*pb.ProposalResponse, error) {
 	endorserLogger.Debugf("[%s][%s] Entry chaincode: %s", chainID, shorttxid(txid), ccid)
@@ -419,6 +464,13 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 	txid := chdr.TxId
 	endorserLogger.Debugf("[%s][%s] processing txid: %s", chainID, shorttxid(txid), txid)
 
+	if hdrExt.AsOfBlock != nil {
+		if err = e.validateHistoricQuery(chainID, hdrExt); err != nil {
+			vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+			return vr, err
+		}
+	}
+
 	if chainID != "" {
 		// Here we handle uniqueness check and ACLs for proposals targeting a chain
 		// Notice that ValidateProposalMessage has already verified that TxID is computed properly
@@ -448,12 +500,43 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 	return vr, nil
 }
 
+// implicitCollectionsEnabled returns whether the chaincodes of the given
+// channel may use implicit collections, which requires the V1_3 capability
+func (e *Endorser) implicitCollectionsEnabled(chainID string) bool {
+	ac, exists := e.s.GetApplicationConfig(chainID)
+	return exists && ac.Capabilities().V1_3Validation()
+}
+
+// validateHistoricQuery checks that a proposal which asks to be simulated
+// against historical state targets an application chaincode of a channel,
+// at a block that was already committed
+func (e *Endorser) validateHistoricQuery(chainID string, hdrExt *pb.ChaincodeHeaderExtension) error {
+	if chainID == "" {
+		return errors.New("historical queries are not supported for chainless proposals")
+	}
+	if e.s.IsSysCC(hdrExt.ChaincodeId.Name) {
+		return errors.Errorf("historical queries are not supported for system chaincode %s", hdrExt.ChaincodeId.Name)
+	}
+	height, err := e.s.GetLedgerHeight(chainID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprint("failed to obtain ledger height for channel ", chainID))
+	}
+	if hdrExt.AsOfBlock.Number >= height {
+		return errors.Errorf("block %d has not been committed yet, ledger height is %d", hdrExt.AsOfBlock.Number, height)
+	}
+	return nil
+}
+
 // ProcessProposal process the Proposal
 func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
 	addr := util.ExtractRemoteAddress(ctx)
 	endorserLogger.Debug("Entering: request from", addr)
 	defer endorserLogger.Debug("Exit: request from", addr)
 
+	if e.LoadTracker != nil {
+		defer e.LoadTracker.track()()
+	}
+
 	// 0 -- check and validate
 	vr, err := e.preProcess(signedProp)
 	if err != nil {
@@ -489,6 +572,11 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		// TODO shouldn't we also add txsim to context here as well? Rather than passing txsim parameter
 		// around separately, since eventually it gets added to context anyways
 		ctx = context.WithValue(ctx, chaincode.HistoryQueryExecutorKey, historyQueryExecutor)
+
+		// historical queries read the state as of the requested block
+		if hdrExt.AsOfBlock != nil {
+			txsim = newHistoricTxSimulator(txsim, historyQueryExecutor, hdrExt.AsOfBlock.Number)
+		}
 	}
 	// this could be a request to a chainless SysCC
 
@@ -525,8 +613,11 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	var pResp *pb.ProposalResponse
 
 	// TODO till we implement global ESCC, CSCC for system chaincodes
-	// chainless proposals (such as CSCC) don't have to be endorsed
-	if chainID == "" {
+	// chainless proposals (such as CSCC) don't have to be endorsed.
+	// Historical queries aren't endorsed either, as they can't be
+	// submitted as transactions: clients that need to trust their
+	// results must query enough peers to satisfy their own policy
+	if chainID == "" || hdrExt.AsOfBlock != nil {
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
 		//Note: To endorseProposal(), we pass the released txsim. Hence, an error would occur if we try to use this txsim
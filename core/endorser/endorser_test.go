@@ -472,6 +472,111 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
+func TestEndorserQueryCache(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	m.On("GetLedgerHeight", mock.Anything).Return(uint64(5), nil)
+	support := &em.MockSupport{
+		Mock: m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC", Version: "0"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: []byte("first")},
+	}
+	attachPluginEndorser(support)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support)
+	es.QueryCache = endorser.NewQueryCache(endorser.QueryCacheConfig{
+		Enabled:    true,
+		Chaincodes: []string{"ccid"},
+	})
+
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("first"), pResp.Response.Payload)
+
+	// The same query is served from the cache
+	support.ExecuteResp = &pb.Response{Status: 200, Payload: []byte("second")}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("first"), pResp.Response.Payload)
+
+	// But not a query with different arguments
+	args := [][]byte{[]byte("other args")}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedPropWithCHIdAndArgs(util.GetTestChainID(), "ccid", "0", args, t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("second"), pResp.Response.Payload)
+
+	// A commit to the chaincode namespace invalidates the cache
+	es.QueryCache.StateCommitDone(util.GetTestChainID())
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("second"), pResp.Response.Payload)
+}
+
+func getSignedPropAsOfBlock(ccid string, asOfBlock uint64, t *testing.T) *pb.SignedProposal {
+	signedProp := getSignedProp(ccid, "0", t)
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	assert.NoError(t, err)
+	hdrExt.AsOfBlock = &pb.BlockNumber{Number: asOfBlock}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	chdr.Extension = utils.MarshalOrPanic(hdrExt)
+	hdr.ChannelHeader = utils.MarshalOrPanic(chdr)
+	prop.Header = utils.MarshalOrPanic(hdr)
+	propBytes := utils.MarshalOrPanic(prop)
+	signature, err := signer.Sign(propBytes)
+	assert.NoError(t, err)
+	return &pb.SignedProposal{ProposalBytes: propBytes, Signature: signature}
+}
+
+func TestEndorserHistoricQuery(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	m.On("GetLedgerHeight", mock.Anything).Return(uint64(5), nil)
+	support := &em.MockSupport{
+		Mock: m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: []byte("historic")},
+		SysCCMap:                   map[string]struct{}{"lscc": {}},
+	}
+	attachPluginEndorser(support)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support)
+
+	// Historical queries are executed, but not endorsed
+	pResp, err := es.ProcessProposal(context.Background(), getSignedPropAsOfBlock("ccid", 4, t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	assert.Equal(t, []byte("historic"), pResp.Response.Payload)
+	assert.Nil(t, pResp.Endorsement)
+
+	// Including the genesis block
+	pResp, err = es.ProcessProposal(context.Background(), getSignedPropAsOfBlock("ccid", 0, t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("historic"), pResp.Response.Payload)
+	assert.Nil(t, pResp.Endorsement)
+
+	// Blocks that weren't committed yet are rejected
+	pResp, err = es.ProcessProposal(context.Background(), getSignedPropAsOfBlock("ccid", 5, t))
+	assert.EqualError(t, err, "block 5 has not been committed yet, ledger height is 5")
+	assert.EqualValues(t, 500, pResp.Response.Status)
+
+	// And so are system chaincodes
+	_, err = es.ProcessProposal(context.Background(), getSignedPropAsOfBlock("lscc", 4, t))
+	assert.EqualError(t, err, "historical queries are not supported for system chaincode lscc")
+}
+
 func TestEndorserBadChannel(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
@@ -517,6 +622,47 @@ func TestEndorserGoodPath(t *testing.T) {
 	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
+func TestEndorserImplicitCollections(t *testing.T) {
+	newSupport := func(ac *mc.MockApplicationCapabilities) *em.MockSupport {
+		m := &mock.Mock{}
+		m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+		m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+		m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(&mockccprovider.MockTxSim{
+			GetTxSimulationResultsRv: &ledger.TxSimulationResults{
+				PubSimulationResults: &rwset.TxReadWriteSet{
+					NsRwset: []*rwset.NsReadWriteSet{{
+						Namespace:             "ccid",
+						CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{{CollectionName: "_implicit_org_SampleOrg"}},
+					}},
+				},
+			},
+		}, nil)
+		support := &em.MockSupport{
+			Mock: m,
+			GetApplicationConfigBoolRv: true,
+			GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: ac},
+			GetTransactionByIDErr:      errors.New(""),
+			ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+			ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		}
+		attachPluginEndorser(support)
+		return support
+	}
+
+	// implicit collections can't be used on a V1_2 channel
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, newSupport(&mc.MockApplicationCapabilities{V1_2ValidationRv: true}))
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "implicit collection _implicit_org_SampleOrg of chaincode ccid requires the V1_3 application capability", pResp.Response.Message)
+
+	// but they can on a V1_3 channel
+	es = endorser.NewEndorserServer(pvtEmptyDistributor, newSupport(&mc.MockApplicationCapabilities{V1_2ValidationRv: true, V1_3ValidationRv: true}))
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+}
+
 func TestEndorserLSCC(t *testing.T) {
 	m := &mock.Mock{}
 	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
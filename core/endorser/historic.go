@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	commonledger "github.com/sinochem-tech/fabric/common/ledger"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
+)
+
+var (
+	errHistoricWrite       = errors.New("writes are not allowed when simulating against historical state")
+	errHistoricUnsupported = errors.New("operation is not supported when simulating against historical state")
+)
+
+// historicTxSimulator is a ledger.TxSimulator that reads the state
+// of the ledger as of the commit of a given block, using the history database.
+// It only supports reading keys by their name, and rejects any write.
+type historicTxSimulator struct {
+	// latest is the simulator over the latest state, used for
+	// resolving chaincode definitions, since only the currently
+	// instantiated version of a chaincode can be executed
+	latest   ledger.TxSimulator
+	history  ledger.HistoryQueryExecutor
+	blockNum uint64
+}
+
+func newHistoricTxSimulator(latest ledger.TxSimulator, history ledger.HistoryQueryExecutor, blockNum uint64) *historicTxSimulator {
+	return &historicTxSimulator{
+		latest:   latest,
+		history:  history,
+		blockNum: blockNum,
+	}
+}
+
+// GetState returns the value of the given key as of the commit of the block
+func (s *historicTxSimulator) GetState(namespace string, key string) ([]byte, error) {
+	if namespace == lsccNamespace {
+		return s.latest.GetState(namespace, key)
+	}
+	return s.history.GetStateAtBlock(namespace, key, s.blockNum)
+}
+
+// GetStateMultipleKeys returns the values of the given keys as of the commit of the block
+func (s *historicTxSimulator) GetStateMultipleKeys(namespace string, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		value, err := s.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (s *historicTxSimulator) GetStateMetadata(namespace, key string) (map[string][]byte, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetPrivateData(namespace, collection, key string) ([]byte, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetPrivateDataMetadata(namespace, collection, key string) (map[string][]byte, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
+	return nil, errHistoricUnsupported
+}
+
+func (s *historicTxSimulator) SetState(namespace string, key string, value []byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) DeleteState(namespace string, key string) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) DeleteStateMetadata(namespace, key string) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) ExecuteUpdate(query string) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) SetPrivateData(namespace, collection, key string, value []byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) DeletePrivateData(namespace, collection, key string) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
+	return errHistoricWrite
+}
+
+func (s *historicTxSimulator) DeletePrivateDataMetadata(namespace, collection, key string) error {
+	return errHistoricWrite
+}
+
+// GetTxSimulationResults returns empty simulation results, since historical
+// reads can't be validated against the latest state and writes are rejected
+func (s *historicTxSimulator) GetTxSimulationResults() (*ledger.TxSimulationResults, error) {
+	return &ledger.TxSimulationResults{
+		PubSimulationResults: &rwset.TxReadWriteSet{},
+	}, nil
+}
+
+// Done releases the underlying simulator
+func (s *historicTxSimulator) Done() {
+	s.latest.Done()
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+
+	"github.com/sinochem-tech/fabric/core/chaincode/mock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoricTxSimulatorReads(t *testing.T) {
+	latest := &mock.TxSimulator{}
+	latest.GetStateReturns([]byte("ccdata"), nil)
+	history := &mock.HistoryQueryExecutor{}
+	history.GetStateAtBlockStub = func(namespace string, key string, blockNum uint64) ([]byte, error) {
+		if key == "missing" {
+			return nil, nil
+		}
+		if key == "broken" {
+			return nil, errors.New("history failure")
+		}
+		return []byte(key + "@" + namespace), nil
+	}
+	sim := newHistoricTxSimulator(latest, history, 5)
+
+	value, err := sim.GetState("mycc", "k1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("k1@mycc"), value)
+	ns, key, blockNum := history.GetStateAtBlockArgsForCall(0)
+	assert.Equal(t, "mycc", ns)
+	assert.Equal(t, "k1", key)
+	assert.Equal(t, uint64(5), blockNum)
+
+	// Chaincode definitions are read from the latest state
+	value, err = sim.GetState("lscc", "mycc")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("ccdata"), value)
+	assert.Equal(t, 1, latest.GetStateCallCount())
+
+	values, err := sim.GetStateMultipleKeys("mycc", []string{"k1", "missing", "k2"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("k1@mycc"), nil, []byte("k2@mycc")}, values)
+
+	_, err = sim.GetStateMultipleKeys("mycc", []string{"k1", "broken"})
+	assert.EqualError(t, err, "history failure")
+
+	_, err = sim.GetStateRangeScanIterator("mycc", "a", "z")
+	assert.Equal(t, errHistoricUnsupported, err)
+	_, err = sim.GetPrivateData("mycc", "coll", "k1")
+	assert.Equal(t, errHistoricUnsupported, err)
+
+	res, err := sim.GetTxSimulationResults()
+	assert.NoError(t, err)
+	assert.Empty(t, res.PubSimulationResults.NsRwset)
+	assert.False(t, res.ContainsPvtWrites())
+
+	sim.Done()
+	assert.Equal(t, 1, latest.DoneCallCount())
+}
+
+func TestHistoricTxSimulatorWrites(t *testing.T) {
+	sim := newHistoricTxSimulator(&mock.TxSimulator{}, &mock.HistoryQueryExecutor{}, 5)
+	assert.Equal(t, errHistoricWrite, sim.SetState("mycc", "k1", []byte("v")))
+	assert.Equal(t, errHistoricWrite, sim.DeleteState("mycc", "k1"))
+	assert.Equal(t, errHistoricWrite, sim.SetStateMultipleKeys("mycc", map[string][]byte{"k1": nil}))
+	assert.Equal(t, errHistoricWrite, sim.SetPrivateData("mycc", "coll", "k1", []byte("v")))
+	assert.Equal(t, errHistoricWrite, sim.DeletePrivateData("mycc", "coll", "k1"))
+	assert.Equal(t, errHistoricWrite, sim.ExecuteUpdate("query"))
+}
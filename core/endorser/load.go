@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/protos/gossip"
+)
+
+// latencyWeight is the weight of the latest sample in
+// the moving average of the endorsement latency
+const latencyWeight = 0.2
+
+// LoadTracker tracks the load of the endorser, namely the number of proposals
+// it is processing and a moving average of the time it takes to process a proposal.
+// The load is published to other peers in the form of load hints,
+// which discovery clients use in order to route proposals away from busy peers.
+type LoadTracker struct {
+	lock    sync.Mutex
+	pending uint32
+	latency float64
+}
+
+// NewLoadTracker creates a new LoadTracker
+func NewLoadTracker() *LoadTracker {
+	return &LoadTracker{}
+}
+
+// track marks the beginning of the processing of a proposal,
+// and returns a function that marks its end
+func (lt *LoadTracker) track() func() {
+	start := time.Now()
+	lt.lock.Lock()
+	lt.pending++
+	lt.lock.Unlock()
+	return func() {
+		elapsed := float64(time.Since(start)) / float64(time.Millisecond)
+		lt.lock.Lock()
+		defer lt.lock.Unlock()
+		lt.pending--
+		if lt.latency == 0 {
+			lt.latency = elapsed
+			return
+		}
+		lt.latency = latencyWeight*elapsed + (1-latencyWeight)*lt.latency
+	}
+}
+
+// LoadHints returns the current load hints of the endorser
+func (lt *LoadTracker) LoadHints() *gossip.LoadHints {
+	lt.lock.Lock()
+	defer lt.lock.Unlock()
+	return &gossip.LoadHints{
+		PendingProposals:   lt.pending,
+		EndorsementLatency: uint64(lt.latency + 0.5),
+	}
+}
+
+// PublishLoadHints passes the load hints to the given publish function every interval,
+// in case they changed since they were last published, until the stop channel is closed
+func (lt *LoadTracker) PublishLoadHints(interval time.Duration, publish func(*gossip.LoadHints), stop <-chan struct{}) {
+	var lastPublished *gossip.LoadHints
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(interval):
+		}
+		hints := lt.LoadHints()
+		if proto.Equal(hints, lastPublished) {
+			continue
+		}
+		publish(hints)
+		lastPublished = hints
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTracker(t *testing.T) {
+	lt := NewLoadTracker()
+	assert.Equal(t, &gossip.LoadHints{}, lt.LoadHints())
+
+	done1 := lt.track()
+	done2 := lt.track()
+	assert.Equal(t, uint32(2), lt.LoadHints().PendingProposals)
+
+	time.Sleep(time.Millisecond * 50)
+	done1()
+	hints := lt.LoadHints()
+	assert.Equal(t, uint32(1), hints.PendingProposals)
+	assert.True(t, hints.EndorsementLatency >= 50)
+
+	// A fast proposal lowers the average latency, but only by its weight
+	lt.latency = 100
+	done2()
+	hints = lt.LoadHints()
+	assert.Equal(t, uint32(0), hints.PendingProposals)
+	assert.True(t, hints.EndorsementLatency >= 80 && hints.EndorsementLatency < 100)
+}
+
+func TestPublishLoadHints(t *testing.T) {
+	lt := NewLoadTracker()
+	published := make(chan *gossip.LoadHints, 10)
+	stop := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		lt.PublishLoadHints(time.Millisecond*10, func(hints *gossip.LoadHints) {
+			published <- hints
+		}, stop)
+		close(finished)
+	}()
+
+	assert.Equal(t, &gossip.LoadHints{}, <-published)
+	done := lt.track()
+	assert.Equal(t, &gossip.LoadHints{PendingProposals: 1}, <-published)
+	lt.latency = 0.1
+	done()
+
+	// Load hints are only published when they change
+	time.Sleep(time.Millisecond * 100)
+	assert.Len(t, published, 1)
+
+	close(stop)
+	<-finished
+}
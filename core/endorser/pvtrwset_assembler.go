@@ -38,6 +38,13 @@ type CollectionConfigRetriever interface {
 }
 
 type rwSetAssembler struct {
+	implicitCollectionPolicy privdata.ImplicitCollectionDisseminationPolicy
+}
+
+// NewPvtRWSetAssembler creates a PvtRWSetAssembler which disseminates the
+// private data of the implicit collections according to the given policy
+func NewPvtRWSetAssembler(implicitCollectionPolicy privdata.ImplicitCollectionDisseminationPolicy) PvtRWSetAssembler {
+	return &rwSetAssembler{implicitCollectionPolicy: implicitCollectionPolicy}
 }
 
 // AssemblePvtRWSet prepares TxPvtReadWriteSet for distribution
@@ -57,23 +64,53 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 			if err != nil {
 				return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
 			}
-			if cb == nil {
-				return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
-			}
-
 			colCP := &common.CollectionConfigPackage{}
-			err = proto.Unmarshal(cb, colCP)
-			if err != nil {
+			if cb == nil {
+				// a chaincode without collection config may only write to implicit collections
+				if !onlyImplicitCollections(pvtRwset) {
+					return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+				}
+			} else if err = proto.Unmarshal(cb, colCP); err != nil {
 				return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
 			}
 
 			txPvtRwSetWithConfig.CollectionConfigs[namespace] = colCP
 		}
+		as.addImplicitCollectionConfigs(txPvtRwSetWithConfig.CollectionConfigs[namespace], pvtRwset)
 	}
 	as.trimCollectionConfigs(txPvtRwSetWithConfig)
 	return txPvtRwSetWithConfig, nil
 }
 
+// onlyImplicitCollections returns whether all the collections of the given private read write set are implicit
+func onlyImplicitCollections(pvtRwset *rwset.NsPvtReadWriteSet) bool {
+	for _, col := range pvtRwset.CollectionPvtRwset {
+		if isImplicit, _ := privdata.MspIDIfImplicitCollection(col.CollectionName); !isImplicit {
+			return false
+		}
+	}
+	return true
+}
+
+// addImplicitCollectionConfigs adds to the given collection config package the configurations
+// of the implicit collections of the given private read write set, which aren't part of it yet
+func (as *rwSetAssembler) addImplicitCollectionConfigs(colCP *common.CollectionConfigPackage, pvtRwset *rwset.NsPvtReadWriteSet) {
+	existing := make(map[string]struct{})
+	for _, conf := range colCP.Config {
+		if colConf := conf.GetStaticCollectionConfig(); colConf != nil {
+			existing[colConf.Name] = struct{}{}
+		}
+	}
+	for _, col := range pvtRwset.CollectionPvtRwset {
+		isImplicit, mspID := privdata.MspIDIfImplicitCollection(col.CollectionName)
+		if _, found := existing[col.CollectionName]; !isImplicit || found {
+			continue
+		}
+		colCP.Config = append(colCP.Config, privdata.ImplicitCollectionConfigForOrg(mspID, as.implicitCollectionPolicy))
+		existing[col.CollectionName] = struct{}{}
+	}
+}
+
 func (as *rwSetAssembler) trimCollectionConfigs(pvtData *transientstore.TxPvtReadWriteSetWithConfigInfo) {
 	flags := make(map[string]map[string]struct{})
 	for _, pvtRWset := range pvtData.PvtRwset.NsPvtRwset {
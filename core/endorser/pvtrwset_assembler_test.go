@@ -88,3 +88,71 @@ func TestAssemblePvtRWSet(t *testing.T) {
 	assert.Equal(t, 1, len(pvtReadWriteSetWithConfigInfo.PvtRwset.NsPvtRwset))
 
 }
+
+func TestAssemblePvtRWSetImplicitCollections(t *testing.T) {
+	collectionsConfigCC1 := &common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{
+			{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name: "mycollection-1",
+					},
+				},
+			},
+		},
+	}
+	colB, err := proto.Marshal(collectionsConfigCC1)
+	assert.NoError(t, err)
+
+	configRetriever := &mockCollectionConfigRetriever{}
+	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("myCC")).Return(colB, nil)
+	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("myCC2")).Return([]byte(nil), nil)
+
+	policy := privdata.ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 1, MaxPeerCount: 2}
+	assembler := NewPvtRWSetAssembler(policy)
+
+	privData := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "myCC",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "mycollection-1",
+						Rwset:          []byte{1, 2, 3, 4},
+					},
+					{
+						CollectionName: "_implicit_org_Org1MSP",
+						Rwset:          []byte{5, 6, 7, 8},
+					},
+				},
+			},
+			{
+				Namespace: "myCC2",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "_implicit_org_Org2MSP",
+						Rwset:          []byte{1, 2, 3, 4},
+					},
+				},
+			},
+		},
+	}
+
+	// the configs of the implicit collections are added to the declared ones, if any
+	pvtReadWriteSetWithConfigInfo, err := assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.NoError(t, err)
+	configPackages := pvtReadWriteSetWithConfigInfo.CollectionConfigs
+	assert.Equal(t, []*common.CollectionConfig{
+		collectionsConfigCC1.Config[0],
+		privdata.ImplicitCollectionConfigForOrg("Org1MSP", policy),
+	}, configPackages["myCC"].Config)
+	assert.Equal(t, []*common.CollectionConfig{
+		privdata.ImplicitCollectionConfigForOrg("Org2MSP", policy),
+	}, configPackages["myCC2"].Config)
+
+	// a chaincode without collection config can't write to explicit collections
+	privData.NsPvtRwset[1].CollectionPvtRwset[0].CollectionName = "mycollection-1"
+	_, err = assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.EqualError(t, err, "no collection config for chaincode \"myCC2\"")
+}
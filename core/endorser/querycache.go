@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	putils "github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultQueryCacheMaxSize        = 10000
+	defaultQueryCacheRetentionRatio = 0.75
+
+	lsccNamespace = "lscc"
+)
+
+// QueryCacheConfig defines the configuration of the endorser query cache
+type QueryCacheConfig struct {
+	// Enabled turns the query cache on
+	Enabled bool
+	// Chaincodes are the names of the chaincodes whose
+	// read-only invocations are eligible for caching
+	Chaincodes []string
+	// MaxCacheSize is the maximum amount of entries kept per channel,
+	// after which a purge takes place
+	MaxCacheSize int
+	// PurgeRetentionRatio is the % of entries that remain in the cache
+	// after the cache is purged due to overpopulation
+	PurgeRetentionRatio float64
+}
+
+// QueryCache memoizes the results of read-only chaincode invocations.
+// Entries are keyed by channel, chaincode, version, invocation arguments,
+// ledger height and creator MSP, and are invalidated whenever a block that
+// writes to one of the cached namespaces is committed.
+// QueryCache implements ledger.StateListener so it can be registered
+// with the ledger in order to receive commit notifications.
+type QueryCache struct {
+	sync.RWMutex
+	conf       QueryCacheConfig
+	namespaces map[string]struct{}
+	channels   map[string]*channelQueryCache
+}
+
+// queryResult is the memoized outcome of a simulation
+type queryResult struct {
+	res    *pb.Response
+	simRes []byte
+}
+
+// queryCacheLookup is an in-flight lookup of an invocation in the cache
+type queryCacheLookup struct {
+	cache   *channelQueryCache
+	key     string
+	height  uint64
+	commits uint64
+	result  *queryResult
+}
+
+type channelQueryCache struct {
+	sync.RWMutex
+	qc *QueryCache
+	// height is the ledger height the entries were computed at
+	height uint64
+	// commits counts the blocks that have been committed
+	// with writes to the cached namespaces
+	commits uint64
+	entries map[string]*queryResult
+}
+
+// NewQueryCache creates a new QueryCache with the given configuration
+func NewQueryCache(conf QueryCacheConfig) *QueryCache {
+	if conf.MaxCacheSize <= 0 {
+		conf.MaxCacheSize = defaultQueryCacheMaxSize
+	}
+	if conf.PurgeRetentionRatio < 0 || conf.PurgeRetentionRatio >= 1 {
+		conf.PurgeRetentionRatio = defaultQueryCacheRetentionRatio
+	}
+	qc := &QueryCache{
+		conf:       conf,
+		namespaces: make(map[string]struct{}),
+		channels:   make(map[string]*channelQueryCache),
+	}
+	if !conf.Enabled {
+		return qc
+	}
+	for _, cc := range conf.Chaincodes {
+		qc.namespaces[cc] = struct{}{}
+	}
+	// The chaincode definition is read from lscc during the simulation,
+	// so its writes invalidate the cache as well
+	if len(qc.namespaces) != 0 {
+		qc.namespaces[lsccNamespace] = struct{}{}
+	}
+	return qc
+}
+
+// InterestedInNamespaces implements function from interface `ledger.StateListener`
+func (qc *QueryCache) InterestedInNamespaces() []string {
+	var namespaces []string
+	for ns := range qc.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// HandleStateUpdates implements function from interface `ledger.StateListener`.
+// The cache is only invalidated after the updates hit the state database,
+// in StateCommitDone.
+func (qc *QueryCache) HandleStateUpdates(ledgerID string, stateUpdates ledger.StateUpdates, committingBlockNum uint64) error {
+	endorserLogger.Debugf("[%s] Block [%d] writes to cached namespaces", ledgerID, committingBlockNum)
+	return nil
+}
+
+// StateCommitDone implements function from interface `ledger.StateListener`
+func (qc *QueryCache) StateCommitDone(channelID string) {
+	qc.channelCache(channelID).invalidate()
+}
+
+// isCacheable returns whether the invocation of the given chaincode may be served from the cache
+func (qc *QueryCache) isCacheable(chainID string, ccName string) bool {
+	if qc == nil || chainID == "" {
+		return false
+	}
+	_, exists := qc.namespaces[ccName]
+	return exists && ccName != lsccNamespace
+}
+
+func (qc *QueryCache) channelCache(channel string) *channelQueryCache {
+	qc.RLock()
+	cache := qc.channels[channel]
+	qc.RUnlock()
+	if cache != nil {
+		return cache
+	}
+	qc.Lock()
+	defer qc.Unlock()
+	// Check again, in case another goroutine created it in the meantime
+	if cache = qc.channels[channel]; cache == nil {
+		cache = &channelQueryCache{
+			qc:      qc,
+			entries: make(map[string]*queryResult),
+		}
+		qc.channels[channel] = cache
+	}
+	return cache
+}
+
+// lookup returns the cached result for the given key and height, if exists,
+// along with the commit count the lookup was performed at
+func (cache *channelQueryCache) lookup(key string, height uint64) (*queryResult, uint64) {
+	cache.RLock()
+	defer cache.RUnlock()
+	if height != cache.height {
+		return nil, cache.commits
+	}
+	return cache.entries[key], cache.commits
+}
+
+// store puts the given result into the cache, unless a block
+// was committed to the cached namespaces since commits was observed
+func (cache *channelQueryCache) store(key string, height uint64, commits uint64, result *queryResult) {
+	cache.Lock()
+	defer cache.Unlock()
+	if commits != cache.commits || height < cache.height {
+		// The result might have been computed on stale state,
+		// so don't put it into the cache
+		return
+	}
+	if height > cache.height {
+		cache.height = height
+		cache.entries = make(map[string]*queryResult)
+	}
+	cache.purgeEntriesIfNeeded()
+	cache.entries[key] = result
+}
+
+// purgeEntriesIfNeeded evicts entries if the cache is full.
+// Must be called while holding the lock.
+func (cache *channelQueryCache) purgeEntriesIfNeeded() {
+	maxCacheSize := cache.qc.conf.MaxCacheSize
+	if len(cache.entries)+1 <= maxCacheSize {
+		return
+	}
+	entries2evict := maxCacheSize - int(cache.qc.conf.PurgeRetentionRatio*float64(maxCacheSize))
+	for key := range cache.entries {
+		if entries2evict == 0 {
+			return
+		}
+		entries2evict--
+		delete(cache.entries, key)
+	}
+}
+
+func (cache *channelQueryCache) invalidate() {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.commits++
+	cache.entries = make(map[string]*queryResult)
+}
+
+// lookup looks up the result of the given invocation in the cache.
+// Must be called while holding a simulator of the channel, so that
+// the observed commit count matches the state the simulator sees.
+func (qc *QueryCache) lookup(chainID string, ccName string, version string, height uint64, prop *pb.Proposal, cis *pb.ChaincodeInvocationSpec) (*queryCacheLookup, error) {
+	key, err := queryCacheKey(chainID, ccName, version, height, prop, cis)
+	if err != nil {
+		return nil, err
+	}
+	cache := qc.channelCache(chainID)
+	result, commits := cache.lookup(key, height)
+	return &queryCacheLookup{
+		cache:   cache,
+		key:     key,
+		height:  height,
+		commits: commits,
+		result:  result,
+	}, nil
+}
+
+// store puts the result of the simulation into the cache
+func (l *queryCacheLookup) store(res *pb.Response, simRes []byte) {
+	l.cache.store(l.key, l.height, l.commits, &queryResult{res: res, simRes: simRes})
+}
+
+// queryCacheKey computes the key of the given invocation, or returns an error
+// if the invocation cannot be served from the cache
+func queryCacheKey(chainID string, ccName string, version string, height uint64, prop *pb.Proposal, cis *pb.ChaincodeInvocationSpec) (string, error) {
+	payload, err := putils.GetChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		return "", err
+	}
+	// Transient data isn't part of the arguments, yet the chaincode may read it
+	if len(payload.TransientMap) != 0 {
+		return "", errors.New("proposal contains transient data")
+	}
+	hdr, err := putils.GetHeader(prop.Header)
+	if err != nil {
+		return "", err
+	}
+	shdr, err := putils.GetSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return "", err
+	}
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, sID); err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling creator")
+	}
+	if cis.ChaincodeSpec == nil || cis.ChaincodeSpec.Input == nil {
+		return "", errors.New("invocation spec has no input")
+	}
+	input, err := proto.Marshal(&pb.ChaincodeInput{Args: cis.ChaincodeSpec.Input.Args})
+	if err != nil {
+		return "", errors.Wrap(err, "failed marshaling chaincode input")
+	}
+	argsHash := hex.EncodeToString(util.ComputeSHA256(input))
+	return fmt.Sprintf("%s/%s/%s/%d/%s/%s", chainID, ccName, version, height, sID.Mspid, argsHash), nil
+}
+
+// isReadOnly returns whether the given simulation results contain no writes,
+// and read only from namespaces the cache is notified about
+func (qc *QueryCache) isReadOnly(simResult *ledger.TxSimulationResults) bool {
+	if simResult.ContainsPvtWrites() || simResult.PubSimulationResults == nil {
+		return false
+	}
+	for _, nsRWSet := range simResult.PubSimulationResults.NsRwset {
+		if _, exists := qc.namespaces[nsRWSet.Namespace]; !exists {
+			return false
+		}
+		// Private data commits aren't reported to state listeners
+		if len(nsRWSet.CollectionHashedRwset) != 0 {
+			return false
+		}
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return false
+		}
+		if len(kvRWSet.Writes) != 0 || len(kvRWSet.MetadataWrites) != 0 {
+			return false
+		}
+	}
+	return true
+}
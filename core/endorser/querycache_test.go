@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryCacheNamespaces(t *testing.T) {
+	qc := NewQueryCache(QueryCacheConfig{Chaincodes: []string{"mycc"}})
+	assert.Empty(t, qc.InterestedInNamespaces())
+	assert.False(t, qc.isCacheable("mychannel", "mycc"))
+
+	qc = NewQueryCache(QueryCacheConfig{Enabled: true, Chaincodes: []string{"mycc"}})
+	assert.ElementsMatch(t, []string{"mycc", "lscc"}, qc.InterestedInNamespaces())
+	assert.True(t, qc.isCacheable("mychannel", "mycc"))
+	assert.False(t, qc.isCacheable("", "mycc"))
+	assert.False(t, qc.isCacheable("mychannel", "lscc"))
+	assert.False(t, qc.isCacheable("mychannel", "othercc"))
+
+	var nilCache *QueryCache
+	assert.False(t, nilCache.isCacheable("mychannel", "mycc"))
+}
+
+func TestQueryCacheStore(t *testing.T) {
+	qc := NewQueryCache(QueryCacheConfig{Enabled: true, Chaincodes: []string{"mycc"}})
+	cache := qc.channelCache("mychannel")
+	res := &queryResult{res: &pb.Response{Status: 200}}
+
+	_, commits := cache.lookup("key", 10)
+	cache.store("key", 10, commits, res)
+	cached, _ := cache.lookup("key", 10)
+	assert.Equal(t, res, cached)
+
+	// Lookups at a different height miss
+	cached, _ = cache.lookup("key", 11)
+	assert.Nil(t, cached)
+
+	// Results computed at a lower height are discarded
+	cache.store("key2", 9, commits, res)
+	cached, _ = cache.lookup("key2", 9)
+	assert.Nil(t, cached)
+
+	// A commit in between the lookup and the store discards the result
+	_, commits = cache.lookup("key3", 10)
+	qc.StateCommitDone("mychannel")
+	cache.store("key3", 10, commits, res)
+	cached, _ = cache.lookup("key3", 10)
+	assert.Nil(t, cached)
+
+	// The commit invalidated everything
+	cached, _ = cache.lookup("key", 10)
+	assert.Nil(t, cached)
+
+	// Results at a higher height replace the older ones
+	_, commits = cache.lookup("key", 10)
+	cache.store("key", 10, commits, res)
+	cache.store("key4", 11, commits, res)
+	assert.Len(t, cache.entries, 1)
+	cached, _ = cache.lookup("key4", 11)
+	assert.Equal(t, res, cached)
+}
+
+func TestQueryCachePurge(t *testing.T) {
+	qc := NewQueryCache(QueryCacheConfig{
+		Enabled:             true,
+		Chaincodes:          []string{"mycc"},
+		MaxCacheSize:        5,
+		PurgeRetentionRatio: 0.4,
+	})
+	cache := qc.channelCache("mychannel")
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		cache.store(key, 1, 0, &queryResult{})
+	}
+	assert.Len(t, cache.entries, 5)
+	cache.store("f", 1, 0, &queryResult{})
+	assert.Len(t, cache.entries, 3)
+}
+
+func TestQueryCacheIsReadOnly(t *testing.T) {
+	qc := NewQueryCache(QueryCacheConfig{Enabled: true, Chaincodes: []string{"mycc"}})
+	simResults := func(ns string, kvRWSet *kvrwset.KVRWSet) *ledger.TxSimulationResults {
+		return &ledger.TxSimulationResults{
+			PubSimulationResults: &rwset.TxReadWriteSet{
+				NsRwset: []*rwset.NsReadWriteSet{
+					{Namespace: ns, Rwset: utils.MarshalOrPanic(kvRWSet)},
+				},
+			},
+		}
+	}
+
+	reads := &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "k"}}}
+	writes := &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "k", Value: []byte("v")}}}
+	assert.True(t, qc.isReadOnly(simResults("mycc", reads)))
+	assert.False(t, qc.isReadOnly(simResults("mycc", writes)))
+	assert.False(t, qc.isReadOnly(simResults("othercc", reads)))
+
+	pvtRead := simResults("mycc", reads)
+	pvtRead.PubSimulationResults.NsRwset[0].CollectionHashedRwset = []*rwset.CollectionHashedReadWriteSet{{CollectionName: "coll"}}
+	assert.False(t, qc.isReadOnly(pvtRead))
+
+	pvtWrite := simResults("mycc", reads)
+	pvtWrite.PvtSimulationResults = &rwset.TxPvtReadWriteSet{}
+	assert.False(t, qc.isReadOnly(pvtWrite))
+}
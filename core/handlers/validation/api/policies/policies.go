@@ -20,6 +20,15 @@ type PolicyEvaluator interface {
 	Evaluate(policyBytes []byte, signatureSet []*common.SignedData) error
 }
 
+// ChannelPolicyEvaluator evaluates the policies of the channel
+type ChannelPolicyEvaluator interface {
+	validation.Dependency
+
+	// EvaluateChannelPolicy takes a set of SignedData and evaluates whether this set
+	// of signatures satisfies the policy of the channel with the given name
+	EvaluateChannelPolicy(policyName string, signatureSet []*common.SignedData) error
+}
+
 // SerializedPolicy defines a serialized policy
 type SerializedPolicy interface {
 	validation.ContextDatum
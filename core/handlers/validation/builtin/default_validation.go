@@ -77,10 +77,11 @@ func convertErrorTypeOrPanic(err error) error {
 
 func (v *DefaultValidation) Init(dependencies ...validation.Dependency) error {
 	var (
-		d  IdentityDeserializer
-		c  Capabilities
-		sf StateFetcher
-		pe PolicyEvaluator
+		d   IdentityDeserializer
+		c   Capabilities
+		sf  StateFetcher
+		pe  PolicyEvaluator
+		cpe ChannelPolicyEvaluator
 	)
 	for _, dep := range dependencies {
 		if deserializer, isIdentityDeserializer := dep.(IdentityDeserializer); isIdentityDeserializer {
@@ -95,6 +96,9 @@ func (v *DefaultValidation) Init(dependencies ...validation.Dependency) error {
 		if policyEvaluator, isPolicyFetcher := dep.(PolicyEvaluator); isPolicyFetcher {
 			pe = policyEvaluator
 		}
+		if channelPolicyEvaluator, isChannelPolicyEvaluator := dep.(ChannelPolicyEvaluator); isChannelPolicyEvaluator {
+			cpe = channelPolicyEvaluator
+		}
 	}
 	if sf == nil {
 		return errors.New("stateFetcher not passed in init")
@@ -109,10 +113,11 @@ func (v *DefaultValidation) Init(dependencies ...validation.Dependency) error {
 		return errors.New("policy fetcher not passed in init")
 	}
 	v.TxValidator = &ValidatorOneValidSignature{
-		policyEvaluator: pe,
-		deserializer:    d,
-		stateFetcher:    sf,
-		capabilities:    c,
+		policyEvaluator:        pe,
+		deserializer:           d,
+		stateFetcher:           sf,
+		capabilities:           c,
+		channelPolicyEvaluator: cpe,
 	}
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/sinochem-tech/fabric/core/chaincode/platforms/ccmetadata"
 
@@ -17,8 +18,10 @@ import (
 	"github.com/sinochem-tech/fabric/common/channelconfig"
 	commonerrors "github.com/sinochem-tech/fabric/common/errors"
 	"github.com/sinochem-tech/fabric/common/flogging"
+	"github.com/sinochem-tech/fabric/common/policies"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
 	"github.com/sinochem-tech/fabric/core/common/privdata"
+	"github.com/sinochem-tech/fabric/core/common/xchannel"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/capabilities"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/identities"
 	. "github.com/sinochem-tech/fabric/core/handlers/validation/api/policies"
@@ -45,6 +48,7 @@ var validCollectionNameRegex = regexp.MustCompile(ccmetadata.AllowedCharsCollect
 //go:generate mockery -dir ../api/state/ -name StateFetcher -case underscore -output mocks/
 //go:generate mockery -dir ../api/identities/ -name IdentityDeserializer -case underscore -output mocks/
 //go:generate mockery -dir ../api/policies/ -name PolicyEvaluator -case underscore -output mocks/
+//go:generate mockery -dir ../api/policies/ -name ChannelPolicyEvaluator -case underscore -output mocks/
 
 // New creates a new instance of the default VSCC
 // Typically this will only be invoked once per peer
@@ -66,6 +70,10 @@ type ValidatorOneValidSignature struct {
 	capabilities    Capabilities
 	stateFetcher    StateFetcher
 	policyEvaluator PolicyEvaluator
+	// channelPolicyEvaluator evaluates the block validation policy of the channel
+	// the proofs of consumed cross-channel transfers are verified against;
+	// when nil, transactions consuming transfers are invalid
+	channelPolicyEvaluator ChannelPolicyEvaluator
 }
 
 // Validate validates the given envelope corresponding to a transaction with an endorsement
@@ -116,23 +124,41 @@ func (vscc *ValidatorOneValidSignature) Validate(envelopeBytes []byte, policyByt
 			return policyErr(err)
 		}
 
-		// evaluate the signature set against the policy
-		err = vscc.policyEvaluator.Evaluate(policyBytes, signatureSet)
-		if err != nil {
-			logger.Warningf("Endorsement policy failure for transaction txid=%s, err: %s", chdr.GetTxId(), err.Error())
-			if len(signatureSet) < len(cap.Action.Endorsements) {
-				// Warning: duplicated identities exist, endorsement failure might be cause by this reason
-				return policyErr(errors.New(DUPLICATED_IDENTITY_ERROR))
-			}
-			return policyErr(fmt.Errorf("VSCC error: endorsement policy failure, err: %s", err))
-		}
-
 		hdrExt, err := utils.GetChaincodeHeaderExtension(payl.Header)
 		if err != nil {
 			logger.Errorf("VSCC error: GetChaincodeHeaderExtension failed, err %s", err)
 			return policyErr(err)
 		}
 
+		// retrieve the policies that the endorsements need to satisfy
+		policies, vErr := vscc.endorsementPolicies(hdrExt.ChaincodeId.Name, cap, policyBytes)
+		if vErr != nil {
+			logger.Errorf("VSCC error: failed retrieving the endorsement policies for transaction txid=%s, err %s", chdr.GetTxId(), vErr)
+			return vErr
+		}
+
+		// evaluate the signature set against the policies
+		for _, policy := range policies {
+			err = vscc.policyEvaluator.Evaluate(policy, signatureSet)
+			if err != nil {
+				logger.Warningf("Endorsement policy failure for transaction txid=%s, err: %s", chdr.GetTxId(), err.Error())
+				if len(signatureSet) < len(cap.Action.Endorsements) {
+					// Warning: duplicated identities exist, endorsement failure might be cause by this reason
+					return policyErr(errors.New(DUPLICATED_IDENTITY_ERROR))
+				}
+				return policyErr(fmt.Errorf("VSCC error: endorsement policy failure, err: %s", err))
+			}
+		}
+
+		// verify again the proofs of the consumed cross-channel transfers
+		if vscc.capabilities.V1_3Validation() {
+			err := vscc.validateConsumedTransfers(chdr.ChannelId, cap)
+			if err != nil {
+				logger.Errorf("VSCC error: validateConsumedTransfers failed, err %s", err)
+				return err
+			}
+		}
+
 		// do some extra validation that is specific to lscc
 		if hdrExt.ChaincodeId.Name == "lscc" {
 			logger.Debugf("VSCC info: doing special validation for LSCC")
@@ -146,6 +172,158 @@ func (vscc *ValidatorOneValidSignature) Validate(envelopeBytes []byte, policyByt
 	return nil
 }
 
+// endorsementPolicies returns the policies that the endorsements of the given action
+// must satisfy. Writes to a collection that defines its own endorsement policy are
+// validated against that policy instead of the chaincode-level one; the latter
+// is returned as well unless every write of the action goes to such a collection.
+// The collection endorsement policies are only enforced with the V1_3 capability.
+func (vscc *ValidatorOneValidSignature) endorsementPolicies(ccName string, cap *pb.ChaincodeActionPayload, ccPolicy []byte) ([][]byte, commonerrors.TxValidationError) {
+	if !vscc.capabilities.V1_3Validation() {
+		return [][]byte{ccPolicy}, nil
+	}
+
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, policyErr(fmt.Errorf("GetProposalResponsePayload error %s", err))
+	}
+	if pRespPayload.Extension == nil {
+		return nil, policyErr(fmt.Errorf("nil pRespPayload.Extension"))
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return nil, policyErr(fmt.Errorf("GetChaincodeAction error %s", err))
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, policyErr(fmt.Errorf("txRWSet.FromProtoBytes error %s", err))
+	}
+
+	// collect the collections of the chaincode that are written to,
+	// and find out whether there are any other writes
+	var writtenCollections []string
+	otherWrites := false
+	for _, ns := range txRWSet.NsRwSets {
+		if len(ns.KvRwSet.GetWrites()) > 0 || len(ns.KvRwSet.GetMetadataWrites()) > 0 {
+			otherWrites = true
+		}
+		for _, coll := range ns.CollHashedRwSets {
+			if len(coll.HashedRwSet.GetHashedWrites()) == 0 && len(coll.HashedRwSet.GetMetadataWrites()) == 0 {
+				continue
+			}
+			if ns.NameSpace != ccName {
+				otherWrites = true
+				continue
+			}
+			writtenCollections = append(writtenCollections, coll.CollectionName)
+		}
+	}
+
+	if len(writtenCollections) == 0 {
+		return [][]byte{ccPolicy}, nil
+	}
+
+	collectionPolicies, err := vscc.collectionEndorsementPolicies(ccName)
+	if err != nil {
+		return nil, &commonerrors.VSCCExecutionFailureError{Err: err}
+	}
+
+	var policies [][]byte
+	for _, coll := range writtenCollections {
+		policy, exists := collectionPolicies[coll]
+		if !exists {
+			otherWrites = true
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	if otherWrites || len(policies) == 0 {
+		policies = append(policies, ccPolicy)
+	}
+	return policies, nil
+}
+
+// validateConsumedTransfers verifies that every write of the given action which marks
+// a cross-channel transfer as consumed holds a valid proof of the transfer. The proofs
+// are verified against the block validation policy of the channel, as they are upon
+// endorsement, so a transaction can't consume a transfer by writing the marker itself.
+func (vscc *ValidatorOneValidSignature) validateConsumedTransfers(chainID string, cap *pb.ChaincodeActionPayload) commonerrors.TxValidationError {
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return policyErr(fmt.Errorf("GetProposalResponsePayload error %s", err))
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return policyErr(fmt.Errorf("GetChaincodeAction error %s", err))
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return policyErr(fmt.Errorf("txRWSet.FromProtoBytes error %s", err))
+	}
+
+	for _, ns := range txRWSet.NsRwSets {
+		for _, write := range ns.KvRwSet.GetWrites() {
+			if !xchannel.IsConsumedTransferKey(write.Key) {
+				continue
+			}
+			if vscc.channelPolicyEvaluator == nil {
+				return policyErr(fmt.Errorf("chaincode %s consumes a cross-channel transfer, but transfers can't be verified", ns.NameSpace))
+			}
+			err := xchannel.VerifyConsumedTransfer(chainID, ns.NameSpace, write, &channelPolicy{
+				name:      policies.BlockValidation,
+				evaluator: vscc.channelPolicyEvaluator,
+			})
+			if err != nil {
+				return policyErr(fmt.Errorf("invalid consumption of a cross-channel transfer by chaincode %s: %s", ns.NameSpace, err))
+			}
+		}
+	}
+	return nil
+}
+
+// channelPolicy is the policy of the channel with the given name
+type channelPolicy struct {
+	name      string
+	evaluator ChannelPolicyEvaluator
+}
+
+// Evaluate evaluates the policy of the channel against the given signature set
+func (p *channelPolicy) Evaluate(signatureSet []*common.SignedData) error {
+	return p.evaluator.EvaluateChannelPolicy(p.name, signatureSet)
+}
+
+// collectionEndorsementPolicies returns the serialized endorsement policies
+// of the collections of the given chaincode that define one, by collection name
+func (vscc *ValidatorOneValidSignature) collectionEndorsementPolicies(ccName string) (map[string][]byte, error) {
+	qe, err := vscc.stateFetcher.FetchState()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve QueryExecutor")
+	}
+	defer qe.Done()
+
+	ccp, err := privdata.RetrieveCollectionConfigPackageFromState(common.CollectionCriteria{Namespace: ccName}, &state{qe})
+	if err != nil {
+		if _, isNoSuchCollectionError := err.(privdata.NoSuchCollectionError); isNoSuchCollectionError {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	policies := make(map[string][]byte)
+	for _, cc := range ccp.Config {
+		staticConfig := cc.GetStaticCollectionConfig()
+		sp := staticConfig.GetEndorsementPolicy().GetSignaturePolicy()
+		if sp == nil {
+			continue
+		}
+		policy, err := proto.Marshal(sp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed marshaling endorsement policy of collection %s", staticConfig.Name)
+		}
+		policies[staticConfig.Name] = policy
+	}
+	return policies, nil
+}
+
 // checkInstantiationPolicy evaluates an instantiation policy against a signed proposal
 func (vscc *ValidatorOneValidSignature) checkInstantiationPolicy(chainName string, env *common.Envelope, instantiationPolicy []byte, payl *common.Payload) commonerrors.TxValidationError {
 	// get the signature header
@@ -167,7 +345,7 @@ func (vscc *ValidatorOneValidSignature) checkInstantiationPolicy(chainName strin
 	return nil
 }
 
-func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfig) error {
+func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfig, ac channelconfig.ApplicationCapabilities) error {
 	newCollectionsMap := make(map[string]bool, len(newCollectionConfigs))
 	// Process each collection config from a set of collection configs
 	for _, newCollectionConfig := range newCollectionConfigs {
@@ -184,6 +362,13 @@ func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfi
 			return err
 		}
 
+		// the names of the implicit collections are reserved from v1.3 on
+		if ac.V1_3Validation() {
+			if err := validateCollectionNameNotReserved(collectionName); err != nil {
+				return err
+			}
+		}
+
 		if _, ok := newCollectionsMap[collectionName]; !ok {
 			newCollectionsMap[collectionName] = true
 		} else {
@@ -209,6 +394,12 @@ func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfi
 		if err != nil {
 			return errors.WithMessage(err, fmt.Sprintf("collection-name: %s -- error in member org policy", collectionName))
 		}
+
+		// the endorsement policy is optional, but if present it must be a signature policy.
+		// It is ignored without the V1_3 capability, as it isn't enforced either
+		if ep := newCollection.EndorsementPolicy; ac.V1_3Validation() && ep != nil && ep.GetSignaturePolicy().GetRule() == nil {
+			return fmt.Errorf("collection-name: %s -- endorsement policy must be a non-empty signature policy", collectionName)
+		}
 	}
 	return nil
 }
@@ -326,6 +517,16 @@ func validateCollectionName(collectionName string) error {
 	return nil
 }
 
+// validateCollectionNameNotReserved checks that the given collection name
+// doesn't clash with the names of the implicit collections
+func validateCollectionNameNotReserved(collectionName string) error {
+	if strings.HasPrefix(collectionName, privdata.ImplicitCollectionNamePrefix) {
+		return fmt.Errorf("collection-name: %s not allowed. The prefix %s is reserved for implicit collections",
+			collectionName, privdata.ImplicitCollectionNamePrefix)
+	}
+	return nil
+}
+
 // validateRWSetAndCollection performs validation of the rwset
 // of an LSCC deploy operation and then it validates any collection
 // configuration
@@ -415,7 +616,7 @@ func (vscc *ValidatorOneValidSignature) validateRWSetAndCollection(
 
 	if ac.V1_2Validation() {
 		newCollectionConfigs := newCollectionConfigPackage.GetConfig()
-		if err := validateNewCollectionConfigs(newCollectionConfigs); err != nil {
+		if err := validateNewCollectionConfigs(newCollectionConfigs, ac); err != nil {
 			return policyErr(err)
 		}
 
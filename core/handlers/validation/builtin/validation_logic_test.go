@@ -20,6 +20,7 @@ import (
 	mc "github.com/sinochem-tech/fabric/common/mocks/config"
 	lm "github.com/sinochem-tech/fabric/common/mocks/ledger"
 	"github.com/sinochem-tech/fabric/common/mocks/scc"
+	"github.com/sinochem-tech/fabric/common/policies"
 	"github.com/sinochem-tech/fabric/common/util"
 	aclmocks "github.com/sinochem-tech/fabric/core/aclmgmt/mocks"
 	"github.com/sinochem-tech/fabric/core/chaincode/shim"
@@ -28,6 +29,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/common/ccpackage"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
 	"github.com/sinochem-tech/fabric/core/common/privdata"
+	"github.com/sinochem-tech/fabric/core/common/xchannel"
 	cutils "github.com/sinochem-tech/fabric/core/container/util"
 	"github.com/sinochem-tech/fabric/core/handlers/validation/api/capabilities"
 	"github.com/sinochem-tech/fabric/core/handlers/validation/builtin/mocks"
@@ -45,9 +47,14 @@ import (
 	"github.com/sinochem-tech/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func createTx(endorsedByDuplicatedIdentity bool) (*common.Envelope, error) {
+	return createTxWithResults(endorsedByDuplicatedIdentity, []byte("res"))
+}
+
+func createTxWithResults(endorsedByDuplicatedIdentity bool, res []byte) (*common.Envelope, error) {
 	ccid := &peer.ChaincodeID{Name: "foo", Version: "v1"}
 	cis := &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccid}}
 
@@ -56,7 +63,7 @@ func createTx(endorsedByDuplicatedIdentity bool) (*common.Envelope, error) {
 		return nil, err
 	}
 
-	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, []byte("res"), nil, ccid, nil, id)
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, ccid, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -413,6 +420,210 @@ func TestInvoke(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCollectionEndorsementPolicy(t *testing.T) {
+	memberPolicy := cauthdsl.SignedByMspMember(mspid)
+	wrongPolicy := cauthdsl.SignedByMspMember("barf")
+	collWithPolicy := func(name string, ep *common.SignaturePolicyEnvelope) *common.CollectionConfig {
+		cc := createCollectionConfig(name, memberPolicy, 0, 1, 0)
+		cc.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: ep},
+		}
+		return cc
+	}
+	newInstance := func(configs ...*common.CollectionConfig) *ValidatorOneValidSignature {
+		state := map[string]map[string][]byte{
+			"lscc": {
+				privdata.BuildCollectionKVSKey("foo"): utils.MarshalOrPanic(&common.CollectionConfigPackage{Config: configs}),
+			},
+		}
+		qec := &mocks2.QueryExecutorCreator{}
+		qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+		return newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{PrivateChannelDataRv: true, V1_3ValidationRv: true})
+	}
+	txWith := func(populate func(*rwsetutil.RWSetBuilder)) []byte {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		populate(rwsetBuilder)
+		sr, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		srBytes, err := sr.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		tx, err := createTxWithResults(false, srBytes)
+		assert.NoError(t, err)
+		return utils.MarshalOrPanic(tx)
+	}
+	goodPolicy := utils.MarshalOrPanic(memberPolicy)
+	badPolicy := utils.MarshalOrPanic(wrongPolicy)
+
+	collOnlyTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToPvtAndHashedWriteSet("foo", "coll1", "key", []byte("value"))
+	})
+	collAndPublicTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToPvtAndHashedWriteSet("foo", "coll1", "key", []byte("value"))
+		b.AddToWriteSet("foo", "key", []byte("value"))
+	})
+	otherCollTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToPvtAndHashedWriteSet("foo", "coll1", "key", []byte("value"))
+		b.AddToPvtAndHashedWriteSet("foo", "coll2", "key", []byte("value"))
+	})
+	collReadTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToHashedReadSet("foo", "coll1", "key", nil)
+	})
+
+	// writes only to a collection with its own endorsement policy
+	// are not validated against the chaincode endorsement policy
+	v := newInstance(collWithPolicy("coll1", memberPolicy), createCollectionConfig("coll2", memberPolicy, 0, 1, 0))
+	assert.NoError(t, v.Validate(collOnlyTx, badPolicy))
+
+	// public writes are still subject to the chaincode endorsement policy
+	assert.Error(t, v.Validate(collAndPublicTx, badPolicy))
+	assert.NoError(t, v.Validate(collAndPublicTx, goodPolicy))
+
+	// and so are writes to collections without an endorsement policy
+	assert.Error(t, v.Validate(otherCollTx, badPolicy))
+	assert.NoError(t, v.Validate(otherCollTx, goodPolicy))
+
+	// reads only are validated against the chaincode endorsement policy
+	assert.Error(t, v.Validate(collReadTx, badPolicy))
+	assert.NoError(t, v.Validate(collReadTx, goodPolicy))
+
+	// the collection endorsement policy is enforced
+	v = newInstance(collWithPolicy("coll1", wrongPolicy))
+	err := v.Validate(collOnlyTx, goodPolicy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "endorsement policy failure")
+
+	// without the V1_3 capability, only the chaincode endorsement policy counts,
+	// even if the collection defines its own endorsement policy
+	qec := &mocks2.QueryExecutorCreator{}
+	qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(map[string]map[string][]byte{
+		"lscc": {
+			privdata.BuildCollectionKVSKey("foo"): utils.MarshalOrPanic(&common.CollectionConfigPackage{
+				Config: []*common.CollectionConfig{collWithPolicy("coll1", wrongPolicy)},
+			}),
+		},
+	}), nil)
+	v = newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{PrivateChannelDataRv: true, V1_2ValidationRv: true})
+	assert.NoError(t, v.Validate(collOnlyTx, goodPolicy))
+	assert.Error(t, v.Validate(collOnlyTx, badPolicy))
+}
+
+func TestConsumedTransfers(t *testing.T) {
+	txWith := func(populate func(*rwsetutil.RWSetBuilder)) []byte {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		populate(rwsetBuilder)
+		sr, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		srBytes, err := sr.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		tx, err := createTxWithResults(false, srBytes)
+		assert.NoError(t, err)
+		return utils.MarshalOrPanic(tx)
+	}
+	newInstance := func(c validation.Capabilities, cpe *mocks.ChannelPolicyEvaluator) *ValidatorOneValidSignature {
+		v := newValidationInstance(make(map[string]map[string][]byte))
+		v.capabilities = c
+		if cpe != nil {
+			v.channelPolicyEvaluator = cpe
+		}
+		return v
+	}
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+
+	// a block of the source channel, signed by some orderer
+	env := &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "source-channel",
+					TxId:      "source-tx",
+				}),
+			},
+		}),
+	}
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 7},
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(env)}},
+	}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata = &common.BlockMetadata{
+		Metadata: [][]byte{
+			utils.MarshalOrPanic(&common.Metadata{
+				Signatures: []*common.MetadataSignature{{
+					SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("orderer")}),
+					Signature:       []byte("signature"),
+				}},
+			}),
+			{}, {}, {},
+		},
+	}
+	proof := utils.MarshalOrPanic(&peer.CrossChannelTransferProof{
+		Block: utils.MarshalOrPanic(block),
+		TxId:  "source-tx",
+	})
+	consumedKey := xchannel.ConsumedTransferKey("source-channel", "source-tx")
+
+	consumeTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToWriteSet("foo", "key", []byte("value"))
+		b.AddToWriteSet("foo", consumedKey, proof)
+	})
+	deleteTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToWriteSet("foo", consumedKey, nil)
+	})
+	plainTx := txWith(func(b *rwsetutil.RWSetBuilder) {
+		b.AddToWriteSet("foo", "key", []byte("value"))
+	})
+
+	// the proof is verified against the block validation policy of the channel
+	cpe := &mocks.ChannelPolicyEvaluator{}
+	cpe.On("EvaluateChannelPolicy", policies.BlockValidation, mock.Anything).Return(errors.New("not signed by the orderers"))
+	v := newInstance(&mc.MockApplicationCapabilities{V1_3ValidationRv: true}, cpe)
+	err = v.Validate(consumeTx, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid consumption of a cross-channel transfer by chaincode foo")
+	assert.Contains(t, err.Error(), "not signed by the orderers")
+	cpe.AssertCalled(t, "EvaluateChannelPolicy", policies.BlockValidation, mock.Anything)
+
+	// consumed transfers can't be deleted
+	err = v.Validate(deleteTx, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can't be deleted")
+
+	// other writes are not affected
+	assert.NoError(t, v.Validate(plainTx, policy))
+
+	// without a channel policy evaluator, transfers can't be consumed
+	v = newInstance(&mc.MockApplicationCapabilities{V1_3ValidationRv: true}, nil)
+	err = v.Validate(consumeTx, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "transfers can't be verified")
+	assert.NoError(t, v.Validate(plainTx, policy))
+
+	// without the V1_3 capability, the proofs are not verified
+	cpe = &mocks.ChannelPolicyEvaluator{}
+	v = newInstance(&mc.MockApplicationCapabilities{}, cpe)
+	assert.NoError(t, v.Validate(consumeTx, policy))
+	cpe.AssertNotCalled(t, "EvaluateChannelPolicy", mock.Anything, mock.Anything)
+}
+
+func TestValidateCollectionEndorsementPolicyConfig(t *testing.T) {
+	policy := cauthdsl.SignedByMspMember(mspid)
+	cc := createCollectionConfig("coll1", policy, 0, 1, 0)
+	assert.NoError(t, validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{}))
+
+	cc.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy},
+	}
+	assert.NoError(t, validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{}))
+
+	// the endorsement policy is only validated with the V1_3 capability
+	cc.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{}
+	assert.NoError(t, validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{}))
+	err := validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{V1_3ValidationRv: true})
+	assert.EqualError(t, err, "collection-name: coll1 -- endorsement policy must be a non-empty signature policy")
+}
+
 func TestRWSetTooBig(t *testing.T) {
 	state := make(map[string]map[string][]byte)
 	mp := (&scc.MocksccProviderFactory{
@@ -1997,3 +2208,13 @@ func TestInValidCollectionName(t *testing.T) {
 		assert.Error(t, validateCollectionName(name), "Testing for name = "+name)
 	}
 }
+
+func TestReservedCollectionName(t *testing.T) {
+	cc := createCollectionConfig("_implicit_org_Org1MSP", cauthdsl.SignedByMspMember(mspid), 0, 1, 0)
+
+	// the names of the implicit collections can be declared without the V1_3 capability
+	assert.NoError(t, validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{}))
+
+	err := validateNewCollectionConfigs([]*common.CollectionConfig{cc}, &mc.MockApplicationCapabilities{V1_3ValidationRv: true})
+	assert.EqualError(t, err, "collection-name: _implicit_org_Org1MSP not allowed. The prefix _implicit_org_ is reserved for implicit collections")
+}
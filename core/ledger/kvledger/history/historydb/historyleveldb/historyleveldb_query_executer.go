@@ -18,6 +18,7 @@ package historyleveldb
 
 import (
 	"errors"
+	"fmt"
 
 	commonledger "github.com/sinochem-tech/fabric/common/ledger"
 	"github.com/sinochem-tech/fabric/common/ledger/blkstorage"
@@ -54,6 +55,54 @@ func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key str
 	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
 }
 
+// GetStateAtBlock implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateAtBlock(namespace string, key string, blockNum uint64) ([]byte, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("History tracking not enabled - historyDatabase is false")
+	}
+
+	savepoint, err := q.historyDB.GetLastSavepoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil || savepoint.BlockNum < blockNum {
+		return nil, fmt.Errorf("Block %d has not been committed to the history database yet", blockNum)
+	}
+
+	// range scan over the history records of namespace~key up to (and including) the given block,
+	// the last one holds the value of the key at that block
+	compositeStartKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeEndKey := append(historydb.ConstructPartialCompositeHistoryKey(namespace, key, false),
+		util.EncodeOrderPreservingVarUint64(blockNum+1)...)
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	defer dbItr.Release()
+	if !dbItr.Last() {
+		logger.Debugf("No history record for namespace:%s key:%s up to block %d", namespace, key, blockNum)
+		return nil, dbItr.Error()
+	}
+
+	_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(dbItr.Key(), compositeStartKey)
+	recordBlockNum, bytesConsumed := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[0:])
+	tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
+	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
+		namespace, key, recordBlockNum, tranNum)
+
+	tranEnvelope, err := q.blockStore.RetrieveTxByBlockNumTranNum(recordBlockNum, tranNum)
+	if err != nil {
+		return nil, err
+	}
+	queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	keyModification := queryResult.(*queryresult.KeyModification)
+	if keyModification.IsDelete {
+		return nil, nil
+	}
+	return keyModification.Value, nil
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -198,6 +198,25 @@ func TestHistory(t *testing.T) {
 		}
 	}
 	testutil.AssertEquals(t, count, 4)
+
+	// the state as of each block reflects the last write up to that block
+	value, err := qhistory.GetStateAtBlock("ns1", "key7", 0)
+	testutil.AssertNoError(t, err, "Error upon GetStateAtBlock()")
+	testutil.AssertNil(t, value)
+	value, err = qhistory.GetStateAtBlock("ns1", "key7", 1)
+	testutil.AssertNoError(t, err, "Error upon GetStateAtBlock()")
+	testutil.AssertEquals(t, value, value1)
+	value, err = qhistory.GetStateAtBlock("ns1", "key7", 2)
+	testutil.AssertNoError(t, err, "Error upon GetStateAtBlock()")
+	testutil.AssertEquals(t, value, value3)
+	value, err = qhistory.GetStateAtBlock("ns1", "key7", 3)
+	testutil.AssertNoError(t, err, "Error upon GetStateAtBlock()")
+	testutil.AssertNil(t, value)
+	value, err = qhistory.GetStateAtBlock("ns1", "key8", 2)
+	testutil.AssertNoError(t, err, "Error upon GetStateAtBlock()")
+	testutil.AssertNil(t, value)
+	_, err = qhistory.GetStateAtBlock("ns1", "key7", 4)
+	testutil.AssertError(t, err, "Expected error for a block that was not committed yet")
 }
 
 func TestHistoryForInvalidTran(t *testing.T) {
@@ -254,6 +273,8 @@ func TestHistoryDisabled(t *testing.T) {
 	testutil.AssertNoError(t, err, "Error upon NewHistoryQueryExecutor")
 	_, err2 := qhistory.GetHistoryForKey("ns1", "key7")
 	testutil.AssertError(t, err2, "Error should have been returned for GetHistoryForKey() when history disabled")
+	_, err2 = qhistory.GetStateAtBlock("ns1", "key7", 1)
+	testutil.AssertError(t, err2, "Error should have been returned for GetStateAtBlock() when history disabled")
 }
 
 //TestGenesisBlockNoError tests that Genesis blocks are ignored by history processing
@@ -102,6 +102,15 @@ func (l *kvLedger) recoverDBs() error {
 		return nil
 	}
 	lastAvailableBlockNum := info.Height - 1
+	if err := l.recoverLostBlocks(lastAvailableBlockNum); err != nil {
+		return err
+	}
+	// The pvt data of old blocks is applied once the state database holds all the blocks
+	return l.applyLastUpdatedOldBlocksPvtData()
+}
+
+// recoverLostBlocks recommits the blocks the state DB and the history DB lag behind the block storage
+func (l *kvLedger) recoverLostBlocks(lastAvailableBlockNum uint64) error {
 	recoverables := []recoverable{l.txtmgmt, l.historyDB}
 	recoverers := []*recoverer{}
 	for _, recoverable := range recoverables {
@@ -317,6 +326,60 @@ func (l *kvLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetriever, e
 	return l.configHistoryRetriever, nil
 }
 
+// GetMissingPvtDataInfoForBlocksBelow returns the pvt data that was missing at the commit
+// of the most recent blocks lower than blockNum, for at most maxBlocks blocks
+func (l *kvLedger) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	return l.blockStore.GetMissingPvtDataInfoForBlocksBelow(blockNum, maxBlocks)
+}
+
+// CommitPvtDataOfOldBlocks commits the pvt data that was missing at the commit of already committed
+// blocks into the pvt data store, and applies it to the state database
+func (l *kvLedger) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	committed, err := l.blockStore.CommitPvtDataOfOldBlocks(blocksPvtData)
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Channel [%s]: Committing pvt data of %d old blocks to state database", l.ledgerID, len(committed))
+	if err := l.txtmgmt.CommitPvtDataOfOldBlocks(committed); err != nil {
+		panic(fmt.Errorf(`Error during commit of pvt data of old blocks to txmgr:%s`, err))
+	}
+	return l.blockStore.ResetLastUpdatedOldBlocksList()
+}
+
+// applyLastUpdatedOldBlocksPvtData applies to the state DB the pvt data of old blocks that was
+// committed to the pvt data store but not to the state DB, e.g. because of a crash in between.
+// Applying pvt data twice is harmless, as the txmgr skips the pvt data superseded in the state
+func (l *kvLedger) applyLastUpdatedOldBlocksPvtData() error {
+	blocksPvtData, err := l.blockStore.GetLastUpdatedOldBlocksPvtData()
+	if err != nil {
+		return err
+	}
+	if len(blocksPvtData) == 0 {
+		return nil
+	}
+	logger.Infof("Channel [%s]: Applying pvt data of %d old blocks to state database", l.ledgerID, len(blocksPvtData))
+	if err := l.txtmgmt.CommitPvtDataOfOldBlocks(blocksPvtData); err != nil {
+		return err
+	}
+	return l.blockStore.ResetLastUpdatedOldBlocksList()
+}
+
+// RecordMissingPvtDataOfOldBlocks records the given pvt data of already committed blocks as missing,
+// so that it can be committed later on via the function `CommitPvtDataOfOldBlocks`
+func (l *kvLedger) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	return l.blockStore.RecordMissingPvtDataOfOldBlocks(missingPvtData)
+}
+
+// GetPvtDataBackfillHeight returns the height up to which the blocks were inspected by the pvt data back-fill
+func (l *kvLedger) GetPvtDataBackfillHeight() (uint64, error) {
+	return l.blockStore.GetPvtDataBackfillHeight()
+}
+
+// SetPvtDataBackfillHeight records the height up to which the blocks were inspected by the pvt data back-fill
+func (l *kvLedger) SetPvtDataBackfillHeight(height uint64) error {
+	return l.blockStore.SetPvtDataBackfillHeight(height)
+}
+
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
 	l.blockStore.Shutdown()
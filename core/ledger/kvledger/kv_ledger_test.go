@@ -369,6 +369,61 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 	)
 }
 
+func TestKVLedgerPvtDataOfOldBlocksRecovery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider, _ := NewProvider()
+	defer provider.Close()
+	testLedgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerid, false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	// the state DB has the hash of the pvt value, but not the pvt value
+	assertPvtValueMissing := func(l lgr.PeerLedger) {
+		simulator, _ := l.NewTxSimulator("assertPvtValueMissing")
+		defer simulator.Done()
+		_, err := simulator.GetPrivateData("ns", "coll", "key1")
+		assert.Error(t, err)
+	}
+
+	collectionConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "simulationForCollConfig", "ns", map[string]uint64{"coll": 0})
+	assert.NoError(t, ledger.CommitWithPvtData(collectionConfigBlk))
+
+	// the pvt data of the blocks 2 and 3 is missing at their commit
+	missingPvtData := map[uint64]*lgr.BlockPvtData{}
+	for blockNum, simulation := range map[uint64]string{2: "SimulateForBlk2", 3: "SimulateForBlk3"} {
+		blockAndPvtdata := prepareNextBlockForTest(t, ledger, bg, simulation,
+			map[string]string{"key1": "value1." + simulation},
+			map[string]string{"key1": "pvtValue1." + simulation})
+		missingPvtData[blockNum] = &lgr.BlockPvtData{BlockNum: blockNum, WriteSets: blockAndPvtdata.BlockPvtData}
+		blockAndPvtdata.BlockPvtData = nil
+		blockAndPvtdata.Missing = []lgr.MissingPrivateData{{SeqInBlock: 0, Namespace: "ns", Collection: "coll"}}
+		assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata))
+		assertPvtValueMissing(ledger)
+	}
+
+	// the pvt data of block 2 is fetched later on, but it is superseded in the state by block 3
+	assert.NoError(t, ledger.CommitPvtDataOfOldBlocks([]*lgr.BlockPvtData{missingPvtData[2]}))
+	assertPvtValueMissing(ledger)
+
+	// the peer fails after committing the pvt data of block 3 to the pvt data
+	// store but before applying it to the state DB
+	_, err := ledger.(*kvLedger).blockStore.CommitPvtDataOfOldBlocks([]*lgr.BlockPvtData{missingPvtData[3]})
+	assert.NoError(t, err)
+	assertPvtValueMissing(ledger)
+	ledger.Close()
+	provider.Close()
+
+	// the state DB should be recovered before returning from NewKVLedger call
+	provider, _ = NewProvider()
+	ledger, _ = provider.Open(testLedgerid)
+	checkStateDBForTest(t, ledger, nil, map[string]string{"key1": "pvtValue1.SimulateForBlk3"})
+	lastUpdatedOldBlocksPvtData, err := ledger.(*kvLedger).blockStore.GetLastUpdatedOldBlocksPvtData()
+	assert.NoError(t, err)
+	assert.Empty(t, lastUpdatedOldBlocksPvtData)
+}
+
 func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 
 	//call a helper method to load the core.yaml
@@ -15,6 +15,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/sinochem-tech/fabric/core/ledger/pvtdatapolicy"
+	"github.com/sinochem-tech/fabric/core/ledger/util"
 )
 
 // PurgeMgr manages purging of the expired pvtdata
@@ -29,6 +30,9 @@ type PurgeMgr interface {
 		hashedUpdates *privacyenabledstate.HashedUpdateBatch) error
 	// BlockCommitDone is a callback to the PurgeMgr when the block is committed to the ledger
 	BlockCommitDone() error
+	// UpdateBookkeepingForPvtDataOfOldBlocks updates the bookkeeping with the pvt keys of the pvtdata committed
+	// after the commit of their blocks, so that the pvt keys get purged along with their key hashes
+	UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates *privacyenabledstate.PvtUpdateBatch) error
 }
 
 type keyAndVersion struct {
@@ -142,6 +146,38 @@ func (p *purgeMgr) BlockCommitDone() error {
 	return p.expKeeper.updateBookkeeping(nil, p.workingset.toClearFromSchedule)
 }
 
+// UpdateBookkeepingForPvtDataOfOldBlocks implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates *privacyenabledstate.PvtUpdateBatch) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	builder := newExpiryScheduleBuilder(p.btlPolicy)
+	for pvtUpdateKey, vv := range pvtUpdates.ToCompositeKeyMap() {
+		keyHash := util.ComputeStringHash(pvtUpdateKey.Key)
+		if err := builder.add(pvtUpdateKey.Namespace, pvtUpdateKey.CollectionName, pvtUpdateKey.Key, keyHash, vv); err != nil {
+			return err
+		}
+	}
+
+	// The key hashes were already scheduled for expiry with the commit of their blocks,
+	// hence the existing entries are merged with the pvt keys
+	var toTrack []*expiryInfo
+	for _, expinfo := range builder.getExpiryInfo() {
+		existingExpinfos, err := p.expKeeper.retrieve(expinfo.expiryInfoKey.expiryBlk)
+		if err != nil {
+			return err
+		}
+		for _, existingExpinfo := range existingExpinfos {
+			if existingExpinfo.expiryInfoKey.committingBlk == expinfo.expiryInfoKey.committingBlk {
+				expinfo.pvtdataKeys = mergePvtdataKeys(existingExpinfo.pvtdataKeys, expinfo.pvtdataKeys)
+				break
+			}
+		}
+		toTrack = append(toTrack, expinfo)
+	}
+	return p.expKeeper.updateBookkeeping(toTrack, nil)
+}
+
 // prepareWorkingsetFor returns a working set for a given expiring block 'expiringAtBlk'.
 // This working set contains the pvt data keys that will expire with the commit of block 'expiringAtBlk'.
 func (p *purgeMgr) prepareWorkingsetFor(expiringAtBlk uint64) *workingset {
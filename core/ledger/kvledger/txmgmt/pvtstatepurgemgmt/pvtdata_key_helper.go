@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package pvtstatepurgemgmt
 
+import "bytes"
+
 func (pvtdataKeys *PvtdataKeys) add(ns string, coll string, key string, keyhash []byte) {
 	colls := pvtdataKeys.getOrCreateCollections(ns)
 	keysAndHashes := colls.getOrCreateKeysAndHashes(coll)
@@ -30,6 +32,37 @@ func (colls *Collections) getOrCreateKeysAndHashes(coll string) *KeysAndHashes {
 	return keysAndHashes
 }
 
+// mergePvtdataKeys returns the keys and key hashes of 'existing' with the keys of 'pvtdataKeys' filled in,
+// along with the keys and key hashes of 'pvtdataKeys' that aren't present in 'existing'
+func mergePvtdataKeys(existing *PvtdataKeys, pvtdataKeys *PvtdataKeys) *PvtdataKeys {
+	if existing.Map == nil {
+		existing.Map = make(map[string]*Collections)
+	}
+	for ns, colls := range pvtdataKeys.Map {
+		for coll, keysAndHashes := range colls.Map {
+			existingKeysAndHashes := existing.getOrCreateCollections(ns).getOrCreateKeysAndHashes(coll)
+			for _, keyAndHash := range keysAndHashes.List {
+				if !existingKeysAndHashes.fillKey(keyAndHash) {
+					existingKeysAndHashes.List = append(existingKeysAndHashes.List, keyAndHash)
+				}
+			}
+		}
+	}
+	return existing
+}
+
+// fillKey sets the key of the entry with the same key hash as the given one, and
+// returns false if there is no such entry
+func (keysAndHashes *KeysAndHashes) fillKey(keyAndHash *KeyAndHash) bool {
+	for _, existing := range keysAndHashes.List {
+		if bytes.Equal(existing.Hash, keyAndHash.Hash) {
+			existing.Key = keyAndHash.Key
+			return true
+		}
+	}
+	return false
+}
+
 func newPvtdataKeys() *PvtdataKeys {
 	return &PvtdataKeys{Map: make(map[string]*Collections)}
 }
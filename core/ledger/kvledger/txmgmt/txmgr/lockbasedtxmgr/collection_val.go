@@ -30,6 +30,11 @@ func newCollNameValidator(queryHelper *queryHelper) *collNameValidator {
 
 func (v *collNameValidator) validateCollName(ns, coll string) error {
 	logger.Debugf("validateCollName() begin - ns=[%s], coll=[%s]", ns, coll)
+	// implicit collections exist in every namespace without being part of its collection config
+	if isImplicit, _ := privdata.MspIDIfImplicitCollection(coll); isImplicit {
+		logger.Debugf("validateCollName() validated implicit collection - ns=[%s], coll=[%s]", ns, coll)
+		return nil
+	}
 	if !v.cache.isPopulatedFor(ns) {
 		conf, err := v.retrieveCollConfigFromStateDB(ns)
 		if err != nil {
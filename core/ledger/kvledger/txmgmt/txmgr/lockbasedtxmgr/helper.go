@@ -128,6 +128,24 @@ func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	return val, nil
 }
 
+func (h *queryHelper) getPrivateDataHash(ns, coll, key string) ([]byte, error) {
+	if err := h.validateCollName(ns, coll); err != nil {
+		return nil, err
+	}
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	versionedValue, err := h.txmgr.db.GetValueHash(ns, coll, util.ComputeStringHash(key))
+	if err != nil {
+		return nil, err
+	}
+	valHash, ver := decomposeVersionedValue(versionedValue)
+	if h.rwsetBuilder != nil {
+		h.rwsetBuilder.AddToHashedReadSet(ns, coll, key, ver)
+	}
+	return valHash, nil
+}
+
 func (h *queryHelper) getPrivateDataMultipleKeys(ns, coll string, keys []string) ([][]byte, error) {
 	if err := h.validateCollName(ns, coll); err != nil {
 		return nil, err
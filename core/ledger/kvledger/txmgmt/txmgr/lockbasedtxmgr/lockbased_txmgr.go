@@ -15,9 +15,11 @@ import (
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/pvtstatepurgemgmt"
+	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/validator"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/validator/valimpl"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/sinochem-tech/fabric/core/ledger/util"
 	"github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
 )
@@ -207,6 +209,82 @@ func (txmgr *LockBasedTxMgr) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvt
 	return txmgr.Commit()
 }
 
+// CommitPvtDataOfOldBlocks implements method in interface `txmgmt.TxMgr`
+// The pvt data of a key is applied to the state only if the key hash in the state was written by
+// the transaction of the pvt data, i.e., the key was not updated by a later transaction
+func (txmgr *LockBasedTxMgr) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	txmgr.commitRWLock.Lock()
+	defer txmgr.commitRWLock.Unlock()
+
+	pvtUpdates := privacyenabledstate.NewPvtUpdateBatch()
+	for _, blockPvtData := range blocksPvtData {
+		for _, txPvtData := range blockPvtData.WriteSets {
+			txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(txPvtData.WriteSet)
+			if err != nil {
+				return err
+			}
+			txHeight := version.NewHeight(blockPvtData.BlockNum, txPvtData.SeqInBlock)
+			if err := txmgr.addPvtDataOfOldTx(txPvtRWSet, txHeight, pvtUpdates); err != nil {
+				return err
+			}
+		}
+	}
+	if pvtUpdates.IsEmpty() {
+		return nil
+	}
+
+	if err := txmgr.pvtdataPurgeMgr.UpdateBookkeepingForPvtDataOfOldBlocks(pvtUpdates); err != nil {
+		return err
+	}
+	// the savepoint is left untouched, as the updates belong to already committed blocks
+	savepoint, err := txmgr.db.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PvtUpdates = pvtUpdates
+	if err := txmgr.db.ApplyPrivacyAwareUpdates(batch, savepoint); err != nil {
+		return err
+	}
+	txmgr.clearCache()
+	return nil
+}
+
+func (txmgr *LockBasedTxMgr) addPvtDataOfOldTx(txPvtRWSet *rwsetutil.TxPvtRwSet, txHeight *version.Height,
+	pvtUpdates *privacyenabledstate.PvtUpdateBatch) error {
+	for _, ns := range txPvtRWSet.NsPvtRwSet {
+		for _, coll := range ns.CollPvtRwSets {
+			for _, kvwrite := range coll.KvRwSet.Writes {
+				committedVersion, err := txmgr.db.GetKeyHashVersion(ns.NameSpace, coll.CollectionName, util.ComputeStringHash(kvwrite.Key))
+				if err != nil {
+					return err
+				}
+				if !kvwrite.IsDelete {
+					if committedVersion == nil || committedVersion.Compare(txHeight) != 0 {
+						logger.Debugf("Skipping stale pvt data of key [%s] in namespace [%s], collection [%s] at height [%#v]",
+							kvwrite.Key, ns.NameSpace, coll.CollectionName, txHeight)
+						continue
+					}
+					pvtUpdates.Put(ns.NameSpace, coll.CollectionName, kvwrite.Key, kvwrite.Value, txHeight)
+					continue
+				}
+				// the delete is applied only if the key is still deleted, and the pvt state holds an older value
+				if committedVersion != nil {
+					continue
+				}
+				committedPvtValue, err := txmgr.db.GetPrivateData(ns.NameSpace, coll.CollectionName, kvwrite.Key)
+				if err != nil {
+					return err
+				}
+				if committedPvtValue != nil && committedPvtValue.Version.Compare(txHeight) < 0 {
+					pvtUpdates.Delete(ns.NameSpace, coll.CollectionName, kvwrite.Key, txHeight)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func extractStateUpdates(batch *privacyenabledstate.UpdateBatch, namespaces []string) ledger.StateUpdates {
 	stateupdates := make(ledger.StateUpdates)
 	for _, namespace := range namespaces {
@@ -21,11 +21,13 @@ import (
 	"github.com/sinochem-tech/fabric/common/ledger/testutil"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/version"
 	ledgertestutil "github.com/sinochem-tech/fabric/core/ledger/testutil"
 	"github.com/sinochem-tech/fabric/core/ledger/util"
 	"github.com/sinochem-tech/fabric/protos/ledger/queryresult"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -769,6 +771,64 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	testutil.AssertNil(t, val)
 }
 
+func TestTxSimulatorPrivateDataHash(t *testing.T) {
+	testEnv := testEnvs[0]
+	testEnv.init(t, "TestTxSimulatorPrivateDataHash", nil)
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr),
+		[]collConfigkey{
+			{"ns1", "coll1"},
+			{"ns1", "coll2"},
+		},
+		version.NewHeight(1, 1),
+	)
+
+	// the private data of coll2 is not present on this peer, only its hash
+	db := testEnv.getVDB()
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 1))
+	updateBatch.PvtUpdates.Put("ns1", "coll1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updateBatch.HashUpdates.Put("ns1", "coll2", util.ComputeStringHash("key2"), util.ComputeStringHash("value2"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1))
+
+	simulator, _ := txMgr.NewTxSimulator("testTxid1")
+	val, err := simulator.GetPrivateDataHash("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value1"), val)
+	val, err = simulator.GetPrivateDataHash("ns1", "coll2", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value2"), val)
+	val, err = simulator.GetPrivateDataHash("ns1", "coll2", "non-existing-key")
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+	_, err = simulator.GetPrivateDataHash("ns1", "non-existing-coll", "key1")
+	assert.Error(t, err)
+
+	// reading the hash is recorded in the hashed read set of the collection
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	assert.NoError(t, err)
+	var collHashedRWSet *rwsetutil.CollHashedRwSet
+	for _, ns := range txRWSet.NsRwSets {
+		for _, coll := range ns.CollHashedRwSets {
+			if ns.NameSpace == "ns1" && coll.CollectionName == "coll2" {
+				collHashedRWSet = coll
+			}
+		}
+	}
+	assert.NotNil(t, collHashedRWSet)
+	assert.Len(t, collHashedRWSet.HashedRwSet.HashedReads, 2)
+	assert.Equal(t, util.ComputeStringHash("key2"), collHashedRWSet.HashedRwSet.HashedReads[0].KeyHash)
+	assert.Equal(t, &kvrwset.Version{BlockNum: 1, TxNum: 1}, collHashedRWSet.HashedRwSet.HashedReads[0].Version)
+	simulator.Done()
+
+	_, err = simulator.GetPrivateDataHash("ns1", "coll1", "key1")
+	assert.Error(t, err, "An error is expected when using simulator after calling `Done` function()")
+}
+
 func TestDeleteOnCursor(t *testing.T) {
 	cID := "cid"
 	env := testEnvs[0]
@@ -863,6 +923,53 @@ func TestTxSimulatorMissingPvtdataExpiry(t *testing.T) {
 	simulator.Done()
 }
 
+func TestCommitPvtDataOfOldBlocks(t *testing.T) {
+	ledgerid := "TestCommitPvtDataOfOldBlocks"
+	testEnv := testEnvs[0]
+	cs := btltestutil.NewMockCollectionStore()
+	cs.SetBTL("ns", "coll", 0)
+	testEnv.init(t, ledgerid, pvtdatapolicy.ConstructBTLPolicy(cs))
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr), []collConfigkey{{"ns", "coll"}}, version.NewHeight(1, 1))
+	bg, _ := testutil.NewBlockGenerator(t, ledgerid, false)
+
+	// block 1 is committed without its pvt data
+	blkAndPvtdata1 := prepareNextBlockForTest(t, txMgr, bg, "txid-1",
+		map[string]string{"pubkey1": "pub-value1"}, map[string]string{"pvtkey1": "pvt-value1", "pvtkey2": "pvt-value2"})
+	missingPvtData1 := blkAndPvtdata1.BlockPvtData
+	blkAndPvtdata1.BlockPvtData = nil
+	testutil.AssertNoError(t, txMgr.ValidateAndPrepare(blkAndPvtdata1, true), "")
+	testutil.AssertNoError(t, txMgr.Commit(), "")
+
+	simulator, _ := txMgr.NewTxSimulator("tx-tmp")
+	_, err := simulator.GetPrivateData("ns", "coll", "pvtkey1")
+	_, ok := err.(*txmgr.ErrPvtdataNotAvailable)
+	testutil.AssertEquals(t, ok, true)
+	simulator.Done()
+
+	// block 2 updates pvtkey2, along with its pvt data
+	blkAndPvtdata2 := prepareNextBlockForTest(t, txMgr, bg, "txid-2",
+		map[string]string{"pubkey1": "pub-value3"}, map[string]string{"pvtkey2": "pvt-value3"})
+	testutil.AssertNoError(t, txMgr.ValidateAndPrepare(blkAndPvtdata2, true), "")
+	testutil.AssertNoError(t, txMgr.Commit(), "")
+
+	// the pvt data of block 1 is committed retroactively, without overwriting the more recent pvtkey2
+	testutil.AssertNoError(t, txMgr.CommitPvtDataOfOldBlocks([]*ledger.BlockPvtData{
+		{BlockNum: blkAndPvtdata1.Block.Header.Number, WriteSets: missingPvtData1},
+	}), "")
+
+	simulator, _ = txMgr.NewTxSimulator("tx-tmp")
+	defer simulator.Done()
+	pvtval, err := simulator.GetPrivateData("ns", "coll", "pvtkey1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pvtval, []byte("pvt-value1"))
+	pvtval, err = simulator.GetPrivateData("ns", "coll", "pvtkey2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, pvtval, []byte("pvt-value3"))
+}
+
 func prepareNextBlockForTest(t *testing.T, txMgr txmgr.TxMgr, bg *testutil.BlockGenerator,
 	txid string, pubKVs map[string]string, pvtKVs map[string]string) *ledger.BlockAndPvtData {
 	simulator, _ := txMgr.NewTxSimulator(txid)
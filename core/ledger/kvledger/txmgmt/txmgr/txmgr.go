@@ -29,6 +29,7 @@ type TxMgr interface {
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error
 	Commit() error
 	Rollback()
 	Shutdown()
@@ -75,6 +75,24 @@ type PeerLedger interface {
 	Prune(policy commonledger.PrunePolicy) error
 	// GetConfigHistoryRetriever returns the ConfigHistoryRetriever
 	GetConfigHistoryRetriever() (ConfigHistoryRetriever, error)
+	// GetMissingPvtDataInfoForBlocksBelow returns the private write sets that were missing
+	// at the commit of the most recent blocks lower than blockNum, for at most maxBlocks blocks.
+	// Private write sets that already expired are not included
+	GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (MissingPvtDataInfo, error)
+	// CommitPvtDataOfOldBlocks commits the private write sets of already committed blocks,
+	// which were missing at the commit of the blocks. Private write sets which aren't recorded
+	// as missing are ignored
+	CommitPvtDataOfOldBlocks(blocksPvtData []*BlockPvtData) error
+	// RecordMissingPvtDataOfOldBlocks records the given private write sets of already committed
+	// blocks as missing, so that they can be committed via CommitPvtDataOfOldBlocks. Private write
+	// sets which are already present, already recorded as missing or already expired are ignored
+	RecordMissingPvtDataOfOldBlocks(missingPvtData MissingPvtDataInfo) error
+	// GetPvtDataBackfillHeight returns the height up to which the blocks were inspected by
+	// the private data back-fill, or 0 if it was never recorded
+	GetPvtDataBackfillHeight() (uint64, error)
+	// SetPvtDataBackfillHeight records the height up to which the blocks were inspected by
+	// the private data back-fill, so that the back-fill resumes from there after a restart
+	SetPvtDataBackfillHeight(height uint64) error
 }
 
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
@@ -108,6 +126,9 @@ type QueryExecutor interface {
 	ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error)
 	// GetPrivateData gets the value of a private data item identified by a tuple <namespace, collection, key>
 	GetPrivateData(namespace, collection, key string) ([]byte, error)
+	// GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>.
+	// It is available regardless of whether the peer is a member of the collection
+	GetPrivateDataHash(namespace, collection, key string) ([]byte, error)
 	// GetPrivateDataMetadata gets the metadata of a private data item identified by a tuple <namespace, collection, key>
 	GetPrivateDataMetadata(namespace, collection, key string) (map[string][]byte, error)
 	// GetPrivateDataMultipleKeys gets the values for the multiple private data items in a single call
@@ -132,6 +153,9 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetStateAtBlock gets the value the given key had right after the commit of the given block.
+	// A nil value is returned if the key did not exist at that block.
+	GetStateAtBlock(namespace string, key string, blockNum uint64) ([]byte, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -187,6 +211,9 @@ type MissingPrivateData struct {
 	SeqInBlock int
 	Namespace  string
 	Collection string
+	// IsBackfill indicates that the private data is missing because the peer became
+	// eligible for the collection only after the private data was committed
+	IsBackfill bool
 }
 
 // BlockAndPvtData encapsulates the block and a map that contains the tuples <seqInBlock, *TxPvtData>
@@ -197,6 +224,17 @@ type BlockAndPvtData struct {
 	Missing      []MissingPrivateData
 }
 
+// MissingPvtDataInfo maps the number of a committed block to the private
+// write sets that were missing at the commit of the block
+type MissingPvtDataInfo map[uint64][]MissingPrivateData
+
+// BlockPvtData encapsulates the number of an already committed block and
+// the tuples <seqInBlock, *TxPvtData> of its private data
+type BlockPvtData struct {
+	BlockNum  uint64
+	WriteSets map[uint64]*TxPvtData
+}
+
 // PvtCollFilter represents the set of the collection names (as keys of the map with value 'true')
 type PvtCollFilter map[string]bool
 
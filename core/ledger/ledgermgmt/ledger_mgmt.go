@@ -46,10 +46,11 @@ var lock sync.Mutex
 var initialized bool
 var once sync.Once
 
-// Initialize initializes ledgermgmt
-func Initialize(customTxProcessors customtx.Processors) {
+// Initialize initializes ledgermgmt. The supplied state listeners
+// are notified about the state updates of the opened ledgers
+func Initialize(customTxProcessors customtx.Processors, stateListeners ...ledger.StateListener) {
 	once.Do(func() {
-		initialize(customTxProcessors, nil)
+		initialize(customTxProcessors, stateListeners)
 	})
 }
 
@@ -115,7 +115,7 @@ func (s *Store) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
 		for _, v := range blockAndPvtdata.BlockPvtData {
 			pvtdata = append(pvtdata, v)
 		}
-		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, pvtdata); err != nil {
+		if err := s.pvtdataStore.Prepare(blockAndPvtdata.Block.Header.Number, pvtdata, blockAndPvtdata.Missing); err != nil {
 			return err
 		}
 		writtenToPvtStore = true
@@ -161,6 +161,72 @@ func (s *Store) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter)
 	return s.getPvtDataByNumWithoutLock(blockNum, filter)
 }
 
+// GetMissingPvtDataInfoForBlocksBelow returns the pvt data that was missing at the commit
+// of the most recent blocks lower than `blockNum`, for at most `maxBlock` blocks
+func (s *Store) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	s.rwlock.RLock()
+	defer s.rwlock.RUnlock()
+	return s.pvtdataStore.GetMissingPvtDataInfoForBlocksBelow(blockNum, maxBlock)
+}
+
+// CommitPvtDataOfOldBlocks commits the pvt data that was missing at the commit of already committed
+// blocks, and returns the pvt data actually committed
+func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) ([]*ledger.BlockPvtData, error) {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.CommitPvtDataOfOldBlocks(blocksPvtData)
+}
+
+// GetLastUpdatedOldBlocksPvtData returns the pvt data of the old blocks committed via the function
+// `CommitPvtDataOfOldBlocks` and not yet reset via the function `ResetLastUpdatedOldBlocksList`
+func (s *Store) GetLastUpdatedOldBlocksPvtData() ([]*ledger.BlockPvtData, error) {
+	s.rwlock.RLock()
+	defer s.rwlock.RUnlock()
+	blockNums, err := s.pvtdataStore.GetLastUpdatedOldBlocksList()
+	if err != nil {
+		return nil, err
+	}
+	var blocksPvtData []*ledger.BlockPvtData
+	for _, blockNum := range blockNums {
+		pvtdata, err := s.getPvtDataByNumWithoutLock(blockNum, nil)
+		if err != nil {
+			return nil, err
+		}
+		blockPvtData := &ledger.BlockPvtData{BlockNum: blockNum, WriteSets: make(map[uint64]*ledger.TxPvtData)}
+		for _, txPvtData := range pvtdata {
+			blockPvtData.WriteSets[txPvtData.SeqInBlock] = txPvtData
+		}
+		blocksPvtData = append(blocksPvtData, blockPvtData)
+	}
+	return blocksPvtData, nil
+}
+
+// ResetLastUpdatedOldBlocksList resets the list of the old blocks whose
+// pvt data was committed via the function `CommitPvtDataOfOldBlocks`
+func (s *Store) ResetLastUpdatedOldBlocksList() error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.ResetLastUpdatedOldBlocksList()
+}
+
+// RecordMissingPvtDataOfOldBlocks records the given pvt data of already committed blocks as missing,
+// so that it can be committed later on via the function `CommitPvtDataOfOldBlocks`
+func (s *Store) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.RecordMissingPvtDataOfOldBlocks(missingPvtData)
+}
+
+// GetPvtDataBackfillHeight returns the height up to which the blocks were inspected by the pvt data back-fill
+func (s *Store) GetPvtDataBackfillHeight() (uint64, error) {
+	return s.pvtdataStore.GetBackfillHeight()
+}
+
+// SetPvtDataBackfillHeight records the height up to which the blocks were inspected by the pvt data back-fill
+func (s *Store) SetPvtDataBackfillHeight(height uint64) error {
+	return s.pvtdataStore.SetBackfillHeight(height)
+}
+
 // getPvtDataByNumWithoutLock returns only the pvt data  corresponding to the given block number.
 // This function does not acquire a readlock and it is expected that in most of the circumstances, the caller
 // posesses a read lock on `s.rwlock`
@@ -42,7 +42,8 @@ type btlkey struct {
 
 // NewBTLPolicy constructs an instance of LSCCBasedBTLPolicy
 func NewBTLPolicy(ledger ledger.PeerLedger) BTLPolicy {
-	return ConstructBTLPolicy(privdata.NewSimpleCollectionStore(&collectionSupport{lgr: ledger}))
+	// the dissemination policy of the implicit collections doesn't affect their block to live
+	return ConstructBTLPolicy(privdata.NewSimpleCollectionStore(&collectionSupport{lgr: ledger}, privdata.ImplicitCollectionDisseminationPolicy{}))
 }
 
 // ConstructBTLPolicy constructs an instance of LSCCBasedBTLPolicy
@@ -14,13 +14,24 @@ import (
 	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
 )
 
-func prepareStoreEntries(blockNum uint64, pvtdata []*ledger.TxPvtData, btlPolicy pvtdatapolicy.BTLPolicy) ([]*dataEntry, []*expiryEntry, error) {
+func prepareStoreEntries(blockNum uint64, pvtdata []*ledger.TxPvtData, missingPvtData []ledger.MissingPrivateData,
+	btlPolicy pvtdatapolicy.BTLPolicy) ([]*dataEntry, []*missingDataEntry, []*expiryEntry, error) {
 	dataEntries := prepareDataEntries(blockNum, pvtdata)
-	expiryEntries, err := prepareExpiryEntries(blockNum, dataEntries, btlPolicy)
+	missingDataEntries := prepareMissingDataEntries(blockNum, missingPvtData)
+	// The expiry entries cover the missing data as well, so that it stops being
+	// reported as missing once it expires
+	dataKeys := make([]*dataKey, 0, len(dataEntries)+len(missingDataEntries))
+	for _, dataEntry := range dataEntries {
+		dataKeys = append(dataKeys, dataEntry.key)
+	}
+	for _, missingDataEntry := range missingDataEntries {
+		dataKeys = append(dataKeys, missingDataEntry.key)
+	}
+	expiryEntries, err := prepareExpiryEntries(blockNum, dataKeys, btlPolicy)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return dataEntries, expiryEntries, nil
+	return dataEntries, missingDataEntries, expiryEntries, nil
 }
 
 func prepareDataEntries(blockNum uint64, pvtData []*ledger.TxPvtData) []*dataEntry {
@@ -39,10 +50,19 @@ func prepareDataEntries(blockNum uint64, pvtData []*ledger.TxPvtData) []*dataEnt
 	return dataEntries
 }
 
-func prepareExpiryEntries(committingBlk uint64, dataEntries []*dataEntry, btlPolicy pvtdatapolicy.BTLPolicy) ([]*expiryEntry, error) {
+func prepareMissingDataEntries(blockNum uint64, missingPvtData []ledger.MissingPrivateData) []*missingDataEntry {
+	var missingDataEntries []*missingDataEntry
+	for _, missing := range missingPvtData {
+		dataKey := &dataKey{blockNum, uint64(missing.SeqInBlock), missing.Namespace, missing.Collection}
+		missingDataEntries = append(missingDataEntries, &missingDataEntry{key: dataKey, txID: missing.TxId, isBackfill: missing.IsBackfill})
+	}
+	return missingDataEntries
+}
+
+func prepareExpiryEntries(committingBlk uint64, dataKeys []*dataKey, btlPolicy pvtdatapolicy.BTLPolicy) ([]*expiryEntry, error) {
 	mapByExpiringBlk := make(map[uint64]*ExpiryData)
-	for _, dataEntry := range dataEntries {
-		expiringBlk, err := btlPolicy.GetExpiringBlock(dataEntry.key.ns, dataEntry.key.coll, dataEntry.key.blkNum)
+	for _, dataKey := range dataKeys {
+		expiringBlk, err := btlPolicy.GetExpiringBlock(dataKey.ns, dataKey.coll, dataKey.blkNum)
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +74,7 @@ func prepareExpiryEntries(committingBlk uint64, dataEntries []*dataEntry, btlPol
 			expiryData = newExpiryData()
 			mapByExpiringBlk[expiringBlk] = expiryData
 		}
-		expiryData.add(dataEntry.key.ns, dataEntry.key.coll, dataEntry.key.txNum)
+		expiryData.add(dataKey.ns, dataKey.coll, dataKey.txNum)
 	}
 	var expiryEntries []*expiryEntry
 	for expiryBlk, expiryData := range mapByExpiringBlk {
@@ -130,3 +150,31 @@ func (a *txPvtdataAssembler) getTxPvtdata() *ledger.TxPvtData {
 	a.done()
 	return &ledger.TxPvtData{SeqInBlock: a.txNum, WriteSet: a.txWset}
 }
+
+func writeSetsAsSlice(writeSets map[uint64]*ledger.TxPvtData) []*ledger.TxPvtData {
+	var pvtData []*ledger.TxPvtData
+	for _, txPvtdata := range writeSets {
+		pvtData = append(pvtData, txPvtdata)
+	}
+	return pvtData
+}
+
+// addCollPvtData adds the collection pvt write set of the given data entry
+// to the pvt data of its transaction in the given map
+func addCollPvtData(writeSets map[uint64]*ledger.TxPvtData, dataEntry *dataEntry) {
+	txPvtdata, ok := writeSets[dataEntry.key.txNum]
+	if !ok {
+		txPvtdata = &ledger.TxPvtData{SeqInBlock: dataEntry.key.txNum, WriteSet: &rwset.TxPvtReadWriteSet{}}
+		writeSets[dataEntry.key.txNum] = txPvtdata
+	}
+	for _, nsPvtdata := range txPvtdata.WriteSet.NsPvtRwset {
+		if nsPvtdata.Namespace == dataEntry.key.ns {
+			nsPvtdata.CollectionPvtRwset = append(nsPvtdata.CollectionPvtRwset, dataEntry.value)
+			return
+		}
+	}
+	txPvtdata.WriteSet.NsPvtRwset = append(txPvtdata.WriteSet.NsPvtRwset, &rwset.NsPvtReadWriteSet{
+		Namespace:          dataEntry.key.ns,
+		CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{dataEntry.value},
+	})
+}
@@ -8,6 +8,7 @@ package pvtdatastorage
 
 import (
 	"bytes"
+	"math"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/version"
@@ -15,13 +16,22 @@ import (
 )
 
 var (
-	pendingCommitKey    = []byte{0}
-	lastCommittedBlkkey = []byte{1}
-	pvtDataKeyPrefix    = []byte{2}
-	expiryKeyPrefix     = []byte{3}
+	pendingCommitKey     = []byte{0}
+	lastCommittedBlkkey  = []byte{1}
+	pvtDataKeyPrefix     = []byte{2}
+	expiryKeyPrefix      = []byte{3}
+	missingDataKeyPrefix = []byte{4}
+	// lastUpdatedOldBlocksKey lists the old blocks whose pvt data was committed
+	// by the last invoke to `CommitPvtDataOfOldBlocks`, until it is reset
+	lastUpdatedOldBlocksKey = []byte{5}
+	// backfillHeightKey holds the height up to which the blocks were inspected by the pvt data back-fill
+	backfillHeightKey = []byte{6}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
+	// backfillMarker prefixes the value of the missing data entries recorded for back-fill.
+	// It can't be mistaken for the first byte of a txID, as txIDs are hex encoded
+	backfillMarker = byte(1)
 )
 
 func getDataKeysForRangeScanByBlockNum(blockNum uint64) (startKey, endKey []byte) {
@@ -36,6 +46,15 @@ func getExpiryKeysForRangeScan(minBlkNum, maxBlkNum uint64) (startKey, endKey []
 	return
 }
 
+// getMissingDataKeysForRangeScan returns the range of the missing data keys of the blocks
+// lower than the given block number. As the block numbers are encoded in reverse order,
+// the range lists the most recent blocks first
+func getMissingDataKeysForRangeScan(belowBlockNum uint64) (startKey, endKey []byte) {
+	startKey = append(missingDataKeyPrefix, version.NewHeight(math.MaxUint64-belowBlockNum+1, 0).ToBytes()...)
+	endKey = []byte{missingDataKeyPrefix[0] + 1}
+	return
+}
+
 func encodeLastCommittedBlockVal(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
@@ -45,6 +64,15 @@ func decodeLastCommittedBlockVal(blockNumBytes []byte) uint64 {
 	return s
 }
 
+func encodeBackfillHeightVal(height uint64) []byte {
+	return proto.EncodeVarint(height)
+}
+
+func decodeBackfillHeightVal(heightBytes []byte) uint64 {
+	h, _ := proto.DecodeVarint(heightBytes)
+	return h
+}
+
 func encodeDataKey(key *dataKey) []byte {
 	dataKeyBytes := append(pvtDataKeyPrefix, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
 	dataKeyBytes = append(dataKeyBytes, []byte(key.ns)...)
@@ -52,6 +80,48 @@ func encodeDataKey(key *dataKey) []byte {
 	return append(dataKeyBytes, []byte(key.coll)...)
 }
 
+// encodeMissingDataKey encodes the block number in reverse order so that
+// a range scan returns the missing data of the most recent blocks first
+func encodeMissingDataKey(key *dataKey) []byte {
+	missingDataKeyBytes := append(missingDataKeyPrefix, version.NewHeight(math.MaxUint64-key.blkNum, key.txNum).ToBytes()...)
+	missingDataKeyBytes = append(missingDataKeyBytes, []byte(key.ns)...)
+	missingDataKeyBytes = append(missingDataKeyBytes, nilByte)
+	return append(missingDataKeyBytes, []byte(key.coll)...)
+}
+
+func encodeMissingDataValue(txID string, isBackfill bool) []byte {
+	if isBackfill {
+		return append([]byte{backfillMarker}, []byte(txID)...)
+	}
+	return []byte(txID)
+}
+
+func encodeLastUpdatedOldBlocksList(blockNums []uint64) []byte {
+	buf := proto.NewBuffer(nil)
+	buf.EncodeVarint(uint64(len(blockNums)))
+	for _, blockNum := range blockNums {
+		buf.EncodeVarint(blockNum)
+	}
+	return buf.Bytes()
+}
+
+func decodeLastUpdatedOldBlocksList(blockNumsBytes []byte) ([]uint64, error) {
+	buf := proto.NewBuffer(blockNumsBytes)
+	numBlocks, err := buf.DecodeVarint()
+	if err != nil {
+		return nil, err
+	}
+	var blockNums []uint64
+	for i := uint64(0); i < numBlocks; i++ {
+		blockNum, err := buf.DecodeVarint()
+		if err != nil {
+			return nil, err
+		}
+		blockNums = append(blockNums, blockNum)
+	}
+	return blockNums, nil
+}
+
 func encodeDataValue(collData *rwset.CollectionPvtReadWriteSet) ([]byte, error) {
 	return proto.Marshal(collData)
 }
@@ -87,6 +157,19 @@ func decodeDatakey(datakeyBytes []byte) *dataKey {
 	return &dataKey{blkNum: blkNum, txNum: tranNum, ns: ns, coll: coll}
 }
 
+func decodeMissingDataKey(missingDataKeyBytes []byte) *dataKey {
+	key := decodeDatakey(missingDataKeyBytes)
+	key.blkNum = math.MaxUint64 - key.blkNum
+	return key
+}
+
+func decodeMissingDataValue(missingDataValueBytes []byte) (txID string, isBackfill bool) {
+	if len(missingDataValueBytes) > 0 && missingDataValueBytes[0] == backfillMarker {
+		return string(missingDataValueBytes[1:]), true
+	}
+	return string(missingDataValueBytes), false
+}
+
 func decodeDataValue(datavalueBytes []byte) (*rwset.CollectionPvtReadWriteSet, error) {
 	collPvtdata := &rwset.CollectionPvtReadWriteSet{}
 	err := proto.Unmarshal(datavalueBytes, collPvtdata)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package pvtdatastorage
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +18,13 @@ func TestDataKeyEncoding(t *testing.T) {
 	datakey2 := decodeDatakey(encodeDataKey(dataKey1))
 	assert.Equal(t, dataKey1, datakey2)
 }
+
+func TestMissingDataKeyEncoding(t *testing.T) {
+	missingDataKey1 := &dataKey{blkNum: 2, txNum: 5, ns: "ns1", coll: "coll1"}
+	missingDataKey2 := decodeMissingDataKey(encodeMissingDataKey(missingDataKey1))
+	assert.Equal(t, missingDataKey1, missingDataKey2)
+
+	// the missing data of the most recent blocks sorts first
+	missingDataKey3 := &dataKey{blkNum: 3, txNum: 1, ns: "ns1", coll: "coll1"}
+	assert.Equal(t, -1, bytes.Compare(encodeMissingDataKey(missingDataKey3), encodeMissingDataKey(missingDataKey1)))
+}
@@ -43,16 +43,43 @@ type Store interface {
 	// The pvt data is filtered by the list of 'ns/collections' supplied in the filter
 	// A nil filter does not filter any results
 	GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
+	// GetMissingPvtDataInfoForBlocksBelow returns the missing pvt data recorded for the most recent
+	// blocks lower than `blockNum`, for at most `maxBlock` blocks. The missing pvt data that already
+	// expired is not included
+	GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlock int) (ledger.MissingPvtDataInfo, error)
 	// Prepare prepares the Store for commiting the pvt data. This call does not commit the pvt data.
 	// Subsequently, the caller is expected to call either `Commit` or `Rollback` function.
 	// Return from this should ensure that enough preparation is done such that `Commit` function invoked afterwards
 	// can commit the data and the store is capable of surviving a crash between this function call and the next
-	// invoke to the `Commit`
-	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData) error
+	// invoke to the `Commit`. The `missingPvtData` is recorded so that it can be committed later on via
+	// the function `CommitPvtDataOfOldBlocks`
+	Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData []ledger.MissingPrivateData) error
 	// Commit commits the pvt data passed in the previous invoke to the `Prepare` function
 	Commit() error
 	// Rollback rolls back the pvt data passed in the previous invoke to the `Prepare` function
 	Rollback() error
+	// CommitPvtDataOfOldBlocks commits the pvt data of already committed blocks. Only the pvt data
+	// that was recorded as missing and that didn't expire yet is committed, the rest is ignored.
+	// It returns the pvt data actually committed
+	// The numbers of the blocks whose pvt data is committed are recorded in the same write, so that the
+	// caller can apply the pvt data to the state database after a crash, until `ResetLastUpdatedOldBlocksList`
+	// is invoked
+	CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) ([]*ledger.BlockPvtData, error)
+	// GetLastUpdatedOldBlocksList returns the numbers of the blocks whose pvt data was committed via the function
+	// `CommitPvtDataOfOldBlocks` since the last invoke to `ResetLastUpdatedOldBlocksList`
+	GetLastUpdatedOldBlocksList() ([]uint64, error)
+	// ResetLastUpdatedOldBlocksList resets the list of the blocks whose pvt data was committed via the function
+	// `CommitPvtDataOfOldBlocks`, once their pvt data is applied to the state database
+	ResetLastUpdatedOldBlocksList() error
+	// RecordMissingPvtDataOfOldBlocks records the given pvt data of already committed blocks as missing,
+	// so that it can be committed later on via the function `CommitPvtDataOfOldBlocks`. The pvt data that
+	// is already present, already recorded as missing or already expired is ignored
+	RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error
+	// GetBackfillHeight returns the height up to which the blocks were inspected by the pvt data
+	// back-fill, as recorded by the function `SetBackfillHeight`, or 0 if it was never recorded
+	GetBackfillHeight() (uint64, error)
+	// SetBackfillHeight records the height up to which the blocks were inspected by the pvt data back-fill
+	SetBackfillHeight(height uint64) error
 	// IsEmpty returns true if the store does not have any block committed yet
 	IsEmpty() (bool, error)
 	// LastCommittedBlockHeight returns the height of the last committed block
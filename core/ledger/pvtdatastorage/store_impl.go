@@ -42,6 +42,12 @@ type dataEntry struct {
 	value *rwset.CollectionPvtReadWriteSet
 }
 
+type missingDataEntry struct {
+	key        *dataKey
+	txID       string
+	isBackfill bool
+}
+
 type expiryEntry struct {
 	key   *expiryKey
 	value *ExpiryData
@@ -104,7 +110,7 @@ func (s *store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 }
 
 // Prepare implements the function in the interface `Store`
-func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData) error {
+func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData []ledger.MissingPrivateData) error {
 	if s.batchPending {
 		return &ErrIllegalCall{`A pending batch exists as as result of last invoke to "Prepare" call.
 			 Invoke "Commit" or "Rollback" on the pending batch before invoking "Prepare" function`}
@@ -117,7 +123,7 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData) error {
 	batch := leveldbhelper.NewUpdateBatch()
 	var err error
 	var keyBytes, valBytes []byte
-	dataEntries, expiryEntries, err := prepareStoreEntries(blockNum, pvtData, s.btlPolicy)
+	dataEntries, missingDataEntries, expiryEntries, err := prepareStoreEntries(blockNum, pvtData, missingPvtData, s.btlPolicy)
 	if err != nil {
 		return err
 	}
@@ -128,6 +134,9 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData) error {
 		}
 		batch.Put(keyBytes, valBytes)
 	}
+	for _, missingDataEntry := range missingDataEntries {
+		batch.Put(encodeMissingDataKey(missingDataEntry.key), encodeMissingDataValue(missingDataEntry.txID, missingDataEntry.isBackfill))
+	}
 	for _, expiryEntry := range expiryEntries {
 		keyBytes = encodeExpiryKey(expiryEntry.key)
 		if valBytes, err = encodeExpiryValue(expiryEntry.value); err != nil {
@@ -140,7 +149,8 @@ func (s *store) Prepare(blockNum uint64, pvtData []*ledger.TxPvtData) error {
 		return err
 	}
 	s.batchPending = true
-	logger.Debugf("Saved %d private data write sets for block [%d]", len(pvtData), blockNum)
+	logger.Debugf("Saved %d private data write sets and %d missing private data entries for block [%d]",
+		len(pvtData), len(missingPvtData), blockNum)
 	return nil
 }
 
@@ -235,6 +245,243 @@ func (s *store) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFil
 	return blockPvtdata, nil
 }
 
+// GetMissingPvtDataInfoForBlocksBelow implements the function in the interface `Store`
+func (s *store) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	if s.isEmpty || maxBlock < 1 || blockNum == 0 {
+		return nil, nil
+	}
+	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	startKey, endKey := getMissingDataKeysForRangeScan(blockNum)
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	for itr.Next() {
+		missingDataKey := decodeMissingDataKey(itr.Key())
+		if _, ok := missingPvtDataInfo[missingDataKey.blkNum]; !ok && len(missingPvtDataInfo) == maxBlock {
+			break
+		}
+		expired, err := isExpired(missingDataKey, s.btlPolicy, s.lastCommittedBlock)
+		if err != nil {
+			return nil, err
+		}
+		if expired {
+			continue
+		}
+		txID, isBackfill := decodeMissingDataValue(itr.Value())
+		missingPvtDataInfo[missingDataKey.blkNum] = append(missingPvtDataInfo[missingDataKey.blkNum], ledger.MissingPrivateData{
+			TxId:       txID,
+			SeqInBlock: int(missingDataKey.txNum),
+			Namespace:  missingDataKey.ns,
+			Collection: missingDataKey.coll,
+			IsBackfill: isBackfill,
+		})
+	}
+	return missingPvtDataInfo, nil
+}
+
+// CommitPvtDataOfOldBlocks implements the function in the interface `Store`
+func (s *store) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) ([]*ledger.BlockPvtData, error) {
+	if s.isEmpty {
+		return nil, &ErrIllegalCall{"The private data store is empty"}
+	}
+	// The purger must not delete the expired data while it is being committed
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	var committed []*ledger.BlockPvtData
+	for _, blockPvtData := range blocksPvtData {
+		if blockPvtData.BlockNum > s.lastCommittedBlock {
+			return nil, &ErrIllegalArgs{fmt.Sprintf("Last committed block=%d, pvt data of block=%d can't be committed as old block",
+				s.lastCommittedBlock, blockPvtData.BlockNum)}
+		}
+		committedWriteSets := make(map[uint64]*ledger.TxPvtData)
+		for _, dataEntry := range prepareDataEntries(blockPvtData.BlockNum, writeSetsAsSlice(blockPvtData.WriteSets)) {
+			missingDataKeyBytes := encodeMissingDataKey(dataEntry.key)
+			v, err := s.db.Get(missingDataKeyBytes)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				logger.Debugf("Private data for block [%d], tran [%d], namespace [%s], collection [%s] isn't missing, ignoring it",
+					dataEntry.key.blkNum, dataEntry.key.txNum, dataEntry.key.ns, dataEntry.key.coll)
+				continue
+			}
+			expired, err := isExpired(dataEntry.key, s.btlPolicy, s.lastCommittedBlock)
+			if err != nil {
+				return nil, err
+			}
+			if expired {
+				batch.Delete(missingDataKeyBytes)
+				continue
+			}
+			valBytes, err := encodeDataValue(dataEntry.value)
+			if err != nil {
+				return nil, err
+			}
+			batch.Put(encodeDataKey(dataEntry.key), valBytes)
+			batch.Delete(missingDataKeyBytes)
+			addCollPvtData(committedWriteSets, dataEntry)
+		}
+		if len(committedWriteSets) > 0 {
+			committed = append(committed, &ledger.BlockPvtData{BlockNum: blockPvtData.BlockNum, WriteSets: committedWriteSets})
+		}
+	}
+	if len(committed) > 0 {
+		lastUpdatedOldBlocks, err := s.GetLastUpdatedOldBlocksList()
+		if err != nil {
+			return nil, err
+		}
+		for _, blockPvtData := range committed {
+			lastUpdatedOldBlocks = append(lastUpdatedOldBlocks, blockPvtData.BlockNum)
+		}
+		batch.Put(lastUpdatedOldBlocksKey, encodeLastUpdatedOldBlocksList(lastUpdatedOldBlocks))
+	}
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return nil, err
+	}
+	logger.Debugf("Committed private data of %d old blocks", len(committed))
+	return committed, nil
+}
+
+// GetLastUpdatedOldBlocksList implements the function in the interface `Store`
+func (s *store) GetLastUpdatedOldBlocksList() ([]uint64, error) {
+	v, err := s.db.Get(lastUpdatedOldBlocksKey)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return decodeLastUpdatedOldBlocksList(v)
+}
+
+// ResetLastUpdatedOldBlocksList implements the function in the interface `Store`
+func (s *store) ResetLastUpdatedOldBlocksList() error {
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Delete(lastUpdatedOldBlocksKey)
+	return s.db.WriteBatch(batch, true)
+}
+
+// GetBackfillHeight implements the function in the interface `Store`
+func (s *store) GetBackfillHeight() (uint64, error) {
+	v, err := s.db.Get(backfillHeightKey)
+	if err != nil || v == nil {
+		return 0, err
+	}
+	return decodeBackfillHeightVal(v), nil
+}
+
+// SetBackfillHeight implements the function in the interface `Store`
+func (s *store) SetBackfillHeight(height uint64) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(backfillHeightKey, encodeBackfillHeightVal(height))
+	return s.db.WriteBatch(batch, true)
+}
+
+// RecordMissingPvtDataOfOldBlocks implements the function in the interface `Store`
+func (s *store) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	if s.isEmpty {
+		return &ErrIllegalCall{"The private data store is empty"}
+	}
+	// The purger must not delete the expiry entries while they are being extended
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	recorded := 0
+	for blockNum, missing := range missingPvtData {
+		if blockNum > s.lastCommittedBlock {
+			return &ErrIllegalArgs{fmt.Sprintf("Last committed block=%d, missing pvt data of block=%d can't be recorded as old block",
+				s.lastCommittedBlock, blockNum)}
+		}
+		var missingDataEntries []*missingDataEntry
+		seen := make(map[dataKey]struct{})
+		for _, missingDataEntry := range prepareMissingDataEntries(blockNum, missing) {
+			if _, exists := seen[*missingDataEntry.key]; exists {
+				continue
+			}
+			seen[*missingDataEntry.key] = struct{}{}
+			exists, err := s.hasDataOrMissingDataEntry(missingDataEntry.key)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			expired, err := isExpired(missingDataEntry.key, s.btlPolicy, s.lastCommittedBlock)
+			if err != nil {
+				return err
+			}
+			if expired {
+				continue
+			}
+			missingDataEntries = append(missingDataEntries, missingDataEntry)
+		}
+		if len(missingDataEntries) == 0 {
+			continue
+		}
+		dataKeys := make([]*dataKey, 0, len(missingDataEntries))
+		for _, missingDataEntry := range missingDataEntries {
+			batch.Put(encodeMissingDataKey(missingDataEntry.key), encodeMissingDataValue(missingDataEntry.txID, missingDataEntry.isBackfill))
+			dataKeys = append(dataKeys, missingDataEntry.key)
+		}
+		expiryEntries, err := prepareExpiryEntries(blockNum, dataKeys, s.btlPolicy)
+		if err != nil {
+			return err
+		}
+		for _, expiryEntry := range expiryEntries {
+			if err := s.mergeExpiryEntry(batch, expiryEntry); err != nil {
+				return err
+			}
+		}
+		recorded += len(missingDataEntries)
+	}
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Debugf("Recorded %d missing private data entries of old blocks", recorded)
+	return nil
+}
+
+// hasDataOrMissingDataEntry returns true if the pvt data of the given key is either present or already recorded as missing
+func (s *store) hasDataOrMissingDataEntry(key *dataKey) (bool, error) {
+	for _, keyBytes := range [][]byte{encodeDataKey(key), encodeMissingDataKey(key)} {
+		v, err := s.db.Get(keyBytes)
+		if err != nil {
+			return false, err
+		}
+		if v != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mergeExpiryEntry adds the given expiry entry to the batch, along with the
+// data keys of the expiry entry that is already present under the same key
+func (s *store) mergeExpiryEntry(batch *leveldbhelper.UpdateBatch, expiryEntry *expiryEntry) error {
+	keyBytes := encodeExpiryKey(expiryEntry.key)
+	expiryData := expiryEntry.value
+	existingBytes, err := s.db.Get(keyBytes)
+	if err != nil {
+		return err
+	}
+	if existingBytes != nil {
+		existing, err := decodeExpiryValue(existingBytes)
+		if err != nil {
+			return err
+		}
+		for _, dataKey := range deriveDataKeys(expiryEntry) {
+			existing.add(dataKey.ns, dataKey.coll, dataKey.txNum)
+		}
+		expiryData = existing
+	}
+	valBytes, err := encodeExpiryValue(expiryData)
+	if err != nil {
+		return err
+	}
+	batch.Put(keyBytes, valBytes)
+	return nil
+}
+
 // InitLastCommittedBlock implements the function in the interface `Store`
 func (s *store) InitLastCommittedBlock(blockNum uint64) error {
 	if !(s.isEmpty && !s.batchPending) {
@@ -279,6 +526,7 @@ func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		batch.Delete(encodeExpiryKey(expiryEntry.key))
 		for _, dataKey := range deriveDataKeys(expiryEntry) {
 			batch.Delete(encodeDataKey(dataKey))
+			batch.Delete(encodeMissingDataKey(dataKey))
 		}
 		s.db.WriteBatch(batch, false)
 	}
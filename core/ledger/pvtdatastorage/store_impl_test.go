@@ -8,6 +8,7 @@ package pvtdatastorage
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strings"
 	"testing"
@@ -56,15 +57,15 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	}
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil))
+	assert.NoError(store.Prepare(0, nil, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data with block 1 - commit
-	assert.NoError(store.Prepare(1, testData))
+	assert.NoError(store.Prepare(1, testData, nil))
 	assert.NoError(store.Commit())
 
 	// pvt data with block 2 - rollback
-	assert.NoError(store.Prepare(2, testData))
+	assert.NoError(store.Prepare(2, testData, nil))
 	assert.NoError(store.Rollback())
 
 	// pvt data retrieval for block 0 should return nil
@@ -111,7 +112,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	store := env.TestStore
 
 	// no pvt data with block 0
-	assert.NoError(store.Prepare(0, nil))
+	assert.NoError(store.Prepare(0, nil, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 1
@@ -119,7 +120,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(1, testDataForBlk1))
+	assert.NoError(store.Prepare(1, testDataForBlk1, nil))
 	assert.NoError(store.Commit())
 
 	// write pvt data for block 2
@@ -127,7 +128,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(store.Prepare(2, testDataForBlk2))
+	assert.NoError(store.Prepare(2, testDataForBlk2, nil))
 	assert.NoError(store.Commit())
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
@@ -135,7 +136,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	testutil.AssertEquals(t, retrievedData, testDataForBlk1)
 
 	// Commit block 3 with no pvtdata
-	assert.NoError(store.Prepare(3, nil))
+	assert.NoError(store.Prepare(3, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
@@ -147,7 +148,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	testutil.AssertEquals(t, retrievedData, expectedPvtdataFromBlock1)
 
 	// Commit block 4 with no pvtdata
-	assert.NoError(store.Prepare(4, nil))
+	assert.NoError(store.Prepare(4, nil, nil))
 	assert.NoError(store.Commit())
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
@@ -167,6 +168,229 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	testutil.AssertEquals(t, retrievedData, expectedPvtdataFromBlock2)
 }
 
+func TestMissingPvtDataAndCommitOfOldBlocks(t *testing.T) {
+	ledgerid := "TestMissingPvtDataAndCommitOfOldBlocks"
+	cs := btltestutil.NewMockCollectionStore()
+	cs.SetBTL("ns-1", "coll-1", 0)
+	cs.SetBTL("ns-1", "coll-2", 1)
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(cs)
+
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	// no missing pvt data in an empty store, and old blocks can't be committed
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 10)
+	assert.NoError(err)
+	assert.Empty(missingPvtDataInfo)
+	_, err = store.CommitPvtDataOfOldBlocks(nil)
+	assert.IsType(&ErrIllegalCall{}, err)
+
+	assert.NoError(store.Prepare(0, nil, nil))
+	assert.NoError(store.Commit())
+
+	// block 1: tx 2 lacks "ns-1:coll-1" and "ns-1:coll-2"
+	assert.NoError(store.Prepare(1, []*ledger.TxPvtData{produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"})}, []ledger.MissingPrivateData{
+		{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-1"},
+		{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-2"},
+	}))
+	assert.NoError(store.Commit())
+
+	// block 2: tx 1 lacks "ns-1:coll-1"
+	assert.NoError(store.Prepare(2, nil, []ledger.MissingPrivateData{
+		{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1"},
+	}))
+	assert.NoError(store.Commit())
+
+	expectedMissingOfBlk1 := []ledger.MissingPrivateData{
+		{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-1"},
+		{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-2"},
+	}
+	expectedMissingOfBlk2 := []ledger.MissingPrivateData{
+		{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1"},
+	}
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{1: expectedMissingOfBlk1, 2: expectedMissingOfBlk2}, missingPvtDataInfo)
+
+	// only the most recent blocks are reported
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 1)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{2: expectedMissingOfBlk2}, missingPvtDataInfo)
+
+	// and only the blocks lower than the given block number are reported
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(2, 10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{1: expectedMissingOfBlk1}, missingPvtDataInfo)
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(1, 10)
+	assert.NoError(err)
+	assert.Empty(missingPvtDataInfo)
+
+	// pvt data of a block that isn't committed yet can't be committed as old block
+	_, err = store.CommitPvtDataOfOldBlocks([]*ledger.BlockPvtData{{BlockNum: 3}})
+	assert.IsType(&ErrIllegalArgs{}, err)
+
+	// only the pvt data that is missing is committed
+	committed, err := store.CommitPvtDataOfOldBlocks([]*ledger.BlockPvtData{
+		{
+			BlockNum: 1,
+			WriteSets: map[uint64]*ledger.TxPvtData{
+				2: produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
+				4: produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"}),
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal([]*ledger.BlockPvtData{
+		{
+			BlockNum:  1,
+			WriteSets: map[uint64]*ledger.TxPvtData{2: produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"})},
+		},
+	}, committed)
+	retrievedData, err := store.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	testutil.AssertEquals(t, retrievedData, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
+		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"}),
+	})
+
+	// the block is recorded until the pvt data is applied to the state and the list is reset
+	lastUpdatedOldBlocks, err := store.GetLastUpdatedOldBlocksList()
+	assert.NoError(err)
+	assert.Equal([]uint64{1}, lastUpdatedOldBlocks)
+	assert.NoError(store.ResetLastUpdatedOldBlocksList())
+	lastUpdatedOldBlocks, err = store.GetLastUpdatedOldBlocksList()
+	assert.NoError(err)
+	assert.Empty(lastUpdatedOldBlocks)
+
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{
+		1: {{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-2"}},
+		2: expectedMissingOfBlk2,
+	}, missingPvtDataInfo)
+
+	// after committing block 3, the missing "ns-1:coll-2" of block 1 has expired
+	assert.NoError(store.Prepare(3, nil, nil))
+	assert.NoError(store.Commit())
+	missingPvtDataInfo, err = store.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{2: expectedMissingOfBlk2}, missingPvtDataInfo)
+
+	// and the expired pvt data isn't committed
+	committed, err = store.CommitPvtDataOfOldBlocks([]*ledger.BlockPvtData{
+		{
+			BlockNum:  1,
+			WriteSets: map[uint64]*ledger.TxPvtData{2: produceSamplePvtdata(t, 2, []string{"ns-1:coll-2"})},
+		},
+	})
+	assert.NoError(err)
+	assert.Empty(committed)
+	lastUpdatedOldBlocks, err = store.GetLastUpdatedOldBlocksList()
+	assert.NoError(err)
+	assert.Empty(lastUpdatedOldBlocks)
+}
+
+func TestRecordMissingPvtDataOfOldBlocks(t *testing.T) {
+	ledgerid := "TestRecordMissingPvtDataOfOldBlocks"
+	cs := btltestutil.NewMockCollectionStore()
+	cs.SetBTL("ns-1", "coll-1", 0)
+	cs.SetBTL("ns-1", "coll-2", 1)
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(cs)
+
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	// missing pvt data can't be recorded in an empty store
+	assert.IsType(&ErrIllegalCall{}, s.RecordMissingPvtDataOfOldBlocks(ledger.MissingPvtDataInfo{}))
+
+	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Commit())
+	// block 1: tx 4 has "ns-1:coll-1" and "ns-1:coll-2"
+	assert.NoError(s.Prepare(1, []*ledger.TxPvtData{produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2"})}, nil))
+	assert.NoError(s.Commit())
+	// block 2: tx 3 has "ns-1:coll-2", tx 1 lacks "ns-1:coll-1"
+	assert.NoError(s.Prepare(2, []*ledger.TxPvtData{produceSamplePvtdata(t, 3, []string{"ns-1:coll-2"})}, []ledger.MissingPrivateData{
+		{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1"},
+	}))
+	assert.NoError(s.Commit())
+	assert.NoError(s.Prepare(3, nil, nil))
+	assert.NoError(s.Commit())
+
+	// missing pvt data of a block that isn't committed yet can't be recorded
+	assert.IsType(&ErrIllegalArgs{}, s.RecordMissingPvtDataOfOldBlocks(ledger.MissingPvtDataInfo{
+		4: {{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1"}},
+	}))
+
+	assert.NoError(s.RecordMissingPvtDataOfOldBlocks(ledger.MissingPvtDataInfo{
+		1: {
+			// already present
+			{TxId: "tx4", SeqInBlock: 4, Namespace: "ns-1", Collection: "coll-1"},
+			// expired at block 3
+			{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-2"},
+			{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-1", IsBackfill: true},
+			{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-1", IsBackfill: true},
+		},
+		2: {
+			// already recorded as missing, hence not marked as back-filled
+			{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1", IsBackfill: true},
+			{TxId: "tx5", SeqInBlock: 5, Namespace: "ns-1", Collection: "coll-2"},
+		},
+	}))
+	missingPvtDataInfo, err := s.GetMissingPvtDataInfoForBlocksBelow(math.MaxUint64, 10)
+	assert.NoError(err)
+	assert.Equal(ledger.MissingPvtDataInfo{
+		1: {{TxId: "tx2", SeqInBlock: 2, Namespace: "ns-1", Collection: "coll-1", IsBackfill: true}},
+		2: {
+			{TxId: "tx1", SeqInBlock: 1, Namespace: "ns-1", Collection: "coll-1"},
+			{TxId: "tx5", SeqInBlock: 5, Namespace: "ns-1", Collection: "coll-2"},
+		},
+	}, missingPvtDataInfo)
+
+	// the expiry entry of block 2 covers both the present and the recorded pvt data
+	expiryEntries, err := s.(*store).retrieveExpiryEntries(0, 10)
+	assert.NoError(err)
+	var block2ExpiryEntry *expiryEntry
+	for _, e := range expiryEntries {
+		if e.key.committingBlk == 2 {
+			block2ExpiryEntry = e
+		}
+	}
+	assert.NotNil(block2ExpiryEntry)
+	assert.Equal(uint64(4), block2ExpiryEntry.key.expiringBlk)
+	assert.Equal([]uint64{3, 5}, block2ExpiryEntry.value.Map["ns-1"].Map["coll-2"].List)
+
+	// the recorded pvt data can be committed as old block
+	committed, err := s.CommitPvtDataOfOldBlocks([]*ledger.BlockPvtData{
+		{
+			BlockNum:  1,
+			WriteSets: map[uint64]*ledger.TxPvtData{2: produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"})},
+		},
+	})
+	assert.NoError(err)
+	assert.Len(committed, 1)
+}
+
+func TestBackfillHeight(t *testing.T) {
+	env := NewTestStoreEnv(t, "TestBackfillHeight", nil)
+	defer env.Cleanup()
+	assert := assert.New(t)
+
+	height, err := env.TestStore.GetBackfillHeight()
+	assert.NoError(err)
+	assert.Equal(uint64(0), height)
+
+	assert.NoError(env.TestStore.SetBackfillHeight(5))
+	// the back-fill height survives a restart
+	env.CloseAndReopen()
+	height, err = env.TestStore.GetBackfillHeight()
+	assert.NoError(err)
+	assert.Equal(uint64(5), height)
+}
+
 func TestStorePurge(t *testing.T) {
 	ledgerid := "TestStorePurge"
 	viper.Set("ledger.pvtdataStore.purgeInterval", 2)
@@ -183,7 +407,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	assert.NoError(s.Prepare(0, nil))
+	assert.NoError(s.Prepare(0, nil, nil))
 	assert.NoError(s.Commit())
 
 	// write pvt data for block 1
@@ -191,11 +415,11 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	assert.NoError(s.Prepare(1, testDataForBlk1))
+	assert.NoError(s.Prepare(1, testDataForBlk1, nil))
 	assert.NoError(s.Commit())
 
 	// write pvt data for block 2
-	assert.NoError(s.Prepare(2, nil))
+	assert.NoError(s.Prepare(2, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	testWaitForPurgerRoutineToFinish(s)
@@ -203,7 +427,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{blkNum: 1, txNum: 2, ns: "ns-2", coll: "coll-2"}))
 
 	// write pvt data for block 3
-	assert.NoError(s.Prepare(3, nil))
+	assert.NoError(s.Prepare(3, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
@@ -211,7 +435,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{blkNum: 1, txNum: 2, ns: "ns-2", coll: "coll-2"}))
 
 	// write pvt data for block 4
-	assert.NoError(s.Prepare(4, nil))
+	assert.NoError(s.Prepare(4, nil, nil))
 	assert.NoError(s.Commit())
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4) but ns-2:coll-2 should exist because it
 	// expires at block 5
@@ -220,7 +444,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{blkNum: 1, txNum: 2, ns: "ns-2", coll: "coll-2"}))
 
 	// write pvt data for block 5
-	assert.NoError(s.Prepare(5, nil))
+	assert.NoError(s.Prepare(5, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
@@ -228,7 +452,7 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{blkNum: 1, txNum: 2, ns: "ns-2", coll: "coll-2"}))
 
 	// write pvt data for block 6
-	assert.NoError(s.Prepare(6, nil))
+	assert.NoError(s.Prepare(6, nil, nil))
 	assert.NoError(s.Commit())
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
@@ -252,14 +476,14 @@ func TestStoreState(t *testing.T) {
 	testData := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
 	}
-	_, ok := store.Prepare(1, testData).(*ErrIllegalArgs)
+	_, ok := store.Prepare(1, testData, nil).(*ErrIllegalArgs)
 	assert.True(ok)
 
-	assert.Nil(store.Prepare(0, testData))
+	assert.Nil(store.Prepare(0, testData, nil))
 	assert.NoError(store.Commit())
 
-	assert.Nil(store.Prepare(1, testData))
-	_, ok = store.Prepare(2, testData).(*ErrIllegalCall)
+	assert.Nil(store.Prepare(1, testData, nil))
+	_, ok = store.Prepare(2, testData, nil).(*ErrIllegalCall)
 	assert.True(ok)
 }
 
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 
 	"github.com/sinochem-tech/fabric/core/comm"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/config"
 	pb "github.com/sinochem-tech/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -229,3 +230,16 @@ func GetClientCertificate() (tls.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// GetImplicitCollectionDisseminationPolicy returns the dissemination policy
+// of the private data of the implicit collections
+func GetImplicitCollectionDisseminationPolicy() privdata.ImplicitCollectionDisseminationPolicy {
+	policy := privdata.ImplicitCollectionDisseminationPolicy{
+		RequiredPeerCount: viper.GetInt("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount"),
+		MaxPeerCount:      1,
+	}
+	if viper.IsSet("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount") {
+		policy.MaxPeerCount = viper.GetInt("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount")
+	}
+	return policy
+}
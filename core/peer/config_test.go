@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/sinochem-tech/fabric/core/comm"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -249,3 +250,12 @@ func TestGetClientCertificate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, cert)
 }
+
+func TestGetImplicitCollectionDisseminationPolicy(t *testing.T) {
+	defer viper.Reset()
+	assert.Equal(t, privdata.ImplicitCollectionDisseminationPolicy{MaxPeerCount: 1}, GetImplicitCollectionDisseminationPolicy())
+
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", 2)
+	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", 3)
+	assert.Equal(t, privdata.ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 2, MaxPeerCount: 3}, GetImplicitCollectionDisseminationPolicy())
+}
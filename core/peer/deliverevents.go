@@ -255,11 +255,7 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 
 		if ccEvent.GetChaincodeId() != "" {
 			filteredAction := &peer.FilteredChaincodeAction{
-				ChaincodeEvent: &peer.ChaincodeEvent{
-					TxId:        ccEvent.TxId,
-					ChaincodeId: ccEvent.ChaincodeId,
-					EventName:   ccEvent.EventName,
-				},
+				ChaincodeEvent: filterChaincodeEvent(ccEvent),
 			}
 			transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
 		}
@@ -269,6 +265,24 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 	}, nil
 }
 
+// filterChaincodeEvent returns the given chaincode event, along with the
+// events it carries, stripped of their payloads
+func filterChaincodeEvent(ccEvent *peer.ChaincodeEvent) *peer.ChaincodeEvent {
+	filteredEvent := &peer.ChaincodeEvent{
+		TxId:        ccEvent.TxId,
+		ChaincodeId: ccEvent.ChaincodeId,
+		EventName:   ccEvent.EventName,
+	}
+	for _, event := range ccEvent.Events {
+		filteredEvent.Events = append(filteredEvent.Events, &peer.ChaincodeEvent{
+			TxId:        event.TxId,
+			ChaincodeId: event.ChaincodeId,
+			EventName:   event.EventName,
+		})
+	}
+	return filteredEvent
+}
+
 func dumpStacktraceOnPanic() {
 	func() {
 		if r := recover(); r != nil {
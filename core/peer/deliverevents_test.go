@@ -510,3 +510,42 @@ func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(data))
 	return block, nil
 }
+
+func TestToFilteredActionsMultipleEvents(t *testing.T) {
+	eventsBytes, err := proto.Marshal(&peer.ChaincodeEvent{
+		ChaincodeId: "mycc",
+		TxId:        "testID",
+		EventName:   "second",
+		Payload:     []byte("second payload"),
+		Events: []*peer.ChaincodeEvent{
+			{ChaincodeId: "mycc", TxId: "testID", EventName: "first", Payload: []byte("first payload")},
+			{ChaincodeId: "mycc", TxId: "testID", EventName: "second", Payload: []byte("second payload")},
+		},
+	})
+	assert.NoError(t, err)
+	actionBytes, err := proto.Marshal(&peer.ChaincodeAction{
+		ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		Events:      eventsBytes,
+	})
+	assert.NoError(t, err)
+	chaincodeActionPayload := &peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: utils.MarshalOrPanic(&peer.ProposalResponsePayload{Extension: actionBytes}),
+		},
+	}
+	actions := transactionActions{{Payload: utils.MarshalOrPanic(chaincodeActionPayload)}}
+
+	filteredActions, err := actions.toFilteredActions()
+	assert.NoError(t, err)
+	chaincodeActions := filteredActions.TransactionActions.ChaincodeActions
+	assert.Len(t, chaincodeActions, 1)
+	assert.Equal(t, &peer.ChaincodeEvent{
+		ChaincodeId: "mycc",
+		TxId:        "testID",
+		EventName:   "second",
+		Events: []*peer.ChaincodeEvent{
+			{ChaincodeId: "mycc", TxId: "testID", EventName: "first"},
+			{ChaincodeId: "mycc", TxId: "testID", EventName: "second"},
+		},
+	}, chaincodeActions[0].ChaincodeEvent)
+}
@@ -383,14 +383,16 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 	csStoreSupport := &collectionSupport{
 		PeerLedger: ledger,
 	}
-	simpleCollectionStore := privdata.NewSimpleCollectionStore(csStoreSupport)
+	implicitCollectionPolicy := GetImplicitCollectionDisseminationPolicy()
+	simpleCollectionStore := privdata.NewSimpleCollectionStore(csStoreSupport, implicitCollectionPolicy)
 
 	service.GetGossipService().InitializeChannel(bundle.ConfigtxValidator().ChainID(), ordererAddresses, service.Support{
-		Validator:            validator,
-		Committer:            c,
-		Store:                store,
-		Cs:                   simpleCollectionStore,
-		IdDeserializeFactory: csStoreSupport,
+		Validator:                validator,
+		Committer:                c,
+		Store:                    store,
+		Cs:                       simpleCollectionStore,
+		IdDeserializeFactory:     csStoreSupport,
+		ImplicitCollectionPolicy: implicitCollectionPolicy,
 	})
 
 	chains.Lock()
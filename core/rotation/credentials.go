@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rotation
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"path/filepath"
+	"time"
+
+	"github.com/sinochem-tech/fabric/msp"
+	"github.com/sinochem-tech/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+)
+
+// LoadTLSCertificate loads a TLS certificate and its private key from the given files, and
+// validates it as a replacement of the given current certificate: the new certificate must
+// be valid already, and be issued by one of the given root CAs, if any. A warning is logged
+// if it expires before the current one.
+func LoadTLSCertificate(certFile, keyFile string, rootCAs [][]byte, current tls.Certificate) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "failed loading the TLS certificate from %s and %s", certFile, keyFile)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed parsing the TLS certificate")
+	}
+
+	now := time.Now()
+	if now.Before(leaf.NotBefore) {
+		return tls.Certificate{}, errors.Errorf("the TLS certificate is valid only from %v", leaf.NotBefore)
+	}
+	if now.After(leaf.NotAfter) {
+		return tls.Certificate{}, errors.Errorf("the TLS certificate expired %v ago", now.Sub(leaf.NotAfter))
+	}
+	if len(current.Certificate) > 0 {
+		currentLeaf, err := x509.ParseCertificate(current.Certificate[0])
+		if err != nil {
+			return tls.Certificate{}, errors.Wrap(err, "failed parsing the current TLS certificate")
+		}
+		if leaf.NotAfter.Before(currentLeaf.NotAfter) {
+			logger.Warningf("The TLS certificate in %s expires at %v, before the current one which expires at %v",
+				certFile, leaf.NotAfter, currentLeaf.NotAfter)
+		}
+	}
+
+	if len(rootCAs) == 0 {
+		return cert, nil
+	}
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, rootCA := range rootCAs {
+		opts.Roots.AppendCertsFromPEM(rootCA)
+	}
+	for _, der := range cert.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return tls.Certificate{}, errors.Wrap(err, "failed parsing an intermediate certificate of the TLS certificate")
+		}
+		opts.Intermediates.AddCert(intermediate)
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "the TLS certificate isn't issued by the TLS root CAs of the peer")
+	}
+	return cert, nil
+}
+
+// TLSCredential returns a Credential that reloads the TLS certificate stored in the given files.
+// The certificate is obtained with get, and replaced with set once validated by LoadTLSCertificate.
+func TLSCredential(name, certFile, keyFile string, rootCAs [][]byte, get func() tls.Certificate, set func(tls.Certificate)) Credential {
+	return Credential{
+		Name:  name,
+		Files: []string{certFile, keyFile},
+		Reload: func() error {
+			cert, err := LoadTLSCertificate(certFile, keyFile, rootCAs, get())
+			if err != nil {
+				return err
+			}
+			set(cert)
+			return nil
+		},
+	}
+}
+
+// SigningIdentityCredential returns a Credential that reloads the signing identity of the local MSP
+// from the signcerts directory of the given MSP directory, and its private key from the given keystore
+// directory. Once the signing identity is replaced, onRotation is called with its serialized form.
+func SigningIdentityCredential(mspDir, keystoreDir string, onRotation func(serializedIdentity []byte) error) Credential {
+	return Credential{
+		Name:  "signing identity of the local MSP",
+		Files: []string{filepath.Join(mspDir, "signcerts"), keystoreDir},
+		Reload: func() error {
+			current, err := serializedSigningIdentity(mgmt.GetLocalMSP().GetDefaultSigningIdentity())
+			if err != nil {
+				return err
+			}
+			rotated, err := serializedSigningIdentity(mgmt.ReloadLocalSigningIdentity(mspDir))
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(current, rotated) {
+				return nil
+			}
+			return onRotation(rotated)
+		},
+	}
+}
+
+func serializedSigningIdentity(sid msp.SigningIdentity, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return sid.Serialize()
+}
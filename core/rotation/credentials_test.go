@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rotation
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/common/crypto/tlsgen"
+	"github.com/sinochem-tech/fabric/core/config/configtest"
+	"github.com/sinochem-tech/fabric/msp/mgmt"
+	msptesttools "github.com/sinochem-tech/fabric/msp/mgmt/testtools"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCertKeyPair(t *testing.T, dir string, ckp *tlsgen.CertKeyPair) (certFile, keyFile string) {
+	certFile = filepath.Join(dir, "tls.crt")
+	keyFile = filepath.Join(dir, "tls.key")
+	assert.NoError(t, ioutil.WriteFile(certFile, ckp.Cert, 0644))
+	assert.NoError(t, ioutil.WriteFile(keyFile, ckp.Key, 0600))
+	return certFile, keyFile
+}
+
+func TestLoadTLSCertificate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	foreignCA, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	rootCAs := [][]byte{ca.CertBytes()}
+	currentCKP, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	current, err := tls.X509KeyPair(currentCKP.Cert, currentCKP.Key)
+	assert.NoError(t, err)
+
+	// The files must hold a key pair
+	_, err = LoadTLSCertificate(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"), rootCAs, current)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed loading the TLS certificate")
+
+	// The certificate must be issued by the root CAs
+	foreign, err := foreignCA.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	certFile, keyFile := writeCertKeyPair(t, dir, foreign)
+	_, err = LoadTLSCertificate(certFile, keyFile, rootCAs, current)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "isn't issued by the TLS root CAs of the peer")
+	_, err = LoadTLSCertificate(certFile, keyFile, nil, current)
+	assert.NoError(t, err)
+
+	// A certificate that expires before the current one is only warned about
+	expiresEarlier, err := ca.NewClientCertKeyPair()
+	assert.NoError(t, err)
+	certFile, keyFile = writeCertKeyPair(t, dir, expiresEarlier)
+	_, err = LoadTLSCertificate(certFile, keyFile, rootCAs, current)
+	assert.NoError(t, err)
+
+	rotated, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	certFile, keyFile = writeCertKeyPair(t, dir, rotated)
+	cert, err := LoadTLSCertificate(certFile, keyFile, rootCAs, current)
+	assert.NoError(t, err)
+	expected, err := tls.X509KeyPair(rotated.Cert, rotated.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Certificate, cert.Certificate)
+}
+
+func TestTLSCredential(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	rotated, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	certFile, keyFile := writeCertKeyPair(t, dir, rotated)
+
+	var current tls.Certificate
+	cred := TLSCredential("TLS server certificate", certFile, keyFile, [][]byte{ca.CertBytes()}, func() tls.Certificate {
+		return current
+	}, func(cert tls.Certificate) {
+		current = cert
+	})
+	assert.Equal(t, []string{certFile, keyFile}, cred.Files)
+	assert.NoError(t, cred.Reload())
+	expected, err := tls.X509KeyPair(rotated.Cert, rotated.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Certificate, current.Certificate)
+
+	// The certificate isn't replaced if it's invalid
+	assert.NoError(t, ioutil.WriteFile(certFile, []byte("invalid"), 0644))
+	assert.Error(t, cred.Reload())
+	assert.Equal(t, expected.Certificate, current.Certificate)
+}
+
+func TestSigningIdentityCredential(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadDevMsp())
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+
+	var rotations int
+	onRotation := func(serializedIdentity []byte) error {
+		rotations++
+		return nil
+	}
+
+	// Reloading the same signing identity isn't a rotation
+	cred := SigningIdentityCredential(mspDir, filepath.Join(mspDir, "keystore"), onRotation)
+	assert.NoError(t, cred.Reload())
+	assert.Equal(t, 0, rotations)
+	sid, err := mgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NotNil(t, sid)
+
+	// A directory without a signing identity can't be reloaded from
+	dir, err := ioutil.TempDir("", "rotation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cred = SigningIdentityCredential(dir, filepath.Join(dir, "keystore"), onRotation)
+	assert.Error(t, cred.Reload())
+	assert.Equal(t, 0, rotations)
+}
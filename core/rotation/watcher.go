@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rotation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sinochem-tech/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("rotation")
+
+// DefaultInterval is the interval at which a Watcher polls the files
+// of the credentials, if no valid interval is given
+const DefaultInterval = time.Minute
+
+// Credential is a credential of the peer that is stored in files,
+// and that the peer can reload once they change
+type Credential struct {
+	// Name describes the credential
+	Name string
+	// Files are the files, or the directories, the credential is stored in
+	Files []string
+	// Reload reloads the credential from its files
+	Reload func() error
+}
+
+// Watcher polls the files of credentials, and reloads the
+// credentials whose files have changed since the last poll
+type Watcher struct {
+	interval     time.Duration
+	credentials  []Credential
+	fingerprints [][]byte
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+	stopWG       sync.WaitGroup
+}
+
+// NewWatcher creates a Watcher of the given credentials, which polls their files at the given interval,
+// or at DefaultInterval if the given interval isn't positive
+func NewWatcher(interval time.Duration, credentials ...Credential) *Watcher {
+	if interval <= 0 {
+		logger.Warningf("Invalid interval %v for polling the files of the credentials, using %v instead", interval, DefaultInterval)
+		interval = DefaultInterval
+	}
+	return &Watcher{
+		interval:     interval,
+		credentials:  credentials,
+		fingerprints: make([][]byte, len(credentials)),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start records the current content of the files of the credentials,
+// and starts polling them in the background
+func (w *Watcher) Start() {
+	for i, cred := range w.credentials {
+		fingerprint, err := fingerprintOf(cred.Files)
+		if err != nil {
+			logger.Warningf("Failed reading the files of the %s: %s", cred.Name, err)
+		}
+		w.fingerprints[i] = fingerprint
+	}
+	w.stopWG.Add(1)
+	go func() {
+		defer w.stopWG.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				w.poll()
+			case <-w.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the files of the credentials
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopChan)
+	})
+	w.stopWG.Wait()
+}
+
+// poll reloads the credentials whose files have changed. A credential that fails
+// reloading is only attempted again once its files change again.
+func (w *Watcher) poll() {
+	for i, cred := range w.credentials {
+		fingerprint, err := fingerprintOf(cred.Files)
+		if err != nil {
+			// The files may be in the middle of being replaced
+			logger.Debugf("Failed reading the files of the %s: %s", cred.Name, err)
+			continue
+		}
+		if bytes.Equal(fingerprint, w.fingerprints[i]) {
+			continue
+		}
+		w.fingerprints[i] = fingerprint
+
+		logger.Infof("The files of the %s have changed, reloading it", cred.Name)
+		if err := cred.Reload(); err != nil {
+			logger.Errorf("Failed reloading the %s: %+v", cred.Name, err)
+			continue
+		}
+		logger.Infof("Reloaded the %s", cred.Name)
+	}
+}
+
+// fingerprintOf hashes the names and the content of the given files, and of
+// the files in the given directories
+func fingerprintOf(paths []string) ([]byte, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		files := []string{path}
+		if info.IsDir() {
+			infos, err := ioutil.ReadDir(path)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			files = files[:0]
+			for _, info := range infos {
+				if info.Mode().IsRegular() {
+					files = append(files, filepath.Join(path, info.Name()))
+				}
+			}
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			digest := sha256.Sum256(content)
+			h.Write([]byte(file))
+			h.Write(digest[:])
+		}
+	}
+	return h.Sum(nil), nil
+}
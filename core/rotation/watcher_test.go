@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rotation
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "cert.pem")
+	subDir := filepath.Join(dir, "keystore")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("cert"), 0644))
+	assert.NoError(t, os.Mkdir(subDir, 0755))
+
+	reloads := make(chan struct{}, 10)
+	var lock sync.Mutex
+	var reloadErr error
+	w := NewWatcher(10*time.Millisecond, Credential{
+		Name:  "test credential",
+		Files: []string{file, subDir},
+		Reload: func() error {
+			reloads <- struct{}{}
+			lock.Lock()
+			defer lock.Unlock()
+			return reloadErr
+		},
+	})
+	w.Start()
+	defer w.Stop()
+
+	assertReloaded := func() {
+		select {
+		case <-reloads:
+		case <-time.After(5 * time.Second):
+			t.Fatal("The credential should have been reloaded")
+		}
+	}
+	assertNotReloaded := func() {
+		select {
+		case <-reloads:
+			t.Fatal("The credential shouldn't have been reloaded")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	// The credential isn't reloaded as long as its files don't change
+	assertNotReloaded()
+
+	// Changing a file, or adding a file to a directory, reloads the credential
+	assert.NoError(t, ioutil.WriteFile(file, []byte("rotated cert"), 0644))
+	assertReloaded()
+	assertNotReloaded()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(subDir, "key"), []byte("key"), 0600))
+	assertReloaded()
+
+	// A credential that fails reloading is retried only once its files change again
+	lock.Lock()
+	reloadErr = errors.New("invalid credential")
+	lock.Unlock()
+	assert.NoError(t, ioutil.WriteFile(file, []byte("invalid cert"), 0644))
+	assertReloaded()
+	assertNotReloaded()
+
+	// Missing files are skipped
+	assert.NoError(t, os.Remove(file))
+	assertNotReloaded()
+	assert.NoError(t, ioutil.WriteFile(file, []byte("fixed cert"), 0644))
+	assertReloaded()
+
+	// The credential isn't reloaded anymore once the watcher is stopped
+	w.Stop()
+	assert.NoError(t, ioutil.WriteFile(file, []byte("another cert"), 0644))
+	assertNotReloaded()
+}
+
+func TestWatcherDefaultInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		w := NewWatcher(interval)
+		assert.Equal(t, DefaultInterval, w.interval)
+		// polling at the default interval doesn't panic
+		w.Start()
+		w.Stop()
+	}
+	assert.Equal(t, time.Second, NewWatcher(time.Second).interval)
+}
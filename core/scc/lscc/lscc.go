@@ -265,6 +265,14 @@ func (lscc *lifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubIn
 		if err != nil {
 			return errors.Wrapf(err, "collection member policy check failed")
 		}
+		// the endorsement policies of the collections are only enforced with the V1_3 capability,
+		// they must not be accepted without it since they would be silently ignored
+		if coll := collectionConfig.GetStaticCollectionConfig(); coll.GetEndorsementPolicy() != nil {
+			ac, exists := lscc.sccprovider.GetApplicationConfig(stub.GetChannelID())
+			if !exists || !ac.Capabilities().V1_3Validation() {
+				return errors.Errorf("collection-name: %s -- collection endorsement policies require the V1_3 application capability", coll.GetName())
+			}
+		}
 	}
 
 	key := privdata.BuildCollectionKVSKey(cd.Name)
@@ -843,6 +843,38 @@ func TestPutChaincodeCollectionData(t *testing.T) {
 	stub.MockTransactionEnd("foo")
 }
 
+func TestPutChaincodeCollectionDataWithEndorsementPolicy(t *testing.T) {
+	capabilities := &config.MockApplicationCapabilities{}
+	scc := New((&mscc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &config.MockApplication{CapabilitiesRv: capabilities},
+	}).NewSystemChaincodeProvider(), mockAclProvider)
+	scc.support = &lscc.MockSupport{}
+	stub := shim.NewMockStub("lscc", scc)
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	coll := createCollectionConfig("mycollection1", &common.SignaturePolicyEnvelope{}, 1, 2)
+	coll.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: cauthdsl.SignedByMspMember("Org1MSP")},
+	}
+	ccpBytes, err := proto.Marshal(&common.CollectionConfigPackage{Config: []*common.CollectionConfig{coll}})
+	assert.NoError(t, err)
+	cd := &ccprovider.ChaincodeData{Name: "foo"}
+
+	// the collection endorsement policies aren't accepted without the V1_3 capability
+	stub.MockTransactionStart("foo")
+	err = scc.putChaincodeCollectionData(stub, cd, ccpBytes)
+	assert.EqualError(t, err, "collection-name: mycollection1 -- collection endorsement policies require the V1_3 application capability")
+	stub.MockTransactionEnd("foo")
+
+	capabilities.V1_3ValidationRv = true
+	stub.MockTransactionStart("foo")
+	err = scc.putChaincodeCollectionData(stub, cd, ccpBytes)
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("foo")
+}
+
 func TestCheckCollectionMemberPolicy(t *testing.T) {
 	// error case: no msp manager set, no collection config set
 	err := checkCollectionMemberPolicy(nil, nil)
@@ -57,6 +57,10 @@ type EndorsementSupport interface {
 type ConfigSupport interface {
 	// Config returns the channel's configuration
 	Config(channel string) (*discovery2.ConfigResult, error)
+
+	// ChannelInfo returns information about the channel that clients
+	// need in order to construct transactions
+	ChannelInfo(channel string) (*discovery2.ChannelInfoResult, error)
 }
 
 // Support defines an interface that allows the discovery service
@@ -39,6 +39,9 @@ type ChannelResponse interface {
 	// Config returns a response for a config query, or error if something went wrong
 	Config() (*discovery.ConfigResult, error)
 
+	// ChannelInfo returns a response for a channel info query, or error if something went wrong
+	ChannelInfo() (*discovery.ChannelInfoResult, error)
+
 	// Peers returns a response for a peer membership query, or error if something went wrong
 	Peers() ([]*Peer, error)
 
@@ -52,6 +55,11 @@ type ChannelResponse interface {
 	// The given InvocationChain specifies the chaincode calls (along with collections)
 	// that the client passed during the construction of the request
 	Endorsers(invocationChain InvocationChain, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error)
+
+	// MinimalEndorsers is like Endorsers, but returns a set of endorsers of the
+	// smallest size among the sets that can be selected, such that as few peers
+	// as possible need to be involved in endorsing the transaction
+	MinimalEndorsers(invocationChain InvocationChain, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error)
 }
 
 // LocalResponse aggregates responses for a channel-less scope
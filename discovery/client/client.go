@@ -11,6 +11,7 @@ import (
 	"context"
 	"encoding/json"
 	"math/rand"
+	"sort"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -21,7 +22,7 @@ import (
 )
 
 var (
-	configTypes = []discovery.QueryType{discovery.ConfigQueryType, discovery.PeerMembershipQueryType, discovery.ChaincodeQueryType, discovery.LocalMembershipQueryType}
+	configTypes = []discovery.QueryType{discovery.ConfigQueryType, discovery.PeerMembershipQueryType, discovery.ChaincodeQueryType, discovery.LocalMembershipQueryType, discovery.ChannelInfoQueryType}
 )
 
 // Client interacts with the discovery server
@@ -71,6 +72,20 @@ func (req *Request) AddConfigQuery() *Request {
 	return req
 }
 
+// AddChannelInfoQuery adds to the request a channel info query
+func (req *Request) AddChannelInfoQuery() *Request {
+	ch := req.lastChannel
+	q := &discovery.Query_ChannelInfoQuery{
+		ChannelInfoQuery: &discovery.ChannelInfoQuery{},
+	}
+	req.Queries = append(req.Queries, &discovery.Query{
+		Channel: ch,
+		Query:   q,
+	})
+	req.addQueryMapping(discovery.ChannelInfoQueryType, ch)
+	return req
+}
+
 // AddEndorsersQuery adds to the request a query for given chaincodes
 // interests are the chaincode interests that the client wants to query for.
 // All interests for a given channel should be supplied in an aggregated slice
@@ -218,6 +233,23 @@ func (cr *channelResponse) Config() (*discovery.ConfigResult, error) {
 	return nil, res.(error)
 }
 
+func (cr *channelResponse) ChannelInfo() (*discovery.ChannelInfoResult, error) {
+	res, exists := cr.response[key{
+		queryType: discovery.ChannelInfoQueryType,
+		channel:   cr.channel,
+	}]
+
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if info, isChannelInfo := res.(*discovery.ChannelInfoResult); isChannelInfo {
+		return info, nil
+	}
+
+	return nil, res.(error)
+}
+
 func parsePeers(queryType discovery.QueryType, r response, channel string) ([]*Peer, error) {
 	res, exists := r[key{
 		queryType: queryType,
@@ -240,6 +272,56 @@ func (cr *channelResponse) Peers() ([]*Peer, error) {
 }
 
 func (cr *channelResponse) Endorsers(invocationChain InvocationChain, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error) {
+	desc, err := cr.endorsementDescriptor(invocationChain)
+	if err != nil {
+		return nil, err
+	}
+	rand.Seed(time.Now().Unix())
+	// We iterate over all layouts to find one that we have enough peers to select
+	for _, index := range rand.Perm(len(desc.layouts)) {
+		layout := desc.layouts[index]
+		endorsers, canLayoutBeSatisfied := selectPeersForLayout(desc.endorsersByGroups, layout, ps, ef)
+		if canLayoutBeSatisfied {
+			return endorsers, nil
+		}
+	}
+	return nil, errors.New("no endorsement combination can be satisfied")
+}
+
+func (cr *channelResponse) MinimalEndorsers(invocationChain InvocationChain, ps PrioritySelector, ef ExclusionFilter) (Endorsers, error) {
+	desc, err := cr.endorsementDescriptor(invocationChain)
+	if err != nil {
+		return nil, err
+	}
+	rand.Seed(time.Now().Unix())
+	// Layouts of the same size are still chosen randomly, to spread the load among them
+	layouts := make([]map[string]int, len(desc.layouts))
+	for i, index := range rand.Perm(len(desc.layouts)) {
+		layouts[i] = desc.layouts[index]
+	}
+	sort.SliceStable(layouts, func(i, j int) bool {
+		return layoutSize(layouts[i]) < layoutSize(layouts[j])
+	})
+	// We iterate over the layouts from the smallest to the largest, to find one that we have enough peers to select
+	for _, layout := range layouts {
+		endorsers, canLayoutBeSatisfied := selectPeersForLayout(desc.endorsersByGroups, layout, ps, ef)
+		if canLayoutBeSatisfied {
+			return endorsers, nil
+		}
+	}
+	return nil, errors.New("no endorsement combination can be satisfied")
+}
+
+// layoutSize returns the number of peers needed to satisfy the given layout
+func layoutSize(layout map[string]int) int {
+	var size int
+	for _, count := range layout {
+		size += count
+	}
+	return size
+}
+
+func (cr *channelResponse) endorsementDescriptor(invocationChain InvocationChain) (*endorsementDescriptor, error) {
 	// If we have a key that has no chaincode field,
 	// it means it's an error returned from the service
 	if err, exists := cr.response[key{
@@ -260,17 +342,7 @@ func (cr *channelResponse) Endorsers(invocationChain InvocationChain, ps Priorit
 		return nil, ErrNotFound
 	}
 
-	desc := res.(*endorsementDescriptor)
-	rand.Seed(time.Now().Unix())
-	// We iterate over all layouts to find one that we have enough peers to select
-	for _, index := range rand.Perm(len(desc.layouts)) {
-		layout := desc.layouts[index]
-		endorsers, canLayoutBeSatisfied := selectPeersForLayout(desc.endorsersByGroups, layout, ps, ef)
-		if canLayoutBeSatisfied {
-			return endorsers, nil
-		}
-	}
-	return nil, errors.New("no endorsement combination can be satisfied")
+	return res.(*endorsementDescriptor), nil
 }
 
 func selectPeersForLayout(endorsersByGroups map[string][]*Peer, layout map[string]int, ps PrioritySelector, ef ExclusionFilter) (Endorsers, bool) {
@@ -317,6 +389,8 @@ func (req *Request) computeResponse(r *discovery.Response) (response, error) {
 		switch configType {
 		case discovery.ConfigQueryType:
 			err = resp.mapConfig(channel2index, r)
+		case discovery.ChannelInfoQueryType:
+			err = resp.mapChannelInfo(channel2index, r)
 		case discovery.ChaincodeQueryType:
 			err = resp.mapEndorsers(channel2index, r, req.queryMapping, req.invocationChainMapping)
 		case discovery.PeerMembershipQueryType:
@@ -353,6 +427,27 @@ func (resp response) mapConfig(channel2index map[string]int, r *discovery.Respon
 	return nil
 }
 
+func (resp response) mapChannelInfo(channel2index map[string]int, r *discovery.Response) error {
+	for ch, index := range channel2index {
+		info, err := r.ChannelInfoAt(index)
+		if info == nil && err == nil {
+			return errors.Errorf("expected QueryResult of either ChannelInfoResult or Error but got %v instead", r.Results[index])
+		}
+		key := key{
+			queryType: discovery.ChannelInfoQueryType,
+			channel:   ch,
+		}
+
+		if err != nil {
+			resp[key] = errors.New(err.Content)
+			continue
+		}
+
+		resp[key] = info
+	}
+	return nil
+}
+
 func (resp response) mapPeerMembership(channel2index map[string]int, r *discovery.Response, qt discovery.QueryType) error {
 	for ch, index := range channel2index {
 		membersRes, err := r.MembershipAt(index)
@@ -101,6 +101,18 @@ var (
 		},
 	}
 
+	expectedChannelInfo = &discovery.ChannelInfoResult{
+		Capabilities: map[string]*discovery.Capabilities{
+			"Channel": {Names: []string{"V1_1"}},
+		},
+		Acls: map[string]string{
+			"peer/Propose": "/Channel/Application/Writers",
+		},
+		AnchorPeers: map[string]*discovery.Endpoints{
+			"A": {Endpoint: []*discovery.Endpoint{{Host: "p0", Port: 7051}}},
+		},
+	}
+
 	channelPeersWithChaincodes = discovery3.Members{
 		newPeer(0, stateInfoMessage(cc, cc2), propertiesWithChaincodes).NetworkMember,
 		newPeer(1, stateInfoMessage(cc, cc2), propertiesWithChaincodes).NetworkMember,
@@ -300,6 +312,7 @@ func createDiscoveryService(sup *mockSupport) discovery.DiscoveryServer {
 		orgCombinations: orgCombinationsThatSatisfyPolicy2,
 	})
 	sup.On("Config", "mychannel").Return(expectedConf)
+	sup.On("ChannelInfo", "mychannel").Return(expectedChannelInfo)
 	sup.On("Peers").Return(membershipPeers)
 	sup.endorsementAnalyzer = endorsement.NewEndorsementAnalyzer(sup, pf, pe, mdf)
 	sup.On("IdentityInfo").Return(peerIdentities)
@@ -332,7 +345,7 @@ func TestClient(t *testing.T) {
 
 	sup.On("PeersOfChannel").Return(channelPeersWithoutChaincodes).Times(2)
 	req := NewRequest()
-	req.OfChannel("mychannel").AddPeersQuery().AddConfigQuery().AddLocalPeersQuery().AddEndorsersQuery(interest("mycc"))
+	req.OfChannel("mychannel").AddPeersQuery().AddConfigQuery().AddChannelInfoQuery().AddLocalPeersQuery().AddEndorsersQuery(interest("mycc"))
 	r, err := cl.Send(ctx, req, authInfo)
 	assert.NoError(t, err)
 
@@ -350,6 +363,16 @@ func TestClient(t *testing.T) {
 		conf, err := fakeChannel.Config()
 		assert.Equal(t, ErrNotFound, err)
 		assert.Nil(t, conf)
+
+		info, err := fakeChannel.ChannelInfo()
+		assert.Equal(t, ErrNotFound, err)
+		assert.Nil(t, info)
+	})
+
+	t.Run("Channel info query", func(t *testing.T) {
+		info, err := r.ForChannel("mychannel").ChannelInfo()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedChannelInfo, info))
 	})
 
 	t.Run("Peer membership query", func(t *testing.T) {
@@ -578,6 +601,72 @@ func TestAddEndorsersQueryInvalidInput(t *testing.T) {
 	assert.Contains(t, err.Error(), "chaincode name should not be empty")
 }
 
+func TestMinimalEndorsers(t *testing.T) {
+	newPeer := func(mspID string, pendingProposals uint32) *Peer {
+		return &Peer{
+			MSPID:            mspID,
+			StateInfoMessage: stateInfoWithLoadHints(&gossip.LoadHints{PendingProposals: pendingProposals}),
+		}
+	}
+	a0, a1, a2 := newPeer("A", 5), newPeer("A", 1), newPeer("A", 3)
+	b0, c0 := newPeer("B", 0), newPeer("C", 0)
+	call := InvocationChain(ccCall("mycc"))
+	resp := response{
+		key{
+			queryType:       discovery.ChaincodeQueryType,
+			channel:         "mychannel",
+			invocationChain: call.String(),
+		}: &endorsementDescriptor{
+			endorsersByGroups: map[string][]*Peer{
+				"A": {a0, a1, a2},
+				"B": {b0},
+				"C": {c0},
+			},
+			layouts: []map[string]int{
+				{"A": 1, "B": 1, "C": 1},
+				{"A": 2},
+				{"B": 1, "C": 1},
+				{"A": 3, "B": 1},
+			},
+		},
+	}
+	mychannel := resp.ForChannel("mychannel")
+
+	// One of the smallest layouts is selected, ties are broken randomly
+	layoutOf := func(endorsers Endorsers) map[string]int {
+		layout := make(map[string]int)
+		for _, endorser := range endorsers {
+			layout[endorser.MSPID]++
+		}
+		return layout
+	}
+	for i := 0; i < 10; i++ {
+		endorsers, err := mychannel.MinimalEndorsers(call, NoPriorities, NoExclusion)
+		assert.NoError(t, err)
+		assert.Len(t, endorsers, 2)
+		assert.Contains(t, []map[string]int{{"A": 2}, {"B": 1, "C": 1}}, layoutOf(endorsers))
+	}
+
+	// The smallest layout that can be satisfied is selected, and the least loaded peers are selected in it
+	excludeC := selectionFunc(func(p Peer) bool {
+		return p.MSPID == "C"
+	})
+	endorsers, err := mychannel.MinimalEndorsers(call, PrioritiesByLoad, excludeC)
+	assert.NoError(t, err)
+	assert.Equal(t, Endorsers{a1, a2}, endorsers)
+
+	// No layout can be satisfied
+	excludeA := selectionFunc(func(p Peer) bool {
+		return p.MSPID == "A" || p.MSPID == "C"
+	})
+	_, err = mychannel.MinimalEndorsers(call, NoPriorities, excludeA)
+	assert.EqualError(t, err, "no endorsement combination can be satisfied")
+
+	// Unknown invocation chain
+	_, err = mychannel.MinimalEndorsers(ccCall("mycc2"), NoPriorities, NoExclusion)
+	assert.Equal(t, ErrNotFound, err)
+}
+
 func TestValidateAliveMessage(t *testing.T) {
 	am := aliveMessage(1)
 	msg, _ := am.ToGossipMessage()
@@ -828,6 +917,10 @@ func (ms *mockSupport) Config(channel string) (*discovery.ConfigResult, error) {
 	return ms.Called(channel).Get(0).(*discovery.ConfigResult), nil
 }
 
+func (ms *mockSupport) ChannelInfo(channel string) (*discovery.ChannelInfoResult, error) {
+	return ms.Called(channel).Get(0).(*discovery.ChannelInfoResult), nil
+}
+
 type mockDiscoveryServer struct {
 	mock.Mock
 	*grpc.Server
@@ -10,6 +10,8 @@ import (
 	"math/rand"
 	"sort"
 	"time"
+
+	"github.com/sinochem-tech/fabric/protos/gossip"
 )
 
 // ExclusionFilter returns true if the given Peer
@@ -43,6 +45,11 @@ type Priority int
 var (
 	// PrioritiesByHeight selects peers by descending height
 	PrioritiesByHeight = &byHeight{}
+	// PrioritiesByLoad selects peers by ascending amount of pending proposals,
+	// and peers with the same amount by ascending endorsement latency
+	PrioritiesByLoad = &byLoad{}
+	// PrioritiesByLatency selects peers by ascending endorsement latency
+	PrioritiesByLatency = &byLatency{}
 	// NoExclusion accepts all peers and rejects no peers
 	NoExclusion = selectionFunc(noExclusion)
 	// NoPriorities is indifferent to how it selects peers
@@ -70,6 +77,61 @@ func (*byHeight) Compare(left Peer, right Peer) Priority {
 	return 0
 }
 
+// loadHints returns the load hints the given peer published, or nil if it didn't publish any
+func loadHints(p Peer) *gossip.LoadHints {
+	if p.StateInfoMessage == nil || p.StateInfoMessage.GetStateInfo() == nil {
+		return nil
+	}
+	return p.StateInfoMessage.GetStateInfo().Properties.GetLoadHints()
+}
+
+// compareLoadHints compares the load hints of 2 peers according to the given metric,
+// in which a lower value is preferred. Peers that didn't publish load hints are
+// least preferred, as nothing is known about their load
+func compareLoadHints(left Peer, right Peer, metric func(*gossip.LoadHints) uint64) Priority {
+	leftHints, rightHints := loadHints(left), loadHints(right)
+	if leftHints == nil && rightHints == nil {
+		return 0
+	}
+	if rightHints == nil {
+		return 1
+	}
+	if leftHints == nil {
+		return -1
+	}
+	leftValue, rightValue := metric(leftHints), metric(rightHints)
+	if leftValue < rightValue {
+		return 1
+	}
+	if rightValue < leftValue {
+		return -1
+	}
+	return 0
+}
+
+func pendingProposals(hints *gossip.LoadHints) uint64 {
+	return uint64(hints.PendingProposals)
+}
+
+func endorsementLatency(hints *gossip.LoadHints) uint64 {
+	return hints.EndorsementLatency
+}
+
+type byLoad struct{}
+
+func (*byLoad) Compare(left Peer, right Peer) Priority {
+	if p := compareLoadHints(left, right, pendingProposals); p != 0 {
+		return p
+	}
+	return compareLoadHints(left, right, endorsementLatency)
+}
+
+type byLatency struct{}
+
+func (*byLatency) Compare(left Peer, right Peer) Priority {
+	return compareLoadHints(left, right, endorsementLatency)
+}
+
 func noExclusion(_ Peer) bool {
 	return false
 }
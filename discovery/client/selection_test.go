@@ -149,6 +149,88 @@ func TestPrioritiesByHeight(t *testing.T) {
 
 }
 
+func TestPrioritiesByLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   Priority
+		leftHints  *gossip.LoadHints
+		rightHints *gossip.LoadHints
+	}{
+		{
+			name:       "Same load",
+			expected:   0,
+			leftHints:  &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 10},
+			rightHints: &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 10},
+		},
+		{
+			name:       "Right has less pending proposals",
+			expected:   -1,
+			leftHints:  &gossip.LoadHints{PendingProposals: 3, EndorsementLatency: 10},
+			rightHints: &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 20},
+		},
+		{
+			name:       "Left has less pending proposals",
+			expected:   1,
+			leftHints:  &gossip.LoadHints{PendingProposals: 1, EndorsementLatency: 20},
+			rightHints: &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 10},
+		},
+		{
+			name:       "Same pending proposals, left has lower latency",
+			expected:   1,
+			leftHints:  &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 10},
+			rightHints: &gossip.LoadHints{PendingProposals: 2, EndorsementLatency: 20},
+		},
+		{
+			name:       "Left has no load hints",
+			expected:   -1,
+			rightHints: &gossip.LoadHints{PendingProposals: 100, EndorsementLatency: 100},
+		},
+		{
+			name:     "No load hints",
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p1 := Peer{
+				StateInfoMessage: stateInfoWithLoadHints(test.leftHints),
+			}
+			p2 := Peer{
+				StateInfoMessage: stateInfoWithLoadHints(test.rightHints),
+			}
+			assert.Equal(t, test.expected, PrioritiesByLoad.Compare(p1, p2))
+			assert.Equal(t, -test.expected, PrioritiesByLoad.Compare(p2, p1))
+		})
+	}
+}
+
+func TestPrioritiesByLatency(t *testing.T) {
+	p1 := Peer{
+		StateInfoMessage: stateInfoWithLoadHints(&gossip.LoadHints{PendingProposals: 5, EndorsementLatency: 10}),
+	}
+	p2 := Peer{
+		StateInfoMessage: stateInfoWithLoadHints(&gossip.LoadHints{PendingProposals: 1, EndorsementLatency: 30}),
+	}
+	p3 := Peer{
+		StateInfoMessage: stateInfoWithHeight(100),
+	}
+	assert.Equal(t, Priority(1), PrioritiesByLatency.Compare(p1, p2))
+	assert.Equal(t, Priority(-1), PrioritiesByLatency.Compare(p2, p1))
+	assert.Equal(t, Priority(1), PrioritiesByLatency.Compare(p2, p3))
+	assert.Equal(t, Priority(0), PrioritiesByLatency.Compare(p3, p3))
+
+	endorsers := Endorsers{&p3, &p2, &p1}
+	assert.Equal(t, Endorsers{&p1, &p2, &p3}, endorsers.Sort(PrioritiesByLatency))
+}
+
+func stateInfoWithLoadHints(hints *gossip.LoadHints) *gossip.SignedGossipMessage {
+	sMsg := stateInfoWithHeight(100)
+	sMsg.GetStateInfo().Properties.LoadHints = hints
+	return sMsg
+}
+
 func stateInfoWithHeight(h uint64) *gossip.SignedGossipMessage {
 	g := &gossip.GossipMessage{
 		Content: &gossip.GossipMessage_StateInfo{
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/sinochem-tech/fabric/cmd/common"
+	"github.com/sinochem-tech/fabric/discovery/client"
+	. "github.com/sinochem-tech/fabric/protos/discovery"
+	"github.com/pkg/errors"
+)
+
+// NewChannelInfoCmd creates a new ChannelInfoCmd
+func NewChannelInfoCmd(stub Stub, parser ResponseParser) *ChannelInfoCmd {
+	return &ChannelInfoCmd{
+		stub:   stub,
+		parser: parser,
+	}
+}
+
+// ChannelInfoCmd executes a command that retrieves channel information
+type ChannelInfoCmd struct {
+	stub    Stub
+	server  *string
+	channel *string
+	parser  ResponseParser
+}
+
+// SetServer sets the server of the ChannelInfoCmd
+func (pc *ChannelInfoCmd) SetServer(server *string) {
+	pc.server = server
+}
+
+// SetChannel sets the channel of the ChannelInfoCmd
+func (pc *ChannelInfoCmd) SetChannel(channel *string) {
+	pc.channel = channel
+}
+
+// Execute executes the command
+func (pc *ChannelInfoCmd) Execute(conf common.Config) error {
+	if pc.server == nil || *pc.server == "" {
+		return errors.New("no server specified")
+	}
+	if pc.channel == nil || *pc.channel == "" {
+		return errors.New("no channel specified")
+	}
+
+	server := *pc.server
+	channel := *pc.channel
+
+	req := discovery.NewRequest().OfChannel(channel).AddChannelInfoQuery()
+	res, err := pc.stub.Send(server, conf, req)
+	if err != nil {
+		return err
+	}
+	return pc.parser.ParseResponse(channel, res)
+}
+
+// ChannelInfoResponseParser parses channel info responses
+type ChannelInfoResponseParser struct {
+	io.Writer
+}
+
+// ParseResponse parses the given response for the given channel
+func (parser *ChannelInfoResponseParser) ParseResponse(channel string, res ServiceResponse) error {
+	info, err := res.ForChannel(channel).ChannelInfo()
+	if err != nil {
+		return err
+	}
+	jsonBytes, _ := json.MarshalIndent(rawChannelInfoToChannelInfo(info), "", "\t")
+	fmt.Fprintln(parser.Writer, string(jsonBytes))
+	return nil
+}
+
+type channelInfo struct {
+	Capabilities      map[string][]string
+	ACLs              map[string]string
+	AnchorPeers       map[string][]string
+	OrdererTLSCACerts map[string]tlsCACerts
+	BatchSettings     *batchSettings `json:",omitempty"`
+}
+
+type tlsCACerts struct {
+	RootCerts         []string
+	IntermediateCerts []string `json:",omitempty"`
+}
+
+type batchSettings struct {
+	MaxMessageCount   uint32
+	AbsoluteMaxBytes  uint32
+	PreferredMaxBytes uint32
+	Timeout           string
+}
+
+func rawChannelInfoToChannelInfo(info *ChannelInfoResult) channelInfo {
+	res := channelInfo{
+		Capabilities:      make(map[string][]string),
+		ACLs:              make(map[string]string),
+		AnchorPeers:       make(map[string][]string),
+		OrdererTLSCACerts: make(map[string]tlsCACerts),
+	}
+	for level, capabilities := range info.Capabilities {
+		res.Capabilities[level] = capabilities.Names
+	}
+	for resource, policy := range info.Acls {
+		res.ACLs[resource] = policy
+	}
+	for mspID, endpoints := range info.AnchorPeers {
+		for _, endpoint := range endpoints.Endpoint {
+			res.AnchorPeers[mspID] = append(res.AnchorPeers[mspID], net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port))))
+		}
+	}
+	for mspID, certs := range info.OrdererTlsCaCerts {
+		res.OrdererTLSCACerts[mspID] = tlsCACerts{
+			RootCerts:         certsToStrings(certs.RootCerts),
+			IntermediateCerts: certsToStrings(certs.IntermediateCerts),
+		}
+	}
+	if bs := info.BatchSettings; bs != nil {
+		res.BatchSettings = &batchSettings{
+			MaxMessageCount:   bs.MaxMessageCount,
+			AbsoluteMaxBytes:  bs.AbsoluteMaxBytes,
+			PreferredMaxBytes: bs.PreferredMaxBytes,
+			Timeout:           bs.Timeout,
+		}
+	}
+	return res
+}
+
+func certsToStrings(certs [][]byte) []string {
+	var res []string
+	for _, cert := range certs {
+		res = append(res, string(cert))
+	}
+	return res
+}
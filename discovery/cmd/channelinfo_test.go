@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/cmd/common"
+	"github.com/sinochem-tech/fabric/discovery/cmd"
+	"github.com/sinochem-tech/fabric/discovery/cmd/mocks"
+	. "github.com/sinochem-tech/fabric/protos/discovery"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestChannelInfoCmd(t *testing.T) {
+	server := "peer0"
+	channel := "mychannel"
+	stub := &mocks.Stub{}
+	parser := &mocks.ResponseParser{}
+	cmd := discovery.NewChannelInfoCmd(stub, parser)
+
+	t.Run("no server supplied", func(t *testing.T) {
+		cmd.SetChannel(&channel)
+		cmd.SetServer(nil)
+
+		err := cmd.Execute(common.Config{})
+		assert.Equal(t, err.Error(), "no server specified")
+	})
+
+	t.Run("no channel supplied", func(t *testing.T) {
+		cmd.SetChannel(nil)
+		cmd.SetServer(&server)
+
+		err := cmd.Execute(common.Config{})
+		assert.Equal(t, err.Error(), "no channel specified")
+	})
+
+	t.Run("Server return error", func(t *testing.T) {
+		cmd.SetChannel(&channel)
+		cmd.SetServer(&server)
+
+		stub.On("Send", server, mock.Anything, mock.Anything).Return(nil, errors.New("deadline exceeded")).Once()
+		err := cmd.Execute(common.Config{})
+		assert.Contains(t, err.Error(), "deadline exceeded")
+	})
+
+	t.Run("Channel info query", func(t *testing.T) {
+		cmd.SetServer(&server)
+		cmd.SetChannel(&channel)
+		stub.On("Send", server, mock.Anything, mock.Anything).Return(nil, nil).Once()
+		parser.On("ParseResponse", channel, mock.Anything).Return(nil)
+
+		err := cmd.Execute(common.Config{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestParseChannelInfoResponse(t *testing.T) {
+	buff := &bytes.Buffer{}
+	parser := &discovery.ChannelInfoResponseParser{Writer: buff}
+	res := &mocks.ServiceResponse{}
+	chanRes := &mocks.ChannelResponse{}
+
+	t.Run("Failure", func(t *testing.T) {
+		chanRes.On("ChannelInfo").Return(nil, errors.New("not found")).Once()
+		res.On("ForChannel", "mychannel").Return(chanRes)
+		err := parser.ParseResponse("mychannel", res)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		chanRes.On("ChannelInfo").Return(&ChannelInfoResult{
+			Capabilities: map[string]*Capabilities{
+				"Channel": {Names: []string{"V1_1"}},
+			},
+			Acls: map[string]string{
+				"peer/Propose": "/Channel/Application/Writers",
+			},
+			AnchorPeers: map[string]*Endpoints{
+				"Org1MSP": {Endpoint: []*Endpoint{
+					{Host: "peer0", Port: 7051},
+				}},
+			},
+			OrdererTlsCaCerts: map[string]*TLSCACerts{
+				"OrdererMSP": {RootCerts: [][]byte{[]byte("cert")}},
+			},
+			BatchSettings: &BatchSettings{
+				MaxMessageCount:   10,
+				AbsoluteMaxBytes:  100,
+				PreferredMaxBytes: 50,
+				Timeout:           "2s",
+			},
+		}, nil).Once()
+		res.On("ForChannel", "mychannel").Return(chanRes)
+
+		err := parser.ParseResponse("mychannel", res)
+		assert.NoError(t, err)
+		expected := "{\n\t\"Capabilities\": {\n\t\t\"Channel\": [\n\t\t\t\"V1_1\"\n\t\t]\n\t},\n\t\"ACLs\": {\n\t\t\"peer/Propose\": \"/Channel/Application/Writers\"\n\t}," +
+			"\n\t\"AnchorPeers\": {\n\t\t\"Org1MSP\": [\n\t\t\t\"peer0:7051\"\n\t\t]\n\t},\n\t\"OrdererTLSCACerts\": {\n\t\t\"OrdererMSP\": {\n\t\t\t\"RootCerts\": [\n\t\t\t\t\"cert\"\n\t\t\t]\n\t\t}\n\t}," +
+			"\n\t\"BatchSettings\": {\n\t\t\"MaxMessageCount\": 10,\n\t\t\"AbsoluteMaxBytes\": 100,\n\t\t\"PreferredMaxBytes\": 50,\n\t\t\"Timeout\": \"2s\"\n\t}\n}"
+		assert.Equal(t, fmt.Sprintf("%s\n", expected), buff.String())
+	})
+}
@@ -16,9 +16,10 @@ import (
 )
 
 const (
-	PeersCommand     = "peers"
-	ConfigCommand    = "config"
-	EndorsersCommand = "endorsers"
+	PeersCommand       = "peers"
+	ConfigCommand      = "config"
+	EndorsersCommand   = "endorsers"
+	ChannelInfoCommand = "channelinfo"
 )
 
 var (
@@ -70,6 +71,13 @@ func AddCommands(cli CommandRegistrar) {
 	configCmd.SetServer(server)
 	configCmd.SetChannel(channel)
 
+	channelInfoCmd := NewChannelInfoCmd(&ClientStub{}, &ChannelInfoResponseParser{Writer: responseParserWriter})
+	channelInfo := cli.Command(ChannelInfoCommand, "Discover channel capabilities, ACLs, anchor peers, orderer TLS CA certificates and batch settings", channelInfoCmd.Execute)
+	server = channelInfo.Flag("server", "Sets the endpoint of the server to connect").String()
+	channel = channelInfo.Flag("channel", "Sets the channel the query is intended to").String()
+	channelInfoCmd.SetServer(server)
+	channelInfoCmd.SetChannel(channel)
+
 	endorserCmd := NewEndorsersCmd(&RawStub{}, &EndorserResponseParser{Writer: responseParserWriter})
 	endorsers := cli.Command(EndorsersCommand, "Discover chaincode endorsers", endorserCmd.Execute)
 	chaincodes := endorsers.Flag("chaincode", "Specifies the chaincode name(s)").Strings()
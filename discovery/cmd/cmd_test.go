@@ -23,9 +23,10 @@ func TestAddCommands(t *testing.T) {
 	cli.On("Command", discovery.PeersCommand, mock.Anything, configFunc).Return(app.Command(discovery.PeersCommand, ""))
 	cli.On("Command", discovery.ConfigCommand, mock.Anything, configFunc).Return(app.Command(discovery.ConfigCommand, ""))
 	cli.On("Command", discovery.EndorsersCommand, mock.Anything, configFunc).Return(app.Command(discovery.EndorsersCommand, ""))
+	cli.On("Command", discovery.ChannelInfoCommand, mock.Anything, configFunc).Return(app.Command(discovery.ChannelInfoCommand, ""))
 	discovery.AddCommands(cli)
 	// Ensure that serve and channel flags are were configured for the sub-commands
-	for _, cmd := range []string{discovery.PeersCommand, discovery.ConfigCommand, discovery.EndorsersCommand} {
+	for _, cmd := range []string{discovery.PeersCommand, discovery.ConfigCommand, discovery.EndorsersCommand, discovery.ChannelInfoCommand} {
 		assert.NotNil(t, app.GetCommand(cmd).GetFlag("server"))
 		assert.NotNil(t, app.GetCommand(cmd).GetFlag("channel"))
 	}
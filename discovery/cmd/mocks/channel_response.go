@@ -10,6 +10,29 @@ type ChannelResponse struct {
 	mock.Mock
 }
 
+// ChannelInfo provides a mock function with given fields:
+func (_m *ChannelResponse) ChannelInfo() (*discovery.ChannelInfoResult, error) {
+	ret := _m.Called()
+
+	var r0 *discovery.ChannelInfoResult
+	if rf, ok := ret.Get(0).(func() *discovery.ChannelInfoResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*discovery.ChannelInfoResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Config provides a mock function with given fields:
 func (_m *ChannelResponse) Config() (*discovery.ConfigResult, error) {
 	ret := _m.Called()
@@ -56,6 +79,29 @@ func (_m *ChannelResponse) Endorsers(invocationChain client.InvocationChain, ps
 	return r0, r1
 }
 
+// MinimalEndorsers provides a mock function with given fields: invocationChain, ps, ef
+func (_m *ChannelResponse) MinimalEndorsers(invocationChain client.InvocationChain, ps client.PrioritySelector, ef client.ExclusionFilter) (client.Endorsers, error) {
+	ret := _m.Called(invocationChain, ps, ef)
+
+	var r0 client.Endorsers
+	if rf, ok := ret.Get(0).(func(client.InvocationChain, client.PrioritySelector, client.ExclusionFilter) client.Endorsers); ok {
+		r0 = rf(invocationChain, ps, ef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(client.Endorsers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(client.InvocationChain, client.PrioritySelector, client.ExclusionFilter) error); ok {
+		r1 = rf(invocationChain, ps, ef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Peers provides a mock function with given fields:
 func (_m *ChannelResponse) Peers() ([]*client.Peer, error) {
 	ret := _m.Called()
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package endorsement
 
 import (
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
 	"github.com/sinochem-tech/fabric/common/policies"
+	"github.com/sinochem-tech/fabric/common/policies/inquire"
 	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/gossip/api"
+	"github.com/sinochem-tech/fabric/protos/common"
 	. "github.com/sinochem-tech/fabric/protos/discovery"
 	"github.com/pkg/errors"
 )
@@ -47,6 +50,32 @@ func principalsFromCollectionConfig(configBytes []byte) (principalSetsByCollecti
 	return principalSetsByCollections, nil
 }
 
+// endorsementPoliciesFromCollectionConfig returns the endorsement policies of the given collections,
+// out of those that define one in the given collection config
+func endorsementPoliciesFromCollectionConfig(configBytes []byte, collections []string) ([]policies.InquireablePolicy, error) {
+	if len(configBytes) == 0 {
+		return nil, nil
+	}
+	ccp, err := privdata.ParseCollectionConfig(configBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid collection bytes")
+	}
+	endorsementPolicies := make(map[string]*common.SignaturePolicyEnvelope)
+	for _, colConfig := range ccp.Config {
+		staticCol := colConfig.GetStaticCollectionConfig()
+		if pol := staticCol.GetEndorsementPolicy().GetSignaturePolicy(); pol != nil {
+			endorsementPolicies[staticCol.Name] = pol
+		}
+	}
+	var res []policies.InquireablePolicy
+	for _, col := range collections {
+		if pol, exists := endorsementPolicies[col]; exists {
+			res = append(res, inquire.NewInquireableSignaturePolicy(pol))
+		}
+	}
+	return res, nil
+}
+
 type principalSetsByCollectionName map[string]policies.PrincipalSet
 
 // toIdentityFilter converts this principalSetsByCollectionName mapping to a filter
@@ -58,6 +87,11 @@ func (psbc principalSetsByCollectionName) toIdentityFilter(channel string, evalu
 		// Otherwise, we have no way of computing a filter because we can't locate the principals the peer identities
 		// need to satisfy.
 		principalSet, exists := psbc[col]
+		// Implicit collections aren't part of the collection config,
+		// their only principal is a member of their org
+		if isImplicit, mspID := privdata.MspIDIfImplicitCollection(col); !exists && isImplicit {
+			principalSet, exists = policies.PrincipalSet(cauthdsl.SignedByAnyMember([]string{mspID}).Identities), true
+		}
 		if !exists {
 			return nil, errors.Errorf("collection %s doesn't exist in collection config for chaincode %s", col, cc.Name)
 		}
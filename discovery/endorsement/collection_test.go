@@ -10,6 +10,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
 	"github.com/sinochem-tech/fabric/common/policies"
 	"github.com/sinochem-tech/fabric/gossip/api"
 	gcommon "github.com/sinochem-tech/fabric/gossip/common"
@@ -44,6 +46,36 @@ func TestPrincipalsFromCollectionConfig(t *testing.T) {
 	})
 }
 
+func TestEndorsementPoliciesFromCollectionConfig(t *testing.T) {
+	t.Run("Empty config", func(t *testing.T) {
+		res, err := endorsementPoliciesFromCollectionConfig(nil, []string{"foo"})
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := endorsementPoliciesFromCollectionConfig([]byte{1, 2, 3}, []string{"foo"})
+		assert.Contains(t, err.Error(), "invalid collection bytes")
+	})
+
+	t.Run("Not empty config", func(t *testing.T) {
+		config := buildCollectionConfig(map[string][]*msp.MSPPrincipal{
+			"foo": {orgPrincipal("Org1MSP")},
+			"bar": {orgPrincipal("Org2MSP")},
+		})
+		config = withEndorsementPolicy(config, "foo", orgPrincipal("Org3MSP"))
+
+		res, err := endorsementPoliciesFromCollectionConfig(config, []string{"bar"})
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+
+		res, err = endorsementPoliciesFromCollectionConfig(config, []string{"foo", "bar"})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, []policies.PrincipalSet{{orgPrincipal("Org3MSP")}}, res[0].SatisfiedBy())
+	})
+}
+
 func TestNewCollectionFilterInvalidInput(t *testing.T) {
 	t.Run("Invalid collection", func(t *testing.T) {
 		filter, err := principalsFromCollectionConfig([]byte{1, 2, 3})
@@ -125,6 +157,22 @@ func TestToIdentityFilter(t *testing.T) {
 		})
 		assert.False(t, filter(identity))
 	})
+
+	t.Run("implicit collection", func(t *testing.T) {
+		filter, err := col2principals.toIdentityFilter("mychannel", &principalEvaluatorMock{}, &discovery.ChaincodeCall{
+			Name:            "mycc",
+			CollectionNames: []string{"_implicit_org_Org3MSP"},
+		})
+		assert.NoError(t, err)
+		identity := utils.MarshalOrPanic(&msp.SerializedIdentity{
+			Mspid: "Org3MSP",
+		})
+		assert.True(t, filter(identity))
+		identity = utils.MarshalOrPanic(&msp.SerializedIdentity{
+			Mspid: "Org1MSP",
+		})
+		assert.False(t, filter(identity))
+	})
 }
 
 func TestCombine(t *testing.T) {
@@ -240,6 +288,34 @@ func buildCollectionConfig(col2principals map[string][]*msp.MSPPrincipal) []byte
 	return utils.MarshalOrPanic(collections)
 }
 
+// withEndorsementPolicy sets an endorsement policy which is satisfied by any of the
+// given principals to the given collection in the given collection config
+func withEndorsementPolicy(config []byte, col string, principals ...*msp.MSPPrincipal) []byte {
+	collections := &common.CollectionConfigPackage{}
+	if err := proto.Unmarshal(config, collections); err != nil {
+		panic(err)
+	}
+	var rules []*common.SignaturePolicy
+	for i := range principals {
+		rules = append(rules, cauthdsl.SignedBy(int32(i)))
+	}
+	for _, colConfig := range collections.Config {
+		staticCol := colConfig.GetStaticCollectionConfig()
+		if staticCol.Name != col {
+			continue
+		}
+		staticCol.EndorsementPolicy = &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: &common.SignaturePolicyEnvelope{
+					Rule:       cauthdsl.NOutOf(1, rules),
+					Identities: principals,
+				},
+			},
+		}
+	}
+	return utils.MarshalOrPanic(collections)
+}
+
 func orgPrincipal(mspID string) *msp.MSPPrincipal {
 	return &msp.MSPPrincipal{
 		PrincipalClassification: msp.MSPPrincipal_ROLE,
@@ -77,7 +77,7 @@ type peerPrincipalEvaluator func(member NetworkMember, principal *msp.MSPPrincip
 
 // PeersForEndorsement returns an EndorsementDescriptor for a given set of peers, channel, and chaincode
 func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
-	chanMembership, err := ea.PeersAuthorizedByCriteria(chainID, interest)
+	chanMembership, metadata, err := ea.peersAuthorizedByCriteria(chainID, interest)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -87,7 +87,7 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 	membersById := aliveMembership.ByID()
 	// Compute a mapping between the PKI-IDs of members to their identities
 	identitiesOfMembers := computeIdentitiesOfMembers(ea.IdentityInfo(), membersById)
-	principalsSets, err := ea.computePrincipalSets(chainID, interest)
+	principalsSets, err := ea.computePrincipalSets(chainID, interest, metadata)
 	if err != nil {
 		logger.Warningf("Principal set computation failed: %v", err)
 		return nil, errors.WithStack(err)
@@ -104,9 +104,16 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 }
 
 func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(chainID common.ChainID, interest *discovery.ChaincodeInterest) (Members, error) {
+	members, _, err := ea.peersAuthorizedByCriteria(chainID, interest)
+	return members, err
+}
+
+// peersAuthorizedByCriteria returns the peers authorized by the given criteria,
+// along with the metadata of the chaincodes in the given interest
+func (ea *endorsementAnalyzer) peersAuthorizedByCriteria(chainID common.ChainID, interest *discovery.ChaincodeInterest) (Members, []*chaincode.Metadata, error) {
 	peersOfChannel := ea.PeersOfChannel(chainID)
 	if interest == nil || len(interest.Chaincodes) == 0 {
-		return peersOfChannel, nil
+		return peersOfChannel, nil, nil
 	}
 	identities := ea.IdentityInfo()
 	identitiesByID := identities.ByID()
@@ -118,13 +125,13 @@ func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(chainID common.ChainID,
 		fetch:            ea,
 	})
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, nil, errors.WithStack(err)
 	}
 	metadata := metadataAndCollectionFilters.md
 	// Filter out peers that don't have the chaincode installed on them
 	chanMembership := peersOfChannel.Filter(peersWithChaincode(metadata...))
 	// Filter out peers that aren't authorized by the collection configs of the chaincode invocation chain
-	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), nil
+	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), metadata, nil
 }
 
 type context struct {
@@ -167,9 +174,24 @@ func (ea *endorsementAnalyzer) computeEndorsementResponse(ctx *context) (*discov
 	}, nil
 }
 
-func (ea *endorsementAnalyzer) computePrincipalSets(chainID common.ChainID, interest *discovery.ChaincodeInterest) (policies.PrincipalSets, error) {
+func (ea *endorsementAnalyzer) computePrincipalSets(chainID common.ChainID, interest *discovery.ChaincodeInterest, metadata []*chaincode.Metadata) (policies.PrincipalSets, error) {
 	var inquireablePolicies []policies.InquireablePolicy
-	for _, chaincode := range interest.Chaincodes {
+	for i, chaincode := range interest.Chaincodes {
+		var collectionPolicies []policies.InquireablePolicy
+		if i < len(metadata) && len(chaincode.CollectionNames) > 0 {
+			var err error
+			collectionPolicies, err = endorsementPoliciesFromCollectionConfig(metadata[i].CollectionsConfig, chaincode.CollectionNames)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		inquireablePolicies = append(inquireablePolicies, collectionPolicies...)
+		if chaincode.DisregardNamespacePolicy {
+			if len(collectionPolicies) == 0 {
+				return nil, errors.Errorf("requested to disregard the endorsement policy of chaincode %s but none of its collections has an endorsement policy", chaincode.Name)
+			}
+			continue
+		}
 		pol := ea.PolicyByChaincode(string(chainID), chaincode.Name)
 		if pol == nil {
 			logger.Debug("Policy for chaincode '", chaincode, "'doesn't exist")
@@ -227,7 +249,9 @@ func loadMetadataAndFilters(ctx metadataAndFilterContext) (*metadataAndColFilter
 			return nil, errors.Errorf("No metadata was found for chaincode %s in channel %s", chaincode.Name, string(ctx.chainID))
 		}
 		metadata = append(metadata, ccMD)
-		if len(chaincode.CollectionNames) == 0 {
+		// Peers that can't read the private data of the collections are eligible endorsers
+		// if the chaincode only writes to them
+		if len(chaincode.CollectionNames) == 0 || chaincode.NoPrivateReads {
 			continue
 		}
 		principalSetByCollections, err := principalsFromCollectionConfig(ccMD.CollectionsConfig)
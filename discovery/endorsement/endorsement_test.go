@@ -254,6 +254,72 @@ func TestPeersForEndorsement(t *testing.T) {
 		}, extractPeers(desc))
 	})
 
+	t.Run("CollectionEndorsementPolicy", func(t *testing.T) {
+		// Scenario X: Policy is found and there are enough peers to satisfy
+		// 2 principal combinations: p0 and p6, or p12 alone.
+		// The query contains a collection whose members are p0 and p12,
+		// but whose endorsement policy requires p6.
+		collectionConfig := withEndorsementPolicy(buildCollectionConfig(map[string][]*msp.MSPPrincipal{
+			"collection": {peerRole("p0"), peerRole("p12")},
+		}), "collection", peerRole("p6"))
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).
+			addPrincipal(peerRole("p6")).newSet().
+			addPrincipal(peerRole("p12")).buildPolicy()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		query := func(call *discoveryprotos.ChaincodeCall) (*discoveryprotos.EndorsementDescriptor, error) {
+			mf.On("Metadata").Return(&chaincode.Metadata{
+				Name: cc, Version: "1.0", CollectionsConfig: collectionConfig,
+			}).Once()
+			g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+			pf.On("PolicyByChaincode", cc).Return(policy).Once()
+			return analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+				Chaincodes: []*discoveryprotos.ChaincodeCall{call},
+			})
+		}
+
+		// p6 isn't a member of the collection, hence it is filtered out
+		desc, err := query(&discoveryprotos.ChaincodeCall{Name: cc, CollectionNames: []string{"collection"}})
+		assert.Nil(t, desc)
+		assert.Equal(t, "cannot satisfy any principal combination", err.Error())
+
+		// When the chaincode doesn't read from the collection, p6 is eligible, and the layout
+		// satisfies both the chaincode and the collection endorsement policies
+		desc, err = query(&discoveryprotos.ChaincodeCall{Name: cc, CollectionNames: []string{"collection"}, NoPrivateReads: true})
+		assert.NoError(t, err)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"): {},
+			peerIdentityString("p6"): {},
+		}, extractPeers(desc))
+
+		// When the chaincode only writes to the collection, its own policy is disregarded
+		desc, err = query(&discoveryprotos.ChaincodeCall{
+			Name:                     cc,
+			CollectionNames:          []string{"collection"},
+			NoPrivateReads:           true,
+			DisregardNamespacePolicy: true,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, desc.Layouts, 1)
+		assert.Equal(t, map[string]struct{}{
+			peerIdentityString("p6"): {},
+		}, extractPeers(desc))
+
+		// The chaincode policy can't be disregarded if no collection has an endorsement policy
+		mf.On("Metadata").Return(&chaincode.Metadata{
+			Name: cc, Version: "1.0", CollectionsConfig: buildCollectionConfig(map[string][]*msp.MSPPrincipal{
+				"collection": {peerRole("p0"), peerRole("p12")},
+			}),
+		}).Once()
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+		desc, err = analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc, CollectionNames: []string{"collection"}, DisregardNamespacePolicy: true}},
+		})
+		assert.Nil(t, desc)
+		assert.Contains(t, err.Error(), "requested to disregard the endorsement policy of chaincode chaincode")
+	})
+
 	t.Run("Chaincode2Chaincode", func(t *testing.T) {
 		// Scenario IX: A chaincode-to-chaincode query is made.
 		// Total organizations are 0, 2, 4, 6, 10, 12
@@ -455,7 +521,7 @@ func TestComputePrincipalSetsNoPolicies(t *testing.T) {
 		Chaincodes: []*discoveryprotos.ChaincodeCall{},
 	}
 	ea := &endorsementAnalyzer{}
-	_, err := ea.computePrincipalSets(common.ChainID("mychannel"), interest)
+	_, err := ea.computePrincipalSets(common.ChainID("mychannel"), interest, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no principal sets remained after filtering")
 }
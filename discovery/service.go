@@ -76,6 +76,7 @@ func NewService(config Config, sup Support) *service {
 		discovery.ConfigQueryType:         s.configQuery,
 		discovery.ChaincodeQueryType:      s.chaincodeQuery,
 		discovery.PeerMembershipQueryType: s.channelMembershipResponse,
+		discovery.ChannelInfoQueryType:    s.channelInfoQuery,
 	}
 	s.localDispatchers = map[discovery.QueryType]dispatcher{
 		discovery.LocalMembershipQueryType: s.localMembershipResponse,
@@ -166,6 +167,19 @@ func (s *service) configQuery(q *discovery.Query) *discovery.QueryResult {
 	}
 }
 
+func (s *service) channelInfoQuery(q *discovery.Query) *discovery.QueryResult {
+	info, err := s.ChannelInfo(q.Channel)
+	if err != nil {
+		logger.Errorf("Failed fetching channel info for channel %s: %v", q.Channel, err)
+		return wrapError(errors.Errorf("failed fetching channel info for channel %s", q.Channel))
+	}
+	return &discovery.QueryResult{
+		Result: &discovery.QueryResult_ChannelInfo{
+			ChannelInfo: info,
+		},
+	}
+}
+
 func wrapPeerResponse(peersByOrg map[string]*discovery.Peers) *discovery.QueryResult {
 	return &discovery.QueryResult{
 		Result: &discovery.QueryResult_Members{
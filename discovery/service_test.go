@@ -314,6 +314,24 @@ func TestService(t *testing.T) {
 	resp, err = service.Discover(ctx, toSignedRequest(req))
 	assert.NoError(t, err)
 	assert.Contains(t, resp.Results[0].GetError().Content, "unknown or missing request type")
+
+	// Scenario XIV: Request with a channel info query that fails
+	mockSup.On("ChannelInfo", mock.Anything).Return(nil, errors.New("failed fetching channel info")).Once()
+	req.Queries[0].Query = &discovery.Query_ChannelInfoQuery{
+		ChannelInfoQuery: &discovery.ChannelInfoQuery{},
+	}
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Results[0].GetError().Content, "failed fetching channel info for channel channelWithAccessGranted")
+
+	// Scenario XV: Request with a channel info query
+	channelInfo := &discovery.ChannelInfoResult{
+		Acls: map[string]string{"peer/Propose": "/Channel/Application/Writers"},
+	}
+	mockSup.On("ChannelInfo", mock.Anything).Return(channelInfo, nil).Once()
+	resp, err = service.Discover(ctx, toSignedRequest(req))
+	assert.NoError(t, err)
+	assert.Equal(t, channelInfo, resp.Results[0].GetChannelInfo())
 }
 
 func TestValidateStructure(t *testing.T) {
@@ -517,6 +535,14 @@ func (ms *mockSupport) Config(channel string) (*discovery.ConfigResult, error) {
 	return args.Get(0).(*discovery.ConfigResult), args.Error(1)
 }
 
+func (ms *mockSupport) ChannelInfo(channel string) (*discovery.ChannelInfoResult, error) {
+	args := ms.Called(channel)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*discovery.ChannelInfoResult), args.Error(1)
+}
+
 func idInfo(id int, org string) api.PeerIdentityInfo {
 	endpoint := fmt.Sprintf("p%d", id)
 	return api.PeerIdentityInfo{
@@ -9,6 +9,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 
 	"github.com/golang/protobuf/proto"
@@ -18,6 +19,8 @@ import (
 	"github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/discovery"
 	"github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/sinochem-tech/fabric/protos/orderer"
+	"github.com/sinochem-tech/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
@@ -52,6 +55,101 @@ func NewDiscoverySupport(getLastConfigBlock CurrentConfigBlockGetter) *Discovery
 
 // Config returns the channel's configuration
 func (s *DiscoverySupport) Config(channel string) (*discovery.ConfigResult, error) {
+	ce, err := s.configEnvelope(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &discovery.ConfigResult{
+		Msps:     make(map[string]*msp.FabricMSPConfig),
+		Orderers: make(map[string]*discovery.Endpoints),
+	}
+	ordererGrp := ce.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups
+	appGrp := ce.Config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups
+
+	ordererAddresses := &common.OrdererAddresses{}
+	if err := proto.Unmarshal(ce.Config.ChannelGroup.Values[channelconfig.OrdererAddressesKey].Value, ordererAddresses); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling orderer addresses")
+	}
+
+	ordererEndpoints, err := computeOrdererEndpoints(ordererGrp, ordererAddresses)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed computing orderer addresses")
+	}
+	res.Orderers = ordererEndpoints
+
+	if err := appendMSPConfigs(ordererGrp, appGrp, res.Msps); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return res, nil
+
+}
+
+// ChannelInfo returns information about the channel that clients
+// need in order to construct transactions
+func (s *DiscoverySupport) ChannelInfo(channel string) (*discovery.ChannelInfoResult, error) {
+	ce, err := s.configEnvelope(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &discovery.ChannelInfoResult{
+		Capabilities:      make(map[string]*discovery.Capabilities),
+		Acls:              make(map[string]string),
+		AnchorPeers:       make(map[string]*discovery.Endpoints),
+		OrdererTlsCaCerts: make(map[string]*discovery.TLSCACerts),
+	}
+	channelGrp := ce.Config.ChannelGroup
+	ordererGrp := channelGrp.Groups[channelconfig.OrdererGroupKey]
+	appGrp := channelGrp.Groups[channelconfig.ApplicationGroupKey]
+
+	groupsByLevel := map[string]*common.ConfigGroup{
+		channelconfig.ChannelGroupKey:     channelGrp,
+		channelconfig.OrdererGroupKey:     ordererGrp,
+		channelconfig.ApplicationGroupKey: appGrp,
+	}
+	for level, grp := range groupsByLevel {
+		capabilities := &common.Capabilities{}
+		exists, err := unmarshalValue(grp, channelconfig.CapabilitiesKey, capabilities)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling %s capabilities", level)
+		}
+		if !exists {
+			continue
+		}
+		res.Capabilities[level] = &discovery.Capabilities{}
+		for name := range capabilities.Capabilities {
+			res.Capabilities[level].Names = append(res.Capabilities[level].Names, name)
+		}
+		sort.Strings(res.Capabilities[level].Names)
+	}
+
+	acls := &peer.ACLs{}
+	if _, err := unmarshalValue(appGrp, channelconfig.ACLsKey, acls); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling ACLs")
+	}
+	for resource, apiResource := range acls.Acls {
+		res.Acls[resource] = apiResource.GetPolicyRef()
+	}
+
+	if err := appendAnchorPeers(appGrp.Groups, res.AnchorPeers); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := appendTLSCACerts(ordererGrp.Groups, res.OrdererTlsCaCerts); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	batchSettings, err := computeBatchSettings(ordererGrp)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	res.BatchSettings = batchSettings
+	return res, nil
+}
+
+// configEnvelope returns the config envelope of the last config block of the given channel
+func (s *DiscoverySupport) configEnvelope(channel string) (*common.ConfigEnvelope, error) {
 	block := s.GetCurrConfigBlock(channel)
 	if block == nil {
 		return nil, errors.Errorf("could not get last config block for channel %s", channel)
@@ -75,30 +173,99 @@ func (s *DiscoverySupport) Config(channel string) (*discovery.ConfigResult, erro
 	if err := ValidateConfigEnvelope(ce); err != nil {
 		return nil, errors.Wrap(err, "config envelope is invalid")
 	}
+	return ce, nil
+}
 
-	res := &discovery.ConfigResult{
-		Msps:     make(map[string]*msp.FabricMSPConfig),
-		Orderers: make(map[string]*discovery.Endpoints),
+// unmarshalValue unmarshals the value of the given key in the given group into the given message,
+// and returns whether the value exists in the group
+func unmarshalValue(grp *common.ConfigGroup, key string, msg proto.Message) (bool, error) {
+	value, exists := grp.Values[key]
+	if !exists {
+		return false, nil
 	}
-	ordererGrp := ce.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups
-	appGrp := ce.Config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups
+	return true, proto.Unmarshal(value.Value, msg)
+}
 
-	ordererAddresses := &common.OrdererAddresses{}
-	if err := proto.Unmarshal(ce.Config.ChannelGroup.Values[channelconfig.OrdererAddressesKey].Value, ordererAddresses); err != nil {
-		return nil, errors.Wrap(err, "failed unmarshaling orderer addresses")
+// fabricMSPConfig returns the FabricMSPConfig of the given organization group,
+// or nil if the organization's MSP isn't a FABRIC MSP
+func fabricMSPConfig(grp *common.ConfigGroup) (*msp.FabricMSPConfig, error) {
+	mspConfig := &msp.MSPConfig{}
+	if _, err := unmarshalValue(grp, channelconfig.MSPKey, mspConfig); err != nil {
+		return nil, errors.Wrap(err, "failed parsing MSPConfig")
+	}
+	if mspConfig.Type != int32(mspconstants.FABRIC) {
+		return nil, nil
+	}
+	fabricConfig := &msp.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return nil, errors.Wrap(err, "failed marshaling FabricMSPConfig")
 	}
+	return fabricConfig, nil
+}
 
-	ordererEndpoints, err := computeOrdererEndpoints(ordererGrp, ordererAddresses)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed computing orderer addresses")
+func appendAnchorPeers(appGrp map[string]*common.ConfigGroup, output map[string]*discovery.Endpoints) error {
+	for name, grp := range appGrp {
+		anchorPeers := &peer.AnchorPeers{}
+		exists, err := unmarshalValue(grp, channelconfig.AnchorPeersKey, anchorPeers)
+		if err != nil {
+			return errors.Wrap(err, "failed unmarshaling anchor peers")
+		}
+		if !exists || len(anchorPeers.AnchorPeers) == 0 {
+			continue
+		}
+		fabricConfig, err := fabricMSPConfig(grp)
+		if err != nil {
+			return err
+		}
+		// Anchor peers of non fabric MSPs are skipped, as they can't be mapped to an MSP ID
+		if fabricConfig == nil {
+			logger.Warning("Application group", name, "has anchor peers but is not a FABRIC MSP")
+			continue
+		}
+		endpoints := &discovery.Endpoints{}
+		for _, anchorPeer := range anchorPeers.AnchorPeers {
+			endpoints.Endpoint = append(endpoints.Endpoint, &discovery.Endpoint{
+				Host: anchorPeer.Host,
+				Port: uint32(anchorPeer.Port),
+			})
+		}
+		output[fabricConfig.Name] = endpoints
 	}
-	res.Orderers = ordererEndpoints
+	return nil
+}
 
-	if err := appendMSPConfigs(ordererGrp, appGrp, res.Msps); err != nil {
-		return nil, errors.WithStack(err)
+func appendTLSCACerts(ordererGrp map[string]*common.ConfigGroup, output map[string]*discovery.TLSCACerts) error {
+	for _, grp := range ordererGrp {
+		fabricConfig, err := fabricMSPConfig(grp)
+		if err != nil {
+			return err
+		}
+		if fabricConfig == nil {
+			continue
+		}
+		output[fabricConfig.Name] = &discovery.TLSCACerts{
+			RootCerts:         fabricConfig.TlsRootCerts,
+			IntermediateCerts: fabricConfig.TlsIntermediateCerts,
+		}
 	}
-	return res, nil
+	return nil
+}
 
+func computeBatchSettings(ordererGrp *common.ConfigGroup) (*discovery.BatchSettings, error) {
+	batchSize := &orderer.BatchSize{}
+	if _, err := unmarshalValue(ordererGrp, channelconfig.BatchSizeKey, batchSize); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling batch size")
+	}
+	batchTimeout := &orderer.BatchTimeout{}
+	if _, err := unmarshalValue(ordererGrp, channelconfig.BatchTimeoutKey, batchTimeout); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling batch timeout")
+	}
+	return &discovery.BatchSettings{
+		MaxMessageCount:   batchSize.MaxMessageCount,
+		AbsoluteMaxBytes:  batchSize.AbsoluteMaxBytes,
+		PreferredMaxBytes: batchSize.PreferredMaxBytes,
+		Timeout:           batchTimeout.Timeout,
+	}, nil
 }
 
 func computeOrdererEndpoints(ordererGrp map[string]*common.ConfigGroup, ordererAddresses *common.OrdererAddresses) (map[string]*discovery.Endpoints, error) {
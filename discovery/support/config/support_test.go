@@ -23,6 +23,7 @@ import (
 	"github.com/sinochem-tech/fabric/discovery/support/config"
 	"github.com/sinochem-tech/fabric/discovery/support/mocks"
 	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/discovery"
 	"github.com/onsi/gomega/gexec"
 	"github.com/stretchr/testify/assert"
 )
@@ -151,6 +152,46 @@ func TestSupportGreenPath(t *testing.T) {
 	assert.NotNil(t, res)
 }
 
+func TestChannelInfo(t *testing.T) {
+	fakeBlockGetter := &mocks.ConfigBlockGetter{}
+	fakeBlockGetter.GetCurrConfigBlockReturnsOnCall(0, nil)
+
+	cs := config.NewDiscoverySupport(fakeBlockGetter)
+	res, err := cs.ChannelInfo("test")
+	assert.Nil(t, res)
+	assert.Equal(t, "could not get last config block for channel test", err.Error())
+
+	block, err := test.MakeGenesisBlock("test")
+	assert.NoError(t, err)
+
+	fakeBlockGetter.GetCurrConfigBlockReturnsOnCall(1, block)
+	res, err = cs.ChannelInfo("test")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*discovery.Capabilities{
+		"Channel":     {Names: []string{"V1_1"}},
+		"Orderer":     {Names: []string{"V1_1"}},
+		"Application": {Names: []string{"V1_2"}},
+	}, res.Capabilities)
+	assert.Equal(t, "/Channel/Application/Writers", res.Acls["peer/Propose"])
+	assert.Equal(t, "/Channel/Application/Readers", res.Acls["qscc/GetChainInfo"])
+	assert.Equal(t, map[string]*discovery.Endpoints{
+		"SampleOrg": {Endpoint: []*discovery.Endpoint{{Host: "127.0.0.1", Port: 7051}}},
+	}, res.AnchorPeers)
+	assert.Len(t, res.OrdererTlsCaCerts, 1)
+	assert.Len(t, res.OrdererTlsCaCerts["SampleOrg"].RootCerts, 1)
+	assert.Equal(t, &discovery.BatchSettings{
+		MaxMessageCount:   10,
+		AbsoluteMaxBytes:  10485760,
+		PreferredMaxBytes: 524288,
+		Timeout:           "2s",
+	}, res.BatchSettings)
+
+	fakeBlockGetter.GetCurrConfigBlockReturnsOnCall(2, blockWithConfigEnvelope())
+	res, err = cs.ChannelInfo("test")
+	assert.Contains(t, err.Error(), "failed unmarshaling config envelope")
+	assert.Nil(t, res)
+}
+
 func TestSupportBadConfig(t *testing.T) {
 	fakeBlockGetter := &mocks.ConfigBlockGetter{}
 	cs := config.NewDiscoverySupport(fakeBlockGetter)
@@ -147,9 +147,26 @@ type Gossip struct {
 	identityInfoReturnsOnCall map[int]struct {
 		result1 api.PeerIdentitySet
 	}
-	StopStub         func()
-	stopMutex        sync.RWMutex
-	stopArgsForCall  []struct{}
+	StopStub                   func()
+	stopMutex                  sync.RWMutex
+	stopArgsForCall            []struct{}
+	UpdateLoadHintsStub        func(loadHints *proto.LoadHints, chainID common.ChainID)
+	updateLoadHintsMutex       sync.RWMutex
+	updateLoadHintsArgsForCall []struct {
+		loadHints *proto.LoadHints
+		chainID   common.ChainID
+	}
+	UpdateIdentityStub        func(identity api.PeerIdentityType) error
+	updateIdentityMutex       sync.RWMutex
+	updateIdentityArgsForCall []struct {
+		identity api.PeerIdentityType
+	}
+	updateIdentityReturns struct {
+		result1 error
+	}
+	updateIdentityReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -745,6 +762,79 @@ func (fake *Gossip) StopCallCount() int {
 	return len(fake.stopArgsForCall)
 }
 
+func (fake *Gossip) UpdateLoadHints(loadHints *proto.LoadHints, chainID common.ChainID) {
+	fake.updateLoadHintsMutex.Lock()
+	fake.updateLoadHintsArgsForCall = append(fake.updateLoadHintsArgsForCall, struct {
+		loadHints *proto.LoadHints
+		chainID   common.ChainID
+	}{loadHints, chainID})
+	fake.recordInvocation("UpdateLoadHints", []interface{}{loadHints, chainID})
+	fake.updateLoadHintsMutex.Unlock()
+	if fake.UpdateLoadHintsStub != nil {
+		fake.UpdateLoadHintsStub(loadHints, chainID)
+	}
+}
+
+func (fake *Gossip) UpdateLoadHintsCallCount() int {
+	fake.updateLoadHintsMutex.RLock()
+	defer fake.updateLoadHintsMutex.RUnlock()
+	return len(fake.updateLoadHintsArgsForCall)
+}
+
+func (fake *Gossip) UpdateLoadHintsArgsForCall(i int) (*proto.LoadHints, common.ChainID) {
+	fake.updateLoadHintsMutex.RLock()
+	defer fake.updateLoadHintsMutex.RUnlock()
+	return fake.updateLoadHintsArgsForCall[i].loadHints, fake.updateLoadHintsArgsForCall[i].chainID
+}
+
+func (fake *Gossip) UpdateIdentity(identity api.PeerIdentityType) error {
+	fake.updateIdentityMutex.Lock()
+	ret, specificReturn := fake.updateIdentityReturnsOnCall[len(fake.updateIdentityArgsForCall)]
+	fake.updateIdentityArgsForCall = append(fake.updateIdentityArgsForCall, struct {
+		identity api.PeerIdentityType
+	}{identity})
+	fake.recordInvocation("UpdateIdentity", []interface{}{identity})
+	fake.updateIdentityMutex.Unlock()
+	if fake.UpdateIdentityStub != nil {
+		return fake.UpdateIdentityStub(identity)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateIdentityReturns.result1
+}
+
+func (fake *Gossip) UpdateIdentityCallCount() int {
+	fake.updateIdentityMutex.RLock()
+	defer fake.updateIdentityMutex.RUnlock()
+	return len(fake.updateIdentityArgsForCall)
+}
+
+func (fake *Gossip) UpdateIdentityArgsForCall(i int) api.PeerIdentityType {
+	fake.updateIdentityMutex.RLock()
+	defer fake.updateIdentityMutex.RUnlock()
+	return fake.updateIdentityArgsForCall[i].identity
+}
+
+func (fake *Gossip) UpdateIdentityReturns(result1 error) {
+	fake.UpdateIdentityStub = nil
+	fake.updateIdentityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Gossip) UpdateIdentityReturnsOnCall(i int, result1 error) {
+	fake.UpdateIdentityStub = nil
+	if fake.updateIdentityReturnsOnCall == nil {
+		fake.updateIdentityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateIdentityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Gossip) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -782,6 +872,10 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.identityInfoMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.updateLoadHintsMutex.RLock()
+	defer fake.updateLoadHintsMutex.RUnlock()
+	fake.updateIdentityMutex.RLock()
+	defer fake.updateIdentityMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -92,6 +92,9 @@ func CreateBlockEvents(block *common.Block) (bevent *pb.Event, fbevent *pb.Event
 							filteredCcEvent := ccEvent
 							// nil out ccevent payload
 							filteredCcEvent.Payload = nil
+							for _, event := range filteredCcEvent.Events {
+								event.Payload = nil
+							}
 							chaincodeAction.ChaincodeEvent = filteredCcEvent
 						}
 						transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, chaincodeAction)
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package producer
+
+import (
+	"testing"
+
+	"github.com/sinochem-tech/fabric/common/ledger/testutil"
+	mmsp "github.com/sinochem-tech/fabric/common/mocks/msp"
+	"github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/protos/common"
+	pb "github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/sinochem-tech/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBlockEventsMultipleChaincodeEvents(t *testing.T) {
+	ccEvent := &pb.ChaincodeEvent{
+		ChaincodeId: "mycc",
+		TxId:        "txid",
+		EventName:   "second",
+		Payload:     []byte("second payload"),
+		Events: []*pb.ChaincodeEvent{
+			{ChaincodeId: "mycc", TxId: "txid", EventName: "first", Payload: []byte("first payload")},
+			{ChaincodeId: "mycc", TxId: "txid", EventName: "second", Payload: []byte("second payload")},
+		},
+	}
+	signer, err := mmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	ccid := &pb.ChaincodeID{Name: "mycc"}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(),
+		&pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: ccid}}, creator)
+	assert.NoError(t, err)
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &pb.Response{Status: 200}, []byte("results"),
+		utils.MarshalOrPanic(ccEvent), ccid, nil, signer)
+	assert.NoError(t, err)
+	env, err := utils.CreateSignedTx(prop, signer, presp)
+	assert.NoError(t, err)
+	block := testutil.NewBlock([]*common.Envelope{env}, 1, []byte("previous hash"))
+
+	bevent, fbevent, channelID, err := CreateBlockEvents(block)
+	assert.NoError(t, err)
+	assert.Equal(t, util.GetTestChainID(), channelID)
+
+	// The filtered block carries the names of all the events, without their payloads
+	filteredTxs := fbevent.GetFilteredBlock().FilteredTransactions
+	assert.Len(t, filteredTxs, 1)
+	actions := filteredTxs[0].GetTransactionActions().ChaincodeActions
+	assert.Len(t, actions, 1)
+	filteredEvent := actions[0].ChaincodeEvent
+	assert.Equal(t, "second", filteredEvent.EventName)
+	assert.Nil(t, filteredEvent.Payload)
+	assert.Len(t, filteredEvent.Events, 2)
+	for i, name := range []string{"first", "second"} {
+		assert.Equal(t, name, filteredEvent.Events[i].EventName)
+		assert.Nil(t, filteredEvent.Events[i].Payload)
+	}
+
+	// The block keeps the payloads of the events
+	action, err := utils.GetActionFromEnvelope(bevent.GetBlock().Data.Data[0])
+	assert.NoError(t, err)
+	events, err := utils.GetChaincodeEvents(action.Events)
+	assert.NoError(t, err)
+	assert.Equal(t, ccEvent.Events, events.AllEvents())
+}
@@ -205,9 +205,11 @@ func main() {
 							if len(chaincodeID) != 0 && event.ChaincodeId == chaincodeID {
 								fmt.Println("")
 								fmt.Println("")
-								fmt.Printf("Received chaincode event from channel '%s'\n", chdr.ChannelId)
+								fmt.Printf("Received chaincode events from channel '%s'\n", chdr.ChannelId)
 								fmt.Println("------------------------")
-								fmt.Printf("Chaincode Event:%+v\n", event)
+								for _, e := range event.AllEvents() {
+									fmt.Printf("Chaincode Event: Name: %s, Payload: %s\n", e.EventName, e.Payload)
+								}
 							}
 						}
 					}
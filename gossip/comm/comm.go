@@ -47,6 +47,14 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// UpdateIdentity replaces the identity this instance authenticates with to remote peers,
+	// and closes the existing connections so that they are re-established with it
+	UpdateIdentity(identity api.PeerIdentityType)
+
+	// SetPinning makes this instance refuse connections with the remote peers that
+	// the pinning policies don't admit, given the organizations of their identities
+	SetPinning(pinning *Pinning, sa api.SecurityAdvisor)
+
 	// Stop stops the module
 	Stop()
 }
@@ -9,6 +9,7 @@ package comm
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"net"
@@ -124,6 +125,7 @@ type commImpl struct {
 	secureDialOpts func() []grpc.DialOption
 	connStore      *connectionStore
 	PKIID          []byte
+	identityLock   sync.RWMutex
 	deadEndpoints  chan common.PKIidType
 	msgPublisher   *ChannelDeMultiplexer
 	lock           *sync.Mutex
@@ -135,6 +137,9 @@ type commImpl struct {
 	port           int
 	stopping       int32
 	dialTimeout    time.Duration
+	pinningLock    sync.RWMutex
+	pinning        *Pinning
+	secAdvisor     api.SecurityAdvisor
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -381,9 +386,46 @@ func (c *commImpl) Stop() {
 }
 
 func (c *commImpl) GetPKIid() common.PKIidType {
+	c.identityLock.RLock()
+	defer c.identityLock.RUnlock()
 	return c.PKIID
 }
 
+func (c *commImpl) UpdateIdentity(identity api.PeerIdentityType) {
+	c.identityLock.Lock()
+	c.peerIdentity = identity
+	c.PKIID = c.idMapper.GetPKIidOfCert(identity)
+	c.identityLock.Unlock()
+	c.logger.Info("Updated identity, PKIid is now", common.PKIidType(c.GetPKIid()))
+	c.connStore.closeAll()
+}
+
+// SetPinning makes this instance refuse connections with the remote peers that
+// the pinning policies don't admit, given the organizations of their identities
+func (c *commImpl) SetPinning(pinning *Pinning, sa api.SecurityAdvisor) {
+	c.pinningLock.Lock()
+	defer c.pinningLock.Unlock()
+	c.pinning = pinning
+	c.secAdvisor = sa
+}
+
+// verifyPinning returns an error if the pinning policies don't admit the remote
+// peer with the given PKI-ID, identity and TLS certificate, or else binds the
+// certificate to the PKI-ID
+func (c *commImpl) verifyPinning(pkiID common.PKIidType, identity api.PeerIdentityType, cert *x509.Certificate) error {
+	c.pinningLock.RLock()
+	pinning, sa := c.pinning, c.secAdvisor
+	c.pinningLock.RUnlock()
+	if pinning == nil {
+		return nil
+	}
+	if err := pinning.Verify(PinnedPeerOfCert(string(sa.OrgByPeerIdentity(identity)), cert)); err != nil {
+		return err
+	}
+	pinning.BindCert(pkiID, cert)
+	return nil
+}
+
 func extractRemoteAddress(stream stream) string {
 	var remoteAddress string
 	p, ok := peer.FromContext(stream.Context())
@@ -398,7 +440,11 @@ func extractRemoteAddress(stream stream) string {
 func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto.ConnectionInfo, error) {
 	ctx := stream.Context()
 	remoteAddress := extractRemoteAddress(stream)
-	remoteCertHash := extractCertificateHashFromContext(ctx)
+	remoteCert := extractCertificateFromContext(ctx)
+	var remoteCertHash []byte
+	if remoteCert != nil {
+		remoteCertHash = certHashFromRawCert(remoteCert.Raw)
+	}
 	var err error
 	var cMsg *proto.SignedGossipMessage
 	useTLS := c.tlsCerts != nil
@@ -422,7 +468,10 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 		return nil, fmt.Errorf("No TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer)
+	c.identityLock.RLock()
+	selfPKIID, selfIdentity := c.PKIID, c.peerIdentity
+	c.identityLock.RUnlock()
+	cMsg, err = c.createConnectionMsg(selfPKIID, selfCertHash, selfIdentity, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -481,6 +530,12 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 		return nil, err
 	}
 
+	// Refuse the peers that the pinning policies don't admit
+	if err := c.verifyPinning(receivedMsg.PkiId, receivedMsg.Identity, remoteCert); err != nil {
+		c.logger.Warningf("Pinning policies refused %s : %v", remoteAddress, err)
+		return nil, err
+	}
+
 	c.logger.Debug("Authenticated", remoteAddress)
 
 	return connInfo, nil
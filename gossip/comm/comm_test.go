@@ -11,6 +11,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"net"
@@ -767,6 +768,77 @@ func TestProbe(t *testing.T) {
 	assert.Equal(t, api.PeerIdentityType("localhost:6612"), id)
 }
 
+// orgsByEndpoint is a SecurityAdvisor mapping the identities of the
+// comm instances of the tests, their endpoints, to organizations
+type orgsByEndpoint map[string]string
+
+func (orgs orgsByEndpoint) OrgByPeerIdentity(identity api.PeerIdentityType) api.OrgIdentityType {
+	return api.OrgIdentityType(orgs[string(identity)])
+}
+
+func TestPinning(t *testing.T) {
+	t.Parallel()
+	comm1, _ := newCommInstance(6621, naiveSec)
+	defer comm1.Stop()
+	comm2, _ := newCommInstance(6622, naiveSec)
+	defer comm2.Stop()
+	comm3, _ := newCommInstance(6623, naiveSec)
+	defer comm3.Stop()
+	time.Sleep(time.Duration(1) * time.Second)
+
+	orgs := orgsByEndpoint{"localhost:6621": "Org1MSP", "localhost:6622": "Org2MSP", "localhost:6623": "Org3MSP"}
+	comm3ServerCert := comm3.(*commImpl).tlsCerts.TLSServerCert.Load().(*tls.Certificate).Certificate[0]
+	pinning, err := NewPinning(PinningConfig{
+		PinningPolicy: PinningPolicy{
+			Deny: PinningRule{MSPIDs: []string{"Org2MSP"}},
+		},
+		Orgs: []OrgPinningPolicy{
+			{
+				MSPID: "Org3MSP",
+				PinningPolicy: PinningPolicy{
+					Allow: PinningRule{CertHashes: []string{hex.EncodeToString(certHashFromRawCert(comm3ServerCert))}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	comm1.SetPinning(pinning, orgs)
+	inMsgs := comm1.Accept(acceptAll)
+	assertReceived := func(expected bool) {
+		select {
+		case <-inMsgs:
+			assert.True(t, expected, "comm1 received a message of a refused peer")
+		case <-time.After(time.Second):
+			assert.False(t, expected, "comm1 didn't receive the message of an admitted peer")
+		}
+	}
+
+	// Peers of denied organizations can't be connected to, nor connect
+	_, err = comm1.Handshake(remotePeer(6622))
+	assert.EqualError(t, err, "MSP ID Org2MSP is denied")
+	comm2.Send(createGossipMsg(), remotePeer(6621))
+	assertReceived(false)
+
+	// Peers of organizations whose certificates are pinned can only connect
+	// with them, and comm3 has different client and server certificates
+	_, err = comm1.Handshake(remotePeer(6623))
+	assert.NoError(t, err)
+	comm3.Send(createGossipMsg(), remotePeer(6621))
+	assertReceived(false)
+
+	// Only the certificates of admitted peers are bound to their PKI-IDs
+	assert.True(t, pinning.IsBound(comm3.GetPKIid()))
+	assert.Equal(t, certHashFromRawCert(comm3ServerCert), pinning.PinnedPeer("Org3MSP", comm3.GetPKIid()).CertHash)
+	assert.False(t, pinning.IsBound(comm2.GetPKIid()))
+
+	// Once the policies are lifted, all peers are admitted
+	comm1.SetPinning(nil, nil)
+	_, err = comm1.Handshake(remotePeer(6622))
+	assert.NoError(t, err)
+	comm3.Send(createGossipMsg(), remotePeer(6621))
+	assertReceived(true)
+}
+
 func TestPresumedDead(t *testing.T) {
 	t.Parallel()
 	comm1, _ := newCommInstance(4611, naiveSec)
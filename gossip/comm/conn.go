@@ -161,6 +161,16 @@ func (cs *connectionStore) shutdown() {
 	wg.Wait()
 }
 
+// closeAll closes all connections, without preventing new ones from being created
+func (cs *connectionStore) closeAll() {
+	cs.Lock()
+	defer cs.Unlock()
+	for pkiID, conn := range cs.pki2Conn {
+		conn.close()
+		delete(cs.pki2Conn, pkiID)
+	}
+}
+
 func (cs *connectionStore) onConnected(serverStream proto.Gossip_GossipStreamServer, connInfo *proto.ConnectionInfo) *connection {
 	cs.Lock()
 	defer cs.Unlock()
@@ -66,6 +66,15 @@ func certHashFromRawCert(rawCert []byte) []byte {
 
 // ExtractCertificateHash extracts the hash of the certificate from the stream
 func extractCertificateHashFromContext(ctx context.Context) []byte {
+	cert := extractCertificateFromContext(ctx)
+	if cert == nil {
+		return nil
+	}
+	return certHashFromRawCert(cert.Raw)
+}
+
+// extractCertificateFromContext extracts the TLS certificate of the remote peer from the stream
+func extractCertificateFromContext(ctx context.Context) *x509.Certificate {
 	pr, extracted := peer.FromContext(ctx)
 	if !extracted {
 		return nil
@@ -84,6 +93,5 @@ func extractCertificateHashFromContext(ctx context.Context) []byte {
 	if len(certs) == 0 {
 		return nil
 	}
-	raw := certs[0].Raw
-	return certHashFromRawCert(raw)
+	return certs[0]
 }
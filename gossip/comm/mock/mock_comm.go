@@ -185,6 +185,16 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// UpdateIdentity replaces the identity of this instance
+func (mock *commMock) UpdateIdentity(identity api.PeerIdentityType) {
+	// NOOP
+}
+
+// SetPinning sets the pinning policies remote peers are verified against
+func (mock *commMock) SetPinning(pinning *comm.Pinning, sa api.SecurityAdvisor) {
+	// NOOP
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
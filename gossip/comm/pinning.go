@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sinochem-tech/fabric/gossip/common"
+)
+
+// PinningRule selects remote peers by the MSP ID of their identity, by the
+// SHA256 hash of their TLS certificate, and by patterns matched against the
+// subject alternative names of their TLS certificate. The patterns have the
+// syntax of path.Match, e.g. "*.org1.example.com".
+type PinningRule struct {
+	MSPIDs     []string
+	CertHashes []string
+	SANs       []string
+}
+
+// PinningPolicy admits the remote peers that match every non-empty criterion
+// of its Allow rule, and none of the criteria of its Deny rule. The peers whose
+// TLS certificate isn't known are refused by the policies with criteria on it.
+type PinningPolicy struct {
+	Allow PinningRule
+	Deny  PinningRule
+}
+
+// OrgPinningPolicy is a PinningPolicy applied to the peers of an organization
+type OrgPinningPolicy struct {
+	MSPID         string
+	PinningPolicy `mapstructure:",squash"`
+}
+
+// ChannelPinningPolicy is a PinningPolicy applied to the members of a channel
+type ChannelPinningPolicy struct {
+	Channel       string
+	PinningPolicy `mapstructure:",squash"`
+}
+
+// PinningConfig is the configuration of the certificate pinning of remote
+// peers. Its global policy applies to all the remote peers, at the handshakes
+// of the connections and in the discovery membership, and so do the policies
+// of Orgs to the peers of their organization. The policies of Channels apply
+// to the membership of their channel.
+type PinningConfig struct {
+	PinningPolicy `mapstructure:",squash"`
+	Orgs          []OrgPinningPolicy
+	Channels      []ChannelPinningPolicy
+}
+
+// PinnedPeer is a remote peer verified against the pinning policies
+type PinnedPeer struct {
+	// MSPID is the MSP ID of the identity of the peer
+	MSPID string
+	// CertHash is the SHA256 hash of the TLS certificate of
+	// the peer, or nil if it isn't known
+	CertHash []byte
+	// Names are the subject alternative names of the TLS certificate of the peer
+	Names []string
+}
+
+// PinnedPeerOfCert returns the PinnedPeer of the given MSP ID and TLS certificate
+func PinnedPeerOfCert(mspID string, cert *x509.Certificate) PinnedPeer {
+	peer := PinnedPeer{MSPID: mspID}
+	if cert == nil {
+		return peer
+	}
+	peer.CertHash = certHashFromRawCert(cert.Raw)
+	peer.Names = append(peer.Names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		peer.Names = append(peer.Names, ip.String())
+	}
+	return peer
+}
+
+// Pinning verifies remote peers against the policies of a PinningConfig, given
+// the TLS certificates they presented at the handshakes of their connections.
+// A nil Pinning admits all the peers.
+type Pinning struct {
+	global   *pinningPolicy
+	orgs     map[string]*pinningPolicy
+	channels map[string]*pinningPolicy
+
+	// certs are the TLS certificates bound to the PKI-IDs
+	// of the remote peers at the handshakes
+	lock  sync.RWMutex
+	certs map[string]*x509.Certificate
+}
+
+// NewPinning creates a Pinning out of the given PinningConfig, or returns
+// nil if the configuration has no policies.
+func NewPinning(conf PinningConfig) (*Pinning, error) {
+	p := &Pinning{
+		orgs:     make(map[string]*pinningPolicy),
+		channels: make(map[string]*pinningPolicy),
+		certs:    make(map[string]*x509.Certificate),
+	}
+	var err error
+	if p.global, err = newPinningPolicy(conf.PinningPolicy); err != nil {
+		return nil, err
+	}
+	for _, org := range conf.Orgs {
+		if org.MSPID == "" {
+			return nil, errors.New("pinning policy of an organization without MSP ID")
+		}
+		if _, exists := p.orgs[org.MSPID]; exists {
+			return nil, errors.Errorf("duplicate pinning policy of organization %s", org.MSPID)
+		}
+		if p.orgs[org.MSPID], err = newPinningPolicy(org.PinningPolicy); err != nil {
+			return nil, errors.WithMessage(err, "invalid pinning policy of organization "+org.MSPID)
+		}
+	}
+	for _, channel := range conf.Channels {
+		if channel.Channel == "" {
+			return nil, errors.New("pinning policy of a channel without name")
+		}
+		if _, exists := p.channels[channel.Channel]; exists {
+			return nil, errors.Errorf("duplicate pinning policy of channel %s", channel.Channel)
+		}
+		if p.channels[channel.Channel], err = newPinningPolicy(channel.PinningPolicy); err != nil {
+			return nil, errors.WithMessage(err, "invalid pinning policy of channel "+channel.Channel)
+		}
+	}
+
+	if p.global.isEmpty() && len(p.orgs) == 0 && len(p.channels) == 0 {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// RequiresCerts returns whether some policies have criteria
+// on the TLS certificates of the peers
+func (p *Pinning) RequiresCerts() bool {
+	if p == nil {
+		return false
+	}
+	if p.global.requiresCert() {
+		return true
+	}
+	for _, policy := range p.orgs {
+		if policy.requiresCert() {
+			return true
+		}
+	}
+	for _, policy := range p.channels {
+		if policy.requiresCert() {
+			return true
+		}
+	}
+	return false
+}
+
+// BindCert binds the TLS certificate the remote peer with the
+// given PKI-ID presented at the handshake of a connection to it
+func (p *Pinning) BindCert(pkiID common.PKIidType, cert *x509.Certificate) {
+	if p == nil || cert == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.certs[string(pkiID)] = cert
+}
+
+// UnbindCert removes the TLS certificate bound to the given PKI-ID
+func (p *Pinning) UnbindCert(pkiID common.PKIidType) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.certs, string(pkiID))
+}
+
+// IsBound returns whether a TLS certificate is bound to the given PKI-ID
+func (p *Pinning) IsBound(pkiID common.PKIidType) bool {
+	if p == nil {
+		return false
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, bound := p.certs[string(pkiID)]
+	return bound
+}
+
+// PinnedPeer returns the PinnedPeer of the given MSP ID and PKI-ID,
+// given the TLS certificate bound to the PKI-ID, if any
+func (p *Pinning) PinnedPeer(mspID string, pkiID common.PKIidType) PinnedPeer {
+	if p == nil {
+		return PinnedPeer{MSPID: mspID}
+	}
+	p.lock.RLock()
+	cert := p.certs[string(pkiID)]
+	p.lock.RUnlock()
+	return PinnedPeerOfCert(mspID, cert)
+}
+
+// Verify returns an error if the global policy, or the policy of
+// the organization of the peer, doesn't admit the given peer.
+func (p *Pinning) Verify(peer PinnedPeer) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.global.verify(peer); err != nil {
+		return err
+	}
+	if policy, exists := p.orgs[peer.MSPID]; exists {
+		return errors.WithMessage(policy.verify(peer), "pinning policy of organization "+peer.MSPID)
+	}
+	return nil
+}
+
+// VerifyInChannel returns an error if the policy of the
+// given channel doesn't admit the given peer.
+func (p *Pinning) VerifyInChannel(channel string, peer PinnedPeer) error {
+	if p == nil {
+		return nil
+	}
+	if policy, exists := p.channels[channel]; exists {
+		return errors.WithMessage(policy.verify(peer), "pinning policy of channel "+channel)
+	}
+	return nil
+}
+
+type pinningRule struct {
+	mspIDs     map[string]struct{}
+	certHashes map[string]struct{}
+	sans       []string
+}
+
+type pinningPolicy struct {
+	allow *pinningRule
+	deny  *pinningRule
+}
+
+func newPinningPolicy(conf PinningPolicy) (*pinningPolicy, error) {
+	allow, err := newPinningRule(conf.Allow)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid allow rule")
+	}
+	deny, err := newPinningRule(conf.Deny)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid deny rule")
+	}
+	return &pinningPolicy{allow: allow, deny: deny}, nil
+}
+
+func newPinningRule(conf PinningRule) (*pinningRule, error) {
+	r := &pinningRule{
+		mspIDs:     make(map[string]struct{}),
+		certHashes: make(map[string]struct{}),
+	}
+	for _, mspID := range conf.MSPIDs {
+		r.mspIDs[mspID] = struct{}{}
+	}
+	for _, certHash := range conf.CertHashes {
+		// Accept the colon separated fingerprints output by openssl
+		hash, err := hex.DecodeString(strings.Replace(certHash, ":", "", -1))
+		if err != nil || len(hash) != 32 {
+			return nil, errors.Errorf("%s is not a hex encoded SHA256 hash", certHash)
+		}
+		r.certHashes[string(hash)] = struct{}{}
+	}
+	for _, san := range conf.SANs {
+		san = strings.ToLower(san)
+		if _, err := path.Match(san, ""); err != nil {
+			return nil, errors.Errorf("%s is not a valid SAN pattern", san)
+		}
+		r.sans = append(r.sans, san)
+	}
+	return r, nil
+}
+
+func (r *pinningRule) isEmpty() bool {
+	return len(r.mspIDs) == 0 && len(r.certHashes) == 0 && len(r.sans) == 0
+}
+
+func (r *pinningRule) requiresCert() bool {
+	return len(r.certHashes) != 0 || len(r.sans) != 0
+}
+
+func (p *pinningPolicy) isEmpty() bool {
+	return p.allow.isEmpty() && p.deny.isEmpty()
+}
+
+func (p *pinningPolicy) requiresCert() bool {
+	return p.allow.requiresCert() || p.deny.requiresCert()
+}
+
+// verify returns an error if the policy doesn't admit the peer. The peer
+// is refused if the policy has criteria on its unknown TLS certificate.
+func (p *pinningPolicy) verify(peer PinnedPeer) error {
+	if _, denied := p.deny.mspIDs[peer.MSPID]; denied {
+		return errors.Errorf("MSP ID %s is denied", peer.MSPID)
+	}
+	if _, allowed := p.allow.mspIDs[peer.MSPID]; len(p.allow.mspIDs) != 0 && !allowed {
+		return errors.Errorf("MSP ID %s is not allowed", peer.MSPID)
+	}
+	if p.requiresCert() && peer.CertHash == nil {
+		return errors.New("the TLS certificate of the peer is unknown")
+	}
+	if _, denied := p.deny.certHashes[string(peer.CertHash)]; denied {
+		return errors.Errorf("TLS certificate %s is denied", hex.EncodeToString(peer.CertHash))
+	}
+	if name, denied := p.deny.matchName(peer.Names); denied {
+		return errors.Errorf("name %s is denied", name)
+	}
+
+	if len(p.allow.certHashes) != 0 {
+		if _, allowed := p.allow.certHashes[string(peer.CertHash)]; !allowed {
+			return errors.Errorf("TLS certificate %s is not allowed", hex.EncodeToString(peer.CertHash))
+		}
+	}
+	if len(p.allow.sans) != 0 {
+		if _, allowed := p.allow.matchName(peer.Names); !allowed {
+			return errors.Errorf("none of the names %v is allowed", peer.Names)
+		}
+	}
+	return nil
+}
+
+// matchName returns the first of the names matching a SAN pattern of the rule
+func (r *pinningRule) matchName(names []string) (string, bool) {
+	for _, name := range names {
+		for _, san := range r.sans {
+			if matched, _ := path.Match(san, strings.ToLower(name)); matched {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
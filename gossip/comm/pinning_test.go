@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/sinochem-tech/fabric/gossip/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPinning(t *testing.T) {
+	pinning, err := NewPinning(PinningConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, pinning)
+	assert.NoError(t, pinning.Verify(PinnedPeer{MSPID: "Org1MSP"}))
+	assert.NoError(t, pinning.VerifyInChannel("A", PinnedPeer{MSPID: "Org1MSP"}))
+
+	for _, conf := range []PinningConfig{
+		{PinningPolicy: PinningPolicy{Allow: PinningRule{CertHashes: []string{"0102"}}}},
+		{PinningPolicy: PinningPolicy{Deny: PinningRule{CertHashes: []string{"not hex"}}}},
+		{PinningPolicy: PinningPolicy{Deny: PinningRule{SANs: []string{"[.example.com"}}}},
+		{Orgs: []OrgPinningPolicy{{}}},
+		{Orgs: []OrgPinningPolicy{{MSPID: "Org1MSP"}, {MSPID: "Org1MSP"}}},
+		{Channels: []ChannelPinningPolicy{{}}},
+		{Channels: []ChannelPinningPolicy{{Channel: "A"}, {Channel: "A"}}},
+	} {
+		_, err := NewPinning(conf)
+		assert.Error(t, err)
+	}
+}
+
+func TestPinningVerify(t *testing.T) {
+	hash1 := make([]byte, 32)
+	hash1[0] = 1
+	hash2 := make([]byte, 32)
+	hash2[0] = 2
+	hash3 := make([]byte, 32)
+	hash3[0] = 3
+	// An openssl fingerprint
+	fingerprint3 := "03:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00:00"
+
+	pinning, err := NewPinning(PinningConfig{
+		PinningPolicy: PinningPolicy{
+			Deny: PinningRule{
+				MSPIDs:     []string{"Org3MSP"},
+				CertHashes: []string{hex.EncodeToString(hash2)},
+			},
+		},
+		Orgs: []OrgPinningPolicy{
+			{
+				MSPID: "Org2MSP",
+				PinningPolicy: PinningPolicy{
+					Allow: PinningRule{
+						CertHashes: []string{hex.EncodeToString(hash1), fingerprint3},
+						SANs:       []string{"*.Org2.example.com"},
+					},
+					Deny: PinningRule{
+						SANs: []string{"peer9.org2.example.com"},
+					},
+				},
+			},
+		},
+		Channels: []ChannelPinningPolicy{
+			{
+				Channel: "A",
+				PinningPolicy: PinningPolicy{
+					Allow: PinningRule{MSPIDs: []string{"Org1MSP"}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// The global policy applies to all peers
+	assert.NoError(t, pinning.Verify(PinnedPeer{MSPID: "Org1MSP", CertHash: hash1}))
+	assert.EqualError(t, pinning.Verify(PinnedPeer{MSPID: "Org3MSP"}), "MSP ID Org3MSP is denied")
+	assert.EqualError(t, pinning.Verify(PinnedPeer{MSPID: "Org1MSP", CertHash: hash2}),
+		"TLS certificate "+hex.EncodeToString(hash2)+" is denied")
+
+	// The policy of an organization applies to its peers
+	assert.NoError(t, pinning.Verify(PinnedPeer{MSPID: "Org2MSP", CertHash: hash1, Names: []string{"peer0.org2.example.com"}}))
+	assert.NoError(t, pinning.Verify(PinnedPeer{MSPID: "Org2MSP", CertHash: hash3, Names: []string{"localhost", "peer0.org2.example.com"}}))
+	err = pinning.Verify(PinnedPeer{MSPID: "Org2MSP", CertHash: make([]byte, 32), Names: []string{"peer0.org2.example.com"}})
+	assert.Contains(t, err.Error(), "pinning policy of organization Org2MSP: TLS certificate")
+	assert.Contains(t, err.Error(), "is not allowed")
+	err = pinning.Verify(PinnedPeer{MSPID: "Org2MSP", CertHash: hash1, Names: []string{"peer0.org1.example.com"}})
+	assert.EqualError(t, err, "pinning policy of organization Org2MSP: none of the names [peer0.org1.example.com] is allowed")
+	err = pinning.Verify(PinnedPeer{MSPID: "Org2MSP", CertHash: hash1, Names: []string{"peer9.org2.example.com"}})
+	assert.EqualError(t, err, "pinning policy of organization Org2MSP: name peer9.org2.example.com is denied")
+	// Peers whose certificate is unknown are refused by the policies with criteria on it
+	assert.EqualError(t, pinning.Verify(PinnedPeer{MSPID: "Org2MSP"}), "the TLS certificate of the peer is unknown")
+
+	// The policy of a channel only applies to its members
+	assert.NoError(t, pinning.VerifyInChannel("A", PinnedPeer{MSPID: "Org1MSP"}))
+	assert.EqualError(t, pinning.VerifyInChannel("A", PinnedPeer{MSPID: "Org2MSP"}),
+		"pinning policy of channel A: MSP ID Org2MSP is not allowed")
+	assert.NoError(t, pinning.VerifyInChannel("B", PinnedPeer{MSPID: "Org2MSP"}))
+}
+
+func TestPinnedPeer(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw:         []byte{1, 2, 3},
+		DNSNames:    []string{"peer0.org1.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+	}
+	peer := PinnedPeerOfCert("Org1MSP", cert)
+	assert.Equal(t, "Org1MSP", peer.MSPID)
+	assert.Equal(t, certHashFromRawCert(cert.Raw), peer.CertHash)
+	assert.Equal(t, []string{"peer0.org1.example.com", "10.0.0.1"}, peer.Names)
+	assert.Equal(t, PinnedPeer{MSPID: "Org1MSP"}, PinnedPeerOfCert("Org1MSP", nil))
+}
+
+func TestPinningBindCert(t *testing.T) {
+	var nilPinning *Pinning
+	nilPinning.BindCert(common.PKIidType("p1"), &x509.Certificate{})
+	assert.False(t, nilPinning.IsBound(common.PKIidType("p1")))
+	assert.False(t, nilPinning.RequiresCerts())
+	assert.Equal(t, PinnedPeer{MSPID: "Org1MSP"}, nilPinning.PinnedPeer("Org1MSP", common.PKIidType("p1")))
+
+	pinning, err := NewPinning(PinningConfig{
+		Channels: []ChannelPinningPolicy{
+			{
+				Channel: "A",
+				PinningPolicy: PinningPolicy{
+					Deny: PinningRule{SANs: []string{"*.org3.example.com"}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, pinning.RequiresCerts())
+
+	cert := &x509.Certificate{Raw: []byte{1, 2, 3}, DNSNames: []string{"peer0.org1.example.com"}}
+	pinning.BindCert(common.PKIidType("p1"), cert)
+	assert.True(t, pinning.IsBound(common.PKIidType("p1")))
+	assert.False(t, pinning.IsBound(common.PKIidType("p2")))
+	assert.Equal(t, PinnedPeerOfCert("Org1MSP", cert), pinning.PinnedPeer("Org1MSP", common.PKIidType("p1")))
+	assert.Equal(t, PinnedPeer{MSPID: "Org1MSP"}, pinning.PinnedPeer("Org1MSP", common.PKIidType("p2")))
+	assert.NoError(t, pinning.VerifyInChannel("A", pinning.PinnedPeer("Org1MSP", common.PKIidType("p1"))))
+	assert.Error(t, pinning.VerifyInChannel("A", pinning.PinnedPeer("Org1MSP", common.PKIidType("p2"))))
+
+	pinning.UnbindCert(common.PKIidType("p1"))
+	assert.False(t, pinning.IsBound(common.PKIidType("p1")))
+}
@@ -118,6 +118,9 @@ type Discovery interface {
 	// UpdateEndpoint updates this instance's endpoint
 	UpdateEndpoint(string)
 
+	// UpdatePKIid updates this instance's PKI-ID
+	UpdatePKIid(common.PKIidType)
+
 	// Stops this instance
 	Stop()
 
@@ -136,11 +136,11 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 
 // Lookup returns a network member, or nil if not found
 func (d *gossipDiscoveryImpl) Lookup(PKIID common.PKIidType) *NetworkMember {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	if bytes.Equal(PKIID, d.self.PKIid) {
 		return &d.self
 	}
-	d.lock.RLock()
-	defer d.lock.RUnlock()
 	nm := d.id2Member[string(PKIID)]
 	return nm
 }
@@ -946,6 +946,13 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.self.Endpoint = endpoint
 }
 
+func (d *gossipDiscoveryImpl) UpdatePKIid(pkiID common.PKIidType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.self.PKIid = pkiID
+}
+
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	var env *proto.Envelope
 	msg, _ := d.aliveMsgAndInternalEndpoint()
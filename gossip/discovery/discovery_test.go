@@ -568,6 +568,16 @@ func TestSelf(t *testing.T) {
 	assert.Equal(t, common.PKIidType("localhost:13463"), inst.Self().PKIid)
 }
 
+func TestUpdatePKIid(t *testing.T) {
+	t.Parallel()
+	inst := createDiscoveryInstance(13464, "d1", []string{})
+	defer inst.Stop()
+	inst.UpdatePKIid(common.PKIidType("rotated"))
+	assert.Equal(t, common.PKIidType("rotated"), inst.Self().PKIid)
+	assert.Equal(t, common.PKIidType("rotated"), inst.Lookup(common.PKIidType("rotated")).PKIid)
+	assert.Nil(t, inst.Lookup(common.PKIidType("localhost:13464")))
+}
+
 func TestExpiration(t *testing.T) {
 	t.Parallel()
 	nodeNum := 5
@@ -20,12 +20,23 @@ import (
 
 type msgImpl struct {
 	msg *proto.GossipMessage
+	// ledgerHeight is the ledger height of the sender
+	ledgerHeight uint64
 }
 
 func (mi *msgImpl) SenderID() peerID {
 	return mi.msg.GetLeadershipMsg().PkiId
 }
 
+func (mi *msgImpl) SenderWeight() Weight {
+	return Weight{
+		Healthy:      !mi.msg.GetLeadershipMsg().Unhealthy,
+		Priority:     mi.msg.GetLeadershipMsg().Priority,
+		LedgerHeight: mi.ledgerHeight,
+		Legacy:       !mi.msg.GetLeadershipMsg().Weighted,
+	}
+}
+
 func (mi *msgImpl) IsProposal() bool {
 	return !mi.IsDeclaration()
 }
@@ -36,12 +47,18 @@ func (mi *msgImpl) IsDeclaration() bool {
 
 type peerImpl struct {
 	member discovery.NetworkMember
+	// ledgerHeight is the ledger height of the peer in the channel
+	ledgerHeight uint64
 }
 
 func (pi *peerImpl) ID() peerID {
 	return peerID(pi.member.PKIid)
 }
 
+func (pi *peerImpl) LedgerHeight() uint64 {
+	return pi.ledgerHeight
+}
+
 type gossip interface {
 	// Peers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
@@ -54,6 +71,13 @@ type gossip interface {
 
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
+
+	// PeersOfChannel returns the NetworkMembers considered alive
+	// and also subscribed to the channel given
+	PeersOfChannel(common.ChainID) []discovery.NetworkMember
+
+	// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+	SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage
 }
 
 type adapterImpl struct {
@@ -65,14 +89,21 @@ type adapterImpl struct {
 
 	channel common.ChainID
 
+	priority    uint32
+	healthCheck HealthCheck
+
 	logger *logging.Logger
 
 	doneCh   chan struct{}
 	stopOnce *sync.Once
 }
 
-// NewAdapter creates new leader election adapter
-func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID) LeaderElectionAdapter {
+// NewAdapter creates new leader election adapter, the given health check
+// determines whether the peer is fit to be the leader, if nil the peer always is
+func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID, healthCheck HealthCheck) LeaderElectionAdapter {
+	if healthCheck == nil {
+		healthCheck = func() bool { return true }
+	}
 	return &adapterImpl{
 		gossip:    gossip,
 		selfPKIid: pkiid,
@@ -82,6 +113,9 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID) L
 
 		channel: channel,
 
+		priority:    getPriority(),
+		healthCheck: healthCheck,
+
 		logger: util.GetLogger(util.LoggingElectionModule, ""),
 
 		doneCh:   make(chan struct{}),
@@ -110,7 +144,7 @@ func (ai *adapterImpl) Accept() <-chan Msg {
 				return
 			case gossipMsg, ok := <-inCh:
 				if ok {
-					outCh <- &msgImpl{gossipMsg}
+					outCh <- &msgImpl{msg: gossipMsg, ledgerHeight: ai.ledgerHeightOf(gossipMsg.GetLeadershipMsg().PkiId)}
 				} else {
 					return
 				}
@@ -131,6 +165,9 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
 		},
+		Priority:  ai.priority,
+		Unhealthy: !ai.healthCheck(),
+		Weighted:  true,
 	}
 
 	msg := &proto.GossipMessage{
@@ -139,15 +176,42 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 		Content: &proto.GossipMessage_LeadershipMsg{LeadershipMsg: leadershipMsg},
 		Channel: ai.channel,
 	}
-	return &msgImpl{msg}
+	return &msgImpl{msg: msg, ledgerHeight: ai.selfLedgerHeight()}
+}
+
+// ledgerHeightOf returns the ledger height of the given peer in the channel,
+// as gossiped in its StateInfo message, or 0 if it isn't known
+func (ai *adapterImpl) ledgerHeightOf(pkiID common.PKIidType) uint64 {
+	for _, member := range ai.gossip.PeersOfChannel(ai.channel) {
+		if bytes.Equal(member.PKIid, pkiID) && member.Properties != nil {
+			return member.Properties.LedgerHeight
+		}
+	}
+	return 0
+}
+
+// selfLedgerHeight returns the ledger height of this peer in the channel,
+// as gossiped in its StateInfo message, or 0 if it isn't known
+func (ai *adapterImpl) selfLedgerHeight() uint64 {
+	stateInfo := ai.gossip.SelfChannelInfo(ai.channel)
+	if stateInfo == nil || stateInfo.GetStateInfo().GetProperties() == nil {
+		return 0
+	}
+	return stateInfo.GetStateInfo().GetProperties().LedgerHeight
 }
 
 func (ai *adapterImpl) Peers() []Peer {
 	peers := ai.gossip.Peers()
+	heights := make(map[string]uint64)
+	for _, member := range ai.gossip.PeersOfChannel(ai.channel) {
+		if member.Properties != nil {
+			heights[string(member.PKIid)] = member.Properties.LedgerHeight
+		}
+	}
 
 	var res []Peer
 	for _, peer := range peers {
-		res = append(res, &peerImpl{peer})
+		res = append(res, &peerImpl{member: peer, ledgerHeight: heights[string(peer.PKIid)]})
 	}
 
 	return res
@@ -18,6 +18,7 @@ import (
 	"github.com/sinochem-tech/fabric/gossip/discovery"
 	"github.com/sinochem-tech/fabric/gossip/util"
 	proto "github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -35,7 +36,7 @@ func TestNewAdapter(t *testing.T) {
 	peersCluster := newClusterOfPeers("0")
 	peersCluster.addPeer("peer0", mockGossip)
 
-	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"))
+	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"), nil)
 }
 
 func TestAdapterImpl_CreateMessage(t *testing.T) {
@@ -46,7 +47,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	}
 	mockGossip := newGossip("peer0", selfNetworkMember)
 
-	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"))
+	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"), nil)
 	msg := adapter.CreateMessage(true)
 
 	if !msg.(*msgImpl).msg.IsLeadershipMsg() {
@@ -68,6 +69,41 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	}
 }
 
+func TestAdapterImpl_Weight(t *testing.T) {
+	SetPriority(3)
+	defer SetPriority(0)
+
+	_, adapters := createCluster(0, 1)
+	adapters["Peer0"].gossip.(*peerMockGossip).member.Properties = &proto.Properties{LedgerHeight: 10}
+	adapters["Peer1"].gossip.(*peerMockGossip).member.Properties = &proto.Properties{LedgerHeight: 20}
+	adapters["Peer1"].healthCheck = func() bool { return false }
+
+	// The weight of this peer is determined by its configuration, health and StateInfo
+	msg := adapters["Peer0"].CreateMessage(false)
+	assert.Equal(t, Weight{Healthy: true, Priority: 3, LedgerHeight: 10}, msg.SenderWeight())
+
+	// The weight of remote peers is determined by their messages, and their StateInfo messages
+	msgCh := adapters["Peer0"].Accept()
+	adapters["Peer1"].Gossip(adapters["Peer1"].CreateMessage(true))
+	select {
+	case msg := <-msgCh:
+		assert.Equal(t, Weight{Healthy: false, Priority: 3, LedgerHeight: 20}, msg.SenderWeight())
+	case <-time.After(time.Second):
+		t.Fatal("Didn't receive a leadership message")
+	}
+
+	// Peers of older versions don't advertise their weight
+	legacyMsg := adapters["Peer1"].CreateMessage(true).(*msgImpl)
+	legacyMsg.msg.GetLeadershipMsg().Weighted = false
+	adapters["Peer1"].Gossip(legacyMsg)
+	select {
+	case msg := <-msgCh:
+		assert.True(t, msg.SenderWeight().Legacy)
+	case <-time.After(time.Second):
+		t.Fatal("Didn't receive a leadership message")
+	}
+}
+
 func TestAdapterImpl_Peers(t *testing.T) {
 	_, adapters := createCluster(0, 1, 2, 3, 4, 5)
 
@@ -92,6 +128,15 @@ func TestAdapterImpl_Peers(t *testing.T) {
 		}
 	}
 
+	// The ledger heights of the peers are the ones of their StateInfo messages
+	adapters["Peer3"].gossip.(*peerMockGossip).member.Properties = &proto.Properties{LedgerHeight: 10}
+	for _, peer := range adapters["Peer0"].Peers() {
+		if string(peer.ID()) == string([]byte{3}) {
+			assert.Equal(t, uint64(10), peer.LedgerHeight())
+		} else {
+			assert.Equal(t, uint64(0), peer.LedgerHeight())
+		}
+	}
 }
 
 func TestAdapterImpl_Stop(t *testing.T) {
@@ -189,6 +234,23 @@ func (g *peerMockGossip) Peers() []discovery.NetworkMember {
 	return res
 }
 
+func (g *peerMockGossip) PeersOfChannel(channel common.ChainID) []discovery.NetworkMember {
+	return g.Peers()
+}
+
+func (g *peerMockGossip) SelfChannelInfo(channel common.ChainID) *proto.SignedGossipMessage {
+	if g.member.Properties == nil {
+		return nil
+	}
+	return &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Content: &proto.GossipMessage_StateInfo{
+				StateInfo: &proto.StateInfo{Properties: g.member.Properties},
+			},
+		},
+	}
+}
+
 func (g *peerMockGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
 	ch := make(chan *proto.GossipMessage, 100)
 	g.acceptorLock.Lock()
@@ -281,7 +343,7 @@ func createCluster(peers ...int) (*clusterOfPeers, map[string]*adapterImpl) {
 		}
 
 		mockGossip := newGossip(peerEndpoint, peerMember)
-		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"))
+		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"), nil)
 		adapters[peerEndpoint] = adapter.(*adapterImpl)
 		cluster.addPeer(peerEndpoint, mockGossip)
 	}
@@ -19,7 +19,7 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing weights, and then IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -27,6 +27,13 @@ import (
 //   is the number of network partitions, but when the partition heals,
 //   only 1 leader should be left eventually
 // - Peers communicate by gossiping leadership proposal or declaration messages
+// - A peer's weight is determined by its health, its configured priority and
+//   whether its ledger height (as gossiped in StateInfo messages) lags behind
+//   the highest ledger height among the alive peers. A peer with a better
+//   weight takes over the leadership from the current leader
+// - Peers of older versions don't advertise their weight and only compare IDs,
+//   so as long as such a peer is alive, weights are ignored and all peers
+//   compare IDs only, in order for them to agree on the leader
 
 // The Algorithm, in pseudo code:
 //
@@ -51,9 +58,12 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
-//			become a follower
+// 			a better candidate, become a follower
+//			If a leadership proposal was received from
+//			a peer with a better weight, become a follower
 //		Else, you're a follower:
+//			If your weight is better than the leader's weight:
+//				Gossip leadership proposal message
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
@@ -64,9 +74,14 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A candidate is better than another if it has a better weight,
+// or if their weights are equal and its ID is lower.
+// Weights are not compared while a peer that doesn't advertise
+// its weight is alive.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -86,6 +101,9 @@ type LeaderElectionAdapter interface {
 
 type leadershipCallback func(isLeader bool)
 
+// HealthCheck returns whether the peer is fit to be the leader
+type HealthCheck func() bool
+
 // LeaderElectionService is the object that runs the leader election algorithm
 type LeaderElectionService interface {
 	// IsLeader returns whether this peer is a leader or not
@@ -105,18 +123,103 @@ type peerID []byte
 type Peer interface {
 	// ID returns the ID of the peer
 	ID() peerID
+	// LedgerHeight returns the height of the peer's ledger, or 0 if it is unknown
+	LedgerHeight() uint64
 }
 
 // Msg describes a message sent from a remote peer
 type Msg interface {
 	// SenderID returns the ID of the peer sent the message
 	SenderID() peerID
+	// SenderWeight returns the weight of the peer sent the message
+	SenderWeight() Weight
 	// IsProposal returns whether this message is a leadership proposal
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
 }
 
+// Weight describes how suitable a peer is for being the leader
+type Weight struct {
+	// Healthy indicates whether the peer considers itself fit to be the leader
+	Healthy bool
+	// Priority is the configured leadership priority of the peer,
+	// peers with a higher priority are preferred
+	Priority uint32
+	// LedgerHeight is the height of the peer's ledger, or 0 if it is unknown
+	LedgerHeight uint64
+	// Legacy indicates the peer doesn't advertise its weight, as peers of older
+	// versions, which ignore weights and only compare IDs
+	Legacy bool
+}
+
+// compareWeights returns a positive number if a is more suitable for being the leader than b,
+// a negative number if b is more suitable, and 0 if both are equally suitable
+// or if any of them doesn't advertise its weight
+func compareWeights(a, b Weight, maxHeight uint64) int {
+	if a.Legacy || b.Legacy {
+		return 0
+	}
+	if a.Healthy != b.Healthy {
+		if a.Healthy {
+			return 1
+		}
+		return -1
+	}
+	if a.Priority != b.Priority {
+		if a.Priority > b.Priority {
+			return 1
+		}
+		return -1
+	}
+	aLags, bLags := a.lagsBehind(maxHeight), b.lagsBehind(maxHeight)
+	if aLags != bLags {
+		if bLags {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// lagsBehind returns whether the ledger height of the peer lags behind
+// the given height by more than the configured threshold
+func (w Weight) lagsBehind(height uint64) bool {
+	if w.LedgerHeight == 0 {
+		// The ledger height isn't known yet, don't penalize the peer for that
+		return false
+	}
+	return height > w.LedgerHeight+getLedgerHeightLagThreshold()
+}
+
+// candidate is a peer that competes for the leadership
+type candidate struct {
+	id     peerID
+	weight Weight
+}
+
+// isBetterThan returns whether c is a better candidate for being the leader than the given candidate,
+// weights are only compared if useWeights is true, otherwise only the IDs are
+func (c candidate) isBetterThan(other candidate, maxHeight uint64, useWeights bool) bool {
+	if !useWeights {
+		return bytes.Compare(c.id, other.id) < 0
+	}
+	if cmp := compareWeights(c.weight, other.weight, maxHeight); cmp != 0 {
+		return cmp > 0
+	}
+	return bytes.Compare(c.id, other.id) < 0
+}
+
+func maxLedgerHeight(weights ...Weight) uint64 {
+	var max uint64
+	for _, w := range weights {
+		if w.LedgerHeight > max {
+			max = w.LedgerHeight
+		}
+	}
+	return max
+}
+
 func noopCallback(_ bool) {
 }
 
@@ -127,7 +230,8 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
-		proposals:     util.NewSet(),
+		proposals:     make(map[string]Weight),
+		legacyPeers:   make(map[string]struct{}),
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
@@ -145,8 +249,16 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 
 // leaderElectionSvcImpl is an implementation of a LeaderElectionService
 type leaderElectionSvcImpl struct {
-	id        peerID
-	proposals *util.Set
+	id peerID
+	// proposals maps the IDs of the peers that proposed
+	// themselves as leaders to their weights
+	proposals map[string]Weight
+	// weight is the weight of this peer, as sent in its latest message
+	weight Weight
+	// legacyPeers are the IDs of the peers that sent messages without their weight
+	legacyPeers map[string]struct{}
+	// declaredLeader is the latest peer that declared itself as the leader
+	declaredLeader *candidate
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -198,14 +310,28 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	le.Lock()
 	defer le.Unlock()
 
+	sender := candidate{id: msg.SenderID(), weight: msg.SenderWeight()}
+	self := candidate{id: le.id, weight: le.weight}
+	maxHeight := le.referenceHeight(sender.weight)
+	if sender.weight.Legacy {
+		le.legacyPeers[string(sender.id)] = struct{}{}
+	}
+	useWeights := le.useWeights()
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals[string(sender.id)] = sender.weight
+		// A peer with a better weight is proposing itself, hand over the leadership to it
+		if le.IsLeader() && useWeights && compareWeights(sender.weight, self.weight, maxHeight) > 0 {
+			le.logger.Info(le.id, ": Handing over the leadership to", sender.id, "which has a better weight")
+			le.stopBeingLeader()
+			atomic.StoreInt32(&le.leaderExists, int32(0))
+		}
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
+		le.declaredLeader = &sender
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if sender.isBetterThan(self, maxHeight, useWeights) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -286,9 +412,19 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	}
 	// Leader doesn't exist, let's see if there is a better candidate than us
 	// for being a leader
-	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+	le.Lock()
+	self := candidate{id: le.id, weight: le.weight}
+	proposals := make([]candidate, 0, len(le.proposals))
+	weights := []Weight{self.weight}
+	for id, weight := range le.proposals {
+		proposals = append(proposals, candidate{id: peerID(id), weight: weight})
+		weights = append(weights, weight)
+	}
+	useWeights := le.useWeights()
+	maxHeight := le.referenceHeight(weights...)
+	le.Unlock()
+	for _, proposal := range proposals {
+		if proposal.isBetterThan(self, maxHeight, useWeights) {
 			return
 		}
 	}
@@ -302,7 +438,38 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 func (le *leaderElectionSvcImpl) propose() {
 	le.logger.Debug(le.id, ": Entering")
 	le.logger.Debug(le.id, ": Exiting")
-	leadershipProposal := le.adapter.CreateMessage(false)
+	leadershipProposal := le.createMessage(false)
+	le.adapter.Gossip(leadershipProposal)
+}
+
+// createMessage creates a leadership message, and records the weight of this peer it carries
+func (le *leaderElectionSvcImpl) createMessage(isDeclaration bool) Msg {
+	msg := le.adapter.CreateMessage(isDeclaration)
+	le.Lock()
+	le.weight = msg.SenderWeight()
+	le.Unlock()
+	return msg
+}
+
+// challengeLeader proposes this peer as the leader
+// if its weight is better than the weight of the current leader
+func (le *leaderElectionSvcImpl) challengeLeader() {
+	le.Lock()
+	leader := le.declaredLeader
+	useWeights := le.useWeights()
+	le.Unlock()
+	if leader == nil || !useWeights || !le.isLeaderExists() || le.isYielding() {
+		return
+	}
+	leadershipProposal := le.createMessage(false)
+	weight := leadershipProposal.SenderWeight()
+	le.Lock()
+	maxHeight := le.referenceHeight(weight, leader.weight)
+	le.Unlock()
+	if compareWeights(weight, leader.weight, maxHeight) <= 0 {
+		return
+	}
+	le.logger.Info(le.id, ": Challenging the leadership of", leader.id, "since we have a better weight")
 	le.adapter.Gossip(leadershipProposal)
 }
 
@@ -310,7 +477,11 @@ func (le *leaderElectionSvcImpl) follower() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
 
-	le.proposals.Clear()
+	le.challengeLeader()
+	le.Lock()
+	le.proposals = make(map[string]Weight)
+	le.declaredLeader = nil
+	le.Unlock()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
@@ -320,11 +491,40 @@ func (le *leaderElectionSvcImpl) follower() {
 }
 
 func (le *leaderElectionSvcImpl) leader() {
-	leaderDeclaration := le.adapter.CreateMessage(true)
+	leaderDeclaration := le.createMessage(true)
 	le.adapter.Gossip(leaderDeclaration)
 	le.waitForInterrupt(getLeadershipDeclarationInterval())
 }
 
+// useWeights returns whether weights are compared when electing the leader,
+// which isn't the case as long as a peer that doesn't advertise its weight is alive,
+// since such a peer only compares IDs and wouldn't agree with us on the leader otherwise.
+// Must be called while holding the lock.
+func (le *leaderElectionSvcImpl) useWeights() bool {
+	for id := range le.legacyPeers {
+		if le.isAlive(peerID(id)) {
+			return false
+		}
+		delete(le.legacyPeers, id)
+	}
+	return true
+}
+
+// referenceHeight returns the height that the ledger heights of the candidates
+// are compared against, which is the highest ledger height among this peer,
+// the alive peers and the given weights. All the candidates of a decision are
+// compared against the same height, otherwise the comparison isn't transitive.
+// Must be called while holding the lock.
+func (le *leaderElectionSvcImpl) referenceHeight(weights ...Weight) uint64 {
+	height := maxLedgerHeight(append(weights, le.weight)...)
+	for _, p := range le.adapter.Peers() {
+		if p.LedgerHeight() > height {
+			height = p.LedgerHeight()
+		}
+	}
+	return height
+}
+
 // waitForMembershipStabilization waits for membership view to stabilize
 // or until a time limit expires, or until a peer declares itself as a leader
 func (le *leaderElectionSvcImpl) waitForMembershipStabilization(timeLimit time.Duration) {
@@ -451,6 +651,17 @@ func SetLeaderElectionDuration(t time.Duration) {
 	viper.Set("peer.gossip.election.leaderElectionDuration", t)
 }
 
+// SetPriority configures the leadership priority of the peer
+func SetPriority(priority uint32) {
+	viper.Set("peer.gossip.election.priority", priority)
+}
+
+// SetLedgerHeightLagThreshold configures the number of blocks a peer's ledger
+// may lag behind the other candidates without being considered lagging
+func SetLedgerHeightLagThreshold(threshold uint64) {
+	viper.Set("peer.gossip.election.ledgerHeightLagThreshold", threshold)
+}
+
 func getStartupGracePeriod() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", time.Second*15)
 }
@@ -471,6 +682,17 @@ func getLeaderElectionDuration() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", time.Second*5)
 }
 
+func getPriority() uint32 {
+	return uint32(viper.GetInt("peer.gossip.election.priority"))
+}
+
+func getLedgerHeightLagThreshold() uint64 {
+	if !viper.IsSet("peer.gossip.election.ledgerHeightLagThreshold") {
+		return 10
+	}
+	return uint64(viper.GetInt("peer.gossip.election.ledgerHeightLagThreshold"))
+}
+
 // GetMsgExpirationTimeout return leadership message expiration timeout
 func GetMsgExpirationTimeout() time.Duration {
 	return getLeaderAliveThreshold() * 10
@@ -37,12 +37,17 @@ func init() {
 type msg struct {
 	sender   string
 	proposal bool
+	weight   Weight
 }
 
 func (m *msg) SenderID() peerID {
 	return peerID(m.sender)
 }
 
+func (m *msg) SenderWeight() Weight {
+	return m.weight
+}
+
 func (m *msg) IsProposal() bool {
 	return m.proposal
 }
@@ -60,6 +65,7 @@ type peer struct {
 	msgChan            chan Msg
 	leaderFromCallback bool
 	callbackInvoked    bool
+	weight             Weight
 	lock               sync.RWMutex
 	LeaderElectionService
 }
@@ -75,6 +81,10 @@ func (p *peer) ID() peerID {
 	return peerID(p.id)
 }
 
+func (p *peer) LedgerHeight() uint64 {
+	return p.getWeight().LedgerHeight
+}
+
 func (p *peer) Gossip(m Msg) {
 	p.sharedLock.RLock()
 	defer p.sharedLock.RUnlock()
@@ -104,7 +114,19 @@ func (p *peer) Accept() <-chan Msg {
 }
 
 func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+	return &msg{proposal: !isDeclaration, sender: p.id, weight: p.getWeight()}
+}
+
+func (p *peer) getWeight() Weight {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.weight
+}
+
+func (p *peer) setWeight(weight Weight) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.weight = weight
 }
 
 func (p *peer) Peers() []Peer {
@@ -117,8 +139,8 @@ func (p *peer) Peers() []Peer {
 	}
 
 	var peers []Peer
-	for id := range p.peers {
-		peers = append(peers, &peer{id: id})
+	for id, pr := range p.peers {
+		peers = append(peers, &peer{id: id, weight: pr.getWeight()})
 	}
 	return peers
 }
@@ -156,10 +178,28 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
+func createWeightedPeers(weights map[int]Weight, ids ...int) []*peer {
+	peers := make([]*peer, len(ids))
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	for i, id := range ids {
+		weight, exists := weights[id]
+		if !exists {
+			weight = Weight{Healthy: true}
+		}
+		peers[i] = createWeightedPeer(id, weight, peerMap, l)
+	}
+	return peers
+}
+
 func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+	return createWeightedPeer(id, Weight{Healthy: true}, peerMap, l)
+}
+
+func createWeightedPeer(id int, weight Weight, peerMap map[string]*peer, l *sync.RWMutex) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false, weight: weight}
 	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
 	l.Lock()
 	peerMap[idStr] = p
@@ -417,6 +457,154 @@ func TestPartition(t *testing.T) {
 
 }
 
+func TestElectionPrefersHealthyPeers(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers are spawned at the same time, and the peer with the lowest ID is unhealthy
+	// expected outcome: the healthy peer with the lowest ID is the leader
+	peers := createWeightedPeers(map[int]Weight{0: {Healthy: false}}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p1"}, leaders)
+}
+
+func TestElectionPrefersHigherPriority(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers are spawned at the same time, and a peer has a higher priority than the rest
+	// expected outcome: the peer with the higher priority is the leader
+	peers := createWeightedPeers(map[int]Weight{2: {Healthy: true, Priority: 1}}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestElectionAvoidsLaggingPeers(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers are spawned at the same time, and the ledger of p0 lags behind
+	// the others by more than the threshold, while p1 lags by exactly the threshold
+	// expected outcome: p1 is the leader, as it is the peer with the lowest ID which doesn't lag behind
+	lagThreshold := getLedgerHeightLagThreshold()
+	peers := createWeightedPeers(map[int]Weight{
+		0: {Healthy: true, LedgerHeight: 100},
+		1: {Healthy: true, LedgerHeight: 101},
+		2: {Healthy: true, LedgerHeight: 101 + lagThreshold},
+		3: {Healthy: true, LedgerHeight: 101 + lagThreshold},
+	}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p1"}, leaders)
+}
+
+func TestLaggingLeaderStepsDown(t *testing.T) {
+	t.Parallel()
+	// Scenario: p0, p1 and p2 have the ledger heights 100, 105 and 115 with a lag threshold of 10,
+	// and p1 declares itself as the leader while p0 is the leader
+	// expected outcome: p0 steps down, as it lags behind p2 and hence p1 is a better candidate,
+	// even though p0 doesn't lag behind p1 and has a lower ID
+	assert.Equal(t, uint64(10), getLedgerHeightLagThreshold())
+	p0 := Weight{Healthy: true, LedgerHeight: 100}
+	p1 := Weight{Healthy: true, LedgerHeight: 105}
+	p2 := Weight{Healthy: true, LedgerHeight: 115}
+	newLeader := func(alivePeers ...Peer) *leaderElectionSvcImpl {
+		adapter := &peer{id: "p0", sharedLock: &sync.RWMutex{}, mockedMethods: make(map[string]struct{})}
+		adapter.On("Peers").Return(alivePeers)
+		le := &leaderElectionSvcImpl{
+			id:            peerID("p0"),
+			weight:        p0,
+			proposals:     make(map[string]Weight),
+			legacyPeers:   make(map[string]struct{}),
+			adapter:       adapter,
+			interruptChan: make(chan struct{}, 1),
+			logger:        util.GetLogger(util.LoggingElectionModule, ""),
+			callback:      noopCallback,
+		}
+		le.beLeader()
+		return le
+	}
+
+	le := newLeader(&peer{id: "p1", weight: p1}, &peer{id: "p2", weight: p2})
+	le.handleMessage(&msg{sender: "p1", weight: p1})
+	assert.False(t, le.IsLeader())
+
+	// Unless p2 isn't alive
+	le = newLeader(&peer{id: "p1", weight: p1})
+	le.handleMessage(&msg{sender: "p1", weight: p1})
+	assert.True(t, le.IsLeader())
+}
+
+func TestElectionWithLegacyPeers(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers are spawned at the same time, p2 has a higher priority than the rest
+	// but p3 doesn't advertise its weight, as peers of older versions
+	// expected outcome: weights are ignored and the peer with the lowest ID is the leader
+	peers := createWeightedPeers(map[int]Weight{
+		2: {Healthy: true, Priority: 1},
+		3: {Legacy: true},
+	}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+
+	// p2 doesn't challenge the leadership of p0 while p3 is alive
+	time.Sleep(getLeaderAliveThreshold() * 3)
+	leaders = waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+}
+
+func TestLeadershipHandoff(t *testing.T) {
+	t.Parallel()
+	// Scenario: peers are spawned at the same time and p0 is elected,
+	// afterwards p2's priority is raised, and later on p0 becomes unhealthy
+	// expected outcome: p0 hands over the leadership to p2,
+	// and no hand-off occurs to a peer with a worse weight
+	peers := createPeers(0, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+
+	peers[1].setWeight(Weight{Healthy: true, Priority: 1})
+	waitForBoolFunc(t, func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p2"
+	}, true, "p2 didn't take over the leadership")
+	waitForBoolFunc(t, peers[3].isLeaderFromCallback, false, "Leadership callback result is wrong for p0")
+	waitForBoolFunc(t, peers[1].isLeaderFromCallback, true, "Leadership callback result is wrong for p2")
+
+	// p0 becoming unhealthy doesn't make it a better candidate
+	peers[3].setWeight(Weight{Healthy: false})
+	time.Sleep(getLeaderAliveThreshold() * 3)
+	leaders = waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestCompareWeights(t *testing.T) {
+	lagThreshold := getLedgerHeightLagThreshold()
+	for _, testCase := range []struct {
+		name     string
+		a, b     Weight
+		expected int
+	}{
+		{name: "equal", a: Weight{Healthy: true}, b: Weight{Healthy: true}, expected: 0},
+		{name: "healthy", a: Weight{Healthy: true}, b: Weight{Healthy: false, Priority: 10}, expected: 1},
+		{name: "priority", a: Weight{Healthy: true, Priority: 1}, b: Weight{Healthy: true, Priority: 2}, expected: -1},
+		{name: "lagging", a: Weight{Healthy: true, LedgerHeight: 1}, b: Weight{Healthy: true, LedgerHeight: 2 + lagThreshold}, expected: -1},
+		{name: "within threshold", a: Weight{Healthy: true, LedgerHeight: 2}, b: Weight{Healthy: true, LedgerHeight: 2 + lagThreshold}, expected: 0},
+		{name: "unknown height", a: Weight{Healthy: true}, b: Weight{Healthy: true, LedgerHeight: 100 + lagThreshold}, expected: 0},
+		{name: "legacy", a: Weight{Healthy: true, Priority: 1}, b: Weight{Legacy: true}, expected: 0},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			maxHeight := maxLedgerHeight(testCase.a, testCase.b)
+			assert.Equal(t, testCase.expected, compareWeights(testCase.a, testCase.b, maxHeight))
+			assert.Equal(t, -testCase.expected, compareWeights(testCase.b, testCase.a, maxHeight))
+		})
+	}
+
+	// Candidates with equal weights are compared by their IDs
+	a := candidate{id: peerID("p0"), weight: Weight{Healthy: true}}
+	b := candidate{id: peerID("p1"), weight: Weight{Healthy: true}}
+	assert.True(t, a.isBetterThan(b, 0, true))
+	assert.False(t, b.isBetterThan(a, 0, true))
+	b.weight.Priority = 1
+	assert.True(t, b.isBetterThan(a, 0, true))
+	// Unless weights are ignored
+	assert.True(t, a.isBetterThan(b, 0, false))
+	assert.False(t, b.isBetterThan(a, 0, false))
+}
+
 func TestConfigFromFile(t *testing.T) {
 	preStartupGracePeriod := getStartupGracePeriod()
 	preMembershipSampleInterval := getMembershipSampleInterval()
@@ -438,6 +626,8 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, uint32(0), getPriority())
+	assert.Equal(t, uint64(10), getLedgerHeightLagThreshold())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -453,6 +643,8 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, uint32(0), getPriority())
+	assert.Equal(t, uint64(10), getLedgerHeightLagThreshold())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
@@ -40,6 +40,7 @@ type Config struct {
 	RequestStateInfoInterval    time.Duration
 	BlockExpirationInterval     time.Duration
 	StateInfoCacheSweepInterval time.Duration
+	Pinning                     *comm.Pinning
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -66,6 +67,10 @@ type GossipChannel interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode)
 
+	// UpdateLoadHints updates the load hints the peer publishes
+	// to other peers in the channel
+	UpdateLoadHints(loadHints *proto.LoadHints)
+
 	// IsOrgInChannel returns whether the given organization is in the channel
 	IsOrgInChannel(membersOrg api.OrgIdentityType) bool
 
@@ -86,6 +91,10 @@ type GossipChannel interface {
 	// LeaveChannel makes the peer leave the channel
 	LeaveChannel()
 
+	// UpdatePKIid updates the PKI-ID the peer publishes
+	// to other peers in the channel
+	UpdatePKIid(pkiID common.PKIidType)
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -134,6 +143,7 @@ type gossipChannel struct {
 	shouldGossipStateInfo     int32
 	mcs                       api.MessageCryptoService
 	pkiID                     common.PKIidType
+	pkiIDLock                 sync.RWMutex
 	selfOrg                   api.OrgIdentityType
 	stopChan                  chan struct{}
 	stateInfoMsg              *proto.SignedGossipMessage
@@ -151,6 +161,7 @@ type gossipChannel struct {
 	ledgerHeight              uint64
 	incTime                   uint64
 	leftChannel               int32
+	pinning                   *comm.Pinning
 }
 
 type membershipFilter struct {
@@ -188,6 +199,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 		stateInfoRequestScheduler: time.NewTicker(adapter.GetConf().RequestStateInfoInterval),
 		orgs:    []api.OrgIdentityType{},
 		chainID: chainID,
+		pinning: adapter.GetConf().Pinning,
 	}
 
 	gc.memFilter = &membershipFilter{adapter: gc.Adapter, gossipChannel: gc}
@@ -212,7 +224,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 	verifyStateInfoMsg := func(msg *proto.SignedGossipMessage, orgs ...api.OrgIdentityType) bool {
 		si := msg.GetStateInfo()
 		// No point in verifying ourselves
-		if bytes.Equal(gc.pkiID, si.PkiId) {
+		if bytes.Equal(gc.selfPKIid(), si.PkiId) {
 			return true
 		}
 		peerIdentity := adapter.GetIdentityByPKIID(si.PkiId)
@@ -306,11 +318,13 @@ func (gc *gossipChannel) LeaveChannel() {
 
 	var chaincodes []*proto.Chaincode
 	var height uint64
+	var loadHints *proto.LoadHints
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 		height = prevMsg.GetStateInfo().Properties.LedgerHeight
+		loadHints = prevMsg.GetStateInfo().Properties.LoadHints
 	}
-	gc.updateProperties(height, chaincodes, true)
+	gc.updateProperties(height, chaincodes, loadHints, true)
 }
 
 func (gc *gossipChannel) hasLeftChannel() bool {
@@ -429,7 +443,21 @@ func (gc *gossipChannel) IsMemberInChan(member discovery.NetworkMember) bool {
 		return false
 	}
 
-	return gc.IsOrgInChannel(org)
+	return gc.IsOrgInChannel(org) && gc.admittedByPinning(member, org)
+}
+
+// admittedByPinning returns whether the pinning policy of the
+// channel admits the given member of the given organization
+func (gc *gossipChannel) admittedByPinning(member discovery.NetworkMember, org api.OrgIdentityType) bool {
+	if gc.pinning == nil {
+		return true
+	}
+	peer := gc.pinning.PinnedPeer(string(org), member.PKIid)
+	if err := gc.pinning.VerifyInChannel(string(gc.chainID), peer); err != nil {
+		gc.logger.Debug("Peer", member.PKIid, "is not admitted in the channel:", err)
+		return false
+	}
+	return true
 }
 
 // PeerFilter receives a SubChannelSelectionCriteria and returns a RoutingFilter that selects
@@ -477,7 +505,7 @@ func (gc *gossipChannel) EligibleForChannel(member discovery.NetworkMember) bool
 	if msg == nil {
 		return false
 	}
-	return true
+	return gc.admittedByPinning(member, gc.GetOrgOfPeer(member.PKIid))
 }
 
 // AddToMsgStore adds a given GossipMessage to the message store
@@ -538,6 +566,17 @@ func (gc *gossipChannel) HandleMessage(msg proto.ReceivedMessage) {
 			", org(", string(orgID), ") but it's not eligible for the channel", string(gc.chainID))
 		return
 	}
+	if gc.pinning != nil {
+		member := gc.Lookup(msg.GetConnectionInfo().ID)
+		if member == nil {
+			member = &discovery.NetworkMember{PKIid: msg.GetConnectionInfo().ID}
+		}
+		if !gc.admittedByPinning(*member, orgID) {
+			gc.logger.Warning("Point to point message came from", msg.GetConnectionInfo(),
+				"but the pinning policy of the channel", string(gc.chainID), "does not admit it")
+			return
+		}
+	}
 
 	if m.IsStateInfoPullRequestMsg() {
 		msg.Respond(gc.createStateInfoSnapshot(orgID))
@@ -795,7 +834,7 @@ func (gc *gossipChannel) createStateInfoRequest() (*proto.SignedGossipMessage, e
 		Nonce: 0,
 		Content: &proto.GossipMessage_StateInfoPullReq{
 			StateInfoPullReq: &proto.StateInfoPullRequest{
-				Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
+				Channel_MAC: GenerateMAC(gc.selfPKIid(), gc.chainID),
 			},
 		},
 	}).NoopSign()
@@ -808,12 +847,14 @@ func (gc *gossipChannel) UpdateLedgerHeight(height uint64) {
 	defer gc.Unlock()
 
 	var chaincodes []*proto.Chaincode
+	var loadHints *proto.LoadHints
 	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+		loadHints = prevMsg.GetStateInfo().Properties.LoadHints
 	}
-	gc.updateProperties(height, chaincodes, leftChannel)
+	gc.updateProperties(height, chaincodes, loadHints, leftChannel)
 }
 
 // UpdateChaincodes updates the chaincodes the peer publishes
@@ -823,12 +864,31 @@ func (gc *gossipChannel) UpdateChaincodes(chaincodes []*proto.Chaincode) {
 	defer gc.Unlock()
 
 	var ledgerHeight uint64 = 1
+	var loadHints *proto.LoadHints
 	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		loadHints = prevMsg.GetStateInfo().Properties.LoadHints
 	}
-	gc.updateProperties(ledgerHeight, chaincodes, leftChannel)
+	gc.updateProperties(ledgerHeight, chaincodes, loadHints, leftChannel)
+}
+
+// UpdateLoadHints updates the load hints the peer publishes
+// to other peers in the channel
+func (gc *gossipChannel) UpdateLoadHints(loadHints *proto.LoadHints) {
+	gc.Lock()
+	defer gc.Unlock()
+
+	var ledgerHeight uint64 = 1
+	var chaincodes []*proto.Chaincode
+	var leftChannel bool
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+	}
+	gc.updateProperties(ledgerHeight, chaincodes, loadHints, leftChannel)
 }
 
 // UpdateStateInfo updates this channel's StateInfo message
@@ -840,10 +900,33 @@ func (gc *gossipChannel) updateStateInfo(msg *proto.SignedGossipMessage) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
-func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool) {
+// UpdatePKIid updates the PKI-ID the peer publishes
+// to other peers in the channel
+func (gc *gossipChannel) UpdatePKIid(pkiID common.PKIidType) {
+	gc.Lock()
+	defer gc.Unlock()
+
+	gc.pkiIDLock.Lock()
+	gc.pkiID = pkiID
+	gc.pkiIDLock.Unlock()
+
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		props := prevMsg.GetStateInfo().Properties
+		gc.updateProperties(props.LedgerHeight, props.Chaincodes, props.LoadHints, props.LeftChannel)
+	}
+}
+
+func (gc *gossipChannel) selfPKIid() common.PKIidType {
+	gc.pkiIDLock.RLock()
+	defer gc.pkiIDLock.RUnlock()
+	return gc.pkiID
+}
+
+func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, loadHints *proto.LoadHints, leftChannel bool) {
+	pkiID := gc.selfPKIid()
 	stateInfMsg := &proto.StateInfo{
-		Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
-		PkiId:       gc.pkiID,
+		Channel_MAC: GenerateMAC(pkiID, gc.chainID),
+		PkiId:       pkiID,
 		Timestamp: &proto.PeerTime{
 			IncNum: gc.incTime,
 			SeqNum: uint64(time.Now().UnixNano()),
@@ -852,6 +935,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 			LeftChannel:  leftChannel,
 			LedgerHeight: ledgerHeight,
 			Chaincodes:   chaincodes,
+			LoadHints:    loadHints,
 		},
 	}
 	m := &proto.GossipMessage{
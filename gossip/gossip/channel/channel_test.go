@@ -8,6 +8,7 @@ package channel
 
 import (
 	"bytes"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"sync"
@@ -512,6 +513,59 @@ func TestChannelPeriodicalPublishStateInfo(t *testing.T) {
 	assert.Equal(t, ledgerHeight, int(msg.GetStateInfo().Properties.LedgerHeight))
 }
 
+func TestChannelUpdateLoadHints(t *testing.T) {
+	t.Parallel()
+	cs := &cryptoService{}
+	cs.On("VerifyBlock", mock.Anything).Return(nil)
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Send", mock.Anything, mock.Anything)
+	adapter.On("Gossip", mock.Anything)
+
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{})
+	defer gc.Stop()
+
+	chaincodes := []*proto.Chaincode{{Name: "cc", Version: "1.0"}}
+	loadHints := &proto.LoadHints{PendingProposals: 3, EndorsementLatency: 20}
+	gc.UpdateLedgerHeight(5)
+	gc.UpdateChaincodes(chaincodes)
+	gc.UpdateLoadHints(loadHints)
+	props := gc.Self().GetStateInfo().Properties
+	assert.Equal(t, uint64(5), props.LedgerHeight)
+	assert.Equal(t, chaincodes, props.Chaincodes)
+	assert.Equal(t, loadHints, props.LoadHints)
+
+	// The load hints are preserved when other properties are updated
+	gc.UpdateLedgerHeight(6)
+	gc.LeaveChannel()
+	props = gc.Self().GetStateInfo().Properties
+	assert.Equal(t, uint64(6), props.LedgerHeight)
+	assert.True(t, props.LeftChannel)
+	assert.Equal(t, loadHints, props.LoadHints)
+}
+
+func TestChannelUpdatePKIid(t *testing.T) {
+	t.Parallel()
+	cs := &cryptoService{}
+	cs.On("VerifyBlock", mock.Anything).Return(nil)
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Send", mock.Anything, mock.Anything)
+	adapter.On("Gossip", mock.Anything)
+
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{})
+	defer gc.Stop()
+
+	// The state info is published again with the new PKI-ID, and the same properties
+	gc.UpdateLedgerHeight(5)
+	rotatedPKIid := common.PKIidType("rotated")
+	gc.UpdatePKIid(rotatedPKIid)
+	stateInfo := gc.Self().GetStateInfo()
+	assert.Equal(t, []byte(rotatedPKIid), stateInfo.PkiId)
+	assert.Equal(t, GenerateMAC(rotatedPKIid, channelA), stateInfo.Channel_MAC)
+	assert.Equal(t, uint64(5), stateInfo.Properties.LedgerHeight)
+}
+
 func TestChannelMsgStoreEviction(t *testing.T) {
 	t.Parallel()
 	// Scenario: Create 4 phases in which the pull mediator of the channel would receive blocks
@@ -871,6 +925,51 @@ func TestChannelIsInChannel(t *testing.T) {
 	assert.False(t, gc.IsMemberInChan(discovery.NetworkMember{PKIid: pkiIDinOrg2}))
 }
 
+func TestChannelPinning(t *testing.T) {
+	t.Parallel()
+
+	pinning, err := comm.NewPinning(comm.PinningConfig{
+		Channels: []comm.ChannelPinningPolicy{
+			{
+				Channel: string(channelA),
+				PinningPolicy: comm.PinningPolicy{
+					Allow: comm.PinningRule{SANs: []string{"*.org1.example.com"}},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	pinnedConf := conf
+	pinnedConf.Pinning = pinning
+	pinning.BindCert(pkiIDInOrg1, &x509.Certificate{Raw: []byte{1}, DNSNames: []string{"peer0.org1.example.com"}})
+	pinning.BindCert(pkiIDInOrg1ButNotEligible, &x509.Certificate{Raw: []byte{2}, DNSNames: []string{"peer0.org3.example.com"}})
+
+	cs := &cryptoService{}
+	cs.On("VerifyBlock", mock.Anything).Return(nil)
+	adapter := new(gossipAdapterMock)
+	adapter.On("GetConf").Return(pinnedConf)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	adapter.On("Forward", mock.Anything)
+	adapter.On("Send", mock.Anything, mock.Anything)
+	adapter.On("DeMultiplex", mock.Anything)
+	adapter.On("Lookup", pkiIDInOrg1).Return(&discovery.NetworkMember{PKIid: pkiIDInOrg1, Endpoint: "peer0.org1.example.com:7051"})
+	adapter.On("Lookup", pkiIDInOrg1ButNotEligible).Return(&discovery.NetworkMember{PKIid: pkiIDInOrg1ButNotEligible, Endpoint: "peer0.org3.example.com:7051"})
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{})
+
+	// Members whose TLS certificates the pinning policy of the channel doesn't admit,
+	// or whose TLS certificates aren't known, aren't in the channel, whatever their endpoints
+	assert.True(t, gc.IsMemberInChan(discovery.NetworkMember{PKIid: pkiIDInOrg1, Endpoint: "peer0.org3.example.com:7051"}))
+	assert.False(t, gc.IsMemberInChan(discovery.NetworkMember{PKIid: pkiIDInOrg1ButNotEligible, Endpoint: "peer0.org1.example.com:7051"}))
+	assert.False(t, gc.IsMemberInChan(discovery.NetworkMember{PKIid: pkiIDinOrg2}))
+
+	// Nor are their messages about the channel accepted
+	gc.HandleMessage(&receivedMsg{msg: createStateInfoMsg(10, pkiIDInOrg1, channelA), PKIID: pkiIDInOrg1})
+	gc.HandleMessage(&receivedMsg{msg: createStateInfoMsg(10, pkiIDInOrg1ButNotEligible, channelA), PKIID: pkiIDInOrg1ButNotEligible})
+	assert.True(t, gc.EligibleForChannel(discovery.NetworkMember{PKIid: pkiIDInOrg1, Endpoint: "peer0.org1.example.com:7051"}))
+	assert.False(t, gc.EligibleForChannel(discovery.NetworkMember{PKIid: pkiIDInOrg1ButNotEligible}))
+}
+
 func TestChannelIsSubscribed(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +93,14 @@ func (cs *channelState) getGossipChannelByChainID(chainID common.ChainID) channe
 	return cs.channels[string(chainID)]
 }
 
+func (cs *channelState) updatePKIid(pkiID common.PKIidType) {
+	cs.RLock()
+	defer cs.RUnlock()
+	for _, gc := range cs.channels {
+		gc.UpdatePKIid(pkiID)
+	}
+}
+
 func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID common.ChainID) {
 	if cs.isStopping() {
 		return
@@ -124,6 +132,7 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		RequestStateInfoInterval:    ga.conf.RequestStateInfoInterval,
 		BlockExpirationInterval:     ga.conf.PullInterval * 100,
 		StateInfoCacheSweepInterval: ga.conf.PullInterval * 5,
+		Pinning:                     ga.conf.Pinning,
 	}
 }
 
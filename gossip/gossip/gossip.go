@@ -52,6 +52,10 @@ type Gossip interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID)
 
+	// UpdateLoadHints updates the load hints the peer publishes
+	// to other peers in the channel
+	UpdateLoadHints(loadHints *proto.LoadHints, chainID common.ChainID)
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -81,6 +85,13 @@ type Gossip interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// UpdateIdentity replaces the identity of the peer with the given one, which must be of
+	// the same organization and whose signing key must already be used by the peer.
+	// The new identity is announced to other peers, and connections are re-established with it.
+	// Since the PKI-ID of the peer is derived from its identity, the previous PKI-ID remains
+	// in the membership of remote peers until it expires, as the one of a dead peer.
+	UpdateIdentity(identity api.PeerIdentityType) error
+
 	// Stop stops the gossip component
 	Stop()
 }
@@ -133,4 +144,6 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	Pinning *comm.Pinning // Pinning policies remote peers are verified against, nil if there are none
 }
@@ -8,6 +8,7 @@ package gossip
 
 import (
 	"bytes"
+	"crypto/rand"
 	"fmt"
 	"reflect"
 	"sync"
@@ -39,6 +40,7 @@ type channelRoutingFilterFactory func(channel.GossipChannel) filter.RoutingFilte
 
 type gossipServiceImpl struct {
 	selfIdentity          api.PeerIdentityType
+	identityLock          sync.Mutex
 	includeIdentityPeriod time.Time
 	certStore             *certStore
 	idMapper              identity.Mapper
@@ -91,6 +93,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 	g.idMapper = identity.NewIdentityMapper(mcs, selfIdentity, func(pkiID common.PKIidType, identity api.PeerIdentityType) {
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 		g.certPuller.Remove(string(pkiID))
+		g.conf.Pinning.UnbindCert(pkiID)
 	}, secAdvisor)
 
 	if s == nil {
@@ -103,6 +106,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		lgr.Error("Failed instntiating communication layer:", err)
 		return nil
 	}
+	g.comm.SetPinning(conf.Pinning, secAdvisor)
 
 	g.chanState = newChannelState(g)
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
@@ -609,6 +613,47 @@ func (g *gossipServiceImpl) IdentityInfo() api.PeerIdentitySet {
 	return g.idMapper.IdentityInfo()
 }
 
+// UpdateIdentity replaces the identity of the peer with the given one
+func (g *gossipServiceImpl) UpdateIdentity(identity api.PeerIdentityType) error {
+	g.identityLock.Lock()
+	defer g.identityLock.Unlock()
+
+	if bytes.Equal(identity, g.selfIdentity) {
+		return nil
+	}
+	if org := g.secAdvisor.OrgByPeerIdentity(identity); !bytes.Equal(org, g.selfOrg) {
+		return errors.Errorf("identity is of organization %s, but the peer is of organization %s", string(org), string(g.selfOrg))
+	}
+	// Ensure the peer signs with the key of the identity, otherwise other peers
+	// wouldn't be able to verify the messages it sends
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return errors.Wrap(err, "failed generating a nonce")
+	}
+	signature, err := g.mcs.Sign(nonce)
+	if err != nil {
+		return errors.WithMessage(err, "failed signing")
+	}
+	if err := g.mcs.Verify(identity, signature, nonce); err != nil {
+		return errors.WithMessage(err, "the peer doesn't sign with the key of the identity")
+	}
+
+	if err := g.idMapper.UpdateSelfIdentity(identity); err != nil {
+		return err
+	}
+	if err := g.certStore.updateSelfIdentity(identity); err != nil {
+		return err
+	}
+	g.disSecAdap.updateIdentity(identity, g.conf.PublishCertPeriod)
+	pkiID := g.idMapper.GetPKIidOfCert(identity)
+	g.disc.UpdatePKIid(pkiID)
+	g.comm.UpdateIdentity(identity)
+	g.chanState.updatePKIid(pkiID)
+	g.selfIdentity = identity
+	g.logger.Info("Updated identity, PKI-ID is now", pkiID)
+	return nil
+}
+
 // SendByCriteria sends a given message to all peers that match the given SendCriteria
 func (g *gossipServiceImpl) SendByCriteria(msg *proto.SignedGossipMessage, criteria SendCriteria) error {
 	if criteria.MaxPeers == 0 {
@@ -802,6 +847,17 @@ func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chai
 	gc.UpdateChaincodes(chaincodes)
 }
 
+// UpdateLoadHints updates the load hints the peer publishes
+// to other peers in the channel
+func (g *gossipServiceImpl) UpdateLoadHints(loadHints *proto.LoadHints, chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Warning("No such channel", chainID)
+		return
+	}
+	gc.UpdateLoadHints(loadHints)
+}
+
 // Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
 // If passThrough is false, the messages are processed by the gossip layer beforehand.
 // If passThrough is true, the gossip layer doesn't intervene and the messages
@@ -975,6 +1031,7 @@ func (da *discoveryAdapter) CloseConn(peer *discovery.NetworkMember) {
 }
 
 type discoverySecurityAdapter struct {
+	sync.RWMutex
 	identity              api.PeerIdentityType
 	includeIdentityPeriod time.Time
 	idMapper              identity.Mapper
@@ -982,6 +1039,7 @@ type discoverySecurityAdapter struct {
 	mcs                   api.MessageCryptoService
 	c                     comm.Comm
 	logger                *logging.Logger
+	pinning               *comm.Pinning
 }
 
 func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
@@ -993,6 +1051,7 @@ func (g *gossipServiceImpl) newDiscoverySecurityAdapter() *discoverySecurityAdap
 		logger:                g.logger,
 		includeIdentityPeriod: g.includeIdentityPeriod,
 		identity:              g.selfIdentity,
+		pinning:               g.conf.Pinning,
 	}
 }
 
@@ -1027,7 +1086,23 @@ func (sa *discoverySecurityAdapter) ValidateAliveMsg(m *proto.SignedGossipMessag
 		return false
 	}
 
-	return sa.validateAliveMsgSignature(m, identity)
+	if !sa.validateAliveMsgSignature(m, identity) {
+		return false
+	}
+
+	// Keep out of the membership the peers that the pinning policies don't admit.
+	// The TLS certificate of a peer is only known once a connection with it has
+	// been established, and until then the handshake verifies the peer instead.
+	pkiID := common.PKIidType(am.Membership.PkiId)
+	if sa.pinning.RequiresCerts() && !sa.pinning.IsBound(pkiID) {
+		return true
+	}
+	peer := sa.pinning.PinnedPeer(string(sa.sa.OrgByPeerIdentity(identity)), pkiID)
+	if err := sa.pinning.Verify(peer); err != nil {
+		sa.logger.Warningf("Pinning policies refused %s : %v", am.Membership.Endpoint, err)
+		return false
+	}
+	return true
 }
 
 // SignMessage signs an AliveMessage and updates its signature field
@@ -1035,9 +1110,11 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 	signer := func(msg []byte) ([]byte, error) {
 		return sa.mcs.Sign(msg)
 	}
+	sa.RLock()
 	if m.IsAliveMsg() && time.Now().Before(sa.includeIdentityPeriod) {
 		m.GetAliveMsg().Identity = sa.identity
 	}
+	sa.RUnlock()
 	sMsg := &proto.SignedGossipMessage{
 		GossipMessage: m,
 	}
@@ -1058,6 +1135,15 @@ func (sa *discoverySecurityAdapter) SignMessage(m *proto.GossipMessage, internal
 	return e
 }
 
+// updateIdentity replaces the identity included in AliveMessages, and
+// includes it again for the given period
+func (sa *discoverySecurityAdapter) updateIdentity(identity api.PeerIdentityType, includeIdentityPeriod time.Duration) {
+	sa.Lock()
+	defer sa.Unlock()
+	sa.identity = identity
+	sa.includeIdentityPeriod = time.Now().Add(includeIdentityPeriod)
+}
+
 func (sa *discoverySecurityAdapter) validateAliveMsgSignature(m *proto.SignedGossipMessage, identity api.PeerIdentityType) bool {
 	am := m.GetAliveMsg()
 	// At this point we got the certificate of the peer, proceed to verifying the AliveMessage
@@ -47,6 +47,7 @@ var tests = []func(t *testing.T){
 	TestLeaveChannel,
 	//TestDisseminateAll2All: {},
 	TestIdentityExpiration,
+	TestUpdateIdentity,
 	TestSendByCriteria,
 	TestMultipleOrgEndpointLeakage,
 	TestConfidentiality,
@@ -1359,6 +1360,58 @@ func TestIdentityExpiration(t *testing.T) {
 	g5.Stop()
 }
 
+func TestUpdateIdentity(t *testing.T) {
+	t.Parallel()
+	defer testWG.Done()
+	// Scenario: spawn 3 peers and have the first replace its identity once they all know each other.
+	// The rest of the peers should eventually know it only by its new identity,
+	// and keep communicating with it.
+
+	portPrefix := 14610
+	g1 := newGossipInstance(portPrefix, 0, 100)
+	g2 := newGossipInstance(portPrefix, 1, 100, 0)
+	g3 := newGossipInstance(portPrefix, 2, 100, 0)
+	peers := []Gossip{g1, g2, g3}
+	defer stopPeers(peers)
+	waitUntilOrFail(t, checkPeersMembership(t, peers, 2))
+
+	oldPKIid := common.PKIidType(fmt.Sprintf("localhost:%d", portPrefix))
+	rotatedIdentity := api.PeerIdentityType(fmt.Sprintf("rotated-localhost:%d", portPrefix))
+	rotatedPKIid := common.PKIidType(rotatedIdentity)
+	assert.NoError(t, g1.UpdateIdentity(rotatedIdentity))
+	assert.Equal(t, rotatedPKIid, g1.(*gossipServiceImpl).comm.GetPKIid())
+	assert.Equal(t, rotatedPKIid, g1.(*gossipServiceImpl).disc.Self().PKIid)
+
+	knownByRotatedIdentityOnly := func() bool {
+		for _, p := range peers[1:] {
+			members := p.Peers()
+			if len(members) != 2 {
+				return false
+			}
+			var foundRotated bool
+			for _, member := range members {
+				if bytes.Equal(member.PKIid, oldPKIid) {
+					return false
+				}
+				if bytes.Equal(member.PKIid, rotatedPKIid) {
+					foundRotated = true
+				}
+			}
+			if !foundRotated {
+				return false
+			}
+		}
+		return true
+	}
+	waitUntilOrFail(t, knownByRotatedIdentityOnly)
+	for _, p := range peers[1:] {
+		identity, err := p.(*gossipServiceImpl).idMapper.Get(rotatedPKIid)
+		assert.NoError(t, err)
+		assert.Equal(t, rotatedIdentity, identity)
+	}
+	assert.Len(t, g1.Peers(), 2)
+}
+
 func TestEndedGoroutines(t *testing.T) {
 	t.Parallel()
 	testWG.Wait()
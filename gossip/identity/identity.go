@@ -50,6 +50,11 @@ type Mapper interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// UpdateSelfIdentity associates the given identity to its pkiID and
+	// makes it the identity of this peer. The previous identity of this peer
+	// is kept, and purged like any other once it is no longer used.
+	UpdateSelfIdentity(identity api.PeerIdentityType) error
+
 	// Stop stops all background computations of the Mapper
 	Stop()
 }
@@ -230,6 +235,19 @@ func (is *identityMapperImpl) IdentityInfo() api.PeerIdentitySet {
 	return res
 }
 
+// UpdateSelfIdentity associates the given identity to its pkiID and
+// makes it the identity of this peer
+func (is *identityMapperImpl) UpdateSelfIdentity(identity api.PeerIdentityType) error {
+	selfPKIID := is.mcs.GetPKIidOfCert(identity)
+	if err := is.Put(selfPKIID, identity); err != nil {
+		return errors.Wrap(err, "failed putting our own identity into the identity mapper")
+	}
+	is.Lock()
+	defer is.Unlock()
+	is.selfPKIID = string(selfPKIID)
+	return nil
+}
+
 func (is *identityMapperImpl) delete(pkiID common.PKIidType, identity api.PeerIdentityType) {
 	is.Lock()
 	defer is.Unlock()
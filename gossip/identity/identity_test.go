@@ -337,3 +337,34 @@ func TestIdentityInfo(t *testing.T) {
 		assert.Equal(t, strings.ToLower(org), string(pkiID[0]))
 	}
 }
+
+func TestUpdateSelfIdentity(t *testing.T) {
+	cs := &naiveCryptoService{revokedIdentities: map[string]struct{}{}}
+	cs.On("Expiration", mock.Anything).Return(time.Now().Add(time.Minute), nil)
+	oldSelf := api.PeerIdentityType("oldSelf")
+	newSelf := api.PeerIdentityType("newSelf")
+	revokedSelf := api.PeerIdentityType("revokedSelf")
+	cs.revokedIdentities[string(revokedSelf)] = struct{}{}
+	idStore := NewIdentityMapper(cs, oldSelf, noopPurgeTrigger, cs)
+	defer idStore.Stop()
+
+	// A revoked identity can't become our own identity
+	assert.Error(t, idStore.UpdateSelfIdentity(revokedSelf))
+	assert.NoError(t, idStore.UpdateSelfIdentity(newSelf))
+	id, err := idStore.Get(cs.GetPKIidOfCert(newSelf))
+	assert.NoError(t, err)
+	assert.Equal(t, newSelf, id)
+
+	// Our previous identity is purged once it is no longer used, but not our new one
+	usageThreshold := GetIdentityUsageThreshold()
+	defer SetIdentityUsageThreshold(usageThreshold)
+	SetIdentityUsageThreshold(time.Millisecond * 100)
+	time.Sleep(time.Millisecond * 200)
+	idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
+		return false
+	})
+	_, err = idStore.Get(cs.GetPKIidOfCert(oldSelf))
+	assert.Error(t, err)
+	_, err = idStore.Get(cs.GetPKIidOfCert(newSelf))
+	assert.NoError(t, err)
+}
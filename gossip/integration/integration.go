@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/sinochem-tech/fabric/common/viperutil"
 	"github.com/sinochem-tech/fabric/gossip/api"
+	"github.com/sinochem-tech/fabric/gossip/comm"
 	"github.com/sinochem-tech/fabric/gossip/common"
 	"github.com/sinochem-tech/fabric/gossip/gossip"
 	"github.com/sinochem-tech/fabric/gossip/util"
@@ -54,6 +56,14 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		TLSCerts:                   certs,
 	}
 
+	pinningConf := comm.PinningConfig{}
+	if err := viperutil.EnhancedExactUnmarshalKey("peer.gossip.pinning", &pinningConf); err != nil {
+		return nil, errors.Wrap(err, "failed loading the pinning policies")
+	}
+	if conf.Pinning, err = comm.NewPinning(pinningConf); err != nil {
+		return nil, errors.WithMessage(err, "misconfigured pinning policies")
+	}
+
 	return conf, nil
 }
 
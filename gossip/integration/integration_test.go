@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package integration
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"strings"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/sinochem-tech/fabric/core/config/configtest"
 	"github.com/sinochem-tech/fabric/gossip/api"
+	"github.com/sinochem-tech/fabric/gossip/comm"
 	"github.com/sinochem-tech/fabric/gossip/common"
 	"github.com/sinochem-tech/fabric/gossip/util"
 	"github.com/sinochem-tech/fabric/msp/mgmt"
@@ -70,6 +72,48 @@ func TestNewGossipCryptoService(t *testing.T) {
 	go s3.Serve(ll3)
 }
 
+func TestNewConfigPinning(t *testing.T) {
+	setupTestEnv()
+	defer setupTestEnv()
+
+	conf, err := newConfig("localhost:5614", "", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, conf.Pinning)
+
+	viper.SetConfigType("yaml")
+	err = viper.ReadConfig(bytes.NewBufferString(`---
+peer:
+  gossip:
+    pinning:
+      deny:
+        mspIDs:
+          - Org3MSP
+      orgs:
+        - mspID: Org2MSP
+          allow:
+            sans:
+              - "*.org2.example.com"
+      channels:
+        - channel: mychannel
+          allow:
+            mspIDs:
+              - Org1MSP
+`))
+	assert.NoError(t, err)
+	conf, err = newConfig("localhost:5614", "", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, conf.Pinning)
+	assert.Error(t, conf.Pinning.Verify(comm.PinnedPeer{MSPID: "Org3MSP"}))
+	assert.Error(t, conf.Pinning.Verify(comm.PinnedPeer{MSPID: "Org2MSP", CertHash: []byte{1}, Names: []string{"peer0.org1.example.com"}}))
+	assert.NoError(t, conf.Pinning.Verify(comm.PinnedPeer{MSPID: "Org2MSP", CertHash: []byte{1}, Names: []string{"peer0.org2.example.com"}}))
+	assert.Error(t, conf.Pinning.Verify(comm.PinnedPeer{MSPID: "Org2MSP"}))
+	assert.Error(t, conf.Pinning.VerifyInChannel("mychannel", comm.PinnedPeer{MSPID: "Org2MSP"}))
+
+	viper.Set("peer.gossip.pinning.orgs", []interface{}{map[string]interface{}{"allow": nil}})
+	_, err = newConfig("localhost:5614", "", nil)
+	assert.EqualError(t, err, "misconfigured pinning policies: pinning policy of an organization without MSP ID")
+}
+
 func setupTestEnv() {
 	viper.SetConfigName("core")
 	viper.SetEnvPrefix("CORE")
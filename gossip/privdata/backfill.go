@@ -0,0 +1,393 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sinochem-tech/fabric/core/committer"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/sinochem-tech/fabric/protos/common"
+	"github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/spf13/viper"
+)
+
+const (
+	backfillEnabledConfigKey = "peer.gossip.pvtData.backfillEnabled"
+	// backfillScanBatchSize is the number of blocks retrieved at a time
+	// while scanning the chain for the private writes to back-fill
+	backfillScanBatchSize = 100
+)
+
+var errBackfillStopped = errors.New("back-fill stopped")
+
+// PvtDataBackfiller detects, in the background, the collections this peer becomes
+// eligible for when their configuration is upgraded, and records the private data
+// written to them beforehand as missing, so that it is pulled from the peers of the
+// other member orgs by the reconciler
+type PvtDataBackfiller interface {
+	// Start starts the backfiller
+	Start()
+	// Stop stops the backfiller
+	Stop()
+}
+
+// BackfillerConfig holds the configuration of the backfiller
+type BackfillerConfig struct {
+	// SleepInterval is the time the backfiller sleeps between inspections of the newly committed blocks
+	SleepInterval time.Duration
+	// IsEnabled indicates whether the backfiller is enabled
+	IsEnabled bool
+}
+
+// GetBackfillerConfig returns the configuration of the backfiller. Since the private data
+// is pulled by the reconciler, the backfiller is enabled only if the reconciliation is
+func GetBackfillerConfig() *BackfillerConfig {
+	reconcilerConfig := GetReconcilerConfig()
+	isEnabled := reconcilerConfig.IsEnabled
+	if viper.IsSet(backfillEnabledConfigKey) {
+		isEnabled = isEnabled && viper.GetBool(backfillEnabledConfigKey)
+	}
+	return &BackfillerConfig{SleepInterval: reconcilerConfig.SleepInterval, IsEnabled: isEnabled}
+}
+
+// BackfillerSupport encapsulates the set of interfaces needed by the backfiller
+type BackfillerSupport struct {
+	committer.Committer
+	IdentityDeserializerFactory
+}
+
+type backfiller struct {
+	channel        string
+	config         *BackfillerConfig
+	selfSignedData common.SignedData
+	// nextBlock is the number of the next block to be inspected for collection config upgrades
+	nextBlock uint64
+	// scanBatchSize is the number of blocks retrieved at a time while scanning for private writes
+	scanBatchSize uint64
+	stopChan      chan struct{}
+	startOnce     sync.Once
+	stopOnce      sync.Once
+	BackfillerSupport
+}
+
+// NewBackfiller creates a new instance of a backfiller of the private data of the given channel
+func NewBackfiller(channel string, support BackfillerSupport, selfSignedData common.SignedData, config *BackfillerConfig) PvtDataBackfiller {
+	if !config.IsEnabled {
+		logger.Info("Private data back-fill is disabled for channel", channel)
+		return &noopBackfiller{}
+	}
+	return &backfiller{
+		channel:           channel,
+		config:            config,
+		selfSignedData:    selfSignedData,
+		scanBatchSize:     backfillScanBatchSize,
+		stopChan:          make(chan struct{}),
+		BackfillerSupport: support,
+	}
+}
+
+// Start implements the function in the interface `PvtDataBackfiller`
+func (b *backfiller) Start() {
+	b.startOnce.Do(func() {
+		nextBlock, err := b.initialBlock()
+		if err != nil {
+			logger.Errorf("Failed obtaining the back-fill height of channel [%s], private data back-fill is disabled: %+v", b.channel, err)
+			return
+		}
+		b.nextBlock = nextBlock
+		go b.run()
+	})
+}
+
+// initialBlock returns the number of the first block to be inspected, which is the block the
+// back-fill stopped at before the peer was restarted. The first time the back-fill starts, only
+// the blocks committed from then on are inspected, and the ledger height is recorded accordingly
+func (b *backfiller) initialBlock() (uint64, error) {
+	nextBlock, err := b.GetPvtDataBackfillHeight()
+	if err != nil {
+		return 0, err
+	}
+	if nextBlock > 0 {
+		return nextBlock, nil
+	}
+	height, err := b.LedgerHeight()
+	if err != nil {
+		return 0, err
+	}
+	if err := b.SetPvtDataBackfillHeight(height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// Stop implements the function in the interface `PvtDataBackfiller`
+func (b *backfiller) Stop() {
+	b.stopOnce.Do(func() {
+		close(b.stopChan)
+	})
+}
+
+func (b *backfiller) run() {
+	for {
+		select {
+		case <-b.stopChan:
+			return
+		case <-time.After(b.config.SleepInterval):
+			if err := b.backfill(); err != nil && err != errBackfillStopped {
+				logger.Errorf("Failed back-filling private data of channel [%s]: %+v", b.channel, err)
+			}
+		}
+	}
+}
+
+// backfill inspects the blocks committed since the previous round for collection config upgrades
+func (b *backfiller) backfill() error {
+	height, err := b.LedgerHeight()
+	if err != nil {
+		return errors.WithMessage(err, "failed obtaining ledger height")
+	}
+	// the progress is recorded once the round is over, a block whose back-fill is interrupted
+	// is inspected again after a restart, as recording private data as missing twice is harmless
+	inspectedBlock := b.nextBlock
+	defer func() {
+		if b.nextBlock == inspectedBlock {
+			return
+		}
+		if err := b.SetPvtDataBackfillHeight(b.nextBlock); err != nil {
+			logger.Errorf("Failed recording the back-fill height of channel [%s]: %+v", b.channel, err)
+		}
+	}()
+	for ; b.nextBlock < height; b.nextBlock++ {
+		blocks := b.GetBlocks([]uint64{b.nextBlock})
+		if len(blocks) == 0 {
+			return errors.Errorf("block [%d] not found", b.nextBlock)
+		}
+		if err := b.backfillBlock(blocks[0], height-1); err != nil {
+			if err == errBackfillStopped {
+				return err
+			}
+			return errors.WithMessage(err, fmt.Sprintf("failed back-filling private data for block [%d]", b.nextBlock))
+		}
+	}
+	return nil
+}
+
+// backfillBlock records as missing the private data of the collections this
+// peer becomes eligible for by the collection config upgrades of the given block
+func (b *backfiller) backfillBlock(block *common.Block, lastCommittedBlock uint64) error {
+	blockNum := block.Header.Number
+	for _, chaincodeName := range collectionConfigUpdates(block) {
+		collections, err := b.newlyEligibleCollections(blockNum, chaincodeName)
+		if err != nil {
+			return err
+		}
+		if len(collections) == 0 {
+			continue
+		}
+		logger.Infof("Became eligible for %d collections of chaincode [%s] of channel [%s] at block [%d], back-filling their private data",
+			len(collections), chaincodeName, b.channel, blockNum)
+		if err := b.recordHistoricalWrites(chaincodeName, collections, blockNum, lastCommittedBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newlyEligibleCollections returns the configurations of the collections of the given chaincode
+// this peer is eligible for according to the collection config committed at the given block,
+// but isn't eligible for according to the collection config committed beforehand
+func (b *backfiller) newlyEligibleCollections(blockNum uint64, chaincodeName string) (map[string]*common.StaticCollectionConfig, error) {
+	retriever, err := b.GetConfigHistoryRetriever()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed obtaining config history retriever")
+	}
+	current, err := retriever.CollectionConfigAt(blockNum, chaincodeName)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed obtaining collection config of chaincode [%s]", chaincodeName))
+	}
+	previous, err := retriever.MostRecentCollectionConfigBelow(blockNum, chaincodeName)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed obtaining previous collection config of chaincode [%s]", chaincodeName))
+	}
+	if current == nil || previous == nil {
+		// the collections are defined along with the chaincode, hence there's no private data yet
+		return nil, nil
+	}
+	previouslyEligible := make(map[string]bool)
+	for _, config := range staticCollectionConfigs(previous.CollectionConfig) {
+		previouslyEligible[config.Name] = b.isEligible(config)
+	}
+	collections := make(map[string]*common.StaticCollectionConfig)
+	for _, config := range staticCollectionConfigs(current.CollectionConfig) {
+		wasEligible, existed := previouslyEligible[config.Name]
+		if !existed || wasEligible || !b.isEligible(config) {
+			continue
+		}
+		collections[config.Name] = config
+	}
+	return collections, nil
+}
+
+// isEligible checks if this peer is eligible for the collection with the given config
+func (b *backfiller) isEligible(config *common.StaticCollectionConfig) bool {
+	sc := &privdata.SimpleCollection{}
+	if err := sc.Setup(config, b.GetIdentityDeserializer(b.channel)); err != nil {
+		logger.Warning("Failed setting up collection", config.Name, ":", err)
+		return false
+	}
+	return sc.AccessFilter()(b.selfSignedData)
+}
+
+// recordHistoricalWrites records as missing the private writes of the valid transactions to the given
+// collections of the given chaincode up until the given block, skipping the ones that already expired.
+// The blocks are scanned in batches, the private writes of each batch are recorded before moving to
+// the next one, and the scan is interrupted as soon as the backfiller is stopped
+func (b *backfiller) recordHistoricalWrites(chaincodeName string, collections map[string]*common.StaticCollectionConfig, untilBlock, lastCommittedBlock uint64) error {
+	// the private data written at block w expires once block w+BTL+1 is committed
+	oldestUnexpired := make(map[string]uint64)
+	fromBlock := untilBlock
+	for name, config := range collections {
+		var oldest uint64
+		if btl := config.BlockToLive; btl > 0 && lastCommittedBlock > btl {
+			oldest = lastCommittedBlock - btl
+		}
+		oldestUnexpired[name] = oldest
+		if oldest < fromBlock {
+			fromBlock = oldest
+		}
+	}
+
+	for batchStart := fromBlock; batchStart <= untilBlock; batchStart += b.scanBatchSize {
+		select {
+		case <-b.stopChan:
+			return errBackfillStopped
+		default:
+		}
+		batchEnd := untilBlock
+		if untilBlock-batchStart >= b.scanBatchSize {
+			batchEnd = batchStart + b.scanBatchSize - 1
+		}
+		missing, err := b.historicalWrites(chaincodeName, oldestUnexpired, batchStart, batchEnd)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		if err := b.RecordMissingPvtDataOfOldBlocks(missing); err != nil {
+			return errors.WithMessage(err, "failed recording private data to back-fill")
+		}
+	}
+	return nil
+}
+
+// historicalWrites returns the private writes of the valid transactions of the blocks in the given range
+// to the collections of the given chaincode, skipping the ones below the oldest unexpired block of each
+func (b *backfiller) historicalWrites(chaincodeName string, oldestUnexpired map[string]uint64, fromBlock, toBlock uint64) (ledger.MissingPvtDataInfo, error) {
+	blockNums := make([]uint64, 0, toBlock-fromBlock+1)
+	for blockNum := fromBlock; blockNum <= toBlock; blockNum++ {
+		blockNums = append(blockNums, blockNum)
+	}
+	blocks := b.GetBlocks(blockNums)
+	if len(blocks) != len(blockNums) {
+		return nil, errors.Errorf("blocks [%d, %d] not found", fromBlock, toBlock)
+	}
+
+	missing := make(ledger.MissingPvtDataInfo)
+	for _, block := range blocks {
+		blockNum := block.Header.Number
+		if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+			return nil, errors.Errorf("block [%d] lacks a Tx filter bitmap", blockNum)
+		}
+		txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		if len(txsFilter) != len(block.Data.Data) {
+			return nil, errors.Errorf("block [%d] data size(%d) is different from Tx filter size(%d)", blockNum, len(block.Data.Data), len(txsFilter))
+		}
+		blockData(block.Data.Data).forEachTxn(txsFilter, func(seqInBlock uint64, chdr *common.ChannelHeader, txRWSet *rwsetutil.TxRwSet, _ []*peer.Endorsement) {
+			for _, ns := range txRWSet.NsRwSets {
+				if ns.NameSpace != chaincodeName {
+					continue
+				}
+				for _, hashedCollection := range ns.CollHashedRwSets {
+					oldest, isBackfilled := oldestUnexpired[hashedCollection.CollectionName]
+					if !isBackfilled || blockNum < oldest || !containsWrites(chdr.TxId, ns.NameSpace, hashedCollection) {
+						continue
+					}
+					missing[blockNum] = append(missing[blockNum], ledger.MissingPrivateData{
+						TxId:       chdr.TxId,
+						SeqInBlock: int(seqInBlock),
+						Namespace:  ns.NameSpace,
+						Collection: hashedCollection.CollectionName,
+						IsBackfill: true,
+					})
+				}
+			}
+		})
+	}
+	return missing, nil
+}
+
+// collectionConfigUpdates returns the names of the chaincodes whose
+// collection config is written by the valid transactions of the given block
+func collectionConfigUpdates(block *common.Block) []string {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil
+	}
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFilter) != len(block.Data.Data) {
+		return nil
+	}
+	var chaincodes []string
+	updated := make(map[string]struct{})
+	blockData(block.Data.Data).forEachTxn(txsFilter, func(_ uint64, _ *common.ChannelHeader, txRWSet *rwsetutil.TxRwSet, _ []*peer.Endorsement) {
+		for _, ns := range txRWSet.NsRwSets {
+			if ns.NameSpace != privdata.LSCCNamespace || ns.KvRwSet == nil {
+				continue
+			}
+			for _, write := range ns.KvRwSet.Writes {
+				if !privdata.IsCollectionConfigKey(write.Key) {
+					continue
+				}
+				chaincodeName := strings.SplitN(write.Key, "~", 2)[0]
+				if write.Key != privdata.BuildCollectionKVSKey(chaincodeName) {
+					continue
+				}
+				if _, exists := updated[chaincodeName]; exists {
+					continue
+				}
+				updated[chaincodeName] = struct{}{}
+				chaincodes = append(chaincodes, chaincodeName)
+			}
+		}
+	})
+	return chaincodes
+}
+
+func staticCollectionConfigs(pkg *common.CollectionConfigPackage) []*common.StaticCollectionConfig {
+	var configs []*common.StaticCollectionConfig
+	for _, config := range pkg.GetConfig() {
+		if staticConfig := config.GetStaticCollectionConfig(); staticConfig != nil {
+			configs = append(configs, staticConfig)
+		}
+	}
+	return configs
+}
+
+type noopBackfiller struct{}
+
+// Start implements the function in the interface `PvtDataBackfiller`
+func (*noopBackfiller) Start() {}
+
+// Stop implements the function in the interface `PvtDataBackfiller`
+func (*noopBackfiller) Stop() {}
@@ -0,0 +1,481 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/common/cauthdsl"
+	util2 "github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/gossip/util"
+	"github.com/sinochem-tech/fabric/msp"
+	"github.com/sinochem-tech/fabric/protos/common"
+	gproto "github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
+	mspproto "github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockIdentity struct {
+	idBytes []byte
+}
+
+func (id *mockIdentity) Anonymous() bool {
+	return false
+}
+
+func (id *mockIdentity) ExpiresAt() time.Time {
+	return time.Time{}
+}
+
+func (id *mockIdentity) SatisfiesPrincipal(p *mspproto.MSPPrincipal) error {
+	if bytes.Equal(id.idBytes, p.Principal) {
+		return nil
+	}
+	return errors.New("principals do not match")
+}
+
+func (id *mockIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{Mspid: string(id.idBytes), Id: string(id.idBytes)}
+}
+
+func (id *mockIdentity) GetMSPIdentifier() string {
+	return string(id.idBytes)
+}
+
+func (id *mockIdentity) Validate() error {
+	return nil
+}
+
+func (id *mockIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	return nil
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	return nil
+}
+
+func (id *mockIdentity) Serialize() ([]byte, error) {
+	return id.idBytes, nil
+}
+
+type mockDeserializer struct{}
+
+func (*mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	return &mockIdentity{idBytes: serializedIdentity}, nil
+}
+
+func (*mockDeserializer) IsWellFormed(_ *mspproto.SerializedIdentity) error {
+	return nil
+}
+
+type mockDeserializerFactory struct{}
+
+func (*mockDeserializerFactory) GetIdentityDeserializer(chainID string) msp.IdentityDeserializer {
+	return &mockDeserializer{}
+}
+
+func collectionConfig(name string, btl uint64, members ...string) *common.CollectionConfig {
+	var signers [][]byte
+	var policies []*common.SignaturePolicy
+	for i, member := range members {
+		signers = append(signers, []byte(member))
+		policies = append(policies, cauthdsl.SignedBy(int32(i)))
+	}
+	return &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name: name,
+				MemberOrgsPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+						SignaturePolicy: cauthdsl.Envelope(cauthdsl.NOutOf(1, policies), signers),
+					},
+				},
+				BlockToLive: btl,
+			},
+		},
+	}
+}
+
+func collectionConfigInfo(blockNum uint64, configs ...*common.CollectionConfig) *ledger.CollectionConfigInfo {
+	return &ledger.CollectionConfigInfo{
+		CollectionConfig:   &common.CollectionConfigPackage{Config: configs},
+		CommittingBlockNum: blockNum,
+	}
+}
+
+func blockWithNumber(block *common.Block, blockNum uint64) *common.Block {
+	block.Header.Number = blockNum
+	return block
+}
+
+func TestGetBackfillerConfig(t *testing.T) {
+	// the keys are unset rather than viper being reset, since
+	// the tests of the coordinator rely on their own settings
+	defer func() {
+		viper.Set(reconcileSleepIntervalConfigKey, nil)
+		viper.Set(backfillEnabledConfigKey, nil)
+		viper.Set(reconciliationEnabledConfigKey, nil)
+	}()
+	conf := GetBackfillerConfig()
+	assert.Equal(t, &BackfillerConfig{SleepInterval: time.Minute, IsEnabled: true}, conf)
+
+	viper.Set(reconcileSleepIntervalConfigKey, time.Second)
+	viper.Set(backfillEnabledConfigKey, false)
+	conf = GetBackfillerConfig()
+	assert.Equal(t, &BackfillerConfig{SleepInterval: time.Second, IsEnabled: false}, conf)
+
+	// the back-fill relies on the reconciliation
+	viper.Set(backfillEnabledConfigKey, true)
+	viper.Set(reconciliationEnabledConfigKey, false)
+	conf = GetBackfillerConfig()
+	assert.False(t, conf.IsEnabled)
+}
+
+func TestNewBackfillerDisabled(t *testing.T) {
+	b := NewBackfiller("test", BackfillerSupport{}, common.SignedData{}, &BackfillerConfig{IsEnabled: false})
+	_, isNoop := b.(*noopBackfiller)
+	assert.True(t, isNoop)
+	b.Start()
+	b.Stop()
+}
+
+func TestBackfill(t *testing.T) {
+	// Scenario: org2 is added at block 4 to the members of collections c1 and c3 of ns1,
+	// while it's already a member of c2, and c4 is defined along with the upgrade.
+	// The private data of c1 and c3 written beforehand is recorded as missing, except
+	// for the private data of c3 which already expired, since its BTL is 2
+	hash := []byte("hash")
+	bf := &blockFactory{channelID: "test"}
+	blocks := map[uint64]*common.Block{
+		0: blockWithNumber(bf.create(), 0),
+		1: blockWithNumber(bf.AddTxn("tx1", "ns1", hash, "c1", "c2", "c3").AddTxn("tx2", "ns2", hash, "c1").create(), 1),
+		2: blockWithNumber(bf.create(), 2),
+		3: blockWithNumber(bf.AddReadOnlyTxn("tx3", "ns1", hash, "c1").AddTxn("tx4", "ns1", hash, "c1", "c3").
+			AddTxn("tx5", "ns1", hash, "c3").withInvalidTxns(1).create(), 3),
+		4: blockWithNumber(bf.AddCollectionConfigUpdateTxn("upgrade", "ns1").AddTxn("tx6", "ns1", hash, "c1").create(), 4),
+		5: blockWithNumber(bf.AddTxn("tx7", "ns1", hash, "c1").create(), 5),
+	}
+
+	historyRetriever := &mockedHistoryRetreiver{}
+	historyRetriever.On("CollectionConfigAt", uint64(4), "ns1").Return(collectionConfigInfo(4,
+		collectionConfig("c1", 0, "org1", "org2"),
+		collectionConfig("c2", 0, "org1", "org2"),
+		collectionConfig("c3", 2, "org1", "org2"),
+		collectionConfig("c4", 0, "org1", "org2"),
+	), nil)
+	historyRetriever.On("MostRecentCollectionConfigBelow", uint64(4), "ns1").Return(collectionConfigInfo(0,
+		collectionConfig("c1", 0, "org1", "org3"),
+		collectionConfig("c2", 0, "org1", "org2"),
+		collectionConfig("c3", 2, "org1", "org3"),
+	), nil)
+
+	committer := &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(6), nil)
+	committer.On("GetConfigHistoryRetriever").Return(historyRetriever, nil)
+	for blockNum, block := range blocks {
+		committer.On("GetBlocks", []uint64{blockNum}).Return([]*common.Block{block})
+	}
+	committer.On("GetBlocks", []uint64{0, 1, 2, 3, 4}).Return([]*common.Block{blocks[0], blocks[1], blocks[2], blocks[3], blocks[4]})
+	var recorded ledger.MissingPvtDataInfo
+	committer.On("RecordMissingPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(0).(ledger.MissingPvtDataInfo)
+	}).Return(nil)
+	committer.On("SetPvtDataBackfillHeight", uint64(6)).Return(nil)
+
+	b := newTestBackfiller(committer, "org2")
+	b.nextBlock = 4
+	assert.NoError(t, b.backfill())
+	assert.Equal(t, uint64(6), b.nextBlock)
+	assert.Equal(t, ledger.MissingPvtDataInfo{
+		1: {{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1", IsBackfill: true}},
+		3: {{TxId: "tx5", SeqInBlock: 2, Namespace: "ns1", Collection: "c3", IsBackfill: true}},
+		4: {{TxId: "tx6", SeqInBlock: 1, Namespace: "ns1", Collection: "c1", IsBackfill: true}},
+	}, recorded)
+	committer.AssertNumberOfCalls(t, "RecordMissingPvtDataOfOldBlocks", 1)
+	// the progress is recorded, so that the back-fill resumes from there after a restart
+	committer.AssertCalled(t, "SetPvtDataBackfillHeight", uint64(6))
+
+	// the blocks already inspected aren't inspected again
+	assert.NoError(t, b.backfill())
+	committer.AssertNumberOfCalls(t, "RecordMissingPvtDataOfOldBlocks", 1)
+	committer.AssertNumberOfCalls(t, "SetPvtDataBackfillHeight", 1)
+}
+
+func TestBackfillScanInBatches(t *testing.T) {
+	// Scenario: org2 is added at block 4 to the members of collection c1 of ns1, whose private
+	// data written beforehand is scanned 2 blocks at a time, and recorded as missing batch by batch
+	hash := []byte("hash")
+	bf := &blockFactory{channelID: "test"}
+	blocks := []*common.Block{
+		blockWithNumber(bf.create(), 0),
+		blockWithNumber(bf.AddTxn("tx1", "ns1", hash, "c1").create(), 1),
+		blockWithNumber(bf.create(), 2),
+		blockWithNumber(bf.AddTxn("tx2", "ns1", hash, "c1").create(), 3),
+		blockWithNumber(bf.AddCollectionConfigUpdateTxn("upgrade", "ns1").create(), 4),
+	}
+
+	historyRetriever := &mockedHistoryRetreiver{}
+	historyRetriever.On("CollectionConfigAt", uint64(4), "ns1").Return(collectionConfigInfo(4,
+		collectionConfig("c1", 0, "org1", "org2"),
+	), nil)
+	historyRetriever.On("MostRecentCollectionConfigBelow", uint64(4), "ns1").Return(collectionConfigInfo(0,
+		collectionConfig("c1", 0, "org1"),
+	), nil)
+
+	committer := &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(5), nil)
+	committer.On("GetConfigHistoryRetriever").Return(historyRetriever, nil)
+	committer.On("GetBlocks", []uint64{4}).Return([]*common.Block{blocks[4]})
+	committer.On("GetBlocks", []uint64{0, 1}).Return(blocks[0:2])
+	committer.On("GetBlocks", []uint64{2, 3}).Return(blocks[2:4])
+	var recorded []ledger.MissingPvtDataInfo
+	committer.On("RecordMissingPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(ledger.MissingPvtDataInfo))
+	}).Return(nil)
+	committer.On("SetPvtDataBackfillHeight", uint64(5)).Return(nil)
+
+	b := newTestBackfiller(committer, "org2")
+	b.scanBatchSize = 2
+	b.nextBlock = 4
+	assert.NoError(t, b.backfill())
+	assert.Equal(t, []ledger.MissingPvtDataInfo{
+		{1: {{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1", IsBackfill: true}}},
+		{3: {{TxId: "tx2", SeqInBlock: 0, Namespace: "ns1", Collection: "c1", IsBackfill: true}}},
+	}, recorded)
+	committer.AssertCalled(t, "GetBlocks", []uint64{4})
+
+	// the scan is interrupted once the backfiller is stopped, and
+	// the block is inspected again once the backfiller is restarted
+	committer = &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(5), nil)
+	committer.On("GetConfigHistoryRetriever").Return(historyRetriever, nil)
+	committer.On("GetBlocks", []uint64{4}).Return([]*common.Block{blocks[4]})
+	b = newTestBackfiller(committer, "org2")
+	b.nextBlock = 4
+	b.Stop()
+	assert.Equal(t, errBackfillStopped, b.backfill())
+	assert.Equal(t, uint64(4), b.nextBlock)
+	committer.AssertNotCalled(t, "RecordMissingPvtDataOfOldBlocks", mock.Anything)
+	committer.AssertNotCalled(t, "SetPvtDataBackfillHeight", mock.Anything)
+}
+
+func TestBackfillNotNewlyEligible(t *testing.T) {
+	hash := []byte("hash")
+	bf := &blockFactory{channelID: "test"}
+	upgrade := blockWithNumber(bf.AddCollectionConfigUpdateTxn("upgrade", "ns1").create(), 2)
+	deploy := blockWithNumber(bf.AddCollectionConfigUpdateTxn("deploy", "ns2").AddTxn("tx1", "ns2", hash, "c1").create(), 3)
+
+	historyRetriever := &mockedHistoryRetreiver{}
+	// org2 is no longer a member of c1 of ns1
+	historyRetriever.On("CollectionConfigAt", uint64(2), "ns1").Return(collectionConfigInfo(2,
+		collectionConfig("c1", 0, "org1", "org3"),
+	), nil)
+	historyRetriever.On("MostRecentCollectionConfigBelow", uint64(2), "ns1").Return(collectionConfigInfo(1,
+		collectionConfig("c1", 0, "org1", "org2"),
+	), nil)
+	// ns2 is deployed along with its collections
+	historyRetriever.On("CollectionConfigAt", uint64(3), "ns2").Return(collectionConfigInfo(3,
+		collectionConfig("c1", 0, "org1", "org2"),
+	), nil)
+	historyRetriever.On("MostRecentCollectionConfigBelow", uint64(3), "ns2").Return((*ledger.CollectionConfigInfo)(nil), nil)
+
+	committer := &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(4), nil)
+	committer.On("GetConfigHistoryRetriever").Return(historyRetriever, nil)
+	committer.On("GetBlocks", []uint64{2}).Return([]*common.Block{upgrade})
+	committer.On("GetBlocks", []uint64{3}).Return([]*common.Block{deploy})
+
+	committer.On("SetPvtDataBackfillHeight", uint64(4)).Return(nil)
+
+	b := newTestBackfiller(committer, "org2")
+	b.nextBlock = 2
+	assert.NoError(t, b.backfill())
+	assert.Equal(t, uint64(4), b.nextBlock)
+	committer.AssertNotCalled(t, "RecordMissingPvtDataOfOldBlocks", mock.Anything)
+}
+
+func TestBackfillFailure(t *testing.T) {
+	bf := &blockFactory{channelID: "test"}
+	upgrade := blockWithNumber(bf.AddCollectionConfigUpdateTxn("upgrade", "ns1").create(), 2)
+
+	historyRetriever := &mockedHistoryRetreiver{}
+	historyRetriever.On("CollectionConfigAt", uint64(2), "ns1").Return((*ledger.CollectionConfigInfo)(nil), errors.New("config history failure"))
+
+	committer := &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(4), nil)
+	committer.On("GetConfigHistoryRetriever").Return(historyRetriever, nil)
+	committer.On("GetBlocks", []uint64{2}).Return([]*common.Block{upgrade})
+
+	// the block whose inspection failed is inspected again in the next round
+	b := newTestBackfiller(committer, "org2")
+	b.nextBlock = 2
+	err := b.backfill()
+	assert.Contains(t, err.Error(), "config history failure")
+	assert.Equal(t, uint64(2), b.nextBlock)
+
+	committer = &committerMock{}
+	committer.On("LedgerHeight").Return(uint64(4), nil)
+	committer.On("GetBlocks", []uint64{2}).Return(nil)
+	b = newTestBackfiller(committer, "org2")
+	b.nextBlock = 2
+	assert.Contains(t, b.backfill().Error(), "block [2] not found")
+}
+
+func TestBackfillerInitialBlock(t *testing.T) {
+	// the back-fill resumes from the block it stopped at
+	committer := &committerMock{}
+	committer.On("GetPvtDataBackfillHeight").Return(uint64(3), nil)
+	b := newTestBackfiller(committer, "org2")
+	nextBlock, err := b.initialBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), nextBlock)
+	committer.AssertNotCalled(t, "LedgerHeight")
+
+	// the first time, only the blocks committed from then on are inspected
+	committer = &committerMock{}
+	committer.On("GetPvtDataBackfillHeight").Return(uint64(0), nil)
+	committer.On("LedgerHeight").Return(uint64(5), nil)
+	committer.On("SetPvtDataBackfillHeight", uint64(5)).Return(nil)
+	b = newTestBackfiller(committer, "org2")
+	nextBlock, err = b.initialBlock()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nextBlock)
+	committer.AssertCalled(t, "SetPvtDataBackfillHeight", uint64(5))
+
+	committer = &committerMock{}
+	committer.On("GetPvtDataBackfillHeight").Return(uint64(0), errors.New("pvt data store failure"))
+	b = newTestBackfiller(committer, "org2")
+	_, err = b.initialBlock()
+	assert.Contains(t, err.Error(), "pvt data store failure")
+
+	committer = &committerMock{}
+	committer.On("GetPvtDataBackfillHeight").Return(uint64(0), nil)
+	committer.On("LedgerHeight").Return(uint64(5), nil)
+	committer.On("SetPvtDataBackfillHeight", uint64(5)).Return(errors.New("pvt data store failure"))
+	b = newTestBackfiller(committer, "org2")
+	_, err = b.initialBlock()
+	assert.Contains(t, err.Error(), "pvt data store failure")
+}
+
+func TestBackfillerStartStop(t *testing.T) {
+	inspected := make(chan struct{}, 1)
+	committer := &committerMock{}
+	committer.On("GetPvtDataBackfillHeight").Return(uint64(0), nil)
+	committer.On("SetPvtDataBackfillHeight", uint64(1)).Return(nil)
+	committer.On("LedgerHeight").Run(func(mock.Arguments) {
+		select {
+		case inspected <- struct{}{}:
+		default:
+		}
+	}).Return(uint64(1), nil)
+	b := NewBackfiller("test", BackfillerSupport{Committer: committer}, common.SignedData{}, &BackfillerConfig{
+		SleepInterval: time.Millisecond * 10,
+		IsEnabled:     true,
+	})
+	b.Start()
+	// the height at start is consumed by Start itself
+	<-inspected
+	select {
+	case <-inspected:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Backfiller didn't run")
+	}
+	b.Stop()
+	b.Stop()
+}
+
+func TestBackfillPulledFromPeer(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 became eligible for collection c1 of ns1 after tx1 of block 1 wrote to it,
+	// and pulls the private data from p2. The collection config tx1 was committed with doesn't
+	// grant access to p1, whereas the latest collection config does
+	gn := &gossipNetwork{}
+	rws := []byte("rws-pre-image")
+	block := blockWithNumber((&blockFactory{channelID: "A"}).AddTxn("tx1", "ns1", util2.ComputeSHA256(rws), "c1").create(), 1)
+
+	refuseAll := &collectionAccessFactoryMock{}
+	refusingPolicy := &collectionAccessPolicyMock{}
+	refusingPolicy.Setup(1, 2, func(common.SignedData) bool {
+		return false
+	}, []string{"org1"})
+	refuseAll.On("AccessPolicy", mock.Anything, mock.Anything).Return(refusingPolicy, nil)
+
+	p1Store := newCollectionStore().withPolicy("c1", 0).thatMapsTo("p2")
+	p1 := gn.newPuller("p1", p1Store, refuseAll, membership(peerData{"p2", uint64(2)})...)
+	p2 := gn.newPuller("p2", newCollectionStore().withPolicy("c1", 0).thatMapsTo("p1"), refuseAll)
+	p2Data := &util.PrivateRWSetWithConfig{
+		RWSet: []util.PrivateRWSet{rws},
+		CollectionConfig: &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{Name: "c1"},
+			},
+		},
+	}
+	backfillDigest := &gproto.PvtDataDigest{TxId: "tx1", Namespace: "ns1", Collection: "c1", BlockSeq: 1, Backfill: true}
+	digest := &gproto.PvtDataDigest{TxId: "tx1", Namespace: "ns1", Collection: "c1", BlockSeq: 1}
+	p2.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", backfillDigest).Return(p2Data, nil)
+	p2.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", digest).Return(p2Data, nil)
+
+	reconcile := func(missing ledger.MissingPrivateData) []*ledger.BlockPvtData {
+		committer := &committerMock{}
+		committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Return(ledger.MissingPvtDataInfo{
+			1: {missing},
+		}, nil)
+		committer.On("GetBlocks", []uint64{1}).Return([]*common.Block{block})
+		var committed []*ledger.BlockPvtData
+		committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+			committed = args.Get(0).([]*ledger.BlockPvtData)
+		}).Return(nil)
+		r := newTestReconciler(ReconcilerSupport{CollectionStore: p1Store, Committer: committer, Fetcher: p1})
+		assert.NoError(t, r.reconcile())
+		return committed
+	}
+
+	// the private data recorded as missing by the back-fill is
+	// checked against the latest collection config, hence pulled
+	committed := reconcile(ledger.MissingPrivateData{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1", IsBackfill: true})
+	assert.Equal(t, []*ledger.BlockPvtData{
+		{
+			BlockNum: 1,
+			WriteSets: map[uint64]*ledger.TxPvtData{
+				0: {SeqInBlock: 0, WriteSet: &rwset.TxPvtReadWriteSet{
+					DataModel: rwset.TxReadWriteSet_KV,
+					NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+						{
+							Namespace: "ns1",
+							CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+								{CollectionName: "c1", Rwset: rws},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}, committed)
+
+	// otherwise, the collection config the private data was written with applies
+	committed = reconcile(ledger.MissingPrivateData{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1"})
+	assert.Empty(t, committed)
+}
+
+func newTestBackfiller(committer *committerMock, org string) *backfiller {
+	return NewBackfiller("test", BackfillerSupport{
+		Committer:                   committer,
+		IdentityDeserializerFactory: &mockDeserializerFactory{},
+	}, common.SignedData{Identity: []byte(org)}, &BackfillerConfig{
+		SleepInterval: time.Minute,
+		IsEnabled:     true,
+	}).(*backfiller)
+}
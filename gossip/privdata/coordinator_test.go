@@ -183,6 +183,34 @@ func (mock *committerMock) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetr
 	return args.Get(0).(ledger.ConfigHistoryRetriever), args.Error(1)
 }
 
+func (mock *committerMock) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	args := mock.Called(blockNum, maxBlocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(ledger.MissingPvtDataInfo), args.Error(1)
+}
+
+func (mock *committerMock) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	args := mock.Called(blocksPvtData)
+	return args.Error(0)
+}
+
+func (mock *committerMock) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	args := mock.Called(missingPvtData)
+	return args.Error(0)
+}
+
+func (mock *committerMock) GetPvtDataBackfillHeight() (uint64, error) {
+	args := mock.Called()
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (mock *committerMock) SetPvtDataBackfillHeight(height uint64) error {
+	args := mock.Called(height)
+	return args.Error(0)
+}
+
 func (mock *committerMock) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
 	args := mock.Called(blockNum, filter)
 	return args.Get(0).([]*ledger.TxPvtData), args.Error(1)
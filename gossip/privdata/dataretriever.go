@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/core/transientstore"
 	"github.com/sinochem-tech/fabric/gossip/util"
@@ -45,13 +46,15 @@ type DataStore interface {
 }
 
 type dataRetriever struct {
-	store DataStore
+	store                    DataStore
+	implicitCollectionPolicy privdata.ImplicitCollectionDisseminationPolicy
 }
 
 // NewDataRetriever constructing function for implementation of the
-// StorageDataRetriever interface
-func NewDataRetriever(store DataStore) StorageDataRetriever {
-	return &dataRetriever{store: store}
+// StorageDataRetriever interface. The private data of the implicit
+// collections is disseminated according to the given policy
+func NewDataRetriever(store DataStore, implicitCollectionPolicy privdata.ImplicitCollectionDisseminationPolicy) StorageDataRetriever {
+	return &dataRetriever{store: store, implicitCollectionPolicy: implicitCollectionPolicy}
 }
 
 // CollectionRWSet retrieves for give digest relevant private data if
@@ -110,11 +113,21 @@ func (dr *dataRetriever) fromLedger(dig *gossip2.PvtDataDigest, filter map[strin
 			"collection name =", dig.Collection, "for chaincode", dig.Namespace))
 	}
 
+	var configs *common.CollectionConfig
+	if configInfo != nil {
+		configs = dr.extractCollectionConfigs(configInfo.CollectionConfig, dig)
+	}
+	// implicit collections aren't part of the collection config history,
+	// unless the chaincode declares a collection of the same name
+	if isImplicit, mspID := privdata.MspIDIfImplicitCollection(dig.Collection); configs == nil && isImplicit {
+		results.CollectionConfig = privdata.ImplicitCollectionConfigForOrg(mspID, dr.implicitCollectionPolicy)
+		return results, nil
+	}
+
 	if configInfo == nil {
 		return nil, errors.New(fmt.Sprint("no collection config update below block sequence = ", dig.BlockSeq,
 			"collection name =", dig.Collection, "for chaincode", dig.Namespace, "is available"))
 	}
-	configs := dr.extractCollectionConfigs(configInfo.CollectionConfig, dig)
 	if configs == nil {
 		return nil, errors.New(fmt.Sprint("no collection config was found for collection", dig.Collection,
 			"namespace", dig.Namespace, "txID", dig.TxId))
@@ -10,8 +10,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/core/transientstore"
+	"github.com/sinochem-tech/fabric/gossip/util"
 	"github.com/sinochem-tech/fabric/protos/common"
 	gossip2 "github.com/sinochem-tech/fabric/protos/gossip"
 	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
@@ -148,7 +150,7 @@ func TestNewDataRetriever_GetDataFromTransientStore(t *testing.T) {
 	dataStore.On("LedgerHeight").Return(uint64(1), nil)
 	dataStore.On("GetTxPvtRWSetByTxid", "testTxID", mock.Anything).Return(rwSetScanner, nil)
 
-	retriever := NewDataRetriever(dataStore)
+	retriever := NewDataRetriever(dataStore, privdata.ImplicitCollectionDisseminationPolicy{})
 
 	// Request digest for private data which is greater than current ledger height
 	// to make it query transient store for missed private data
@@ -228,7 +230,7 @@ func TestNewDataRetriever_GetDataFromLedger(t *testing.T) {
 	}, nil)
 	dataStore.On("GetConfigHistoryRetriever").Return(historyRetreiver, nil)
 
-	retriever := NewDataRetriever(dataStore)
+	retriever := NewDataRetriever(dataStore, privdata.ImplicitCollectionDisseminationPolicy{})
 
 	// Request digest for private data which is greater than current ledger height
 	// to make it query ledger for missed private data
@@ -254,6 +256,71 @@ func TestNewDataRetriever_GetDataFromLedger(t *testing.T) {
 	assertion.Equal([]byte{1, 2, 3, 4}, mergedRWSet)
 }
 
+func TestNewDataRetriever_GetImplicitCollectionDataFromLedger(t *testing.T) {
+	t.Parallel()
+	dataStore := &mockedDataStore{}
+
+	namespace := "testChaincodeName1"
+	collectionName := "_implicit_org_Org1MSP"
+
+	result := []*ledger.TxPvtData{{
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: namespace,
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{
+						CollectionName: collectionName,
+						Rwset:          []byte{1, 2},
+					}},
+				},
+			},
+		},
+		SeqInBlock: 1,
+	}}
+
+	dataStore.On("LedgerHeight").Return(uint64(10), nil)
+	dataStore.On("GetPvtDataByNum", uint64(5), mock.Anything).Return(result, nil)
+
+	historyRetreiver := &mockedHistoryRetreiver{}
+	historyRetreiver.On("MostRecentCollectionConfigBelow", mock.Anything, namespace).Return((*ledger.CollectionConfigInfo)(nil), nil).Once()
+	dataStore.On("GetConfigHistoryRetriever").Return(historyRetreiver, nil)
+
+	policy := privdata.ImplicitCollectionDisseminationPolicy{RequiredPeerCount: 1, MaxPeerCount: 2}
+	retriever := NewDataRetriever(dataStore, policy)
+	digest := &gossip2.PvtDataDigest{
+		Namespace:  namespace,
+		Collection: collectionName,
+		BlockSeq:   uint64(5),
+		TxId:       "testTxID",
+		SeqInBlock: 1,
+	}
+
+	// The config of the implicit collection isn't in the config history, hence it is generated
+	rwSets, err := retriever.CollectionRWSet(digest)
+
+	assertion := assert.New(t)
+	assertion.NoError(err)
+	assertion.Equal([]util.PrivateRWSet{{1, 2}}, rwSets.RWSet)
+	assertion.Equal(privdata.ImplicitCollectionConfigForOrg("Org1MSP", policy), rwSets.CollectionConfig)
+
+	// unless the chaincode declares a collection of the same name
+	declared := &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name:        collectionName,
+				BlockToLive: 10,
+			},
+		},
+	}
+	historyRetreiver.On("MostRecentCollectionConfigBelow", mock.Anything, namespace).Return(&ledger.CollectionConfigInfo{
+		CollectionConfig: &common.CollectionConfigPackage{Config: []*common.CollectionConfig{declared}},
+	}, nil)
+	rwSets, err = retriever.CollectionRWSet(digest)
+	assertion.NoError(err)
+	assertion.Equal(declared, rwSets.CollectionConfig)
+}
+
 func TestNewDataRetriever_FailGetPvtDataFromLedger(t *testing.T) {
 	t.Parallel()
 	dataStore := &mockedDataStore{}
@@ -265,7 +332,7 @@ func TestNewDataRetriever_FailGetPvtDataFromLedger(t *testing.T) {
 	dataStore.On("GetPvtDataByNum", uint64(5), mock.Anything).
 		Return(nil, errors.New("failing retrieving private data"))
 
-	retriever := NewDataRetriever(dataStore)
+	retriever := NewDataRetriever(dataStore, privdata.ImplicitCollectionDisseminationPolicy{})
 
 	// Request digest for private data which is greater than current ledger height
 	// to make it query transient store for missed private data
@@ -344,7 +411,7 @@ func TestNewDataRetriever_GetOnlyRelevantPvtData(t *testing.T) {
 	}, nil)
 	dataStore.On("GetConfigHistoryRetriever").Return(historyRetreiver, nil)
 
-	retriever := NewDataRetriever(dataStore)
+	retriever := NewDataRetriever(dataStore, privdata.ImplicitCollectionDisseminationPolicy{})
 
 	// Request digest for private data which is greater than current ledger height
 	// to make it query transient store for missed private data
@@ -156,7 +156,7 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 			continue
 		}
 
-		colAP, err := p.AccessPolicy(rwSets.CollectionConfig, p.channel)
+		colAP, err := p.accessPolicy(dig, rwSets.CollectionConfig)
 		if err != nil {
 			logger.Debug("No policy found for channel", p.channel, ", collection", dig.Collection, "txID", dig.TxId, ":", err, "skipping...")
 			continue
@@ -185,6 +185,22 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 	return returned
 }
 
+// accessPolicy returns the access policy the eligibility of the requesting peer is checked against.
+// The private data is disseminated according to the collection config it was written with, unless
+// it is back-filled, in which case the requesting peer became eligible for the collection only
+// afterwards, hence the latest collection config applies
+func (p *puller) accessPolicy(dig *proto.PvtDataDigest, config *fcommon.CollectionConfig) (privdata.CollectionAccessPolicy, error) {
+	if !dig.Backfill {
+		return p.AccessPolicy(config, p.channel)
+	}
+	return p.cs.RetrieveCollectionAccessPolicy(fcommon.CollectionCriteria{
+		Channel:    p.channel,
+		TxId:       dig.TxId,
+		Namespace:  dig.Namespace,
+		Collection: dig.Collection,
+	})
+}
+
 func (p *puller) handleResponse(message proto.ReceivedMessage) {
 	msg := message.GetGossipMessage().GetPrivateRes()
 	logger.Debug("Got", msg, "from", message.GetConnectionInfo().Endpoint)
@@ -0,0 +1,369 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"encoding/hex"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sinochem-tech/fabric/common/metrics"
+	util2 "github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/committer"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/sinochem-tech/fabric/protos/common"
+	gossip2 "github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/spf13/viper"
+)
+
+const (
+	reconcileSleepIntervalConfigKey = "peer.gossip.pvtData.reconcileSleepInterval"
+	reconcileSleepIntervalDefault   = time.Minute
+	reconcileBatchSizeConfigKey     = "peer.gossip.pvtData.reconcileBatchSize"
+	reconcileBatchSizeDefault       = 10
+	reconciliationEnabledConfigKey  = "peer.gossip.pvtData.reconciliationEnabled"
+)
+
+// PvtDataReconciler completes, in the background, the private data
+// that was missing at the commit of the blocks
+type PvtDataReconciler interface {
+	// Start starts the reconciler
+	Start()
+	// Stop stops the reconciler
+	Stop()
+}
+
+// ReconcilerConfig holds the configuration of the reconciler
+type ReconcilerConfig struct {
+	// SleepInterval is the time the reconciler sleeps between reconciliation rounds
+	SleepInterval time.Duration
+	// BatchSize is the maximum number of blocks whose missing private data is reconciled in a single round
+	BatchSize int
+	// IsEnabled indicates whether the reconciler is enabled
+	IsEnabled bool
+}
+
+// GetReconcilerConfig returns the configuration of the reconciler
+func GetReconcilerConfig() *ReconcilerConfig {
+	sleepInterval := viper.GetDuration(reconcileSleepIntervalConfigKey)
+	if sleepInterval <= 0 {
+		logger.Warning("Configuration key", reconcileSleepIntervalConfigKey, "isn't set, defaulting to", reconcileSleepIntervalDefault)
+		sleepInterval = reconcileSleepIntervalDefault
+	}
+	batchSize := viper.GetInt(reconcileBatchSizeConfigKey)
+	if batchSize <= 0 {
+		logger.Warning("Configuration key", reconcileBatchSizeConfigKey, "isn't set, defaulting to", reconcileBatchSizeDefault)
+		batchSize = reconcileBatchSizeDefault
+	}
+	isEnabled := true
+	if viper.IsSet(reconciliationEnabledConfigKey) {
+		isEnabled = viper.GetBool(reconciliationEnabledConfigKey)
+	}
+	return &ReconcilerConfig{SleepInterval: sleepInterval, BatchSize: batchSize, IsEnabled: isEnabled}
+}
+
+// ReconcilerSupport encapsulates the set of interfaces needed by the reconciler
+type ReconcilerSupport struct {
+	privdata.CollectionStore
+	committer.Committer
+	Fetcher
+}
+
+// reconcilerMetrics holds the metrics reported by the reconciler
+type reconcilerMetrics struct {
+	// missing is the number of private write sets still missing for the blocks inspected in the last round
+	missing metrics.Gauge
+	// reconciled is the number of private write sets committed by the reconciler
+	reconciled metrics.Counter
+}
+
+func newReconcilerMetrics(scope metrics.Scope) *reconcilerMetrics {
+	if scope == nil {
+		return &reconcilerMetrics{missing: noopGauge{}, reconciled: noopCounter{}}
+	}
+	return &reconcilerMetrics{
+		missing:    scope.Gauge("missing_pvtdata"),
+		reconciled: scope.Counter("reconciled_pvtdata"),
+	}
+}
+
+type noopGauge struct{}
+
+func (noopGauge) Update(float64) {}
+
+type noopCounter struct{}
+
+func (noopCounter) Inc(int64) {}
+
+type reconciler struct {
+	channel string
+	config  *ReconcilerConfig
+	metrics *reconcilerMetrics
+	// blocksBelow is the block number below which the next round looks for missing private data.
+	// It is only accessed by the reconciliation goroutine
+	blocksBelow uint64
+	stopChan    chan struct{}
+	startOnce   sync.Once
+	stopOnce    sync.Once
+	ReconcilerSupport
+}
+
+// NewReconciler creates a new instance of a reconciler of the missing private data of the given channel.
+// The metrics are reported under the given scope, if any
+func NewReconciler(channel string, support ReconcilerSupport, scope metrics.Scope, config *ReconcilerConfig) PvtDataReconciler {
+	if !config.IsEnabled {
+		logger.Info("Private data reconciliation is disabled for channel", channel)
+		return &noopReconciler{}
+	}
+	return &reconciler{
+		channel:           channel,
+		config:            config,
+		metrics:           newReconcilerMetrics(scope),
+		blocksBelow:       math.MaxUint64,
+		stopChan:          make(chan struct{}),
+		ReconcilerSupport: support,
+	}
+}
+
+// Start implements the function in the interface `PvtDataReconciler`
+func (r *reconciler) Start() {
+	r.startOnce.Do(func() {
+		go r.run()
+	})
+}
+
+// Stop implements the function in the interface `PvtDataReconciler`
+func (r *reconciler) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+	})
+}
+
+func (r *reconciler) run() {
+	for {
+		select {
+		case <-r.stopChan:
+			return
+		case <-time.After(r.config.SleepInterval):
+			if err := r.reconcile(); err != nil {
+				logger.Errorf("Failed reconciling missing private data of channel [%s]: %+v", r.channel, err)
+			}
+		}
+	}
+}
+
+// reconcile pulls from other peers the private data that was missing at the commit of the
+// next batch of blocks, and commits the private data that was successfully pulled
+func (r *reconciler) reconcile() error {
+	missingPvtDataInfo, err := r.nextMissingPvtDataInfo()
+	if err != nil {
+		return errors.WithMessage(err, "failed obtaining missing private data")
+	}
+	missing := 0
+	for _, missingPvtData := range missingPvtDataInfo {
+		missing += len(missingPvtData)
+	}
+	r.metrics.missing.Update(float64(missing))
+	if missing == 0 {
+		logger.Debug("No missing private data to reconcile for channel", r.channel)
+		return nil
+	}
+	logger.Debug("Reconciling", missing, "missing private write sets of", len(missingPvtDataInfo), "blocks of channel", r.channel)
+
+	var blocksPvtData []*ledger.BlockPvtData
+	reconciled := 0
+	for blockNum, missingPvtData := range missingPvtDataInfo {
+		blockPvtData, err := r.reconcileBlock(blockNum, missingPvtData)
+		if err != nil {
+			logger.Warningf("Failed reconciling missing private data of block [%d] of channel [%s]: %+v", blockNum, r.channel, err)
+			continue
+		}
+		if blockPvtData == nil {
+			continue
+		}
+		for _, txPvtData := range blockPvtData.WriteSets {
+			for _, ns := range txPvtData.WriteSet.NsPvtRwset {
+				reconciled += len(ns.CollectionPvtRwset)
+			}
+		}
+		blocksPvtData = append(blocksPvtData, blockPvtData)
+	}
+	if len(blocksPvtData) == 0 {
+		return nil
+	}
+
+	if err := r.CommitPvtDataOfOldBlocks(blocksPvtData); err != nil {
+		return errors.WithMessage(err, "failed committing reconciled private data")
+	}
+	r.metrics.reconciled.Inc(int64(reconciled))
+	r.metrics.missing.Update(float64(missing - reconciled))
+	logger.Infof("Reconciled %d out of %d missing private write sets of channel [%s]", reconciled, missing, r.channel)
+	return nil
+}
+
+// nextMissingPvtDataInfo returns the missing private data of the next batch of blocks. Each round
+// moves to the blocks below the ones inspected in the previous round, and starts over from the most
+// recent blocks once the oldest blocks are reached, so that the private data missing in older blocks
+// isn't starved by private data of recent blocks that can't be reconciled
+func (r *reconciler) nextMissingPvtDataInfo() (ledger.MissingPvtDataInfo, error) {
+	missingPvtDataInfo, err := r.GetMissingPvtDataInfoForBlocksBelow(r.blocksBelow, r.config.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingPvtDataInfo) == 0 && r.blocksBelow != math.MaxUint64 {
+		r.blocksBelow = math.MaxUint64
+		missingPvtDataInfo, err = r.GetMissingPvtDataInfoForBlocksBelow(r.blocksBelow, r.config.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for blockNum := range missingPvtDataInfo {
+		if blockNum < r.blocksBelow {
+			r.blocksBelow = blockNum
+		}
+	}
+	return missingPvtDataInfo, nil
+}
+
+// reconcileBlock pulls the given missing private data of the block with the given number,
+// and returns the private data that was pulled, or nil if none was
+func (r *reconciler) reconcileBlock(blockNum uint64, missingPvtData []ledger.MissingPrivateData) (*ledger.BlockPvtData, error) {
+	blocks := r.GetBlocks([]uint64{blockNum})
+	if len(blocks) == 0 {
+		return nil, errors.Errorf("block [%d] not found", blockNum)
+	}
+	missingKeys, sources, backfilled, err := r.missingKeysOfBlock(blocks[0], missingPvtData)
+	if err != nil {
+		return nil, err
+	}
+	if len(missingKeys) == 0 {
+		return nil, nil
+	}
+
+	dig2src := make(dig2sources)
+	for key := range missingKeys {
+		dig := &gossip2.PvtDataDigest{
+			TxId:       key.txID,
+			SeqInBlock: key.seqInBlock,
+			Namespace:  key.namespace,
+			Collection: key.collection,
+			BlockSeq:   blockNum,
+		}
+		// the peers holding back-filled private data check the eligibility
+		// of this peer against the latest collection config
+		_, dig.Backfill = backfilled[key]
+		dig2src[dig] = sources[key]
+	}
+	fetched, err := r.fetch(dig2src, blockNum)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed fetching private data from peers")
+	}
+
+	ownedRWsets := make(rwsetByKeys)
+	for _, element := range fetched.AvailableElemenets {
+		dig := element.Digest
+		for _, rws := range element.Payload {
+			key := rwSetKey{
+				txID:       dig.TxId,
+				seqInBlock: dig.SeqInBlock,
+				namespace:  dig.Namespace,
+				collection: dig.Collection,
+				hash:       hex.EncodeToString(util2.ComputeSHA256(rws)),
+			}
+			if _, isMissing := missingKeys[key]; !isMissing {
+				logger.Debug("Ignoring", key, "because it doesn't match the hash in the block")
+				continue
+			}
+			ownedRWsets[key] = rws
+		}
+	}
+	if len(ownedRWsets) == 0 {
+		return nil, nil
+	}
+
+	blockPvtData := &ledger.BlockPvtData{BlockNum: blockNum, WriteSets: make(map[uint64]*ledger.TxPvtData)}
+	for seqInBlock, rwsets := range ownedRWsets.bySeqsInBlock() {
+		blockPvtData.WriteSets[seqInBlock] = &ledger.TxPvtData{
+			SeqInBlock: seqInBlock,
+			WriteSet:   rwsets.toRWSet(),
+		}
+	}
+	return blockPvtData, nil
+}
+
+// missingKeysOfBlock returns the keys, along with the hashes found in the given block, of the given
+// missing private data, the endorsements of the peers that are expected to hold the private data,
+// and the keys of the missing private data that is back-filled
+func (r *reconciler) missingKeysOfBlock(block *common.Block, missingPvtData []ledger.MissingPrivateData) (rwsetKeys, map[rwSetKey][]*peer.Endorsement, rwsetKeys, error) {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil, nil, nil, errors.New("Block.Metadata is nil or Block.Metadata lacks a Tx filter bitmap")
+	}
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFilter) != len(block.Data.Data) {
+		return nil, nil, nil, errors.Errorf("Block data size(%d) is different from Tx filter size(%d)", len(block.Data.Data), len(txsFilter))
+	}
+
+	type missingCollection struct {
+		seqInBlock            uint64
+		namespace, collection string
+	}
+	// maps the missing collections to whether they are back-filled
+	missing := make(map[missingCollection]bool)
+	for _, m := range missingPvtData {
+		missing[missingCollection{seqInBlock: uint64(m.SeqInBlock), namespace: m.Namespace, collection: m.Collection}] = m.IsBackfill
+	}
+
+	missingKeys := make(rwsetKeys)
+	backfilled := make(rwsetKeys)
+	sources := make(map[rwSetKey][]*peer.Endorsement)
+	blockData(block.Data.Data).forEachTxn(txsFilter, func(seqInBlock uint64, chdr *common.ChannelHeader, txRWSet *rwsetutil.TxRwSet, endorsers []*peer.Endorsement) {
+		for _, ns := range txRWSet.NsRwSets {
+			for _, hashedCollection := range ns.CollHashedRwSets {
+				isBackfill, isMissing := missing[missingCollection{seqInBlock, ns.NameSpace, hashedCollection.CollectionName}]
+				if !isMissing {
+					continue
+				}
+				policy, err := r.RetrieveCollectionAccessPolicy(common.CollectionCriteria{
+					Channel:    chdr.ChannelId,
+					TxId:       chdr.TxId,
+					Namespace:  ns.NameSpace,
+					Collection: hashedCollection.CollectionName,
+				})
+				if err != nil {
+					logger.Warning("Failed obtaining policy for collection", hashedCollection.CollectionName, "of namespace", ns.NameSpace, ":", err)
+					continue
+				}
+				key := rwSetKey{
+					txID:       chdr.TxId,
+					seqInBlock: seqInBlock,
+					namespace:  ns.NameSpace,
+					collection: hashedCollection.CollectionName,
+					hash:       hex.EncodeToString(hashedCollection.PvtRwSetHash),
+				}
+				missingKeys[key] = struct{}{}
+				if isBackfill {
+					backfilled[key] = struct{}{}
+				}
+				sources[key] = endorsersFromOrgs(ns.NameSpace, hashedCollection.CollectionName, endorsers, policy.MemberOrgs())
+			}
+		}
+	})
+	return missingKeys, sources, backfilled, nil
+}
+
+type noopReconciler struct{}
+
+// Start implements the function in the interface `PvtDataReconciler`
+func (*noopReconciler) Start() {}
+
+// Stop implements the function in the interface `PvtDataReconciler`
+func (*noopReconciler) Stop() {}
@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/common/metrics"
+	util2 "github.com/sinochem-tech/fabric/common/util"
+	"github.com/sinochem-tech/fabric/core/ledger"
+	"github.com/sinochem-tech/fabric/protos/common"
+	proto "github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetReconcilerConfig(t *testing.T) {
+	defer viper.Reset()
+	conf := GetReconcilerConfig()
+	assert.Equal(t, &ReconcilerConfig{SleepInterval: time.Minute, BatchSize: 10, IsEnabled: true}, conf)
+
+	viper.Set(reconcileSleepIntervalConfigKey, time.Second)
+	viper.Set(reconcileBatchSizeConfigKey, 3)
+	viper.Set(reconciliationEnabledConfigKey, false)
+	conf = GetReconcilerConfig()
+	assert.Equal(t, &ReconcilerConfig{SleepInterval: time.Second, BatchSize: 3, IsEnabled: false}, conf)
+}
+
+func TestNewReconcilerDisabled(t *testing.T) {
+	r := NewReconciler("test", ReconcilerSupport{}, nil, &ReconcilerConfig{IsEnabled: false})
+	_, isNoop := r.(*noopReconciler)
+	assert.True(t, isNoop)
+	r.Start()
+	r.Stop()
+}
+
+func TestReconcileNothingMissing(t *testing.T) {
+	committer := &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Return(ledger.MissingPvtDataInfo{}, nil)
+	r := newTestReconciler(ReconcilerSupport{Committer: committer})
+	assert.NoError(t, r.reconcile())
+	committer.AssertNotCalled(t, "CommitPvtDataOfOldBlocks", mock.Anything)
+
+	committer = &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Return(nil, errors.New("ledger failure"))
+	r = newTestReconciler(ReconcilerSupport{Committer: committer})
+	assert.Contains(t, r.reconcile().Error(), "ledger failure")
+}
+
+func TestReconcile(t *testing.T) {
+	// Scenario: the private data of collection c1 of ns1 in tx1 of block 1 is missing,
+	// and is pulled from a peer of org1, which endorsed the transaction
+	hash := util2.ComputeSHA256([]byte("rws-pre-image"))
+	block := (&blockFactory{channelID: "test"}).AddTxnWithEndorsement("tx1", "ns1", hash, "org1", true, "c1", "c2").create()
+
+	committer := &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Return(ledger.MissingPvtDataInfo{
+		1: {{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1"}},
+	}, nil)
+	committer.On("GetBlocks", []uint64{1}).Return([]*common.Block{block})
+	var committed []*ledger.BlockPvtData
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything).Run(func(args mock.Arguments) {
+		committed = args.Get(0).([]*ledger.BlockPvtData)
+	}).Return(nil)
+
+	digest := &proto.PvtDataDigest{TxId: "tx1", Namespace: "ns1", Collection: "c1", BlockSeq: 1}
+	fetcher := &fetcherMock{t: t}
+	fetcher.On("fetch", mock.Anything).expectingEndorsers("org1").expectingDigests([]*proto.PvtDataDigest{digest}).Return(&FetchedPvtDataContainer{
+		AvailableElemenets: []*proto.PvtDataElement{
+			{
+				Digest:  digest,
+				Payload: [][]byte{[]byte("rws-pre-image")},
+			},
+		},
+	}, nil)
+
+	// The metrics are reported to a statsd server
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.NoError(t, metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: "statsd",
+		Interval: 100 * time.Millisecond,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conn.LocalAddr().String(),
+			FlushInterval: 100 * time.Millisecond,
+			FlushBytes:    512,
+		},
+	}))
+	assert.NoError(t, metrics.Start())
+	defer metrics.Shutdown()
+
+	scope := metrics.RootScope.SubScope("gossip_privdata").Tagged(map[string]string{"channel": "test"})
+	r := NewReconciler("test", ReconcilerSupport{
+		CollectionStore: createcollectionStore(common.SignedData{}).thatAcceptsAll(),
+		Committer:       committer,
+		Fetcher:         fetcher,
+	}, scope, &ReconcilerConfig{SleepInterval: time.Minute, BatchSize: 10, IsEnabled: true}).(*reconciler)
+	assert.NoError(t, r.reconcile())
+	waitForStatsdMetrics(t, conn,
+		"hyperledger_fabric.gossip_privdata.reconciled_pvtdata.channel-test:1|c",
+		"hyperledger_fabric.gossip_privdata.missing_pvtdata.channel-test:0|g")
+	assert.Equal(t, []*ledger.BlockPvtData{
+		{
+			BlockNum: 1,
+			WriteSets: map[uint64]*ledger.TxPvtData{
+				0: {SeqInBlock: 0, WriteSet: &rwset.TxPvtReadWriteSet{
+					DataModel: rwset.TxReadWriteSet_KV,
+					NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+						{
+							Namespace: "ns1",
+							CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+								{
+									CollectionName: "c1",
+									Rwset:          []byte("rws-pre-image"),
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}, committed)
+}
+
+func TestReconcileWrongHash(t *testing.T) {
+	// Scenario: the private data pulled for the missing collection doesn't match
+	// the hash in the block, hence nothing is committed
+	hash := util2.ComputeSHA256([]byte("rws-pre-image"))
+	block := (&blockFactory{channelID: "test"}).AddTxnWithEndorsement("tx1", "ns1", hash, "org1", true, "c1").create()
+
+	committer := &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Return(ledger.MissingPvtDataInfo{
+		1: {{TxId: "tx1", SeqInBlock: 0, Namespace: "ns1", Collection: "c1"}},
+	}, nil)
+	committer.On("GetBlocks", []uint64{1}).Return([]*common.Block{block})
+
+	digest := &proto.PvtDataDigest{TxId: "tx1", Namespace: "ns1", Collection: "c1", BlockSeq: 1}
+	fetcher := &fetcherMock{t: t}
+	fetcher.On("fetch", mock.Anything).expectingEndorsers("org1").expectingDigests([]*proto.PvtDataDigest{digest}).Return(&FetchedPvtDataContainer{
+		AvailableElemenets: []*proto.PvtDataElement{
+			{
+				Digest:  digest,
+				Payload: [][]byte{[]byte("wrong pre-image")},
+			},
+		},
+	}, nil)
+
+	r := newTestReconciler(ReconcilerSupport{
+		CollectionStore: createcollectionStore(common.SignedData{}).thatAcceptsAll(),
+		Committer:       committer,
+		Fetcher:         fetcher,
+	})
+	assert.NoError(t, r.reconcile())
+	committer.AssertNotCalled(t, "CommitPvtDataOfOldBlocks", mock.Anything)
+}
+
+func TestReconcileIteratesOverGaps(t *testing.T) {
+	// Scenario: the private data missing in block 5 can't be reconciled, yet the next rounds
+	// move to the older block 3, and then start over from the most recent blocks
+	committer := &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 1).Return(ledger.MissingPvtDataInfo{
+		5: {{TxId: "tx5", SeqInBlock: 0, Namespace: "ns1", Collection: "c1"}},
+	}, nil)
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(5), 1).Return(ledger.MissingPvtDataInfo{
+		3: {{TxId: "tx3", SeqInBlock: 0, Namespace: "ns1", Collection: "c1"}},
+	}, nil)
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(3), 1).Return(ledger.MissingPvtDataInfo{}, nil)
+	committer.On("GetBlocks", mock.Anything).Return([]*common.Block{})
+
+	r := NewReconciler("test", ReconcilerSupport{Committer: committer}, nil, &ReconcilerConfig{
+		SleepInterval: time.Minute,
+		BatchSize:     1,
+		IsEnabled:     true,
+	}).(*reconciler)
+
+	assert.NoError(t, r.reconcile())
+	committer.AssertCalled(t, "GetBlocks", []uint64{5})
+	assert.NoError(t, r.reconcile())
+	committer.AssertCalled(t, "GetBlocks", []uint64{3})
+	assert.NoError(t, r.reconcile())
+	committer.AssertCalled(t, "GetMissingPvtDataInfoForBlocksBelow", uint64(3), 1)
+	committer.AssertNumberOfCalls(t, "GetMissingPvtDataInfoForBlocksBelow", 4)
+	committer.AssertNumberOfCalls(t, "GetBlocks", 3)
+	assert.Equal(t, uint64(5), r.blocksBelow)
+}
+
+func TestReconcilerStartStop(t *testing.T) {
+	reconciled := make(chan struct{}, 1)
+	committer := &committerMock{}
+	committer.On("GetMissingPvtDataInfoForBlocksBelow", uint64(math.MaxUint64), 10).Run(func(mock.Arguments) {
+		select {
+		case reconciled <- struct{}{}:
+		default:
+		}
+	}).Return(ledger.MissingPvtDataInfo{}, nil)
+	r := NewReconciler("test", ReconcilerSupport{Committer: committer}, nil, &ReconcilerConfig{
+		SleepInterval: time.Millisecond * 10,
+		BatchSize:     10,
+		IsEnabled:     true,
+	})
+	r.Start()
+	select {
+	case <-reconciled:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Reconciler didn't run")
+	}
+	r.Stop()
+	r.Stop()
+}
+
+// waitForStatsdMetrics waits for the given metrics to be received by the statsd server listening on the given connection
+func waitForStatsdMetrics(t *testing.T, conn net.PacketConn, expected ...string) {
+	buf := make([]byte, 2048)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for len(expected) > 0 {
+		n, _, err := conn.ReadFrom(buf)
+		if !assert.NoError(t, err, "metrics %v weren't reported", expected) {
+			return
+		}
+		var missing []string
+		for _, metric := range expected {
+			if !strings.Contains(string(buf[:n]), metric) {
+				missing = append(missing, metric)
+			}
+		}
+		expected = missing
+	}
+}
+
+func newTestReconciler(support ReconcilerSupport) *reconciler {
+	return NewReconciler("test", support, nil, &ReconcilerConfig{
+		SleepInterval: time.Minute,
+		BatchSize:     10,
+		IsEnabled:     true,
+	}).(*reconciler)
+}
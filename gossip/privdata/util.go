@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/core/common/privdata"
 	"github.com/sinochem-tech/fabric/core/ledger"
 	"github.com/sinochem-tech/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/sinochem-tech/fabric/protos/common"
@@ -39,11 +40,6 @@ func (bf *blockFactory) AddReadOnlyTxn(txID string, nsName string, hash []byte,
 }
 
 func (bf *blockFactory) AddTxnWithEndorsement(txID string, nsName string, hash []byte, org string, hasWrites bool, collections ...string) *blockFactory {
-	txn := &peer.Transaction{
-		Actions: []*peer.TransactionAction{
-			{},
-		},
-	}
 	nsRWSet := sampleNsRwSet(nsName, hash, collections...)
 	if !hasWrites {
 		nsRWSet = sampleReadOnlyNsRwSet(nsName, hash, collections...)
@@ -51,7 +47,32 @@ func (bf *blockFactory) AddTxnWithEndorsement(txID string, nsName string, hash [
 	txrws := rwsetutil.TxRwSet{
 		NsRwSets: []*rwsetutil.NsRwSet{nsRWSet},
 	}
+	return bf.addTxnWithRWSet(txID, txrws, org)
+}
+
+func (bf *blockFactory) AddCollectionConfigUpdateTxn(txID string, chaincodeName string) *blockFactory {
+	txrws := rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: privdata.LSCCNamespace,
+				KvRwSet: &kvrwset.KVRWSet{
+					Writes: []*kvrwset.KVWrite{
+						{Key: chaincodeName, Value: []byte("chaincode data")},
+						{Key: chaincodeName + "~collection", Value: []byte("collection config")},
+					},
+				},
+			},
+		},
+	}
+	return bf.addTxnWithRWSet(txID, txrws, "")
+}
 
+func (bf *blockFactory) addTxnWithRWSet(txID string, txrws rwsetutil.TxRwSet, org string) *blockFactory {
+	txn := &peer.Transaction{
+		Actions: []*peer.TransactionAction{
+			{},
+		},
+	}
 	b, err := txrws.ToProtoBytes()
 	if err != nil {
 		panic(err)
@@ -9,6 +9,7 @@ package service
 import (
 	"sync"
 
+	"github.com/sinochem-tech/fabric/common/metrics"
 	"github.com/sinochem-tech/fabric/core/committer"
 	"github.com/sinochem-tech/fabric/core/committer/txvalidator"
 	"github.com/sinochem-tech/fabric/core/common/privdata"
@@ -76,10 +77,14 @@ type privateHandler struct {
 	support     Support
 	coordinator privdata2.Coordinator
 	distributor privdata2.PvtDataDistributor
+	reconciler  privdata2.PvtDataReconciler
+	backfiller  privdata2.PvtDataBackfiller
 }
 
 func (p privateHandler) close() {
 	p.coordinator.Close()
+	p.reconciler.Stop()
+	p.backfiller.Stop()
 }
 
 type gossipServiceImpl struct {
@@ -205,6 +210,8 @@ type Support struct {
 	Store                privdata2.TransientStore
 	Cs                   privdata.CollectionStore
 	IdDeserializeFactory privdata2.IdentityDeserializerFactory
+	// ImplicitCollectionPolicy is the dissemination policy of the private data of the implicit collections
+	ImplicitCollectionPolicy privdata.ImplicitCollectionDisseminationPolicy
 }
 
 // DataStoreSupport aggregates interfaces capable
@@ -230,7 +237,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 		Committer:      support.Committer,
 	}
 	// Initialize private data fetcher
-	dataRetriever := privdata2.NewDataRetriever(storeSupport)
+	dataRetriever := privdata2.NewDataRetriever(storeSupport, support.ImplicitCollectionPolicy)
 	collectionAccessFactory := privdata2.NewCollectionAccessFactory(support.IdDeserializeFactory)
 	fetcher := privdata2.NewPuller(support.Cs, g.gossipSvc, dataRetriever, collectionAccessFactory, chainID)
 
@@ -242,11 +249,29 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 		Fetcher:         fetcher,
 	}, g.createSelfSignedData())
 
+	var reconcilerScope metrics.Scope
+	if metrics.RootScope != nil {
+		reconcilerScope = metrics.RootScope.SubScope("gossip_privdata").Tagged(map[string]string{"channel": chainID})
+	}
+	reconciler := privdata2.NewReconciler(chainID, privdata2.ReconcilerSupport{
+		CollectionStore: support.Cs,
+		Committer:       support.Committer,
+		Fetcher:         fetcher,
+	}, reconcilerScope, privdata2.GetReconcilerConfig())
+	backfiller := privdata2.NewBackfiller(chainID, privdata2.BackfillerSupport{
+		Committer:                   support.Committer,
+		IdentityDeserializerFactory: support.IdDeserializeFactory,
+	}, g.createSelfSignedData(), privdata2.GetBackfillerConfig())
+
 	g.privateHandlers[chainID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
 		distributor: privdata2.NewDistributor(chainID, g, collectionAccessFactory),
+		reconciler:  reconciler,
+		backfiller:  backfiller,
 	}
+	reconciler.Start()
+	backfiller.Start()
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator)
 	if g.deliveryService[chainID] == nil {
 		var err error
@@ -274,7 +299,8 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 
 		if leaderElection {
 			logger.Debug("Delivery uses dynamic leader election mechanism, channel", chainID)
-			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID, support.Committer))
+			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID, support.Committer),
+				ledgerAccessibleCheck(support.Committer))
 		} else if isStaticOrgLeader {
 			logger.Debug("This peer is configured to connect to ordering service for blocks delivery, channel", chainID)
 			g.deliveryService[chainID].StartDeliverForChannel(chainID, support.Committer, func() {})
@@ -364,12 +390,71 @@ func (g *gossipServiceImpl) Stop() {
 	g.gossipSvc.Stop()
 }
 
-func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool)) election.LeaderElectionService {
+// UpdateIdentity replaces the identity of the peer with the given one, and restarts
+// the leader election of the channels so that the peer takes part in it with its new identity.
+// The PKI-ID of the peer changes along with its identity, and the previous one remains in the
+// membership of remote peers until its alive messages expire.
+func (g *gossipServiceImpl) UpdateIdentity(identity api.PeerIdentityType) error {
+	if err := g.gossipSvc.UpdateIdentity(identity); err != nil {
+		return err
+	}
+
+	g.lock.Lock()
+	g.peerIdentity = identity
+	leaderElection := make(map[string]election.LeaderElectionService, len(g.leaderElection))
+	for chainID, le := range g.leaderElection {
+		leaderElection[chainID] = le
+	}
+	g.lock.Unlock()
+
+	// The leader election components and the delivery are stopped without holding
+	// the lock, as the delivery service may concurrently yield the leadership
+	for chainID, le := range leaderElection {
+		g.lock.RLock()
+		ds := g.deliveryService[chainID]
+		handler, exists := g.privateHandlers[chainID]
+		g.lock.RUnlock()
+		if !exists {
+			logger.Warning("Channel", chainID, "has no committer, not restarting its leader election")
+			continue
+		}
+		committer := handler.support.Committer
+		wasLeader := le.IsLeader()
+		le.Stop()
+		if wasLeader && ds != nil {
+			logger.Info("Restarting leader election with the new identity, stopping delivery service for channel", chainID)
+			if err := ds.StopDeliverForChannel(chainID); err != nil {
+				logger.Errorf("Delivery service is not able to stop blocks delivery for chain, due to %+v", errors.WithStack(err))
+			}
+		}
+		le = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID, committer), ledgerAccessibleCheck(committer))
+		g.lock.Lock()
+		g.leaderElection[chainID] = le
+		g.lock.Unlock()
+	}
+	return nil
+}
+
+func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool), healthCheck election.HealthCheck) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
-	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID))
+	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID), healthCheck)
 	return election.NewLeaderElectionService(adapter, string(PKIid), callback)
 }
 
+// ledgerAccessibleCheck returns a health check which deems the peer
+// fit to be the leader as long as its ledger is accessible.
+// Whether the ledger keeps up with the channel isn't checked here,
+// since the election already prefers peers whose ledger doesn't lag behind
+func ledgerAccessibleCheck(ledger blocksprovider.LedgerInfo) election.HealthCheck {
+	return func() bool {
+		if _, err := ledger.LedgerHeight(); err != nil {
+			logger.Warningf("Ledger is inaccessible, the peer isn't fit to be the leader: %+v", err)
+			return false
+		}
+		return true
+	}
+}
+
 func (g *gossipServiceImpl) amIinChannel(myOrg string, config Config) bool {
 	for _, orgName := range orgListFromConfig(config) {
 		if orgName == myOrg {
@@ -35,6 +35,7 @@ import (
 	"github.com/sinochem-tech/fabric/peer/gossip/mocks"
 	"github.com/sinochem-tech/fabric/protos/common"
 	"github.com/sinochem-tech/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -342,6 +343,26 @@ func (li *mockLedgerInfo) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetri
 	panic("implement me")
 }
 
+func (li *mockLedgerInfo) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	return nil, nil
+}
+
+func (li *mockLedgerInfo) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	panic("implement me")
+}
+
+func (li *mockLedgerInfo) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	panic("implement me")
+}
+
+func (li *mockLedgerInfo) GetPvtDataBackfillHeight() (uint64, error) {
+	return 0, nil
+}
+
+func (li *mockLedgerInfo) SetPvtDataBackfillHeight(height uint64) error {
+	return nil
+}
+
 func (li *mockLedgerInfo) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
 	panic("implement me")
 }
@@ -403,7 +424,7 @@ func TestLeaderElectionWithRealGossip(t *testing.T) {
 
 	for i := 0; i < n; i++ {
 		services[i] = &electionService{nil, false, 0}
-		services[i].LeaderElectionService = gossips[i].(*gossipServiceImpl).newLeaderElectionComponent(channelName, services[i].callback)
+		services[i].LeaderElectionService = gossips[i].(*gossipServiceImpl).newLeaderElectionComponent(channelName, services[i].callback, nil)
 	}
 
 	logger.Warning("Waiting for leader election")
@@ -429,7 +450,7 @@ func TestLeaderElectionWithRealGossip(t *testing.T) {
 
 	for idx, i := range secondChannelPeerIndexes {
 		secondChannelServices[idx] = &electionService{nil, false, 0}
-		secondChannelServices[idx].LeaderElectionService = gossips[i].(*gossipServiceImpl).newLeaderElectionComponent(secondChannelName, secondChannelServices[idx].callback)
+		secondChannelServices[idx].LeaderElectionService = gossips[i].(*gossipServiceImpl).newLeaderElectionComponent(secondChannelName, secondChannelServices[idx].callback, nil)
 	}
 
 	assert.True(t, waitForLeaderElection(t, secondChannelServices, time.Second*30, time.Second*2), "One leader should be selected for chanB")
@@ -484,6 +505,46 @@ func TestLeaderElectionWithRealGossip(t *testing.T) {
 	stopPeers(gossips[2:])
 }
 
+func TestUpdateIdentity(t *testing.T) {
+	g := &gossipMock{}
+	gService := &gossipServiceImpl{
+		gossipSvc:       g,
+		leaderElection:  make(map[string]election.LeaderElectionService),
+		privateHandlers: make(map[string]privateHandler),
+		deliveryService: make(map[string]deliverclient.DeliverService),
+	}
+
+	// Failing to update the identity of gossip leaves the peer as is
+	g.On("UpdateIdentity", api.PeerIdentityType("failing")).Return(errors.New("foo")).Once()
+	assert.EqualError(t, gService.UpdateIdentity(api.PeerIdentityType("failing")), "foo")
+	assert.Nil(t, gService.peerIdentity)
+
+	// The leader election of a channel whose private data handler
+	// isn't initialized yet is left untouched
+	le := &leaderElectionMock{}
+	gService.leaderElection["A"] = le
+	g.On("UpdateIdentity", api.PeerIdentityType("new")).Return(nil).Once()
+	assert.NoError(t, gService.UpdateIdentity(api.PeerIdentityType("new")))
+	assert.Equal(t, []byte("new"), gService.peerIdentity)
+	assert.False(t, le.stopped)
+	assert.Equal(t, le, gService.leaderElection["A"])
+}
+
+type leaderElectionMock struct {
+	stopped bool
+}
+
+func (le *leaderElectionMock) IsLeader() bool {
+	return false
+}
+
+func (le *leaderElectionMock) Stop() {
+	le.stopped = true
+}
+
+func (le *leaderElectionMock) Yield() {
+}
+
 type electionService struct {
 	election.LeaderElectionService
 	callbackInvokeRes   bool
@@ -84,6 +84,12 @@ func (*gossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common
 	panic("implement me")
 }
 
+// UpdateLoadHints updates the load hints the peer publishes
+// to other peers in the channel
+func (*gossipMock) UpdateLoadHints(loadHints *proto.LoadHints, chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
@@ -104,6 +110,11 @@ func (g *gossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("implement me")
 }
 
+func (g *gossipMock) UpdateIdentity(identity api.PeerIdentityType) error {
+	args := g.Called(identity)
+	return args.Error(0)
+}
+
 func (*gossipMock) Stop() {
 	panic("implement me")
 }
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"time"
+)
+
+// batchSizer adapts the number of blocks requested in a single state transfer
+// request to the throughput observed for the previous requests: the batch size
+// grows as long as the throughput doesn't degrade, and shrinks otherwise, or
+// when a request fails
+type batchSizer struct {
+	sync.Mutex
+	min, max   uint64
+	current    uint64
+	throughput float64
+}
+
+func newBatchSizer(min, max uint64) *batchSizer {
+	return &batchSizer{min: min, max: max, current: min}
+}
+
+// size returns the current batch size
+func (b *batchSizer) size() uint64 {
+	b.Lock()
+	defer b.Unlock()
+	return b.current
+}
+
+// succeeded records that the given number of blocks were received in the given time
+func (b *batchSizer) succeeded(blocks uint64, elapsed time.Duration) {
+	if blocks == 0 || elapsed <= 0 {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	throughput := float64(blocks) / elapsed.Seconds()
+	// small fluctuations of the throughput are tolerated
+	if throughput >= b.throughput*0.9 {
+		b.grow()
+	} else {
+		b.shrink()
+	}
+	b.throughput = throughput
+}
+
+// failed records that a request failed
+func (b *batchSizer) failed() {
+	b.Lock()
+	defer b.Unlock()
+	b.shrink()
+}
+
+func (b *batchSizer) grow() {
+	b.current *= 2
+	if b.current > b.max {
+		b.current = b.max
+	}
+}
+
+func (b *batchSizer) shrink() {
+	b.current /= 2
+	if b.current < b.min {
+		b.current = b.min
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSizer(t *testing.T) {
+	b := newBatchSizer(10, 50)
+	assert.Equal(t, uint64(10), b.size())
+
+	// The batch size grows as long as the throughput doesn't degrade, up to the maximum
+	b.succeeded(10, time.Second)
+	assert.Equal(t, uint64(20), b.size())
+	b.succeeded(20, time.Second)
+	assert.Equal(t, uint64(40), b.size())
+	b.succeeded(40, time.Second)
+	assert.Equal(t, uint64(50), b.size())
+	// Small fluctuations of the throughput are tolerated
+	b.succeeded(38, time.Second)
+	assert.Equal(t, uint64(50), b.size())
+
+	// The batch size shrinks when the throughput degrades
+	b.succeeded(10, time.Second)
+	assert.Equal(t, uint64(25), b.size())
+
+	// and when a request fails, down to the minimum
+	b.failed()
+	assert.Equal(t, uint64(12), b.size())
+	b.failed()
+	assert.Equal(t, uint64(10), b.size())
+
+	// Responses without blocks don't affect the batch size
+	b.succeeded(0, time.Second)
+	assert.Equal(t, uint64(10), b.size())
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	corecomm "github.com/sinochem-tech/fabric/core/comm"
+	proto "github.com/sinochem-tech/fabric/protos/gossip"
+)
+
+// maxDecompressedResponseSize returns the maximum size of the serialized payloads of a
+// compressed state response, which guards against maliciously crafted responses.
+// The payloads of uncompressed responses are bounded by the maximum size of the
+// gRPC messages the peer receives, so are those of compressed ones.
+func maxDecompressedResponseSize() int {
+	return corecomm.MaxRecvMsgSize
+}
+
+// compressionTypeByName returns the compression type with the given name, which is case insensitive
+func compressionTypeByName(name string) (proto.CompressionType, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return proto.CompressionType_NO_COMPRESSION, nil
+	case "gzip":
+		return proto.CompressionType_GZIP, nil
+	case "snappy":
+		return proto.CompressionType_SNAPPY, nil
+	default:
+		return proto.CompressionType_NO_COMPRESSION, errors.Errorf("unknown compression type %s", name)
+	}
+}
+
+// selectCompression returns the first of the given compression types that is supported
+func selectCompression(accepted []proto.CompressionType) proto.CompressionType {
+	for _, compression := range accepted {
+		switch compression {
+		case proto.CompressionType_GZIP, proto.CompressionType_SNAPPY:
+			return compression
+		}
+	}
+	return proto.CompressionType_NO_COMPRESSION
+}
+
+// compressResponse returns a state response which holds the given payloads compressed with the given
+// compression type, or the payloads as is if the compression type is NO_COMPRESSION
+func compressResponse(payloads []*proto.Payload, compression proto.CompressionType) (*proto.RemoteStateResponse, error) {
+	if compression == proto.CompressionType_NO_COMPRESSION {
+		return &proto.RemoteStateResponse{Payloads: payloads}, nil
+	}
+	serialized, err := pb.Marshal(&proto.RemoteStateResponse{Payloads: payloads})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling payloads")
+	}
+	var compressed []byte
+	switch compression {
+	case proto.CompressionType_GZIP:
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(serialized); err != nil {
+			return nil, errors.Wrap(err, "failed compressing payloads")
+		}
+		if err := w.Close(); err != nil {
+			return nil, errors.Wrap(err, "failed compressing payloads")
+		}
+		compressed = buf.Bytes()
+	case proto.CompressionType_SNAPPY:
+		compressed = snappy.Encode(nil, serialized)
+	default:
+		return nil, errors.Errorf("unsupported compression type %s", compression)
+	}
+	return &proto.RemoteStateResponse{Compression: compression, CompressedPayloads: compressed}, nil
+}
+
+// responsePayloads returns the payloads of the given state response, decompressing them if needed
+func responsePayloads(response *proto.RemoteStateResponse) ([]*proto.Payload, error) {
+	var serialized []byte
+	maxSize := maxDecompressedResponseSize()
+	switch response.Compression {
+	case proto.CompressionType_NO_COMPRESSION:
+		return response.Payloads, nil
+	case proto.CompressionType_GZIP:
+		r, err := gzip.NewReader(bytes.NewReader(response.CompressedPayloads))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing payloads")
+		}
+		// read one more byte than allowed, to detect responses that exceed the limit
+		serialized, err = ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing payloads")
+		}
+	case proto.CompressionType_SNAPPY:
+		size, err := snappy.DecodedLen(response.CompressedPayloads)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing payloads")
+		}
+		if size > maxSize {
+			return nil, errors.Errorf("decompressed payloads exceed %d bytes", maxSize)
+		}
+		serialized, err = snappy.Decode(nil, response.CompressedPayloads)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing payloads")
+		}
+	default:
+		return nil, errors.Errorf("unsupported compression type %s", response.Compression)
+	}
+	if len(serialized) > maxSize {
+		return nil, errors.Errorf("decompressed payloads exceed %d bytes", maxSize)
+	}
+	decompressed := &proto.RemoteStateResponse{}
+	if err := pb.Unmarshal(serialized, decompressed); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling decompressed payloads")
+	}
+	return decompressed.Payloads, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/snappy"
+	corecomm "github.com/sinochem-tech/fabric/core/comm"
+	proto "github.com/sinochem-tech/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressionTypeByName(t *testing.T) {
+	for name, expected := range map[string]proto.CompressionType{
+		"":       proto.CompressionType_NO_COMPRESSION,
+		"none":   proto.CompressionType_NO_COMPRESSION,
+		"GZIP":   proto.CompressionType_GZIP,
+		"snappy": proto.CompressionType_SNAPPY,
+	} {
+		compression, err := compressionTypeByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, compression)
+	}
+	_, err := compressionTypeByName("lz4")
+	assert.EqualError(t, err, "unknown compression type lz4")
+}
+
+func TestSelectCompression(t *testing.T) {
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, selectCompression(nil))
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, selectCompression([]proto.CompressionType{proto.CompressionType(42)}))
+	assert.Equal(t, proto.CompressionType_SNAPPY, selectCompression([]proto.CompressionType{proto.CompressionType(42), proto.CompressionType_SNAPPY, proto.CompressionType_GZIP}))
+	assert.Equal(t, proto.CompressionType_GZIP, selectCompression([]proto.CompressionType{proto.CompressionType_GZIP}))
+}
+
+func TestCompressResponse(t *testing.T) {
+	var payloads []*proto.Payload
+	for i := uint64(1); i <= 10; i++ {
+		payload, err := randomPayloadWithSeqNum(i)
+		assert.NoError(t, err)
+		payload.PrivateData = [][]byte{{1, 2, 3}}
+		payloads = append(payloads, payload)
+	}
+
+	for _, compression := range []proto.CompressionType{proto.CompressionType_NO_COMPRESSION, proto.CompressionType_GZIP, proto.CompressionType_SNAPPY} {
+		response, err := compressResponse(payloads, compression)
+		assert.NoError(t, err)
+		assert.Equal(t, compression, response.Compression)
+		if compression != proto.CompressionType_NO_COMPRESSION {
+			assert.Empty(t, response.Payloads)
+			assert.NotEmpty(t, response.CompressedPayloads)
+		}
+		decompressed, err := responsePayloads(response)
+		assert.NoError(t, err)
+		assert.Len(t, decompressed, len(payloads))
+		for i := range payloads {
+			assert.Equal(t, payloads[i].SeqNum, decompressed[i].SeqNum)
+			assert.Equal(t, payloads[i].Data, decompressed[i].Data)
+			assert.Equal(t, payloads[i].PrivateData, decompressed[i].PrivateData)
+		}
+	}
+
+	_, err := compressResponse(payloads, proto.CompressionType(42))
+	assert.EqualError(t, err, "unsupported compression type 42")
+}
+
+func TestResponsePayloadsMalformed(t *testing.T) {
+	_, err := responsePayloads(&proto.RemoteStateResponse{Compression: proto.CompressionType_GZIP, CompressedPayloads: []byte{1, 2, 3}})
+	assert.Contains(t, err.Error(), "failed decompressing payloads")
+
+	_, err = responsePayloads(&proto.RemoteStateResponse{Compression: proto.CompressionType_SNAPPY, CompressedPayloads: []byte{1, 2, 3}})
+	assert.Contains(t, err.Error(), "failed decompressing payloads")
+
+	_, err = responsePayloads(&proto.RemoteStateResponse{Compression: proto.CompressionType_SNAPPY, CompressedPayloads: snappy.Encode(nil, []byte{0xff, 0xff})})
+	assert.Contains(t, err.Error(), "failed unmarshaling decompressed payloads")
+
+	_, err = responsePayloads(&proto.RemoteStateResponse{Compression: proto.CompressionType(42)})
+	assert.EqualError(t, err, "unsupported compression type 42")
+}
+
+func TestResponsePayloadsTooLarge(t *testing.T) {
+	// The payloads are smaller once compressed, but exceed the maximum size of
+	// the received gRPC messages once decompressed
+	payloads := []*proto.Payload{{SeqNum: 1, Data: make([]byte, 1024)}}
+	defer func(maxRecvMsgSize int) {
+		corecomm.MaxRecvMsgSize = maxRecvMsgSize
+	}(corecomm.MaxRecvMsgSize)
+	corecomm.MaxRecvMsgSize = 512
+
+	for _, compression := range []proto.CompressionType{proto.CompressionType_GZIP, proto.CompressionType_SNAPPY} {
+		response, err := compressResponse(payloads, compression)
+		assert.NoError(t, err)
+		assert.True(t, len(response.CompressedPayloads) < 512)
+		_, err = responsePayloads(response)
+		assert.EqualError(t, err, "decompressed payloads exceed 512 bytes")
+	}
+}
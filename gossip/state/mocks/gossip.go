@@ -49,6 +49,12 @@ func (g *GossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID comm
 
 }
 
+// UpdateLoadHints updates the load hints the peer publishes
+// to other peers in the channel
+func (g *GossipMock) UpdateLoadHints(loadHints *proto.LoadHints, chainID common.ChainID) {
+
+}
+
 func (g *GossipMock) LeaveChan(_ common.ChainID) {
 	panic("implement me")
 }
@@ -90,6 +96,11 @@ func (g *GossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("not implemented")
 }
 
+// UpdateIdentity replaces the identity of the peer with the given one
+func (g *GossipMock) UpdateIdentity(identity api.PeerIdentityType) error {
+	panic("not implemented")
+}
+
 func (g *GossipMock) Stop() {
 
 }
@@ -8,6 +8,8 @@ package state
 
 import (
 	"bytes"
+	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -47,6 +49,14 @@ const (
 
 	defMaxBlockDistance = 100
 
+	defMaxAntiEntropyBatchSize   = 50
+	defMaxParallelStateRequests  = 2
+	defStateResponseCompression  = "snappy"
+	minBatchSizeConfigKey        = "peer.gossip.state.minBatchSize"
+	maxBatchSizeConfigKey        = "peer.gossip.state.maxBatchSize"
+	maxParallelRequestsConfigKey = "peer.gossip.state.maxParallelRequests"
+	responseCompressionConfigKey = "peer.gossip.state.compression"
+
 	blocking    = true
 	nonBlocking = false
 
@@ -110,6 +120,51 @@ type ledgerResources interface {
 	Close()
 }
 
+// stateTransferConfig holds the configuration of the state transfer
+type stateTransferConfig struct {
+	// minBatchSize and maxBatchSize bound the number of blocks
+	// requested from a peer in a single state request
+	minBatchSize uint64
+	maxBatchSize uint64
+	// maxParallelRequests is the maximum number of state
+	// requests sent in parallel, each to a different peer
+	maxParallelRequests int
+	// compression is the compression the state responses are requested to be compressed with
+	compression proto.CompressionType
+}
+
+func readStateTransferConfig() *stateTransferConfig {
+	conf := &stateTransferConfig{
+		minBatchSize:        defAntiEntropyBatchSize,
+		maxBatchSize:        defMaxAntiEntropyBatchSize,
+		maxParallelRequests: defMaxParallelStateRequests,
+	}
+	if viper.IsSet(minBatchSizeConfigKey) && viper.GetInt(minBatchSizeConfigKey) > 0 {
+		conf.minBatchSize = uint64(viper.GetInt(minBatchSizeConfigKey))
+	}
+	if viper.IsSet(maxBatchSizeConfigKey) && viper.GetInt(maxBatchSizeConfigKey) > 0 {
+		conf.maxBatchSize = uint64(viper.GetInt(maxBatchSizeConfigKey))
+	}
+	if conf.maxBatchSize < conf.minBatchSize {
+		logger.Warningf("%s (%d) is smaller than %s (%d), using the latter", maxBatchSizeConfigKey, conf.maxBatchSize,
+			minBatchSizeConfigKey, conf.minBatchSize)
+		conf.maxBatchSize = conf.minBatchSize
+	}
+	if viper.IsSet(maxParallelRequestsConfigKey) && viper.GetInt(maxParallelRequestsConfigKey) > 0 {
+		conf.maxParallelRequests = viper.GetInt(maxParallelRequestsConfigKey)
+	}
+	compressionName := defStateResponseCompression
+	if viper.IsSet(responseCompressionConfigKey) {
+		compressionName = viper.GetString(responseCompressionConfigKey)
+	}
+	compression, err := compressionTypeByName(compressionName)
+	if err != nil {
+		logger.Warningf("Invalid %s: %s, state responses won't be compressed", responseCompressionConfigKey, err)
+	}
+	conf.compression = compression
+	return conf
+}
+
 // ServicesMediator aggregated adapter to compound all mediator
 // required by state transfer into single struct
 type ServicesMediator struct {
@@ -136,10 +191,24 @@ type GossipStateProviderImpl struct {
 
 	ledger ledgerResources
 
-	stateResponseCh chan proto.ReceivedMessage
+	// pendingResponses maps the nonces of the outstanding
+	// state requests to the channels awaiting their responses
+	pendingResponses map[uint64]chan proto.ReceivedMessage
+
+	pendingResponsesLock sync.Mutex
 
 	stateRequestCh chan proto.ReceivedMessage
 
+	config *stateTransferConfig
+
+	batchSizer *batchSizer
+
+	// maxBatchSizes maps the PKI-IDs of the peers to the
+	// maximal batch sizes they advertise in their state responses
+	maxBatchSizes map[string]uint64
+
+	maxBatchSizesLock sync.RWMutex
+
 	stopCh chan struct{}
 
 	done sync.WaitGroup
@@ -215,7 +284,9 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		ledger: ledger,
 
-		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		pendingResponses: make(map[uint64]chan proto.ReceivedMessage),
+
+		maxBatchSizes: make(map[string]uint64),
 
 		stateRequestCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
 
@@ -225,6 +296,8 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		once: sync.Once{},
 	}
+	s.config = readStateTransferConfig()
+	s.batchSizer = newBatchSizer(s.config.minBatchSize, s.config.maxBatchSize)
 
 	logger.Infof("Updating metadata information, "+
 		"current ledger sequence is at = %d, next expected block is = %d", height-1, s.payloads.Next())
@@ -355,7 +428,7 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 		// no reason to process the message
 		if atomic.LoadInt32(&s.stateTransferActive) == 1 {
 			// Send signal of state response message
-			s.stateResponseCh <- msg
+			s.routeStateResponse(msg)
 		}
 	}
 }
@@ -382,31 +455,32 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	}
 	request := msg.GetGossipMessage().GetStateRequest()
 
-	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
-		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
-		return
-	}
-
 	if request.StartSeqNum > request.EndSeqNum {
 		logger.Errorf("Invalid sequence interval [%d...%d], ignoring request...", request.StartSeqNum, request.EndSeqNum)
 		return
 	}
 
+	// The requesting peer asks for the rest of the blocks in its next request
+	requestEndSeqNum := request.EndSeqNum
+	if batchSize := request.EndSeqNum - request.StartSeqNum; batchSize > s.config.maxBatchSize {
+		logger.Debugf("Requesting blocks batchSize size (%d) greater than configured allowed"+
+			" (%d) batching for anti-entropy, sending only the first blocks", batchSize, s.config.maxBatchSize)
+		requestEndSeqNum = request.StartSeqNum + s.config.maxBatchSize
+	}
+
 	currentHeight, err := s.ledger.LedgerHeight()
 	if err != nil {
 		logger.Errorf("Cannot access to current ledger height, due to %+v", errors.WithStack(err))
 		return
 	}
-	if currentHeight < request.EndSeqNum {
+	if currentHeight < requestEndSeqNum {
 		logger.Warningf("Received state request to transfer blocks with sequence numbers higher  [%d...%d] "+
 			"than available in ledger (%d)", request.StartSeqNum, request.StartSeqNum, currentHeight)
 	}
 
-	endSeqNum := min(currentHeight, request.EndSeqNum)
+	endSeqNum := min(currentHeight, requestEndSeqNum)
 
-	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
+	payloads := make([]*proto.Payload, 0)
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
 		logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
 		connInfo := msg.GetConnectionInfo()
@@ -445,12 +519,20 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 		}
 
 		// Appending result to the response
-		response.Payloads = append(response.Payloads, &proto.Payload{
+		payloads = append(payloads, &proto.Payload{
 			SeqNum:      seqNum,
 			Data:        blockBytes,
 			PrivateData: pvtBytes,
 		})
 	}
+
+	compression := selectCompression(request.AcceptedCompressions)
+	response, err := compressResponse(payloads, compression)
+	if err != nil {
+		logger.Warningf("Failed compressing state response with %s, sending it uncompressed: %+v", compression, err)
+		response = &proto.RemoteStateResponse{Payloads: payloads}
+	}
+	response.MaxBatchSize = s.config.maxBatchSize
 	// Sending back response with missing blocks
 	msg.Respond(&proto.GossipMessage{
 		// Copy nonce field from the request, so it will be possible to match response
@@ -465,11 +547,15 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg proto.ReceivedMessage)
 	max := uint64(0)
 	// Send signal that response for given nonce has been received
 	response := msg.GetGossipMessage().GetStateResponse()
+	payloads, err := responsePayloads(response)
+	if err != nil {
+		return uint64(0), errors.WithMessage(err, "Received malformed state transfer response")
+	}
 	// Extract payloads, verify and push into buffer
-	if len(response.GetPayloads()) == 0 {
+	if len(payloads) == 0 {
 		return uint64(0), errors.New("Received state transfer response without payload")
 	}
-	for _, payload := range response.GetPayloads() {
+	for _, payload := range payloads {
 		logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
 		if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
 			err = errors.WithStack(err)
@@ -499,7 +585,6 @@ func (s *GossipStateProviderImpl) Stop() {
 		// Close all resources
 		s.ledger.Close()
 		close(s.stateRequestCh)
-		close(s.stateResponseCh)
 		close(s.stopCh)
 	})
 }
@@ -619,61 +704,197 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 }
 
 // GetBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end].
+// numbers in the range [start...end]. The blocks are requested in
+// batches, several of which are requested in parallel from different peers
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
 	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
-
-		responseReceived := false
-		tryCounts := 0
+		batches := s.nextBatches(prev, end)
+		results := make([]batchResult, len(batches))
+		// Each batch starts from a different peer, so the batches are requested from distinct peers
+		offset := util.RandomInt(math.MaxInt16)
+		var wg sync.WaitGroup
+		wg.Add(len(batches))
+		for i, batch := range batches {
+			go func(i int, batch blockRange) {
+				defer wg.Done()
+				results[i] = s.requestBatch(batch, offset+i)
+			}(i, batch)
+		}
+		wg.Wait()
 
-		for !responseReceived {
-			if tryCounts > defAntiEntropyMaxRetries {
-				logger.Warningf("Wasn't  able to get blocks in range [%d...%d], after %d retries",
-					prev, next, tryCounts)
+		// Continue from the first block that wasn't received, the blocks
+		// received beyond it are already in the payloads buffer
+		for i, result := range results {
+			if result.stopped {
 				return
 			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				logger.Warningf("Cannot send state request for blocks in range [%d...%d], due to %+v",
-					prev, next, errors.WithStack(err))
-				return
+			if result.err != nil {
+				if i == 0 {
+					logger.Warningf("Wasn't  able to get blocks in range [%d...%d], due to %+v",
+						batches[i].start, batches[i].end, result.err)
+					return
+				}
+				prev = batches[i].start
+				break
+			}
+			prev = result.maxSeq + 1
+			if result.maxSeq < batches[i].end {
+				break
 			}
+		}
+	}
+}
 
-			logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
+// blockRange is a range [start...end] of block sequence numbers
+type blockRange struct {
+	start, end uint64
+}
 
-			s.mediator.Send(gossipMsg, peer)
-			tryCounts++
+// batchResult is the outcome of a state request of a batch of blocks
+type batchResult struct {
+	// maxSeq is the highest sequence number of the blocks received
+	maxSeq uint64
+	err    error
+	// stopped indicates that the state provider was stopped
+	stopped bool
+}
 
-			// Wait until timeout or response arrival
-			select {
-			case msg := <-s.stateResponseCh:
-				if msg.GetGossipMessage().Nonce != gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
-					continue
-				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(defAntiEntropyStateResponseTimeout):
-			case <-s.stopCh:
-				s.stopCh <- struct{}{}
-				return
+// nextBatches splits the blocks starting from the given sequence number into at most
+// maxParallelRequests batches, that are covered by at most defMaxBlockDistance blocks,
+// since the blocks of the later batches wait in the payloads buffer for the earlier ones
+func (s *GossipStateProviderImpl) nextBatches(start uint64, end uint64) []blockRange {
+	batchSize := s.batchSizer.size()
+	var batches []blockRange
+	for prev := start; prev <= end && len(batches) < s.config.maxParallelRequests; {
+		if len(batches) > 0 && prev+batchSize-start >= defMaxBlockDistance {
+			break
+		}
+		next := min(end, prev+batchSize)
+		batches = append(batches, blockRange{start: prev, end: next})
+		prev = next + 1
+	}
+	return batches
+}
+
+// requestBatch requests the given batch of blocks, retrying with the next peer on failure
+func (s *GossipStateProviderImpl) requestBatch(batch blockRange, peerIndex int) batchResult {
+	var lastErr error
+	for tryCounts := 0; tryCounts <= defAntiEntropyMaxRetries; tryCounts++ {
+		// Select peer to ask for blocks
+		peer, err := s.selectPeerToRequestFrom(batch.end, peerIndex+tryCounts)
+		if err != nil {
+			return batchResult{err: errors.WithMessage(err, "cannot send state request")}
+		}
+
+		// The batch is truncated to the batch size the peer serves, and the
+		// blocks beyond it are requested along with the next batches
+		end := min(batch.end, batch.start+s.maxBatchSizeOf(peer.PKIID))
+
+		logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+			"for chainID %s", peer.Endpoint, batch.start, end, s.chainID)
+
+		gossipMsg := s.stateRequestMessage(batch.start, end)
+		responseCh := s.awaitStateResponse(gossipMsg.Nonce)
+		sentAt := time.Now()
+		s.mediator.Send(gossipMsg, peer)
+
+		// Wait until timeout or response arrival
+		select {
+		case msg := <-responseCh:
+			s.stopAwaitingStateResponse(gossipMsg.Nonce)
+			s.recordMaxBatchSize(peer.PKIID, msg.GetGossipMessage().GetStateResponse().GetMaxBatchSize())
+			// Got corresponding response for state request, can continue
+			index, err := s.handleStateResponse(msg)
+			if err != nil {
+				lastErr = errors.WithMessage(err, "wasn't able to process state response")
+				logger.Warningf("Wasn't able to process state response for "+
+					"blocks [%d...%d], due to %+v", batch.start, end, err)
+				s.batchSizer.failed()
+				continue
+			}
+			if index >= batch.start {
+				s.batchSizer.succeeded(index-batch.start+1, time.Since(sentAt))
 			}
+			return batchResult{maxSeq: index}
+		case <-time.After(defAntiEntropyStateResponseTimeout):
+			s.stopAwaitingStateResponse(gossipMsg.Nonce)
+			lastErr = errors.Errorf("timed out waiting for state response from %s", peer.Endpoint)
+			s.batchSizer.failed()
+		case <-s.stopCh:
+			s.stopAwaitingStateResponse(gossipMsg.Nonce)
+			s.stopCh <- struct{}{}
+			return batchResult{stopped: true}
 		}
 	}
+	return batchResult{err: errors.WithMessage(lastErr, fmt.Sprintf("failed after %d retries", defAntiEntropyMaxRetries))}
+}
+
+// maxBatchSizeOf returns the maximal batch size of the state requests sent to the peer with the given
+// PKI-ID. Peers which don't advertise their maximal batch size reject state requests above the default
+// batch size, hence they are sent state requests of the default batch size at most
+func (s *GossipStateProviderImpl) maxBatchSizeOf(pkiID common2.PKIidType) uint64 {
+	s.maxBatchSizesLock.RLock()
+	defer s.maxBatchSizesLock.RUnlock()
+	if maxBatchSize, exists := s.maxBatchSizes[string(pkiID)]; exists {
+		return maxBatchSize
+	}
+	return defAntiEntropyBatchSize
+}
+
+// recordMaxBatchSize records the maximal batch size advertised by the peer with the given PKI-ID.
+// The peers which are no longer members of the channel are forgotten along the way
+func (s *GossipStateProviderImpl) recordMaxBatchSize(pkiID common2.PKIidType, maxBatchSize uint64) {
+	if maxBatchSize == 0 {
+		return
+	}
+	s.maxBatchSizesLock.Lock()
+	defer s.maxBatchSizesLock.Unlock()
+	if _, exists := s.maxBatchSizes[string(pkiID)]; !exists {
+		members := make(map[string]struct{})
+		for _, member := range s.mediator.PeersOfChannel(common2.ChainID(s.chainID)) {
+			members[string(member.PKIid)] = struct{}{}
+		}
+		for id := range s.maxBatchSizes {
+			if _, isMember := members[id]; !isMember {
+				delete(s.maxBatchSizes, id)
+			}
+		}
+	}
+	s.maxBatchSizes[string(pkiID)] = maxBatchSize
+}
+
+// awaitStateResponse returns a channel the response to the state request with the given nonce is sent to
+func (s *GossipStateProviderImpl) awaitStateResponse(nonce uint64) <-chan proto.ReceivedMessage {
+	s.pendingResponsesLock.Lock()
+	defer s.pendingResponsesLock.Unlock()
+	responseCh := make(chan proto.ReceivedMessage, 1)
+	s.pendingResponses[nonce] = responseCh
+	return responseCh
+}
+
+func (s *GossipStateProviderImpl) stopAwaitingStateResponse(nonce uint64) {
+	s.pendingResponsesLock.Lock()
+	defer s.pendingResponsesLock.Unlock()
+	delete(s.pendingResponses, nonce)
+}
+
+// routeStateResponse sends the given state response to the request awaiting it, if any
+func (s *GossipStateProviderImpl) routeStateResponse(msg proto.ReceivedMessage) {
+	s.pendingResponsesLock.Lock()
+	defer s.pendingResponsesLock.Unlock()
+	responseCh, exists := s.pendingResponses[msg.GetGossipMessage().Nonce]
+	if !exists {
+		logger.Debug("Received state response for nonce", msg.GetGossipMessage().Nonce, "which isn't awaited, ignoring it")
+		return
+	}
+	select {
+	case responseCh <- msg:
+	default:
+		logger.Debug("Received duplicate state response for nonce", msg.GetGossipMessage().Nonce)
+	}
 }
 
 // Generate state request message for given blocks in range [beginSeq...endSeq]
@@ -684,15 +905,25 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 		Channel: []byte(s.chainID),
 		Content: &proto.GossipMessage_StateRequest{
 			StateRequest: &proto.RemoteStateRequest{
-				StartSeqNum: beginSeq,
-				EndSeqNum:   endSeq,
+				StartSeqNum:          beginSeq,
+				EndSeqNum:            endSeq,
+				AcceptedCompressions: s.acceptedCompressions(),
 			},
 		},
 	}
 }
 
-// Select peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
+// acceptedCompressions returns the compression types the state responses may be compressed with
+func (s *GossipStateProviderImpl) acceptedCompressions() []proto.CompressionType {
+	if s.config == nil || s.config.compression == proto.CompressionType_NO_COMPRESSION {
+		return nil
+	}
+	return []proto.CompressionType{s.config.compression}
+}
+
+// Select peer which has required blocks to ask missing blocks from,
+// the peer is picked by the given index among the eligible peers
+func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64, index int) (*comm.RemotePeer, error) {
 	// Filter peers which posses required range of missing blocks
 	peers := s.filterPeers(s.hasRequiredHeight(height))
 
@@ -702,7 +933,7 @@ func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.
 	}
 
 	// Select peer to ask for blocks
-	return peers[util.RandomInt(n)], nil
+	return peers[index%n], nil
 }
 
 // filterPeers return list of peers which aligns the predicate provided
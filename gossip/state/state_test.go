@@ -40,6 +40,7 @@ import (
 	"github.com/sinochem-tech/fabric/protos/ledger/rwset"
 	transientstore2 "github.com/sinochem-tech/fabric/protos/transientstore"
 	"github.com/op/go-logging"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -202,6 +203,34 @@ func (mc *mockCommitter) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetrie
 	return args.Get(0).(ledger.ConfigHistoryRetriever), args.Error(1)
 }
 
+func (mc *mockCommitter) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	args := mc.Called(blockNum, maxBlocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(ledger.MissingPvtDataInfo), args.Error(1)
+}
+
+func (mc *mockCommitter) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	args := mc.Called(blocksPvtData)
+	return args.Error(0)
+}
+
+func (mc *mockCommitter) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	args := mc.Called(missingPvtData)
+	return args.Error(0)
+}
+
+func (mc *mockCommitter) GetPvtDataBackfillHeight() (uint64, error) {
+	args := mc.Called()
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (mc *mockCommitter) SetPvtDataBackfillHeight(height uint64) error {
+	args := mc.Called(height)
+	return args.Error(0)
+}
+
 func (mc *mockCommitter) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
 	args := mc.Called(blockNum, filter)
 	return args.Get(0).([]*ledger.TxPvtData), args.Error(1)
@@ -250,6 +279,26 @@ func (mock *ramLedger) GetConfigHistoryRetriever() (ledger.ConfigHistoryRetrieve
 	panic("implement me")
 }
 
+func (mock *ramLedger) GetMissingPvtDataInfoForBlocksBelow(blockNum uint64, maxBlocks int) (ledger.MissingPvtDataInfo, error) {
+	panic("implement me")
+}
+
+func (mock *ramLedger) CommitPvtDataOfOldBlocks(blocksPvtData []*ledger.BlockPvtData) error {
+	panic("implement me")
+}
+
+func (mock *ramLedger) RecordMissingPvtDataOfOldBlocks(missingPvtData ledger.MissingPvtDataInfo) error {
+	panic("implement me")
+}
+
+func (mock *ramLedger) GetPvtDataBackfillHeight() (uint64, error) {
+	panic("implement me")
+}
+
+func (mock *ramLedger) SetPvtDataBackfillHeight(height uint64) error {
+	panic("implement me")
+}
+
 func (mock *ramLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
 	mock.RLock()
 	defer mock.RUnlock()
@@ -1460,6 +1509,187 @@ func TestTransferOfPrivateRWSet(t *testing.T) {
 	}
 }
 
+func TestReadStateTransferConfig(t *testing.T) {
+	keys := []string{minBatchSizeConfigKey, maxBatchSizeConfigKey, maxParallelRequestsConfigKey, responseCompressionConfigKey}
+	defer func() {
+		for _, key := range keys {
+			viper.Set(key, nil)
+		}
+	}()
+
+	conf := readStateTransferConfig()
+	assert.Equal(t, &stateTransferConfig{
+		minBatchSize:        defAntiEntropyBatchSize,
+		maxBatchSize:        defMaxAntiEntropyBatchSize,
+		maxParallelRequests: defMaxParallelStateRequests,
+		compression:         proto.CompressionType_SNAPPY,
+	}, conf)
+
+	viper.Set(minBatchSizeConfigKey, 20)
+	viper.Set(maxBatchSizeConfigKey, 100)
+	viper.Set(maxParallelRequestsConfigKey, 4)
+	viper.Set(responseCompressionConfigKey, "gzip")
+	conf = readStateTransferConfig()
+	assert.Equal(t, &stateTransferConfig{
+		minBatchSize:        20,
+		maxBatchSize:        100,
+		maxParallelRequests: 4,
+		compression:         proto.CompressionType_GZIP,
+	}, conf)
+
+	// The maximum batch size can't be smaller than the minimum one,
+	// and an unknown compression type disables compression
+	viper.Set(maxBatchSizeConfigKey, 5)
+	viper.Set(responseCompressionConfigKey, "lz4")
+	conf = readStateTransferConfig()
+	assert.Equal(t, uint64(20), conf.maxBatchSize)
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, conf.compression)
+	assert.Empty(t, (&GossipStateProviderImpl{config: conf}).acceptedCompressions())
+}
+
+func TestNextBatches(t *testing.T) {
+	t.Parallel()
+	s := &GossipStateProviderImpl{
+		config:     &stateTransferConfig{maxParallelRequests: 3},
+		batchSizer: newBatchSizer(10, 50),
+	}
+
+	// Batches are split by the current batch size, up to the parallel requests limit
+	assert.Equal(t, []blockRange{{start: 1, end: 11}, {start: 12, end: 22}, {start: 23, end: 33}}, s.nextBatches(1, 100))
+	// and don't go beyond the last block requested
+	assert.Equal(t, []blockRange{{start: 1, end: 11}, {start: 12, end: 15}}, s.nextBatches(1, 15))
+	assert.Equal(t, []blockRange{{start: 7, end: 7}}, s.nextBatches(7, 7))
+
+	// The blocks of all batches must fit within defMaxBlockDistance
+	s.batchSizer.succeeded(10, time.Second)
+	s.batchSizer.succeeded(20, time.Second)
+	s.batchSizer.succeeded(40, time.Second)
+	assert.Equal(t, []blockRange{{start: 1, end: 51}}, s.nextBatches(1, 1000))
+}
+
+func TestStateResponseCompression(t *testing.T) {
+	t.Parallel()
+	coord := new(coordinatorMock)
+	coord.On("LedgerHeight", mock.Anything).Return(uint64(10), nil)
+	for seqNum := uint64(2); seqNum <= 4; seqNum++ {
+		coord.On("GetPvtDataAndBlockByNum", seqNum).Return(pcomm.NewBlock(seqNum, []byte{}), gutil.PvtDataCollections{}, nil)
+	}
+	s := &GossipStateProviderImpl{
+		ledger: coord,
+		config: &stateTransferConfig{maxBatchSize: defMaxAntiEntropyBatchSize},
+	}
+
+	respond := func(accepted []proto.CompressionType) *proto.RemoteStateResponse {
+		requestMsg := new(receivedMessageMock)
+		msg, _ := (&proto.GossipMessage{
+			Nonce: 1,
+			Tag:   proto.GossipMessage_CHAN_OR_ORG,
+			Content: &proto.GossipMessage_StateRequest{StateRequest: &proto.RemoteStateRequest{
+				StartSeqNum:          2,
+				EndSeqNum:            4,
+				AcceptedCompressions: accepted,
+			}},
+		}).NoopSign()
+		requestMsg.On("GetGossipMessage").Return(msg)
+		requestMsg.On("GetConnectionInfo").Return(&proto.ConnectionInfo{
+			Auth: &proto.AuthInfo{},
+		})
+		var response *proto.RemoteStateResponse
+		requestMsg.On("Respond", mock.Anything).Run(func(args mock.Arguments) {
+			response = args.Get(0).(*proto.GossipMessage).GetStateResponse()
+		})
+		s.handleStateRequest(requestMsg)
+		return response
+	}
+
+	// Requesters that don't accept compression, e.g. older peers, receive the payloads uncompressed
+	response := respond(nil)
+	assert.Equal(t, proto.CompressionType_NO_COMPRESSION, response.Compression)
+	assert.Equal(t, uint64(defMaxAntiEntropyBatchSize), response.MaxBatchSize)
+	assert.Len(t, response.Payloads, 3)
+
+	for _, compression := range []proto.CompressionType{proto.CompressionType_GZIP, proto.CompressionType_SNAPPY} {
+		response = respond([]proto.CompressionType{compression})
+		assert.Equal(t, compression, response.Compression)
+		assert.Equal(t, uint64(defMaxAntiEntropyBatchSize), response.MaxBatchSize)
+		assert.Empty(t, response.Payloads)
+		payloads, err := responsePayloads(response)
+		assert.NoError(t, err)
+		assert.Len(t, payloads, 3)
+		for i, payload := range payloads {
+			assert.Equal(t, uint64(i+2), payload.SeqNum)
+		}
+	}
+}
+
+func TestStateRequestAboveMaxBatchSize(t *testing.T) {
+	t.Parallel()
+	// Scenario: a peer requests more blocks than the batch size served by the responding
+	// peer, which sends the first blocks rather than ignoring the request
+	coord := new(coordinatorMock)
+	coord.On("LedgerHeight", mock.Anything).Return(uint64(20), nil)
+	for seqNum := uint64(2); seqNum <= 10; seqNum++ {
+		coord.On("GetPvtDataAndBlockByNum", seqNum).Return(pcomm.NewBlock(seqNum, []byte{}), gutil.PvtDataCollections{}, nil)
+	}
+	s := &GossipStateProviderImpl{
+		ledger: coord,
+		config: &stateTransferConfig{maxBatchSize: 2},
+	}
+
+	requestMsg := new(receivedMessageMock)
+	msg, _ := (&proto.GossipMessage{
+		Nonce: 1,
+		Tag:   proto.GossipMessage_CHAN_OR_ORG,
+		Content: &proto.GossipMessage_StateRequest{StateRequest: &proto.RemoteStateRequest{
+			StartSeqNum: 2,
+			EndSeqNum:   10,
+		}},
+	}).NoopSign()
+	requestMsg.On("GetGossipMessage").Return(msg)
+	requestMsg.On("GetConnectionInfo").Return(&proto.ConnectionInfo{
+		Auth: &proto.AuthInfo{},
+	})
+	var response *proto.RemoteStateResponse
+	requestMsg.On("Respond", mock.Anything).Run(func(args mock.Arguments) {
+		response = args.Get(0).(*proto.GossipMessage).GetStateResponse()
+	})
+	s.handleStateRequest(requestMsg)
+
+	assert.NotNil(t, response)
+	assert.Equal(t, uint64(2), response.MaxBatchSize)
+	var seqNums []uint64
+	for _, payload := range response.Payloads {
+		seqNums = append(seqNums, payload.SeqNum)
+	}
+	assert.Equal(t, []uint64{2, 3, 4}, seqNums)
+}
+
+func TestMaxBatchSizeOfPeers(t *testing.T) {
+	t.Parallel()
+	g := &mocks.GossipMock{}
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{{PKIid: common.PKIidType("p2")}})
+	s := &GossipStateProviderImpl{
+		chainID:       "testchainid",
+		mediator:      &ServicesMediator{GossipAdapter: g},
+		maxBatchSizes: make(map[string]uint64),
+	}
+
+	// Peers that don't advertise their max batch size, e.g. older
+	// peers, are only sent requests of the default batch size
+	assert.Equal(t, uint64(defAntiEntropyBatchSize), s.maxBatchSizeOf(common.PKIidType("p1")))
+	s.recordMaxBatchSize(common.PKIidType("p1"), 0)
+	assert.Equal(t, uint64(defAntiEntropyBatchSize), s.maxBatchSizeOf(common.PKIidType("p1")))
+
+	s.recordMaxBatchSize(common.PKIidType("p1"), 50)
+	assert.Equal(t, uint64(50), s.maxBatchSizeOf(common.PKIidType("p1")))
+
+	// p1 isn't a member of the channel, hence it's
+	// forgotten once the max batch size of p2 is recorded
+	s.recordMaxBatchSize(common.PKIidType("p2"), 30)
+	assert.Equal(t, uint64(30), s.maxBatchSizeOf(common.PKIidType("p2")))
+	assert.Equal(t, uint64(defAntiEntropyBatchSize), s.maxBatchSizeOf(common.PKIidType("p1")))
+}
+
 type testPeer struct {
 	*mocks.GossipMock
 	id            string
@@ -18,6 +18,9 @@ It has these top-level messages:
 	NonRevocationProof
 	NymSignature
 	CredentialRevocationInformation
+	NonRevokedHandleSignature
+	PlainSigRevocationData
+	PlainSigNonRevocationProof
 */
 package idemix
 
@@ -597,6 +600,80 @@ func (m *CredentialRevocationInformation) GetRevocationData() []byte {
 	return nil
 }
 
+// NonRevokedHandleSignature is a signature of the revocation authority,
+// valid under the epoch key, on a revocation handle that is not revoked
+type NonRevokedHandleSignature struct {
+	// revocation_handle is the revocation handle that is signed
+	RevocationHandle []byte `protobuf:"bytes,1,opt,name=revocation_handle,json=revocationHandle,proto3" json:"revocation_handle,omitempty"`
+	// signature is the weak Boneh-Boyen signature on the revocation handle
+	Signature *ECP `protobuf:"bytes,2,opt,name=signature" json:"signature,omitempty"`
+}
+
+func (m *NonRevokedHandleSignature) Reset()                    { *m = NonRevokedHandleSignature{} }
+func (m *NonRevokedHandleSignature) String() string            { return proto.CompactTextString(m) }
+func (*NonRevokedHandleSignature) ProtoMessage()               {}
+func (*NonRevokedHandleSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *NonRevokedHandleSignature) GetRevocationHandle() []byte {
+	if m != nil {
+		return m.RevocationHandle
+	}
+	return nil
+}
+
+func (m *NonRevokedHandleSignature) GetSignature() *ECP {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PlainSigRevocationData is the revocation data of a CRI
+// when the ALG_PLAIN_SIGNATURE revocation algorithm is used
+type PlainSigRevocationData struct {
+	Signatures []*NonRevokedHandleSignature `protobuf:"bytes,1,rep,name=signatures" json:"signatures,omitempty"`
+}
+
+func (m *PlainSigRevocationData) Reset()                    { *m = PlainSigRevocationData{} }
+func (m *PlainSigRevocationData) String() string            { return proto.CompactTextString(m) }
+func (*PlainSigRevocationData) ProtoMessage()               {}
+func (*PlainSigRevocationData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *PlainSigRevocationData) GetSignatures() []*NonRevokedHandleSignature {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+// PlainSigNonRevocationProof is the non-revocation proof of the
+// ALG_PLAIN_SIGNATURE revocation algorithm
+type PlainSigNonRevocationProof struct {
+	// sigma_prime is the randomized signature on the revocation handle
+	SigmaPrime *ECP `protobuf:"bytes,1,opt,name=sigma_prime,json=sigmaPrime" json:"sigma_prime,omitempty"`
+	// proof_s_r is the response for the randomness of sigma_prime
+	ProofSR []byte `protobuf:"bytes,2,opt,name=proof_s_r,json=proofSR,proto3" json:"proof_s_r,omitempty"`
+}
+
+func (m *PlainSigNonRevocationProof) Reset()                    { *m = PlainSigNonRevocationProof{} }
+func (m *PlainSigNonRevocationProof) String() string            { return proto.CompactTextString(m) }
+func (*PlainSigNonRevocationProof) ProtoMessage()               {}
+func (*PlainSigNonRevocationProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *PlainSigNonRevocationProof) GetSigmaPrime() *ECP {
+	if m != nil {
+		return m.SigmaPrime
+	}
+	return nil
+}
+
+func (m *PlainSigNonRevocationProof) GetProofSR() []byte {
+	if m != nil {
+		return m.ProofSR
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ECP)(nil), "ECP")
 	proto.RegisterType((*ECP2)(nil), "ECP2")
@@ -608,61 +685,70 @@ func init() {
 	proto.RegisterType((*NonRevocationProof)(nil), "NonRevocationProof")
 	proto.RegisterType((*NymSignature)(nil), "NymSignature")
 	proto.RegisterType((*CredentialRevocationInformation)(nil), "CredentialRevocationInformation")
+	proto.RegisterType((*NonRevokedHandleSignature)(nil), "NonRevokedHandleSignature")
+	proto.RegisterType((*PlainSigRevocationData)(nil), "PlainSigRevocationData")
+	proto.RegisterType((*PlainSigNonRevocationProof)(nil), "PlainSigNonRevocationProof")
 }
 
 func init() { proto.RegisterFile("idemix/idemix.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 816 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0xdd, 0x6e, 0xe2, 0x46,
-	0x14, 0xd6, 0x60, 0x9b, 0x84, 0x83, 0x13, 0xb2, 0x93, 0xa8, 0x3b, 0xfd, 0x53, 0x59, 0xab, 0xdb,
-	0x8d, 0x7a, 0x41, 0xba, 0x44, 0x7d, 0x80, 0x2c, 0xa5, 0xd5, 0xaa, 0x12, 0x42, 0xe6, 0xae, 0x37,
-	0xa3, 0x19, 0x18, 0xb0, 0x05, 0xb6, 0xe9, 0xd8, 0x74, 0x71, 0x2f, 0xfa, 0x34, 0x7d, 0x9b, 0x5e,
-	0xf4, 0x95, 0xaa, 0xf9, 0x01, 0x0f, 0x61, 0xb7, 0x57, 0xf1, 0x39, 0xdf, 0xf9, 0xe3, 0xfb, 0x3e,
-	0xc7, 0x70, 0x9b, 0x2e, 0x44, 0x96, 0xee, 0x1f, 0xcc, 0x9f, 0xc1, 0x56, 0x16, 0x55, 0x11, 0xbd,
-	0x02, 0x6f, 0x3c, 0x9a, 0xe2, 0x10, 0xd0, 0x9e, 0xa0, 0x3e, 0xba, 0x0f, 0x63, 0xb4, 0x57, 0x51,
-	0x4d, 0x5a, 0x26, 0xaa, 0xa3, 0x9f, 0xc1, 0x1f, 0x8f, 0xa6, 0x43, 0x7c, 0x0d, 0xad, 0x3d, 0xb3,
-	0x45, 0xad, 0x3d, 0xd3, 0x31, 0xb7, 0x65, 0xad, 0x3d, 0x57, 0x71, 0xcd, 0x88, 0x67, 0xe2, 0x5a,
-	0xe3, 0x35, 0x27, 0xbe, 0x8d, 0x79, 0xf4, 0x77, 0x0b, 0x7a, 0xef, 0xcb, 0x72, 0x27, 0xe4, 0x74,
-	0xc7, 0x37, 0xe9, 0xfc, 0x57, 0x51, 0xe3, 0x37, 0xd0, 0x63, 0x55, 0x25, 0x53, 0xbe, 0xab, 0x04,
-	0xcd, 0x59, 0x26, 0x4a, 0x82, 0xfa, 0xde, 0x7d, 0x27, 0xbe, 0x3e, 0xa6, 0x27, 0x2a, 0x8b, 0x5f,
-	0x82, 0x9f, 0xd0, 0x72, 0xad, 0xd7, 0x75, 0x87, 0xfe, 0x60, 0x3c, 0x9a, 0xc6, 0x5e, 0x32, 0x5b,
-	0xe3, 0x2f, 0xa1, 0x9d, 0x50, 0xc9, 0xf2, 0x85, 0xde, 0x7c, 0x80, 0x82, 0x24, 0x66, 0xf9, 0x02,
-	0x7f, 0x0d, 0x17, 0x09, 0x55, 0x93, 0x4a, 0xe2, 0xf7, 0xbd, 0x23, 0xda, 0x4e, 0x9e, 0x54, 0x0e,
-	0xdf, 0x02, 0xfa, 0x40, 0x02, 0xdd, 0x16, 0x28, 0x60, 0x18, 0xa3, 0x0f, 0x6a, 0x20, 0x67, 0x92,
-	0xae, 0xde, 0x92, 0xb6, 0x3b, 0x90, 0x33, 0xf9, 0xcb, 0xdb, 0x23, 0x38, 0x24, 0x17, 0xcf, 0xc1,
-	0x21, 0x7e, 0x09, 0x17, 0x5b, 0x59, 0x14, 0x4b, 0x3a, 0x27, 0x97, 0xfa, 0x57, 0xb7, 0x75, 0x38,
-	0x6a, 0x80, 0x92, 0x74, 0x1c, 0x60, 0x86, 0x31, 0xf8, 0x09, 0x2b, 0x13, 0x02, 0x3a, 0xab, 0x9f,
-	0xa3, 0x27, 0xe8, 0x18, 0x96, 0x14, 0x3f, 0x37, 0xe0, 0xa5, 0xe5, 0xda, 0x92, 0xae, 0x1e, 0x71,
-	0x04, 0x5e, 0xba, 0x3d, 0xf0, 0x70, 0x33, 0x78, 0x46, 0x68, 0xac, 0xc0, 0x68, 0x09, 0x30, 0x92,
-	0x62, 0x21, 0xf2, 0x2a, 0x65, 0x1b, 0x8c, 0x01, 0x19, 0xd9, 0x0e, 0xe7, 0x22, 0xa6, 0x72, 0xfc,
-	0x84, 0x4b, 0xc4, 0x95, 0xea, 0xc2, 0xca, 0x87, 0x84, 0x8a, 0x4a, 0x2b, 0x1e, 0x2a, 0xf1, 0x1d,
-	0x04, 0x86, 0xc6, 0xa0, 0xef, 0xdd, 0x87, 0xb1, 0x09, 0xa2, 0x3f, 0xa1, 0xab, 0xf6, 0xc4, 0xe2,
-	0xf7, 0x9d, 0x28, 0x2b, 0xfc, 0x19, 0x78, 0x79, 0x9d, 0x9d, 0xac, 0x52, 0x09, 0xfc, 0x0a, 0xc2,
-	0x54, 0x9f, 0x49, 0xf3, 0x22, 0x9f, 0x0b, 0x6b, 0x99, 0xae, 0xc9, 0x4d, 0x54, 0xca, 0xa5, 0xce,
-	0xfb, 0x14, 0x75, 0xbe, 0x4b, 0x5d, 0xf4, 0xaf, 0x0f, 0x9d, 0x59, 0xba, 0xca, 0x59, 0xb5, 0x93,
-	0x42, 0x09, 0xcd, 0xe8, 0x56, 0xa6, 0x99, 0x38, 0x59, 0xdf, 0x66, 0x53, 0x95, 0xc3, 0x9f, 0x43,
-	0xc0, 0x28, 0x67, 0xf2, 0xe4, 0x27, 0xfb, 0xec, 0x1d, 0x93, 0xaa, 0x93, 0xdb, 0x4e, 0xd7, 0x40,
-	0x6d, 0x6e, 0x3a, 0x9d, 0xc3, 0xfc, 0x93, 0xc3, 0xbe, 0x02, 0xb0, 0x87, 0x29, 0x5b, 0x06, 0x1a,
-	0xbb, 0x34, 0xb7, 0xcd, 0xd6, 0xf8, 0x0b, 0xe8, 0x1c, 0x50, 0xa1, 0x7d, 0x14, 0xc6, 0x66, 0xce,
-	0x6c, 0xec, 0x76, 0x4a, 0xe3, 0xa3, 0x63, 0x67, 0x3c, 0x3c, 0x41, 0x1f, 0xad, 0x8f, 0x0e, 0xe8,
-	0x23, 0x7e, 0x0d, 0xbd, 0xe3, 0x56, 0x7b, 0xb5, 0x71, 0x54, 0x68, 0x57, 0x9b, 0xab, 0x23, 0xb8,
-	0x3a, 0x94, 0x19, 0xd9, 0x40, 0xcb, 0xd6, 0x35, 0x45, 0xc6, 0xfc, 0x77, 0x10, 0x18, 0x39, 0xba,
-	0x7a, 0x80, 0x09, 0x0e, 0x1a, 0x86, 0xe7, 0x1a, 0x1e, 0x27, 0x4a, 0xaa, 0x2a, 0xae, 0x74, 0x17,
-	0xd8, 0xcb, 0x26, 0x75, 0x86, 0x7f, 0x84, 0x5b, 0x29, 0xfe, 0x28, 0xe6, 0xac, 0x4a, 0x8b, 0x9c,
-	0x8a, 0x6d, 0x31, 0x4f, 0xe8, 0x76, 0x4d, 0xae, 0xdd, 0xf7, 0xeb, 0x45, 0x53, 0x31, 0x56, 0x05,
-	0xd3, 0x35, 0xfe, 0x1e, 0x9c, 0x24, 0xdd, 0xae, 0x69, 0x99, 0xae, 0x48, 0x4f, 0x4f, 0xef, 0x35,
-	0xc0, 0x74, 0x3d, 0x4b, 0x57, 0xea, 0x66, 0x3d, 0x97, 0xdc, 0xf4, 0xd1, 0xbd, 0x17, 0x9b, 0x00,
-	0x8f, 0xe1, 0x2e, 0x2f, 0x72, 0xea, 0x4e, 0x51, 0x57, 0x91, 0x17, 0x7a, 0xf3, 0xed, 0x60, 0x52,
-	0xe4, 0x71, 0x33, 0x48, 0x41, 0x31, 0xce, 0xcf, 0x72, 0x51, 0x06, 0xf8, 0xbc, 0x12, 0xbf, 0x86,
-	0x6b, 0x67, 0x30, 0xdb, 0xac, 0xb4, 0xc1, 0x82, 0xf8, 0xaa, 0xc9, 0x3e, 0x6d, 0x56, 0xf8, 0x87,
-	0x4f, 0xdc, 0x60, 0xbc, 0xfe, 0xb1, 0x75, 0x7f, 0x41, 0x38, 0xa9, 0xb3, 0xc6, 0xc2, 0x8e, 0xd3,
-	0xd0, 0xff, 0x38, 0xad, 0xf5, 0xcc, 0x69, 0x67, 0xc2, 0x78, 0x67, 0xc2, 0x1c, 0x95, 0xf6, 0x1d,
-	0xa5, 0xa3, 0x7f, 0x10, 0x7c, 0xd3, 0xfc, 0x97, 0x68, 0xae, 0x7b, 0x9f, 0x2f, 0x0b, 0x99, 0xe9,
-	0xc7, 0x86, 0x6f, 0xe4, 0xf2, 0xdd, 0x87, 0xcb, 0xa3, 0xba, 0x2d, 0x57, 0xdd, 0x0b, 0x61, 0x35,
-	0xed, 0x43, 0x78, 0xa8, 0xd0, 0x72, 0xda, 0x9b, 0x2c, 0xac, 0x94, 0x3c, 0xa7, 0xd5, 0xff, 0x18,
-	0xad, 0x6f, 0xc0, 0xf1, 0x00, 0x5d, 0xb0, 0x8a, 0xd9, 0x57, 0xcd, 0xe9, 0xfe, 0x89, 0x55, 0xec,
-	0xdd, 0x77, 0xbf, 0x7d, 0xbb, 0x4a, 0xab, 0x64, 0xc7, 0x07, 0xf3, 0x22, 0x7b, 0x48, 0xea, 0xad,
-	0x90, 0x1b, 0xb1, 0x58, 0x09, 0xf9, 0xb0, 0x64, 0x5c, 0xa6, 0x73, 0xfb, 0xd5, 0xe3, 0x6d, 0xfd,
-	0xd9, 0x7b, 0xfc, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xaa, 0xcc, 0x9c, 0x10, 0x0d, 0x07, 0x00, 0x00,
+	// 910 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0x5f, 0x6f, 0xe3, 0xc4,
+	0x17, 0x95, 0x63, 0x27, 0x6d, 0x6e, 0xdc, 0xa6, 0x3b, 0xad, 0x76, 0x67, 0xfb, 0xfb, 0x21, 0xb2,
+	0x16, 0xcb, 0x56, 0x20, 0xa5, 0x6c, 0x2a, 0x5e, 0x78, 0xeb, 0x86, 0x00, 0x2b, 0xa4, 0x28, 0x72,
+	0x78, 0xe2, 0xc5, 0x1a, 0x27, 0x53, 0x7b, 0xe4, 0xd8, 0x0e, 0x63, 0x87, 0x8d, 0x79, 0xe0, 0xd3,
+	0xf0, 0x6d, 0x78, 0xe0, 0x2b, 0xa1, 0xf9, 0x13, 0x7b, 0xdc, 0xb4, 0x3c, 0xd5, 0xf7, 0x9e, 0x7b,
+	0xef, 0xdc, 0x9c, 0x73, 0x3c, 0x35, 0x5c, 0xb2, 0x35, 0x4d, 0xd9, 0xfe, 0x56, 0xfd, 0x19, 0x6f,
+	0x79, 0x5e, 0xe6, 0xde, 0x1b, 0xb0, 0x67, 0xd3, 0x05, 0x72, 0xc1, 0xda, 0x63, 0x6b, 0x64, 0xdd,
+	0xb8, 0xbe, 0xb5, 0x17, 0x51, 0x85, 0x3b, 0x2a, 0xaa, 0xbc, 0x1f, 0xc0, 0x99, 0x4d, 0x17, 0x13,
+	0x74, 0x0e, 0x9d, 0x3d, 0xd1, 0x45, 0x9d, 0x3d, 0x91, 0x71, 0xa8, 0xcb, 0x3a, 0xfb, 0x50, 0xc4,
+	0x15, 0xc1, 0xb6, 0x8a, 0x2b, 0x89, 0x57, 0x21, 0x76, 0x74, 0x1c, 0x7a, 0x7f, 0x75, 0x60, 0xf8,
+	0xb1, 0x28, 0x76, 0x94, 0x2f, 0x76, 0xe1, 0x86, 0xad, 0x7e, 0xa6, 0x15, 0x7a, 0x07, 0x43, 0x52,
+	0x96, 0x9c, 0x85, 0xbb, 0x92, 0x06, 0x19, 0x49, 0x69, 0x81, 0xad, 0x91, 0x7d, 0xd3, 0xf7, 0xcf,
+	0xeb, 0xf4, 0x5c, 0x64, 0xd1, 0x2b, 0x70, 0xe2, 0xa0, 0x48, 0xe4, 0x71, 0x83, 0x89, 0x33, 0x9e,
+	0x4d, 0x17, 0xbe, 0x1d, 0x2f, 0x13, 0xf4, 0x3f, 0xe8, 0xc5, 0x01, 0x27, 0xd9, 0x1a, 0xdb, 0x06,
+	0xd4, 0x8d, 0x7d, 0x92, 0xad, 0xd1, 0x67, 0x70, 0x12, 0x07, 0x62, 0x52, 0x81, 0x9d, 0x91, 0x5d,
+	0xa3, 0xbd, 0xf8, 0x5e, 0xe4, 0xd0, 0x25, 0x58, 0x9f, 0x70, 0x57, 0xb6, 0x75, 0x05, 0x30, 0xf1,
+	0xad, 0x4f, 0x62, 0x60, 0x48, 0x78, 0x10, 0xbd, 0xc7, 0x3d, 0x73, 0x60, 0x48, 0xf8, 0x8f, 0xef,
+	0x6b, 0x70, 0x82, 0x4f, 0x1e, 0x83, 0x13, 0xf4, 0x0a, 0x4e, 0xb6, 0x3c, 0xcf, 0x1f, 0x82, 0x15,
+	0x3e, 0x95, 0xbf, 0xba, 0x27, 0xc3, 0x69, 0x03, 0x14, 0xb8, 0x6f, 0x00, 0x4b, 0x84, 0xc0, 0x89,
+	0x49, 0x11, 0x63, 0x90, 0x59, 0xf9, 0xec, 0xdd, 0x43, 0x5f, 0xb1, 0x24, 0xf8, 0xb9, 0x00, 0x9b,
+	0x15, 0x89, 0x26, 0x5d, 0x3c, 0x22, 0x0f, 0x6c, 0xb6, 0x3d, 0xf0, 0x70, 0x31, 0x7e, 0x44, 0xa8,
+	0x2f, 0x40, 0xef, 0x01, 0x60, 0xca, 0xe9, 0x9a, 0x66, 0x25, 0x23, 0x1b, 0x84, 0xc0, 0x52, 0xb2,
+	0x1d, 0xd6, 0xb5, 0x88, 0xc8, 0x85, 0x2d, 0x2e, 0xad, 0x50, 0xa8, 0x4e, 0xb5, 0x7c, 0x16, 0x15,
+	0x51, 0xa1, 0xc5, 0xb3, 0x0a, 0x74, 0x05, 0x5d, 0x45, 0x63, 0x77, 0x64, 0xdf, 0xb8, 0xbe, 0x0a,
+	0xbc, 0x3f, 0x60, 0x20, 0xce, 0xf1, 0xe9, 0x6f, 0x3b, 0x5a, 0x94, 0xe8, 0x25, 0xd8, 0x59, 0x95,
+	0xb6, 0x8e, 0x12, 0x09, 0xf4, 0x06, 0x5c, 0x26, 0xd7, 0x0c, 0xb2, 0x3c, 0x5b, 0x51, 0x6d, 0x99,
+	0x81, 0xca, 0xcd, 0x45, 0xca, 0xa4, 0xce, 0x7e, 0x8e, 0x3a, 0xc7, 0xa4, 0xce, 0xfb, 0xc7, 0x81,
+	0xfe, 0x92, 0x45, 0x19, 0x29, 0x77, 0x9c, 0x0a, 0xa1, 0x49, 0xb0, 0xe5, 0x2c, 0xa5, 0xad, 0xe3,
+	0x7b, 0x64, 0x21, 0x72, 0xe8, 0x35, 0x74, 0x49, 0x10, 0x12, 0xde, 0xfa, 0xc9, 0x0e, 0xf9, 0x40,
+	0xb8, 0xe8, 0x0c, 0x75, 0xa7, 0x69, 0xa0, 0x5e, 0xa8, 0x3a, 0x8d, 0xc5, 0x9c, 0xd6, 0x62, 0xff,
+	0x07, 0xd0, 0x8b, 0x09, 0x5b, 0x76, 0x25, 0x76, 0xaa, 0x76, 0x5b, 0x26, 0xe8, 0x1a, 0xfa, 0x07,
+	0x94, 0x4a, 0x1f, 0xb9, 0xbe, 0x9a, 0xb3, 0x9c, 0x99, 0x9d, 0x5c, 0xf9, 0xa8, 0xee, 0xf4, 0x27,
+	0x2d, 0xf4, 0x0e, 0x9f, 0xb6, 0xd0, 0x3b, 0xf4, 0x16, 0x86, 0xf5, 0xa9, 0x7a, 0x6b, 0xe5, 0x28,
+	0x57, 0x1f, 0xad, 0xb6, 0xf6, 0xe0, 0xec, 0x50, 0xa6, 0x64, 0x03, 0x29, 0xdb, 0x40, 0x15, 0x29,
+	0xf3, 0x5f, 0x41, 0x57, 0xc9, 0x31, 0x90, 0x03, 0x54, 0x70, 0xd0, 0xd0, 0x3d, 0xd6, 0xb0, 0x9e,
+	0xc8, 0x03, 0x51, 0x71, 0x26, 0xbb, 0x40, 0x6f, 0x36, 0xaf, 0x52, 0xf4, 0x2d, 0x5c, 0x72, 0xfa,
+	0x7b, 0xbe, 0x22, 0x25, 0xcb, 0xb3, 0x80, 0x6e, 0xf3, 0x55, 0x1c, 0x6c, 0x13, 0x7c, 0x6e, 0xbe,
+	0x5f, 0x2f, 0x9a, 0x8a, 0x99, 0x28, 0x58, 0x24, 0xe8, 0x2b, 0x30, 0x92, 0xc1, 0x36, 0x09, 0x0a,
+	0x16, 0xe1, 0xa1, 0x9c, 0x3e, 0x6c, 0x80, 0x45, 0xb2, 0x64, 0x91, 0xd8, 0x59, 0xce, 0xc5, 0x17,
+	0x23, 0xeb, 0xc6, 0xf6, 0x55, 0x80, 0x66, 0x70, 0x95, 0xe5, 0x59, 0x60, 0x4e, 0x11, 0x5b, 0xe1,
+	0x17, 0xf2, 0xe4, 0xcb, 0xf1, 0x3c, 0xcf, 0xfc, 0x66, 0x90, 0x80, 0x7c, 0x94, 0x1d, 0xe5, 0xbc,
+	0x14, 0xd0, 0x71, 0x25, 0x7a, 0x0b, 0xe7, 0xc6, 0x60, 0xb2, 0x89, 0xa4, 0xc1, 0xba, 0xfe, 0x59,
+	0x93, 0xbd, 0xdf, 0x44, 0xe8, 0x9b, 0x67, 0x76, 0x50, 0x5e, 0x7f, 0xea, 0xb8, 0x3f, 0xc1, 0x9d,
+	0x57, 0x69, 0x63, 0x61, 0xc3, 0x69, 0xd6, 0x7f, 0x38, 0xad, 0xf3, 0xc8, 0x69, 0x47, 0xc2, 0xd8,
+	0x47, 0xc2, 0xd4, 0x4a, 0x3b, 0x86, 0xd2, 0xde, 0xdf, 0x16, 0x7c, 0xde, 0xdc, 0x12, 0xcd, 0x76,
+	0x1f, 0xb3, 0x87, 0x9c, 0xa7, 0xf2, 0xb1, 0xe1, 0xdb, 0x32, 0xf9, 0x1e, 0xc1, 0x69, 0xad, 0x6e,
+	0xc7, 0x54, 0xf7, 0x84, 0x6a, 0x4d, 0x47, 0xe0, 0x1e, 0x2a, 0xa4, 0x9c, 0x7a, 0x27, 0x0d, 0x0b,
+	0x25, 0x8f, 0x69, 0x75, 0x9e, 0xa2, 0xf5, 0x1d, 0x18, 0x1e, 0x08, 0xd6, 0xa4, 0x24, 0xfa, 0x55,
+	0x33, 0xba, 0xbf, 0x27, 0x25, 0xf1, 0x36, 0xf0, 0x5a, 0x8b, 0x97, 0xd0, 0xf5, 0x4f, 0x24, 0x5b,
+	0x6f, 0x68, 0x43, 0xed, 0xd7, 0x2d, 0x8b, 0xc5, 0x12, 0xd5, 0x24, 0x5f, 0x34, 0x80, 0xea, 0x42,
+	0x1e, 0xf4, 0x8b, 0x43, 0x67, 0xeb, 0xbe, 0x68, 0xd2, 0xde, 0x2f, 0xf0, 0x72, 0xb1, 0x21, 0x2c,
+	0x5b, 0xb2, 0xc8, 0x6f, 0xed, 0x81, 0xbe, 0x03, 0xa8, 0xcb, 0xd4, 0xff, 0xb2, 0xc1, 0xe4, 0x7a,
+	0xfc, 0xec, 0x6a, 0xbe, 0x51, 0xed, 0x05, 0x70, 0x7d, 0x98, 0xfa, 0xa4, 0x11, 0x07, 0x05, 0x8b,
+	0xd2, 0xa7, 0xae, 0x39, 0x90, 0x80, 0x7a, 0xf5, 0x8d, 0x9b, 0x87, 0x6b, 0xb3, 0xe8, 0x9b, 0xc7,
+	0xff, 0xf0, 0xe5, 0xaf, 0x5f, 0x44, 0xac, 0x8c, 0x77, 0xe1, 0x78, 0x95, 0xa7, 0xb7, 0x71, 0xb5,
+	0xa5, 0x7c, 0x43, 0xd7, 0x11, 0xe5, 0xb7, 0x0f, 0x24, 0xe4, 0x6c, 0xa5, 0x3f, 0x0d, 0xc2, 0x9e,
+	0xfc, 0x36, 0xb8, 0xfb, 0x77, 0x00, 0x99, 0x83, 0xd3, 0xa5, 0x32, 0x08, 0x00, 0x00,
 }
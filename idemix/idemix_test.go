@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric-amcl/amcl/FP256BN"
 	"github.com/stretchr/testify/assert"
 )
@@ -162,3 +163,106 @@ func TestIdemix(t *testing.T) {
 		return
 	}
 }
+
+func TestPlainSignatureRevocation(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	AttributeNames := []string{"Attr1", "Attr2", "Attr3", "Attr4", "Attr5"}
+	key, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+
+	// issue a credential whose revocation handle is the last attribute
+	rhindex := 4
+	attrs := make([]*FP256BN.BIG, len(AttributeNames))
+	for i := range AttributeNames {
+		attrs[i] = FP256BN.NewBIGint(i)
+	}
+	attrs[rhindex] = FP256BN.NewBIGint(42)
+	sk := RandModOrder(rng)
+	ni := RandModOrder(rng)
+	m := NewCredRequest(sk, ni, key.Ipk, rng)
+	cred, err := NewCredential(key, m, attrs, rng)
+	assert.NoError(t, err)
+
+	revocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+
+	// epoch 1: the handle is not revoked
+	epoch := 1
+	unrevoked := []*FP256BN.BIG{FP256BN.NewBIGint(7), attrs[rhindex], FP256BN.NewBIGint(99)}
+	cri, err := CreateCRI(revocationKey, unrevoked, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyCRI(&revocationKey.PublicKey, cri, epoch))
+	assert.Error(t, VerifyCRI(&revocationKey.PublicKey, cri, epoch+1))
+
+	Nym, RandNym := MakeNym(sk, key.Ipk, rng)
+	disclosure := []byte{0, 1, 1, 0, 0}
+	msg := []byte{1, 2, 3, 4, 5}
+	sig, err := NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(ALG_PLAIN_SIGNATURE), sig.NonRevocationProof.RevocationAlg)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// the non-revocation proof can't be verified when revocation is ignored
+	err = sig.VerIgnoringRevocation(disclosure, key.Ipk, msg, attrs, rhindex)
+	assert.EqualError(t, err, "signature invalid: revocation algorithm 1 is not supported without revocation")
+
+	// the signature is not valid in another epoch
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch+1)
+	assert.Error(t, err)
+
+	// the signature is not valid under another revocation authority
+	otherRevocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &otherRevocationKey.PublicKey, epoch)
+	assert.Error(t, err)
+
+	// a tampered non-revocation proof is rejected
+	proof := &PlainSigNonRevocationProof{}
+	assert.NoError(t, proto.Unmarshal(sig.NonRevocationProof.NonRevocationProof, proof))
+	proof.ProofSR = BigToBytes(RandModOrder(rng))
+	sig.NonRevocationProof.NonRevocationProof, err = proto.Marshal(proof)
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch)
+	assert.EqualError(t, err, "signature invalid: zero-knowledge proof is invalid")
+
+	// disclosing the revocation handle is not allowed
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, []byte{0, 0, 0, 0, 1}, msg, rhindex, cri, rng)
+	assert.Error(t, err)
+
+	// epoch 2: the handle is revoked, so no signature can be created with the new CRI
+	epoch = 2
+	cri, err = CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(7), FP256BN.NewBIGint(99)}, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is revoked in epoch 2")
+
+	// ...and a CRI of the previous epoch is useless: signing with an epoch 1 CRI
+	// whose epoch key isn't signed for epoch 2 fails verification
+	oldCri, err := CreateCRI(revocationKey, unrevoked, 1, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, oldCri, rng)
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// a signature that claims no revocation is used is rejected, since the revocation
+	// authority didn't publish a CRI without revocation for this epoch
+	noRevCri, err := CreateCRI(revocationKey, nil, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	noRevCri.RevocationAlg = int32(ALG_NO_REVOCATION)
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, noRevCri, rng)
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// unless revocation is ignored, as it was before credentials could be revoked
+	assert.NoError(t, sig.VerIgnoringRevocation(disclosure, key.Ipk, msg, attrs, rhindex))
+	assert.Error(t, sig.VerIgnoringRevocation(disclosure, key.Ipk, []byte("another message"), attrs, rhindex))
+
+	// bad input
+	_, err = CreateCRI(revocationKey, []*FP256BN.BIG{nil}, epoch, ALG_PLAIN_SIGNATURE, rng)
+	assert.Error(t, err)
+	_, err = CreateCRI(revocationKey, nil, epoch, RevocationAlgorithm(42), rng)
+	assert.Error(t, err)
+}
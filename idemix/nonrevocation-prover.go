@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric-amcl/amcl"
 	"github.com/sinochem-tech/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
@@ -27,10 +30,84 @@ func (prover *nopNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRe
 	return ret, nil
 }
 
+// plainSigNonRevokedProver proves knowledge of a weak Boneh-Boyen signature sigma, valid under
+// the epoch key, on the revocation handle rh of the credential, without revealing sigma or rh.
+// It randomizes the signature as sigmaPrime = sigma^r and proves knowledge of r and rh such that
+// e(sigmaPrime, epochPK) = e(g1, g2)^r * e(sigmaPrime, g2)^(-rh)
+type plainSigNonRevokedProver struct {
+	sigmaPrime *FP256BN.ECP
+	r          *FP256BN.BIG
+	rR         *FP256BN.BIG
+}
+
+func (prover *plainSigNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	if rh == nil || rRh == nil || cri == nil || cri.EpochPk == nil || rng == nil {
+		return nil, errors.Errorf("cannot compute non-revocation proof: received nil input")
+	}
+
+	revocationData := &PlainSigRevocationData{}
+	err := proto.Unmarshal(cri.RevocationData, revocationData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal revocation data")
+	}
+
+	// look up the signature of the revocation authority on our revocation handle
+	rhBytes := BigToBytes(rh)
+	var sigma *FP256BN.ECP
+	for _, s := range revocationData.Signatures {
+		if bytes.Equal(s.RevocationHandle, rhBytes) && s.Signature != nil {
+			sigma = EcpFromProto(s.Signature)
+			break
+		}
+	}
+	if sigma == nil {
+		return nil, errors.Errorf("the revocation handle is revoked in epoch %d", cri.Epoch)
+	}
+	err = WBBVerify(Ecp2FromProto(cri.EpochPk), sigma, rh)
+	if err != nil {
+		return nil, errors.WithMessage(err, "the signature on the revocation handle is invalid")
+	}
+
+	prover.r = RandModOrder(rng)
+	prover.rR = RandModOrder(rng)
+	prover.sigmaPrime = FP256BN.G1mul(sigma, prover.r)
+
+	// t = e(g1^rR * sigmaPrime^(-rRh), g2)
+	T := FP256BN.G1mul(GenG1, prover.rR)
+	T.Sub(FP256BN.G1mul(prover.sigmaPrime, rRh))
+	T.Affine()
+	t := FP256BN.Fexp(FP256BN.Ate(GenG2, T))
+
+	ret := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(ret, 0, prover.sigmaPrime)
+	t.ToBytes(ret[index:])
+	return ret, nil
+}
+
+func (prover *plainSigNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	if prover.sigmaPrime == nil {
+		return nil, errors.Errorf("cannot compute non-revocation proof: FS contribution missing")
+	}
+	proofSR := Modadd(prover.rR, FP256BN.Modmul(chal, prover.r, GroupOrder), GroupOrder)
+	proofBytes, err := proto.Marshal(&PlainSigNonRevocationProof{
+		SigmaPrime: EcpToProto(prover.sigmaPrime),
+		ProofSR:    BigToBytes(proofSR),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal non-revocation proof")
+	}
+	return &NonRevocationProof{
+		RevocationAlg:      int32(ALG_PLAIN_SIGNATURE),
+		NonRevocationProof: proofBytes,
+	}, nil
+}
+
 func getNonRevocationProver(algorithm RevocationAlgorithm) (nonRevokedProver, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevokedProver{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevokedProver{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
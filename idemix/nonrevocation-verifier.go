@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
 )
@@ -20,10 +21,49 @@ func (verifier *nopNonRevocationVerifier) recomputeFSContribution(proof *NonRevo
 	return nil, nil
 }
 
+// plainSigNonRevocationVerifier verifies the proofs produced by plainSigNonRevokedProver
+type plainSigNonRevocationVerifier struct{}
+
+func (verifier *plainSigNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	if proof == nil || chal == nil || epochPK == nil || proofSRh == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: received nil input")
+	}
+
+	plainSigProof := &PlainSigNonRevocationProof{}
+	err := proto.Unmarshal(proof.NonRevocationProof, plainSigProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal non-revocation proof")
+	}
+	if plainSigProof.SigmaPrime == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: randomized signature missing")
+	}
+	sigmaPrime := EcpFromProto(plainSigProof.SigmaPrime)
+	if sigmaPrime.Is_infinity() {
+		return nil, errors.Errorf("non-revocation proof invalid: randomized signature is the identity")
+	}
+	proofSR := FP256BN.FromBytes(plainSigProof.ProofSR)
+
+	// t = e(g1^sR * sigmaPrime^(-sRh), g2) * e(sigmaPrime^(-c), epochPK)
+	T := FP256BN.G1mul(GenG1, proofSR)
+	T.Sub(FP256BN.G1mul(sigmaPrime, proofSRh))
+	T.Affine()
+	U := FP256BN.NewECP()
+	U.Sub(FP256BN.G1mul(sigmaPrime, chal))
+	U.Affine()
+	t := FP256BN.Fexp(FP256BN.Ate2(GenG2, T, epochPK, U))
+
+	ret := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(ret, 0, sigmaPrime)
+	t.ToBytes(ret[index:])
+	return ret, nil
+}
+
 func getNonRevocationVerifier(algorithm RevocationAlgorithm) (nonRevocationVerifier, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevocationVerifier{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevocationVerifier{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
@@ -25,10 +25,16 @@ type RevocationAlgorithm int32
 
 const (
 	ALG_NO_REVOCATION RevocationAlgorithm = iota
+	// ALG_PLAIN_SIGNATURE lets the revocation authority place a weak Boneh-Boyen signature
+	// on every unrevoked handle in each epoch; a signer proves in zero-knowledge that it
+	// knows such a signature on the (hidden) revocation handle in its credential
+	ALG_PLAIN_SIGNATURE
 )
 
 var ProofBytes = map[RevocationAlgorithm]int{
 	ALG_NO_REVOCATION: 0,
+	// a randomized signature in G1 and a commitment in GT
+	ALG_PLAIN_SIGNATURE: 2*FieldBytes + 1 + 12*FieldBytes,
 }
 
 // GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
@@ -48,13 +54,19 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 	cri.RevocationAlg = int32(alg)
 	cri.Epoch = int64(epoch)
 
-	if alg == ALG_NO_REVOCATION {
+	var epochSk *FP256BN.BIG
+	switch alg {
+	case ALG_NO_REVOCATION:
 		// put a dummy PK in the proto
 		cri.EpochPk = Ecp2ToProto(GenG2)
-	} else {
-		// create epoch key
-		_, epochPk := WBBKeyGen(rng)
+	case ALG_PLAIN_SIGNATURE:
+		// create a fresh epoch key, such that the signatures of the previous epochs
+		// (in particular the ones on handles revoked since) are no longer valid
+		var epochPk *FP256BN.ECP2
+		epochSk, epochPk = WBBKeyGen(rng)
 		cri.EpochPk = Ecp2ToProto(epochPk)
+	default:
+		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
 	}
 
 	// sign epoch + epoch key with long term key
@@ -70,11 +82,38 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 		return nil, err
 	}
 
-	if alg == ALG_NO_REVOCATION {
-		return cri, nil
-	} else {
-		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
+	if alg == ALG_PLAIN_SIGNATURE {
+		// sign the unrevoked handles with the epoch key
+		revocationData := &PlainSigRevocationData{}
+		for _, rh := range unrevokedHandles {
+			if rh == nil {
+				return nil, errors.Errorf("CreateCRI received nil revocation handle")
+			}
+			revocationData.Signatures = append(revocationData.Signatures, &NonRevokedHandleSignature{
+				RevocationHandle: BigToBytes(rh),
+				Signature:        EcpToProto(WBBSign(epochSk, rh)),
+			})
+		}
+		cri.RevocationData, err = proto.Marshal(revocationData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal revocation data")
+		}
+	}
+
+	return cri, nil
+}
+
+// VerifyCRI checks that the Credential Revocation Information was published by the
+// revocation authority for the given epoch, i.e. that its epoch key is signed with
+// the long term revocation key.
+func VerifyCRI(pk *ecdsa.PublicKey, cri *CredentialRevocationInformation, epoch int) error {
+	if cri == nil {
+		return errors.Errorf("CRI invalid: received nil input")
+	}
+	if cri.Epoch != int64(epoch) {
+		return errors.Errorf("CRI invalid: it is for epoch %d, but the current epoch is %d", cri.Epoch, epoch)
 	}
+	return VerifyEpochPK(pk, cri.EpochPk, cri.EpochPkSig, epoch, RevocationAlgorithm(cri.RevocationAlg))
 }
 
 // VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
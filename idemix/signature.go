@@ -196,11 +196,53 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 // Disclosure steers which attributes it expects to be disclosed
 // attributeValues contains the desired attribute values.
 // This function will check that if attribute i is disclosed, the i-th attribute equals attributeValues[i].
+// It also checks that the signature was created in the given epoch, with the epoch key
+// the revocation authority published for it.
 func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG, rhIndex int, revPk *ecdsa.PublicKey, epoch int) error {
 	if ipk == nil || revPk == nil {
 		return errors.Errorf("cannot verify idemix signature: received nil input")
 	}
 
+	if sig.NonRevocationProof == nil {
+		return errors.Errorf("signature invalid: non-revocation proof missing")
+	}
+
+	// make sure that the revocation authority published the epoch key and revocation algorithm
+	// used by this signature for the current epoch, which also rules out signatures made with
+	// a CRI of an older epoch
+	if sig.Epoch != int64(epoch) {
+		return errors.Errorf("signature invalid: it was created in epoch %d, but the current epoch is %d", sig.Epoch, epoch)
+	}
+	err := VerifyEpochPK(revPk, sig.RevocationEpochPk, sig.RevocationPkSig, epoch, RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg))
+	if err != nil {
+		return errors.WithMessage(err, "signature invalid: epoch key is not valid")
+	}
+
+	return sig.ver(Disclosure, ipk, msg, attributeValues, rhIndex)
+}
+
+// VerIgnoringRevocation verifies an idemix signature like Ver, but without checking
+// the epoch and the epoch key of the signature, as signatures were verified before
+// credentials could be revoked. For the same reason, only signatures without a
+// non-revocation proof (ALG_NO_REVOCATION) are accepted.
+func (sig *Signature) VerIgnoringRevocation(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG, rhIndex int) error {
+	if ipk == nil {
+		return errors.Errorf("cannot verify idemix signature: received nil input")
+	}
+
+	if sig.NonRevocationProof == nil {
+		return errors.Errorf("signature invalid: non-revocation proof missing")
+	}
+
+	if sig.NonRevocationProof.RevocationAlg != int32(ALG_NO_REVOCATION) {
+		return errors.Errorf("signature invalid: revocation algorithm %d is not supported without revocation", sig.NonRevocationProof.RevocationAlg)
+	}
+
+	return sig.ver(Disclosure, ipk, msg, attributeValues, rhIndex)
+}
+
+// ver verifies the zero-knowledge proof of an idemix signature, along with its non-revocation proof
+func (sig *Signature) ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG, rhIndex int) error {
 	if rhIndex < 0 || rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) {
 		return errors.Errorf("cannot verify idemix signature: received invalid input")
 	}
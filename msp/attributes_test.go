@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/common/attrmgr"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// attributesTestCA is the CA issuing the certificates of the attributes
+// tests, either an Ed25519 or an ECDSA one
+type attributesTestCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func (ca *attributesTestCA) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// newAttributesTestCert returns the PEM of a certificate issued by the CA
+// with the given attributes in the extension added by the Fabric CA. The
+// key of the certificate has the algorithm of the key of the CA
+func newAttributesTestCert(t *testing.T, ca *attributesTestCA, attrs map[string]string) []byte {
+	var pub interface{}
+	if _, isEd25519 := ca.key.(ed25519.PrivateKey); isEd25519 {
+		edPub, _, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		pub = edPub
+	} else {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		pub = &key.PublicKey
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "user"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if attrs != nil {
+		value, err := json.Marshal(&attrmgr.Attributes{Attrs: attrs})
+		assert.NoError(t, err)
+		template.ExtraExtensions = []pkix.Extension{{Id: attrmgr.AttrOID, Value: value}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, pub, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newED25519AttributesTestCA(t *testing.T) *attributesTestCA {
+	ca := newED25519TestCert(t, "ca", true, nil)
+	return &attributesTestCA{cert: ca.cert, key: ca.key}
+}
+
+func attributesPrincipal(mspID string, attrs ...string) *msp.MSPPrincipal {
+	mspAttrs := &msp.MSPAttributes{MspIdentifier: mspID}
+	for i := 0; i < len(attrs); i += 2 {
+		mspAttrs.Attributes = append(mspAttrs.Attributes, &msp.MSPAttributes_Attribute{Name: attrs[i], Value: attrs[i+1]})
+	}
+	principalBytes, _ := proto.Marshal(mspAttrs)
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTES,
+		Principal:               principalBytes}
+}
+
+func setupAttributesTestMSP(t *testing.T, ca *attributesTestCA, version MSPVersion) MSP {
+	dir, err := ioutil.TempDir("", "attributesmsp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), ca.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "admin.pem"), newAttributesTestCert(t, ca, nil))
+	conf, err := GetVerifyingMspConfig(dir, "AttributesOrg", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(version)
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(conf))
+	return thisMSP
+}
+
+func TestSatisfiesAttributesPrincipal(t *testing.T) {
+	ca := newED25519AttributesTestCA(t)
+	thisMSP := setupAttributesTestMSP(t, ca, MSPv1_3)
+
+	id, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(newAttributesTestCert(t, ca, map[string]string{
+		"role":    "auditor",
+		"dept":    "finance",
+		"hf.Type": "client",
+	}))
+	assert.NoError(t, err)
+
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg", "role", "auditor")))
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg", "role", "auditor", "dept", "finance")))
+
+	err = thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg", "role", "auditor", "dept", "sales"))
+	assert.EqualError(t, err, "The identity has a different value for the attribute [dept] (expected sales, got finance)")
+	err = thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg", "level", "1"))
+	assert.EqualError(t, err, "The identity does not have the attribute [level]")
+	err = thisMSP.SatisfiesPrincipal(id, attributesPrincipal("OtherOrg", "role", "auditor"))
+	assert.Contains(t, err.Error(), "the identity is a member of a different MSP")
+	err = thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg"))
+	assert.EqualError(t, err, "No attributes in MSPAttributes")
+
+	// Identities without attributes don't satisfy the principal
+	noAttrsID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(newAttributesTestCert(t, ca, nil))
+	assert.NoError(t, err)
+	err = thisMSP.SatisfiesPrincipal(noAttrsID, attributesPrincipal("AttributesOrg", "role", "auditor"))
+	assert.EqualError(t, err, "The identity does not have the attribute [role]")
+
+	// Nor do identities issued by foreign CAs, whatever their attributes
+	foreignCA := newED25519AttributesTestCA(t)
+	foreignID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(newAttributesTestCert(t, foreignCA, map[string]string{"role": "auditor"}))
+	assert.NoError(t, err)
+	err = thisMSP.SatisfiesPrincipal(foreignID, attributesPrincipal("AttributesOrg", "role", "auditor"))
+	assert.Contains(t, err.Error(), "The identity is not valid under this MSP [AttributesOrg]")
+
+	// Attributes can be combined with other principals
+	combined, err := proto.Marshal(&msp.CombinedPrincipal{Principals: []*msp.MSPPrincipal{
+		attributesPrincipal("AttributesOrg", "role", "auditor"),
+		attributesPrincipal("AttributesOrg", "hf.Type", "client"),
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.SatisfiesPrincipal(id, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_COMBINED,
+		Principal:               combined}))
+}
+
+func TestSatisfiesAttributesPrincipalPreV13Fail(t *testing.T) {
+	// Ed25519 identities require MSPv1_3, hence the CA of the earlier
+	// versions is an ECDSA one
+	ecdsaCA := newOCSPTestCert(t, "ca", nil, "", "")
+	ca := &attributesTestCA{cert: ecdsaCA.cert, key: ecdsaCA.key}
+	thisMSP := setupAttributesTestMSP(t, ca, MSPv1_1)
+
+	id, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(newAttributesTestCert(t, ca, map[string]string{"role": "auditor"}))
+	assert.NoError(t, err)
+	err = thisMSP.SatisfiesPrincipal(id, attributesPrincipal("AttributesOrg", "role", "auditor"))
+	assert.EqualError(t, err, "invalid principal type 5")
+}
@@ -9,6 +9,7 @@ package cache
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/golang/groupcache/lru"
 	"github.com/sinochem-tech/fabric/common/flogging"
@@ -71,6 +72,17 @@ func (id *cachedIdentity) Validate() error {
 	return id.cache.Validate(id.Identity)
 }
 
+// CheckRevocationOnline is never cached, since its outcome may change at any time
+func (id *cachedIdentity) CheckRevocationOnline() error {
+	return msp.CheckRevocationOnline(id.Identity)
+}
+
+// CheckStapledRevocationStatus depends on the stapled OCSP response, which is not
+// part of the identifier of the identity, therefore it is never cached either
+func (id *cachedIdentity) CheckStapledRevocationStatus(at time.Time) error {
+	return msp.CheckStapledRevocationStatus(id.Identity, at)
+}
+
 func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
 	c.dicMutex.Lock()
 	id, ok := c.deserializeIdentityCache.Get(string(serializedIdentity))
@@ -148,6 +160,15 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	return err
 }
 
+// ReloadSigningIdentity replaces the default signing identity of the underlying MSP
+func (c *cachedMSP) ReloadSigningIdentity(sidInfo *pmsp.SigningIdentityInfo) (msp.SigningIdentity, error) {
+	reloader, ok := c.MSP.(msp.SigningIdentityReloader)
+	if !ok {
+		return nil, fmt.Errorf("MSP of type %T can't reload its signing identity", c.MSP)
+	}
+	return reloader.ReloadSigningIdentity(sidInfo)
+}
+
 func (c *cachedMSP) cleanCash() error {
 	c.deserializeIdentityCache = lru.New(deserializeIdentityCacheSize)
 	c.satisfiesPrincipalCache = lru.New(satisfiesPrincipalCacheSize)
@@ -9,6 +9,7 @@ package cache
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/sinochem-tech/fabric/msp"
 	"github.com/sinochem-tech/fabric/msp/mocks"
@@ -321,3 +322,44 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	assert.NotNil(t, v)
 	assert.Contains(t, "Invalid", v.(error).Error())
 }
+
+type revocationCheckingIdentity struct {
+	*mocks.MockIdentity
+	onlineChecks int
+	stapledAt    []time.Time
+}
+
+func (id *revocationCheckingIdentity) CheckRevocationOnline() error {
+	id.onlineChecks++
+	return errors.New("The certificate has been revoked")
+}
+
+func (id *revocationCheckingIdentity) CheckStapledRevocationStatus(at time.Time) error {
+	id.stapledAt = append(id.stapledAt, at)
+	return nil
+}
+
+func TestRevocationStatusChecks(t *testing.T) {
+	mockMSP := &mocks.MockMSP{}
+	i, err := New(mockMSP)
+	assert.NoError(t, err)
+
+	mockIdentity := &revocationCheckingIdentity{MockIdentity: &mocks.MockIdentity{ID: "Alice"}}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	mockMSP.On("DeserializeIdentity", []byte{1, 2, 3}).Return(mockIdentity, nil)
+	mockMSP.On("Validate", mockIdentity).Return(nil).Once()
+	id, err := i.DeserializeIdentity([]byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	// Validations are deterministic, hence they are cached,
+	// while the revocation status checks are delegated every time
+	now := time.Now()
+	for j := 0; j < 2; j++ {
+		assert.NoError(t, id.Validate())
+		assert.EqualError(t, msp.CheckRevocationOnline(id), "The certificate has been revoked")
+		assert.NoError(t, msp.CheckStapledRevocationStatus(id, now))
+	}
+	mockMSP.AssertExpectations(t)
+	assert.Equal(t, 2, mockIdentity.onlineChecks)
+	assert.Equal(t, []time.Time{now, now}, mockIdentity.stapledAt)
+}
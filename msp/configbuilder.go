@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric/bccsp"
 	"github.com/sinochem-tech/fabric/bccsp/factory"
+	"github.com/sinochem-tech/fabric/idemix"
 	"github.com/sinochem-tech/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -52,6 +53,36 @@ type Configuration struct {
 	// NodeOUs enables the MSP to tell apart clients, peers and orderers based
 	// on the identity's OU.
 	NodeOUs *NodeOUs `yaml:"NodeOUs,omitempty"`
+	// CryptoConfig overrides the hash functions used by the MSP,
+	// which default to the SHA2 family and SHA256
+	CryptoConfig *CryptoConfig `yaml:"CryptoConfig,omitempty"`
+	// RevocationCheck enables checking the revocation status of identities
+	// online, using the OCSP responders and the CRL distribution points
+	// listed in their certificates
+	RevocationCheck *RevocationCheck `yaml:"RevocationCheck,omitempty"`
+}
+
+// RevocationCheck contains the settings of the online revocation checking
+type RevocationCheck struct {
+	// OCSP enables querying the OCSP responders listed in the certificates
+	OCSP bool `yaml:"OCSP,omitempty"`
+	// CRLDistributionPoints enables fetching the CRLs listed in the certificates
+	CRLDistributionPoints bool `yaml:"CRLDistributionPoints,omitempty"`
+	// HardFail rejects identities whose revocation status can't be determined
+	HardFail bool `yaml:"HardFail,omitempty"`
+	// CacheExpiration is how long a revocation status is cached, e.g. 10m
+	CacheExpiration string `yaml:"CacheExpiration,omitempty"`
+	// Timeout is the timeout of the requests to the OCSP responders and the CRL distribution points, e.g. 5s
+	Timeout string `yaml:"Timeout,omitempty"`
+}
+
+// CryptoConfig contains the hash functions used by an MSP, for instance
+// SM3 for both when the certificates of the MSP are SM2 certificates
+type CryptoConfig struct {
+	// SignatureHashFamily is the hash family used to compute the digests of signatures
+	SignatureHashFamily string `yaml:"SignatureHashFamily,omitempty"`
+	// IdentityIdentifierHashFunction is the hash function used to compute identity identifiers
+	IdentityIdentifierHashFunction string `yaml:"IdentityIdentifierHashFunction,omitempty"`
 }
 
 func readFile(file string) ([]byte, error) {
@@ -140,10 +171,29 @@ func SetupBCCSPKeystoreConfig(bccspConfig *factory.FactoryOpts, keystoreDir stri
 		}
 
 		// Only override the KeyStorePath if it was left empty
-		if bccspConfig.SwOpts.FileKeystore == nil ||
-			bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+		if bccspConfig.SwOpts.FileKeystore == nil {
 			bccspConfig.SwOpts.Ephemeral = false
 			bccspConfig.SwOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+		} else if bccspConfig.SwOpts.FileKeystore.KeyStorePath == "" {
+			// Keep the password options of the key store
+			bccspConfig.SwOpts.Ephemeral = false
+			bccspConfig.SwOpts.FileKeystore.KeyStorePath = keystoreDir
+		}
+	}
+
+	if bccspConfig.ProviderName == "GM" {
+		if bccspConfig.GmOpts == nil {
+			bccspConfig.GmOpts = &factory.GmOpts{}
+		}
+
+		// Only override the KeyStorePath if it was left empty
+		if bccspConfig.GmOpts.FileKeystore == nil {
+			bccspConfig.GmOpts.Ephemeral = false
+			bccspConfig.GmOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: keystoreDir}
+		} else if bccspConfig.GmOpts.FileKeystore.KeyStorePath == "" {
+			// Keep the password options of the key store
+			bccspConfig.GmOpts.Ephemeral = false
+			bccspConfig.GmOpts.FileKeystore.KeyStorePath = keystoreDir
 		}
 	}
 
@@ -165,7 +215,6 @@ func GetLocalMspConfigWithType(dir string, bccspConfig *factory.FactoryOpts, ID,
 }
 
 func GetLocalMspConfig(dir string, bccspConfig *factory.FactoryOpts, ID string) (*msp.MSPConfig, error) {
-	signcertDir := filepath.Join(dir, signcerts)
 	keystoreDir := filepath.Join(dir, keystore)
 	bccspConfig = SetupBCCSPKeystoreConfig(bccspConfig, keystoreDir)
 
@@ -174,6 +223,17 @@ func GetLocalMspConfig(dir string, bccspConfig *factory.FactoryOpts, ID string)
 		return nil, errors.WithMessage(err, "could not initialize BCCSP Factories")
 	}
 
+	sigid, err := GetLocalSigningIdentityInfo(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return getMspConfig(dir, ID, sigid)
+}
+
+// GetLocalSigningIdentityInfo returns the signing identity of the local MSP in the given directory
+func GetLocalSigningIdentityInfo(dir string) (*msp.SigningIdentityInfo, error) {
+	signcertDir := filepath.Join(dir, signcerts)
 	signcert, err := getPemMaterialFromDir(signcertDir)
 	if err != nil || len(signcert) == 0 {
 		return nil, errors.Wrapf(err, "could not load a valid signer certificate from directory %s", signcertDir)
@@ -185,9 +245,7 @@ func GetLocalMspConfig(dir string, bccspConfig *factory.FactoryOpts, ID string)
 	   signing cert
 	*/
 
-	sigid := &msp.SigningIdentityInfo{PublicSigner: signcert[0], PrivateSigner: nil}
-
-	return getMspConfig(dir, ID, sigid)
+	return &msp.SigningIdentityInfo{PublicSigner: signcert[0], PrivateSigner: nil}, nil
 }
 
 // GetVerifyingMspConfig returns an MSP config given directory, ID and type
@@ -257,6 +315,11 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 	// otherwise skip it
 	var ouis []*msp.FabricOUIdentifier
 	var nodeOUs *msp.FabricNodeOUs
+	var revocationCheck *msp.FabricRevocationCheckConfig
+	cryptoConfig := &msp.FabricCryptoConfig{
+		SignatureHashFamily:            bccsp.SHA2,
+		IdentityIdentifierHashFunction: bccsp.SHA256,
+	}
 	_, err = os.Stat(configFile)
 	if err == nil {
 		// load the file, if there is a failure in loading it then
@@ -325,16 +388,31 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 				nodeOUs.PeerOuIdentifier.Certificate = raw
 			}
 		}
+
+		// Prepare CryptoConfig
+		if configuration.CryptoConfig != nil {
+			if configuration.CryptoConfig.SignatureHashFamily != "" {
+				cryptoConfig.SignatureHashFamily = configuration.CryptoConfig.SignatureHashFamily
+			}
+			if configuration.CryptoConfig.IdentityIdentifierHashFunction != "" {
+				cryptoConfig.IdentityIdentifierHashFunction = configuration.CryptoConfig.IdentityIdentifierHashFunction
+			}
+		}
+
+		// Prepare RevocationCheck
+		if configuration.RevocationCheck != nil {
+			revocationCheck = &msp.FabricRevocationCheckConfig{
+				Ocsp:                  configuration.RevocationCheck.OCSP,
+				CrlDistributionPoints: configuration.RevocationCheck.CRLDistributionPoints,
+				HardFail:              configuration.RevocationCheck.HardFail,
+				CacheExpiration:       configuration.RevocationCheck.CacheExpiration,
+				Timeout:               configuration.RevocationCheck.Timeout,
+			}
+		}
 	} else {
 		mspLogger.Debugf("MSP configuration file not found at [%s]: [%s]", configFile, err)
 	}
 
-	// Set FabricCryptoConfig
-	cryptoConfig := &msp.FabricCryptoConfig{
-		SignatureHashFamily:            bccsp.SHA2,
-		IdentityIdentifierHashFunction: bccsp.SHA256,
-	}
-
 	// Compose FabricMSPConfig
 	fmspconf := &msp.FabricMSPConfig{
 		Admins:            admincert,
@@ -348,6 +426,7 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		TlsRootCerts:                  tlsCACerts,
 		TlsIntermediateCerts:          tlsIntermediateCerts,
 		FabricNodeOus:                 nodeOUs,
+		RevocationCheck:               revocationCheck,
 	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
@@ -362,6 +441,7 @@ const (
 	IdemixConfigDirUser                 = "user"
 	IdemixConfigFileIssuerPublicKey     = "IssuerPublicKey"
 	IdemixConfigFileRevocationPublicKey = "RevocationPublicKey"
+	IdemixConfigFileRevocationInfo      = "RevocationInformation"
 	IdemixConfigFileSigner              = "SignerConfig"
 )
 
@@ -383,6 +463,18 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 		RevocationPk: revocationPkBytes,
 	}
 
+	// the credential revocation information of the current epoch is optional
+	criBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationInfo))
+	if err == nil {
+		cri := &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(criBytes, cri)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal credential revocation information")
+		}
+		idemixConfig.Epoch = cri.Epoch
+		idemixConfig.CredentialRevocationInformation = criBytes
+	}
+
 	signerBytes, err := readFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
 	if err == nil {
 		signerConfig := &msp.IdemixMSPSignerConfig{}
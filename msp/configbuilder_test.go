@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/sinochem-tech/fabric/bccsp/factory"
 	"github.com/sinochem-tech/fabric/core/config/configtest"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +29,24 @@ func TestGetLocalMspConfigFails(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSetupBCCSPKeystoreConfig(t *testing.T) {
+	config := &factory.FactoryOpts{
+		ProviderName: "SW",
+		SwOpts: &factory.SwOpts{
+			FileKeystore: &factory.FileKeystoreOpts{PasswordEnv: "KEYSTORE_PASSWORD"},
+		},
+	}
+	config = SetupBCCSPKeystoreConfig(config, "/msp/keystore")
+	assert.Equal(t, "/msp/keystore", config.SwOpts.FileKeystore.KeyStorePath)
+	assert.Equal(t, "KEYSTORE_PASSWORD", config.SwOpts.FileKeystore.PasswordEnv)
+
+	config = SetupBCCSPKeystoreConfig(config, "/other/keystore")
+	assert.Equal(t, "/msp/keystore", config.SwOpts.FileKeystore.KeyStorePath)
+
+	config = SetupBCCSPKeystoreConfig(&factory.FactoryOpts{ProviderName: "GM"}, "/msp/keystore")
+	assert.Equal(t, "/msp/keystore", config.GmOpts.FileKeystore.KeyStorePath)
+}
+
 func TestGetPemMaterialFromDirWithFile(t *testing.T) {
 	tempFile, err := ioutil.TempFile("", "fabric-msp-test")
 	assert.NoError(t, err)
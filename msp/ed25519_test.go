@@ -0,0 +1,293 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/common/crypto/frost"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+type ed25519TestCA struct {
+	cert *x509.Certificate
+	key  ed25519.PrivateKey
+}
+
+func newED25519TestCert(t *testing.T, cn string, isCA bool, parent *ed25519TestCA) *ed25519TestCA {
+	return newED25519TestCertWithValidity(t, cn, isCA, parent, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+}
+
+func newED25519TestCertWithValidity(t *testing.T, cn string, isCA bool, parent *ed25519TestCA, notBefore, notAfter time.Time) *ed25519TestCA {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.NoError(t, err)
+	ski := sha256.Sum256(pub)
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"ed25519org"}},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          ski[:],
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, pub, signer)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &ed25519TestCA{cert: cert, key: key}
+}
+
+func (ca *ed25519TestCA) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *ed25519TestCA) revoke(t *testing.T, serials ...*big.Int) []byte {
+	var revoked []pkix.RevokedCertificate
+	for _, serial := range serials {
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: serial, RevocationTime: time.Now()})
+	}
+	der, err := ca.cert.CreateCRL(rand.Reader, ca.key, revoked, time.Now(), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func TestED25519MSP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rootCA := newED25519TestCert(t, "ca", true, nil)
+	intermediateCA := newED25519TestCert(t, "ica", true, rootCA)
+	peer := newED25519TestCert(t, "peer", false, intermediateCA)
+	admin := newED25519TestCert(t, "admin", false, intermediateCA)
+	revoked := newED25519TestCert(t, "revoked", false, intermediateCA)
+
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), rootCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, intermediatecerts, "ica.pem"), intermediateCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), peer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "admin.pem"), admin.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, crlsfolder, "crl.pem"), intermediateCA.revoke(t, revoked.cert.SerialNumber))
+	rawKey, err := utils.PrivateKeyToPEM(peer.key, nil)
+	assert.NoError(t, err)
+	writeMSPTestFile(t, filepath.Join(dir, keystore, hex.EncodeToString(peer.cert.SubjectKeyId)+"_sk"), rawKey)
+
+	conf, err := GetLocalMspConfig(dir, nil, "ED25519Org")
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, keystore), true)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	// The signing identity is valid and signs with Ed25519
+	signer, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Validate())
+	msg := []byte("hello world")
+	signature, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.Len(t, signature, ed25519.SignatureSize)
+	assert.NoError(t, signer.Verify(msg, signature))
+	assert.Error(t, signer.Verify([]byte("hello world!"), signature))
+
+	// Serialized identities round trip
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, id.Verify(msg, signature))
+
+	// Admins are recognized
+	adminID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(admin.certPEM())
+	assert.NoError(t, err)
+	assert.NoError(t, adminID.Validate())
+
+	// Admins whose key is split into shares sign with any threshold of them
+	shares, err := frost.SplitKey(admin.key, 2, 3, rand.Reader)
+	assert.NoError(t, err)
+	digest := sha256.Sum256(msg)
+	pkg := &frost.SigningPackage{Message: digest[:]}
+	var nonces []*frost.Nonces
+	for _, share := range []*frost.KeyShare{shares[0], shares[2]} {
+		n, c, err := frost.Commit(share, rand.Reader)
+		assert.NoError(t, err)
+		nonces = append(nonces, n)
+		pkg.Commitments = append(pkg.Commitments, *c)
+	}
+	var signatureShares []*frost.SignatureShare
+	for i, share := range []*frost.KeyShare{shares[0], shares[2]} {
+		s, err := frost.Sign(share, nonces[i], pkg)
+		assert.NoError(t, err)
+		signatureShares = append(signatureShares, s)
+	}
+	signature, err = frost.Aggregate(&shares[0].PublicKeyPackage, pkg, signatureShares)
+	assert.NoError(t, err)
+	assert.NoError(t, adminID.Verify(msg, signature))
+
+	// Revoked certificates are refused
+	revokedID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(revoked.certPEM())
+	assert.NoError(t, err)
+	err = revokedID.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has been revoked")
+
+	// Certificates issued by foreign CAs are refused
+	foreignCA := newED25519TestCert(t, "ca", true, nil)
+	foreign := newED25519TestCert(t, "peer", false, foreignCA)
+	foreignID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(foreign.certPEM())
+	assert.NoError(t, err)
+	assert.Error(t, foreignID.Validate())
+}
+
+func TestED25519SigningIdentityFromKeyMaterial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rootCA := newED25519TestCert(t, "ca", true, nil)
+	peer := newED25519TestCert(t, "peer", false, rootCA)
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), rootCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), peer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "peer.pem"), peer.certPEM())
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, keystore), 0755))
+
+	// The private key isn't in the keystore but in the MSP config
+	conf, err := GetLocalMspConfig(dir, nil, "ED25519Org")
+	assert.NoError(t, err)
+	fabricConf := &msp.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	rawKey, err := utils.PrivateKeyToPEM(peer.key, nil)
+	assert.NoError(t, err)
+	fabricConf.SigningIdentity.PrivateSigner = &msp.KeyInfo{KeyMaterial: rawKey}
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SHA2", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	signer, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	msg := []byte("hello world")
+	signature, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Verify(msg, signature))
+}
+
+func TestED25519IdentitiesRequireMSPv13(t *testing.T) {
+	// An Ed25519 leaf certificate issued by an ECDSA CA
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          []byte{1, 2, 3},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "peer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err = x509.CreateCertificate(rand.Reader, leafTemplate, caCert, pub, caKey)
+	assert.NoError(t, err)
+
+	fabricConf := &msp.FabricMSPConfig{
+		Name:      "ECDSAOrg",
+		RootCerts: [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})},
+	}
+	confBytes, err := proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+	serialized, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   "ECDSAOrg",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	})
+	assert.NoError(t, err)
+
+	// Without the V1_3 channel capability the Ed25519 identity is refused
+	thisMSP, err := newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(&msp.MSPConfig{Config: confBytes}))
+	_, err = thisMSP.DeserializeIdentity(serialized)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Ed25519 certificates require MSP version 1.3 or later")
+
+	// With it the Ed25519 identity is accepted
+	thisMSP, err = newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(&msp.MSPConfig{Config: confBytes}))
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	// So is it by the local MSP, which accepts every key algorithm whatever its version
+	thisMSP, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_0}, AcceptAllKeyAlgorithms: true})
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(&msp.MSPConfig{Config: confBytes}))
+	id, err = thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+
+	// MSPs of Ed25519 CAs can't be set up without it either
+	fabricConf.RootCerts = [][]byte{newED25519TestCert(t, "ca", true, nil).certPEM()}
+	confBytes, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+	thisMSP, err = newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	assert.Error(t, thisMSP.Setup(&msp.MSPConfig{Config: confBytes}))
+}
@@ -36,6 +36,11 @@ func (o *NewBaseOpts) GetVersion() MSPVersion {
 // BCCSPNewOpts contains the options to instantiate a new BCCSP-based (X509) MSP
 type BCCSPNewOpts struct {
 	NewBaseOpts
+
+	// AcceptAllKeyAlgorithms makes the MSP accept the SM2 and Ed25519
+	// identities whatever its version. It is meant for the local MSP, which
+	// doesn't take part in the validation of the channels
+	AcceptAllKeyAlgorithms bool
 }
 
 // IdemixNewOpts contains the options to instantiate a new Idemix-based MSP
@@ -45,18 +50,25 @@ type IdemixNewOpts struct {
 
 // New create a new MSP instance depending on the passed Opts
 func New(opts NewOpts) (MSP, error) {
-	switch opts.(type) {
+	switch o := opts.(type) {
 	case *BCCSPNewOpts:
+		var theMsp MSP
+		var err error
 		switch opts.GetVersion() {
 		case MSPv1_0:
-			return newBccspMsp(MSPv1_0)
+			theMsp, err = newBccspMsp(MSPv1_0)
 		case MSPv1_1:
-			return newBccspMsp(MSPv1_1)
+			theMsp, err = newBccspMsp(MSPv1_1)
 		case MSPv1_3:
-			return newBccspMsp(MSPv1_3)
+			theMsp, err = newBccspMsp(MSPv1_3)
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
+		if err != nil {
+			return nil, err
+		}
+		theMsp.(*bccspmsp).acceptAllKeyAlgorithms = o.AcceptAllKeyAlgorithms
+		return theMsp, nil
 	case *IdemixNewOpts:
 		switch opts.GetVersion() {
 		case MSPv1_3:
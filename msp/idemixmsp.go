@@ -149,6 +149,29 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	}
 	msp.revocationPK = ecdsaPublicKey
 
+	// get the credential revocation information of the current epoch, if distributed via the config.
+	// Before v1.3 the epoch of the config isn't enforced on the identities, like the peers of earlier
+	// versions do, so that all the peers of a channel agree on the validity of the identities. The
+	// revocation information still serves the default signer of a local msp, which doesn't take part
+	// in the validation of the channels, so that it proves it isn't revoked in the current epoch
+	var cri *idemix.CredentialRevocationInformation
+	if msp.version >= MSPv1_3 {
+		msp.epoch = int(conf.Epoch)
+	} else if conf.Signer == nil && (conf.Epoch != 0 || len(conf.CredentialRevocationInformation) != 0) {
+		mspLogger.Warningf("the revocation information in the config of idemix msp %s is ignored, it requires MSP version 1.3 or later", msp.name)
+	}
+	if len(conf.CredentialRevocationInformation) != 0 && (msp.version >= MSPv1_3 || conf.Signer != nil) {
+		cri = &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(conf.CredentialRevocationInformation, cri)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal credential revocation information from idemix msp config")
+		}
+		err = idemix.VerifyCRI(msp.revocationPK, cri, int(conf.Epoch))
+		if err != nil {
+			return errors.WithMessage(err, "invalid credential revocation information in idemix msp config")
+		}
+	}
+
 	if conf.Signer == nil {
 		// No credential in config, so we don't setup a default signer
 		mspLogger.Debug("idemix msp setup as verification only msp (no key material found)")
@@ -208,10 +231,14 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.Wrap(err, "Credential is not cryptographically valid")
 	}
 
-	cri := &idemix.CredentialRevocationInformation{}
-	err = proto.Unmarshal(conf.Signer.CredentialRevocationInformation, cri)
-	if err != nil {
-		return errors.Wrap(err, "failed to unmarshal credential revocation information")
+	// the CRI distributed via the msp config takes precedence over the one
+	// that came with the credential, which might be of an earlier epoch
+	if cri == nil {
+		cri = &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(conf.Signer.CredentialRevocationInformation, cri)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal credential revocation information")
+		}
 	}
 
 	// Create the cryptographic evidence that this identity is valid
@@ -321,6 +348,11 @@ func (id *idemixidentity) verifyProof() error {
 	ouBytes := []byte(id.OU.OrganizationalUnitIdentifier)
 	attributeValues := []*FP256BN.BIG{idemix.HashModOrder(ouBytes), FP256BN.NewBIGint(int(id.Role.Role))}
 
+	// the epoch and the revocation of the credential are only enforced from v1.3 on,
+	// which requires all the peers of the channel to be able to enforce them
+	if id.msp.version < MSPv1_3 {
+		return id.associationProof.VerIgnoringRevocation(discloseFlags, id.msp.ipk, nil, attributeValues, rhIndex)
+	}
 	return id.associationProof.Ver(discloseFlags, id.msp.ipk, nil, attributeValues, rhIndex, id.msp.revocationPK, id.msp.epoch)
 }
 
@@ -502,8 +534,8 @@ func newIdemixIdentity(msp *idemixmsp, nym *FP256BN.ECP, role *m.MSPRole, ou *m.
 }
 
 func (id *idemixidentity) ExpiresAt() time.Time {
-	// Idemix MSP currently does not use expiration dates or revocation,
-	// so we return the zero time to indicate this.
+	// Idemix MSP currently does not use expiration dates (revocation is
+	// handled by epochs), so we return the zero time to indicate this.
 	return time.Time{}
 }
 
@@ -46,6 +46,9 @@ type identity struct {
 
 	// reference to the MSP that "owns" this identity
 	msp *bccspmsp
+
+	// ocspResponse is the OCSP response stapled to the serialized identity, if any
+	ocspResponse []byte
 }
 
 func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity, error) {
@@ -104,6 +107,35 @@ func (id *identity) Validate() error {
 	return id.msp.Validate(id)
 }
 
+// CheckRevocationOnline checks the revocation status of this instance online,
+// if its MSP is configured to do so
+func (id *identity) CheckRevocationOnline() error {
+	rc := id.msp.revocationChecker
+	if rc == nil {
+		return nil
+	}
+	issuer, err := id.msp.getIssuer(id)
+	if err != nil {
+		return err
+	}
+	return rc.check(id.cert, issuer, id.ocspResponse)
+}
+
+// CheckStapledRevocationStatus checks the revocation status of this instance at
+// the given time against its stapled OCSP response, if its MSP is configured to
+// check revocation online
+func (id *identity) CheckStapledRevocationStatus(at time.Time) error {
+	rc := id.msp.revocationChecker
+	if rc == nil || len(id.ocspResponse) == 0 {
+		return nil
+	}
+	issuer, err := id.msp.getIssuer(id)
+	if err != nil {
+		return err
+	}
+	return rc.checkStapled(id.cert, issuer, id.ocspResponse, at)
+}
+
 // GetOrganizationalUnits returns the OU for this instance
 func (id *identity) GetOrganizationalUnits() []*OUIdentifier {
 	if id.cert == nil {
@@ -205,6 +237,8 @@ func (id *identity) getHashOpt(hashFamily string) (bccsp.HashOpts, error) {
 		return bccsp.GetHashOpt(bccsp.SHA256)
 	case bccsp.SHA3:
 		return bccsp.GetHashOpt(bccsp.SHA3_256)
+	case bccsp.SM3:
+		return bccsp.GetHashOpt(bccsp.SM3)
 	}
 	return nil, errors.Errorf("hash familiy not recognized [%s]", hashFamily)
 }
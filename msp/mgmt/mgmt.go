@@ -46,6 +46,24 @@ func LoadLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) er
 	return GetLocalMSP().Setup(conf)
 }
 
+// ReloadLocalSigningIdentity replaces the signing identity of the local MSP with
+// the one found in the specified directory, and returns the signing identity in use
+func ReloadLocalSigningIdentity(dir string) (msp.SigningIdentity, error) {
+	sidInfo, err := msp.GetLocalSigningIdentityInfo(dir)
+	if err != nil {
+		return nil, err
+	}
+	if sidInfo == nil {
+		return nil, errors.Errorf("no signing identity found in directory %s", dir)
+	}
+
+	reloader, ok := GetLocalMSP().(msp.SigningIdentityReloader)
+	if !ok {
+		return nil, errors.New("the local MSP can't reload its signing identity")
+	}
+	return reloader.ReloadSigningIdentity(sidInfo)
+}
+
 // FIXME: AS SOON AS THE CHAIN MANAGEMENT CODE IS COMPLETE,
 // THESE MAPS AND HELPSER FUNCTIONS SHOULD DISAPPEAR BECAUSE
 // OWNERSHIP OF PER-CHAIN MSP MANAGERS WILL BE HANDLED BY IT;
@@ -150,8 +168,10 @@ func loadLocaMSP() msp.MSP {
 		mspType = msp.ProviderTypeToString(msp.FABRIC)
 	}
 
+	// the local MSP doesn't take part in the validation of the channels,
+	// therefore it accepts the identities of every supported key algorithm
 	var mspOpts = map[string]msp.NewOpts{
-		msp.ProviderTypeToString(msp.FABRIC): &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}},
+		msp.ProviderTypeToString(msp.FABRIC): &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}, AcceptAllKeyAlgorithms: true},
 		msp.ProviderTypeToString(msp.IDEMIX): &msp.IdemixNewOpts{msp.NewBaseOpts{Version: msp.MSPv1_1}},
 	}
 	newOpts, found := mspOpts[mspType]
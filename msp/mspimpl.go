@@ -8,16 +8,21 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric/bccsp"
 	"github.com/sinochem-tech/fabric/bccsp/factory"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/sinochem-tech/fabric/bccsp/signer"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/common/attrmgr"
 	m "github.com/sinochem-tech/fabric/protos/msp"
 	"github.com/pkg/errors"
 )
@@ -36,6 +41,10 @@ type satisfiesPrincipalInternalFuncType func(id Identity, principal *m.MSPPrinci
 type bccspmsp struct {
 	// version specifies the behaviour of this msp
 	version MSPVersion
+
+	// acceptAllKeyAlgorithms makes the msp accept the SM2 and Ed25519
+	// identities even when its version predates MSPv1_3
+	acceptAllKeyAlgorithms bool
 	// The following function pointers are used to change the behaviour
 	// of this MSP depending on its version.
 	// internalSetupFunc is the pointer to the setup function
@@ -68,6 +77,9 @@ type bccspmsp struct {
 	// list of signing identities
 	signer SigningIdentity
 
+	// signerLock protects the default signing identity, which may be reloaded
+	signerLock sync.RWMutex
+
 	// list of admin identities
 	admins []Identity
 
@@ -80,6 +92,10 @@ type bccspmsp struct {
 	// verification options for MSP members
 	opts *x509.VerifyOptions
 
+	// verification options for the MSP members holding SM2-signed certificates,
+	// which the x509 package cannot verify
+	sm2Opts *sm2.VerifyOptions
+
 	// list of certificate revocation lists
 	CRL []*pkix.CertificateList
 
@@ -94,6 +110,10 @@ type bccspmsp struct {
 	// These are the OUIdentifiers of the clients, peers and orderers.
 	// They are used to tell apart these entities
 	clientOU, peerOU *OUIdentifier
+
+	// revocationChecker checks the revocation status of identities online,
+	// it is nil if online checking is not enabled
+	revocationChecker *revocationChecker
 }
 
 // newBccspMsp returns an MSP instance backed up by a BCCSP
@@ -140,7 +160,7 @@ func (msp *bccspmsp) getCertFromPem(idBytes []byte) (*x509.Certificate, error) {
 
 	// get a cert
 	var cert *x509.Certificate
-	cert, err := x509.ParseCertificate(pemCert.Bytes)
+	cert, err := msp.parseCertificate(pemCert.Bytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "getCertFromPem error: failed to parse x509 cert")
 	}
@@ -148,6 +168,29 @@ func (msp *bccspmsp) getCertFromPem(idBytes []byte) (*x509.Certificate, error) {
 	return cert, nil
 }
 
+// parseCertificate parses the supplied DER-encoded certificate. Peers
+// that predate MSPv1_3 can't parse the certificates of SM2 and Ed25519
+// keys, nor verify the SM2 signatures, therefore MSPs of earlier versions
+// reject them, so that every peer of the channel takes the same decision
+// on the same identity. The local MSP, which doesn't validate the
+// transactions of the channels, may accept them regardless of its version
+func (msp *bccspmsp) parseCertificate(der []byte) (*x509.Certificate, error) {
+	if msp.version >= MSPv1_3 || msp.acceptAllKeyAlgorithms {
+		return sm2.ParseCertificate(der)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	if sm2.IsSM2SignedCert(cert) {
+		return nil, errors.Errorf("SM2 certificates require MSP version 1.3 or later, this MSP has version %d", msp.version)
+	}
+	if _, isEd25519 := cert.PublicKey.(ed25519.PublicKey); isEd25519 || cert.SignatureAlgorithm == x509.PureEd25519 {
+		return nil, errors.Errorf("Ed25519 certificates require MSP version 1.3 or later, this MSP has version %d", msp.version)
+	}
+	return cert, nil
+}
+
 func (msp *bccspmsp) getIdentityFromConf(idBytes []byte) (Identity, bccsp.Key, error) {
 	// get a cert
 	cert, err := msp.getCertFromPem(idBytes)
@@ -187,9 +230,12 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		}
 
 		pemKey, _ := pem.Decode(sidInfo.PrivateSigner.KeyMaterial)
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		if pemKey == nil {
+			return nil, errors.New("getIdentityFromBytes error: KeyMaterial is not PEM encoded")
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, privateKeyImportOpts(pemKey.Bytes))
 		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import private key")
 		}
 	}
 
@@ -202,6 +248,21 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 	return newSigningIdentity(idPub.(*identity).cert, idPub.(*identity).pk, peerSigner, msp)
 }
 
+// privateKeyImportOpts returns the options to import the supplied
+// DER-encoded private key, based on its algorithm; ECDSA is the default
+func privateKeyImportOpts(der []byte) bccsp.KeyImportOpts {
+	key, err := utils.DERToPrivateKey(der)
+	if err == nil {
+		switch key.(type) {
+		case *sm2.PrivateKey:
+			return &bccsp.SM2PrivateKeyImportOpts{Temporary: true}
+		case ed25519.PrivateKey:
+			return &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+	}
+	return &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+}
+
 // Setup sets up the internal data structures
 // for this MSP, given an MSPConfig ref; it
 // returns nil in case of success or an error otherwise
@@ -255,6 +316,9 @@ func (msp *bccspmsp) GetTLSIntermediateCerts() [][]byte {
 func (msp *bccspmsp) GetDefaultSigningIdentity() (SigningIdentity, error) {
 	mspLogger.Debugf("Obtaining default signing identity")
 
+	msp.signerLock.RLock()
+	defer msp.signerLock.RUnlock()
+
 	if msp.signer == nil {
 		return nil, errors.New("this MSP does not possess a valid default signing identity")
 	}
@@ -347,7 +411,17 @@ func (msp *bccspmsp) DeserializeIdentity(serializedID []byte) (Identity, error)
 		return nil, errors.Errorf("expected MSP ID %s, received %s", msp.name, sId.Mspid)
 	}
 
-	return msp.deserializeIdentityInternal(sId.IdBytes)
+	id, err := msp.deserializeIdentityInternal(sId.IdBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the stapled OCSP response, if any, for the validation of the identity
+	if len(sId.OcspResponse) != 0 {
+		id.(*identity).ocspResponse = sId.OcspResponse
+	}
+
+	return id, nil
 }
 
 // deserializeIdentityInternal returns an identity given its byte-level representation
@@ -357,7 +431,7 @@ func (msp *bccspmsp) deserializeIdentityInternal(serializedIdentity []byte) (Ide
 	if bl == nil {
 		return nil, errors.New("could not decode the PEM structure")
 	}
-	cert, err := x509.ParseCertificate(bl.Bytes)
+	cert, err := msp.parseCertificate(bl.Bytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "parseCertificate failed")
 	}
@@ -554,6 +628,48 @@ func (msp *bccspmsp) satisfiesPrincipalInternalV13(id Identity, principal *m.MSP
 		default:
 			return errors.Errorf("Unknown principal anonymity type: %d", anon.AnonymityType)
 		}
+	case m.MSPPrincipal_ATTRIBUTES:
+		// Principal contains the attributes the identity should have
+		mspAttrs := &m.MSPAttributes{}
+		err := proto.Unmarshal(principal.Principal, mspAttrs)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal MSPAttributes from principal")
+		}
+		if len(mspAttrs.Attributes) == 0 {
+			return errors.New("No attributes in MSPAttributes")
+		}
+
+		// at first, we check whether the MSP
+		// identifier is the same as that of the identity
+		if mspAttrs.MspIdentifier != msp.name {
+			return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", mspAttrs.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		// we then check if the identity is valid with this MSP
+		// and fail if it is not
+		if err := msp.Validate(id); err != nil {
+			return errors.Wrapf(err, "The identity is not valid under this MSP [%s]", msp.name)
+		}
+
+		// now we check that the attributes in the certificate of
+		// the identity, added by the Fabric CA, have the requested values
+		attrs, err := attrmgr.New().GetAttributesFromCert(id.(*identity).cert)
+		if err != nil {
+			return errors.WithMessage(err, "could not get the attributes of the identity")
+		}
+		for _, attr := range mspAttrs.Attributes {
+			value, found, err := attrs.Value(attr.Name)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("could not get the value of the attribute [%s]", attr.Name))
+			}
+			if !found {
+				return errors.Errorf("The identity does not have the attribute [%s]", attr.Name)
+			}
+			if value != attr.Value {
+				return errors.Errorf("The identity has a different value for the attribute [%s] (expected %s, got %s)", attr.Name, attr.Value, value)
+			}
+		}
+		return nil
 
 	default:
 		// Use the pre-v1.3 function to check other principal types
@@ -601,7 +717,17 @@ func (msp *bccspmsp) getUniqueValidationChain(cert *x509.Certificate, opts x509.
 	if msp.opts == nil {
 		return nil, errors.New("the supplied identity has no verify options")
 	}
-	validationChains, err := cert.Verify(opts)
+	var validationChains [][]*x509.Certificate
+	var err error
+	if sm2.IsSM2SignedCert(cert) && msp.sm2Opts != nil {
+		validationChains, err = sm2.VerifyCertificate(cert, sm2.VerifyOptions{
+			Roots:         msp.sm2Opts.Roots,
+			Intermediates: msp.sm2Opts.Intermediates,
+			CurrentTime:   opts.CurrentTime,
+		})
+	} else {
+		validationChains, err = cert.Verify(opts)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "the supplied identity is not valid")
 	}
@@ -716,6 +842,6 @@ func (msp *bccspmsp) IsWellFormed(identity *m.SerializedIdentity) error {
 	if bl.Type != "CERTIFICATE" && bl.Type != "" {
 		return errors.Errorf("pem type is %s, should be 'CERTIFICATE' or missing", bl.Type)
 	}
-	_, err := x509.ParseCertificate(bl.Bytes)
+	_, err := msp.parseCertificate(bl.Bytes)
 	return err
 }
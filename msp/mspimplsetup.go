@@ -15,6 +15,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/sinochem-tech/fabric/bccsp"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	m "github.com/sinochem-tech/fabric/protos/msp"
 	errors "github.com/pkg/errors"
 )
@@ -112,12 +113,14 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 	// CA certificates. After their sanitization is done, the opts
 	// will be recreated using the sanitized certs.
 	msp.opts = &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
+	msp.sm2Opts = &sm2.VerifyOptions{}
 	for _, v := range conf.RootCerts {
 		cert, err := msp.getCertFromPem(v)
 		if err != nil {
 			return err
 		}
 		msp.opts.Roots.AddCert(cert)
+		msp.sm2Opts.Roots = append(msp.sm2Opts.Roots, cert)
 	}
 	for _, v := range conf.IntermediateCerts {
 		cert, err := msp.getCertFromPem(v)
@@ -125,6 +128,7 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 			return err
 		}
 		msp.opts.Intermediates.AddCert(cert)
+		msp.sm2Opts.Intermediates = append(msp.sm2Opts.Intermediates, cert)
 	}
 
 	// Load root and intermediate CA identities
@@ -152,11 +156,14 @@ func (msp *bccspmsp) setupCAs(conf *m.FabricMSPConfig) error {
 
 	// root CA and intermediate CA certificates are sanitized, they can be reimported
 	msp.opts = &x509.VerifyOptions{Roots: x509.NewCertPool(), Intermediates: x509.NewCertPool()}
+	msp.sm2Opts = &sm2.VerifyOptions{}
 	for _, id := range msp.rootCerts {
 		msp.opts.Roots.AddCert(id.(*identity).cert)
+		msp.sm2Opts.Roots = append(msp.sm2Opts.Roots, id.(*identity).cert)
 	}
 	for _, id := range msp.intermediateCerts {
 		msp.opts.Intermediates.AddCert(id.(*identity).cert)
+		msp.sm2Opts.Intermediates = append(msp.sm2Opts.Intermediates, id.(*identity).cert)
 	}
 
 	return nil
@@ -197,6 +204,22 @@ func (msp *bccspmsp) setupCRLs(conf *m.FabricMSPConfig) error {
 	return nil
 }
 
+func (msp *bccspmsp) setupRevocationCheck(conf *m.FabricMSPConfig) error {
+	// setup the online revocation checking (if enabled)
+	if conf.RevocationCheck == nil || (!conf.RevocationCheck.Ocsp && !conf.RevocationCheck.CrlDistributionPoints) {
+		msp.revocationChecker = nil
+		return nil
+	}
+
+	rc, err := newRevocationChecker(conf.RevocationCheck)
+	if err != nil {
+		return errors.WithMessage(err, "could not setup the online revocation checking")
+	}
+	msp.revocationChecker = rc
+
+	return nil
+}
+
 func (msp *bccspmsp) finalizeSetupCAs(config *m.FabricMSPConfig) error {
 	// ensure that our CAs are properly formed and that they are valid
 	for _, id := range append(append([]Identity{}, msp.rootCerts...), msp.intermediateCerts...) {
@@ -374,7 +397,9 @@ func (msp *bccspmsp) setupV1(conf1 *m.FabricMSPConfig) error {
 		return err
 	}
 
-	return nil
+	// Setup the online revocation checking, once the setup
+	// doesn't need to validate identities anymore
+	return msp.setupRevocationCheck(conf1)
 }
 
 func (msp *bccspmsp) preSetupV1(conf *m.FabricMSPConfig) error {
@@ -451,7 +476,9 @@ func (msp *bccspmsp) setupV11(conf *m.FabricMSPConfig) error {
 		return err
 	}
 
-	return nil
+	// Setup the online revocation checking, once the setup
+	// doesn't need to validate identities anymore
+	return msp.setupRevocationCheck(conf)
 }
 
 func (msp *bccspmsp) postSetupV11(conf *m.FabricMSPConfig) error {
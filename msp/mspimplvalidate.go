@@ -17,6 +17,7 @@ import (
 	"math/big"
 	"reflect"
 
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
 	"github.com/pkg/errors"
 )
 
@@ -104,7 +105,7 @@ func (msp *bccspmsp) validateCertAgainstChain(cert *x509.Certificate, validation
 					// certificate that is under validation. As a
 					// precaution, we verify that said CA is also the
 					// signer of this CRL.
-					err = validationChain[1].CheckCRLSignature(crl)
+					err = sm2.CheckCRLSignature(validationChain[1], crl)
 					if err != nil {
 						// the CA cert that signed the certificate
 						// that is under validation did not sign the
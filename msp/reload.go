@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"time"
+
+	m "github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// SigningIdentityReloader is implemented by MSPs whose default signing
+// identity can be replaced at runtime, without setting them up again
+type SigningIdentityReloader interface {
+	// ReloadSigningIdentity replaces the default signing identity of the MSP with the
+	// given one, and returns it. The current signing identity is kept, and returned,
+	// if the given one is invalid, or if they are the same. A warning is logged if
+	// the given one expires before the current one.
+	ReloadSigningIdentity(sidInfo *m.SigningIdentityInfo) (SigningIdentity, error)
+}
+
+// ReloadSigningIdentity replaces the default signing identity of this MSP, following the
+// rotation of its certificate. The new signing identity must be valid for this MSP and be
+// valid already. A warning is logged if it expires before the current one, as the identities
+// issued from the current one (such as the gossip identity of a peer) may then expire early.
+func (msp *bccspmsp) ReloadSigningIdentity(sidInfo *m.SigningIdentityInfo) (SigningIdentity, error) {
+	msp.signerLock.RLock()
+	current := msp.signer
+	msp.signerLock.RUnlock()
+	if current == nil {
+		return nil, errors.New("this MSP does not possess a default signing identity to replace")
+	}
+
+	sid, err := msp.getSigningIdentityFromConf(sidInfo)
+	if err != nil {
+		return current, errors.WithMessage(err, "failed loading the new signing identity")
+	}
+	currentID, isX509 := current.(*signingidentity)
+	if !isX509 {
+		return current, errors.Errorf("the default signing identity of type %T can't be reloaded", current)
+	}
+	newID := sid.(*signingidentity)
+	if bytes.Equal(newID.cert.Raw, currentID.cert.Raw) {
+		return current, nil
+	}
+
+	if err := newID.Validate(); err != nil {
+		return current, errors.WithMessage(err, "the new signing identity is not valid")
+	}
+	now := time.Now()
+	if now.Before(newID.cert.NotBefore) {
+		return current, errors.Errorf("the new signing identity is valid only from %v", newID.cert.NotBefore)
+	}
+	if now.After(newID.cert.NotAfter) {
+		return current, errors.Errorf("the new signing identity expired %v ago", now.Sub(newID.cert.NotAfter))
+	}
+	if newID.cert.NotAfter.Before(currentID.cert.NotAfter) {
+		mspLogger.Warningf("The new signing identity of MSP %s expires at %v, before the current one which expires at %v",
+			msp.name, newID.cert.NotAfter, currentID.cert.NotAfter)
+	}
+
+	msp.signerLock.Lock()
+	msp.signer = sid
+	msp.signerLock.Unlock()
+	mspLogger.Infof("Replaced the default signing identity of MSP %s, it expires at %v", msp.name, newID.cert.NotAfter)
+
+	return sid, nil
+}
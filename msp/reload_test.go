@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadSigningIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadmsp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rootCA := newED25519TestCert(t, "ca", true, nil)
+	peer := newED25519TestCert(t, "peer", false, rootCA)
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), rootCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "admin.pem"), peer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), peer.certPEM())
+	writeKey := func(ca *ed25519TestCA) {
+		rawKey, err := utils.PrivateKeyToPEM(ca.key, nil)
+		assert.NoError(t, err)
+		writeMSPTestFile(t, filepath.Join(dir, keystore, hex.EncodeToString(ca.cert.SubjectKeyId)+"_sk"), rawKey)
+	}
+	writeKey(peer)
+
+	conf, err := GetLocalMspConfig(dir, nil, "ReloadOrg")
+	assert.NoError(t, err)
+	thisMSP, err := newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, keystore), true)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+	reloader := thisMSP.(SigningIdentityReloader)
+	current, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+
+	reload := func(ca *ed25519TestCA) (SigningIdentity, error) {
+		writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), ca.certPEM())
+		sidInfo, err := GetLocalSigningIdentityInfo(dir)
+		assert.NoError(t, err)
+		return reloader.ReloadSigningIdentity(sidInfo)
+	}
+
+	// Reloading the same signing identity keeps it
+	sid, err := reload(peer)
+	assert.NoError(t, err)
+	assert.Equal(t, current, sid)
+
+	// The private key of the new signing identity must be available
+	rotated := newED25519TestCert(t, "peer", false, rootCA)
+	sid, err = reload(rotated)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed loading the new signing identity")
+	assert.Equal(t, current, sid)
+	writeKey(rotated)
+
+	// The new signing identity must be valid for the MSP
+	foreign := newED25519TestCert(t, "peer", false, newED25519TestCert(t, "ca", true, nil))
+	writeKey(foreign)
+	sid, err = reload(foreign)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the new signing identity is not valid")
+	assert.Equal(t, current, sid)
+
+	// The new signing identity must be valid already
+	notYetValid := newED25519TestCertWithValidity(t, "peer", false, rootCA, time.Now().Add(time.Minute), time.Now().Add(2*time.Hour))
+	writeKey(notYetValid)
+	_, err = reload(notYetValid)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the new signing identity is valid only from")
+	sid, err = thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.Equal(t, current, sid)
+
+	// A signing identity that expires before the current one replaces it nonetheless
+	expiresEarlier := newED25519TestCertWithValidity(t, "peer", false, rootCA, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+	writeKey(expiresEarlier)
+	sid, err = reload(expiresEarlier)
+	assert.NoError(t, err)
+	assert.NotEqual(t, current, sid)
+	current = sid
+
+	// The rotated signing identity replaces the current one
+	sid, err = reload(rotated)
+	assert.NoError(t, err)
+	assert.NotEqual(t, current, sid)
+	defaultSID, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.Equal(t, sid, defaultSID)
+	serialized, err := sid.Serialize()
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.Equal(t, rotated.cert.Raw, id.(*identity).cert.Raw)
+	msg := []byte("hello world")
+	signature, err := sid.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, signature))
+	assert.Error(t, current.Verify(msg, signature))
+}
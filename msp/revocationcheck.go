@@ -0,0 +1,370 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/sinochem-tech/fabric/common/crypto/ocsp"
+	m "github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/pkg/errors"
+	xocsp "golang.org/x/crypto/ocsp"
+)
+
+const (
+	defaultRevocationCacheExpiration = 10 * time.Minute
+	defaultRevocationCheckTimeout    = 5 * time.Second
+	// maxRevocationResponseSize bounds the size of the OCSP responses and CRLs that are fetched
+	maxRevocationResponseSize = 10 * 1024 * 1024
+)
+
+// RevocationStatusChecker is implemented by identities whose revocation status
+// can be checked beyond the revocation lists of their MSP.
+// Identity.Validate doesn't perform these checks, so that its outcome is the
+// same on every peer.
+type RevocationStatusChecker interface {
+	// CheckRevocationOnline checks the revocation status of the identity at the
+	// current time, using its stapled OCSP response, the OCSP responders and the
+	// CRL distribution points of its certificate. Its outcome depends on time and
+	// on the availability of the responders, therefore it is meant for requests
+	// served by the node only, such as proposals, gossip and deliver requests,
+	// and never for the validation of transactions.
+	CheckRevocationOnline() error
+
+	// CheckStapledRevocationStatus checks the revocation status of the identity at
+	// the given time, such as the timestamp of a transaction, using only the OCSP
+	// response stapled to it. Its outcome only depends on the identity and on at.
+	CheckStapledRevocationStatus(at time.Time) error
+}
+
+// CheckRevocationOnline checks the revocation status of id online,
+// if id is a RevocationStatusChecker. Otherwise it returns nil.
+func CheckRevocationOnline(id Identity) error {
+	rsc, ok := id.(RevocationStatusChecker)
+	if !ok {
+		return nil
+	}
+	return rsc.CheckRevocationOnline()
+}
+
+// CheckStapledRevocationStatus checks the revocation status of id at the given time
+// against its stapled OCSP response, if id is a RevocationStatusChecker.
+// Otherwise it returns nil.
+func CheckStapledRevocationStatus(id Identity, at time.Time) error {
+	rsc, ok := id.(RevocationStatusChecker)
+	if !ok {
+		return nil
+	}
+	return rsc.CheckStapledRevocationStatus(at)
+}
+
+// StapleOCSPResponse returns the given serialized identity with the given
+// DER encoded OCSP response stapled to it, so that MSPs checking revocation
+// online don't need to contact the OCSP responder of the identity
+func StapleOCSPResponse(serializedID, ocspResponse []byte) ([]byte, error) {
+	sId := &m.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedID, sId); err != nil {
+		return nil, errors.Wrap(err, "could not deserialize a SerializedIdentity")
+	}
+	sId.OcspResponse = ocspResponse
+
+	raw, err := proto.Marshal(sId)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal a SerializedIdentity")
+	}
+	return raw, nil
+}
+
+// getIssuer returns the certificate of the CA that issued the certificate of id
+func (msp *bccspmsp) getIssuer(id *identity) (*x509.Certificate, error) {
+	validationChain, err := msp.getCertificationChainForBCCSPIdentity(id)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not obtain certification chain")
+	}
+	return validationChain[1], nil
+}
+
+// revocationStatus is the cached revocation status of a certificate
+type revocationStatus struct {
+	revoked bool
+	expiry  time.Time
+}
+
+// cachedCRL is a CRL fetched from a distribution point
+type cachedCRL struct {
+	crl    *pkix.CertificateList
+	expiry time.Time
+}
+
+// revocationChecker checks the revocation status of certificates online, using
+// stapled OCSP responses, the OCSP responders and the CRL distribution points
+// listed in the certificates
+type revocationChecker struct {
+	ocsp                  bool
+	crlDistributionPoints bool
+	hardFail              bool
+	cacheExpiration       time.Duration
+	client                *http.Client
+
+	lock     sync.Mutex
+	statuses map[string]revocationStatus
+	crls     map[string]cachedCRL
+}
+
+func newRevocationChecker(conf *m.FabricRevocationCheckConfig) (*revocationChecker, error) {
+	rc := &revocationChecker{
+		ocsp:                  conf.Ocsp,
+		crlDistributionPoints: conf.CrlDistributionPoints,
+		hardFail:              conf.HardFail,
+		cacheExpiration:       defaultRevocationCacheExpiration,
+		statuses:              make(map[string]revocationStatus),
+		crls:                  make(map[string]cachedCRL),
+	}
+
+	if conf.CacheExpiration != "" {
+		d, err := time.ParseDuration(conf.CacheExpiration)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid revocation check cache expiration [%s]", conf.CacheExpiration)
+		}
+		rc.cacheExpiration = d
+	}
+
+	timeout := defaultRevocationCheckTimeout
+	if conf.Timeout != "" {
+		d, err := time.ParseDuration(conf.Timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid revocation check timeout [%s]", conf.Timeout)
+		}
+		timeout = d
+	}
+	rc.client = &http.Client{Timeout: timeout}
+
+	return rc, nil
+}
+
+// check returns an error if cert, issued by issuer, is revoked. If its revocation
+// status can't be determined, an error is returned only in hard-fail mode.
+// ocspResponse is an optional stapled OCSP response for the certificate.
+func (rc *revocationChecker) check(cert, issuer *x509.Certificate, ocspResponse []byte) error {
+	key := statusKey(cert, issuer)
+	now := time.Now()
+
+	rc.lock.Lock()
+	status, cached := rc.statuses[key]
+	rc.lock.Unlock()
+	if cached && now.Before(status.expiry) {
+		return status.err()
+	}
+
+	var errs []error
+
+	// A stapled OCSP response, if valid, spares contacting the responder
+	if len(ocspResponse) != 0 {
+		status, err := rc.statusFromOCSPResponse(ocspResponse, cert, issuer, now)
+		if err == nil {
+			return rc.store(key, status)
+		}
+		mspLogger.Warningf("Ignoring stapled OCSP response: %s", err)
+		errs = append(errs, errors.WithMessage(err, "invalid stapled OCSP response"))
+	}
+
+	if rc.ocsp {
+		for _, url := range cert.OCSPServer {
+			status, err := rc.queryOCSP(url, cert, issuer)
+			if err == nil {
+				return rc.store(key, status)
+			}
+			mspLogger.Warningf("Failed querying OCSP responder %s: %s", url, err)
+			errs = append(errs, err)
+		}
+	}
+
+	if rc.crlDistributionPoints {
+		for _, url := range cert.CRLDistributionPoints {
+			status, err := rc.statusFromCRL(url, cert, issuer)
+			if err == nil {
+				return rc.store(key, status)
+			}
+			mspLogger.Warningf("Failed checking CRL distribution point %s: %s", url, err)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		// there is no source of revocation information for this certificate
+		return nil
+	}
+
+	if rc.hardFail {
+		return errors.Errorf("could not determine the revocation status of the certificate: %v", errs)
+	}
+	mspLogger.Warningf("Could not determine the revocation status of certificate with serial number %s, accepting it", cert.SerialNumber)
+	return nil
+}
+
+// checkStapled returns an error if the stapled OCSP response states that cert,
+// issued by issuer, is revoked at the given time. No responder is contacted and
+// nothing is cached, hence the outcome only depends on the arguments.
+// Identities without a stapled response are accepted, while a stapled response
+// that isn't valid at the given time makes the identity invalid only in hard-fail mode.
+func (rc *revocationChecker) checkStapled(cert, issuer *x509.Certificate, ocspResponse []byte, at time.Time) error {
+	if len(ocspResponse) == 0 {
+		return nil
+	}
+	status, err := rc.statusFromOCSPResponse(ocspResponse, cert, issuer, at)
+	if err != nil {
+		if rc.hardFail {
+			return errors.WithMessage(err, "invalid stapled OCSP response")
+		}
+		mspLogger.Warningf("Ignoring stapled OCSP response: %s", err)
+		return nil
+	}
+	return status.err()
+}
+
+func (rc *revocationChecker) store(key string, status revocationStatus) error {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+	rc.statuses[key] = status
+	return status.err()
+}
+
+func (rc *revocationChecker) queryOCSP(url string, cert, issuer *x509.Certificate) (revocationStatus, error) {
+	req, err := xocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return revocationStatus{}, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(req))
+	if err != nil {
+		return revocationStatus{}, errors.Wrap(err, "failed creating OCSP request")
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	resp, err := rc.fetch(httpReq)
+	if err != nil {
+		return revocationStatus{}, err
+	}
+	return rc.statusFromOCSPResponse(resp, cert, issuer, time.Now())
+}
+
+func (rc *revocationChecker) statusFromOCSPResponse(raw []byte, cert, issuer *x509.Certificate, now time.Time) (revocationStatus, error) {
+	resp, err := ocsp.ParseResponse(raw, cert, issuer)
+	if err != nil {
+		return revocationStatus{}, err
+	}
+	if now.Before(resp.ThisUpdate.Add(-time.Minute)) {
+		return revocationStatus{}, errors.New("the OCSP response is not valid yet")
+	}
+	if !resp.NextUpdate.IsZero() && now.After(resp.NextUpdate) {
+		return revocationStatus{}, errors.New("the OCSP response has expired")
+	}
+
+	switch resp.Status {
+	case xocsp.Good:
+		return rc.newStatus(false, resp.NextUpdate, now), nil
+	case xocsp.Revoked:
+		return rc.newStatus(true, resp.NextUpdate, now), nil
+	default:
+		return revocationStatus{}, errors.New("the OCSP responder doesn't know the certificate")
+	}
+}
+
+func (rc *revocationChecker) statusFromCRL(url string, cert, issuer *x509.Certificate) (revocationStatus, error) {
+	now := time.Now()
+
+	// the same distribution point might be listed in certificates of different issuers
+	issuerHash := sha256.Sum256(issuer.Raw)
+	crlKey := url + "#" + hex.EncodeToString(issuerHash[:])
+
+	rc.lock.Lock()
+	cached, exists := rc.crls[crlKey]
+	rc.lock.Unlock()
+
+	crl := cached.crl
+	if !exists || !now.Before(cached.expiry) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return revocationStatus{}, errors.Wrap(err, "failed creating CRL request")
+		}
+		raw, err := rc.fetch(req)
+		if err != nil {
+			return revocationStatus{}, err
+		}
+		crl, err = x509.ParseCRL(raw)
+		if err != nil {
+			return revocationStatus{}, errors.Wrap(err, "failed parsing CRL")
+		}
+		if err := sm2.CheckCRLSignature(issuer, crl); err != nil {
+			return revocationStatus{}, errors.Wrap(err, "the CRL is not signed by the issuer of the certificate")
+		}
+		if crl.HasExpired(now) {
+			return revocationStatus{}, errors.New("the CRL has expired")
+		}
+
+		rc.lock.Lock()
+		rc.crls[crlKey] = cachedCRL{crl: crl, expiry: rc.newStatus(false, crl.TBSCertList.NextUpdate, now).expiry}
+		rc.lock.Unlock()
+	}
+
+	for _, revoked := range crl.TBSCertList.RevokedCertificates {
+		if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return rc.newStatus(true, crl.TBSCertList.NextUpdate, now), nil
+		}
+	}
+	return rc.newStatus(false, crl.TBSCertList.NextUpdate, now), nil
+}
+
+func (rc *revocationChecker) fetch(req *http.Request) ([]byte, error) {
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed contacting %s", req.URL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s replied with status %d", req.URL, resp.StatusCode)
+	}
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading the reply of %s", req.URL)
+	}
+	return raw, nil
+}
+
+// newStatus returns a revocation status that expires at nextUpdate,
+// but not after the configured cache expiration
+func (rc *revocationChecker) newStatus(revoked bool, nextUpdate, now time.Time) revocationStatus {
+	expiry := now.Add(rc.cacheExpiration)
+	if !nextUpdate.IsZero() && nextUpdate.Before(expiry) {
+		expiry = nextUpdate
+	}
+	return revocationStatus{revoked: revoked, expiry: expiry}
+}
+
+func (s revocationStatus) err() error {
+	if s.revoked {
+		return errors.New("The certificate has been revoked")
+	}
+	return nil
+}
+
+// statusKey identifies a certificate by its issuer and serial number
+func statusKey(cert, issuer *x509.Certificate) string {
+	issuerHash := sha256.Sum256(issuer.Raw)
+	return hex.EncodeToString(issuerHash[:]) + ":" + cert.SerialNumber.String()
+}
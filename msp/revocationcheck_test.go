@@ -0,0 +1,365 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	m "github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ocsp"
+)
+
+type ocspTestCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newOCSPTestCert(t *testing.T, cn string, parent *ocspTestCA, ocspServer, crlDistributionPoint string) *ocspTestCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.NoError(t, err)
+	ski := sha256.Sum256(elliptic.Marshal(key.Curve, key.X, key.Y))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"ocsporg"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          ski[:],
+	}
+	if ocspServer != "" {
+		template.OCSPServer = []string{ocspServer}
+	}
+	if crlDistributionPoint != "" {
+		template.CRLDistributionPoints = []string{crlDistributionPoint}
+	}
+
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	} else {
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &ocspTestCA{cert: cert, key: key}
+}
+
+func (ca *ocspTestCA) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// testOCSPResponder is an in-process OCSP responder and CRL distribution point
+type testOCSPResponder struct {
+	ca *ocspTestCA
+
+	lock     sync.Mutex
+	revoked  map[string]bool
+	requests int
+}
+
+func (r *testOCSPResponder) revoke(serial *big.Int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.revoked[serial.String()] = true
+}
+
+func (r *testOCSPResponder) requestCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests
+}
+
+func (r *testOCSPResponder) response(t *testing.T, serial *big.Int) []byte {
+	r.lock.Lock()
+	status := ocsp.Good
+	if r.revoked[serial.String()] {
+		status = ocsp.Revoked
+	}
+	r.lock.Unlock()
+
+	now := time.Now().Truncate(time.Second)
+	resp, err := ocsp.CreateResponse(r.ca.cert, r.ca.cert, ocsp.Response{
+		Status:       status,
+		SerialNumber: serial,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(time.Hour),
+		RevokedAt:    now,
+	}, r.ca.key)
+	assert.NoError(t, err)
+	return resp
+}
+
+// nameHash returns the hash of the subject of the issuer in OCSP requests
+func nameHash(hash crypto.Hash, issuer *x509.Certificate) []byte {
+	h := hash.New()
+	h.Write(issuer.RawSubject)
+	return h.Sum(nil)
+}
+
+func (r *testOCSPResponder) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocsp", func(w http.ResponseWriter, req *http.Request) {
+		r.lock.Lock()
+		r.requests++
+		r.lock.Unlock()
+
+		raw, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		ocspReq, err := ocsp.ParseRequest(raw)
+		if err != nil || !bytes.Equal(ocspReq.IssuerNameHash, nameHash(ocspReq.HashAlgorithm, r.ca.cert)) {
+			w.Write(ocsp.MalformedRequestErrorResponse)
+			return
+		}
+		w.Write(r.response(t, ocspReq.SerialNumber))
+	})
+	mux.HandleFunc("/crl", func(w http.ResponseWriter, req *http.Request) {
+		r.lock.Lock()
+		r.requests++
+		var revoked []pkix.RevokedCertificate
+		for serial := range r.revoked {
+			n, _ := new(big.Int).SetString(serial, 10)
+			revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: n, RevocationTime: time.Now()})
+		}
+		r.lock.Unlock()
+
+		der, err := r.ca.cert.CreateCRL(rand.Reader, r.ca.key, revoked, time.Now(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		w.Write(der)
+	})
+	return mux
+}
+
+func setupRevocationCheckMSP(t *testing.T, dir string, ca, signer *ocspTestCA, config string) MSP {
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), ca.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), signer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "admin.pem"), signer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, configfilename), []byte(config))
+	rawKey, err := utils.PrivateKeyToPEM(signer.key, nil)
+	assert.NoError(t, err)
+	writeMSPTestFile(t, filepath.Join(dir, keystore, hex.EncodeToString(signer.cert.SubjectKeyId)+"_sk"), rawKey)
+
+	conf, err := GetLocalMspConfig(dir, nil, "OCSPOrg")
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, keystore), true)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SHA2", ks)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+	return thisMSP
+}
+
+func TestOnlineRevocationCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ocspmsp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newOCSPTestCert(t, "ca", nil, "", "")
+	responder := &testOCSPResponder{ca: ca, revoked: make(map[string]bool)}
+	server := httptest.NewServer(responder.handler(t))
+	defer server.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	// The signer has an OCSP responder which is never contacted during setup
+	peer := newOCSPTestCert(t, "peer", ca, server.URL+"/ocsp", "")
+	thisMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "soft"), ca, peer, "RevocationCheck:\n  OCSP: true\n  CRLDistributionPoints: true\n")
+	assert.Equal(t, 0, responder.requestCount())
+
+	getID := func(msp MSP, cert *ocspTestCA) Identity {
+		id, _, err := msp.(*bccspmsp).getIdentityFromConf(cert.certPEM())
+		assert.NoError(t, err)
+		return id
+	}
+
+	// A good certificate is accepted, and its status is cached
+	signer, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, CheckRevocationOnline(signer))
+	assert.Equal(t, 1, responder.requestCount())
+	assert.NoError(t, CheckRevocationOnline(signer))
+	assert.Equal(t, 1, responder.requestCount())
+
+	// A certificate revoked through OCSP is refused
+	revoked := newOCSPTestCert(t, "revoked", ca, server.URL+"/ocsp", "")
+	responder.revoke(revoked.cert.SerialNumber)
+	err = CheckRevocationOnline(getID(thisMSP, revoked))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The certificate has been revoked")
+
+	// The validation of identities doesn't contact the responders,
+	// so that its outcome is the same on every peer
+	count := responder.requestCount()
+	assert.NoError(t, getID(thisMSP, revoked).Validate())
+	assert.Equal(t, count, responder.requestCount())
+
+	// A certificate revoked through a CRL distribution point is refused
+	revokedCRL := newOCSPTestCert(t, "revokedcrl", ca, "", server.URL+"/crl")
+	goodCRL := newOCSPTestCert(t, "goodcrl", ca, "", server.URL+"/crl")
+	responder.revoke(revokedCRL.cert.SerialNumber)
+	err = CheckRevocationOnline(getID(thisMSP, revokedCRL))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The certificate has been revoked")
+	count = responder.requestCount()
+	assert.NoError(t, CheckRevocationOnline(getID(thisMSP, goodCRL)))
+	// the CRL is cached
+	assert.Equal(t, count, responder.requestCount())
+
+	// A stapled OCSP response spares contacting the responder
+	stapled := newOCSPTestCert(t, "stapled", ca, server.URL+"/ocsp", "")
+	serialized, err := getID(thisMSP, stapled).Serialize()
+	assert.NoError(t, err)
+	serialized, err = StapleOCSPResponse(serialized, responder.response(t, stapled.cert.SerialNumber))
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	count = responder.requestCount()
+	assert.NoError(t, CheckRevocationOnline(id))
+	assert.Equal(t, count, responder.requestCount())
+	// the staple isn't part of the identity
+	reserialized, err := id.Serialize()
+	assert.NoError(t, err)
+	assert.NotEqual(t, serialized, reserialized)
+
+	// A stapled OCSP response telling about another certificate is ignored
+	serialized, err = getID(thisMSP, revoked).Serialize()
+	assert.NoError(t, err)
+	serialized, err = StapleOCSPResponse(serialized, responder.response(t, stapled.cert.SerialNumber))
+	assert.NoError(t, err)
+	id, err = thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.Error(t, CheckRevocationOnline(id))
+
+	// Soft-fail: a certificate whose status can't be determined is accepted
+	unknown := newOCSPTestCert(t, "unknown", ca, unreachable.URL+"/ocsp", "")
+	assert.NoError(t, CheckRevocationOnline(getID(thisMSP, unknown)))
+
+	// Certificates without revocation information are accepted
+	assert.NoError(t, CheckRevocationOnline(getID(thisMSP, newOCSPTestCert(t, "noinfo", ca, "", ""))))
+
+	// Hard-fail: a certificate whose status can't be determined is refused
+	hardMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "hard"), ca, peer, "RevocationCheck:\n  OCSP: true\n  HardFail: true\n  Timeout: 1s\n")
+	err = CheckRevocationOnline(getID(hardMSP, unknown))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not determine the revocation status of the certificate")
+	assert.NoError(t, CheckRevocationOnline(getID(hardMSP, goodCRL)))
+
+	// Without online checking, revocation information in the certificates is ignored
+	offlineMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "offline"), ca, peer, "RevocationCheck:\n  HardFail: true\n")
+	assert.NoError(t, CheckRevocationOnline(getID(offlineMSP, revoked)))
+}
+
+func TestStapledRevocationStatus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ocspmsp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newOCSPTestCert(t, "ca", nil, "", "")
+	responder := &testOCSPResponder{ca: ca, revoked: make(map[string]bool)}
+	server := httptest.NewServer(responder.handler(t))
+	defer server.Close()
+	peer := newOCSPTestCert(t, "peer", ca, server.URL+"/ocsp", "")
+	softMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "soft"), ca, peer, "RevocationCheck:\n  OCSP: true\n")
+	hardMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "hard"), ca, peer, "RevocationCheck:\n  OCSP: true\n  HardFail: true\n")
+
+	staple := func(msp MSP, cert *ocspTestCA) Identity {
+		id, _, err := msp.(*bccspmsp).getIdentityFromConf(cert.certPEM())
+		assert.NoError(t, err)
+		serialized, err := id.Serialize()
+		assert.NoError(t, err)
+		serialized, err = StapleOCSPResponse(serialized, responder.response(t, cert.cert.SerialNumber))
+		assert.NoError(t, err)
+		id, err = msp.DeserializeIdentity(serialized)
+		assert.NoError(t, err)
+		return id
+	}
+
+	good := newOCSPTestCert(t, "good", ca, server.URL+"/ocsp", "")
+	revoked := newOCSPTestCert(t, "revoked", ca, server.URL+"/ocsp", "")
+	responder.revoke(revoked.cert.SerialNumber)
+	now := time.Now()
+	later := now.Add(2 * time.Hour)
+
+	for _, msp := range []MSP{softMSP, hardMSP} {
+		// The responders are never contacted
+		assert.NoError(t, CheckStapledRevocationStatus(staple(msp, good), now))
+		err := CheckStapledRevocationStatus(staple(msp, revoked), now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "The certificate has been revoked")
+
+		// Identities without a staple are accepted
+		id, _, err := msp.(*bccspmsp).getIdentityFromConf(revoked.certPEM())
+		assert.NoError(t, err)
+		assert.NoError(t, CheckStapledRevocationStatus(id, now))
+	}
+	assert.Equal(t, 0, responder.requestCount())
+
+	// A staple that has expired at the given time is ignored, unless in hard-fail mode
+	assert.NoError(t, CheckStapledRevocationStatus(staple(softMSP, revoked), later))
+	err = CheckStapledRevocationStatus(staple(hardMSP, good), later)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the OCSP response has expired")
+
+	// Without online checking, staples are ignored
+	offlineMSP := setupRevocationCheckMSP(t, filepath.Join(dir, "offline"), ca, peer, "")
+	assert.NoError(t, CheckStapledRevocationStatus(staple(offlineMSP, revoked), now))
+}
+
+func TestRevocationCheckerCacheExpiration(t *testing.T) {
+	ca := newOCSPTestCert(t, "ca", nil, "", "")
+	responder := &testOCSPResponder{ca: ca, revoked: make(map[string]bool)}
+	server := httptest.NewServer(responder.handler(t))
+	defer server.Close()
+	peer := newOCSPTestCert(t, "peer", ca, server.URL+"/ocsp", "")
+
+	_, err := newRevocationChecker(&m.FabricRevocationCheckConfig{Ocsp: true, CacheExpiration: "forever"})
+	assert.Error(t, err)
+	_, err = newRevocationChecker(&m.FabricRevocationCheckConfig{Ocsp: true, Timeout: "never"})
+	assert.Error(t, err)
+
+	rc, err := newRevocationChecker(&m.FabricRevocationCheckConfig{Ocsp: true, CacheExpiration: "1ns"})
+	assert.NoError(t, err)
+	assert.NoError(t, rc.check(peer.cert, ca.cert, nil))
+	assert.Equal(t, 1, responder.requestCount())
+
+	// Once the cached status expires, a revocation is noticed
+	responder.revoke(peer.cert.SerialNumber)
+	time.Sleep(time.Millisecond)
+	assert.EqualError(t, rc.check(peer.cert, ca.cert, nil), "The certificate has been revoked")
+	assert.Equal(t, 2, responder.requestCount())
+}
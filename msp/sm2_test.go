@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm2"
+	"github.com/sinochem-tech/fabric/bccsp/gm/sm3"
+	"github.com/sinochem-tech/fabric/bccsp/sw"
+	"github.com/sinochem-tech/fabric/bccsp/utils"
+	"github.com/sinochem-tech/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+type sm2TestCA struct {
+	cert *x509.Certificate
+	key  *sm2.PrivateKey
+}
+
+func newSM2TestCert(t *testing.T, cn string, isCA bool, parent *sm2TestCA) *sm2TestCA {
+	key, err := sm2.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.NoError(t, err)
+	ski := sm3.Sum(elliptic.Marshal(key.Curve, key.X, key.Y))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"sm2org"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		SubjectKeyId:          ski[:],
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := sm2.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	assert.NoError(t, err)
+	cert, err := sm2.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &sm2TestCA{cert: cert, key: key}
+}
+
+func (ca *sm2TestCA) certPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *sm2TestCA) revoke(t *testing.T, serials ...*big.Int) []byte {
+	aki, err := asn1.Marshal(authorityKeyIdentifier{KeyIdentifier: ca.cert.SubjectKeyId})
+	assert.NoError(t, err)
+	tbs := pkix.TBSCertificateList{
+		Version:    1,
+		Signature:  pkix.AlgorithmIdentifier{Algorithm: sm2.OIDSignatureSM2WithSM3},
+		Issuer:     ca.cert.Subject.ToRDNSequence(),
+		ThisUpdate: time.Now().UTC(),
+		NextUpdate: time.Now().Add(time.Hour).UTC(),
+		Extensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{2, 5, 29, 35}, Value: aki}},
+	}
+	for _, serial := range serials {
+		tbs.RevokedCertificates = append(tbs.RevokedCertificates, pkix.RevokedCertificate{SerialNumber: serial, RevocationTime: time.Now().UTC()})
+	}
+	rawTBS, err := asn1.Marshal(tbs)
+	assert.NoError(t, err)
+	signature, err := ca.key.Sign(rand.Reader, rawTBS, nil)
+	assert.NoError(t, err)
+	der, err := asn1.Marshal(pkix.CertificateList{
+		TBSCertList:        tbs,
+		SignatureAlgorithm: tbs.Signature,
+		SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func writeMSPTestFile(t *testing.T, path string, content []byte) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, content, 0644))
+}
+
+func TestSM2MSP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sm2msp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rootCA := newSM2TestCert(t, "ca", true, nil)
+	intermediateCA := newSM2TestCert(t, "ica", true, rootCA)
+	peer := newSM2TestCert(t, "peer", false, intermediateCA)
+	admin := newSM2TestCert(t, "admin", false, intermediateCA)
+	revoked := newSM2TestCert(t, "revoked", false, intermediateCA)
+
+	writeMSPTestFile(t, filepath.Join(dir, cacerts, "ca.pem"), rootCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, intermediatecerts, "ica.pem"), intermediateCA.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, signcerts, "peer.pem"), peer.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, admincerts, "admin.pem"), admin.certPEM())
+	writeMSPTestFile(t, filepath.Join(dir, crlsfolder, "crl.pem"), intermediateCA.revoke(t, revoked.cert.SerialNumber))
+	writeMSPTestFile(t, filepath.Join(dir, configfilename), []byte("CryptoConfig:\n  SignatureHashFamily: SM3\n  IdentityIdentifierHashFunction: SM3\n"))
+	rawKey, err := utils.PrivateKeyToPEM(peer.key, nil)
+	assert.NoError(t, err)
+	writeMSPTestFile(t, filepath.Join(dir, keystore, hex.EncodeToString(peer.cert.SubjectKeyId)+"_sk"), rawKey)
+
+	conf, err := GetLocalMspConfig(dir, nil, "SM2Org")
+	assert.NoError(t, err)
+
+	thisMSP, err := newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, keystore), true)
+	assert.NoError(t, err)
+	csp, err := sw.NewWithParams(256, "SM3", ks)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+
+	// The signing identity is valid, and signs with SM2 over SM3 digests
+	signer, err := thisMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Validate())
+	msg := []byte("hello world")
+	signature, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, signer.Verify(msg, signature))
+	assert.Error(t, signer.Verify([]byte("hello world!"), signature))
+	expectedID := sm3.Sum(peer.cert.Raw)
+	assert.Equal(t, hex.EncodeToString(expectedID[:]), signer.GetIdentifier().Id)
+
+	// Serialized identities round trip
+	serialized, err := signer.Serialize()
+	assert.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	assert.NoError(t, id.Verify(msg, signature))
+
+	// Admins are recognized
+	adminID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(admin.certPEM())
+	assert.NoError(t, err)
+	assert.NoError(t, adminID.Validate())
+
+	// Revoked certificates are refused
+	revokedID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(revoked.certPEM())
+	assert.NoError(t, err)
+	err = revokedID.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has been revoked")
+
+	// Certificates issued by foreign CAs are refused
+	foreignCA := newSM2TestCert(t, "ca", true, nil)
+	foreign := newSM2TestCert(t, "peer", false, foreignCA)
+	foreignID, _, err := thisMSP.(*bccspmsp).getIdentityFromConf(foreign.certPEM())
+	assert.NoError(t, err)
+	assert.Error(t, foreignID.Validate())
+}
+
+func TestSM2IdentitiesRequireMSPv13(t *testing.T) {
+	rootCA := newSM2TestCert(t, "ca", true, nil)
+	peer := newSM2TestCert(t, "peer", false, rootCA)
+	fabricConf := &msp.FabricMSPConfig{
+		Name:      "SM2Org",
+		RootCerts: [][]byte{rootCA.certPEM()},
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            "SM3",
+			IdentityIdentifierHashFunction: "SM3",
+		},
+	}
+	confBytes, err := proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+	conf := &msp.MSPConfig{Config: confBytes}
+	csp, err := sw.NewWithParams(256, "SM3", sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	// Without the V1_3 channel capability neither SM2 CAs nor SM2 identities are accepted
+	thisMSP, err := newBccspMsp(MSPv1_1)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.Error(t, thisMSP.Setup(conf))
+	_, err = thisMSP.(*bccspmsp).deserializeIdentityInternal(peer.certPEM())
+	assert.Error(t, err)
+
+	// With it they are
+	thisMSP, err = newBccspMsp(MSPv1_3)
+	assert.NoError(t, err)
+	thisMSP.(*bccspmsp).bccsp = csp
+	assert.NoError(t, thisMSP.Setup(conf))
+	id, err := thisMSP.(*bccspmsp).deserializeIdentityInternal(peer.certPEM())
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+}
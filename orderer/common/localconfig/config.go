@@ -34,6 +34,7 @@ type TopLevel struct {
 	RAMLedger  RAMLedger
 	Kafka      Kafka
 	Debug      Debug
+	Metrics    Metrics
 }
 
 // General contains config which should be common among all orderer types.
@@ -153,6 +154,27 @@ type Debug struct {
 	DeliverTraceDir   string
 }
 
+// Metrics contains configuration for the metrics reported by the orderer.
+type Metrics struct {
+	Enabled        bool
+	Reporter       string
+	Interval       time.Duration
+	StatsdReporter StatsdReporter
+	PromReporter   PromReporter
+}
+
+// StatsdReporter contains configuration for reporting the metrics to a statsd server.
+type StatsdReporter struct {
+	Address       string
+	FlushInterval time.Duration
+	FlushBytes    int
+}
+
+// PromReporter contains configuration for exposing the metrics to Prometheus.
+type PromReporter struct {
+	ListenAddress string
+}
+
 // Defaults carries the default orderer configuration values.
 var Defaults = TopLevel{
 	General: General{
@@ -216,6 +238,19 @@ var Defaults = TopLevel{
 		BroadcastTraceDir: "",
 		DeliverTraceDir:   "",
 	},
+	Metrics: Metrics{
+		Enabled:  false,
+		Reporter: "statsd",
+		Interval: time.Second,
+		StatsdReporter: StatsdReporter{
+			Address:       "0.0.0.0:8125",
+			FlushInterval: 2 * time.Second,
+			FlushBytes:    1432,
+		},
+		PromReporter: PromReporter{
+			ListenAddress: "0.0.0.0:8080",
+		},
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -250,6 +285,10 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		if c.General.BCCSP != nil && c.General.BCCSP.SwOpts != nil && c.General.BCCSP.SwOpts.FileKeystore != nil &&
+			c.General.BCCSP.SwOpts.FileKeystore.PasswordFile != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.BCCSP.SwOpts.FileKeystore.PasswordFile)
+		}
 	}()
 
 	for {
@@ -352,6 +391,19 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Version unset, setting to %v", Defaults.Kafka.Version)
 			c.Kafka.Version = Defaults.Kafka.Version
 
+		case c.Metrics.Enabled && c.Metrics.Reporter == "":
+			logger.Infof("Metrics enabled and Metrics.Reporter unset, setting to %s", Defaults.Metrics.Reporter)
+			c.Metrics.Reporter = Defaults.Metrics.Reporter
+		case c.Metrics.Enabled && c.Metrics.Interval == 0:
+			logger.Infof("Metrics enabled and Metrics.Interval unset, setting to %v", Defaults.Metrics.Interval)
+			c.Metrics.Interval = Defaults.Metrics.Interval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushInterval == 0:
+			logger.Infof("Metrics enabled and Metrics.StatsdReporter.FlushInterval unset, setting to %v", Defaults.Metrics.StatsdReporter.FlushInterval)
+			c.Metrics.StatsdReporter.FlushInterval = Defaults.Metrics.StatsdReporter.FlushInterval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushBytes == 0:
+			logger.Infof("Metrics enabled and Metrics.StatsdReporter.FlushBytes unset, setting to %v", Defaults.Metrics.StatsdReporter.FlushBytes)
+			c.Metrics.StatsdReporter.FlushBytes = Defaults.Metrics.StatsdReporter.FlushBytes
+
 		default:
 			return
 		}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	bccsp "github.com/sinochem-tech/fabric/bccsp/factory"
 	"github.com/sinochem-tech/fabric/core/config/configtest"
 	"github.com/stretchr/testify/assert"
 )
@@ -114,3 +115,17 @@ func TestSystemChannel(t *testing.T) {
 	assert.Equal(t, Defaults.General.SystemChannel, conf.General.SystemChannel,
 		"Expected default system channel ID to be '%s', got '%s' instead", Defaults.General.SystemChannel, conf.General.SystemChannel)
 }
+
+func TestKeyStorePasswordFileTranslation(t *testing.T) {
+	uconf := &TopLevel{General: General{BCCSP: &bccsp.FactoryOpts{
+		SwOpts: &bccsp.SwOpts{FileKeystore: &bccsp.FileKeystoreOpts{PasswordFile: "keystore.pwd"}},
+	}}}
+	uconf.completeInitialization("/dummy/path")
+	assert.Equal(t, filepath.Join("/dummy/path", "keystore.pwd"), uconf.General.BCCSP.SwOpts.FileKeystore.PasswordFile)
+
+	uconf = &TopLevel{General: General{BCCSP: &bccsp.FactoryOpts{
+		SwOpts: &bccsp.SwOpts{FileKeystore: &bccsp.FileKeystoreOpts{}},
+	}}}
+	uconf.completeInitialization("/dummy/path")
+	assert.Empty(t, uconf.General.BCCSP.SwOpts.FileKeystore.PasswordFile)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sinochem-tech/fabric/common/crypto"
 	"github.com/sinochem-tech/fabric/common/flogging"
 	"github.com/sinochem-tech/fabric/common/ledger/blockledger"
+	"github.com/sinochem-tech/fabric/common/metrics"
 	"github.com/sinochem-tech/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/sinochem-tech/fabric/common/tools/configtxgen/localconfig"
 	"github.com/sinochem-tech/fabric/core/comm"
@@ -74,6 +75,7 @@ func Main() {
 	}
 	initializeLoggingLevel(conf)
 	initializeLocalMsp(conf)
+	initializeMetrics(conf)
 
 	prettyPrintStruct(conf)
 	Start(fullCmd, conf)
@@ -133,6 +135,34 @@ func initializeProfilingService(conf *localconfig.TopLevel) {
 	}
 }
 
+// initializeMetrics initializes the metrics and starts reporting them, if they are enabled
+func initializeMetrics(conf *localconfig.TopLevel) {
+	if !conf.Metrics.Enabled {
+		return
+	}
+	err := metrics.Init(metrics.Opts{
+		Enabled:  true,
+		Reporter: conf.Metrics.Reporter,
+		Interval: conf.Metrics.Interval,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conf.Metrics.StatsdReporter.Address,
+			FlushInterval: conf.Metrics.StatsdReporter.FlushInterval,
+			FlushBytes:    conf.Metrics.StatsdReporter.FlushBytes,
+		},
+		PromReporterOpts: metrics.PromReporterOpts{
+			ListenAddress: conf.Metrics.PromReporter.ListenAddress,
+		},
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize metrics:", err)
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Error("Failed to start metrics server:", err)
+		}
+	}()
+}
+
 func initializeServerConfig(conf *localconfig.TopLevel) comm.ServerConfig {
 	// secure server config
 	secureOpts := &comm.SecureOptions{
@@ -19,6 +19,7 @@ import (
 	"github.com/sinochem-tech/fabric/common/channelconfig"
 	"github.com/sinochem-tech/fabric/common/flogging"
 	"github.com/sinochem-tech/fabric/common/localmsp"
+	"github.com/sinochem-tech/fabric/common/metrics"
 	genesisconfig "github.com/sinochem-tech/fabric/common/tools/configtxgen/localconfig"
 	"github.com/sinochem-tech/fabric/core/comm"
 	"github.com/sinochem-tech/fabric/core/config/configtest"
@@ -68,6 +69,41 @@ func TestInitializeProfilingService(t *testing.T) {
 	}
 }
 
+func TestInitializeMetrics(t *testing.T) {
+	// The metrics aren't initialized unless they are enabled
+	initializeMetrics(&localconfig.TopLevel{})
+	assert.Nil(t, metrics.RootScope)
+
+	// Once they are, the root scope reports them
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	initializeMetrics(&localconfig.TopLevel{
+		Metrics: localconfig.Metrics{
+			Enabled:  true,
+			Reporter: "statsd",
+			Interval: 100 * time.Millisecond,
+			StatsdReporter: localconfig.StatsdReporter{
+				Address:       conn.LocalAddr().String(),
+				FlushInterval: 100 * time.Millisecond,
+				FlushBytes:    512,
+			},
+		},
+	})
+	defer metrics.Shutdown()
+	assert.NotNil(t, metrics.RootScope)
+
+	metrics.RootScope.SubScope("bccsp_remote").Tagged(map[string]string{"operation": "sign"}).Counter("requests").Inc(1)
+	buf := make([]byte, 2048)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if !assert.NoError(t, err) || strings.Contains(string(buf[:n]), "hyperledger_fabric.bccsp_remote.requests.operation-sign:1|c") {
+			return
+		}
+	}
+}
+
 func TestInitializeServerConfig(t *testing.T) {
 	conf := &localconfig.TopLevel{
 		General: localconfig.General{
@@ -162,11 +162,12 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 }
 
 type collectionConfigJson struct {
-	Name          string `json:"name"`
-	Policy        string `json:"policy"`
-	RequiredCount int32  `json:"requiredPeerCount"`
-	MaxPeerCount  int32  `json:"maxPeerCount"`
-	BlockToLive   uint64 `json:"blockToLive"`
+	Name              string `json:"name"`
+	Policy            string `json:"policy"`
+	RequiredCount     int32  `json:"requiredPeerCount"`
+	MaxPeerCount      int32  `json:"maxPeerCount"`
+	BlockToLive       uint64 `json:"blockToLive"`
+	EndorsementPolicy string `json:"endorsementPolicy,omitempty"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -204,6 +205,20 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		// the endorsement policy of the collection is optional
+		var epc *pcommon.CollectionPolicyConfig
+		if cconfitem.EndorsementPolicy != "" {
+			ep, err := cauthdsl.FromString(cconfitem.EndorsementPolicy)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid endorsement policy %s", cconfitem.EndorsementPolicy))
+			}
+			epc = &pcommon.CollectionPolicyConfig{
+				Payload: &pcommon.CollectionPolicyConfig_SignaturePolicy{
+					SignaturePolicy: ep,
+				},
+			}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -212,6 +227,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 					RequiredPeerCount: cconfitem.RequiredCount,
 					MaximumPeerCount:  cconfitem.MaxPeerCount,
 					BlockToLive:       cconfitem.BlockToLive,
+					EndorsementPolicy: epc,
 				},
 			},
 		}
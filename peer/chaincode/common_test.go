@@ -199,6 +199,26 @@ const sampleCollectionConfigBad = `[
 	}
 ]`
 
+const sampleCollectionConfigWithEndorsementPolicy = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": "AND('A.peer', 'C.peer')"
+	}
+]`
+
+const sampleCollectionConfigBadEndorsementPolicy = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 2,
+		"endorsementPolicy": "barf"
+	}
+]`
+
 func TestCollectionParsing(t *testing.T) {
 	cc, err := getCollectionConfigFromBytes([]byte(sampleCollectionConfigGood))
 	assert.NoError(t, err)
@@ -221,6 +241,21 @@ func TestCollectionParsing(t *testing.T) {
 	cc, err = getCollectionConfigFromBytes([]byte("barf"))
 	assert.Error(t, err)
 	assert.Nil(t, cc)
+
+	// the endorsement policy is not set unless specified
+	assert.Nil(t, conf.EndorsementPolicy)
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigWithEndorsementPolicy))
+	assert.NoError(t, err)
+	ccp = &common2.CollectionConfigPackage{}
+	proto.Unmarshal(cc, ccp)
+	conf = ccp.Config[0].GetStaticCollectionConfig()
+	pol, _ = cauthdsl.FromString("AND('A.peer', 'C.peer')")
+	assert.Equal(t, pol, conf.EndorsementPolicy.GetSignaturePolicy())
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBadEndorsementPolicy))
+	assert.Contains(t, err.Error(), "invalid endorsement policy barf")
+	assert.Nil(t, cc)
 }
 
 func TestValidatePeerConnectionParams(t *testing.T) {
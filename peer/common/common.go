@@ -134,11 +134,14 @@ func InitCrypto(mspMgrConfigDir, localMSPID, localMSPType string) error {
 	return nil
 }
 
-// SetBCCSPKeystorePath sets the file keystore path for the SW BCCSP provider
-// to an absolute path relative to the config file
+// SetBCCSPKeystorePath sets the file keystore path, and the path of the file
+// with the keystore password, for the SW BCCSP provider to absolute paths
+// relative to the config file
 func SetBCCSPKeystorePath() {
 	viper.Set("peer.BCCSP.SW.FileKeyStore.KeyStore",
 		config.GetPath("peer.BCCSP.SW.FileKeyStore.KeyStore"))
+	viper.Set("peer.BCCSP.SW.FileKeyStore.PasswordFile",
+		config.GetPath("peer.BCCSP.SW.FileKeyStore.PasswordFile"))
 }
 
 // GetDefaultSigner return a default Signer(Default/PERR) for cli
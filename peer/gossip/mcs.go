@@ -143,22 +143,42 @@ func (s *mspMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 		return fmt.Errorf("Invalid block's channel id. Expected [%s]. Given [%s]", chainID, channelID)
 	}
 
-	// - Unmarshal medatada
 	if block.Metadata == nil || len(block.Metadata.Metadata) == 0 {
 		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, chainID)
 	}
 
-	metadata, err := utils.GetMetadataFromBlock(block, pcommon.BlockMetadataIndex_SIGNATURES)
-	if err != nil {
-		return fmt.Errorf("Failed unmarshalling medatata for signatures [%s]", err)
-	}
-
 	// - Verify that Header.DataHash is equal to the hash of block.Data
 	// This is to ensure that the header is consistent with the data carried by this block
 	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
 		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
 	}
 
+	return s.verifyBlockSignatures(channelID, block)
+}
+
+// VerifyHeader returns nil if the header of a block of the given channel is properly signed,
+// according to the signatures in the given metadata of the block.
+// Since the data of the block isn't given, it isn't checked against the header
+func (s *mspMessageCryptoService) VerifyHeader(chainID string, header *pcommon.BlockHeader, metadata *pcommon.BlockMetadata) error {
+	if header == nil {
+		return fmt.Errorf("Invalid Block on channel [%s]. Header must be different from nil.", chainID)
+	}
+	return s.verifyBlockSignatures(chainID, &pcommon.Block{Header: header, Metadata: metadata})
+}
+
+// verifyBlockSignatures returns nil if the signatures in the metadata of the given block,
+// over its header, satisfy the block validation policy of the given channel
+func (s *mspMessageCryptoService) verifyBlockSignatures(channelID string, block *pcommon.Block) error {
+	// - Unmarshal medatada
+	if block.Metadata == nil || len(block.Metadata.Metadata) == 0 {
+		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, channelID)
+	}
+
+	metadata, err := utils.GetMetadataFromBlock(block, pcommon.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling medatata for signatures [%s]", err)
+	}
+
 	// - Get Policy for block validation
 
 	// Get the policy manager for channelID
@@ -179,7 +199,7 @@ func (s *mspMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 	for _, metadataSignature := range metadata.Signatures {
 		shdr, err := utils.GetSignatureHeader(metadataSignature.SignatureHeader)
 		if err != nil {
-			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, chainID, err)
+			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, channelID, err)
 		}
 		signatureSet = append(
 			signatureSet,
@@ -306,7 +326,7 @@ func (s *mspMessageCryptoService) getValidatedIdentity(peerIdentity api.PeerIden
 			// Notice that at this stage we don't have to check the identity
 			// against any channel's policies.
 			// This will be done by the caller function, if needed.
-			return identity, nil, identity.Validate()
+			return identity, nil, validateIdentity(identity)
 		}
 	}
 
@@ -329,7 +349,7 @@ func (s *mspMessageCryptoService) getValidatedIdentity(peerIdentity api.PeerIden
 		// against any channel's policies.
 		// This will be done by the caller function, if needed.
 
-		if err := identity.Validate(); err != nil {
+		if err := validateIdentity(identity); err != nil {
 			mcsLogger.Debugf("Failed validating identity [% x] on [%s]: [%s]", peerIdentity, chainID, err)
 			continue
 		}
@@ -341,3 +361,12 @@ func (s *mspMessageCryptoService) getValidatedIdentity(peerIdentity api.PeerIden
 
 	return nil, nil, fmt.Errorf("Peer Identity [% x] cannot be validated. No MSP found able to do that.", peerIdentity)
 }
+
+// validateIdentity validates the identity of a peer, and checks online whether
+// it has been revoked, as the outcome of gossip doesn't need to be deterministic
+func validateIdentity(identity msp.Identity) error {
+	if err := identity.Validate(); err != nil {
+		return err
+	}
+	return msp.CheckRevocationOnline(identity)
+}
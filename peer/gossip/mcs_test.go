@@ -65,9 +65,12 @@ func TestPKIidOfCert(t *testing.T) {
 	assert.NoError(t, err, "Failed computing digest of serialized identity [% x]", []byte(peerIdentity))
 	assert.Equal(t, digest, []byte(pkid), "PKID must be the SHA2-256 of peerIdentity")
 
-	//  The PKI-ID is calculated by concatenating the MspId with IdBytes. Ensure that additional fields haven't been introduced in the code
+	//  The PKI-ID is calculated by concatenating the MspId with IdBytes. Ensure that additional fields haven't been introduced in the code.
+	//  The third field is the stapled OCSP response, which is deliberately left out of the PKI-ID,
+	//  since it is refreshed over time while the identity of the peer stays the same.
 	v := reflect.Indirect(reflect.ValueOf(id))
-	assert.Equal(t, 2, v.NumField())
+	assert.Equal(t, 3, v.NumField())
+	assert.Equal(t, "OcspResponse", v.Type().Field(2).Name)
 }
 
 func TestPKIidOfNil(t *testing.T) {
@@ -225,6 +228,42 @@ func TestVerifyBlock(t *testing.T) {
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, nil))
 }
 
+func TestVerifyHeader(t *testing.T) {
+	aliceSigner := &mockscrypto.LocalSigner{Identity: []byte("Alice")}
+	policyManagerGetter := &mocks.ChannelPolicyManagerGetterWithManager{
+		map[string]policies.Manager{
+			"A": &mocks.ChannelPolicyManager{&mocks.Policy{&mocks.IdentityDeserializer{[]byte("Bob"), []byte("msg2"), mock.Mock{}}}},
+			"C": &mocks.ChannelPolicyManager{&mocks.Policy{&mocks.IdentityDeserializer{[]byte("Alice"), []byte("msg1"), mock.Mock{}}}},
+		},
+	}
+	msgCryptoService := NewMCS(
+		policyManagerGetter,
+		aliceSigner,
+		&mocks.DeserializersManager{
+			LocalDeserializer: &mocks.IdentityDeserializer{[]byte("Alice"), []byte("msg1"), mock.Mock{}},
+		},
+	)
+
+	// Only the header and the metadata of the block are verified
+	blockRaw, msg := mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	block, err := utils.GetBlockFromBlockBytes(blockRaw)
+	assert.NoError(t, err)
+	assert.NoError(t, msgCryptoService.VerifyHeader("C", block.Header, block.Metadata))
+
+	// A header that isn't signed as the policy of the channel requires is rejected
+	assert.Error(t, msgCryptoService.VerifyHeader("A", block.Header, block.Metadata))
+
+	// A header that was tampered with is rejected
+	tampered := *block.Header
+	tampered.Number = 1000
+	assert.Error(t, msgCryptoService.VerifyHeader("C", &tampered, block.Metadata))
+
+	// A header without signatures is rejected
+	assert.Error(t, msgCryptoService.VerifyHeader("C", block.Header, nil))
+	assert.Error(t, msgCryptoService.VerifyHeader("C", nil, block.Metadata))
+}
+
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner crypto.LocalSigner, dataHash []byte) ([]byte, []byte) {
 	block := common.NewBlock(seqNum, nil)
 
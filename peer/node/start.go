@@ -7,12 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/sinochem-tech/fabric/common/deliver"
 	"github.com/sinochem-tech/fabric/common/flogging"
 	"github.com/sinochem-tech/fabric/common/localmsp"
+	"github.com/sinochem-tech/fabric/common/metrics"
 	"github.com/sinochem-tech/fabric/common/policies"
 	"github.com/sinochem-tech/fabric/common/viperutil"
 	"github.com/sinochem-tech/fabric/core/aclmgmt"
@@ -33,6 +36,8 @@ import (
 	"github.com/sinochem-tech/fabric/core/comm"
 	"github.com/sinochem-tech/fabric/core/committer/txvalidator"
 	"github.com/sinochem-tech/fabric/core/common/ccprovider"
+	msgvalidation "github.com/sinochem-tech/fabric/core/common/validation"
+	coreconfig "github.com/sinochem-tech/fabric/core/config"
 	"github.com/sinochem-tech/fabric/core/container"
 	"github.com/sinochem-tech/fabric/core/container/dockercontroller"
 	"github.com/sinochem-tech/fabric/core/container/inproccontroller"
@@ -45,6 +50,7 @@ import (
 	"github.com/sinochem-tech/fabric/core/ledger/cceventmgmt"
 	"github.com/sinochem-tech/fabric/core/ledger/ledgermgmt"
 	"github.com/sinochem-tech/fabric/core/peer"
+	"github.com/sinochem-tech/fabric/core/rotation"
 	"github.com/sinochem-tech/fabric/core/scc"
 	"github.com/sinochem-tech/fabric/discovery"
 	"github.com/sinochem-tech/fabric/discovery/endorsement"
@@ -63,6 +69,7 @@ import (
 	"github.com/sinochem-tech/fabric/peer/version"
 	cb "github.com/sinochem-tech/fabric/protos/common"
 	discprotos "github.com/sinochem-tech/fabric/protos/discovery"
+	gossipproto "github.com/sinochem-tech/fabric/protos/gossip"
 	pb "github.com/sinochem-tech/fabric/protos/peer"
 	"github.com/sinochem-tech/fabric/protos/transientstore"
 	"github.com/sinochem-tech/fabric/protos/utils"
@@ -77,6 +84,8 @@ const (
 	chaincodeAddrKey       = "peer.chaincodeAddress"
 	chaincodeListenAddrKey = "peer.chaincodeListenAddress"
 	defaultChaincodePort   = 7052
+
+	defaultLoadHintsPublishInterval = time.Second * 10
 )
 
 var chaincodeDevMode bool
@@ -149,8 +158,23 @@ func serve(args []string) error {
 		aclmgmt.ResourceGetter(peer.GetStableChannelConfig),
 	)
 
+	// the query cache is notified by the ledger about commits, hence
+	// it needs to be created before the ledger is initialized
+	queryCache := endorser.NewQueryCache(endorser.QueryCacheConfig{
+		Enabled:             viper.GetBool("peer.queryCache.enabled"),
+		Chaincodes:          viper.GetStringSlice("peer.queryCache.chaincodes"),
+		MaxCacheSize:        viper.GetInt("peer.queryCache.maxCacheSize"),
+		PurgeRetentionRatio: viper.GetFloat64("peer.queryCache.purgeRetentionRatio"),
+	})
+
+	// the metrics are reported by components created along with
+	// the channels, hence they need to be initialized beforehand
+	if err := initializeMetrics(); err != nil {
+		return fmt.Errorf("Failed initializing metrics: %s", err)
+	}
+
 	//initialize resource management exit
-	ledgermgmt.Initialize(peer.ConfigTxProcessors)
+	ledgermgmt.Initialize(peer.ConfigTxProcessors, queryCache)
 
 	// Parameter overrides must be processed before any parameters are
 	// cached. Failures to cache cause the server to terminate immediately.
@@ -211,6 +235,9 @@ func serve(args []string) error {
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	policyCheckerProvider := func(resourceName string) deliver.PolicyCheckerFunc {
 		return func(env *cb.Envelope, channelID string) error {
+			if err := msgvalidation.CheckCreatorRevocationOnline(env, channelID); err != nil {
+				return err
+			}
 			return aclProvider.CheckACL(resourceName, channelID, env)
 		}
 	}
@@ -259,7 +286,7 @@ func serve(args []string) error {
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
 	endorserSupport := &endorser.SupportImpl{
-		SignerSupport:    signingIdentity,
+		SignerSupport:    localmsp.NewSignerSupport(),
 		Peer:             peer.Default,
 		PeerSupport:      peer.DefaultSupport,
 		ChaincodeSupport: chaincodeSupport,
@@ -279,6 +306,11 @@ func serve(args []string) error {
 	})
 	endorserSupport.PluginEndorser = pluginEndorser
 	serverEndorser := endorser.NewEndorserServer(privDataDist, endorserSupport)
+	serverEndorser.QueryCache = queryCache
+	serverEndorser.PvtRWSetAssembler = endorser.NewPvtRWSetAssembler(peer.GetImplicitCollectionDisseminationPolicy())
+	if viper.GetBool("peer.discovery.enabled") && viper.GetBool("peer.discovery.loadHints.enabled") {
+		serverEndorser.LoadTracker = endorser.NewLoadTracker()
+	}
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
@@ -339,6 +371,12 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	if viper.GetBool("peer.credentialReload.enabled") {
+		watcher := newCredentialsWatcher(serverConfig, certs, peerServer, ehubGrpcServer)
+		watcher.Start()
+		defer watcher.Stop()
+	}
+
 	//initialize system chaincodes
 
 	//deploy system chaincodes
@@ -374,6 +412,13 @@ func serve(args []string) error {
 		registerDiscoveryService(peerServer, policyMgr, lifecycle)
 	}
 
+	if serverEndorser.LoadTracker != nil {
+		// Stop publishing the load hints before the gossip service is stopped
+		stopLoadHints := make(chan struct{})
+		defer close(stopLoadHints)
+		go publishLoadHints(serverEndorser.LoadTracker, stopLoadHints)
+	}
+
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]",
 		peerEndpoint.Id, viper.GetString("peer.networkId"), peerEndpoint.Address)
 
@@ -422,6 +467,22 @@ func serve(args []string) error {
 	return <-serve
 }
 
+// initializeMetrics initializes the metrics and starts reporting them, if they are enabled
+func initializeMetrics() error {
+	if !viper.GetBool("metrics.enabled") {
+		return nil
+	}
+	if err := metrics.Init(metrics.NewOpts()); err != nil {
+		return err
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Error starting metrics server: %s", err)
+		}
+	}()
+	return nil
+}
+
 func localPolicy(policyObject proto.Message) policies.Policy {
 	localMSP := mgmt.GetLocalMSP()
 	pp := cauthdsl.NewPolicyProvider(localMSP)
@@ -455,6 +516,21 @@ func registerDiscoveryService(peerServer *comm.GRPCServer, polMgr policies.Chann
 	discprotos.RegisterDiscoveryServer(peerServer.Server(), svc)
 }
 
+// publishLoadHints periodically publishes the load hints of the endorser
+// to the other peers of all channels the peer is in, until the given channel is closed
+func publishLoadHints(lt *endorser.LoadTracker, stop <-chan struct{}) {
+	interval := viper.GetDuration("peer.discovery.loadHints.publishInterval")
+	if interval <= 0 {
+		interval = defaultLoadHintsPublishInterval
+	}
+	logger.Infof("Publishing endorser load hints every %v", interval)
+	lt.PublishLoadHints(interval, func(hints *gossipproto.LoadHints) {
+		for _, ch := range peer.GetChannelsInfo() {
+			service.GetGossipService().UpdateLoadHints(hints, gossipcommon.ChainID(ch.ChannelId))
+		}
+	}, stop)
+}
+
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
 func createChaincodeServer(ca tlsgen.CA, peerHostname string) (srv *comm.GRPCServer, ccEndpoint string, err error) {
 	// before potentially setting chaincodeListenAddress, compute chaincode endpoint at first
@@ -627,6 +703,9 @@ func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca
 		}),
 		sccp,
 	)
+	chaincodeSupport.TransferVerifier = &chaincode.TransferProofVerifier{
+		PolicyManagerGetter: peer.NewChannelPolicyManagerGetter(),
+	}
 	ccp := chaincode.NewProvider(chaincodeSupport)
 
 	ccSrv := pb.ChaincodeSupportServer(chaincodeSupport)
@@ -644,6 +723,53 @@ func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca
 	return chaincodeSupport, ccp, sccp
 }
 
+// newCredentialsWatcher creates a watcher that reloads the signing identity of the local MSP
+// and the TLS certificates of the peer once their files change
+func newCredentialsWatcher(serverConfig comm.ServerConfig, gossipCerts *gossipcommon.TLSCertificates, servers ...*comm.GRPCServer) *rotation.Watcher {
+	mspDir := coreconfig.GetPath("peer.mspConfigPath")
+	keystoreDir := viper.GetString("peer.BCCSP.SW.FileKeyStore.KeyStore")
+	if keystoreDir == "" {
+		keystoreDir = filepath.Join(mspDir, "keystore")
+	}
+	credentials := []rotation.Credential{
+		rotation.SigningIdentityCredential(mspDir, keystoreDir, func(serializedIdentity []byte) error {
+			return service.GetGossipService().UpdateIdentity(serializedIdentity)
+		}),
+	}
+
+	if serverConfig.SecOpts.UseTLS {
+		rootCAs := serverConfig.SecOpts.ServerRootCAs
+		setClientCert := func(cert tls.Certificate) {
+			comm.GetCredentialSupport().SetClientCertificate(cert)
+			if gossipCerts != nil {
+				gossipCerts.TLSClientCert.Store(&cert)
+			}
+		}
+		// Unless the peer has a dedicated client certificate, it uses its server certificate as a client
+		separateClientCert := viper.GetString("peer.tls.clientCert.file") != ""
+		credentials = append(credentials, rotation.TLSCredential("TLS server certificate",
+			coreconfig.GetPath("peer.tls.cert.file"), coreconfig.GetPath("peer.tls.key.file"), rootCAs,
+			servers[0].ServerCertificate, func(cert tls.Certificate) {
+				for _, server := range servers {
+					server.SetServerCertificate(cert)
+				}
+				if gossipCerts != nil {
+					gossipCerts.TLSServerCert.Store(&cert)
+				}
+				if !separateClientCert {
+					setClientCert(cert)
+				}
+			}))
+		if separateClientCert {
+			credentials = append(credentials, rotation.TLSCredential("TLS client certificate",
+				coreconfig.GetPath("peer.tls.clientCert.file"), coreconfig.GetPath("peer.tls.clientKey.file"), rootCAs,
+				comm.GetCredentialSupport().GetClientCertificate, setClientCert))
+		}
+	}
+
+	return rotation.NewWatcher(viper.GetDuration("peer.credentialReload.interval"), credentials...)
+}
+
 func createEventHubServer(serverConfig comm.ServerConfig) (*comm.GRPCServer, error) {
 	var lis net.Listener
 	var err error
@@ -18,9 +18,13 @@ package node
 
 import (
 	"bytes"
+	"net"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/sinochem-tech/fabric/common/metrics"
 	"github.com/sinochem-tech/fabric/common/viperutil"
 	"github.com/sinochem-tech/fabric/core/handlers/library"
 	"github.com/sinochem-tech/fabric/msp/mgmt/testtools"
@@ -58,6 +62,38 @@ func TestStartCmd(t *testing.T) {
 	g.Eventually(grpcProbe("localhost:6051")).Should(BeTrue())
 }
 
+func TestInitializeMetrics(t *testing.T) {
+	defer viper.Reset()
+
+	// The metrics aren't initialized unless they are enabled
+	assert.NoError(t, initializeMetrics())
+	assert.Nil(t, metrics.RootScope)
+
+	// Once they are, the root scope reports them
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+	viper.Set("metrics.enabled", true)
+	viper.Set("metrics.reporter", "statsd")
+	viper.Set("metrics.interval", "100ms")
+	viper.Set("metrics.statsdReporter.address", conn.LocalAddr().String())
+	viper.Set("metrics.statsdReporter.flushInterval", "100ms")
+	viper.Set("metrics.statsdReporter.flushBytes", 512)
+	assert.NoError(t, initializeMetrics())
+	defer metrics.Shutdown()
+	assert.NotNil(t, metrics.RootScope)
+
+	metrics.RootScope.SubScope("gossip_privdata").Tagged(map[string]string{"channel": "testchannel"}).Gauge("missing_pvtdata").Update(3)
+	buf := make([]byte, 2048)
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Second)))
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if !assert.NoError(t, err) || strings.Contains(string(buf[:n]), "hyperledger_fabric.gossip_privdata.missing_pvtdata.channel-testchannel:3|g") {
+			return
+		}
+	}
+}
+
 func TestAdminHasSeparateListener(t *testing.T) {
 	assert.False(t, adminHasSeparateListener("0.0.0.0:7051", ""))
 
@@ -201,6 +201,10 @@ type StaticCollectionConfig struct {
 	// For instance if the value is set to 10, a key last modified by block number 100
 	// will be purged at block number 111. A zero value is treated same as MaxUint64
 	BlockToLive uint64 `protobuf:"varint,5,opt,name=block_to_live,json=blockToLive" json:"block_to_live,omitempty"`
+	// An optional endorsement policy for transactions writing to the
+	// collection. When set, it overrides the chaincode-level endorsement
+	// policy for writes to this collection.
+	EndorsementPolicy *CollectionPolicyConfig `protobuf:"bytes,6,opt,name=endorsement_policy,json=endorsementPolicy" json:"endorsement_policy,omitempty"`
 }
 
 func (m *StaticCollectionConfig) Reset()                    { *m = StaticCollectionConfig{} }
@@ -243,6 +247,13 @@ func (m *StaticCollectionConfig) GetBlockToLive() uint64 {
 	return 0
 }
 
+func (m *StaticCollectionConfig) GetEndorsementPolicy() *CollectionPolicyConfig {
+	if m != nil {
+		return m.EndorsementPolicy
+	}
+	return nil
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -388,34 +399,35 @@ func init() {
 func init() { proto.RegisterFile("common/collection.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 453 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x41, 0x6b, 0xdb, 0x40,
-	0x10, 0x85, 0xa3, 0xc6, 0x76, 0xd0, 0x98, 0x52, 0x77, 0x43, 0x1d, 0x51, 0x4a, 0x6a, 0x44, 0x0f,
-	0x86, 0x16, 0xa9, 0xa4, 0xff, 0x20, 0xa6, 0x90, 0x52, 0x43, 0x8d, 0xd2, 0x53, 0x2e, 0x62, 0xb5,
-	0x9a, 0xc8, 0x4b, 0x24, 0xad, 0xb2, 0xbb, 0x32, 0xf6, 0xb1, 0xff, 0xbb, 0x87, 0xe0, 0x5d, 0xc9,
-	0x52, 0x8c, 0x6f, 0x9e, 0x79, 0xdf, 0x3c, 0xcf, 0x3c, 0x2d, 0x5c, 0x31, 0x51, 0x14, 0xa2, 0x0c,
-	0x99, 0xc8, 0x73, 0x64, 0x9a, 0x8b, 0x32, 0xa8, 0xa4, 0xd0, 0x82, 0x8c, 0xac, 0xf0, 0xf1, 0x43,
-	0x03, 0x54, 0x22, 0xe7, 0x8c, 0xa3, 0xb2, 0xb2, 0xff, 0x1b, 0xae, 0x16, 0x87, 0x91, 0x85, 0x28,
-	0x1f, 0x79, 0xb6, 0xa2, 0xec, 0x89, 0x66, 0x48, 0xbe, 0xc3, 0x88, 0x99, 0x86, 0xe7, 0xcc, 0xce,
-	0xe7, 0xe3, 0x1b, 0x2f, 0xb0, 0x16, 0xc1, 0xf1, 0x40, 0xd4, 0x70, 0xfe, 0x0e, 0x26, 0xc7, 0x1a,
-	0x79, 0x00, 0x4f, 0x69, 0xaa, 0x39, 0x8b, 0xbb, 0xd5, 0xe2, 0x83, 0xaf, 0x33, 0x1f, 0xdf, 0x5c,
-	0xb7, 0xbe, 0xf7, 0x86, 0x3b, 0x76, 0xb8, 0x3b, 0x8b, 0xa6, 0xea, 0xa4, 0x72, 0xeb, 0xc2, 0x45,
-	0x45, 0x77, 0xb9, 0xa0, 0xa9, 0xff, 0xdf, 0x81, 0xe9, 0xe9, 0x79, 0x42, 0x60, 0x50, 0xd2, 0x02,
-	0xcd, 0xbf, 0xb9, 0x91, 0xf9, 0x4d, 0x96, 0x40, 0x0a, 0x2c, 0x12, 0x94, 0xb1, 0x90, 0x99, 0x8a,
-	0x4d, 0x28, 0x3b, 0xef, 0xcd, 0xeb, 0x7d, 0x3a, 0xa7, 0x95, 0xd1, 0x9b, 0x6b, 0x27, 0x76, 0xf2,
-	0x8f, 0xcc, 0x94, 0xed, 0x93, 0x00, 0x2e, 0x25, 0x3e, 0xd7, 0x5c, 0x62, 0x1a, 0x57, 0x88, 0x32,
-	0x66, 0xa2, 0x2e, 0xb5, 0x77, 0x3e, 0x73, 0xe6, 0xc3, 0xe8, 0x7d, 0x2b, 0xad, 0x10, 0xe5, 0x62,
-	0x2f, 0x90, 0x6f, 0x40, 0x0a, 0xba, 0xe5, 0x45, 0x5d, 0xf4, 0xf1, 0x81, 0xc1, 0x27, 0x8d, 0xd2,
-	0xd1, 0x3e, 0xbc, 0x4d, 0x72, 0xc1, 0x9e, 0x62, 0x2d, 0xe2, 0x9c, 0x6f, 0xd0, 0x1b, 0xce, 0x9c,
-	0xf9, 0x20, 0x1a, 0x9b, 0xe6, 0x5f, 0xb1, 0xe4, 0x1b, 0xf4, 0x9f, 0x61, 0x7a, 0x7a, 0x5b, 0xb2,
-	0x84, 0x89, 0xe2, 0x59, 0x49, 0x75, 0x2d, 0xb1, 0xbd, 0xd3, 0xe6, 0xfe, 0xf9, 0x90, 0x7b, 0xab,
-	0xdb, 0xc1, 0x9f, 0xe5, 0x06, 0x73, 0x51, 0xe1, 0xdd, 0x59, 0xf4, 0x4e, 0xbd, 0x96, 0xfa, 0x89,
-	0xff, 0x73, 0x80, 0xf4, 0xb2, 0x96, 0x5c, 0xa3, 0xe4, 0x94, 0x78, 0x70, 0xc1, 0xd6, 0xb4, 0x2c,
-	0x31, 0x6f, 0x02, 0x6f, 0x4b, 0x72, 0x09, 0x43, 0xbd, 0x8d, 0x79, 0x6a, 0x62, 0x76, 0xa3, 0x81,
-	0xde, 0xfe, 0x4a, 0xc9, 0x35, 0x40, 0xf7, 0x2e, 0x4c, 0x62, 0x6e, 0xd4, 0xeb, 0x90, 0x4f, 0xe0,
-	0xee, 0x3f, 0x98, 0xaa, 0x28, 0x43, 0x93, 0x90, 0x1b, 0x75, 0x8d, 0xdb, 0x7b, 0xf8, 0x22, 0x64,
-	0x16, 0xac, 0x77, 0x15, 0xca, 0x1c, 0xd3, 0x0c, 0x65, 0xf0, 0x48, 0x13, 0xc9, 0x99, 0x7d, 0xdd,
-	0xaa, 0xb9, 0xf0, 0xe1, 0x6b, 0xc6, 0xf5, 0xba, 0x4e, 0xf6, 0x65, 0xd8, 0x83, 0x43, 0x0b, 0x87,
-	0x16, 0x0e, 0x2d, 0x9c, 0x8c, 0x4c, 0xf9, 0xe3, 0x25, 0x00, 0x00, 0xff, 0xff, 0x04, 0x6f, 0x60,
-	0x95, 0x53, 0x03, 0x00, 0x00,
+	// 473 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xd1, 0x6e, 0xda, 0x30,
+	0x14, 0x86, 0x4b, 0x0b, 0x54, 0x39, 0x68, 0x1a, 0x75, 0x35, 0x1a, 0x4d, 0x53, 0x87, 0xd0, 0x2e,
+	0x90, 0x36, 0x85, 0xa9, 0x7b, 0x83, 0xa2, 0x49, 0x9d, 0xc6, 0x34, 0x94, 0xee, 0xaa, 0x37, 0x91,
+	0xe3, 0x9c, 0x06, 0xab, 0x89, 0x9d, 0xda, 0x0e, 0x82, 0xcb, 0x3d, 0xdf, 0x5e, 0x6a, 0xc2, 0x4e,
+	0x48, 0x8a, 0xb8, 0xe8, 0x1d, 0xe7, 0xfc, 0xdf, 0x7f, 0xec, 0xe3, 0x9f, 0xc0, 0x15, 0x93, 0x79,
+	0x2e, 0xc5, 0x8c, 0xc9, 0x2c, 0x43, 0x66, 0xb8, 0x14, 0x41, 0xa1, 0xa4, 0x91, 0xa4, 0xef, 0x84,
+	0xf7, 0xef, 0x2a, 0xa0, 0x90, 0x19, 0x67, 0x1c, 0xb5, 0x93, 0x27, 0x3f, 0xe1, 0x6a, 0xbe, 0xb7,
+	0xcc, 0xa5, 0x78, 0xe4, 0xe9, 0x92, 0xb2, 0x27, 0x9a, 0x22, 0xf9, 0x0a, 0x7d, 0x66, 0x1b, 0x7e,
+	0x67, 0x7c, 0x36, 0x1d, 0xdc, 0xf8, 0x81, 0x1b, 0x11, 0x1c, 0x1a, 0xc2, 0x8a, 0x9b, 0x6c, 0x61,
+	0x78, 0xa8, 0x91, 0x07, 0xf0, 0xb5, 0xa1, 0x86, 0xb3, 0xa8, 0xb9, 0x5a, 0xb4, 0x9f, 0xdb, 0x99,
+	0x0e, 0x6e, 0xae, 0xeb, 0xb9, 0xf7, 0x96, 0x3b, 0x9c, 0x70, 0x77, 0x12, 0x8e, 0xf4, 0x51, 0xe5,
+	0xd6, 0x83, 0xf3, 0x82, 0x6e, 0x33, 0x49, 0x93, 0xc9, 0xbf, 0x53, 0x18, 0x1d, 0xf7, 0x13, 0x02,
+	0x5d, 0x41, 0x73, 0xb4, 0xa7, 0x79, 0xa1, 0xfd, 0x4d, 0x16, 0x40, 0x72, 0xcc, 0x63, 0x54, 0x91,
+	0x54, 0xa9, 0x8e, 0xec, 0xa3, 0x6c, 0xfd, 0xd3, 0x97, 0xf7, 0x69, 0x26, 0x2d, 0xad, 0x5e, 0x6d,
+	0x3b, 0x74, 0xce, 0xdf, 0x2a, 0xd5, 0xae, 0x4f, 0x02, 0xb8, 0x54, 0xf8, 0x5c, 0x72, 0x85, 0x49,
+	0x54, 0x20, 0xaa, 0x88, 0xc9, 0x52, 0x18, 0xff, 0x6c, 0xdc, 0x99, 0xf6, 0xc2, 0x8b, 0x5a, 0x5a,
+	0x22, 0xaa, 0xf9, 0x4e, 0x20, 0x5f, 0x80, 0xe4, 0x74, 0xc3, 0xf3, 0x32, 0x6f, 0xe3, 0x5d, 0x8b,
+	0x0f, 0x2b, 0xa5, 0xa1, 0x27, 0xf0, 0x26, 0xce, 0x24, 0x7b, 0x8a, 0x8c, 0x8c, 0x32, 0xbe, 0x46,
+	0xbf, 0x37, 0xee, 0x4c, 0xbb, 0xe1, 0xc0, 0x36, 0xff, 0xc8, 0x05, 0x5f, 0x23, 0xf9, 0x05, 0x04,
+	0x45, 0x22, 0x95, 0xc6, 0x1c, 0x85, 0xa9, 0xf7, 0xe9, 0xbf, 0x6a, 0x9f, 0x8b, 0x96, 0xd3, 0x09,
+	0x93, 0x67, 0x18, 0x1d, 0x87, 0xc9, 0x02, 0x86, 0x9a, 0xa7, 0x82, 0x9a, 0x52, 0x61, 0x7d, 0x8c,
+	0x8b, 0xf1, 0xe3, 0x3e, 0xc6, 0x5a, 0x77, 0xc6, 0xef, 0x62, 0x8d, 0x99, 0x2c, 0xf0, 0xee, 0x24,
+	0x7c, 0xab, 0x5f, 0x4a, 0xed, 0x00, 0xff, 0x76, 0x80, 0xb4, 0xa2, 0x53, 0xdc, 0xa0, 0xe2, 0x94,
+	0xf8, 0x70, 0xce, 0x56, 0x54, 0x08, 0xcc, 0xaa, 0xfc, 0xea, 0x92, 0x5c, 0x42, 0xcf, 0x6c, 0x22,
+	0x9e, 0xd8, 0xd4, 0xbc, 0xb0, 0x6b, 0x36, 0x3f, 0x12, 0x72, 0x0d, 0xd0, 0xfc, 0xcd, 0x6c, 0x00,
+	0x5e, 0xd8, 0xea, 0x90, 0x0f, 0xe0, 0xed, 0xf2, 0xd7, 0x05, 0x65, 0x68, 0x1f, 0xdc, 0x0b, 0x9b,
+	0xc6, 0xed, 0x3d, 0x7c, 0x92, 0x2a, 0x0d, 0x56, 0xdb, 0x02, 0x55, 0x86, 0x49, 0x8a, 0x2a, 0x78,
+	0xa4, 0xb1, 0xe2, 0xcc, 0x7d, 0x2c, 0xba, 0xda, 0xf0, 0xe1, 0x73, 0xca, 0xcd, 0xaa, 0x8c, 0x77,
+	0xe5, 0xac, 0x05, 0xcf, 0x1c, 0x3c, 0x73, 0xf0, 0xcc, 0xc1, 0x71, 0xdf, 0x96, 0xdf, 0xfe, 0x0f,
+	0x00, 0xd5, 0xcb, 0xf5, 0x97, 0xa2, 0x03, 0x00, 0x00,
 }
@@ -19,6 +19,7 @@ const (
 	PeerMembershipQueryType
 	ChaincodeQueryType
 	LocalMembershipQueryType
+	ChannelInfoQueryType
 )
 
 // GetType returns the type of the request
@@ -35,6 +36,9 @@ func (q *Query) GetType() QueryType {
 	if q.GetLocalPeers() != nil {
 		return LocalMembershipQueryType
 	}
+	if q.GetChannelInfoQuery() != nil {
+		return ChannelInfoQueryType
+	}
 	return InvalidQueryType
 }
 
@@ -66,3 +70,10 @@ func (m *Response) EndorsersAt(i int) (*ChaincodeQueryResult, *Error) {
 	r := m.Results[i]
 	return r.GetCcQueryRes(), r.GetError()
 }
+
+// ChannelInfoAt returns the ChannelInfoResult at a given index in the Response,
+// or an Error if present.
+func (m *Response) ChannelInfoAt(i int) (*ChannelInfoResult, *Error) {
+	r := m.Results[i]
+	return r.GetChannelInfo(), r.GetError()
+}
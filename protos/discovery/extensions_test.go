@@ -53,6 +53,12 @@ func TestGetType(t *testing.T) {
 		},
 	}
 	assert.Equal(t, ChaincodeQueryType, q.GetType())
+	q = &Query{
+		Query: &Query_ChannelInfoQuery{
+			ChannelInfoQuery: &ChannelInfoQuery{},
+		},
+	}
+	assert.Equal(t, ChannelInfoQueryType, q.GetType())
 
 	q = &Query{
 		Query: &invalidQuery{},
@@ -16,6 +16,11 @@ It has these top-level messages:
 	QueryResult
 	ConfigQuery
 	ConfigResult
+	ChannelInfoQuery
+	ChannelInfoResult
+	Capabilities
+	TLSCACerts
+	BatchSettings
 	PeerMembershipQuery
 	PeerMembershipResult
 	ChaincodeQuery
@@ -175,6 +180,7 @@ type Query struct {
 	//	*Query_PeerQuery
 	//	*Query_CcQuery
 	//	*Query_LocalPeers
+	//	*Query_ChannelInfoQuery
 	Query isQuery_Query `protobuf_oneof:"query"`
 }
 
@@ -197,11 +203,15 @@ type Query_CcQuery struct {
 type Query_LocalPeers struct {
 	LocalPeers *LocalPeerQuery `protobuf:"bytes,5,opt,name=local_peers,json=localPeers,oneof"`
 }
+type Query_ChannelInfoQuery struct {
+	ChannelInfoQuery *ChannelInfoQuery `protobuf:"bytes,6,opt,name=channel_info_query,json=channelInfoQuery,oneof"`
+}
 
-func (*Query_ConfigQuery) isQuery_Query() {}
-func (*Query_PeerQuery) isQuery_Query()   {}
-func (*Query_CcQuery) isQuery_Query()     {}
-func (*Query_LocalPeers) isQuery_Query()  {}
+func (*Query_ConfigQuery) isQuery_Query()      {}
+func (*Query_PeerQuery) isQuery_Query()        {}
+func (*Query_CcQuery) isQuery_Query()          {}
+func (*Query_LocalPeers) isQuery_Query()       {}
+func (*Query_ChannelInfoQuery) isQuery_Query() {}
 
 func (m *Query) GetQuery() isQuery_Query {
 	if m != nil {
@@ -245,6 +255,13 @@ func (m *Query) GetLocalPeers() *LocalPeerQuery {
 	return nil
 }
 
+func (m *Query) GetChannelInfoQuery() *ChannelInfoQuery {
+	if x, ok := m.GetQuery().(*Query_ChannelInfoQuery); ok {
+		return x.ChannelInfoQuery
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Query_OneofMarshaler, _Query_OneofUnmarshaler, _Query_OneofSizer, []interface{}{
@@ -252,6 +269,7 @@ func (*Query) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Query_PeerQuery)(nil),
 		(*Query_CcQuery)(nil),
 		(*Query_LocalPeers)(nil),
+		(*Query_ChannelInfoQuery)(nil),
 	}
 }
 
@@ -279,6 +297,11 @@ func _Query_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LocalPeers); err != nil {
 			return err
 		}
+	case *Query_ChannelInfoQuery:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChannelInfoQuery); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Query.Query has unexpected type %T", x)
@@ -321,6 +344,14 @@ func _Query_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Query = &Query_LocalPeers{msg}
 		return true, err
+	case 6: // query.channel_info_query
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelInfoQuery)
+		err := b.DecodeMessage(msg)
+		m.Query = &Query_ChannelInfoQuery{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -350,6 +381,11 @@ func _Query_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Query_ChannelInfoQuery:
+		s := proto.Size(x.ChannelInfoQuery)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -367,6 +403,7 @@ type QueryResult struct {
 	//	*QueryResult_ConfigResult
 	//	*QueryResult_CcQueryRes
 	//	*QueryResult_Members
+	//	*QueryResult_ChannelInfo
 	Result isQueryResult_Result `protobuf_oneof:"result"`
 }
 
@@ -389,11 +426,15 @@ type QueryResult_CcQueryRes struct {
 type QueryResult_Members struct {
 	Members *PeerMembershipResult `protobuf:"bytes,4,opt,name=members,oneof"`
 }
+type QueryResult_ChannelInfo struct {
+	ChannelInfo *ChannelInfoResult `protobuf:"bytes,5,opt,name=channel_info,json=channelInfo,oneof"`
+}
 
 func (*QueryResult_Error) isQueryResult_Result()        {}
 func (*QueryResult_ConfigResult) isQueryResult_Result() {}
 func (*QueryResult_CcQueryRes) isQueryResult_Result()   {}
 func (*QueryResult_Members) isQueryResult_Result()      {}
+func (*QueryResult_ChannelInfo) isQueryResult_Result()  {}
 
 func (m *QueryResult) GetResult() isQueryResult_Result {
 	if m != nil {
@@ -430,6 +471,13 @@ func (m *QueryResult) GetMembers() *PeerMembershipResult {
 	return nil
 }
 
+func (m *QueryResult) GetChannelInfo() *ChannelInfoResult {
+	if x, ok := m.GetResult().(*QueryResult_ChannelInfo); ok {
+		return x.ChannelInfo
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*QueryResult) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _QueryResult_OneofMarshaler, _QueryResult_OneofUnmarshaler, _QueryResult_OneofSizer, []interface{}{
@@ -437,6 +485,7 @@ func (*QueryResult) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) e
 		(*QueryResult_ConfigResult)(nil),
 		(*QueryResult_CcQueryRes)(nil),
 		(*QueryResult_Members)(nil),
+		(*QueryResult_ChannelInfo)(nil),
 	}
 }
 
@@ -464,6 +513,11 @@ func _QueryResult_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Members); err != nil {
 			return err
 		}
+	case *QueryResult_ChannelInfo:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChannelInfo); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("QueryResult.Result has unexpected type %T", x)
@@ -506,6 +560,14 @@ func _QueryResult_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Bu
 		err := b.DecodeMessage(msg)
 		m.Result = &QueryResult_Members{msg}
 		return true, err
+	case 5: // result.channel_info
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelInfoResult)
+		err := b.DecodeMessage(msg)
+		m.Result = &QueryResult_ChannelInfo{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -535,6 +597,11 @@ func _QueryResult_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(4<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *QueryResult_ChannelInfo:
+		s := proto.Size(x.ChannelInfo)
+		n += proto.SizeVarint(5<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -577,6 +644,156 @@ func (m *ConfigResult) GetOrderers() map[string]*Endpoints {
 	return nil
 }
 
+// ChannelInfoQuery requests a ChannelInfoResult
+type ChannelInfoQuery struct {
+}
+
+func (m *ChannelInfoQuery) Reset()                    { *m = ChannelInfoQuery{} }
+func (m *ChannelInfoQuery) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInfoQuery) ProtoMessage()               {}
+func (*ChannelInfoQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type ChannelInfoResult struct {
+	// capabilities is a map from a config level (Channel, Orderer or Application)
+	// to the capabilities required at that level
+	Capabilities map[string]*Capabilities `protobuf:"bytes,1,rep,name=capabilities" json:"capabilities,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// acls is a map from resource names to the names of the policies that govern them
+	Acls map[string]string `protobuf:"bytes,2,rep,name=acls" json:"acls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// anchor_peers is a map from MSP_ID to the anchor peers of an application organization
+	AnchorPeers map[string]*Endpoints `protobuf:"bytes,3,rep,name=anchor_peers,json=anchorPeers" json:"anchor_peers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// orderer_tls_ca_certs is a map from MSP_ID to the TLS CA certificates of an orderer organization
+	OrdererTlsCaCerts map[string]*TLSCACerts `protobuf:"bytes,4,rep,name=orderer_tls_ca_certs,json=ordererTlsCaCerts" json:"orderer_tls_ca_certs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// batch_settings are the settings the orderers cut blocks of the channel by
+	BatchSettings *BatchSettings `protobuf:"bytes,5,opt,name=batch_settings,json=batchSettings" json:"batch_settings,omitempty"`
+}
+
+func (m *ChannelInfoResult) Reset()                    { *m = ChannelInfoResult{} }
+func (m *ChannelInfoResult) String() string            { return proto.CompactTextString(m) }
+func (*ChannelInfoResult) ProtoMessage()               {}
+func (*ChannelInfoResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ChannelInfoResult) GetCapabilities() map[string]*Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *ChannelInfoResult) GetAcls() map[string]string {
+	if m != nil {
+		return m.Acls
+	}
+	return nil
+}
+
+func (m *ChannelInfoResult) GetAnchorPeers() map[string]*Endpoints {
+	if m != nil {
+		return m.AnchorPeers
+	}
+	return nil
+}
+
+func (m *ChannelInfoResult) GetOrdererTlsCaCerts() map[string]*TLSCACerts {
+	if m != nil {
+		return m.OrdererTlsCaCerts
+	}
+	return nil
+}
+
+func (m *ChannelInfoResult) GetBatchSettings() *BatchSettings {
+	if m != nil {
+		return m.BatchSettings
+	}
+	return nil
+}
+
+// Capabilities contains the names of capabilities
+type Capabilities struct {
+	Names []string `protobuf:"bytes,1,rep,name=names" json:"names,omitempty"`
+}
+
+func (m *Capabilities) Reset()                    { *m = Capabilities{} }
+func (m *Capabilities) String() string            { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()               {}
+func (*Capabilities) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Capabilities) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+// TLSCACerts contains the TLS root and intermediate CA certificates of an organization
+type TLSCACerts struct {
+	RootCerts         [][]byte `protobuf:"bytes,1,rep,name=root_certs,json=rootCerts,proto3" json:"root_certs,omitempty"`
+	IntermediateCerts [][]byte `protobuf:"bytes,2,rep,name=intermediate_certs,json=intermediateCerts,proto3" json:"intermediate_certs,omitempty"`
+}
+
+func (m *TLSCACerts) Reset()                    { *m = TLSCACerts{} }
+func (m *TLSCACerts) String() string            { return proto.CompactTextString(m) }
+func (*TLSCACerts) ProtoMessage()               {}
+func (*TLSCACerts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *TLSCACerts) GetRootCerts() [][]byte {
+	if m != nil {
+		return m.RootCerts
+	}
+	return nil
+}
+
+func (m *TLSCACerts) GetIntermediateCerts() [][]byte {
+	if m != nil {
+		return m.IntermediateCerts
+	}
+	return nil
+}
+
+// BatchSettings contains the settings that the orderers cut blocks by
+type BatchSettings struct {
+	// max_message_count is the maximum amount of transactions in a block
+	MaxMessageCount uint32 `protobuf:"varint,1,opt,name=max_message_count,json=maxMessageCount" json:"max_message_count,omitempty"`
+	// absolute_max_bytes is the maximum size of the transactions in a block
+	AbsoluteMaxBytes uint32 `protobuf:"varint,2,opt,name=absolute_max_bytes,json=absoluteMaxBytes" json:"absolute_max_bytes,omitempty"`
+	// preferred_max_bytes is the preferred maximum size of the transactions in a block
+	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes" json:"preferred_max_bytes,omitempty"`
+	// timeout is the time to wait before cutting a block, in the form of a duration string
+	Timeout string `protobuf:"bytes,4,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *BatchSettings) Reset()                    { *m = BatchSettings{} }
+func (m *BatchSettings) String() string            { return proto.CompactTextString(m) }
+func (*BatchSettings) ProtoMessage()               {}
+func (*BatchSettings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *BatchSettings) GetMaxMessageCount() uint32 {
+	if m != nil {
+		return m.MaxMessageCount
+	}
+	return 0
+}
+
+func (m *BatchSettings) GetAbsoluteMaxBytes() uint32 {
+	if m != nil {
+		return m.AbsoluteMaxBytes
+	}
+	return 0
+}
+
+func (m *BatchSettings) GetPreferredMaxBytes() uint32 {
+	if m != nil {
+		return m.PreferredMaxBytes
+	}
+	return 0
+}
+
+func (m *BatchSettings) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
 // PeerMembershipQuery requests PeerMembershipResult
 type PeerMembershipQuery struct {
 }
@@ -584,7 +801,7 @@ type PeerMembershipQuery struct {
 func (m *PeerMembershipQuery) Reset()                    { *m = PeerMembershipQuery{} }
 func (m *PeerMembershipQuery) String() string            { return proto.CompactTextString(m) }
 func (*PeerMembershipQuery) ProtoMessage()               {}
-func (*PeerMembershipQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*PeerMembershipQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 // PeerMembershipResult contains peers mapped by their organizations (MSP_ID)
 type PeerMembershipResult struct {
@@ -594,7 +811,7 @@ type PeerMembershipResult struct {
 func (m *PeerMembershipResult) Reset()                    { *m = PeerMembershipResult{} }
 func (m *PeerMembershipResult) String() string            { return proto.CompactTextString(m) }
 func (*PeerMembershipResult) ProtoMessage()               {}
-func (*PeerMembershipResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*PeerMembershipResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *PeerMembershipResult) GetPeersByOrg() map[string]*Peers {
 	if m != nil {
@@ -614,7 +831,7 @@ type ChaincodeQuery struct {
 func (m *ChaincodeQuery) Reset()                    { *m = ChaincodeQuery{} }
 func (m *ChaincodeQuery) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeQuery) ProtoMessage()               {}
-func (*ChaincodeQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*ChaincodeQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *ChaincodeQuery) GetInterests() []*ChaincodeInterest {
 	if m != nil {
@@ -633,7 +850,7 @@ type ChaincodeInterest struct {
 func (m *ChaincodeInterest) Reset()                    { *m = ChaincodeInterest{} }
 func (m *ChaincodeInterest) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeInterest) ProtoMessage()               {}
-func (*ChaincodeInterest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ChaincodeInterest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *ChaincodeInterest) GetChaincodes() []*ChaincodeCall {
 	if m != nil {
@@ -647,12 +864,18 @@ func (m *ChaincodeInterest) GetChaincodes() []*ChaincodeCall {
 type ChaincodeCall struct {
 	Name            string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	CollectionNames []string `protobuf:"bytes,2,rep,name=collection_names,json=collectionNames" json:"collection_names,omitempty"`
+	// Indicates we wish to ignore peers that cannot read the private data
+	// of the collections, as the chaincode only writes to them
+	NoPrivateReads bool `protobuf:"varint,3,opt,name=no_private_reads,json=noPrivateReads" json:"no_private_reads,omitempty"`
+	// Indicates we wish to ignore the chaincode endorsement policy, as the
+	// chaincode only writes to collections that have an endorsement policy
+	DisregardNamespacePolicy bool `protobuf:"varint,4,opt,name=disregard_namespace_policy,json=disregardNamespacePolicy" json:"disregard_namespace_policy,omitempty"`
 }
 
 func (m *ChaincodeCall) Reset()                    { *m = ChaincodeCall{} }
 func (m *ChaincodeCall) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeCall) ProtoMessage()               {}
-func (*ChaincodeCall) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*ChaincodeCall) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *ChaincodeCall) GetName() string {
 	if m != nil {
@@ -668,6 +891,20 @@ func (m *ChaincodeCall) GetCollectionNames() []string {
 	return nil
 }
 
+func (m *ChaincodeCall) GetNoPrivateReads() bool {
+	if m != nil {
+		return m.NoPrivateReads
+	}
+	return false
+}
+
+func (m *ChaincodeCall) GetDisregardNamespacePolicy() bool {
+	if m != nil {
+		return m.DisregardNamespacePolicy
+	}
+	return false
+}
+
 // ChaincodeQueryResult contains EndorsementDescriptors for
 // chaincodes
 type ChaincodeQueryResult struct {
@@ -677,7 +914,7 @@ type ChaincodeQueryResult struct {
 func (m *ChaincodeQueryResult) Reset()                    { *m = ChaincodeQueryResult{} }
 func (m *ChaincodeQueryResult) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeQueryResult) ProtoMessage()               {}
-func (*ChaincodeQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*ChaincodeQueryResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *ChaincodeQueryResult) GetContent() []*EndorsementDescriptor {
 	if m != nil {
@@ -693,7 +930,7 @@ type LocalPeerQuery struct {
 func (m *LocalPeerQuery) Reset()                    { *m = LocalPeerQuery{} }
 func (m *LocalPeerQuery) String() string            { return proto.CompactTextString(m) }
 func (*LocalPeerQuery) ProtoMessage()               {}
-func (*LocalPeerQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*LocalPeerQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 // EndorsementDescriptor contains information about which peers can be used
 // to request endorsement from, such that the endorsement policy would be fulfilled.
@@ -720,7 +957,7 @@ type EndorsementDescriptor struct {
 func (m *EndorsementDescriptor) Reset()                    { *m = EndorsementDescriptor{} }
 func (m *EndorsementDescriptor) String() string            { return proto.CompactTextString(m) }
 func (*EndorsementDescriptor) ProtoMessage()               {}
-func (*EndorsementDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*EndorsementDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *EndorsementDescriptor) GetChaincode() string {
 	if m != nil {
@@ -754,7 +991,7 @@ type Layout struct {
 func (m *Layout) Reset()                    { *m = Layout{} }
 func (m *Layout) String() string            { return proto.CompactTextString(m) }
 func (*Layout) ProtoMessage()               {}
-func (*Layout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*Layout) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *Layout) GetQuantitiesByGroup() map[string]uint32 {
 	if m != nil {
@@ -771,7 +1008,7 @@ type Peers struct {
 func (m *Peers) Reset()                    { *m = Peers{} }
 func (m *Peers) String() string            { return proto.CompactTextString(m) }
 func (*Peers) ProtoMessage()               {}
-func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*Peers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Peers) GetPeers() []*Peer {
 	if m != nil {
@@ -794,7 +1031,7 @@ type Peer struct {
 func (m *Peer) Reset()                    { *m = Peer{} }
 func (m *Peer) String() string            { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *Peer) GetStateInfo() *gossip.Envelope {
 	if m != nil {
@@ -825,7 +1062,7 @@ type Error struct {
 func (m *Error) Reset()                    { *m = Error{} }
 func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *Error) GetContent() string {
 	if m != nil {
@@ -842,7 +1079,7 @@ type Endpoints struct {
 func (m *Endpoints) Reset()                    { *m = Endpoints{} }
 func (m *Endpoints) String() string            { return proto.CompactTextString(m) }
 func (*Endpoints) ProtoMessage()               {}
-func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Endpoints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *Endpoints) GetEndpoint() []*Endpoint {
 	if m != nil {
@@ -860,7 +1097,7 @@ type Endpoint struct {
 func (m *Endpoint) Reset()                    { *m = Endpoint{} }
 func (m *Endpoint) String() string            { return proto.CompactTextString(m) }
 func (*Endpoint) ProtoMessage()               {}
-func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Endpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *Endpoint) GetHost() string {
 	if m != nil {
@@ -885,6 +1122,11 @@ func init() {
 	proto.RegisterType((*QueryResult)(nil), "discovery.QueryResult")
 	proto.RegisterType((*ConfigQuery)(nil), "discovery.ConfigQuery")
 	proto.RegisterType((*ConfigResult)(nil), "discovery.ConfigResult")
+	proto.RegisterType((*ChannelInfoQuery)(nil), "discovery.ChannelInfoQuery")
+	proto.RegisterType((*ChannelInfoResult)(nil), "discovery.ChannelInfoResult")
+	proto.RegisterType((*Capabilities)(nil), "discovery.Capabilities")
+	proto.RegisterType((*TLSCACerts)(nil), "discovery.TLSCACerts")
+	proto.RegisterType((*BatchSettings)(nil), "discovery.BatchSettings")
 	proto.RegisterType((*PeerMembershipQuery)(nil), "discovery.PeerMembershipQuery")
 	proto.RegisterType((*PeerMembershipResult)(nil), "discovery.PeerMembershipResult")
 	proto.RegisterType((*ChaincodeQuery)(nil), "discovery.ChaincodeQuery")
@@ -978,76 +1220,104 @@ var _Discovery_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("discovery/protocol.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1131 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0x1b, 0x45,
-	0x14, 0x8e, 0x9d, 0xb8, 0xb6, 0x8f, 0xe3, 0x5c, 0x26, 0x6e, 0x30, 0x56, 0x45, 0xd3, 0x95, 0x0a,
-	0xa1, 0x48, 0xeb, 0x2a, 0x08, 0x28, 0x49, 0x04, 0x6a, 0x2e, 0xd4, 0x91, 0x9a, 0x26, 0x99, 0x22,
-	0x84, 0x78, 0xb1, 0x36, 0xeb, 0x13, 0x7b, 0xc5, 0x7a, 0x67, 0x33, 0x33, 0x1b, 0x69, 0x9f, 0x79,
-	0xe7, 0x27, 0xf0, 0xc2, 0x0b, 0xe2, 0x27, 0xf0, 0xeb, 0xd0, 0xce, 0x65, 0xbd, 0x76, 0x36, 0x2a,
-	0x12, 0x6f, 0x33, 0xe7, 0x9c, 0xef, 0x3b, 0x97, 0x39, 0x33, 0x73, 0xa0, 0x3b, 0x0a, 0x84, 0xcf,
-	0xee, 0x90, 0xa7, 0xfd, 0x98, 0x33, 0xc9, 0x7c, 0x16, 0xba, 0x6a, 0x41, 0x9a, 0xb9, 0xa6, 0xd7,
-	0x19, 0x33, 0x21, 0x82, 0xb8, 0x3f, 0x45, 0x21, 0xbc, 0x31, 0x6a, 0x83, 0x5e, 0x67, 0x2a, 0xe2,
-	0xfe, 0x54, 0xc4, 0x43, 0x9f, 0x45, 0x37, 0xc1, 0xb8, 0x28, 0x0d, 0x46, 0x18, 0xc9, 0x40, 0x06,
-	0x28, 0xb4, 0xd4, 0x79, 0x03, 0xed, 0xf7, 0xc1, 0x38, 0xc2, 0x11, 0xc5, 0xdb, 0x04, 0x85, 0x24,
-	0x5d, 0xa8, 0xc7, 0x5e, 0x1a, 0x32, 0x6f, 0xd4, 0xad, 0xec, 0x54, 0x76, 0x57, 0xa9, 0xdd, 0x92,
-	0x27, 0xd0, 0x14, 0xc1, 0x38, 0xf2, 0x64, 0xc2, 0xb1, 0x5b, 0x55, 0xba, 0x99, 0xc0, 0xe1, 0x50,
-	0xb7, 0x14, 0x07, 0xb0, 0xe6, 0x25, 0x72, 0x92, 0x79, 0xf2, 0x3d, 0x19, 0xb0, 0x48, 0x31, 0xb5,
-	0xf6, 0xb6, 0xdc, 0x3c, 0x72, 0xf7, 0x75, 0x22, 0x27, 0x67, 0xd1, 0x0d, 0xa3, 0x0b, 0xa6, 0xe4,
-	0x05, 0xd4, 0x6f, 0x13, 0xe4, 0x01, 0x8a, 0x6e, 0x75, 0x67, 0x79, 0xb7, 0xb5, 0xb7, 0x51, 0x40,
-	0x5d, 0x25, 0xc8, 0x53, 0x6a, 0x0d, 0x9c, 0x43, 0x68, 0x50, 0x14, 0x31, 0x8b, 0x04, 0x92, 0x97,
-	0x50, 0xe7, 0x28, 0x92, 0x50, 0x8a, 0x6e, 0x45, 0xe1, 0xb6, 0xef, 0xe1, 0x94, 0x9a, 0x5a, 0x33,
-	0x67, 0x04, 0x0d, 0x1b, 0x05, 0xf9, 0x0c, 0xd6, 0xfd, 0x30, 0xc0, 0x48, 0x0e, 0x4d, 0x85, 0x52,
-	0x93, 0xfd, 0x9a, 0x16, 0x9f, 0x19, 0x29, 0xe9, 0x43, 0xc7, 0x18, 0xca, 0x50, 0x0c, 0x7d, 0xe4,
-	0x72, 0x38, 0xf1, 0xc4, 0xc4, 0xd4, 0x63, 0x53, 0xeb, 0x7e, 0x0c, 0xc5, 0x31, 0x72, 0x39, 0xf0,
-	0xc4, 0xc4, 0xf9, 0xa3, 0x0a, 0x35, 0xe5, 0x3e, 0xab, 0xac, 0x3f, 0xf1, 0xa2, 0x08, 0x43, 0xc5,
-	0xdd, 0xa4, 0x76, 0x4b, 0x0e, 0x60, 0x55, 0x1f, 0xd5, 0x30, 0xcb, 0x2c, 0x55, 0x64, 0xf3, 0x09,
-	0x1c, 0x2b, 0xb5, 0xe2, 0x19, 0x2c, 0xd1, 0x96, 0x3f, 0xdb, 0x92, 0xef, 0x01, 0x62, 0x44, 0x6e,
-	0xa0, 0xcb, 0x0a, 0xfa, 0x49, 0x01, 0x7a, 0x89, 0xc8, 0xcf, 0x71, 0x7a, 0x8d, 0x5c, 0x4c, 0x82,
-	0xd8, 0x52, 0x34, 0x33, 0x8c, 0x26, 0xf8, 0x1a, 0x1a, 0xbe, 0x6f, 0xe0, 0x2b, 0x0a, 0xfe, 0x71,
-	0xd1, 0xf3, 0xc4, 0x0b, 0x22, 0x9f, 0x8d, 0xd0, 0x22, 0xeb, 0xbe, 0xaf, 0x71, 0x87, 0xd0, 0x0a,
-	0x99, 0xef, 0x85, 0xc3, 0x8c, 0x4a, 0x74, 0x6b, 0xf7, 0xa0, 0x6f, 0x33, 0xed, 0xa5, 0xf5, 0x33,
-	0x58, 0xa2, 0x10, 0x5a, 0x89, 0x38, 0xaa, 0x43, 0x4d, 0xb9, 0x74, 0x7e, 0xab, 0x42, 0xab, 0x70,
-	0x3e, 0x64, 0x17, 0x6a, 0xc8, 0x39, 0xe3, 0xa6, 0x69, 0x8a, 0xc7, 0x7f, 0x9a, 0xc9, 0x07, 0x4b,
-	0x54, 0x1b, 0x90, 0xef, 0xa0, 0x6d, 0xca, 0xa6, 0x8f, 0xd4, 0xd4, 0xed, 0xa3, 0x7b, 0x75, 0xd3,
-	0xcc, 0x83, 0x25, 0x6a, 0xca, 0x6c, 0x3c, 0x1d, 0xc3, 0xaa, 0x4d, 0x3c, 0x63, 0x30, 0xb5, 0x7b,
-	0xfa, 0x60, 0xf2, 0x39, 0x0d, 0x98, 0x12, 0x50, 0x14, 0xe4, 0x00, 0xea, 0x53, 0x5d, 0x5d, 0x53,
-	0xbc, 0xa7, 0x0f, 0xd6, 0x3e, 0xc7, 0x5b, 0xc4, 0x51, 0x03, 0x1e, 0xe9, 0xd0, 0x9d, 0x36, 0xb4,
-	0x0a, 0x67, 0xec, 0xfc, 0x5d, 0x85, 0xd5, 0x62, 0xec, 0xe4, 0x2b, 0x58, 0x99, 0x8a, 0xd8, 0xf6,
-	0xf6, 0xb3, 0x07, 0x52, 0x74, 0xcf, 0x45, 0x2c, 0x4e, 0x23, 0xc9, 0x53, 0xaa, 0xcc, 0xc9, 0x6b,
-	0x68, 0x30, 0x3e, 0x42, 0x9e, 0x85, 0xa7, 0xaf, 0xd3, 0xf3, 0x87, 0xa0, 0x17, 0xc6, 0x4e, 0xc3,
-	0x73, 0x58, 0xef, 0x1c, 0x9a, 0x39, 0x2b, 0xd9, 0x80, 0xe5, 0x5f, 0x31, 0x35, 0xfd, 0x9b, 0x2d,
-	0xc9, 0x0b, 0xa8, 0xdd, 0x79, 0x61, 0x82, 0xa6, 0xf8, 0x1d, 0x77, 0x2a, 0x62, 0xf7, 0x07, 0xef,
-	0x9a, 0x07, 0xfe, 0xf9, 0xfb, 0x4b, 0xe3, 0x41, 0x9b, 0xec, 0x57, 0x5f, 0x55, 0x7a, 0x57, 0xd0,
-	0x9e, 0xf3, 0xf4, 0x5f, 0x28, 0x0b, 0x1d, 0x10, 0x8d, 0x62, 0x16, 0x44, 0x52, 0x14, 0x28, 0x9d,
-	0xc7, 0xb0, 0x55, 0xd2, 0xe4, 0xce, 0x3f, 0x15, 0xe8, 0x94, 0x1d, 0x00, 0xb9, 0x82, 0x55, 0xd5,
-	0xb2, 0xc3, 0xeb, 0x74, 0xc8, 0xf8, 0xd8, 0xd4, 0xb4, 0xff, 0x81, 0x73, 0x73, 0x75, 0xdf, 0xa6,
-	0x17, 0x7c, 0xac, 0x4b, 0xa4, 0xae, 0x9d, 0x16, 0xf4, 0x2e, 0x60, 0x7d, 0x41, 0x5d, 0x92, 0xd7,
-	0xa7, 0xf3, 0x79, 0x6d, 0x2c, 0x38, 0x9c, 0xcb, 0xe9, 0x2d, 0xac, 0xcd, 0x37, 0x1f, 0xd9, 0x87,
-	0x66, 0x10, 0x49, 0xe4, 0x28, 0xf2, 0x27, 0xee, 0x49, 0x59, 0xab, 0x9e, 0x19, 0x23, 0x3a, 0x33,
-	0x77, 0xce, 0x61, 0xf3, 0x9e, 0x9e, 0xbc, 0x02, 0xf0, 0xad, 0xd0, 0x32, 0x76, 0xcb, 0x18, 0x8f,
-	0xbd, 0x30, 0xa4, 0x05, 0x5b, 0xe7, 0x1d, 0xb4, 0xe7, 0x94, 0x84, 0xc0, 0x4a, 0xe4, 0x4d, 0xd1,
-	0x24, 0xab, 0xd6, 0xe4, 0x73, 0xd8, 0xf0, 0x59, 0x18, 0xa2, 0x9f, 0x3d, 0xeb, 0xc3, 0x4c, 0xa4,
-	0x5b, 0xb0, 0x49, 0xd7, 0x67, 0xf2, 0x77, 0x99, 0xd8, 0xa1, 0xd0, 0x29, 0xbb, 0x69, 0x64, 0x1f,
-	0xea, 0x3e, 0x8b, 0x24, 0x46, 0xd2, 0x84, 0xb7, 0x33, 0xdf, 0x0a, 0x8c, 0x0b, 0x9c, 0x62, 0x24,
-	0x4f, 0x50, 0xf8, 0x3c, 0x88, 0x25, 0xe3, 0xd4, 0x02, 0x9c, 0x0d, 0x58, 0x9b, 0x7f, 0x7f, 0x9c,
-	0x3f, 0xab, 0xf0, 0xb8, 0x14, 0x94, 0xfd, 0x6c, 0x79, 0x76, 0x26, 0x87, 0x99, 0x80, 0x8c, 0x61,
-	0x0b, 0x35, 0x4c, 0xb7, 0xcc, 0x98, 0xb3, 0x24, 0xb6, 0xd7, 0xe9, 0x9b, 0x0f, 0x45, 0x64, 0xa5,
-	0x59, 0x6f, 0xbc, 0x51, 0x48, 0xdd, 0x3d, 0x9b, 0xb8, 0x28, 0x27, 0x5f, 0x40, 0x3d, 0xf4, 0x52,
-	0x96, 0xc8, 0xec, 0x29, 0xca, 0xc8, 0x37, 0x8b, 0x8f, 0xa9, 0xd2, 0x50, 0x6b, 0xd1, 0xfb, 0x09,
-	0xb6, 0xcb, 0x99, 0xff, 0x67, 0xe3, 0xfd, 0x55, 0x81, 0x47, 0xda, 0x17, 0xf9, 0x19, 0xb6, 0x6e,
-	0x13, 0xcf, 0xcc, 0x0b, 0x79, 0xe6, 0xe6, 0x28, 0x76, 0xef, 0xc5, 0xe6, 0x5e, 0xe5, 0xc6, 0x26,
-	0x20, 0x93, 0xe9, 0xed, 0xa2, 0xbc, 0x77, 0x02, 0xdb, 0xe5, 0xc6, 0x25, 0xc1, 0x77, 0x8a, 0xc1,
-	0xb7, 0x8b, 0xa1, 0xba, 0x50, 0x53, 0xe1, 0x93, 0xe7, 0x50, 0xd3, 0x7f, 0x90, 0x0e, 0x6d, 0x7d,
-	0x21, 0x3f, 0xaa, 0xb5, 0xce, 0xef, 0x15, 0x58, 0xc9, 0xf6, 0xa4, 0x0f, 0x20, 0xa4, 0x27, 0x71,
-	0x18, 0x44, 0x37, 0x2c, 0xff, 0x67, 0xf4, 0x2c, 0xe5, 0x9e, 0x46, 0x77, 0x18, 0xb2, 0x18, 0x69,
-	0x53, 0xd9, 0xa8, 0xf1, 0xe0, 0x5b, 0x58, 0x9f, 0xe6, 0xcf, 0x81, 0x46, 0x55, 0x1f, 0x40, 0xad,
-	0xcd, 0x0c, 0x15, 0xb4, 0x07, 0x8d, 0x7c, 0xa4, 0x58, 0x56, 0x43, 0x42, 0xbe, 0x77, 0x9e, 0x41,
-	0x4d, 0x7d, 0x69, 0x6a, 0x34, 0xc8, 0x1b, 0x5d, 0x8f, 0x06, 0xa6, 0x8d, 0x0f, 0xa1, 0x99, 0xbf,
-	0x79, 0xa4, 0x0f, 0x0d, 0x34, 0x1b, 0x93, 0xea, 0x56, 0xc9, 0xdb, 0x48, 0x73, 0x23, 0x67, 0x0f,
-	0x1a, 0x56, 0x9a, 0xdd, 0xd1, 0x09, 0x13, 0xd6, 0x81, 0x5a, 0x67, 0xb2, 0x98, 0x71, 0x69, 0x4a,
-	0xab, 0xd6, 0x7b, 0x03, 0x68, 0x9e, 0x58, 0x4e, 0x72, 0x00, 0x0d, 0xbb, 0x21, 0xc5, 0xb7, 0x61,
-	0x6e, 0x66, 0xec, 0x15, 0xa3, 0xb0, 0x03, 0x99, 0xb3, 0x74, 0xf4, 0xf2, 0x17, 0x77, 0x1c, 0xc8,
-	0x49, 0x72, 0xed, 0xfa, 0x6c, 0xda, 0x9f, 0xa4, 0x31, 0xf2, 0x10, 0x47, 0x63, 0xe4, 0xfd, 0x1b,
-	0xf5, 0x3f, 0xe8, 0xc1, 0x56, 0xf4, 0x73, 0xf0, 0xf5, 0x23, 0x25, 0xf9, 0xf2, 0xdf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x59, 0x7e, 0x3b, 0x2c, 0xfd, 0x0a, 0x00, 0x00,
+	// 1572 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xdd, 0x6e, 0x1b, 0x37,
+	0x16, 0xb6, 0x64, 0xcb, 0x92, 0x8e, 0x24, 0x5b, 0xa2, 0x15, 0xaf, 0x56, 0x9b, 0xdd, 0x4d, 0x06,
+	0x9b, 0xac, 0x37, 0xd9, 0x48, 0x81, 0x83, 0x36, 0xa9, 0x6d, 0x34, 0xb0, 0x9d, 0x34, 0x0e, 0x1a,
+	0x27, 0xf6, 0x38, 0x28, 0x82, 0xf4, 0x62, 0x40, 0x8d, 0x68, 0x69, 0xd0, 0xd1, 0x70, 0x4c, 0x52,
+	0x46, 0xf4, 0x12, 0x7d, 0x89, 0xde, 0x14, 0xbd, 0x2d, 0xd0, 0x8b, 0x3e, 0x40, 0x1f, 0xa4, 0xb7,
+	0x7d, 0x89, 0x82, 0x7f, 0xa3, 0x19, 0x49, 0x76, 0x0a, 0xe4, 0x4e, 0x3c, 0xe7, 0xfb, 0x3e, 0x92,
+	0xe7, 0x9c, 0x39, 0x24, 0x05, 0xad, 0x7e, 0xc0, 0x7d, 0x7a, 0x49, 0xd8, 0xa4, 0x1b, 0x33, 0x2a,
+	0xa8, 0x4f, 0xc3, 0x8e, 0xfa, 0x81, 0xca, 0x89, 0xa7, 0xdd, 0x1c, 0x50, 0xce, 0x83, 0xb8, 0x3b,
+	0x22, 0x9c, 0xe3, 0x01, 0xd1, 0x80, 0x76, 0x73, 0xc4, 0xe3, 0xee, 0x88, 0xc7, 0x9e, 0x4f, 0xa3,
+	0xf3, 0x60, 0x90, 0xb6, 0x06, 0x7d, 0x12, 0x89, 0x40, 0x04, 0x84, 0x6b, 0xab, 0xf3, 0x02, 0x6a,
+	0x67, 0xc1, 0x20, 0x22, 0x7d, 0x97, 0x5c, 0x8c, 0x09, 0x17, 0xa8, 0x05, 0xc5, 0x18, 0x4f, 0x42,
+	0x8a, 0xfb, 0xad, 0xdc, 0xad, 0xdc, 0x56, 0xd5, 0xb5, 0x43, 0x74, 0x13, 0xca, 0x3c, 0x18, 0x44,
+	0x58, 0x8c, 0x19, 0x69, 0xe5, 0x95, 0x6f, 0x6a, 0x70, 0x18, 0x14, 0xad, 0xc4, 0x2e, 0xac, 0xe1,
+	0xb1, 0x18, 0xca, 0x99, 0x7c, 0x2c, 0x02, 0x1a, 0x29, 0xa5, 0xca, 0xf6, 0x46, 0x27, 0x59, 0x79,
+	0x67, 0x7f, 0x2c, 0x86, 0x2f, 0xa3, 0x73, 0xea, 0xce, 0x40, 0xd1, 0x3d, 0x28, 0x5e, 0x8c, 0x09,
+	0x0b, 0x08, 0x6f, 0xe5, 0x6f, 0x2d, 0x6f, 0x55, 0xb6, 0xeb, 0x29, 0xd6, 0xe9, 0x98, 0xb0, 0x89,
+	0x6b, 0x01, 0xce, 0x1e, 0x94, 0x5c, 0xc2, 0x63, 0x1a, 0x71, 0x82, 0x1e, 0x42, 0x91, 0x11, 0x3e,
+	0x0e, 0x05, 0x6f, 0xe5, 0x14, 0x6f, 0x73, 0x8e, 0xa7, 0xdc, 0xae, 0x85, 0x39, 0x7d, 0x28, 0xd9,
+	0x55, 0xa0, 0xff, 0xc2, 0xba, 0x1f, 0x06, 0x24, 0x12, 0x9e, 0x89, 0xd0, 0xc4, 0xec, 0x7e, 0x4d,
+	0x9b, 0x5f, 0x1a, 0x2b, 0xea, 0x42, 0xd3, 0x00, 0x45, 0xc8, 0x3d, 0x9f, 0x30, 0xe1, 0x0d, 0x31,
+	0x1f, 0x9a, 0x78, 0x34, 0xb4, 0xef, 0x6d, 0xc8, 0x0f, 0x09, 0x13, 0x47, 0x98, 0x0f, 0x9d, 0x3f,
+	0xf2, 0x50, 0x50, 0xd3, 0xcb, 0xc8, 0xfa, 0x43, 0x1c, 0x45, 0x24, 0x54, 0xda, 0x65, 0xd7, 0x0e,
+	0xd1, 0x2e, 0x54, 0x75, 0xaa, 0x3c, 0xb9, 0xb3, 0x89, 0x12, 0xcb, 0x6e, 0xe0, 0x50, 0xb9, 0x95,
+	0xce, 0xd1, 0x92, 0x5b, 0xf1, 0xa7, 0x43, 0xf4, 0x14, 0x20, 0x26, 0x84, 0x19, 0xea, 0xb2, 0xa2,
+	0xfe, 0x2b, 0x45, 0x3d, 0x21, 0x84, 0x1d, 0x93, 0x51, 0x8f, 0x30, 0x3e, 0x0c, 0x62, 0x2b, 0x51,
+	0x96, 0x1c, 0x2d, 0xf0, 0x39, 0x94, 0x7c, 0xdf, 0xd0, 0x57, 0x14, 0xfd, 0xef, 0xe9, 0x99, 0x87,
+	0x38, 0x88, 0x7c, 0xda, 0x27, 0x96, 0x59, 0xf4, 0x7d, 0xcd, 0xdb, 0x83, 0x4a, 0x48, 0x7d, 0x1c,
+	0x7a, 0x52, 0x8a, 0xb7, 0x0a, 0x73, 0xd4, 0x57, 0xd2, 0x7b, 0x62, 0xe7, 0x39, 0x5a, 0x72, 0x21,
+	0xb4, 0x16, 0x8e, 0xbe, 0x06, 0x64, 0xb6, 0xef, 0x05, 0xd1, 0x39, 0x35, 0xf3, 0xaf, 0x2a, 0x91,
+	0x7f, 0x64, 0xe7, 0x97, 0x20, 0x99, 0x25, 0x2b, 0x53, 0xf7, 0x67, 0x6c, 0x07, 0x45, 0x28, 0x28,
+	0xbe, 0xf3, 0x5b, 0x1e, 0x2a, 0xa9, 0x64, 0xa3, 0x2d, 0x28, 0x10, 0xc6, 0x28, 0x33, 0x15, 0x98,
+	0xae, 0xa5, 0xe7, 0xd2, 0x7e, 0xb4, 0xe4, 0x6a, 0x00, 0xfa, 0x12, 0x6a, 0x26, 0x07, 0xba, 0x3e,
+	0x4c, 0x12, 0xfe, 0x36, 0x97, 0x04, 0xad, 0x7c, 0xb4, 0xe4, 0x56, 0xfd, 0xd4, 0x18, 0x1d, 0x42,
+	0xd5, 0x46, 0x51, 0x2a, 0x98, 0x44, 0xfc, 0xfb, 0xca, 0x48, 0x26, 0x32, 0x60, 0xe2, 0xe9, 0x12,
+	0x8e, 0x76, 0xa1, 0x38, 0xd2, 0xa9, 0x6a, 0xad, 0xcc, 0xf1, 0xb3, 0x89, 0x4c, 0xf8, 0x96, 0x81,
+	0xf6, 0xa1, 0x9a, 0x8e, 0xa8, 0x49, 0xc8, 0xcd, 0xc5, 0xb1, 0x4c, 0xe8, 0x95, 0x54, 0x30, 0x0f,
+	0x4a, 0xb0, 0xaa, 0x77, 0xef, 0xd4, 0xa0, 0x92, 0xaa, 0x39, 0xe7, 0xa7, 0x3c, 0x54, 0xd3, 0xdb,
+	0x47, 0x9f, 0xc1, 0xca, 0x88, 0xc7, 0xf6, 0x5b, 0xbb, 0x7d, 0x45, 0x94, 0x3a, 0xc7, 0x3c, 0xe6,
+	0xcf, 0x23, 0xc1, 0x26, 0xae, 0x82, 0xa3, 0x7d, 0x28, 0x51, 0xd6, 0x27, 0x8c, 0x30, 0xfb, 0x79,
+	0xdf, 0xb9, 0x8a, 0xfa, 0xc6, 0xe0, 0x34, 0x3d, 0xa1, 0xb5, 0x8f, 0xa1, 0x9c, 0xa8, 0xa2, 0x3a,
+	0x2c, 0x7f, 0x47, 0x26, 0xe6, 0x7b, 0x92, 0x3f, 0xd1, 0x3d, 0x28, 0x5c, 0xe2, 0x70, 0x4c, 0x4c,
+	0xfe, 0x9a, 0x9d, 0x11, 0x8f, 0x3b, 0x5f, 0xe1, 0x1e, 0x0b, 0xfc, 0xe3, 0xb3, 0x13, 0x33, 0x83,
+	0x86, 0xec, 0xe4, 0x9f, 0xe4, 0xda, 0xa7, 0x50, 0xcb, 0xcc, 0xf4, 0x57, 0x24, 0x53, 0x45, 0x14,
+	0xf5, 0x63, 0x1a, 0x44, 0x82, 0xa7, 0x24, 0x1d, 0x04, 0xf5, 0xd9, 0xaa, 0x75, 0x7e, 0x2f, 0x40,
+	0x63, 0x2e, 0xfc, 0xc8, 0x85, 0xaa, 0x8f, 0x63, 0xdc, 0x0b, 0x42, 0xd5, 0x93, 0x4d, 0x34, 0x3b,
+	0xd7, 0xa5, 0xac, 0x73, 0x98, 0x22, 0xe8, 0xd8, 0x64, 0x34, 0xd0, 0x0e, 0xac, 0x60, 0x3f, 0xb4,
+	0xe1, 0xbd, 0x7b, 0xad, 0xd6, 0xbe, 0x1f, 0xda, 0xf4, 0x48, 0x0e, 0x3a, 0x81, 0x2a, 0x8e, 0xfc,
+	0x21, 0x65, 0xe6, 0x9b, 0x5e, 0x56, 0x1a, 0x0f, 0xae, 0xd7, 0x50, 0x04, 0xf5, 0x51, 0x6b, 0xa9,
+	0x0a, 0x9e, 0x5a, 0x50, 0x1f, 0x9a, 0x26, 0x73, 0xba, 0x61, 0x62, 0xd5, 0x33, 0x65, 0x79, 0x4b,
+	0xe5, 0x47, 0xd7, 0x2a, 0x9b, 0xbc, 0xc8, 0x6e, 0x8a, 0x65, 0x3f, 0x35, 0xfa, 0x0d, 0x3a, 0x6b,
+	0x47, 0x4f, 0x61, 0xad, 0x87, 0x85, 0x3f, 0xf4, 0x38, 0x11, 0x22, 0x88, 0x06, 0xb6, 0x1b, 0xb5,
+	0x52, 0xfa, 0x07, 0x12, 0x70, 0x66, 0xfc, 0x6e, 0xad, 0x97, 0x1e, 0xb6, 0xdf, 0x41, 0x63, 0x2e,
+	0xae, 0x0b, 0x2a, 0xe1, 0x41, 0xb6, 0x12, 0x32, 0xcd, 0x21, 0x45, 0x4f, 0xd7, 0xd7, 0x63, 0x28,
+	0x27, 0x51, 0x5e, 0xa0, 0xd8, 0x4c, 0x2b, 0x96, 0xd3, 0xc4, 0xb7, 0x50, 0x9f, 0x0d, 0xed, 0xa7,
+	0xd7, 0x66, 0xfb, 0x5b, 0xd8, 0x5c, 0x1c, 0xd6, 0x05, 0xda, 0xf7, 0xb3, 0xda, 0x37, 0x52, 0xda,
+	0x6f, 0x5f, 0x9d, 0x1d, 0xee, 0x2b, 0x72, 0xba, 0xf0, 0xff, 0x03, 0xd5, 0x74, 0x18, 0xe4, 0xe6,
+	0x22, 0x3c, 0x32, 0x75, 0x5d, 0x76, 0xf5, 0xc0, 0x79, 0x0f, 0x30, 0xa5, 0xa3, 0x7f, 0x02, 0x30,
+	0x4a, 0x85, 0x29, 0x0b, 0x09, 0xac, 0xba, 0x65, 0x69, 0xd1, 0xee, 0x07, 0x80, 0x82, 0x48, 0x10,
+	0x36, 0x22, 0xfd, 0x00, 0x0b, 0x62, 0x60, 0x79, 0x05, 0x6b, 0xa4, 0x3d, 0x0a, 0xee, 0xfc, 0x9c,
+	0x83, 0x5a, 0x26, 0xd1, 0xe8, 0x1e, 0x34, 0x46, 0xf8, 0x83, 0x67, 0x6e, 0x48, 0x9e, 0x4f, 0xc7,
+	0x91, 0x50, 0x9b, 0xac, 0xb9, 0xeb, 0x23, 0xfc, 0xe1, 0x58, 0xdb, 0x0f, 0xa5, 0x19, 0xfd, 0x1f,
+	0x10, 0xee, 0x71, 0x1a, 0x8e, 0x05, 0xf1, 0x24, 0xa9, 0x37, 0x11, 0xea, 0x1a, 0x22, 0xc1, 0x75,
+	0xeb, 0x39, 0xc6, 0x1f, 0x0e, 0xa4, 0x1d, 0x75, 0x60, 0x23, 0x66, 0xe4, 0x9c, 0x30, 0x46, 0xfa,
+	0x29, 0xf8, 0xb2, 0x82, 0x37, 0x12, 0x57, 0x82, 0x6f, 0x41, 0x51, 0x04, 0x23, 0x42, 0xc7, 0x42,
+	0x35, 0xf7, 0xb2, 0x6b, 0x87, 0xce, 0x0d, 0xd8, 0x58, 0x70, 0x4a, 0x3b, 0xbf, 0xe6, 0xa0, 0xb9,
+	0xa8, 0xe9, 0xa3, 0x53, 0xa8, 0xaa, 0xef, 0xd3, 0xeb, 0x4d, 0x3c, 0xca, 0x06, 0xa6, 0x6d, 0x74,
+	0x3f, 0x72, 0x56, 0x28, 0x23, 0x3f, 0x98, 0xbc, 0x61, 0x03, 0xfd, 0x21, 0x41, 0x9c, 0x18, 0xda,
+	0x6f, 0x60, 0x7d, 0xc6, 0xbd, 0xa0, 0x20, 0xee, 0x66, 0x0b, 0xa2, 0x3e, 0x33, 0x61, 0xa6, 0x16,
+	0x5e, 0xc1, 0x5a, 0xf6, 0xc0, 0x43, 0x3b, 0x50, 0x56, 0x09, 0x23, 0x3c, 0xb9, 0xa3, 0xdd, 0x5c,
+	0x74, 0x3c, 0xbe, 0x34, 0x20, 0x77, 0x0a, 0x77, 0x8e, 0xa1, 0x31, 0xe7, 0x47, 0x4f, 0x00, 0x7c,
+	0x6b, 0xb4, 0x8a, 0xad, 0x45, 0x8a, 0x87, 0x38, 0x0c, 0xdd, 0x14, 0xd6, 0xf9, 0x25, 0x07, 0xb5,
+	0x8c, 0x17, 0x21, 0x58, 0x91, 0xd5, 0x69, 0x76, 0xab, 0x7e, 0xa3, 0xff, 0x41, 0xdd, 0xa7, 0x61,
+	0x48, 0x7c, 0x79, 0x31, 0xf5, 0x74, 0x25, 0xe7, 0x55, 0x25, 0xaf, 0x4f, 0xed, 0xaf, 0xa5, 0x19,
+	0x6d, 0x41, 0x3d, 0xa2, 0x5e, 0xcc, 0x82, 0x4b, 0x59, 0xa4, 0x8c, 0xe0, 0xbe, 0x2e, 0x84, 0x92,
+	0xbb, 0x16, 0xd1, 0x13, 0x6d, 0x76, 0xa5, 0x15, 0xed, 0x41, 0xbb, 0x1f, 0x70, 0x46, 0x06, 0x98,
+	0xf5, 0xb5, 0x66, 0x8c, 0x7d, 0xe2, 0xc5, 0x34, 0x0c, 0x7c, 0x7d, 0xff, 0x2a, 0xb9, 0xad, 0x04,
+	0xf1, 0xda, 0x02, 0x4e, 0x94, 0xdf, 0x71, 0xa1, 0xb9, 0xe8, 0x1a, 0x81, 0x76, 0xa0, 0xe8, 0xd3,
+	0x48, 0x90, 0x48, 0x98, 0x38, 0xdc, 0xca, 0x36, 0x02, 0xca, 0x38, 0x19, 0x91, 0x48, 0x3c, 0x23,
+	0xdc, 0x67, 0x41, 0x2c, 0x28, 0x73, 0x2d, 0xc1, 0xa9, 0xc3, 0x5a, 0xf6, 0xa6, 0xe6, 0xfc, 0x90,
+	0x87, 0x1b, 0x0b, 0x49, 0xf2, 0x0d, 0x90, 0x84, 0xd1, 0xc4, 0x6a, 0x6a, 0x40, 0x03, 0xd8, 0x20,
+	0x9a, 0xa6, 0x6b, 0x73, 0xc0, 0xe8, 0x38, 0xb6, 0x27, 0xd1, 0xe3, 0x8f, 0xad, 0xc8, 0x5a, 0x65,
+	0x11, 0xbe, 0x50, 0x4c, 0xd3, 0xef, 0xc9, 0xac, 0x1d, 0xdd, 0x87, 0x62, 0x88, 0x27, 0x74, 0x2c,
+	0xec, 0x11, 0xd5, 0x48, 0x5f, 0x3b, 0x95, 0xc7, 0xb5, 0x88, 0xf6, 0x37, 0xb0, 0xb9, 0x58, 0xf9,
+	0x13, 0x2b, 0xfc, 0xc7, 0x1c, 0xac, 0xea, 0xb9, 0xd0, 0x3b, 0xd8, 0xb8, 0x18, 0x63, 0xf3, 0xb2,
+	0x4a, 0x76, 0x6e, 0x52, 0xb1, 0x35, 0xb7, 0xb6, 0xce, 0x69, 0x02, 0x36, 0x0b, 0x32, 0x3b, 0xbd,
+	0x98, 0xb5, 0xb7, 0x9f, 0xc1, 0xe6, 0x62, 0xf0, 0xc7, 0xce, 0x92, 0x5a, 0x7a, 0xa9, 0x1d, 0x28,
+	0xe8, 0xe3, 0xf8, 0x0e, 0x14, 0xf4, 0xc9, 0xae, 0x97, 0xb6, 0x3e, 0xb3, 0x3f, 0x57, 0x7b, 0x9d,
+	0xef, 0x73, 0xb0, 0x22, 0xc7, 0xa8, 0x0b, 0xc0, 0x85, 0x2c, 0x69, 0x75, 0xa3, 0xb4, 0x97, 0x68,
+	0xfd, 0xea, 0xec, 0x3c, 0x8f, 0x2e, 0x49, 0x48, 0x63, 0xe2, 0x96, 0x15, 0x46, 0x3d, 0xa4, 0xbe,
+	0x80, 0xf5, 0x51, 0xd2, 0x77, 0x34, 0x2b, 0x7f, 0x05, 0x6b, 0x6d, 0x0a, 0x54, 0xd4, 0x36, 0x94,
+	0x92, 0xc7, 0xd7, 0xb2, 0x7a, 0x4e, 0x25, 0x63, 0xe7, 0x36, 0x14, 0xd4, 0x7d, 0x5d, 0x3d, 0xa2,
+	0x92, 0x42, 0xd7, 0x8f, 0x28, 0x53, 0xc6, 0x7b, 0x50, 0x4e, 0x4e, 0x3c, 0xd4, 0x85, 0x12, 0x31,
+	0x03, 0xb3, 0xd5, 0x8d, 0x05, 0x27, 0xa3, 0x9b, 0x80, 0x9c, 0x6d, 0x28, 0x59, 0xab, 0xec, 0x05,
+	0x43, 0xca, 0xed, 0x04, 0xea, 0xb7, 0xb4, 0xc5, 0x94, 0x09, 0x13, 0x5a, 0xf5, 0x7b, 0xfb, 0x08,
+	0xca, 0xcf, 0xac, 0x26, 0xda, 0x85, 0x92, 0x1d, 0xa0, 0x74, 0x13, 0xca, 0xbc, 0xae, 0xdb, 0xe9,
+	0x55, 0xd8, 0xa7, 0xab, 0xb3, 0x74, 0xf0, 0xf0, 0x7d, 0x67, 0x10, 0x88, 0xe1, 0xb8, 0xd7, 0xf1,
+	0xe9, 0xa8, 0x3b, 0x9c, 0xc4, 0x84, 0x85, 0xa4, 0x3f, 0x20, 0xac, 0x7b, 0xae, 0x6e, 0xae, 0xfa,
+	0x2f, 0x00, 0xde, 0x4d, 0xc8, 0xbd, 0x55, 0x65, 0x79, 0xf4, 0xe7, 0x00, 0x0e, 0xa2, 0x32, 0x0f,
+	0x27, 0x10, 0x00, 0x00,
 }
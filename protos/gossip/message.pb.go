@@ -14,6 +14,7 @@ It has these top-level messages:
 	GossipMessage
 	StateInfo
 	Properties
+	LoadHints
 	StateInfoSnapshot
 	StateInfoPullRequest
 	ConnEstablish
@@ -90,6 +91,31 @@ func (x PullMsgType) String() string {
 }
 func (PullMsgType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+// CompressionType defines the compression of a RemoteStateResponse
+type CompressionType int32
+
+const (
+	CompressionType_NO_COMPRESSION CompressionType = 0
+	CompressionType_GZIP           CompressionType = 1
+	CompressionType_SNAPPY         CompressionType = 2
+)
+
+var CompressionType_name = map[int32]string{
+	0: "NO_COMPRESSION",
+	1: "GZIP",
+	2: "SNAPPY",
+}
+var CompressionType_value = map[string]int32{
+	"NO_COMPRESSION": 0,
+	"GZIP":           1,
+	"SNAPPY":         2,
+}
+
+func (x CompressionType) String() string {
+	return proto.EnumName(CompressionType_name, int32(x))
+}
+func (CompressionType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
 type GossipMessage_Tag int32
 
 const (
@@ -1064,6 +1090,7 @@ type Properties struct {
 	LedgerHeight uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
 	LeftChannel  bool         `protobuf:"varint,2,opt,name=left_channel,json=leftChannel" json:"left_channel,omitempty"`
 	Chaincodes   []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	LoadHints    *LoadHints   `protobuf:"bytes,4,opt,name=load_hints,json=loadHints" json:"load_hints,omitempty"`
 }
 
 func (m *Properties) Reset()                    { *m = Properties{} }
@@ -1092,6 +1119,43 @@ func (m *Properties) GetChaincodes() []*Chaincode {
 	return nil
 }
 
+func (m *Properties) GetLoadHints() *LoadHints {
+	if m != nil {
+		return m.LoadHints
+	}
+	return nil
+}
+
+// LoadHints are hints about the load of a peer,
+// used by clients to route proposals away from busy peers
+type LoadHints struct {
+	// pending_proposals is the number of proposals
+	// the peer is currently processing
+	PendingProposals uint32 `protobuf:"varint,1,opt,name=pending_proposals,json=pendingProposals" json:"pending_proposals,omitempty"`
+	// endorsement_latency is the recent average time (in milliseconds)
+	// it took the peer to process a proposal
+	EndorsementLatency uint64 `protobuf:"varint,2,opt,name=endorsement_latency,json=endorsementLatency" json:"endorsement_latency,omitempty"`
+}
+
+func (m *LoadHints) Reset()                    { *m = LoadHints{} }
+func (m *LoadHints) String() string            { return proto.CompactTextString(m) }
+func (*LoadHints) ProtoMessage()               {}
+func (*LoadHints) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *LoadHints) GetPendingProposals() uint32 {
+	if m != nil {
+		return m.PendingProposals
+	}
+	return 0
+}
+
+func (m *LoadHints) GetEndorsementLatency() uint64 {
+	if m != nil {
+		return m.EndorsementLatency
+	}
+	return 0
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -1100,7 +1164,7 @@ type StateInfoSnapshot struct {
 func (m *StateInfoSnapshot) Reset()                    { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()               {}
-func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *StateInfoSnapshot) GetElements() []*Envelope {
 	if m != nil {
@@ -1121,7 +1185,7 @@ type StateInfoPullRequest struct {
 func (m *StateInfoPullRequest) Reset()                    { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()               {}
-func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *StateInfoPullRequest) GetChannel_MAC() []byte {
 	if m != nil {
@@ -1142,7 +1206,7 @@ type ConnEstablish struct {
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string            { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()               {}
-func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ConnEstablish) GetPkiId() []byte {
 	if m != nil {
@@ -1177,7 +1241,7 @@ type PeerIdentity struct {
 func (m *PeerIdentity) Reset()                    { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string            { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()               {}
-func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PeerIdentity) GetPkiId() []byte {
 	if m != nil {
@@ -1211,7 +1275,7 @@ type DataRequest struct {
 func (m *DataRequest) Reset()                    { *m = DataRequest{} }
 func (m *DataRequest) String() string            { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()               {}
-func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *DataRequest) GetNonce() uint64 {
 	if m != nil {
@@ -1245,7 +1309,7 @@ type GossipHello struct {
 func (m *GossipHello) Reset()                    { *m = GossipHello{} }
 func (m *GossipHello) String() string            { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()               {}
-func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *GossipHello) GetNonce() uint64 {
 	if m != nil {
@@ -1279,7 +1343,7 @@ type DataUpdate struct {
 func (m *DataUpdate) Reset()                    { *m = DataUpdate{} }
 func (m *DataUpdate) String() string            { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()               {}
-func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *DataUpdate) GetNonce() uint64 {
 	if m != nil {
@@ -1313,7 +1377,7 @@ type DataDigest struct {
 func (m *DataDigest) Reset()                    { *m = DataDigest{} }
 func (m *DataDigest) String() string            { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()               {}
-func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *DataDigest) GetNonce() uint64 {
 	if m != nil {
@@ -1344,7 +1408,7 @@ type DataMessage struct {
 func (m *DataMessage) Reset()                    { *m = DataMessage{} }
 func (m *DataMessage) String() string            { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()               {}
-func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *DataMessage) GetPayload() *Payload {
 	if m != nil {
@@ -1363,7 +1427,7 @@ type PrivateDataMessage struct {
 func (m *PrivateDataMessage) Reset()                    { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string            { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()               {}
-func (*PrivateDataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PrivateDataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *PrivateDataMessage) GetPayload() *PrivatePayload {
 	if m != nil {
@@ -1382,7 +1446,7 @@ type Payload struct {
 func (m *Payload) Reset()                    { *m = Payload{} }
 func (m *Payload) String() string            { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()               {}
-func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *Payload) GetSeqNum() uint64 {
 	if m != nil {
@@ -1420,7 +1484,7 @@ type PrivatePayload struct {
 func (m *PrivatePayload) Reset()                    { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string            { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()               {}
-func (*PrivatePayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*PrivatePayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *PrivatePayload) GetCollectionName() string {
 	if m != nil {
@@ -1475,7 +1539,7 @@ type AliveMessage struct {
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
 func (m *AliveMessage) String() string            { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()               {}
-func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AliveMessage) GetMembership() *Member {
 	if m != nil {
@@ -1504,12 +1568,20 @@ type LeadershipMessage struct {
 	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration" json:"is_declaration,omitempty"`
+	// priority is the configured leadership priority of the peer,
+	// peers with a higher priority are preferred as leaders
+	Priority uint32 `protobuf:"varint,4,opt,name=priority" json:"priority,omitempty"`
+	// unhealthy indicates the peer considers itself unfit to be the leader
+	Unhealthy bool `protobuf:"varint,5,opt,name=unhealthy" json:"unhealthy,omitempty"`
+	// weighted indicates the peer takes the priority, health and ledger
+	// height of peers into account when electing the leader
+	Weighted bool `protobuf:"varint,6,opt,name=weighted" json:"weighted,omitempty"`
 }
 
 func (m *LeadershipMessage) Reset()                    { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string            { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()               {}
-func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *LeadershipMessage) GetPkiId() []byte {
 	if m != nil {
@@ -1532,6 +1604,27 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *LeadershipMessage) GetUnhealthy() bool {
+	if m != nil {
+		return m.Unhealthy
+	}
+	return false
+}
+
+func (m *LeadershipMessage) GetWeighted() bool {
+	if m != nil {
+		return m.Weighted
+	}
+	return false
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum uint64 `protobuf:"varint,1,opt,name=inc_num,json=incNum" json:"inc_num,omitempty"`
@@ -1541,7 +1634,7 @@ type PeerTime struct {
 func (m *PeerTime) Reset()                    { *m = PeerTime{} }
 func (m *PeerTime) String() string            { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()               {}
-func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *PeerTime) GetIncNum() uint64 {
 	if m != nil {
@@ -1567,7 +1660,7 @@ type MembershipRequest struct {
 func (m *MembershipRequest) Reset()                    { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string            { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()               {}
-func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *MembershipRequest) GetSelfInformation() *Envelope {
 	if m != nil {
@@ -1592,7 +1685,7 @@ type MembershipResponse struct {
 func (m *MembershipResponse) Reset()                    { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string            { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()               {}
-func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *MembershipResponse) GetAlive() []*Envelope {
 	if m != nil {
@@ -1619,7 +1712,7 @@ type Member struct {
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *Member) GetEndpoint() string {
 	if m != nil {
@@ -1649,19 +1742,22 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 // RemoteStateRequest is used to ask a set of blocks
 // from a remote peer
 type RemoteStateRequest struct {
 	StartSeqNum uint64 `protobuf:"varint,1,opt,name=start_seq_num,json=startSeqNum" json:"start_seq_num,omitempty"`
 	EndSeqNum   uint64 `protobuf:"varint,2,opt,name=end_seq_num,json=endSeqNum" json:"end_seq_num,omitempty"`
+	// accepted_compressions are the compression types, by order
+	// of preference, the response may be compressed with
+	AcceptedCompressions []CompressionType `protobuf:"varint,3,rep,packed,name=accepted_compressions,json=acceptedCompressions,enum=gossip.CompressionType" json:"accepted_compressions,omitempty"`
 }
 
 func (m *RemoteStateRequest) Reset()                    { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()               {}
-func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *RemoteStateRequest) GetStartSeqNum() uint64 {
 	if m != nil {
@@ -1677,16 +1773,33 @@ func (m *RemoteStateRequest) GetEndSeqNum() uint64 {
 	return 0
 }
 
+func (m *RemoteStateRequest) GetAcceptedCompressions() []CompressionType {
+	if m != nil {
+		return m.AcceptedCompressions
+	}
+	return nil
+}
+
 // RemoteStateResponse is used to send a set of blocks
 // to a remote peer
 type RemoteStateResponse struct {
 	Payloads []*Payload `protobuf:"bytes,1,rep,name=payloads" json:"payloads,omitempty"`
+	// compression is the compression type of compressed_payloads
+	Compression CompressionType `protobuf:"varint,2,opt,name=compression,enum=gossip.CompressionType" json:"compression,omitempty"`
+	// compressed_payloads is the compressed serialization of a
+	// RemoteStateResponse which contains the payloads, and is set
+	// instead of the payloads when the response is compressed
+	CompressedPayloads []byte `protobuf:"bytes,3,opt,name=compressed_payloads,json=compressedPayloads,proto3" json:"compressed_payloads,omitempty"`
+	// max_batch_size is the largest difference between the end and start sequence
+	// numbers of the state requests the responding peer serves in full. Peers that
+	// don't set it are only sent state requests of the default batch size
+	MaxBatchSize uint64 `protobuf:"varint,4,opt,name=max_batch_size,json=maxBatchSize" json:"max_batch_size,omitempty"`
 }
 
 func (m *RemoteStateResponse) Reset()                    { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()               {}
-func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	if m != nil {
@@ -1695,6 +1808,27 @@ func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	return nil
 }
 
+func (m *RemoteStateResponse) GetCompression() CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return CompressionType_NO_COMPRESSION
+}
+
+func (m *RemoteStateResponse) GetCompressedPayloads() []byte {
+	if m != nil {
+		return m.CompressedPayloads
+	}
+	return nil
+}
+
+func (m *RemoteStateResponse) GetMaxBatchSize() uint64 {
+	if m != nil {
+		return m.MaxBatchSize
+	}
+	return 0
+}
+
 // RemotePrivateDataRequest message used to request
 // missing private rwset
 type RemotePvtDataRequest struct {
@@ -1704,7 +1838,7 @@ type RemotePvtDataRequest struct {
 func (m *RemotePvtDataRequest) Reset()                    { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()               {}
-func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *RemotePvtDataRequest) GetDigests() []*PvtDataDigest {
 	if m != nil {
@@ -1720,12 +1854,16 @@ type PvtDataDigest struct {
 	Collection string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
 	BlockSeq   uint64 `protobuf:"varint,4,opt,name=block_seq,json=blockSeq" json:"block_seq,omitempty"`
 	SeqInBlock uint64 `protobuf:"varint,5,opt,name=seq_in_block,json=seqInBlock" json:"seq_in_block,omitempty"`
+	// backfill indicates that the requesting peer became eligible for the collection
+	// only after the private data was committed, hence the responding peer evaluates
+	// the eligibility of the requesting peer against the latest collection config
+	Backfill bool `protobuf:"varint,6,opt,name=backfill" json:"backfill,omitempty"`
 }
 
 func (m *PvtDataDigest) Reset()                    { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()               {}
-func (*PvtDataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*PvtDataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *PvtDataDigest) GetTxId() string {
 	if m != nil {
@@ -1762,6 +1900,13 @@ func (m *PvtDataDigest) GetSeqInBlock() uint64 {
 	return 0
 }
 
+func (m *PvtDataDigest) GetBackfill() bool {
+	if m != nil {
+		return m.Backfill
+	}
+	return false
+}
+
 // RemotePrivateData message to response on private
 // data replication request
 type RemotePvtDataResponse struct {
@@ -1771,7 +1916,7 @@ type RemotePvtDataResponse struct {
 func (m *RemotePvtDataResponse) Reset()                    { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()               {}
-func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *RemotePvtDataResponse) GetElements() []*PvtDataElement {
 	if m != nil {
@@ -1789,7 +1934,7 @@ type PvtDataElement struct {
 func (m *PvtDataElement) Reset()                    { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()               {}
-func (*PvtDataElement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*PvtDataElement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *PvtDataElement) GetDigest() *PvtDataDigest {
 	if m != nil {
@@ -1817,7 +1962,7 @@ type PvtDataPayload struct {
 func (m *PvtDataPayload) Reset()                    { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()               {}
-func (*PvtDataPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*PvtDataPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *PvtDataPayload) GetTxSeqInBlock() uint64 {
 	if m != nil {
@@ -1840,7 +1985,7 @@ type Acknowledgement struct {
 func (m *Acknowledgement) Reset()                    { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string            { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()               {}
-func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *Acknowledgement) GetError() string {
 	if m != nil {
@@ -1860,7 +2005,7 @@ type Chaincode struct {
 func (m *Chaincode) Reset()                    { *m = Chaincode{} }
 func (m *Chaincode) String() string            { return proto.CompactTextString(m) }
 func (*Chaincode) ProtoMessage()               {}
-func (*Chaincode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*Chaincode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 func (m *Chaincode) GetName() string {
 	if m != nil {
@@ -1890,6 +2035,7 @@ func init() {
 	proto.RegisterType((*GossipMessage)(nil), "gossip.GossipMessage")
 	proto.RegisterType((*StateInfo)(nil), "gossip.StateInfo")
 	proto.RegisterType((*Properties)(nil), "gossip.Properties")
+	proto.RegisterType((*LoadHints)(nil), "gossip.LoadHints")
 	proto.RegisterType((*StateInfoSnapshot)(nil), "gossip.StateInfoSnapshot")
 	proto.RegisterType((*StateInfoPullRequest)(nil), "gossip.StateInfoPullRequest")
 	proto.RegisterType((*ConnEstablish)(nil), "gossip.ConnEstablish")
@@ -1919,6 +2065,7 @@ func init() {
 	proto.RegisterType((*Acknowledgement)(nil), "gossip.Acknowledgement")
 	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
 	proto.RegisterEnum("gossip.PullMsgType", PullMsgType_name, PullMsgType_value)
+	proto.RegisterEnum("gossip.CompressionType", CompressionType_name, CompressionType_value)
 	proto.RegisterEnum("gossip.GossipMessage_Tag", GossipMessage_Tag_name, GossipMessage_Tag_value)
 }
 
@@ -2067,123 +2214,139 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1877 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x6f, 0xe3, 0xc6,
-	0x11, 0x17, 0x6d, 0x49, 0x16, 0x47, 0x1f, 0x96, 0xd7, 0xbe, 0x3b, 0xc6, 0x49, 0x13, 0x87, 0xed,
-	0x25, 0xd7, 0xfa, 0x62, 0x5f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x07, 0x5b, 0x52, 0x2c, 0x21, 0x27,
-	0x9d, 0x4b, 0xfb, 0xd0, 0xba, 0x2f, 0xc4, 0x9a, 0x5c, 0x53, 0xac, 0xc9, 0x25, 0xcd, 0x5d, 0x3b,
-	0xf6, 0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0xdf, 0xd0, 0xa7, 0xfe, 0x9b, 0xc5, 0xee, 0xf2, 0x53,
-	0xb2, 0x0f, 0xb8, 0x00, 0x79, 0xd3, 0x7c, 0xef, 0xce, 0xce, 0xfc, 0x66, 0x28, 0xd8, 0xf2, 0x22,
-	0xc6, 0xfc, 0x78, 0x3f, 0x24, 0x8c, 0x61, 0x8f, 0xec, 0xc5, 0x49, 0xc4, 0x23, 0xd4, 0x54, 0xdc,
-	0xed, 0x67, 0x4e, 0x14, 0x86, 0x11, 0xdd, 0x77, 0xa2, 0x20, 0x20, 0x0e, 0xf7, 0x23, 0xaa, 0x14,
-	0xcc, 0x7f, 0x69, 0xd0, 0x1a, 0xd1, 0x5b, 0x12, 0x44, 0x31, 0x41, 0x06, 0xac, 0xc5, 0xf8, 0x3e,
-	0x88, 0xb0, 0x6b, 0x68, 0x3b, 0xda, 0x8b, 0x8e, 0x95, 0x91, 0xe8, 0x13, 0xd0, 0x99, 0xef, 0x51,
-	0xcc, 0x6f, 0x12, 0x62, 0xac, 0x48, 0x59, 0xc1, 0x40, 0xaf, 0x61, 0x9d, 0x11, 0x27, 0x21, 0xdc,
-	0x26, 0xa9, 0x2b, 0x63, 0x75, 0x47, 0x7b, 0xd1, 0x3e, 0x78, 0xba, 0xa7, 0xe2, 0xef, 0x9d, 0x4a,
-	0x71, 0x16, 0xc8, 0xea, 0xb1, 0x0a, 0x6d, 0x8e, 0xa1, 0x57, 0xd5, 0xf8, 0xa9, 0x47, 0x31, 0x0f,
-	0xa1, 0xa9, 0x3c, 0xa1, 0x97, 0xd0, 0xf7, 0x29, 0x27, 0x09, 0xc5, 0xc1, 0x88, 0xba, 0x71, 0xe4,
-	0x53, 0x2e, 0x5d, 0xe9, 0xe3, 0x9a, 0xb5, 0x24, 0x39, 0xd2, 0x61, 0xcd, 0x89, 0x28, 0x27, 0x94,
-	0x9b, 0x3f, 0xb6, 0xa1, 0x7b, 0x2c, 0x8f, 0x3d, 0x55, 0xb9, 0x44, 0x5b, 0xd0, 0xa0, 0x11, 0x75,
-	0x88, 0xb4, 0xaf, 0x5b, 0x8a, 0x10, 0x47, 0x74, 0xe6, 0x98, 0x52, 0x12, 0xa4, 0xc7, 0xc8, 0x48,
-	0xb4, 0x0b, 0xab, 0x1c, 0x7b, 0x32, 0x07, 0xbd, 0x83, 0x8f, 0xb2, 0x1c, 0x54, 0x7c, 0xee, 0x9d,
-	0x61, 0xcf, 0x12, 0x5a, 0xe8, 0x6b, 0xd0, 0x71, 0xe0, 0xdf, 0x12, 0x3b, 0x64, 0x9e, 0xd1, 0x90,
-	0x69, 0xdb, 0xca, 0x4c, 0x0e, 0x85, 0x20, 0xb5, 0x18, 0xd7, 0xac, 0x96, 0x54, 0x9c, 0x32, 0x0f,
-	0xfd, 0x0e, 0xd6, 0x42, 0x12, 0xda, 0x09, 0xb9, 0x36, 0x9a, 0xd2, 0x24, 0x8f, 0x32, 0x25, 0xe1,
-	0x05, 0x49, 0xd8, 0xdc, 0x8f, 0x2d, 0x72, 0x7d, 0x43, 0x18, 0x1f, 0xd7, 0xac, 0x66, 0x48, 0x42,
-	0x8b, 0x5c, 0xa3, 0xdf, 0x67, 0x56, 0xcc, 0x58, 0x93, 0x56, 0xdb, 0x0f, 0x59, 0xb1, 0x38, 0xa2,
-	0x8c, 0xe4, 0x66, 0x0c, 0xbd, 0x82, 0x96, 0x8b, 0x39, 0x96, 0x07, 0x6c, 0x49, 0xbb, 0xcd, 0xcc,
-	0x6e, 0x88, 0x39, 0x2e, 0xce, 0xb7, 0x26, 0xd4, 0xc4, 0xf1, 0x76, 0xa1, 0x31, 0x27, 0x41, 0x10,
-	0x19, 0x7a, 0x55, 0x5d, 0xa5, 0x60, 0x2c, 0x44, 0xe3, 0x9a, 0xa5, 0x74, 0xd0, 0x7e, 0xea, 0xde,
-	0xf5, 0x3d, 0x03, 0xa4, 0x3e, 0x2a, 0xbb, 0x1f, 0xfa, 0x9e, 0xba, 0x85, 0xf4, 0x3e, 0xf4, 0xbd,
-	0xfc, 0x3c, 0xe2, 0xf6, 0xed, 0xe5, 0xf3, 0x14, 0xf7, 0x96, 0x16, 0xea, 0xe2, 0x6d, 0x69, 0x71,
-	0x13, 0xbb, 0x98, 0x13, 0xa3, 0xb3, 0x1c, 0xe5, 0x9d, 0x94, 0x8c, 0x6b, 0x16, 0xb8, 0x39, 0x85,
-	0x9e, 0x43, 0x83, 0x84, 0x31, 0xbf, 0x37, 0xba, 0xd2, 0xa0, 0x9b, 0x19, 0x8c, 0x04, 0x53, 0x5c,
-	0x40, 0x4a, 0xd1, 0x2e, 0xd4, 0x9d, 0x88, 0x52, 0xa3, 0x27, 0xb5, 0x9e, 0x64, 0x5a, 0x83, 0x88,
-	0xd2, 0x11, 0xe3, 0xf8, 0x22, 0xf0, 0xd9, 0x7c, 0x5c, 0xb3, 0xa4, 0x12, 0x3a, 0x00, 0x60, 0x1c,
-	0x73, 0x62, 0xfb, 0xf4, 0x32, 0x32, 0xd6, 0xa5, 0xc9, 0x46, 0xde, 0x26, 0x42, 0x32, 0xa1, 0x97,
-	0x22, 0x3b, 0x3a, 0xcb, 0x08, 0x74, 0x04, 0x3d, 0x65, 0xc3, 0x28, 0x8e, 0xd9, 0x3c, 0xe2, 0x46,
-	0xbf, 0xfa, 0xe8, 0xb9, 0xdd, 0x69, 0xaa, 0x30, 0xae, 0x59, 0x5d, 0x69, 0x92, 0x31, 0xd0, 0x14,
-	0x36, 0x8b, 0xb8, 0x76, 0x7c, 0x13, 0x04, 0x32, 0x7f, 0x1b, 0xd2, 0xd1, 0x27, 0x4b, 0x8e, 0x4e,
-	0x6e, 0x82, 0xa0, 0x48, 0x64, 0x9f, 0x2d, 0xf0, 0xd1, 0x21, 0x28, 0xff, 0xc2, 0x89, 0x50, 0x32,
-	0x50, 0xb5, 0xa0, 0x2c, 0x12, 0x46, 0x9c, 0x48, 0x77, 0x85, 0x9b, 0x0e, 0x2b, 0xd1, 0x68, 0x98,
-	0xdd, 0x2a, 0x49, 0x4b, 0xce, 0xd8, 0x94, 0x3e, 0x3e, 0x7e, 0xd0, 0x47, 0x5e, 0x95, 0x5d, 0x56,
-	0x66, 0x88, 0xdc, 0x04, 0x04, 0xbb, 0xaa, 0x78, 0x65, 0x89, 0x6e, 0x55, 0x73, 0xf3, 0x26, 0x97,
-	0x16, 0x85, 0xda, 0x2d, 0x4c, 0x44, 0xb9, 0x7e, 0x0b, 0xdd, 0x98, 0x90, 0xc4, 0xf6, 0x5d, 0x42,
-	0xb9, 0xcf, 0xef, 0x8d, 0x27, 0xd5, 0x36, 0x3c, 0x21, 0x24, 0x99, 0xa4, 0x32, 0x71, 0x8d, 0xb8,
-	0x44, 0x8b, 0x66, 0xc7, 0xce, 0x95, 0xf1, 0x54, 0x9a, 0x3c, 0xcb, 0x3b, 0xd7, 0xb9, 0xa2, 0xd1,
-	0x0f, 0x01, 0x71, 0x3d, 0x12, 0x12, 0x2a, 0x2e, 0x2f, 0xb4, 0xd0, 0x9f, 0x01, 0xe2, 0xc4, 0xbf,
-	0x55, 0x59, 0x30, 0x9e, 0x55, 0x93, 0xaf, 0xee, 0x7b, 0x72, 0xcb, 0xab, 0x55, 0x5c, 0xb2, 0x40,
-	0xaf, 0x4b, 0xf6, 0xcc, 0x30, 0xa4, 0xfd, 0x2f, 0x1e, 0xb1, 0xcf, 0x33, 0x56, 0x32, 0x41, 0xaf,
-	0xa1, 0x93, 0x52, 0xb6, 0x28, 0x74, 0xe3, 0xa3, 0xea, 0xb3, 0x9d, 0x28, 0x59, 0xb5, 0xad, 0xdb,
-	0x71, 0xc1, 0x35, 0x6d, 0x58, 0x3d, 0xc3, 0x1e, 0xea, 0x82, 0xfe, 0x6e, 0x36, 0x1c, 0x7d, 0x37,
-	0x99, 0x8d, 0x86, 0xfd, 0x1a, 0xd2, 0xa1, 0x31, 0x9a, 0x9e, 0x9c, 0x9d, 0xf7, 0x35, 0xd4, 0x81,
-	0xd6, 0x5b, 0xeb, 0xd8, 0x7e, 0x3b, 0x7b, 0x73, 0xde, 0x5f, 0x11, 0x7a, 0x83, 0xf1, 0xe1, 0x4c,
-	0x91, 0xab, 0xa8, 0x0f, 0x1d, 0x49, 0x1e, 0xce, 0x86, 0xf6, 0x5b, 0xeb, 0xb8, 0x5f, 0x47, 0xeb,
-	0xd0, 0x56, 0x0a, 0x96, 0x64, 0x34, 0xca, 0x48, 0xfc, 0x3f, 0x0d, 0xf4, 0xbc, 0x22, 0xd1, 0x1e,
-	0xe8, 0xdc, 0x0f, 0x09, 0xe3, 0x38, 0x8c, 0x25, 0xe2, 0xb6, 0x0f, 0xfa, 0xe5, 0x17, 0x3a, 0xf3,
-	0x43, 0x62, 0x15, 0x2a, 0xe8, 0x09, 0x34, 0xe3, 0x2b, 0xdf, 0xf6, 0x5d, 0x09, 0xc4, 0x1d, 0xab,
-	0x11, 0x5f, 0xf9, 0x13, 0x17, 0x7d, 0x06, 0xed, 0x14, 0xa7, 0xed, 0xe9, 0xe1, 0xc0, 0xa8, 0x4b,
-	0x19, 0xa4, 0xac, 0xe9, 0xe1, 0x40, 0x74, 0x68, 0x9c, 0x44, 0x31, 0x49, 0xb8, 0x4f, 0x58, 0x8a,
-	0xc8, 0xa8, 0x48, 0x50, 0x26, 0xb1, 0x4a, 0x5a, 0xe6, 0x8f, 0x1a, 0x40, 0x21, 0x42, 0xbf, 0x84,
-	0xae, 0x7c, 0xfa, 0xc4, 0x9e, 0x13, 0xdf, 0x9b, 0xf3, 0x74, 0x70, 0x74, 0x14, 0x73, 0x2c, 0x79,
-	0xe8, 0x73, 0xe8, 0x04, 0xe4, 0x92, 0xdb, 0xe5, 0x21, 0xd2, 0xb2, 0xda, 0x82, 0x37, 0x48, 0x07,
-	0xc9, 0x6f, 0x41, 0x1c, 0xcc, 0xa7, 0x4e, 0xe4, 0x12, 0x66, 0xac, 0xee, 0xac, 0x96, 0xc1, 0x62,
-	0x90, 0x49, 0xac, 0x92, 0x92, 0x79, 0x08, 0x1b, 0x4b, 0x68, 0x80, 0x5e, 0x42, 0x8b, 0x04, 0xb2,
-	0x10, 0x99, 0xa1, 0x49, 0x2f, 0x79, 0xe6, 0xf2, 0x99, 0x9c, 0x6b, 0x98, 0x7f, 0x80, 0xad, 0x87,
-	0x70, 0x60, 0x31, 0x73, 0xda, 0x62, 0xe6, 0xcc, 0x4b, 0xe8, 0x56, 0x40, 0xaf, 0xf4, 0x04, 0x5a,
-	0xf9, 0x09, 0xb6, 0xa1, 0x95, 0xb7, 0x9a, 0x1a, 0x9d, 0x39, 0x8d, 0x4c, 0xe8, 0xf2, 0x80, 0xd9,
-	0x0e, 0x49, 0xb8, 0x3d, 0xc7, 0x6c, 0x9e, 0x3e, 0x5e, 0x9b, 0x07, 0x6c, 0x40, 0x12, 0x3e, 0xc6,
-	0x6c, 0x6e, 0xbe, 0x83, 0x4e, 0xb9, 0x25, 0x1f, 0x0b, 0x83, 0xa0, 0x2e, 0xdc, 0xa4, 0x21, 0xe4,
-	0x6f, 0x11, 0x3a, 0x24, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x73, 0xda, 0x0c, 0xa1, 0x5d, 0xea, 0xbc,
-	0xc7, 0xa7, 0xbe, 0x2b, 0x27, 0x12, 0x33, 0x56, 0x76, 0x56, 0x5f, 0xe8, 0x56, 0x46, 0xa2, 0x3d,
-	0x68, 0x85, 0xcc, 0xb3, 0xf9, 0x7d, 0xba, 0xfe, 0xf4, 0x8a, 0xb1, 0x24, 0xb2, 0x38, 0x65, 0xde,
-	0xd9, 0x7d, 0x4c, 0xac, 0xb5, 0x50, 0xfd, 0x30, 0x23, 0x68, 0x97, 0xe6, 0xe1, 0x23, 0xe1, 0xca,
-	0xe7, 0x5d, 0xa9, 0x9e, 0xf7, 0x83, 0x03, 0xde, 0x01, 0x14, 0xa3, 0xee, 0x91, 0x78, 0xbf, 0x82,
-	0x7a, 0x1a, 0xeb, 0xe1, 0x2a, 0xa9, 0xff, 0xa4, 0xc8, 0x81, 0x8a, 0xac, 0x46, 0xf9, 0xcf, 0x9e,
-	0xd8, 0x6f, 0xd4, 0x3b, 0x66, 0xdb, 0xdb, 0xaf, 0xab, 0xab, 0x64, 0xfb, 0x60, 0x3d, 0xb7, 0x56,
-	0xec, 0x7c, 0xb7, 0x34, 0xbf, 0x03, 0xb4, 0x8c, 0x80, 0xe8, 0xd5, 0xa2, 0x83, 0xa7, 0x0b, 0x70,
-	0xb9, 0xe4, 0xe7, 0x1c, 0xd6, 0x52, 0x1e, 0x7a, 0x06, 0x6b, 0x8c, 0x5c, 0xdb, 0xf4, 0x26, 0x4c,
-	0xaf, 0xdb, 0x64, 0xe4, 0x7a, 0x76, 0x13, 0x8a, 0xea, 0x2c, 0xbd, 0xaa, 0xca, 0xeb, 0xe7, 0x0b,
-	0xe8, 0x2c, 0x3a, 0xbe, 0x53, 0xc5, 0xdf, 0xff, 0xac, 0x40, 0xaf, 0x1a, 0x16, 0x7d, 0x09, 0xeb,
-	0xc5, 0x5e, 0x6f, 0x53, 0x1c, 0xaa, 0xcc, 0xea, 0x56, 0xaf, 0x60, 0xcf, 0x70, 0x48, 0xc4, 0xea,
-	0x2c, 0xa4, 0x2c, 0xc6, 0x8e, 0x5a, 0x9d, 0x75, 0xab, 0x60, 0xa0, 0x4d, 0x68, 0xf0, 0xbb, 0x0c,
-	0x2e, 0x75, 0xab, 0xce, 0xef, 0x26, 0xae, 0x40, 0xb2, 0xec, 0x44, 0xc9, 0x0f, 0x8c, 0xf0, 0x14,
-	0x2f, 0xb3, 0x63, 0x5a, 0x82, 0x87, 0x5e, 0x02, 0xca, 0x94, 0x98, 0x1f, 0x66, 0x98, 0xd7, 0x90,
-	0xd7, 0xed, 0xa7, 0x92, 0x53, 0x3f, 0x4c, 0x71, 0x6f, 0x06, 0xa8, 0x74, 0x5c, 0x27, 0xa2, 0x97,
-	0xbe, 0xc7, 0xd2, 0x35, 0xf6, 0xb3, 0x3d, 0xf5, 0xa1, 0xb2, 0x37, 0xc8, 0x35, 0x06, 0x52, 0xe1,
-	0x04, 0x3b, 0x57, 0xd8, 0x23, 0xd6, 0x86, 0xb3, 0x20, 0x60, 0xe6, 0xbf, 0x35, 0xe8, 0x94, 0x17,
-	0x65, 0xb4, 0x07, 0x10, 0xe6, 0xfb, 0x6c, 0xfa, 0x64, 0xbd, 0xea, 0xa6, 0x6b, 0x95, 0x34, 0x3e,
-	0x78, 0xb0, 0x94, 0xe1, 0xab, 0x5e, 0x85, 0x2f, 0xf3, 0x9f, 0x1a, 0x6c, 0x2c, 0x6d, 0x1c, 0x8f,
-	0x01, 0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x9f, 0xd9, 0x2e, 0x71, 0x02, 0x9c, 0x60, 0x91, 0x02,
-	0xf9, 0x54, 0x2d, 0xab, 0xeb, 0xb3, 0x61, 0xc1, 0x34, 0xff, 0x08, 0xad, 0xcc, 0x5a, 0x94, 0x9f,
-	0x4f, 0x9d, 0x72, 0xf9, 0xf9, 0xd4, 0x11, 0xe5, 0x57, 0xaa, 0xcb, 0x95, 0x72, 0x5d, 0x9a, 0x97,
-	0xb0, 0xb1, 0xf4, 0x0d, 0x81, 0xbe, 0x85, 0x3e, 0x23, 0xc1, 0xa5, 0x5c, 0x1e, 0x93, 0x50, 0xc5,
-	0xd6, 0xaa, 0x07, 0xce, 0x21, 0x62, 0x5d, 0x68, 0x4e, 0x0a, 0x45, 0xd1, 0xef, 0x62, 0x19, 0xa2,
-	0xb2, 0xaf, 0x3b, 0x96, 0x22, 0xcc, 0x0b, 0x40, 0xcb, 0x5f, 0x1d, 0xe8, 0x0b, 0x68, 0xc8, 0x8f,
-	0x9c, 0x47, 0xc7, 0x94, 0x12, 0x4b, 0x9c, 0x22, 0xd8, 0x7d, 0x0f, 0x4e, 0x11, 0xec, 0x9a, 0x7f,
-	0x85, 0xa6, 0x8a, 0x21, 0xde, 0x8c, 0x54, 0xbe, 0x02, 0xad, 0x9c, 0x7e, 0x2f, 0xc6, 0x3e, 0xbc,
-	0x44, 0x98, 0x6b, 0xd0, 0x90, 0x1f, 0x01, 0xe6, 0xdf, 0x00, 0x2d, 0xaf, 0xba, 0x62, 0x88, 0x31,
-	0x8e, 0x13, 0x6e, 0x57, 0x5b, 0xbf, 0x2d, 0x99, 0xa7, 0xaa, 0xff, 0x3f, 0x85, 0x36, 0xa1, 0xae,
-	0x5d, 0x7d, 0x04, 0x9d, 0x50, 0x57, 0xc9, 0xcd, 0x23, 0xd8, 0x7c, 0x60, 0x01, 0x46, 0xbb, 0xd0,
-	0x4a, 0x51, 0x26, 0x1b, 0xe5, 0x4b, 0x70, 0x96, 0x2b, 0x98, 0xc7, 0xb0, 0xf5, 0xd0, 0x52, 0x89,
-	0xf6, 0x0b, 0xac, 0x55, 0x3e, 0xf2, 0x8f, 0x96, 0x54, 0x51, 0x21, 0x75, 0x0e, 0xc1, 0xe6, 0x7f,
-	0x35, 0xe8, 0x56, 0x44, 0x05, 0x5a, 0x68, 0x25, 0xb4, 0x78, 0x3f, 0xc0, 0x7c, 0x0a, 0x50, 0x74,
-	0x6f, 0x8a, 0x32, 0x25, 0x0e, 0xfa, 0x18, 0xf4, 0x8b, 0x20, 0x72, 0xae, 0x44, 0x4e, 0x64, 0x63,
-	0xd5, 0xad, 0x96, 0x64, 0x9c, 0x92, 0x6b, 0xb4, 0x03, 0x1d, 0x91, 0x2a, 0x9f, 0xda, 0x92, 0x95,
-	0xa2, 0x0b, 0x30, 0x72, 0x3d, 0xa1, 0x47, 0x82, 0x63, 0x7e, 0x0f, 0x4f, 0x1e, 0xdc, 0x80, 0xd1,
-	0xc1, 0xd2, 0xf6, 0xf3, 0x74, 0xe1, 0xba, 0x23, 0x25, 0x2e, 0xed, 0x40, 0xe7, 0xd0, 0xab, 0xca,
-	0xd0, 0x57, 0xd0, 0x54, 0xd9, 0x48, 0x0b, 0xff, 0x91, 0x94, 0xa5, 0x4a, 0xe5, 0x3f, 0x30, 0x54,
-	0xd9, 0xe7, 0xc3, 0xe1, 0x2f, 0xb9, 0xeb, 0x0c, 0xc0, 0x9f, 0xc3, 0x3a, 0xbf, 0xb3, 0x2b, 0xd7,
-	0x4b, 0x17, 0x46, 0x7e, 0x77, 0x9a, 0x5f, 0xb0, 0xea, 0xb2, 0xfc, 0x9f, 0x88, 0xf9, 0x25, 0xac,
-	0x2f, 0x7c, 0x70, 0x88, 0xa6, 0x23, 0x49, 0x12, 0x25, 0xe9, 0xfb, 0x28, 0xc2, 0x7c, 0x07, 0x7a,
-	0xbe, 0x36, 0x8a, 0x09, 0x54, 0x1a, 0x16, 0xf2, 0xb7, 0x88, 0x71, 0x4b, 0x12, 0x26, 0x1e, 0x48,
-	0xbd, 0x5f, 0x46, 0xbe, 0x6f, 0x73, 0xfa, 0xcd, 0x9f, 0xa0, 0x5d, 0x9a, 0xc4, 0x8b, 0x1f, 0x07,
-	0x5d, 0xd0, 0x8f, 0xde, 0xbc, 0x1d, 0x7c, 0x6f, 0x4f, 0x4f, 0x8f, 0xfb, 0x9a, 0xf8, 0x06, 0x98,
-	0x0c, 0x47, 0xb3, 0xb3, 0xc9, 0xd9, 0xb9, 0xe4, 0xac, 0x1c, 0xfc, 0x03, 0x9a, 0x6a, 0x13, 0x42,
-	0xdf, 0x40, 0x47, 0xfd, 0x3a, 0xe5, 0x09, 0xc1, 0x21, 0x5a, 0x6a, 0xec, 0xed, 0x25, 0x8e, 0x59,
-	0x7b, 0xa1, 0xbd, 0xd2, 0xd0, 0x17, 0x50, 0x3f, 0xf1, 0xa9, 0x87, 0xaa, 0x1f, 0xe9, 0xdb, 0x55,
-	0xd2, 0xac, 0x1d, 0x7d, 0xf5, 0xf7, 0x5d, 0xcf, 0xe7, 0xf3, 0x9b, 0x0b, 0x31, 0x69, 0xf6, 0xe7,
-	0xf7, 0x31, 0x49, 0xd4, 0x56, 0xbe, 0x7f, 0x89, 0x2f, 0x12, 0xdf, 0xd9, 0x97, 0xff, 0x8b, 0xb1,
-	0x7d, 0x65, 0x76, 0xd1, 0x94, 0xe4, 0xd7, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0x22, 0x0e, 0xc2,
-	0x6f, 0x5f, 0x13, 0x00, 0x00,
+	// 2133 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x52, 0x23, 0xc7,
+	0xf5, 0x47, 0x20, 0x09, 0xe9, 0xe8, 0x03, 0xd1, 0xb0, 0xbb, 0xe3, 0xb5, 0xff, 0x36, 0xff, 0x89,
+	0xd7, 0xde, 0x98, 0x35, 0x6c, 0x70, 0x52, 0x71, 0x6a, 0x93, 0x6c, 0x81, 0xc0, 0x48, 0x65, 0x10,
+	0x4a, 0x8b, 0xad, 0x14, 0xbe, 0x99, 0x6a, 0x66, 0x1a, 0xa9, 0xc3, 0x4c, 0xcf, 0x30, 0xdd, 0xec,
+	0x82, 0x6f, 0x73, 0x91, 0xaa, 0xdc, 0xe4, 0x2d, 0x92, 0x67, 0xc8, 0x45, 0xde, 0x22, 0x55, 0x79,
+	0x9d, 0x54, 0x77, 0xcf, 0x27, 0x82, 0xad, 0x5a, 0x57, 0xe5, 0x6e, 0xce, 0x57, 0x9f, 0xd3, 0xa7,
+	0x4f, 0xff, 0xce, 0xe9, 0x81, 0xf5, 0x69, 0x28, 0x04, 0x8b, 0xb6, 0x03, 0x2a, 0x04, 0x99, 0xd2,
+	0xad, 0x28, 0x0e, 0x65, 0x88, 0xea, 0x86, 0xfb, 0xf4, 0x89, 0x1b, 0x06, 0x41, 0xc8, 0xb7, 0xdd,
+	0xd0, 0xf7, 0xa9, 0x2b, 0x59, 0xc8, 0x8d, 0x82, 0xfd, 0xe7, 0x0a, 0x34, 0x0e, 0xf8, 0x5b, 0xea,
+	0x87, 0x11, 0x45, 0x16, 0x2c, 0x47, 0xe4, 0xd6, 0x0f, 0x89, 0x67, 0x55, 0x36, 0x2a, 0xcf, 0xdb,
+	0x38, 0x25, 0xd1, 0x27, 0xd0, 0x14, 0x6c, 0xca, 0x89, 0xbc, 0x8e, 0xa9, 0xb5, 0xa8, 0x65, 0x39,
+	0x03, 0xbd, 0x86, 0x15, 0x41, 0xdd, 0x98, 0x4a, 0x87, 0x26, 0x4b, 0x59, 0x4b, 0x1b, 0x95, 0xe7,
+	0xad, 0x9d, 0xc7, 0x5b, 0xc6, 0xff, 0xd6, 0x44, 0x8b, 0x53, 0x47, 0xb8, 0x2b, 0x4a, 0xb4, 0x3d,
+	0x80, 0x6e, 0x59, 0xe3, 0xa7, 0x86, 0x62, 0xef, 0x42, 0xdd, 0xac, 0x84, 0x5e, 0x40, 0x8f, 0x71,
+	0x49, 0x63, 0x4e, 0xfc, 0x03, 0xee, 0x45, 0x21, 0xe3, 0x52, 0x2f, 0xd5, 0x1c, 0x2c, 0xe0, 0x39,
+	0xc9, 0x5e, 0x13, 0x96, 0xdd, 0x90, 0x4b, 0xca, 0xa5, 0xfd, 0x97, 0x16, 0x74, 0x0e, 0x75, 0xd8,
+	0xc7, 0x26, 0x97, 0x68, 0x1d, 0x6a, 0x3c, 0xe4, 0x2e, 0xd5, 0xf6, 0x55, 0x6c, 0x08, 0x15, 0xa2,
+	0x3b, 0x23, 0x9c, 0x53, 0x3f, 0x09, 0x23, 0x25, 0xd1, 0x26, 0x2c, 0x49, 0x32, 0xd5, 0x39, 0xe8,
+	0xee, 0x7c, 0x94, 0xe6, 0xa0, 0xb4, 0xe6, 0xd6, 0x29, 0x99, 0x62, 0xa5, 0x85, 0xbe, 0x81, 0x26,
+	0xf1, 0xd9, 0x5b, 0xea, 0x04, 0x62, 0x6a, 0xd5, 0x74, 0xda, 0xd6, 0x53, 0x93, 0x5d, 0x25, 0x48,
+	0x2c, 0x06, 0x0b, 0xb8, 0xa1, 0x15, 0x8f, 0xc5, 0x14, 0xfd, 0x12, 0x96, 0x03, 0x1a, 0x38, 0x31,
+	0xbd, 0xb2, 0xea, 0xda, 0x24, 0xf3, 0x72, 0x4c, 0x83, 0x73, 0x1a, 0x8b, 0x19, 0x8b, 0x30, 0xbd,
+	0xba, 0xa6, 0x42, 0x0e, 0x16, 0x70, 0x3d, 0xa0, 0x01, 0xa6, 0x57, 0xe8, 0x57, 0xa9, 0x95, 0xb0,
+	0x96, 0xb5, 0xd5, 0xd3, 0xfb, 0xac, 0x44, 0x14, 0x72, 0x41, 0x33, 0x33, 0x81, 0x5e, 0x42, 0xc3,
+	0x23, 0x92, 0xe8, 0x00, 0x1b, 0xda, 0x6e, 0x2d, 0xb5, 0xdb, 0x27, 0x92, 0xe4, 0xf1, 0x2d, 0x2b,
+	0x35, 0x15, 0xde, 0x26, 0xd4, 0x66, 0xd4, 0xf7, 0x43, 0xab, 0x59, 0x56, 0x37, 0x29, 0x18, 0x28,
+	0xd1, 0x60, 0x01, 0x1b, 0x1d, 0xb4, 0x9d, 0x2c, 0xef, 0xb1, 0xa9, 0x05, 0x5a, 0x1f, 0x15, 0x97,
+	0xdf, 0x67, 0x53, 0xb3, 0x0b, 0xbd, 0xfa, 0x3e, 0x9b, 0x66, 0xf1, 0xa8, 0xdd, 0xb7, 0xe6, 0xe3,
+	0xc9, 0xf7, 0xad, 0x2d, 0xcc, 0xc6, 0x5b, 0xda, 0xe2, 0x3a, 0xf2, 0x88, 0xa4, 0x56, 0x7b, 0xde,
+	0xcb, 0x1b, 0x2d, 0x19, 0x2c, 0x60, 0xf0, 0x32, 0x0a, 0x3d, 0x83, 0x1a, 0x0d, 0x22, 0x79, 0x6b,
+	0x75, 0xb4, 0x41, 0x27, 0x35, 0x38, 0x50, 0x4c, 0xb5, 0x01, 0x2d, 0x45, 0x9b, 0x50, 0x75, 0x43,
+	0xce, 0xad, 0xae, 0xd6, 0x7a, 0x94, 0x6a, 0xf5, 0x43, 0xce, 0x0f, 0x84, 0x24, 0xe7, 0x3e, 0x13,
+	0xb3, 0xc1, 0x02, 0xd6, 0x4a, 0x68, 0x07, 0x40, 0x48, 0x22, 0xa9, 0xc3, 0xf8, 0x45, 0x68, 0xad,
+	0x68, 0x93, 0xd5, 0xec, 0x9a, 0x28, 0xc9, 0x90, 0x5f, 0xa8, 0xec, 0x34, 0x45, 0x4a, 0xa0, 0x3d,
+	0xe8, 0x1a, 0x1b, 0xc1, 0x49, 0x24, 0x66, 0xa1, 0xb4, 0x7a, 0xe5, 0x43, 0xcf, 0xec, 0x26, 0x89,
+	0xc2, 0x60, 0x01, 0x77, 0xb4, 0x49, 0xca, 0x40, 0xc7, 0xb0, 0x96, 0xfb, 0x75, 0xa2, 0x6b, 0xdf,
+	0xd7, 0xf9, 0x5b, 0xd5, 0x0b, 0x7d, 0x32, 0xb7, 0xd0, 0xf8, 0xda, 0xf7, 0xf3, 0x44, 0xf6, 0xc4,
+	0x1d, 0x3e, 0xda, 0x05, 0xb3, 0xbe, 0x13, 0x1b, 0x25, 0x0b, 0x95, 0x0b, 0x0a, 0xd3, 0x20, 0x94,
+	0x54, 0x2f, 0x97, 0x2f, 0xd3, 0x16, 0x05, 0x1a, 0xed, 0xa7, 0xbb, 0x8a, 0x93, 0x92, 0xb3, 0xd6,
+	0xf4, 0x1a, 0x1f, 0xdf, 0xbb, 0x46, 0x56, 0x95, 0x1d, 0x51, 0x64, 0xa8, 0xdc, 0xf8, 0x94, 0x78,
+	0xa6, 0x78, 0x75, 0x89, 0xae, 0x97, 0x73, 0x73, 0x94, 0x49, 0xf3, 0x42, 0xed, 0xe4, 0x26, 0xaa,
+	0x5c, 0x5f, 0x41, 0x27, 0xa2, 0x34, 0x76, 0x98, 0x47, 0xb9, 0x64, 0xf2, 0xd6, 0x7a, 0x54, 0xbe,
+	0x86, 0x63, 0x4a, 0xe3, 0x61, 0x22, 0x53, 0xdb, 0x88, 0x0a, 0xb4, 0xba, 0xec, 0xc4, 0xbd, 0xb4,
+	0x1e, 0x6b, 0x93, 0x27, 0xd9, 0xcd, 0x75, 0x2f, 0x79, 0xf8, 0xce, 0xa7, 0xde, 0x94, 0x06, 0x94,
+	0xab, 0xcd, 0x2b, 0x2d, 0xf4, 0x7b, 0x80, 0x28, 0x66, 0x6f, 0x4d, 0x16, 0xac, 0x27, 0xe5, 0xe4,
+	0x9b, 0xfd, 0x8e, 0xdf, 0xca, 0x72, 0x15, 0x17, 0x2c, 0xd0, 0xeb, 0x82, 0xbd, 0xb0, 0x2c, 0x6d,
+	0xff, 0x7f, 0x0f, 0xd8, 0x67, 0x19, 0x2b, 0x98, 0xa0, 0xd7, 0xd0, 0x4e, 0x28, 0x47, 0x15, 0xba,
+	0xf5, 0x51, 0xf9, 0xd8, 0xc6, 0x46, 0x56, 0xbe, 0xd6, 0xad, 0x28, 0xe7, 0xda, 0x0e, 0x2c, 0x9d,
+	0x92, 0x29, 0xea, 0x40, 0xf3, 0xcd, 0x68, 0xff, 0xe0, 0xbb, 0xe1, 0xe8, 0x60, 0xbf, 0xb7, 0x80,
+	0x9a, 0x50, 0x3b, 0x38, 0x1e, 0x9f, 0x9e, 0xf5, 0x2a, 0xa8, 0x0d, 0x8d, 0x13, 0x7c, 0xe8, 0x9c,
+	0x8c, 0x8e, 0xce, 0x7a, 0x8b, 0x4a, 0xaf, 0x3f, 0xd8, 0x1d, 0x19, 0x72, 0x09, 0xf5, 0xa0, 0xad,
+	0xc9, 0xdd, 0xd1, 0xbe, 0x73, 0x82, 0x0f, 0x7b, 0x55, 0xb4, 0x02, 0x2d, 0xa3, 0x80, 0x35, 0xa3,
+	0x56, 0x44, 0xe2, 0x7f, 0x54, 0xa0, 0x99, 0x55, 0x24, 0xda, 0x82, 0xa6, 0x64, 0x01, 0x15, 0x92,
+	0x04, 0x91, 0x46, 0xdc, 0xd6, 0x4e, 0xaf, 0x78, 0x42, 0xa7, 0x2c, 0xa0, 0x38, 0x57, 0x41, 0x8f,
+	0xa0, 0x1e, 0x5d, 0x32, 0x87, 0x79, 0x1a, 0x88, 0xdb, 0xb8, 0x16, 0x5d, 0xb2, 0xa1, 0x87, 0x3e,
+	0x83, 0x56, 0x82, 0xd3, 0xce, 0xf1, 0x6e, 0xdf, 0xaa, 0x6a, 0x19, 0x24, 0xac, 0xe3, 0xdd, 0xbe,
+	0xba, 0xa1, 0x51, 0x1c, 0x46, 0x34, 0x96, 0x8c, 0x0a, 0xab, 0x56, 0xc6, 0x8a, 0x71, 0x26, 0xc1,
+	0x05, 0x2d, 0xfb, 0x9f, 0x15, 0x80, 0x5c, 0x84, 0x7e, 0x06, 0x1d, 0x7d, 0xf4, 0xb1, 0x33, 0xa3,
+	0x6c, 0x3a, 0x93, 0x49, 0xe3, 0x68, 0x1b, 0xe6, 0x40, 0xf3, 0xd0, 0xff, 0x43, 0xdb, 0xa7, 0x17,
+	0xd2, 0x29, 0x36, 0x91, 0x06, 0x6e, 0x29, 0x5e, 0xdf, 0xb0, 0xd0, 0x2f, 0x40, 0x05, 0xc6, 0xb8,
+	0x1b, 0x7a, 0x54, 0x58, 0x4b, 0x1b, 0x4b, 0x45, 0xb0, 0xe8, 0xa7, 0x12, 0x5c, 0x50, 0x42, 0x2f,
+	0x01, 0x54, 0x9b, 0x74, 0x66, 0x8c, 0x4b, 0xa1, 0x77, 0x57, 0x30, 0x39, 0x0a, 0x89, 0x37, 0x50,
+	0x02, 0xdc, 0xf4, 0xd3, 0x4f, 0x9b, 0x41, 0x33, 0xe3, 0xa3, 0x4d, 0x58, 0x8d, 0x28, 0xf7, 0x18,
+	0x9f, 0x3a, 0x6a, 0x7b, 0xa1, 0x20, 0xbe, 0xd0, 0xd1, 0x77, 0x70, 0x2f, 0x11, 0x8c, 0x53, 0x3e,
+	0xda, 0x86, 0x35, 0xca, 0xbd, 0x30, 0x16, 0xba, 0xc6, 0x1d, 0x9f, 0x48, 0xca, 0xdd, 0x5b, 0xbd,
+	0x91, 0x2a, 0x46, 0x05, 0xd1, 0x91, 0x91, 0xd8, 0xbb, 0xb0, 0x3a, 0x07, 0x55, 0xe8, 0x05, 0x34,
+	0xa8, 0xaf, 0xd5, 0x94, 0xa7, 0xa5, 0xe2, 0xb1, 0x66, 0x03, 0x43, 0xa6, 0x61, 0xff, 0x1a, 0xd6,
+	0xef, 0x03, 0xa9, 0xbb, 0xc7, 0x5a, 0xb9, 0x7b, 0xac, 0xf6, 0x05, 0x74, 0x4a, 0x88, 0x5c, 0xa8,
+	0x8f, 0x4a, 0xb1, 0x3e, 0x9e, 0x42, 0x23, 0xc3, 0x01, 0xd3, 0xd7, 0x33, 0x1a, 0xd9, 0xd0, 0x91,
+	0xbe, 0x70, 0x5c, 0x1a, 0x4b, 0x67, 0x46, 0xc4, 0x2c, 0xa9, 0xac, 0x96, 0xf4, 0x45, 0x9f, 0xc6,
+	0x72, 0x40, 0xc4, 0xcc, 0x7e, 0x03, 0xed, 0x22, 0x5e, 0x3c, 0xe4, 0x06, 0x41, 0x55, 0x2d, 0x93,
+	0xb8, 0xd0, 0xdf, 0xca, 0x75, 0x40, 0x25, 0xd1, 0x17, 0xd3, 0xac, 0x9c, 0xd1, 0x76, 0x00, 0xad,
+	0x02, 0x2c, 0x3c, 0x3c, 0x92, 0x78, 0xba, 0x5d, 0x0a, 0x6b, 0x71, 0x63, 0xe9, 0x79, 0x13, 0xa7,
+	0x24, 0xda, 0x82, 0x46, 0x20, 0xa6, 0x8e, 0xbc, 0x4d, 0x66, 0xb3, 0x6e, 0xde, 0x33, 0x55, 0x16,
+	0x8f, 0xc5, 0xf4, 0xf4, 0x36, 0xa2, 0x78, 0x39, 0x30, 0x1f, 0x76, 0x08, 0xad, 0x42, 0xb3, 0x7e,
+	0xc0, 0x5d, 0x31, 0xde, 0xc5, 0x72, 0xbc, 0x1f, 0xec, 0xf0, 0x06, 0x20, 0xef, 0xc3, 0x0f, 0xf8,
+	0xfb, 0x1c, 0xaa, 0x89, 0xaf, 0xfb, 0xab, 0xa4, 0xfa, 0x93, 0x3c, 0xfb, 0x00, 0xf9, 0x9c, 0xf1,
+	0x3f, 0x4f, 0xec, 0xb7, 0xd0, 0x2a, 0xa0, 0x2b, 0xfa, 0x79, 0x79, 0xce, 0x6d, 0xed, 0xac, 0x64,
+	0xd6, 0x86, 0x9d, 0x0d, 0xbe, 0xf6, 0x77, 0x80, 0xe6, 0xe1, 0x19, 0xbd, 0xbc, 0xbb, 0xc0, 0xe3,
+	0x3b, 0x58, 0x3e, 0xb7, 0xce, 0x19, 0x2c, 0x27, 0x3c, 0xf4, 0x04, 0x96, 0x05, 0xbd, 0x72, 0xf8,
+	0x75, 0x90, 0x6c, 0xb7, 0x2e, 0xe8, 0xd5, 0xe8, 0x3a, 0x50, 0xd5, 0x59, 0x38, 0x55, 0xfd, 0xad,
+	0xf0, 0xaa, 0xd4, 0x3a, 0x14, 0x1c, 0xb5, 0xcb, 0xcd, 0xe1, 0x6f, 0x8b, 0xd0, 0x2d, 0xbb, 0x45,
+	0x5f, 0xc2, 0x4a, 0xfe, 0xe8, 0x70, 0x38, 0x09, 0x4c, 0x66, 0x9b, 0xb8, 0x9b, 0xb3, 0x47, 0x24,
+	0xa0, 0x6a, 0xae, 0x57, 0x52, 0x11, 0x11, 0xd7, 0xcc, 0xf5, 0x4d, 0x9c, 0x33, 0xd0, 0x1a, 0xd4,
+	0xe4, 0x4d, 0x8a, 0xe5, 0x4d, 0x5c, 0x95, 0x37, 0x43, 0x4f, 0xc1, 0x6c, 0x1a, 0x51, 0xfc, 0x4e,
+	0x50, 0x99, 0x80, 0x79, 0x1a, 0x26, 0x56, 0x3c, 0xf4, 0x02, 0x50, 0xaa, 0x24, 0x58, 0x90, 0x02,
+	0x72, 0x4d, 0x6f, 0xb7, 0x97, 0x48, 0x26, 0x2c, 0x48, 0x40, 0x79, 0x04, 0xa8, 0x10, 0xae, 0x1b,
+	0xf2, 0x0b, 0x36, 0x15, 0xc9, 0x8c, 0xfd, 0xd9, 0x96, 0x79, 0x45, 0x6d, 0xf5, 0x33, 0x8d, 0xbe,
+	0x56, 0x18, 0x13, 0xf7, 0x92, 0x4c, 0x29, 0x5e, 0x75, 0xef, 0x08, 0x84, 0xfd, 0xd7, 0x0a, 0xb4,
+	0x8b, 0x53, 0x3c, 0xda, 0x02, 0x08, 0xb2, 0x61, 0x3b, 0x39, 0xb2, 0x6e, 0x79, 0x0c, 0xc7, 0x05,
+	0x8d, 0x0f, 0xee, 0x7a, 0x45, 0xf8, 0xaa, 0x96, 0xe1, 0xcb, 0xfe, 0x4f, 0x05, 0x56, 0xe7, 0xc6,
+	0xa1, 0x87, 0x00, 0xea, 0x43, 0x1d, 0x3f, 0x83, 0x2e, 0x13, 0x8e, 0x47, 0x5d, 0x9f, 0xc4, 0x44,
+	0xa5, 0x40, 0x1f, 0x55, 0x03, 0x77, 0x98, 0xd8, 0xcf, 0x99, 0x2a, 0xbe, 0x28, 0x66, 0x61, 0x9c,
+	0xc6, 0xd7, 0xc1, 0x19, 0xad, 0x4a, 0xe0, 0x9a, 0xcf, 0x28, 0xf1, 0xe5, 0xec, 0x56, 0x9f, 0x50,
+	0x03, 0xe7, 0x0c, 0x65, 0xf9, 0x4e, 0x1f, 0x12, 0xf5, 0xf4, 0x81, 0x34, 0x70, 0x46, 0xdb, 0xbf,
+	0x85, 0x46, 0x1a, 0x93, 0x2a, 0x6a, 0xc6, 0xdd, 0x62, 0x51, 0x33, 0xee, 0xaa, 0xa2, 0x2e, 0x54,
+	0xfb, 0x62, 0xb1, 0xda, 0xed, 0x0b, 0x58, 0x9d, 0x7b, 0x36, 0xa1, 0x57, 0xd0, 0x13, 0xd4, 0xbf,
+	0xd0, 0xf3, 0x72, 0x1c, 0x98, 0x1d, 0x55, 0x36, 0x2a, 0xf7, 0x02, 0xcf, 0x8a, 0xd2, 0x1c, 0xe6,
+	0x8a, 0x0a, 0x45, 0xd4, 0xfc, 0xc7, 0x35, 0x5a, 0xb4, 0xb1, 0x21, 0xec, 0x73, 0x40, 0xf3, 0x0f,
+	0x2d, 0xf4, 0x05, 0xd4, 0xf4, 0xbb, 0xee, 0xc1, 0xe6, 0x67, 0xc4, 0x1a, 0xfd, 0x28, 0xf1, 0xde,
+	0x83, 0x7e, 0x94, 0x78, 0xf6, 0x1f, 0xa1, 0x6e, 0x7c, 0xa8, 0x7c, 0xd1, 0xd2, 0xc3, 0x17, 0x67,
+	0xf4, 0x7b, 0x91, 0xfb, 0xfe, 0xb9, 0xc9, 0x5e, 0x86, 0x9a, 0x7e, 0xf7, 0xd8, 0x7f, 0xaf, 0x00,
+	0x9a, 0x1f, 0xef, 0x55, 0x6f, 0x14, 0x92, 0xc4, 0xd2, 0x29, 0x23, 0x4a, 0x4b, 0x33, 0x27, 0x06,
+	0x56, 0x3e, 0x85, 0x16, 0xe5, 0x9e, 0x53, 0x3e, 0x85, 0x26, 0xe5, 0x5e, 0x22, 0x3f, 0x82, 0x47,
+	0xc4, 0x75, 0x69, 0x24, 0xa9, 0xe7, 0xb8, 0x61, 0x10, 0xc5, 0x54, 0x08, 0x16, 0x72, 0x33, 0xfa,
+	0x74, 0xf3, 0xe9, 0xba, 0x9f, 0xcb, 0x34, 0xba, 0xae, 0xa7, 0x56, 0x05, 0x81, 0xb0, 0xff, 0x5d,
+	0x81, 0xb5, 0x7b, 0xde, 0x10, 0x68, 0x13, 0x1a, 0x09, 0x16, 0xa6, 0x03, 0xc7, 0x1c, 0xe8, 0x66,
+	0x0a, 0xe8, 0x37, 0xd0, 0x2a, 0x44, 0xa2, 0x43, 0x7e, 0x4f, 0x20, 0x45, 0x5d, 0x35, 0x1e, 0xa5,
+	0x24, 0xf5, 0x9c, 0xcc, 0xa5, 0xc9, 0x2a, 0xca, 0x45, 0xe3, 0xd4, 0xd7, 0xe7, 0xd0, 0x0d, 0xc8,
+	0x8d, 0x73, 0x4e, 0xa4, 0x3b, 0x73, 0x04, 0xfb, 0x91, 0xea, 0x1b, 0x52, 0xc5, 0xed, 0x80, 0xdc,
+	0xec, 0x29, 0xe6, 0x84, 0xfd, 0x48, 0xed, 0x43, 0x58, 0xbf, 0xef, 0xa5, 0x80, 0xb6, 0xf3, 0x1e,
+	0x65, 0x76, 0x95, 0xbd, 0x44, 0x13, 0x45, 0xd3, 0xe1, 0xb2, 0xd6, 0x65, 0xff, 0xab, 0x02, 0x9d,
+	0x92, 0x28, 0x47, 0xd9, 0x4a, 0x01, 0x65, 0xdf, 0x0f, 0xcc, 0x9f, 0x02, 0xe4, 0xa8, 0x97, 0xa0,
+	0x73, 0x81, 0x83, 0x3e, 0x86, 0xe6, 0xb9, 0x1f, 0xba, 0x97, 0xea, 0xd0, 0x93, 0xed, 0x34, 0x34,
+	0x63, 0x42, 0xaf, 0xd0, 0x06, 0xb4, 0x55, 0x2d, 0x30, 0xee, 0x68, 0x56, 0x82, 0xca, 0x20, 0xe8,
+	0xd5, 0x90, 0xef, 0x29, 0x8e, 0x2a, 0xd4, 0x73, 0xe2, 0x5e, 0x5e, 0x30, 0xdf, 0x4f, 0x2f, 0x7d,
+	0x4a, 0xdb, 0xdf, 0xc3, 0xa3, 0x7b, 0x9f, 0x3c, 0x68, 0x67, 0x6e, 0xa2, 0x7c, 0x7c, 0x27, 0x15,
+	0x07, 0x46, 0x5c, 0x98, 0x2b, 0xcf, 0xa0, 0x5b, 0x96, 0xa1, 0xaf, 0xa1, 0x6e, 0x32, 0x95, 0x5c,
+	0xfb, 0x07, 0xd2, 0x99, 0x28, 0x15, 0xff, 0x58, 0x99, 0x4b, 0x9f, 0x92, 0xf6, 0x1f, 0xb2, 0xa5,
+	0xd3, 0xa6, 0xf8, 0x0c, 0x56, 0xe4, 0x8d, 0x53, 0xda, 0x7a, 0xf2, 0x42, 0x90, 0x37, 0x93, 0x7c,
+	0xf3, 0xa5, 0x25, 0x8b, 0x3f, 0xc1, 0xec, 0x2f, 0x61, 0xe5, 0xce, 0x0b, 0x53, 0x41, 0x0e, 0x8d,
+	0xe3, 0x30, 0x4e, 0xce, 0xce, 0x10, 0xf6, 0x1b, 0x68, 0x66, 0xef, 0x04, 0xd5, 0xd5, 0x0b, 0x0d,
+	0x58, 0x7f, 0x2b, 0x1f, 0x6f, 0x69, 0x9c, 0xd5, 0x76, 0x13, 0xa7, 0xe4, 0xfb, 0xa6, 0xd1, 0xaf,
+	0x7e, 0x07, 0xad, 0xc2, 0x74, 0x73, 0xf7, 0x35, 0xd8, 0x81, 0xe6, 0xde, 0xd1, 0x49, 0xff, 0x7b,
+	0xe7, 0x78, 0x72, 0xd8, 0xab, 0xa8, 0x47, 0xdf, 0x70, 0xff, 0x60, 0x74, 0x3a, 0x3c, 0x3d, 0xd3,
+	0x9c, 0xc5, 0xaf, 0x5e, 0xc1, 0xca, 0x9d, 0x9b, 0x83, 0x10, 0x74, 0x47, 0x27, 0x4e, 0xff, 0xe4,
+	0x78, 0x8c, 0x0f, 0x26, 0x93, 0xe1, 0xc9, 0xa8, 0xb7, 0x80, 0x1a, 0x50, 0x3d, 0xfc, 0x61, 0x38,
+	0xee, 0x55, 0x10, 0x40, 0x7d, 0x32, 0xda, 0x1d, 0x8f, 0xcf, 0x7a, 0x8b, 0x3b, 0x7f, 0x82, 0xba,
+	0x19, 0x4d, 0xd1, 0xb7, 0xd0, 0x36, 0x5f, 0x13, 0x19, 0x53, 0x12, 0xa0, 0x39, 0x4c, 0x7c, 0x3a,
+	0xc7, 0xb1, 0x17, 0x9e, 0x57, 0x5e, 0x56, 0xd0, 0x17, 0x50, 0x1d, 0x33, 0x3e, 0x45, 0xe5, 0x5f,
+	0x3a, 0x4f, 0xcb, 0xa4, 0xbd, 0xb0, 0xf7, 0xf5, 0x0f, 0x9b, 0x53, 0x26, 0x67, 0xd7, 0xe7, 0xaa,
+	0xf5, 0x6f, 0xcf, 0x6e, 0x23, 0x1a, 0x9b, 0x37, 0xdc, 0xf6, 0x05, 0x39, 0x8f, 0x99, 0xbb, 0xad,
+	0xff, 0xa2, 0x8a, 0x6d, 0x63, 0x76, 0x5e, 0xd7, 0xe4, 0x37, 0xff, 0x1d, 0x00, 0x17, 0x2f, 0xd9,
+	0x74, 0x8d, 0x15, 0x00, 0x00,
 }
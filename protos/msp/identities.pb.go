@@ -15,6 +15,7 @@ It has these top-level messages:
 	MSPConfig
 	FabricMSPConfig
 	FabricCryptoConfig
+	FabricRevocationCheckConfig
 	IdemixMSPConfig
 	IdemixMSPSignerConfig
 	SigningIdentityInfo
@@ -26,6 +27,7 @@ It has these top-level messages:
 	MSPRole
 	MSPIdentityAnonymity
 	CombinedPrincipal
+	MSPAttributes
 */
 package msp
 
@@ -52,6 +54,10 @@ type SerializedIdentity struct {
 	Mspid string `protobuf:"bytes,1,opt,name=mspid" json:"mspid,omitempty"`
 	// the Identity, serialized according to the rules of its MPS
 	IdBytes []byte `protobuf:"bytes,2,opt,name=id_bytes,json=idBytes,proto3" json:"id_bytes,omitempty"`
+	// ocsp_response optionally contains a (stapled) DER-encoded OCSP response
+	// asserting the revocation status of the certificate of an X.509 identity,
+	// which is used instead of contacting the OCSP responder
+	OcspResponse []byte `protobuf:"bytes,3,opt,name=ocsp_response,json=ocspResponse,proto3" json:"ocsp_response,omitempty"`
 }
 
 func (m *SerializedIdentity) Reset()                    { *m = SerializedIdentity{} }
@@ -73,6 +79,13 @@ func (m *SerializedIdentity) GetIdBytes() []byte {
 	return nil
 }
 
+func (m *SerializedIdentity) GetOcspResponse() []byte {
+	if m != nil {
+		return m.OcspResponse
+	}
+	return nil
+}
+
 // This struct represents an Idemix Identity
 // to be used to serialize it and deserialize it.
 // The IdemixMSP will first serialize an idemix identity to bytes using
@@ -142,20 +155,21 @@ func init() {
 func init() { proto.RegisterFile("msp/identities.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 238 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x8f, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0x47, 0x95, 0x34, 0xe1, 0x8f, 0x55, 0x31, 0x98, 0x0e, 0x66, 0x2b, 0x9d, 0x32, 0xc5, 0x03,
-	0xdf, 0xa0, 0x12, 0x03, 0x03, 0x4b, 0x58, 0x80, 0xa5, 0x6a, 0xea, 0x6b, 0x7a, 0x52, 0x2e, 0x67,
-	0xd9, 0x8e, 0x54, 0x33, 0xf0, 0xd9, 0x51, 0x62, 0x40, 0xb0, 0xdd, 0xef, 0xe9, 0xe9, 0xc9, 0x16,
-	0x2b, 0xf2, 0x56, 0xa3, 0x81, 0x21, 0x60, 0x40, 0xf0, 0xb5, 0x75, 0x1c, 0x58, 0x2e, 0xc8, 0xdb,
-	0xcd, 0xa3, 0x90, 0x2f, 0xe0, 0x70, 0xdf, 0xe3, 0x07, 0x98, 0xa7, 0xa4, 0x44, 0xb9, 0x12, 0x25,
-	0x79, 0x8b, 0x46, 0x65, 0xeb, 0xac, 0xba, 0x6e, 0xd2, 0x90, 0x77, 0xe2, 0x0a, 0xcd, 0xae, 0x8d,
-	0x01, 0xbc, 0xca, 0xd7, 0x59, 0xb5, 0x6c, 0x2e, 0xd1, 0x6c, 0xa7, 0xb9, 0xf9, 0x14, 0xea, 0x5f,
-	0x86, 0xf0, 0xfc, 0x1b, 0xbb, 0x15, 0xe5, 0x10, 0x69, 0x77, 0x9e, 0x63, 0xcb, 0xa6, 0x18, 0x22,
-	0xbd, 0xfe, 0xc0, 0xf8, 0x1d, 0x9a, 0xe0, 0x9b, 0xbc, 0x11, 0x39, 0x8f, 0x6a, 0x31, 0x93, 0x9c,
-	0x47, 0x29, 0x45, 0xe1, 0xb8, 0x07, 0x55, 0x24, 0x67, 0xba, 0xa7, 0xa7, 0x59, 0xc7, 0x7c, 0x54,
-	0xe5, 0x0c, 0xd3, 0xd8, 0x3e, 0x8b, 0x7b, 0x76, 0x5d, 0x7d, 0x8a, 0x16, 0x5c, 0x0f, 0xa6, 0x03,
-	0x57, 0x1f, 0xf7, 0xad, 0xc3, 0x43, 0xfa, 0xab, 0xaf, 0xc9, 0xdb, 0xf7, 0xaa, 0xc3, 0x70, 0x1a,
-	0xdb, 0xfa, 0xc0, 0xa4, 0xff, 0x98, 0x3a, 0x99, 0x3a, 0x99, 0x9a, 0xbc, 0x6d, 0x2f, 0xe6, 0xfb,
-	0xe1, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x13, 0xdc, 0xc8, 0x62, 0x39, 0x01, 0x00, 0x00,
+	// 254 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xbd, 0x4e, 0xc3, 0x30,
+	0x14, 0x85, 0xd5, 0x34, 0xe1, 0xc7, 0x0a, 0x0c, 0xa6, 0x83, 0xd9, 0x4a, 0x59, 0x32, 0x25, 0x03,
+	0x6f, 0xd0, 0x8d, 0x81, 0x25, 0x2c, 0xc0, 0x12, 0x35, 0xf1, 0x6d, 0x7a, 0x51, 0x9c, 0x6b, 0xf9,
+	0x26, 0x52, 0xcd, 0xc0, 0xb3, 0xa3, 0xc4, 0xa5, 0x82, 0xed, 0x9c, 0xcf, 0x9f, 0x7c, 0x2c, 0x8b,
+	0x95, 0x61, 0x5b, 0xa0, 0x86, 0x7e, 0xc0, 0x01, 0x81, 0x73, 0xeb, 0x68, 0x20, 0xb9, 0x34, 0x6c,
+	0x37, 0x9f, 0x42, 0xbe, 0x82, 0xc3, 0x5d, 0x87, 0x5f, 0xa0, 0x9f, 0x83, 0xe2, 0xe5, 0x4a, 0x24,
+	0x86, 0x2d, 0x6a, 0xb5, 0x58, 0x2f, 0xb2, 0xeb, 0x32, 0x14, 0x79, 0x2f, 0xae, 0x50, 0x57, 0xb5,
+	0x1f, 0x80, 0x55, 0xb4, 0x5e, 0x64, 0x69, 0x79, 0x89, 0x7a, 0x3b, 0x55, 0xf9, 0x28, 0x6e, 0xa8,
+	0x61, 0x5b, 0x39, 0x60, 0x4b, 0x3d, 0x83, 0x5a, 0xce, 0xe7, 0xe9, 0x04, 0xcb, 0x13, 0xdb, 0x7c,
+	0x0b, 0xf5, 0x6f, 0xcb, 0xe0, 0xf1, 0xbc, 0x78, 0x27, 0x92, 0xde, 0x9b, 0xea, 0x38, 0x2f, 0xa6,
+	0x65, 0xdc, 0x7b, 0xf3, 0xf6, 0x0b, 0xbd, 0x8a, 0xce, 0xf0, 0x5d, 0xde, 0x8a, 0x88, 0xc6, 0xd3,
+	0xfd, 0x11, 0x8d, 0x52, 0x8a, 0xd8, 0x51, 0x07, 0x2a, 0x0e, 0xce, 0x94, 0xa7, 0xf7, 0x5b, 0x47,
+	0xb4, 0x57, 0xc9, 0x0c, 0x43, 0xd9, 0xbe, 0x88, 0x07, 0x72, 0x6d, 0x7e, 0xf0, 0x16, 0x5c, 0x07,
+	0xba, 0x05, 0x97, 0xef, 0x77, 0xb5, 0xc3, 0x26, 0x7c, 0x08, 0xe7, 0x86, 0xed, 0x47, 0xd6, 0xe2,
+	0x70, 0x18, 0xeb, 0xbc, 0x21, 0x53, 0xfc, 0x31, 0x8b, 0x60, 0x16, 0xc1, 0x2c, 0x0c, 0xdb, 0xfa,
+	0x62, 0xce, 0x4f, 0x3f, 0x03, 0x00, 0x30, 0x8b, 0xe9, 0x01, 0x5e, 0x01, 0x00, 0x00,
 }
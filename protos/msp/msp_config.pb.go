@@ -94,6 +94,10 @@ type FabricMSPConfig struct {
 	// fabric_node_ous contains the configuration to distinguish clients from peers from orderers
 	// based on the OUs.
 	FabricNodeOus *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus" json:"fabric_node_ous,omitempty"`
+	// revocation_check configures the online checking of the revocation status
+	// of the certificates of identities, via OCSP and CRL distribution points.
+	// If nil, only the revocation_list is used
+	RevocationCheck *FabricRevocationCheckConfig `protobuf:"bytes,12,opt,name=revocation_check,json=revocationCheck" json:"revocation_check,omitempty"`
 }
 
 func (m *FabricMSPConfig) Reset()                    { *m = FabricMSPConfig{} }
@@ -178,6 +182,13 @@ func (m *FabricMSPConfig) GetFabricNodeOus() *FabricNodeOUs {
 	return nil
 }
 
+func (m *FabricMSPConfig) GetRevocationCheck() *FabricRevocationCheckConfig {
+	if m != nil {
+		return m.RevocationCheck
+	}
+	return nil
+}
+
 // FabricCryptoConfig contains configuration parameters
 // for the cryptographic algorithms used by the MSP
 // this configuration refers to
@@ -211,6 +222,67 @@ func (m *FabricCryptoConfig) GetIdentityIdentifierHashFunction() string {
 	return ""
 }
 
+// FabricRevocationCheckConfig contains the configuration of the online checking
+// of the revocation status of certificates. Since the outcome of online checks
+// depends on time and on the availability of the responders, the responders are
+// only contacted for endorsement, gossip and deliver requests, while transactions
+// are validated against the OCSP response stapled to the identity of their creator.
+type FabricRevocationCheckConfig struct {
+	// ocsp enables querying the OCSP responders listed in certificates
+	Ocsp bool `protobuf:"varint,1,opt,name=ocsp" json:"ocsp,omitempty"`
+	// crl_distribution_points enables fetching the CRLs listed in certificates
+	CrlDistributionPoints bool `protobuf:"varint,2,opt,name=crl_distribution_points,json=crlDistributionPoints" json:"crl_distribution_points,omitempty"`
+	// hard_fail makes an identity invalid if the revocation status of its
+	// certificate cannot be determined, or if its stapled OCSP response isn't
+	// valid at the time of a transaction, otherwise the identity is accepted
+	HardFail bool `protobuf:"varint,3,opt,name=hard_fail,json=hardFail" json:"hard_fail,omitempty"`
+	// cache_expiration is the maximum time, such as "10m", a revocation status
+	// is cached for, even if the responder states it is valid for longer
+	CacheExpiration string `protobuf:"bytes,4,opt,name=cache_expiration,json=cacheExpiration" json:"cache_expiration,omitempty"`
+	// timeout is the maximum time, such as "5s", spent contacting a responder
+	Timeout string `protobuf:"bytes,5,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *FabricRevocationCheckConfig) Reset()                    { *m = FabricRevocationCheckConfig{} }
+func (m *FabricRevocationCheckConfig) String() string            { return proto.CompactTextString(m) }
+func (*FabricRevocationCheckConfig) ProtoMessage()               {}
+func (*FabricRevocationCheckConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+func (m *FabricRevocationCheckConfig) GetOcsp() bool {
+	if m != nil {
+		return m.Ocsp
+	}
+	return false
+}
+
+func (m *FabricRevocationCheckConfig) GetCrlDistributionPoints() bool {
+	if m != nil {
+		return m.CrlDistributionPoints
+	}
+	return false
+}
+
+func (m *FabricRevocationCheckConfig) GetHardFail() bool {
+	if m != nil {
+		return m.HardFail
+	}
+	return false
+}
+
+func (m *FabricRevocationCheckConfig) GetCacheExpiration() string {
+	if m != nil {
+		return m.CacheExpiration
+	}
+	return ""
+}
+
+func (m *FabricRevocationCheckConfig) GetTimeout() string {
+	if m != nil {
+		return m.Timeout
+	}
+	return ""
+}
+
 // IdemixMSPConfig collects all the configuration information for
 // an Idemix MSP.
 type IdemixMSPConfig struct {
@@ -224,12 +296,16 @@ type IdemixMSPConfig struct {
 	RevocationPk []byte `protobuf:"bytes,4,opt,name=revocation_pk,json=revocationPk,proto3" json:"revocation_pk,omitempty"`
 	// epoch represents the current epoch (time interval) used for revocation
 	Epoch int64 `protobuf:"varint,5,opt,name=epoch" json:"epoch,omitempty"`
+	// credential_revocation_information contains the serialized CredentialRevocationInformation
+	// published by the revocation authority for the current epoch. When present, the default
+	// signer uses it to prove that its credential is not revoked
+	CredentialRevocationInformation []byte `protobuf:"bytes,6,opt,name=credential_revocation_information,json=credentialRevocationInformation,proto3" json:"credential_revocation_information,omitempty"`
 }
 
 func (m *IdemixMSPConfig) Reset()                    { *m = IdemixMSPConfig{} }
 func (m *IdemixMSPConfig) String() string            { return proto.CompactTextString(m) }
 func (*IdemixMSPConfig) ProtoMessage()               {}
-func (*IdemixMSPConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+func (*IdemixMSPConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 func (m *IdemixMSPConfig) GetName() string {
 	if m != nil {
@@ -266,6 +342,13 @@ func (m *IdemixMSPConfig) GetEpoch() int64 {
 	return 0
 }
 
+func (m *IdemixMSPConfig) GetCredentialRevocationInformation() []byte {
+	if m != nil {
+		return m.CredentialRevocationInformation
+	}
+	return nil
+}
+
 // IdemixMSPSIgnerConfig contains the crypto material to set up an idemix signing identity
 type IdemixMSPSignerConfig struct {
 	// cred represents the serialized idemix credential of the default signer
@@ -285,7 +368,7 @@ type IdemixMSPSignerConfig struct {
 func (m *IdemixMSPSignerConfig) Reset()                    { *m = IdemixMSPSignerConfig{} }
 func (m *IdemixMSPSignerConfig) String() string            { return proto.CompactTextString(m) }
 func (*IdemixMSPSignerConfig) ProtoMessage()               {}
-func (*IdemixMSPSignerConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+func (*IdemixMSPSignerConfig) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
 
 func (m *IdemixMSPSignerConfig) GetCred() []byte {
 	if m != nil {
@@ -345,7 +428,7 @@ type SigningIdentityInfo struct {
 func (m *SigningIdentityInfo) Reset()                    { *m = SigningIdentityInfo{} }
 func (m *SigningIdentityInfo) String() string            { return proto.CompactTextString(m) }
 func (*SigningIdentityInfo) ProtoMessage()               {}
-func (*SigningIdentityInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+func (*SigningIdentityInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
 
 func (m *SigningIdentityInfo) GetPublicSigner() []byte {
 	if m != nil {
@@ -378,7 +461,7 @@ type KeyInfo struct {
 func (m *KeyInfo) Reset()                    { *m = KeyInfo{} }
 func (m *KeyInfo) String() string            { return proto.CompactTextString(m) }
 func (*KeyInfo) ProtoMessage()               {}
-func (*KeyInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+func (*KeyInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
 
 func (m *KeyInfo) GetKeyIdentifier() string {
 	if m != nil {
@@ -413,7 +496,7 @@ type FabricOUIdentifier struct {
 func (m *FabricOUIdentifier) Reset()                    { *m = FabricOUIdentifier{} }
 func (m *FabricOUIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*FabricOUIdentifier) ProtoMessage()               {}
-func (*FabricOUIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+func (*FabricOUIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
 
 func (m *FabricOUIdentifier) GetCertificate() []byte {
 	if m != nil {
@@ -444,7 +527,7 @@ type FabricNodeOUs struct {
 func (m *FabricNodeOUs) Reset()                    { *m = FabricNodeOUs{} }
 func (m *FabricNodeOUs) String() string            { return proto.CompactTextString(m) }
 func (*FabricNodeOUs) ProtoMessage()               {}
-func (*FabricNodeOUs) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+func (*FabricNodeOUs) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
 
 func (m *FabricNodeOUs) GetEnable() bool {
 	if m != nil {
@@ -471,6 +554,7 @@ func init() {
 	proto.RegisterType((*MSPConfig)(nil), "msp.MSPConfig")
 	proto.RegisterType((*FabricMSPConfig)(nil), "msp.FabricMSPConfig")
 	proto.RegisterType((*FabricCryptoConfig)(nil), "msp.FabricCryptoConfig")
+	proto.RegisterType((*FabricRevocationCheckConfig)(nil), "msp.FabricRevocationCheckConfig")
 	proto.RegisterType((*IdemixMSPConfig)(nil), "msp.IdemixMSPConfig")
 	proto.RegisterType((*IdemixMSPSignerConfig)(nil), "msp.IdemixMSPSignerConfig")
 	proto.RegisterType((*SigningIdentityInfo)(nil), "msp.SigningIdentityInfo")
@@ -482,59 +566,67 @@ func init() {
 func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 854 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0x57, 0x92, 0x36, 0x6d, 0x26, 0x4e, 0x52, 0xf6, 0x7a, 0xc5, 0x20, 0xee, 0x2e, 0x35, 0x20,
-	0xf2, 0x42, 0x2a, 0xf5, 0x90, 0x90, 0x10, 0x2f, 0x5c, 0xe1, 0x84, 0x81, 0xd2, 0x6a, 0xab, 0xbe,
-	0xf0, 0x62, 0x6d, 0xec, 0x4d, 0xb2, 0xb2, 0xbd, 0x6b, 0xed, 0xae, 0x4f, 0x04, 0xf1, 0x15, 0x78,
-	0xe2, 0x3b, 0xf0, 0xcc, 0x2b, 0xdf, 0x0e, 0xed, 0x9f, 0xc6, 0xce, 0x5d, 0x15, 0x78, 0xdb, 0x99,
-	0xf9, 0xcd, 0xcf, 0xb3, 0xbf, 0x99, 0x59, 0xc3, 0x69, 0xa9, 0xaa, 0x8b, 0x52, 0x55, 0x49, 0x2a,
-	0xf8, 0x92, 0xad, 0xe6, 0x95, 0x14, 0x5a, 0xa0, 0x5e, 0xa9, 0xaa, 0xe8, 0x4b, 0x18, 0x5c, 0xdf,
-	0xdd, 0x5e, 0x59, 0x3f, 0x42, 0x70, 0xa0, 0x37, 0x15, 0x0d, 0x3b, 0xd3, 0xce, 0xec, 0x10, 0xdb,
-	0x33, 0x3a, 0x83, 0xbe, 0xcb, 0x0a, 0xbb, 0xd3, 0xce, 0x2c, 0xc0, 0xde, 0x8a, 0xfe, 0x3e, 0x80,
-	0xc9, 0x6b, 0xb2, 0x90, 0x2c, 0xdd, 0xc9, 0xe7, 0xa4, 0x74, 0xf9, 0x03, 0x6c, 0xcf, 0xe8, 0x19,
-	0x80, 0x14, 0x42, 0x27, 0x29, 0x95, 0x5a, 0x85, 0xdd, 0x69, 0x6f, 0x16, 0xe0, 0x81, 0xf1, 0x5c,
-	0x19, 0x07, 0xfa, 0x1c, 0x10, 0xe3, 0x9a, 0xca, 0x92, 0x66, 0x8c, 0x68, 0xea, 0x61, 0x3d, 0x0b,
-	0x7b, 0xaf, 0x1d, 0x71, 0xf0, 0x33, 0xe8, 0x93, 0xac, 0x64, 0x5c, 0x85, 0x07, 0x16, 0xe2, 0x2d,
-	0xf4, 0x19, 0x4c, 0x24, 0x7d, 0x23, 0x52, 0xa2, 0x99, 0xe0, 0x49, 0xc1, 0x94, 0x0e, 0x0f, 0x2d,
-	0x60, 0xdc, 0xb8, 0x7f, 0x62, 0x4a, 0xa3, 0x2b, 0x38, 0x51, 0x6c, 0xc5, 0x19, 0x5f, 0x25, 0x2c,
-	0xa3, 0x5c, 0x33, 0xbd, 0x09, 0xfb, 0xd3, 0xce, 0x6c, 0x78, 0x19, 0xce, 0x4b, 0x55, 0xcd, 0xef,
-	0x5c, 0x30, 0xf6, 0xb1, 0x98, 0x2f, 0x05, 0x9e, 0xa8, 0x5d, 0x27, 0x4a, 0xe0, 0x85, 0x90, 0x2b,
-	0xc2, 0xd9, 0x6f, 0x96, 0x98, 0x14, 0x49, 0xcd, 0x99, 0xf6, 0x84, 0x4b, 0x46, 0xa5, 0x0a, 0x8f,
-	0xa6, 0xbd, 0xd9, 0xf0, 0xf2, 0x7d, 0xcb, 0xe9, 0x64, 0xba, 0xb9, 0x8f, 0xb7, 0x71, 0xfc, 0x6c,
-	0x37, 0xff, 0x9e, 0x33, 0xdd, 0x44, 0x15, 0xfa, 0x1a, 0x46, 0xa9, 0xdc, 0x54, 0x5a, 0xf8, 0x8e,
-	0x85, 0xc7, 0xb6, 0xc4, 0x36, 0xdd, 0x95, 0x8d, 0x3b, 0xe1, 0x71, 0x90, 0xb6, 0x2c, 0xf4, 0x09,
-	0x8c, 0x75, 0xa1, 0x92, 0x96, 0xec, 0x03, 0xab, 0x45, 0xa0, 0x0b, 0x85, 0xb7, 0xca, 0x7f, 0x01,
-	0x67, 0x06, 0xf5, 0x88, 0xfa, 0x60, 0xd1, 0xa7, 0xba, 0x50, 0xf1, 0x3b, 0x0d, 0xf8, 0x0a, 0x26,
-	0x4b, 0xfb, 0xfd, 0x84, 0x8b, 0x8c, 0x26, 0xa2, 0x56, 0xe1, 0xd0, 0xd6, 0x86, 0x5a, 0xb5, 0xfd,
-	0x2c, 0x32, 0x7a, 0x73, 0xaf, 0xf0, 0x68, 0xd9, 0x98, 0xb5, 0x8a, 0xfe, 0xec, 0x00, 0x7a, 0xb7,
-	0x78, 0x74, 0x09, 0x4f, 0x8d, 0xc0, 0x44, 0xd7, 0x92, 0x26, 0x6b, 0xa2, 0xd6, 0xc9, 0x92, 0x94,
-	0xac, 0xd8, 0xf8, 0x31, 0x7a, 0xb2, 0x0d, 0x7e, 0x4f, 0xd4, 0xfa, 0xb5, 0x0d, 0xa1, 0x18, 0xce,
-	0x1f, 0xda, 0xd7, 0x92, 0xdd, 0x67, 0xd7, 0x3c, 0x35, 0xb2, 0xda, 0x81, 0x1d, 0xe0, 0xe7, 0x0f,
-	0xc0, 0x46, 0x60, 0x4b, 0xe4, 0x51, 0xd1, 0x5f, 0x1d, 0x98, 0xc4, 0x19, 0x2d, 0xd9, 0xaf, 0xfb,
-	0x07, 0xf9, 0x04, 0x7a, 0xac, 0xca, 0xfd, 0x16, 0x98, 0x23, 0xba, 0x84, 0xbe, 0xa9, 0x8d, 0xca,
-	0xb0, 0x67, 0x25, 0xf8, 0xd0, 0x4a, 0xb0, 0xe5, 0xba, 0xb3, 0x31, 0xdf, 0x21, 0x8f, 0x44, 0x1f,
-	0xc3, 0xa8, 0x35, 0xa8, 0x55, 0x1e, 0x1e, 0x58, 0xbe, 0xa0, 0x71, 0xde, 0xe6, 0xe8, 0x14, 0x0e,
-	0x69, 0x25, 0xd2, 0x75, 0x78, 0x38, 0xed, 0xcc, 0x7a, 0xd8, 0x19, 0xd1, 0x1f, 0x5d, 0x78, 0xfa,
-	0x28, 0xb9, 0x29, 0x37, 0x95, 0x34, 0xb3, 0xe5, 0x06, 0xd8, 0x9e, 0xd1, 0x18, 0xba, 0xea, 0xa1,
-	0xda, 0xae, 0xca, 0xd1, 0xb7, 0xf0, 0x7c, 0xff, 0xcc, 0xda, 0x4b, 0x0c, 0xf0, 0x47, 0xfb, 0x26,
-	0x13, 0x7d, 0x00, 0xc7, 0x4c, 0x25, 0x76, 0xe9, 0x6c, 0xe5, 0xc7, 0xf8, 0x88, 0xa9, 0x6f, 0x8c,
-	0x69, 0x6e, 0x46, 0xb9, 0x14, 0x45, 0x51, 0x52, 0x6e, 0x78, 0x6d, 0xf1, 0x03, 0x1c, 0x34, 0xce,
-	0x38, 0x43, 0x3f, 0xc0, 0xb9, 0xa9, 0xce, 0xf0, 0x91, 0x22, 0x69, 0x29, 0xc1, 0xf8, 0x52, 0xc8,
-	0xd2, 0x9e, 0xed, 0x3e, 0x06, 0xf8, 0x45, 0x03, 0xc4, 0x5b, 0x5c, 0xdc, 0xc0, 0x22, 0x01, 0x4f,
-	0x1e, 0xd9, 0x56, 0x53, 0x47, 0x55, 0x2f, 0x0a, 0x96, 0x26, 0xbe, 0x39, 0x4e, 0x95, 0xc0, 0x39,
-	0x9d, 0x6e, 0xe8, 0x25, 0x8c, 0x2b, 0xc9, 0xde, 0x98, 0x99, 0xf7, 0xa8, 0xae, 0x6d, 0x61, 0x60,
-	0x5b, 0xf8, 0x23, 0x75, 0x8b, 0x3f, 0xf2, 0x18, 0x97, 0x14, 0xdd, 0xc1, 0x91, 0x8f, 0xa0, 0x4f,
-	0x61, 0x9c, 0xd3, 0xf6, 0xe8, 0xf9, 0x51, 0x19, 0xe5, 0xb4, 0x35, 0x67, 0xe8, 0x1c, 0x02, 0x03,
-	0x2b, 0x89, 0xa6, 0x92, 0x91, 0xc2, 0xb7, 0x63, 0x98, 0xd3, 0xcd, 0xb5, 0x77, 0x45, 0xbf, 0x3f,
-	0xec, 0x44, 0xfb, 0x7d, 0x40, 0x53, 0x18, 0x9a, 0x5d, 0x64, 0x4b, 0x96, 0x12, 0x4d, 0xfd, 0x15,
-	0xda, 0xae, 0xff, 0xd1, 0xcf, 0xee, 0x7f, 0xf7, 0x33, 0xfa, 0xa7, 0x03, 0xa3, 0x9d, 0x9d, 0x35,
-	0x2f, 0x2c, 0xe5, 0x64, 0x51, 0xb8, 0x8f, 0x1e, 0x63, 0x6f, 0xa1, 0x18, 0x4e, 0xd3, 0x82, 0x99,
-	0xd6, 0x8a, 0xfa, 0xed, 0xaf, 0xec, 0x79, 0xe8, 0x90, 0x4b, 0xba, 0xa9, 0x5b, 0x97, 0xfb, 0x0e,
-	0x50, 0x45, 0xa9, 0x7c, 0x8b, 0xa8, 0xb7, 0x9f, 0xe8, 0xc4, 0xa4, 0xb4, 0x69, 0x5e, 0x25, 0x70,
-	0x2e, 0xe4, 0x6a, 0xbe, 0xde, 0x54, 0x54, 0x16, 0x34, 0x5b, 0x51, 0x39, 0x77, 0xef, 0x8d, 0xfb,
-	0xbf, 0x29, 0xc3, 0xf4, 0xea, 0xe4, 0x5a, 0x55, 0x6e, 0x4b, 0x6e, 0x49, 0x9a, 0x93, 0x15, 0xfd,
-	0x65, 0xb6, 0x62, 0x7a, 0x5d, 0x2f, 0xe6, 0xa9, 0x28, 0x2f, 0x5a, 0xb9, 0x17, 0x2e, 0xf7, 0xc2,
-	0xe5, 0x9a, 0xbf, 0xe5, 0xa2, 0x6f, 0xcf, 0x2f, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x1a, 0x91,
-	0xb8, 0x49, 0x3f, 0x07, 0x00, 0x00,
+	// 977 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0x96, 0xed, 0xc4, 0x89, 0xcb, 0xe3, 0x1f, 0x7a, 0x93, 0xec, 0xc0, 0xb2, 0xbb, 0x8e, 0x01,
+	0x61, 0x0e, 0x38, 0x92, 0x17, 0x81, 0x84, 0xb8, 0xb0, 0xde, 0x8d, 0x30, 0x4b, 0x48, 0x34, 0x51,
+	0x2e, 0x5c, 0x46, 0xed, 0x9e, 0xb6, 0xdd, 0xf2, 0xcc, 0xf4, 0xa8, 0xbb, 0x67, 0xb5, 0x46, 0xbc,
+	0x02, 0x27, 0x9e, 0x86, 0x67, 0xe0, 0x6d, 0xb8, 0x23, 0xa1, 0xae, 0xe9, 0xd8, 0xe3, 0x24, 0x32,
+	0x1c, 0xf6, 0xd6, 0x55, 0xf5, 0x55, 0x4d, 0xd5, 0xf7, 0x75, 0x97, 0x0d, 0x47, 0x89, 0xce, 0xce,
+	0x12, 0x9d, 0x85, 0x4c, 0xa6, 0x33, 0x31, 0x1f, 0x66, 0x4a, 0x1a, 0x49, 0x6a, 0x89, 0xce, 0xfa,
+	0xdf, 0x40, 0xe3, 0xe2, 0xfa, 0x6a, 0x8c, 0x7e, 0x42, 0x60, 0xcf, 0xac, 0x32, 0xee, 0x57, 0x7a,
+	0x95, 0xc1, 0x7e, 0x80, 0x67, 0x72, 0x02, 0xf5, 0x22, 0xcb, 0xaf, 0xf6, 0x2a, 0x03, 0x2f, 0x70,
+	0x56, 0xff, 0x9f, 0x3d, 0xe8, 0x9c, 0xd3, 0xa9, 0x12, 0x6c, 0x2b, 0x3f, 0xa5, 0x49, 0x91, 0xdf,
+	0x08, 0xf0, 0x4c, 0x9e, 0x02, 0x28, 0x29, 0x4d, 0xc8, 0xb8, 0x32, 0xda, 0xaf, 0xf6, 0x6a, 0x03,
+	0x2f, 0x68, 0x58, 0xcf, 0xd8, 0x3a, 0xc8, 0x97, 0x40, 0x44, 0x6a, 0xb8, 0x4a, 0x78, 0x24, 0xa8,
+	0xe1, 0x0e, 0x56, 0x43, 0xd8, 0x07, 0xe5, 0x48, 0x01, 0x3f, 0x81, 0x3a, 0x8d, 0x12, 0x91, 0x6a,
+	0x7f, 0x0f, 0x21, 0xce, 0x22, 0x9f, 0x43, 0x47, 0xf1, 0xb7, 0x92, 0x51, 0x23, 0x64, 0x1a, 0xc6,
+	0x42, 0x1b, 0x7f, 0x1f, 0x01, 0xed, 0x8d, 0xfb, 0x27, 0xa1, 0x0d, 0x19, 0x43, 0x57, 0x8b, 0x79,
+	0x2a, 0xd2, 0x79, 0x28, 0x22, 0x9e, 0x1a, 0x61, 0x56, 0x7e, 0xbd, 0x57, 0x19, 0x34, 0x47, 0xfe,
+	0x30, 0xd1, 0xd9, 0xf0, 0xba, 0x08, 0x4e, 0x5c, 0x6c, 0x92, 0xce, 0x64, 0xd0, 0xd1, 0xdb, 0x4e,
+	0x12, 0xc2, 0x73, 0xa9, 0xe6, 0x34, 0x15, 0xbf, 0x62, 0x61, 0x1a, 0x87, 0x79, 0x2a, 0x8c, 0x2b,
+	0x38, 0x13, 0x5c, 0x69, 0xff, 0xa0, 0x57, 0x1b, 0x34, 0x47, 0x8f, 0xb1, 0x66, 0x41, 0xd3, 0xe5,
+	0xcd, 0x64, 0x1d, 0x0f, 0x9e, 0x6e, 0xe7, 0xdf, 0xa4, 0xc2, 0x6c, 0xa2, 0x9a, 0x7c, 0x07, 0x2d,
+	0xa6, 0x56, 0x99, 0x91, 0x4e, 0x31, 0xff, 0xb0, 0x57, 0xb9, 0x53, 0x6e, 0x8c, 0xf1, 0x82, 0xf8,
+	0xc0, 0x63, 0x25, 0x8b, 0x7c, 0x0a, 0x6d, 0x13, 0xeb, 0xb0, 0x44, 0x7b, 0x03, 0xb9, 0xf0, 0x4c,
+	0xac, 0x83, 0x35, 0xf3, 0x5f, 0xc1, 0x89, 0x45, 0x3d, 0xc0, 0x3e, 0x20, 0xfa, 0xc8, 0xc4, 0x7a,
+	0x72, 0x4f, 0x80, 0x6f, 0xa1, 0x33, 0xc3, 0xef, 0x87, 0xa9, 0x8c, 0x78, 0x28, 0x73, 0xed, 0x37,
+	0xb1, 0x37, 0x52, 0xea, 0xed, 0x67, 0x19, 0xf1, 0xcb, 0x1b, 0x1d, 0xb4, 0x66, 0x1b, 0x33, 0xd7,
+	0xe4, 0x0d, 0x74, 0x4b, 0x22, 0xb1, 0x05, 0x67, 0x4b, 0xdf, 0xc3, 0xe4, 0x5e, 0x29, 0x39, 0x58,
+	0x43, 0xc6, 0x16, 0xe1, 0x26, 0xec, 0xa8, 0x6d, 0x77, 0xff, 0x8f, 0x0a, 0x90, 0xfb, 0x4c, 0x90,
+	0x11, 0x1c, 0x5b, 0xb5, 0xa8, 0xc9, 0x15, 0x0f, 0x17, 0x54, 0x2f, 0xc2, 0x19, 0x4d, 0x44, 0xbc,
+	0x72, 0x77, 0xf2, 0xd1, 0x3a, 0xf8, 0x03, 0xd5, 0x8b, 0x73, 0x0c, 0x91, 0x09, 0x9c, 0xde, 0xde,
+	0x85, 0x92, 0x86, 0x2e, 0x3b, 0x4f, 0x99, 0xfd, 0x28, 0xde, 0xfe, 0x46, 0xf0, 0xec, 0x16, 0xb8,
+	0x51, 0x0b, 0x0b, 0x39, 0x54, 0xff, 0xaf, 0x0a, 0x3c, 0xd9, 0x31, 0x86, 0x7d, 0x21, 0x92, 0xe9,
+	0x0c, 0xbb, 0x39, 0x0c, 0xf0, 0x4c, 0xbe, 0x86, 0xc7, 0x4c, 0xc5, 0x61, 0x24, 0xb4, 0x51, 0x62,
+	0x9a, 0x23, 0x39, 0x99, 0x14, 0x29, 0x3e, 0x17, 0x0b, 0x3b, 0x66, 0x2a, 0x7e, 0x55, 0x8a, 0x5e,
+	0x61, 0x90, 0x3c, 0x81, 0xc6, 0x82, 0xaa, 0x28, 0x9c, 0x51, 0x11, 0xfb, 0x35, 0x44, 0x1e, 0x5a,
+	0xc7, 0x39, 0x15, 0x31, 0xf9, 0x02, 0xba, 0x8c, 0xb2, 0x05, 0x0f, 0xf9, 0xbb, 0x4c, 0x28, 0xec,
+	0xc3, 0xdf, 0xc3, 0x11, 0x3a, 0xe8, 0x7f, 0xbd, 0x76, 0x13, 0x1f, 0x0e, 0x8c, 0x48, 0xb8, 0xcc,
+	0xed, 0x9b, 0xb1, 0x88, 0x5b, 0xb3, 0xff, 0x77, 0x05, 0x3a, 0x93, 0x88, 0x27, 0xe2, 0xdd, 0xee,
+	0x37, 0xde, 0x85, 0x9a, 0xc8, 0x96, 0x6e, 0x41, 0xd8, 0x23, 0x19, 0x41, 0xdd, 0x32, 0xcd, 0x15,
+	0x36, 0xd6, 0x1c, 0x7d, 0x84, 0x02, 0xaf, 0x6b, 0x5d, 0x63, 0xcc, 0x49, 0xeb, 0x90, 0xe4, 0x13,
+	0x68, 0x95, 0xae, 0x47, 0xb6, 0xc4, 0x7e, 0xbd, 0xc0, 0xdb, 0x38, 0xaf, 0x96, 0xe4, 0x08, 0xf6,
+	0x79, 0x26, 0xd9, 0x02, 0x5b, 0xad, 0x05, 0x85, 0x41, 0x7e, 0x84, 0x53, 0xa6, 0x38, 0x4a, 0x42,
+	0xe3, 0xb0, 0x54, 0x45, 0xa4, 0x33, 0xa9, 0x92, 0x62, 0xfc, 0x3a, 0x96, 0x7b, 0xbe, 0x01, 0x6e,
+	0x24, 0x9a, 0x6c, 0x60, 0xfd, 0xdf, 0xab, 0x70, 0xfc, 0x60, 0xa3, 0x76, 0x74, 0x9b, 0x8c, 0xa3,
+	0x7b, 0x01, 0x9e, 0x49, 0x1b, 0xaa, 0xfa, 0x76, 0xf2, 0xaa, 0x5e, 0x92, 0x57, 0xf0, 0x6c, 0xf7,
+	0x6a, 0x40, 0x42, 0x1a, 0xc1, 0xc7, 0xbb, 0x16, 0x00, 0xf9, 0x10, 0x0e, 0x85, 0x0e, 0x71, 0xb7,
+	0x21, 0x0b, 0x87, 0xc1, 0x81, 0xd0, 0xdf, 0x5b, 0xd3, 0xb2, 0xc4, 0x53, 0x25, 0xe3, 0x38, 0xe1,
+	0xa9, 0xad, 0xeb, 0x34, 0xf3, 0x36, 0xce, 0x49, 0xf4, 0x5e, 0xf9, 0x90, 0xf0, 0xe8, 0x81, 0xa5,
+	0x68, 0xfb, 0xc8, 0xf2, 0x69, 0x2c, 0x58, 0xe8, 0x84, 0x2e, 0x58, 0xf1, 0x0a, 0x67, 0xc1, 0x1b,
+	0x79, 0x01, 0xed, 0x4c, 0x89, 0xb7, 0x76, 0xb5, 0x38, 0x54, 0x15, 0xaf, 0x83, 0x87, 0xd7, 0xe1,
+	0x0d, 0x2f, 0xf6, 0x6b, 0xcb, 0x61, 0x8a, 0xa4, 0xfe, 0x35, 0x1c, 0xb8, 0x08, 0xf9, 0x0c, 0xda,
+	0x4b, 0x5e, 0x7e, 0x94, 0xee, 0xda, 0xb5, 0x96, 0xbc, 0xf4, 0x02, 0xc9, 0x29, 0x78, 0x16, 0x96,
+	0x50, 0xc3, 0x95, 0xa0, 0xb1, 0x93, 0xa3, 0xb9, 0xe4, 0xab, 0x0b, 0xe7, 0xea, 0xff, 0x06, 0xe4,
+	0xfe, 0x1a, 0x26, 0x3d, 0x68, 0xda, 0x95, 0x27, 0x66, 0x82, 0x51, 0xc3, 0xdd, 0x08, 0x65, 0xd7,
+	0xff, 0xd0, 0xb3, 0xfa, 0xdf, 0x7a, 0xf6, 0xff, 0xac, 0x40, 0x6b, 0x6b, 0x35, 0xda, 0x1f, 0x32,
+	0x9e, 0xd2, 0x69, 0xcc, 0xdd, 0x2a, 0x70, 0x16, 0x99, 0xc0, 0x11, 0x8b, 0x85, 0x95, 0x56, 0xe6,
+	0x77, 0xbf, 0xb2, 0xe3, 0xf7, 0x84, 0x14, 0x49, 0x97, 0x79, 0x69, 0xb8, 0xd7, 0x40, 0x32, 0xce,
+	0xd5, 0x9d, 0x42, 0xb5, 0xdd, 0x85, 0xba, 0x36, 0xa5, 0x5c, 0xe6, 0x65, 0x08, 0xa7, 0x52, 0xcd,
+	0x87, 0x8b, 0x55, 0xc6, 0x55, 0xcc, 0xa3, 0x39, 0x57, 0xc3, 0x62, 0xad, 0x17, 0x7f, 0x23, 0xb4,
+	0xad, 0xf4, 0xb2, 0x7b, 0xa1, 0xb3, 0xe2, 0x95, 0x5c, 0x51, 0xb6, 0xa4, 0x73, 0xfe, 0xcb, 0x60,
+	0x2e, 0xcc, 0x22, 0x9f, 0x0e, 0x99, 0x4c, 0xce, 0x4a, 0xb9, 0x67, 0x45, 0xee, 0x59, 0x91, 0x6b,
+	0xff, 0x94, 0x4c, 0xeb, 0x78, 0x7e, 0xf1, 0xef, 0x00, 0xf1, 0x5b, 0x93, 0x96, 0xa6, 0x08, 0x00,
+	0x00,
 }
@@ -35,6 +35,8 @@ func (mp *MSPPrincipal) VariablyOpaqueFieldProto(name string) (proto.Message, er
 		return &MSPRole{}, nil
 	case MSPPrincipal_ORGANIZATION_UNIT:
 		return &OrganizationUnit{}, nil
+	case MSPPrincipal_ATTRIBUTES:
+		return &MSPAttributes{}, nil
 	case MSPPrincipal_IDENTITY:
 		return nil, fmt.Errorf("unable to decode MSP type IDENTITY until the protos are fixed to include the IDENTITY proto in protos/msp")
 	default:
@@ -26,7 +26,8 @@ const (
 	// identity
 	MSPPrincipal_ANONYMITY MSPPrincipal_Classification = 3
 	// an identity to be anonymous or nominal.
-	MSPPrincipal_COMBINED MSPPrincipal_Classification = 4
+	MSPPrincipal_COMBINED   MSPPrincipal_Classification = 4
+	MSPPrincipal_ATTRIBUTES MSPPrincipal_Classification = 5
 )
 
 var MSPPrincipal_Classification_name = map[int32]string{
@@ -35,6 +36,7 @@ var MSPPrincipal_Classification_name = map[int32]string{
 	2: "IDENTITY",
 	3: "ANONYMITY",
 	4: "COMBINED",
+	5: "ATTRIBUTES",
 }
 var MSPPrincipal_Classification_value = map[string]int32{
 	"ROLE":              0,
@@ -42,6 +44,7 @@ var MSPPrincipal_Classification_value = map[string]int32{
 	"IDENTITY":          2,
 	"ANONYMITY":         3,
 	"COMBINED":          4,
+	"ATTRIBUTES":        5,
 }
 
 func (x MSPPrincipal_Classification) String() string {
@@ -135,6 +138,8 @@ type MSPPrincipal struct {
 	// identity, respectively.
 	// For the Combined Classification type, the Principal is a marshalled
 	// CombinedPrincipal.
+	// For the Attributes Classification type, the Principal is a marshalled
+	// MSPAttributes.
 	Principal []byte `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
 }
 
@@ -266,12 +271,73 @@ func (m *CombinedPrincipal) GetPrincipals() []*MSPPrincipal {
 	return nil
 }
 
+// MSPAttributes governs the organization of the Principal
+// field of a policy principal when principal_classification has
+// indicated that the identities of an MSP having a set of attributes
+// are to be defined within a policy principal. The attributes are the
+// ones an identity carries in the attribute extension of its certificate
+// issued by the Fabric CA.
+type MSPAttributes struct {
+	// MSPIdentifier represents the identifier of the MSP this principal
+	// refers to
+	MspIdentifier string `protobuf:"bytes,1,opt,name=msp_identifier,json=mspIdentifier" json:"msp_identifier,omitempty"`
+	// Attributes are the attributes an identity should possess, all
+	// of them with the given values
+	Attributes []*MSPAttributes_Attribute `protobuf:"bytes,2,rep,name=attributes" json:"attributes,omitempty"`
+}
+
+func (m *MSPAttributes) Reset()                    { *m = MSPAttributes{} }
+func (m *MSPAttributes) String() string            { return proto.CompactTextString(m) }
+func (*MSPAttributes) ProtoMessage()               {}
+func (*MSPAttributes) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5} }
+
+func (m *MSPAttributes) GetMspIdentifier() string {
+	if m != nil {
+		return m.MspIdentifier
+	}
+	return ""
+}
+
+func (m *MSPAttributes) GetAttributes() []*MSPAttributes_Attribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+// Attribute is a name-value pair an identity should possess
+type MSPAttributes_Attribute struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *MSPAttributes_Attribute) Reset()                    { *m = MSPAttributes_Attribute{} }
+func (m *MSPAttributes_Attribute) String() string            { return proto.CompactTextString(m) }
+func (*MSPAttributes_Attribute) ProtoMessage()               {}
+func (*MSPAttributes_Attribute) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{5, 0} }
+
+func (m *MSPAttributes_Attribute) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MSPAttributes_Attribute) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*MSPPrincipal)(nil), "common.MSPPrincipal")
 	proto.RegisterType((*OrganizationUnit)(nil), "common.OrganizationUnit")
 	proto.RegisterType((*MSPRole)(nil), "common.MSPRole")
 	proto.RegisterType((*MSPIdentityAnonymity)(nil), "common.MSPIdentityAnonymity")
 	proto.RegisterType((*CombinedPrincipal)(nil), "common.CombinedPrincipal")
+	proto.RegisterType((*MSPAttributes)(nil), "common.MSPAttributes")
+	proto.RegisterType((*MSPAttributes_Attribute)(nil), "common.MSPAttributes.Attribute")
 	proto.RegisterEnum("common.MSPPrincipal_Classification", MSPPrincipal_Classification_name, MSPPrincipal_Classification_value)
 	proto.RegisterEnum("common.MSPRole_MSPRoleType", MSPRole_MSPRoleType_name, MSPRole_MSPRoleType_value)
 	proto.RegisterEnum("common.MSPIdentityAnonymity_MSPIdentityAnonymityType", MSPIdentityAnonymity_MSPIdentityAnonymityType_name, MSPIdentityAnonymity_MSPIdentityAnonymityType_value)
@@ -280,38 +346,42 @@ func init() {
 func init() { proto.RegisterFile("msp/msp_principal.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 519 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xdf, 0x6a, 0xdb, 0x30,
-	0x14, 0xc6, 0xeb, 0xa4, 0x6b, 0x9b, 0x93, 0x3f, 0xa8, 0x22, 0xa5, 0x81, 0x95, 0x11, 0xbc, 0x0d,
-	0x72, 0xe5, 0x40, 0xba, 0xed, 0x62, 0x77, 0x4e, 0x62, 0x86, 0x20, 0x96, 0x8d, 0xe3, 0x5c, 0xb4,
-	0x94, 0x05, 0xc7, 0x51, 0x52, 0x81, 0x6d, 0x19, 0xdb, 0xbd, 0xf0, 0xde, 0x65, 0x6f, 0xb0, 0xcb,
-	0x3d, 0xd5, 0x9e, 0x62, 0xd8, 0x6e, 0x12, 0x65, 0xeb, 0x60, 0x57, 0xf6, 0x39, 0xe7, 0xf7, 0x1d,
-	0x1d, 0x49, 0x9f, 0xe0, 0x3a, 0x4c, 0xe3, 0x61, 0x98, 0xc6, 0xcb, 0x38, 0xe1, 0x91, 0xcf, 0x63,
-	0x2f, 0xd0, 0xe2, 0x44, 0x64, 0x02, 0x9f, 0xf9, 0x22, 0x0c, 0x45, 0xa4, 0xfe, 0x52, 0xa0, 0x65,
-	0xce, 0x6d, 0x7b, 0x57, 0xc6, 0x5f, 0xa1, 0xb7, 0x67, 0x97, 0x7e, 0xe0, 0xa5, 0x29, 0xdf, 0x70,
-	0xdf, 0xcb, 0xb8, 0x88, 0x7a, 0x4a, 0x5f, 0x19, 0x74, 0x46, 0x6f, 0xb5, 0x4a, 0xab, 0xc9, 0x3a,
-	0x6d, 0x72, 0x84, 0x3a, 0xd7, 0xfb, 0x26, 0xc7, 0x05, 0x7c, 0x03, 0x8d, 0x7d, 0xa9, 0x57, 0xeb,
-	0x2b, 0x83, 0x96, 0x73, 0x48, 0xa8, 0x0f, 0xd0, 0xf9, 0x83, 0xbf, 0x80, 0x53, 0xc7, 0x9a, 0x19,
-	0xe8, 0x04, 0x5f, 0xc1, 0xa5, 0xe5, 0x7c, 0xd1, 0x29, 0xb9, 0xd7, 0x5d, 0x62, 0xd1, 0xe5, 0x82,
-	0x12, 0x17, 0x29, 0xb8, 0x05, 0x17, 0x64, 0x6a, 0x50, 0x97, 0xb8, 0x77, 0xa8, 0x86, 0xdb, 0xd0,
-	0xd0, 0xa9, 0x45, 0xef, 0xcc, 0x22, 0xac, 0x17, 0xc5, 0x89, 0x65, 0x8e, 0x09, 0x35, 0xa6, 0xe8,
-	0x54, 0xfd, 0xa9, 0x00, 0xb2, 0x92, 0xad, 0x17, 0xf1, 0x6f, 0x65, 0xf3, 0x45, 0xc4, 0x33, 0xfc,
-	0x1e, 0x3a, 0xc5, 0x01, 0xf1, 0x35, 0x8b, 0x32, 0xbe, 0xe1, 0x2c, 0x29, 0xb7, 0xd9, 0x70, 0xda,
-	0x61, 0x1a, 0x93, 0x7d, 0x12, 0x4f, 0xe1, 0x8d, 0x90, 0xa4, 0x5e, 0xb0, 0x7c, 0x8a, 0x78, 0x26,
-	0xcb, 0x6a, 0xa5, 0xec, 0xe6, 0x98, 0x2a, 0x96, 0x90, 0xba, 0xdc, 0xc2, 0x95, 0xcf, 0x92, 0x2a,
-	0x48, 0x65, 0x71, 0xbd, 0x3c, 0x89, 0xee, 0xa1, 0x78, 0x10, 0xa9, 0xdf, 0x15, 0x38, 0x37, 0xe7,
-	0xb6, 0x23, 0x02, 0xf6, 0xbf, 0xd3, 0x0e, 0xe1, 0x34, 0x11, 0x01, 0x2b, 0x67, 0xea, 0x8c, 0x5e,
-	0x4b, 0x37, 0x56, 0x74, 0xd9, 0x7d, 0xdd, 0x3c, 0x66, 0x4e, 0x09, 0xaa, 0x9f, 0xa1, 0x29, 0x25,
-	0x31, 0xc0, 0x99, 0x69, 0x98, 0x63, 0xc3, 0x41, 0x27, 0xb8, 0x01, 0xaf, 0xf4, 0xa9, 0x49, 0x28,
-	0x52, 0x8a, 0xf4, 0x64, 0x46, 0x0c, 0xea, 0xa2, 0x5a, 0x71, 0x31, 0xb6, 0x61, 0x38, 0xa8, 0xae,
-	0xfe, 0x50, 0xa0, 0x6b, 0xce, 0xed, 0x6a, 0xf9, 0x2c, 0xd7, 0x23, 0x11, 0xe5, 0x21, 0xcf, 0x72,
-	0xfc, 0x00, 0x1d, 0x6f, 0x17, 0x2c, 0xb3, 0x3c, 0x66, 0xcf, 0x0e, 0xfa, 0x28, 0xcd, 0xf3, 0x97,
-	0xea, 0xc5, 0x64, 0x39, 0x69, 0xdb, 0x93, 0x43, 0xf5, 0x13, 0xf4, 0xfe, 0x85, 0xe2, 0x26, 0x9c,
-	0x53, 0xcb, 0x24, 0x54, 0x9f, 0xa1, 0x93, 0x83, 0x27, 0xac, 0xc5, 0x1c, 0x29, 0x2a, 0x81, 0xcb,
-	0x89, 0x08, 0x57, 0x3c, 0x62, 0xeb, 0x83, 0xed, 0x3f, 0x00, 0xec, 0x5d, 0x98, 0xf6, 0x94, 0x7e,
-	0x7d, 0xd0, 0x1c, 0x75, 0x5f, 0x32, 0xba, 0x23, 0x71, 0x63, 0x1b, 0xde, 0x89, 0x64, 0xab, 0x3d,
-	0xe6, 0x31, 0x4b, 0x02, 0xb6, 0xde, 0xb2, 0x44, 0xdb, 0x78, 0xab, 0x84, 0xfb, 0xd5, 0x2b, 0x4b,
-	0x9f, 0x1b, 0xdc, 0x0f, 0xb6, 0x3c, 0x7b, 0x7c, 0x5a, 0x15, 0xe1, 0x50, 0x82, 0x87, 0x15, 0x3c,
-	0xac, 0xe0, 0xe2, 0x9d, 0xae, 0xce, 0xca, 0xff, 0xdb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x40,
-	0x36, 0xd2, 0xf9, 0xb9, 0x03, 0x00, 0x00,
+	// 591 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6a, 0xdb, 0x4c,
+	0x14, 0xcd, 0xd8, 0xce, 0x8f, 0x6f, 0x62, 0x33, 0x19, 0x1c, 0x3e, 0xf3, 0x35, 0xa4, 0x41, 0x6d,
+	0xc1, 0x2b, 0x19, 0x92, 0xa6, 0x8b, 0x6e, 0x8a, 0x6c, 0x8b, 0x32, 0x10, 0xfd, 0x30, 0x96, 0x17,
+	0x09, 0xa5, 0x46, 0x76, 0x26, 0xce, 0x80, 0xfe, 0x90, 0xc6, 0x05, 0xf7, 0x5d, 0xfa, 0x06, 0xa5,
+	0xab, 0x3e, 0x5d, 0x57, 0x45, 0xb2, 0x23, 0x8d, 0xdb, 0x14, 0xb2, 0x10, 0x9a, 0x7b, 0xcf, 0x39,
+	0xf7, 0x5c, 0xcd, 0x5c, 0x0d, 0x9c, 0x25, 0x69, 0x2c, 0xe3, 0xac, 0x1f, 0x66, 0x49, 0xfe, 0x4c,
+	0x93, 0x54, 0x44, 0x73, 0x91, 0xf8, 0x81, 0x5e, 0x00, 0x64, 0x6f, 0x1e, 0x87, 0x61, 0x1c, 0x69,
+	0xbf, 0x10, 0x1c, 0x59, 0x63, 0xd7, 0x7d, 0x84, 0xc9, 0x67, 0xe8, 0x96, 0xdc, 0xe9, 0x3c, 0xf0,
+	0xb3, 0x4c, 0xdc, 0x8b, 0xb9, 0x2f, 0x45, 0x1c, 0x75, 0xd1, 0x39, 0xea, 0xb5, 0x2f, 0x5e, 0xe9,
+	0x6b, 0xad, 0xae, 0xea, 0xf4, 0xe1, 0x16, 0x95, 0xfd, 0x57, 0x16, 0xd9, 0x06, 0xc8, 0x29, 0x34,
+	0x4b, 0xa8, 0x5b, 0x3b, 0x47, 0xbd, 0x23, 0x56, 0x25, 0xb4, 0x00, 0xda, 0x7f, 0xf0, 0x0f, 0xa0,
+	0xc1, 0x9c, 0x6b, 0x13, 0xef, 0x90, 0x13, 0x38, 0x76, 0xd8, 0x47, 0xc3, 0xa6, 0xb7, 0x86, 0x47,
+	0x1d, 0x7b, 0x3a, 0xb1, 0xa9, 0x87, 0x11, 0x39, 0x82, 0x03, 0x3a, 0x32, 0x6d, 0x8f, 0x7a, 0x37,
+	0xb8, 0x46, 0x5a, 0xd0, 0x34, 0x6c, 0xc7, 0xbe, 0xb1, 0xf2, 0xb0, 0x9e, 0x83, 0x43, 0xc7, 0x1a,
+	0x50, 0xdb, 0x1c, 0xe1, 0x06, 0x69, 0x03, 0x18, 0x9e, 0xc7, 0xe8, 0x60, 0xe2, 0x99, 0x63, 0xbc,
+	0xab, 0xfd, 0x44, 0x80, 0x9d, 0x74, 0xe1, 0x47, 0xe2, 0x6b, 0x61, 0x36, 0x89, 0x84, 0x24, 0x6f,
+	0xa0, 0x9d, 0x6f, 0x98, 0xb8, 0xe3, 0x91, 0x14, 0xf7, 0x82, 0xa7, 0xc5, 0x67, 0x37, 0x59, 0x2b,
+	0xcc, 0x12, 0x5a, 0x26, 0xc9, 0x08, 0xce, 0x62, 0x45, 0xea, 0x07, 0xd3, 0x65, 0x24, 0xa4, 0x2a,
+	0xab, 0x15, 0xb2, 0xd3, 0x6d, 0x56, 0x6e, 0xa1, 0x54, 0xb9, 0x84, 0x93, 0x39, 0x4f, 0xd7, 0x41,
+	0xa6, 0x8a, 0xeb, 0xc5, 0xce, 0x74, 0x2a, 0xb0, 0x12, 0x69, 0xdf, 0x10, 0xec, 0x5b, 0x63, 0x97,
+	0xc5, 0x01, 0x7f, 0x6e, 0xb7, 0x7d, 0x68, 0xa4, 0x71, 0xc0, 0x8b, 0x9e, 0xda, 0x17, 0x2f, 0x94,
+	0x13, 0xcc, 0xab, 0x3c, 0xbe, 0xbd, 0x55, 0xc2, 0x59, 0x41, 0xd4, 0xde, 0xc3, 0xa1, 0x92, 0x24,
+	0x00, 0x7b, 0x96, 0x69, 0x0d, 0x4c, 0x86, 0x77, 0x48, 0x13, 0x76, 0x8d, 0x91, 0x45, 0x6d, 0x8c,
+	0xf2, 0xf4, 0xf0, 0x9a, 0x9a, 0xb6, 0x87, 0x6b, 0xf9, 0x41, 0xb9, 0xa6, 0xc9, 0x70, 0x5d, 0xfb,
+	0x8e, 0xa0, 0x63, 0x8d, 0xdd, 0xb5, 0xbd, 0x5c, 0x19, 0x51, 0x1c, 0xad, 0x42, 0x21, 0x57, 0xe4,
+	0x13, 0xb4, 0xfd, 0xc7, 0x60, 0x2a, 0x57, 0x09, 0xdf, 0x4c, 0xd4, 0x95, 0xd2, 0xcf, 0x5f, 0xaa,
+	0x27, 0x93, 0x45, 0xa7, 0x2d, 0x5f, 0x0d, 0xb5, 0x77, 0xd0, 0xfd, 0x17, 0x95, 0x1c, 0xc2, 0xbe,
+	0xed, 0x58, 0xd4, 0x36, 0xae, 0xf1, 0x4e, 0x35, 0x23, 0xce, 0x64, 0x8c, 0x91, 0x46, 0xe1, 0x78,
+	0x18, 0x87, 0x33, 0x11, 0xf1, 0xbb, 0xea, 0x37, 0x78, 0x0b, 0x50, 0x4e, 0x65, 0xd6, 0x45, 0xe7,
+	0xf5, 0xde, 0xe1, 0x45, 0xe7, 0xa9, 0xc1, 0x67, 0x0a, 0x4f, 0xfb, 0x81, 0xa0, 0x65, 0x8d, 0x5d,
+	0x43, 0xca, 0x54, 0xcc, 0x96, 0x92, 0x67, 0xcf, 0x3d, 0x9f, 0x0f, 0x00, 0x7e, 0x29, 0xea, 0xd6,
+	0x0a, 0xbb, 0x97, 0x8a, 0x5d, 0x55, 0x51, 0x2f, 0x97, 0x4c, 0x91, 0xfc, 0x7f, 0x05, 0xcd, 0x12,
+	0x20, 0x04, 0x1a, 0x91, 0x1f, 0xf2, 0x8d, 0x55, 0xb1, 0x26, 0x1d, 0xd8, 0xfd, 0xe2, 0x07, 0x4b,
+	0xbe, 0x19, 0xcb, 0x75, 0x30, 0x70, 0xe1, 0x75, 0x9c, 0x2e, 0xf4, 0x87, 0x55, 0xc2, 0xd3, 0x80,
+	0xdf, 0x2d, 0x78, 0xaa, 0xdf, 0xfb, 0xb3, 0x54, 0xcc, 0xd7, 0xd7, 0x44, 0xb6, 0x69, 0xe1, 0xb6,
+	0xb7, 0x10, 0xf2, 0x61, 0x39, 0xcb, 0xc3, 0xbe, 0x42, 0xee, 0xaf, 0xc9, 0xfd, 0xea, 0xb2, 0x99,
+	0xed, 0x15, 0xeb, 0xcb, 0xdf, 0x03, 0x00, 0x51, 0x9d, 0xf7, 0x95, 0x81, 0x04, 0x00, 0x00,
 }
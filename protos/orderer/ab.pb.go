@@ -72,6 +72,31 @@ func (x SeekInfo_SeekBehavior) String() string {
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
 
+// SeekContentType indicates what type of content to deliver in response to a request. If
+// BLOCK is specified, the orderer will stream blocks back to the peer. This is the
+// default behavior. If HEADER_WITH_SIG is specified, the orderer will stream only the
+// headers and the metadata of the blocks back to the peer.
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK           SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_SIG SeekInfo_SeekContentType = 1
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_SIG",
+}
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":           0,
+	"HEADER_WITH_SIG": 1,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 1} }
+
 type BroadcastResponse struct {
 	// Status code, which may be used to programatically respond to success/failure
 	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status" json:"status,omitempty"`
@@ -289,9 +314,10 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
 type SeekInfo struct {
-	Start    *SeekPosition         `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
-	Stop     *SeekPosition         `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
-	Behavior SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	Start       *SeekPosition            `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	Stop        *SeekPosition            `protobuf:"bytes,2,opt,name=stop" json:"stop,omitempty"`
+	Behavior    SeekInfo_SeekBehavior    `protobuf:"varint,3,opt,name=behavior,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	ContentType SeekInfo_SeekContentType `protobuf:"varint,4,opt,name=content_type,json=contentType,enum=orderer.SeekInfo_SeekContentType" json:"content_type,omitempty"`
 }
 
 func (m *SeekInfo) Reset()                    { *m = SeekInfo{} }
@@ -320,6 +346,13 @@ func (m *SeekInfo) GetBehavior() SeekInfo_SeekBehavior {
 	return SeekInfo_BLOCK_UNTIL_READY
 }
 
+func (m *SeekInfo) GetContentType() SeekInfo_SeekContentType {
+	if m != nil {
+		return m.ContentType
+	}
+	return SeekInfo_BLOCK
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
@@ -443,6 +476,7 @@ func init() {
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -621,37 +655,40 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+	// 560 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0x6f, 0x6f, 0xd2, 0x40,
+	0x1c, 0xc7, 0x5b, 0x64, 0x6c, 0xfc, 0xc6, 0x06, 0xbb, 0x65, 0x4b, 0xb3, 0x07, 0x66, 0x36, 0x99,
+	0x62, 0xd4, 0x56, 0x31, 0xf1, 0x81, 0x9a, 0x18, 0x3a, 0x98, 0x34, 0x2e, 0xc3, 0x1c, 0x2c, 0x46,
+	0x9f, 0x34, 0x6d, 0x39, 0x46, 0x1d, 0xf4, 0x9a, 0xeb, 0x81, 0xe1, 0x55, 0xf8, 0x46, 0x7c, 0x3b,
+	0xbe, 0x1f, 0x73, 0xd7, 0x6b, 0x19, 0x93, 0xec, 0x51, 0xfb, 0xfd, 0xdd, 0xe7, 0xfb, 0xfb, 0x73,
+	0x7f, 0xa0, 0x41, 0xd9, 0x88, 0x30, 0xc2, 0x6c, 0x3f, 0xb0, 0x12, 0x46, 0x39, 0x45, 0xdb, 0x2a,
+	0x72, 0x72, 0x18, 0xd2, 0xd9, 0x8c, 0xc6, 0x76, 0xf6, 0xc9, 0x56, 0xcd, 0x3e, 0x1c, 0x38, 0x8c,
+	0xfa, 0xa3, 0xd0, 0x4f, 0x39, 0x26, 0x69, 0x42, 0xe3, 0x94, 0xa0, 0xa7, 0x50, 0x49, 0xb9, 0xcf,
+	0xe7, 0xa9, 0xa1, 0x9f, 0xea, 0xcd, 0xfd, 0xd6, 0xbe, 0xa5, 0x3c, 0x03, 0x19, 0xc5, 0x6a, 0x15,
+	0x21, 0x28, 0x47, 0xf1, 0x98, 0x1a, 0xa5, 0x53, 0xbd, 0x59, 0xc5, 0xf2, 0xdf, 0xac, 0x01, 0x0c,
+	0x08, 0xb9, 0xbd, 0x22, 0xbf, 0x48, 0xca, 0x73, 0xd5, 0x9f, 0x8e, 0x84, 0x7a, 0x06, 0x7b, 0x42,
+	0x0d, 0x12, 0x12, 0x46, 0xe3, 0x88, 0x8c, 0xd0, 0x31, 0x54, 0xe2, 0xf9, 0x2c, 0x20, 0x4c, 0x16,
+	0x2a, 0x63, 0xa5, 0xcc, 0x3f, 0x3a, 0xd4, 0x04, 0xf9, 0x95, 0xa6, 0x11, 0x8f, 0x68, 0x8c, 0x5e,
+	0x41, 0x25, 0x96, 0x19, 0x25, 0xb8, 0xdb, 0x3a, 0xb4, 0xd4, 0x54, 0xd6, 0xaa, 0x58, 0x4f, 0xc3,
+	0x0a, 0x12, 0x38, 0x95, 0x25, 0x8d, 0xd2, 0x06, 0x3c, 0xeb, 0x46, 0xe0, 0x19, 0x84, 0xde, 0x41,
+	0x35, 0xcd, 0x7b, 0x32, 0x1e, 0x49, 0xc7, 0xf1, 0x9a, 0xa3, 0xe8, 0xb8, 0xa7, 0xe1, 0x15, 0xea,
+	0x54, 0xa0, 0x3c, 0x5c, 0x26, 0xc4, 0xfc, 0x5b, 0x82, 0x1d, 0x81, 0xb9, 0xf1, 0x98, 0xa2, 0x17,
+	0xb0, 0x95, 0x72, 0x9f, 0xe5, 0x9d, 0x1e, 0xad, 0x25, 0xca, 0x07, 0xc2, 0x19, 0x83, 0x9e, 0x43,
+	0x39, 0xe5, 0x34, 0x31, 0x4a, 0x0f, 0xb1, 0x12, 0x41, 0xef, 0x61, 0x27, 0x20, 0x13, 0x7f, 0x11,
+	0x51, 0x26, 0x7b, 0xdc, 0x6f, 0x3d, 0x5e, 0xc3, 0x45, 0x71, 0xf9, 0xe3, 0x28, 0x0a, 0x17, 0x3c,
+	0xea, 0x40, 0x2d, 0xa4, 0x31, 0x27, 0x31, 0xf7, 0xf8, 0x32, 0x21, 0x46, 0x59, 0xfa, 0x9f, 0x6c,
+	0xf6, 0x9f, 0x67, 0xa4, 0x98, 0x0c, 0xef, 0x86, 0x2b, 0x61, 0x7e, 0x84, 0xda, 0xdd, 0xfc, 0xe8,
+	0x08, 0x0e, 0x9c, 0xcb, 0xfe, 0xf9, 0x17, 0xef, 0xfa, 0x6a, 0xe8, 0x5e, 0x7a, 0xb8, 0xdb, 0xee,
+	0x7c, 0x6f, 0x68, 0x22, 0x7c, 0xd1, 0x76, 0x2f, 0x3d, 0xf7, 0xc2, 0xbb, 0xea, 0x0f, 0x55, 0x58,
+	0x37, 0xdf, 0x40, 0xfd, 0x5e, 0x76, 0x54, 0x85, 0x2d, 0x99, 0xa0, 0xa1, 0xa1, 0x43, 0xa8, 0xf7,
+	0xba, 0xed, 0x4e, 0x17, 0x7b, 0xdf, 0xdc, 0x61, 0xcf, 0x1b, 0xb8, 0x9f, 0x1b, 0xba, 0xf9, 0x13,
+	0xea, 0x1d, 0x32, 0x8d, 0x16, 0x84, 0x15, 0x57, 0xb3, 0xf9, 0xf0, 0xd5, 0x14, 0x87, 0xaa, 0x2e,
+	0xe7, 0x19, 0x6c, 0x05, 0x53, 0x1a, 0xde, 0xaa, 0xbd, 0xdd, 0xcb, 0x41, 0x47, 0x04, 0x7b, 0x1a,
+	0xce, 0x56, 0xf3, 0x33, 0x6c, 0xfd, 0xd6, 0xa1, 0xde, 0xe6, 0x74, 0x16, 0x85, 0xc5, 0x7b, 0x40,
+	0x9f, 0xa0, 0xba, 0x12, 0x8d, 0x3c, 0x41, 0x37, 0x5e, 0x90, 0x29, 0x4d, 0xc8, 0xc9, 0x49, 0xb1,
+	0x7f, 0xff, 0x3d, 0x21, 0x53, 0x6b, 0xea, 0xaf, 0x75, 0xf4, 0x01, 0xb6, 0xd5, 0x00, 0x1b, 0xec,
+	0x46, 0x61, 0xbf, 0x37, 0x64, 0x66, 0x76, 0xae, 0xe1, 0x8c, 0xb2, 0x1b, 0x6b, 0xb2, 0x4c, 0x08,
+	0x9b, 0x92, 0xd1, 0x0d, 0x61, 0xd6, 0xd8, 0x0f, 0x58, 0x14, 0x66, 0x4f, 0x37, 0xcd, 0xed, 0x3f,
+	0x5e, 0xde, 0x44, 0x7c, 0x32, 0x0f, 0x44, 0x01, 0xfb, 0x0e, 0x6d, 0x67, 0xb4, 0x9d, 0xd1, 0xb6,
+	0xa2, 0x83, 0x8a, 0xd4, 0x6f, 0xff, 0x0d, 0x00, 0x59, 0x57, 0x0a, 0xba, 0x2a, 0x04, 0x00, 0x00,
 }
@@ -42,6 +42,8 @@ It has these top-level messages:
 	QueryStateClose
 	QueryResultBytes
 	QueryResponse
+	CrossChannelTransfer
+	CrossChannelTransferProof
 	AnchorPeers
 	AnchorPeer
 	APIResource
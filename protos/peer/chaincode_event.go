@@ -0,0 +1,20 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+// AllEvents returns the events the chaincode set during the transaction,
+// in the order they were set. A chaincode event that carries no list of
+// events describes the only event set during the transaction.
+func (m *ChaincodeEvent) AllEvents() []*ChaincodeEvent {
+	if m == nil {
+		return nil
+	}
+	if len(m.Events) == 0 {
+		return []*ChaincodeEvent{m}
+	}
+	return m.Events
+}
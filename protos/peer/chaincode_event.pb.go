@@ -19,6 +19,11 @@ type ChaincodeEvent struct {
 	TxId        string `protobuf:"bytes,2,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
 	EventName   string `protobuf:"bytes,3,opt,name=event_name,json=eventName" json:"event_name,omitempty"`
 	Payload     []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// events are all the events set by the chaincode during the transaction,
+	// in the order they were set, when it set more than one. The fields
+	// above then describe the last of them, for consumers unaware of
+	// multiple events.
+	Events []*ChaincodeEvent `protobuf:"bytes,5,rep,name=events" json:"events,omitempty"`
 }
 
 func (m *ChaincodeEvent) Reset()                    { *m = ChaincodeEvent{} }
@@ -54,6 +59,13 @@ func (m *ChaincodeEvent) GetPayload() []byte {
 	return nil
 }
 
+func (m *ChaincodeEvent) GetEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeEvent)(nil), "protos.ChaincodeEvent")
 }
@@ -61,19 +73,20 @@ func init() {
 func init() { proto.RegisterFile("peer/chaincode_event.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 218 bytes of a gzipped FileDescriptorProto
+	// 237 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2a, 0x48, 0x4d, 0x2d,
 	0xd2, 0x4f, 0xce, 0x48, 0xcc, 0xcc, 0x4b, 0xce, 0x4f, 0x49, 0x8d, 0x4f, 0x2d, 0x4b, 0xcd, 0x2b,
-	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03, 0x53, 0xc5, 0x4a, 0x8d, 0x8c, 0x5c, 0x7c,
-	0xce, 0x30, 0x15, 0xae, 0x20, 0x05, 0x42, 0x8a, 0x5c, 0x3c, 0x08, 0x3d, 0x99, 0x29, 0x12, 0x8c,
-	0x0a, 0x8c, 0x1a, 0x9c, 0x41, 0xdc, 0x70, 0x31, 0xcf, 0x14, 0x21, 0x61, 0x2e, 0xd6, 0x92, 0x0a,
-	0x90, 0x1c, 0x13, 0x58, 0x8e, 0xa5, 0xa4, 0xc2, 0x33, 0x45, 0x48, 0x96, 0x8b, 0x0b, 0x6c, 0x43,
-	0x7c, 0x5e, 0x62, 0x6e, 0xaa, 0x04, 0x33, 0x58, 0x86, 0x13, 0x2c, 0xe2, 0x97, 0x98, 0x9b, 0x2a,
-	0x24, 0xc1, 0xc5, 0x5e, 0x90, 0x58, 0x99, 0x93, 0x9f, 0x98, 0x22, 0xc1, 0xa2, 0xc0, 0xa8, 0xc1,
-	0x13, 0x04, 0xe3, 0x3a, 0xa5, 0x71, 0x29, 0xe5, 0x17, 0xa5, 0xeb, 0x65, 0x54, 0x16, 0xa4, 0x16,
-	0xe5, 0xa4, 0xa6, 0xa4, 0xa7, 0x16, 0xe9, 0xa5, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0xdc, 0x5a,
-	0xac, 0x07, 0xf2, 0x87, 0x93, 0x28, 0xaa, 0x33, 0x03, 0x12, 0x93, 0xb3, 0x13, 0xd3, 0x53, 0xa3,
-	0x34, 0xd3, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x91, 0x4c, 0xd0, 0x87,
-	0x98, 0xa0, 0x0f, 0x31, 0x41, 0x1f, 0x64, 0x42, 0x12, 0xc4, 0xcf, 0xc6, 0x80, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x4a, 0x9d, 0xa8, 0x17, 0x18, 0x01, 0x00, 0x00,
+	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x03, 0x53, 0xc5, 0x4a, 0x1b, 0x19, 0xb9, 0xf8,
+	0x9c, 0x61, 0x2a, 0x5c, 0x41, 0x0a, 0x84, 0x14, 0xb9, 0x78, 0x10, 0x7a, 0x32, 0x53, 0x24, 0x18,
+	0x15, 0x18, 0x35, 0x38, 0x83, 0xb8, 0xe1, 0x62, 0x9e, 0x29, 0x42, 0xc2, 0x5c, 0xac, 0x25, 0x15,
+	0x20, 0x39, 0x26, 0xb0, 0x1c, 0x4b, 0x49, 0x85, 0x67, 0x8a, 0x90, 0x2c, 0x17, 0x17, 0xd8, 0x86,
+	0xf8, 0xbc, 0xc4, 0xdc, 0x54, 0x09, 0x66, 0xb0, 0x0c, 0x27, 0x58, 0xc4, 0x2f, 0x31, 0x37, 0x55,
+	0x48, 0x82, 0x8b, 0xbd, 0x20, 0xb1, 0x32, 0x27, 0x3f, 0x31, 0x45, 0x82, 0x45, 0x81, 0x51, 0x83,
+	0x27, 0x08, 0xc6, 0x15, 0xd2, 0xe3, 0x62, 0x03, 0x2b, 0x2b, 0x96, 0x60, 0x55, 0x60, 0xd6, 0xe0,
+	0x36, 0x12, 0x83, 0xb8, 0xb1, 0x58, 0x0f, 0xd5, 0x61, 0x41, 0x50, 0x55, 0x4e, 0x69, 0x5c, 0x4a,
+	0xf9, 0x45, 0xe9, 0x7a, 0x19, 0x95, 0x05, 0xa9, 0x45, 0x39, 0xa9, 0x29, 0xe9, 0xa9, 0x45, 0x7a,
+	0x69, 0x89, 0x49, 0x45, 0x99, 0xc9, 0x30, 0x7d, 0x20, 0x7f, 0x3b, 0x89, 0xa2, 0xea, 0x0e, 0x48,
+	0x4c, 0xce, 0x4e, 0x4c, 0x4f, 0x8d, 0xd2, 0x4c, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce,
+	0xcf, 0xd5, 0x47, 0x32, 0x41, 0x1f, 0x62, 0x82, 0x3e, 0xc4, 0x04, 0x7d, 0x90, 0x09, 0x49, 0x90,
+	0x30, 0x32, 0x06, 0x0c, 0x00, 0x24, 0xdf, 0x03, 0x6a, 0x48, 0x01, 0x00, 0x00,
 }
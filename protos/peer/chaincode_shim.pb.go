@@ -21,25 +21,28 @@ var _ = math.Inf
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED           ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER            ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED          ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY               ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION         ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED           ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR               ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE           ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE           ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE           ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE    ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE            ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE  ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT    ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT    ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
+	ChaincodeMessage_CREATE_TRANSFER       ChaincodeMessage_Type = 20
+	ChaincodeMessage_CONSUME_TRANSFER      ChaincodeMessage_Type = 21
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,27 +65,33 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "CREATE_TRANSFER",
+	21: "CONSUME_TRANSFER",
+	22: "GET_PRIVATE_DATA_HASH",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":           0,
-	"REGISTER":            1,
-	"REGISTERED":          2,
-	"INIT":                3,
-	"READY":               4,
-	"TRANSACTION":         5,
-	"COMPLETED":           6,
-	"ERROR":               7,
-	"GET_STATE":           8,
-	"PUT_STATE":           9,
-	"DEL_STATE":           10,
-	"INVOKE_CHAINCODE":    11,
-	"RESPONSE":            13,
-	"GET_STATE_BY_RANGE":  14,
-	"GET_QUERY_RESULT":    15,
-	"QUERY_STATE_NEXT":    16,
-	"QUERY_STATE_CLOSE":   17,
-	"KEEPALIVE":           18,
-	"GET_HISTORY_FOR_KEY": 19,
+	"UNDEFINED":             0,
+	"REGISTER":              1,
+	"REGISTERED":            2,
+	"INIT":                  3,
+	"READY":                 4,
+	"TRANSACTION":           5,
+	"COMPLETED":             6,
+	"ERROR":                 7,
+	"GET_STATE":             8,
+	"PUT_STATE":             9,
+	"DEL_STATE":             10,
+	"INVOKE_CHAINCODE":      11,
+	"RESPONSE":              13,
+	"GET_STATE_BY_RANGE":    14,
+	"GET_QUERY_RESULT":      15,
+	"QUERY_STATE_NEXT":      16,
+	"QUERY_STATE_CLOSE":     17,
+	"KEEPALIVE":             18,
+	"GET_HISTORY_FOR_KEY":   19,
+	"CREATE_TRANSFER":       20,
+	"CONSUME_TRANSFER":      21,
+	"GET_PRIVATE_DATA_HASH": 22,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -390,6 +399,138 @@ func (m *QueryResponse) GetId() string {
 	return ""
 }
 
+// CrossChannelTransfer is a payload sent by a chaincode on a channel to a
+// chaincode on another channel. The transfer is recorded in the state of the
+// source chaincode by the transaction creating it, and is consumed by the
+// target chaincode upon presentation of a CrossChannelTransferProof.
+type CrossChannelTransfer struct {
+	SourceChannel   string `protobuf:"bytes,1,opt,name=source_channel,json=sourceChannel" json:"source_channel,omitempty"`
+	SourceChaincode string `protobuf:"bytes,2,opt,name=source_chaincode,json=sourceChaincode" json:"source_chaincode,omitempty"`
+	TargetChannel   string `protobuf:"bytes,3,opt,name=target_channel,json=targetChannel" json:"target_channel,omitempty"`
+	TargetChaincode string `protobuf:"bytes,4,opt,name=target_chaincode,json=targetChaincode" json:"target_chaincode,omitempty"`
+	// tx_id is the ID of the transaction that created the transfer
+	TxId    string `protobuf:"bytes,5,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	Payload []byte `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *CrossChannelTransfer) Reset()                    { *m = CrossChannelTransfer{} }
+func (m *CrossChannelTransfer) String() string            { return proto.CompactTextString(m) }
+func (*CrossChannelTransfer) ProtoMessage()               {}
+func (*CrossChannelTransfer) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{11} }
+
+func (m *CrossChannelTransfer) GetSourceChannel() string {
+	if m != nil {
+		return m.SourceChannel
+	}
+	return ""
+}
+
+func (m *CrossChannelTransfer) GetSourceChaincode() string {
+	if m != nil {
+		return m.SourceChaincode
+	}
+	return ""
+}
+
+func (m *CrossChannelTransfer) GetTargetChannel() string {
+	if m != nil {
+		return m.TargetChannel
+	}
+	return ""
+}
+
+func (m *CrossChannelTransfer) GetTargetChaincode() string {
+	if m != nil {
+		return m.TargetChaincode
+	}
+	return ""
+}
+
+func (m *CrossChannelTransfer) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *CrossChannelTransfer) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+// CrossChannelTransferProof proves that a transfer was created by a
+// transaction of the source channel.
+type CrossChannelTransferProof struct {
+	// block is the marshaled common.Block of the source channel
+	// containing the transaction
+	Block []byte `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	TxId  string `protobuf:"bytes,2,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	// config_block is the marshaled common.Block of the source channel
+	// holding the configuration in effect at block
+	ConfigBlock []byte `protobuf:"bytes,3,opt,name=config_block,json=configBlock,proto3" json:"config_block,omitempty"`
+	// chaincode_definition_block is the marshaled common.Block of the source
+	// channel holding the definition of the source chaincode that the
+	// transaction read from lscc
+	ChaincodeDefinitionBlock []byte `protobuf:"bytes,4,opt,name=chaincode_definition_block,json=chaincodeDefinitionBlock,proto3" json:"chaincode_definition_block,omitempty"`
+	// headers are the marshaled common.BlockHeaders of the blocks of the
+	// source channel that follow the older of config_block and
+	// chaincode_definition_block and precede block, in ascending order
+	Headers [][]byte `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty"`
+	// attestations are the marshaled ProposalResponses of qscc
+	// GetTransactionByID, through which peers of the source channel
+	// attest that the transaction has been committed as valid
+	Attestations [][]byte `protobuf:"bytes,6,rep,name=attestations,proto3" json:"attestations,omitempty"`
+}
+
+func (m *CrossChannelTransferProof) Reset()                    { *m = CrossChannelTransferProof{} }
+func (m *CrossChannelTransferProof) String() string            { return proto.CompactTextString(m) }
+func (*CrossChannelTransferProof) ProtoMessage()               {}
+func (*CrossChannelTransferProof) Descriptor() ([]byte, []int) { return fileDescriptor3, []int{12} }
+
+func (m *CrossChannelTransferProof) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *CrossChannelTransferProof) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *CrossChannelTransferProof) GetConfigBlock() []byte {
+	if m != nil {
+		return m.ConfigBlock
+	}
+	return nil
+}
+
+func (m *CrossChannelTransferProof) GetChaincodeDefinitionBlock() []byte {
+	if m != nil {
+		return m.ChaincodeDefinitionBlock
+	}
+	return nil
+}
+
+func (m *CrossChannelTransferProof) GetHeaders() [][]byte {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *CrossChannelTransferProof) GetAttestations() [][]byte {
+	if m != nil {
+		return m.Attestations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*GetState)(nil), "protos.GetState")
@@ -402,6 +543,8 @@ func init() {
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
 	proto.RegisterType((*QueryResponse)(nil), "protos.QueryResponse")
+	proto.RegisterType((*CrossChannelTransfer)(nil), "protos.CrossChannelTransfer")
+	proto.RegisterType((*CrossChannelTransferProof)(nil), "protos.CrossChannelTransferProof")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
 }
 
@@ -512,57 +655,71 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0xc7, 0x8f, 0x00, 0xc1, 0x0c, 0x09, 0xec, 0x6d, 0xae, 0xa9, 0x0f, 0xe9, 0x5a, 0x8a, 0xfa,
-	0x40, 0x5f, 0xa0, 0xa5, 0x7d, 0xe8, 0xc3, 0x49, 0x15, 0xc1, 0x1b, 0x62, 0x85, 0xd8, 0xdc, 0xda,
-	0x39, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x67, 0xac, 0x1a, 0xaf, 0x6b, 0x2f, 0xa7, 0xf3, 0x67, 0xe8,
-	0x07, 0xeb, 0xd7, 0xaa, 0xd6, 0xc6, 0x84, 0x23, 0x8a, 0x4e, 0xea, 0x13, 0xfe, 0xcf, 0xfc, 0x66,
-	0xe6, 0x3f, 0xd6, 0xb2, 0x86, 0xd7, 0x31, 0x63, 0xc9, 0x68, 0xb5, 0x76, 0x83, 0x68, 0xc5, 0x3d,
-	0xe6, 0xa4, 0xeb, 0x60, 0x33, 0x8c, 0x13, 0x2e, 0x38, 0x3e, 0xcd, 0x7f, 0xd2, 0x6e, 0xf7, 0x08,
-	0x61, 0x9f, 0x58, 0x24, 0x0a, 0xa6, 0x7b, 0x91, 0xe7, 0xe2, 0x84, 0xc7, 0x3c, 0x75, 0xc3, 0x5d,
-	0xf0, 0x7b, 0x9f, 0x73, 0x3f, 0x64, 0xa3, 0x5c, 0x3d, 0x6c, 0x3f, 0x8e, 0x44, 0xb0, 0x61, 0xa9,
-	0x70, 0x37, 0x71, 0x01, 0xf4, 0xff, 0xa9, 0x03, 0x9a, 0x96, 0xfd, 0xee, 0x58, 0x9a, 0xba, 0x3e,
-	0xc3, 0xbf, 0x40, 0x4d, 0x64, 0x31, 0x53, 0x2b, 0xbd, 0xca, 0xa0, 0x3d, 0x7e, 0x53, 0xa0, 0xe9,
-	0xf0, 0x98, 0x1b, 0xda, 0x59, 0xcc, 0x68, 0x8e, 0xe2, 0xdf, 0xa1, 0xb9, 0x6f, 0xad, 0x9e, 0xf4,
-	0x2a, 0x83, 0xd6, 0xb8, 0x3b, 0x2c, 0x86, 0x0f, 0xcb, 0xe1, 0x43, 0xbb, 0x24, 0xe8, 0x23, 0x8c,
-	0x55, 0x68, 0xc4, 0x6e, 0x16, 0x72, 0xd7, 0x53, 0xab, 0xbd, 0xca, 0xe0, 0x8c, 0x96, 0x12, 0x63,
-	0xa8, 0x89, 0xcf, 0x81, 0xa7, 0xd6, 0x7a, 0x95, 0x41, 0x93, 0xe6, 0xcf, 0x78, 0x0c, 0x4a, 0xb9,
-	0xa2, 0x5a, 0xcf, 0xc7, 0x5c, 0x96, 0xf6, 0xac, 0xc0, 0x8f, 0x98, 0xb7, 0xd8, 0x65, 0xe9, 0x9e,
-	0xc3, 0x7f, 0x40, 0xe7, 0xe8, 0x95, 0xa9, 0xa7, 0x5f, 0x96, 0xee, 0x37, 0x23, 0x32, 0x4b, 0xdb,
-	0xab, 0x2f, 0x34, 0x7e, 0x03, 0xb0, 0x5a, 0xbb, 0x51, 0xc4, 0x42, 0x27, 0xf0, 0xd4, 0x46, 0x6e,
-	0xa7, 0xb9, 0x8b, 0xe8, 0x5e, 0xff, 0xdf, 0x13, 0xa8, 0xc9, 0x57, 0x81, 0xcf, 0xa1, 0x79, 0x6f,
-	0x68, 0xe4, 0x5a, 0x37, 0x88, 0x86, 0x5e, 0xe0, 0x33, 0x50, 0x28, 0x99, 0xe9, 0x96, 0x4d, 0x28,
-	0xaa, 0xe0, 0x36, 0x40, 0xa9, 0x88, 0x86, 0x4e, 0xb0, 0x02, 0x35, 0xdd, 0xd0, 0x6d, 0x54, 0xc5,
-	0x4d, 0xa8, 0x53, 0x32, 0xd1, 0x96, 0xa8, 0x86, 0x3b, 0xd0, 0xb2, 0xe9, 0xc4, 0xb0, 0x26, 0x53,
-	0x5b, 0x37, 0x0d, 0x54, 0x97, 0x2d, 0xa7, 0xe6, 0xdd, 0x62, 0x4e, 0x6c, 0xa2, 0xa1, 0x53, 0x89,
-	0x12, 0x4a, 0x4d, 0x8a, 0x1a, 0x32, 0x33, 0x23, 0xb6, 0x63, 0xd9, 0x13, 0x9b, 0x20, 0x45, 0xca,
-	0xc5, 0x7d, 0x29, 0x9b, 0x52, 0x6a, 0x64, 0xbe, 0x93, 0x80, 0x5f, 0x01, 0xd2, 0x8d, 0xf7, 0xe6,
-	0x2d, 0x71, 0xa6, 0x37, 0x13, 0xdd, 0x98, 0x9a, 0x1a, 0x41, 0xad, 0xc2, 0xa0, 0xb5, 0x30, 0x0d,
-	0x8b, 0xa0, 0x73, 0x7c, 0x09, 0x78, 0xdf, 0xd0, 0xb9, 0x5a, 0x3a, 0x74, 0x62, 0xcc, 0x08, 0x6a,
-	0xcb, 0x5a, 0x19, 0x7f, 0x77, 0x4f, 0xe8, 0xd2, 0xa1, 0xc4, 0xba, 0x9f, 0xdb, 0xa8, 0x23, 0xa3,
-	0x45, 0xa4, 0xe0, 0x0d, 0xf2, 0xc1, 0x46, 0x08, 0x7f, 0x03, 0x2f, 0x0f, 0xa3, 0xd3, 0xb9, 0x69,
-	0x11, 0xf4, 0x52, 0xba, 0xb9, 0x25, 0x64, 0x31, 0x99, 0xeb, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8,
-	0x90, 0x1d, 0x6f, 0x74, 0xcb, 0x36, 0xe9, 0xd2, 0xb9, 0x36, 0xa9, 0x73, 0x4b, 0x96, 0xe8, 0xa2,
-	0xff, 0x16, 0x94, 0x19, 0x13, 0x96, 0x70, 0x05, 0xc3, 0x08, 0xaa, 0x7f, 0xb1, 0x2c, 0x3f, 0x83,
-	0x4d, 0x2a, 0x1f, 0xf1, 0x77, 0x00, 0x2b, 0x1e, 0x86, 0x6c, 0x25, 0x02, 0x1e, 0xe5, 0x87, 0xac,
-	0x49, 0x0f, 0x22, 0x7d, 0x0a, 0xca, 0x62, 0xfb, 0x6c, 0xf5, 0x2b, 0xa8, 0x7f, 0x72, 0xc3, 0x2d,
-	0xcb, 0x0b, 0xcf, 0x68, 0x21, 0x8e, 0x7a, 0x56, 0x9f, 0xf4, 0x7c, 0x0b, 0x8a, 0xc6, 0xc2, 0xff,
-	0xeb, 0x88, 0x41, 0xa7, 0xdc, 0xe7, 0x2a, 0xa3, 0x6e, 0xe4, 0x33, 0xdc, 0x05, 0x25, 0x15, 0x6e,
-	0x22, 0x6e, 0xf7, 0x9d, 0xf6, 0x1a, 0x5f, 0xc2, 0x29, 0x8b, 0x3c, 0x99, 0x29, 0x5a, 0xed, 0xd4,
-	0x57, 0x4d, 0x5e, 0x43, 0x7b, 0xc6, 0xc4, 0xbb, 0x2d, 0x4b, 0x32, 0xca, 0xd2, 0x6d, 0x28, 0xe4,
-	0xb2, 0x7f, 0x4b, 0xb9, 0x1b, 0x51, 0x88, 0xaf, 0xda, 0xfd, 0x11, 0xd0, 0x8c, 0x89, 0x9b, 0x20,
-	0x15, 0x3c, 0xc9, 0xae, 0x79, 0x22, 0x67, 0x3f, 0x59, 0xba, 0xdf, 0x83, 0x76, 0x3e, 0x2a, 0x5f,
-	0xcb, 0x60, 0x9f, 0x05, 0x6e, 0xc3, 0x49, 0xe0, 0xed, 0x90, 0x93, 0xc0, 0xeb, 0xff, 0x00, 0x9d,
-	0x47, 0x62, 0x1a, 0xf2, 0x94, 0x3d, 0x41, 0x7e, 0x03, 0x74, 0xe0, 0xf7, 0x2a, 0x13, 0x2c, 0xc5,
-	0x3d, 0x68, 0x25, 0x8f, 0x32, 0x87, 0xcf, 0xe8, 0x61, 0xa8, 0x1f, 0xc1, 0x79, 0x59, 0x15, 0xf3,
-	0x28, 0x65, 0x78, 0x0c, 0x8d, 0x22, 0x2f, 0xf1, 0xea, 0xa0, 0x35, 0x56, 0xcb, 0xbf, 0xf4, 0x71,
-	0x77, 0x5a, 0x82, 0xf8, 0x35, 0x28, 0x6b, 0x37, 0x75, 0x36, 0x3c, 0x29, 0xce, 0x82, 0x42, 0x1b,
-	0x6b, 0x37, 0xbd, 0xe3, 0x49, 0xe9, 0xb2, 0x5a, 0xba, 0x1c, 0x7f, 0x38, 0xb8, 0x1c, 0xad, 0x6d,
-	0x1c, 0xf3, 0x44, 0x60, 0x0d, 0x14, 0xca, 0xfc, 0x20, 0x15, 0x2c, 0xc1, 0xea, 0x73, 0x57, 0x63,
-	0xf7, 0xd9, 0x4c, 0xff, 0xc5, 0xa0, 0xf2, 0x73, 0xe5, 0xca, 0x84, 0x3e, 0x4f, 0xfc, 0xe1, 0x3a,
-	0x8b, 0x59, 0x12, 0x32, 0xcf, 0x67, 0xc9, 0xf0, 0xa3, 0xfb, 0x90, 0x04, 0xab, 0xb2, 0x4e, 0xde,
-	0xe6, 0x7f, 0xfe, 0xe4, 0x07, 0x62, 0xbd, 0x7d, 0x18, 0xae, 0xf8, 0x66, 0x74, 0x80, 0x8e, 0x0a,
-	0xb4, 0xb8, 0xd5, 0xd3, 0x91, 0x44, 0x1f, 0x8a, 0x4f, 0xc4, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x18, 0x6a, 0x79, 0xe7, 0x46, 0x06, 0x00, 0x00,
+	// 1054 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xd1, 0x6e, 0xe2, 0x46,
+	0x14, 0x5d, 0x02, 0x04, 0xb8, 0x10, 0x98, 0x9d, 0x64, 0x53, 0x07, 0x69, 0x5b, 0x16, 0xb5, 0x12,
+	0xfb, 0x02, 0x2d, 0xed, 0x43, 0x1f, 0x56, 0xaa, 0x08, 0x4c, 0x88, 0x95, 0xc4, 0xb0, 0x63, 0x67,
+	0xb5, 0xe9, 0x8b, 0xe5, 0xe0, 0x01, 0xac, 0x75, 0x3c, 0xae, 0x3d, 0xac, 0x96, 0x8f, 0xe8, 0xc7,
+	0xf5, 0x23, 0xfa, 0xd0, 0xbf, 0xa8, 0xc6, 0x63, 0x1b, 0x92, 0xed, 0x6a, 0xa5, 0x3e, 0xc5, 0xe7,
+	0xdc, 0x73, 0xcf, 0xbd, 0x77, 0x32, 0xba, 0x03, 0x9c, 0x85, 0x8c, 0x45, 0x83, 0xc5, 0xda, 0xf1,
+	0x82, 0x05, 0x77, 0x99, 0x1d, 0xaf, 0xbd, 0x87, 0x7e, 0x18, 0x71, 0xc1, 0xf1, 0x61, 0xf2, 0x27,
+	0x6e, 0xb7, 0x9f, 0x48, 0xd8, 0x47, 0x16, 0x08, 0xa5, 0x69, 0x1f, 0x27, 0xb1, 0x30, 0xe2, 0x21,
+	0x8f, 0x1d, 0x3f, 0x25, 0xbf, 0x5b, 0x71, 0xbe, 0xf2, 0xd9, 0x20, 0x41, 0xf7, 0x9b, 0xe5, 0x40,
+	0x78, 0x0f, 0x2c, 0x16, 0xce, 0x43, 0xa8, 0x04, 0xdd, 0xbf, 0xca, 0x80, 0xc6, 0x99, 0xdf, 0x0d,
+	0x8b, 0x63, 0x67, 0xc5, 0xf0, 0x4f, 0x50, 0x12, 0xdb, 0x90, 0x69, 0x85, 0x4e, 0xa1, 0xd7, 0x1c,
+	0xbe, 0x54, 0xd2, 0xb8, 0xff, 0x54, 0xd7, 0xb7, 0xb6, 0x21, 0xa3, 0x89, 0x14, 0xff, 0x0a, 0xb5,
+	0xdc, 0x5a, 0x3b, 0xe8, 0x14, 0x7a, 0xf5, 0x61, 0xbb, 0xaf, 0x8a, 0xf7, 0xb3, 0xe2, 0x7d, 0x2b,
+	0x53, 0xd0, 0x9d, 0x18, 0x6b, 0x50, 0x09, 0x9d, 0xad, 0xcf, 0x1d, 0x57, 0x2b, 0x76, 0x0a, 0xbd,
+	0x06, 0xcd, 0x20, 0xc6, 0x50, 0x12, 0x9f, 0x3c, 0x57, 0x2b, 0x75, 0x0a, 0xbd, 0x1a, 0x4d, 0xbe,
+	0xf1, 0x10, 0xaa, 0xd9, 0x88, 0x5a, 0x39, 0x29, 0x73, 0x9a, 0xb5, 0x67, 0x7a, 0xab, 0x80, 0xb9,
+	0xf3, 0x34, 0x4a, 0x73, 0x1d, 0xfe, 0x0d, 0x5a, 0x4f, 0x8e, 0x4c, 0x3b, 0x7c, 0x9c, 0x9a, 0x4f,
+	0x46, 0x64, 0x94, 0x36, 0x17, 0x8f, 0x30, 0x7e, 0x09, 0xb0, 0x58, 0x3b, 0x41, 0xc0, 0x7c, 0xdb,
+	0x73, 0xb5, 0x4a, 0xd2, 0x4e, 0x2d, 0x65, 0x74, 0xb7, 0xfb, 0x67, 0x11, 0x4a, 0xf2, 0x28, 0xf0,
+	0x11, 0xd4, 0x6e, 0x8d, 0x09, 0xb9, 0xd0, 0x0d, 0x32, 0x41, 0xcf, 0x70, 0x03, 0xaa, 0x94, 0x4c,
+	0x75, 0xd3, 0x22, 0x14, 0x15, 0x70, 0x13, 0x20, 0x43, 0x64, 0x82, 0x0e, 0x70, 0x15, 0x4a, 0xba,
+	0xa1, 0x5b, 0xa8, 0x88, 0x6b, 0x50, 0xa6, 0x64, 0x34, 0xb9, 0x43, 0x25, 0xdc, 0x82, 0xba, 0x45,
+	0x47, 0x86, 0x39, 0x1a, 0x5b, 0xfa, 0xcc, 0x40, 0x65, 0x69, 0x39, 0x9e, 0xdd, 0xcc, 0xaf, 0x89,
+	0x45, 0x26, 0xe8, 0x50, 0x4a, 0x09, 0xa5, 0x33, 0x8a, 0x2a, 0x32, 0x32, 0x25, 0x96, 0x6d, 0x5a,
+	0x23, 0x8b, 0xa0, 0xaa, 0x84, 0xf3, 0xdb, 0x0c, 0xd6, 0x24, 0x9c, 0x90, 0xeb, 0x14, 0x02, 0x3e,
+	0x01, 0xa4, 0x1b, 0xef, 0x66, 0x57, 0xc4, 0x1e, 0x5f, 0x8e, 0x74, 0x63, 0x3c, 0x9b, 0x10, 0x54,
+	0x57, 0x0d, 0x9a, 0xf3, 0x99, 0x61, 0x12, 0x74, 0x84, 0x4f, 0x01, 0xe7, 0x86, 0xf6, 0xf9, 0x9d,
+	0x4d, 0x47, 0xc6, 0x94, 0xa0, 0xa6, 0xcc, 0x95, 0xfc, 0xdb, 0x5b, 0x42, 0xef, 0x6c, 0x4a, 0xcc,
+	0xdb, 0x6b, 0x0b, 0xb5, 0x24, 0xab, 0x18, 0xa5, 0x37, 0xc8, 0x7b, 0x0b, 0x21, 0xfc, 0x02, 0x9e,
+	0xef, 0xb3, 0xe3, 0xeb, 0x99, 0x49, 0xd0, 0x73, 0xd9, 0xcd, 0x15, 0x21, 0xf3, 0xd1, 0xb5, 0xfe,
+	0x8e, 0x20, 0x8c, 0xbf, 0x81, 0x63, 0xe9, 0x78, 0xa9, 0x9b, 0xd6, 0x8c, 0xde, 0xd9, 0x17, 0x33,
+	0x6a, 0x5f, 0x91, 0x3b, 0x74, 0x8c, 0x8f, 0xa1, 0x35, 0xa6, 0x44, 0x66, 0x26, 0xa7, 0x70, 0x41,
+	0x28, 0x3a, 0x91, 0x95, 0xc6, 0x33, 0xc3, 0xbc, 0xbd, 0xd9, 0x63, 0x5f, 0xe0, 0x33, 0x78, 0x21,
+	0x3d, 0xe6, 0x54, 0x7f, 0x27, 0xf5, 0x93, 0x91, 0x35, 0xb2, 0x2f, 0x47, 0xe6, 0x25, 0x3a, 0xed,
+	0xbe, 0x81, 0xea, 0x94, 0x09, 0x53, 0x38, 0x82, 0x61, 0x04, 0xc5, 0x0f, 0x6c, 0x9b, 0xdc, 0xe4,
+	0x1a, 0x95, 0x9f, 0xf8, 0x5b, 0x80, 0x05, 0xf7, 0x7d, 0xb6, 0x10, 0x1e, 0x0f, 0x92, 0xab, 0x5a,
+	0xa3, 0x7b, 0x4c, 0x97, 0x42, 0x75, 0xbe, 0xf9, 0x62, 0xf6, 0x09, 0x94, 0x3f, 0x3a, 0xfe, 0x86,
+	0x25, 0x89, 0x0d, 0xaa, 0xc0, 0x13, 0xcf, 0xe2, 0x67, 0x9e, 0x6f, 0xa0, 0x3a, 0x61, 0xfe, 0xff,
+	0xed, 0x88, 0x41, 0x2b, 0x9b, 0xe7, 0x7c, 0x4b, 0x9d, 0x60, 0xc5, 0x70, 0x1b, 0xaa, 0xb1, 0x70,
+	0x22, 0x71, 0x95, 0x3b, 0xe5, 0x18, 0x9f, 0xc2, 0x21, 0x0b, 0x5c, 0x19, 0x51, 0x56, 0x29, 0xfa,
+	0x6a, 0x93, 0x17, 0xd0, 0x9c, 0x32, 0xf1, 0x76, 0xc3, 0xa2, 0x2d, 0x65, 0xf1, 0xc6, 0x17, 0x72,
+	0xd8, 0x3f, 0x24, 0x4c, 0x4b, 0x28, 0xf0, 0xd5, 0x76, 0xbf, 0x07, 0x34, 0x65, 0xe2, 0xd2, 0x8b,
+	0x05, 0x8f, 0xb6, 0x17, 0x3c, 0x92, 0xb5, 0x3f, 0x1b, 0xba, 0xdb, 0x81, 0x66, 0x52, 0x2a, 0x19,
+	0xcb, 0x60, 0x9f, 0x04, 0x6e, 0xc2, 0x81, 0xe7, 0xa6, 0x92, 0x03, 0xcf, 0xed, 0xbe, 0x82, 0xd6,
+	0x4e, 0x31, 0xf6, 0x79, 0xcc, 0x3e, 0x93, 0xfc, 0x02, 0x68, 0xaf, 0xdf, 0xf3, 0xad, 0x60, 0x31,
+	0xee, 0x40, 0x3d, 0xda, 0xc1, 0x44, 0xdc, 0xa0, 0xfb, 0x54, 0x37, 0x80, 0xa3, 0x2c, 0x2b, 0xe4,
+	0x41, 0xcc, 0xf0, 0x10, 0x2a, 0x2a, 0x2e, 0xe5, 0xc5, 0x5e, 0x7d, 0xa8, 0x65, 0x8b, 0xe1, 0xa9,
+	0x3b, 0xcd, 0x84, 0xf8, 0x0c, 0xaa, 0x6b, 0x27, 0xb6, 0x1f, 0x78, 0xa4, 0xee, 0x42, 0x95, 0x56,
+	0xd6, 0x4e, 0x7c, 0xc3, 0xa3, 0xac, 0xcb, 0x62, 0xde, 0xe5, 0x3f, 0x05, 0x38, 0x19, 0x47, 0x3c,
+	0x8e, 0xc7, 0x6a, 0x65, 0x58, 0x91, 0x13, 0xc4, 0x4b, 0x16, 0xe1, 0x1f, 0xa0, 0x19, 0xf3, 0x4d,
+	0xb4, 0x60, 0x76, 0xba, 0x4c, 0xd2, 0xd1, 0x8e, 0x14, 0x9b, 0xca, 0xf1, 0x6b, 0x40, 0x3b, 0x99,
+	0xda, 0x4b, 0xe9, 0xb1, 0xb7, 0x72, 0xa1, 0xa2, 0xa5, 0xa3, 0x70, 0xa2, 0x15, 0x13, 0xb9, 0xa3,
+	0x6a, 0xe3, 0x48, 0xb1, 0x7b, 0x8e, 0x3b, 0x59, 0xea, 0xa8, 0xb6, 0x6c, 0x2b, 0x17, 0xa6, 0x8e,
+	0xc7, 0x50, 0x16, 0x9f, 0xe4, 0xda, 0x2b, 0x67, 0x5b, 0x58, 0x77, 0xf7, 0x77, 0xf6, 0xe1, 0xa3,
+	0x9d, 0xdd, 0xfd, 0xbb, 0x00, 0x67, 0xff, 0x35, 0xeb, 0x3c, 0xe2, 0x7c, 0x29, 0x2f, 0xd4, 0xbd,
+	0xcf, 0x17, 0x1f, 0xd2, 0xff, 0x8a, 0x02, 0xbb, 0x12, 0x07, 0x7b, 0x25, 0x5e, 0x41, 0x63, 0xc1,
+	0x83, 0xa5, 0xb7, 0xb2, 0x55, 0x86, 0x7a, 0x1b, 0xea, 0x8a, 0x3b, 0x4f, 0xf2, 0xde, 0x40, 0x7b,
+	0xb7, 0xd7, 0x5d, 0xb6, 0xf4, 0x02, 0x4f, 0x5e, 0xc0, 0x34, 0xa1, 0x94, 0x24, 0x68, 0xb9, 0x62,
+	0x92, 0x0b, 0x54, 0xb6, 0x06, 0x95, 0x35, 0x73, 0x5c, 0x16, 0xc5, 0x5a, 0xb9, 0x53, 0x94, 0x33,
+	0xa4, 0x10, 0x77, 0xa1, 0xe1, 0x08, 0x21, 0x9f, 0x27, 0xa9, 0x8e, 0xb5, 0xc3, 0x24, 0xfc, 0x88,
+	0x1b, 0xbe, 0xdf, 0x7b, 0x36, 0xcd, 0x4d, 0x18, 0xf2, 0x48, 0xe0, 0x09, 0x54, 0x29, 0x5b, 0x79,
+	0xb1, 0x60, 0x11, 0xd6, 0xbe, 0xf4, 0x68, 0xb6, 0xbf, 0x18, 0xe9, 0x3e, 0xeb, 0x15, 0x7e, 0x2c,
+	0x9c, 0xcf, 0xa0, 0xcb, 0xa3, 0x55, 0x7f, 0xbd, 0x0d, 0x59, 0xe4, 0x33, 0x77, 0xc5, 0xa2, 0xfe,
+	0xd2, 0xb9, 0x8f, 0xbc, 0x45, 0x96, 0x27, 0xdf, 0xf9, 0xdf, 0x5f, 0xaf, 0x3c, 0xb1, 0xde, 0xdc,
+	0xf7, 0x17, 0xfc, 0x61, 0xb0, 0x27, 0x1d, 0x28, 0xa9, 0x7a, 0xef, 0xe3, 0x81, 0x94, 0xde, 0xab,
+	0x1f, 0x0f, 0x3f, 0xff, 0x3b, 0x00, 0xf8, 0x35, 0x71, 0x5c, 0x60, 0x08, 0x00, 0x00,
 }
@@ -139,6 +139,13 @@ type ChaincodeHeaderExtension struct {
 	PayloadVisibility []byte `protobuf:"bytes,1,opt,name=payload_visibility,json=payloadVisibility,proto3" json:"payload_visibility,omitempty"`
 	// The ID of the chaincode to target.
 	ChaincodeId *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
+	// If set, the proposal is simulated against the state of the ledger
+	// as of the commit of the given block, instead of the latest state.
+	// Only read-only queries can be simulated against historical state.
+	// The endorser does not endorse such proposals, since their results
+	// can't be submitted as transactions: the proposal response carries
+	// the response of the chaincode, but no endorsement.
+	AsOfBlock *BlockNumber `protobuf:"bytes,3,opt,name=as_of_block,json=asOfBlock" json:"as_of_block,omitempty"`
 }
 
 func (m *ChaincodeHeaderExtension) Reset()                    { *m = ChaincodeHeaderExtension{} }
@@ -160,6 +167,31 @@ func (m *ChaincodeHeaderExtension) GetChaincodeId() *ChaincodeID {
 	return nil
 }
 
+func (m *ChaincodeHeaderExtension) GetAsOfBlock() *BlockNumber {
+	if m != nil {
+		return m.AsOfBlock
+	}
+	return nil
+}
+
+// BlockNumber wraps the number of a block, so that the genesis block
+// can be told apart from an unset block number.
+type BlockNumber struct {
+	Number uint64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+}
+
+func (m *BlockNumber) Reset()                    { *m = BlockNumber{} }
+func (m *BlockNumber) String() string            { return proto.CompactTextString(m) }
+func (*BlockNumber) ProtoMessage()               {}
+func (*BlockNumber) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{3} }
+
+func (m *BlockNumber) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
 // ChaincodeProposalPayload is the Proposal's payload message to be used when
 // the Header's type is CHAINCODE.  It contains the arguments for this
 // invocation.
@@ -178,7 +210,7 @@ type ChaincodeProposalPayload struct {
 func (m *ChaincodeProposalPayload) Reset()                    { *m = ChaincodeProposalPayload{} }
 func (m *ChaincodeProposalPayload) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()               {}
-func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{3} }
+func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{4} }
 
 func (m *ChaincodeProposalPayload) GetInput() []byte {
 	if m != nil {
@@ -216,7 +248,7 @@ type ChaincodeAction struct {
 func (m *ChaincodeAction) Reset()                    { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()               {}
-func (*ChaincodeAction) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{4} }
+func (*ChaincodeAction) Descriptor() ([]byte, []int) { return fileDescriptor7, []int{5} }
 
 func (m *ChaincodeAction) GetResults() []byte {
 	if m != nil {
@@ -250,6 +282,7 @@ func init() {
 	proto.RegisterType((*SignedProposal)(nil), "protos.SignedProposal")
 	proto.RegisterType((*Proposal)(nil), "protos.Proposal")
 	proto.RegisterType((*ChaincodeHeaderExtension)(nil), "protos.ChaincodeHeaderExtension")
+	proto.RegisterType((*BlockNumber)(nil), "protos.BlockNumber")
 	proto.RegisterType((*ChaincodeProposalPayload)(nil), "protos.ChaincodeProposalPayload")
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
 }
@@ -257,34 +290,36 @@ func init() {
 func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor7) }
 
 var fileDescriptor7 = []byte{
-	// 452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0x96, 0x93, 0xf7, 0xed, 0xc7, 0x24, 0xf4, 0x63, 0x5b, 0x21, 0x2b, 0xea, 0xa1, 0xb2, 0x84,
-	0x54, 0x24, 0xb0, 0xa5, 0x20, 0x21, 0xc4, 0x05, 0x11, 0xa8, 0x44, 0x0f, 0x48, 0x95, 0x81, 0x1e,
-	0x7a, 0x09, 0x6b, 0x7b, 0x70, 0x56, 0x35, 0xbb, 0xab, 0xdd, 0x75, 0x84, 0x8f, 0xfc, 0x1c, 0x7e,
-	0x0a, 0xff, 0x0a, 0xd9, 0xfb, 0xd1, 0x94, 0x5c, 0x38, 0x25, 0x33, 0xf3, 0x3c, 0xcf, 0xcc, 0x3c,
-	0xb3, 0x86, 0x13, 0x89, 0xa8, 0x32, 0xa9, 0x84, 0x14, 0x9a, 0x36, 0xa9, 0x54, 0xc2, 0x08, 0xb2,
-	0x33, 0xfc, 0xe8, 0xd9, 0xe9, 0x50, 0x2c, 0x57, 0x94, 0xf1, 0x52, 0x54, 0x68, 0xab, 0xb3, 0xb3,
-	0x07, 0x94, 0xa5, 0x42, 0x2d, 0x05, 0xd7, 0xae, 0x9a, 0x7c, 0x81, 0x83, 0x4f, 0xac, 0xe6, 0x58,
-	0x5d, 0x3b, 0x00, 0x79, 0x02, 0x07, 0x01, 0x5c, 0x74, 0x06, 0x75, 0x1c, 0x9d, 0x47, 0x17, 0xd3,
-	0xfc, 0x91, 0xcf, 0x2e, 0xfa, 0x24, 0x39, 0x83, 0x7d, 0xcd, 0x6a, 0x4e, 0x4d, 0xab, 0x30, 0x1e,
-	0x0d, 0x88, 0xfb, 0x44, 0x72, 0x0b, 0x7b, 0x41, 0xf0, 0x31, 0xec, 0xac, 0x90, 0x56, 0xa8, 0x9c,
-	0x90, 0x8b, 0x48, 0x0c, 0xbb, 0x92, 0x76, 0x8d, 0xa0, 0x95, 0xe3, 0xfb, 0xb0, 0xd7, 0xc6, 0x1f,
-	0x06, 0xb9, 0x66, 0x82, 0xc7, 0x63, 0xab, 0x1d, 0x12, 0xc9, 0xcf, 0x08, 0xe2, 0x77, 0x7e, 0xc9,
-	0x0f, 0x83, 0xd6, 0xa5, 0x2f, 0x92, 0xe7, 0x40, 0x9c, 0xca, 0x72, 0xcd, 0x34, 0x2b, 0x58, 0xc3,
-	0x4c, 0xe7, 0x1a, 0x1f, 0xbb, 0xca, 0x4d, 0x28, 0x90, 0x97, 0x30, 0x0d, 0x7e, 0x2d, 0x99, 0x1d,
-	0x64, 0x32, 0x3f, 0xb1, 0xe6, 0xe8, 0x34, 0xb4, 0xb9, 0x7a, 0x9f, 0x4f, 0x02, 0xf0, 0xaa, 0x4a,
-	0x7e, 0x6f, 0xce, 0xe0, 0x37, 0xbd, 0x76, 0xe3, 0x9f, 0xc2, 0xff, 0x8c, 0xcb, 0xd6, 0xb8, 0xb6,
-	0x36, 0x20, 0x37, 0x30, 0xfd, 0xac, 0x28, 0xd7, 0x0c, 0xb9, 0xf9, 0x48, 0x65, 0x3c, 0x3a, 0x1f,
-	0x5f, 0x4c, 0xe6, 0xf3, 0xad, 0x56, 0x7f, 0xa9, 0xa5, 0x9b, 0xa4, 0x4b, 0x6e, 0x54, 0x97, 0x3f,
-	0xd0, 0x99, 0xbd, 0x81, 0xe3, 0x2d, 0x08, 0x39, 0x82, 0xf1, 0x1d, 0xda, 0xbd, 0xf7, 0xf3, 0xfe,
-	0x6f, 0x3f, 0xd4, 0x9a, 0x36, 0xad, 0xbf, 0x95, 0x0d, 0x5e, 0x8f, 0x5e, 0x45, 0xc9, 0xaf, 0x08,
-	0x0e, 0x43, 0xf7, 0xb7, 0xa5, 0xe9, 0x6d, 0x8c, 0x61, 0x57, 0xa1, 0x6e, 0x1b, 0xe3, 0xaf, 0xef,
-	0xc3, 0xfe, 0x9a, 0xb8, 0x46, 0x6e, 0xb4, 0x13, 0x72, 0x11, 0x79, 0x06, 0x7b, 0xfe, 0x69, 0x0d,
-	0x27, 0x9b, 0xcc, 0x8f, 0xfc, 0x6a, 0xb9, 0xcb, 0xe7, 0x01, 0xb1, 0xe5, 0xfb, 0x7f, 0xff, 0xe6,
-	0xfb, 0xe2, 0x2b, 0x24, 0x42, 0xd5, 0xe9, 0xaa, 0x93, 0xa8, 0x1a, 0xac, 0x6a, 0x54, 0xe9, 0x37,
-	0x5a, 0x28, 0x56, 0x7a, 0x66, 0xff, 0xd8, 0x17, 0x87, 0xf7, 0x1e, 0x96, 0x77, 0xb4, 0xc6, 0xdb,
-	0xa7, 0x35, 0x33, 0xab, 0xb6, 0x48, 0x4b, 0xf1, 0x3d, 0xdb, 0xe0, 0x66, 0x96, 0x9b, 0x59, 0x6e,
-	0xd6, 0x73, 0x0b, 0xfb, 0x31, 0xbd, 0xf8, 0x13, 0x00, 0x00, 0xff, 0xff, 0x12, 0x75, 0xb6, 0xaf,
-	0x6a, 0x03, 0x00, 0x00,
+	// 491 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x55, 0xdb, 0xb1, 0xad, 0xb7, 0x65, 0x1f, 0xde, 0x84, 0xa2, 0x6a, 0x0f, 0x53, 0xa4, 0x49,
+	0x43, 0x82, 0x54, 0xea, 0x24, 0x84, 0x78, 0x41, 0x14, 0x26, 0xb1, 0x07, 0x60, 0x0a, 0xb0, 0x87,
+	0xbd, 0x14, 0x27, 0xb9, 0x4d, 0xad, 0x66, 0x76, 0x64, 0x3b, 0x15, 0xf9, 0x49, 0xfc, 0x01, 0xfe,
+	0x03, 0xff, 0x0a, 0x39, 0xb6, 0xb3, 0x96, 0xbe, 0xf0, 0x94, 0xdc, 0x73, 0xee, 0x39, 0xf6, 0x3d,
+	0xb6, 0xe1, 0xa4, 0x44, 0x94, 0xe3, 0x52, 0x8a, 0x52, 0x28, 0x5a, 0x44, 0xa5, 0x14, 0x5a, 0x90,
+	0xdd, 0xe6, 0xa3, 0x46, 0xa7, 0x0d, 0x99, 0x2e, 0x28, 0xe3, 0xa9, 0xc8, 0xd0, 0xb2, 0xa3, 0xb3,
+	0x0d, 0xc9, 0x4c, 0xa2, 0x2a, 0x05, 0x57, 0x8e, 0x0d, 0xbf, 0xc3, 0xc1, 0x57, 0x96, 0x73, 0xcc,
+	0x6e, 0x5d, 0x03, 0xb9, 0x80, 0x83, 0xb6, 0x39, 0xa9, 0x35, 0xaa, 0xa0, 0x73, 0xde, 0xb9, 0x1c,
+	0xc6, 0x4f, 0x3d, 0x3a, 0x35, 0x20, 0x39, 0x83, 0xbe, 0x62, 0x39, 0xa7, 0xba, 0x92, 0x18, 0x74,
+	0x9b, 0x8e, 0x47, 0x20, 0xbc, 0x87, 0xfd, 0xd6, 0xf0, 0x19, 0xec, 0x2e, 0x90, 0x66, 0x28, 0x9d,
+	0x91, 0xab, 0x48, 0x00, 0x7b, 0x25, 0xad, 0x0b, 0x41, 0x33, 0xa7, 0xf7, 0xa5, 0xf1, 0xc6, 0x9f,
+	0x1a, 0xb9, 0x62, 0x82, 0x07, 0x3d, 0xeb, 0xdd, 0x02, 0xe1, 0xef, 0x0e, 0x04, 0xef, 0xfd, 0x90,
+	0x1f, 0x1b, 0xaf, 0x6b, 0x4f, 0x92, 0x97, 0x40, 0x9c, 0xcb, 0x6c, 0xc5, 0x14, 0x4b, 0x58, 0xc1,
+	0x74, 0xed, 0x16, 0x3e, 0x76, 0xcc, 0x5d, 0x4b, 0x90, 0x57, 0x30, 0x6c, 0xf3, 0x9a, 0x31, 0xbb,
+	0x91, 0xc1, 0xe4, 0xc4, 0x86, 0xa3, 0xa2, 0x76, 0x99, 0x9b, 0x0f, 0xf1, 0xa0, 0x6d, 0xbc, 0xc9,
+	0xc8, 0x15, 0x0c, 0xa8, 0x9a, 0x89, 0xf9, 0x2c, 0x29, 0x44, 0xba, 0x0c, 0x7a, 0x9b, 0xb2, 0xa9,
+	0x01, 0x3f, 0x57, 0x0f, 0x09, 0xca, 0xb8, 0x4f, 0xd5, 0x97, 0x79, 0x03, 0x84, 0x17, 0x30, 0x58,
+	0x63, 0x4c, 0x2e, 0xbc, 0xf9, 0x6b, 0xb6, 0xb7, 0x13, 0xbb, 0x2a, 0xfc, 0xb3, 0x3e, 0x9f, 0x4f,
+	0xf1, 0xd6, 0x45, 0x73, 0x0a, 0x4f, 0x18, 0x2f, 0x2b, 0xed, 0x46, 0xb2, 0x05, 0xb9, 0x83, 0xe1,
+	0x37, 0x49, 0xb9, 0x62, 0xc8, 0xf5, 0x27, 0x5a, 0x06, 0xdd, 0xf3, 0xde, 0xe5, 0x60, 0x32, 0xd9,
+	0x1a, 0xe3, 0x1f, 0xb7, 0x68, 0x5d, 0x74, 0xcd, 0xb5, 0xac, 0xe3, 0x0d, 0x9f, 0xd1, 0x5b, 0x38,
+	0xde, 0x6a, 0x21, 0x47, 0xd0, 0x5b, 0xa2, 0xcd, 0xb4, 0x1f, 0x9b, 0x5f, 0xb3, 0xa9, 0x15, 0x2d,
+	0x2a, 0x7f, 0x0f, 0x6c, 0xf1, 0xa6, 0xfb, 0xba, 0x13, 0xfe, 0xea, 0xc0, 0x61, 0xbb, 0xfa, 0xbb,
+	0x54, 0x9b, 0x23, 0x0a, 0x60, 0x4f, 0xa2, 0xaa, 0x0a, 0xed, 0x6f, 0x96, 0x2f, 0x4d, 0x22, 0xb8,
+	0x42, 0xae, 0x95, 0x33, 0x72, 0x15, 0x79, 0x01, 0xfb, 0xfe, 0xda, 0xba, 0xa8, 0x8f, 0xfc, 0x68,
+	0xb1, 0xc3, 0xe3, 0xb6, 0x63, 0xeb, 0x4c, 0x77, 0xfe, 0xef, 0x4c, 0xa7, 0x3f, 0x20, 0x14, 0x32,
+	0x8f, 0x16, 0x75, 0x89, 0xb2, 0xc0, 0x2c, 0x47, 0x19, 0xcd, 0x69, 0x22, 0x59, 0xea, 0x95, 0xe6,
+	0x21, 0x4d, 0x0f, 0x1f, 0x33, 0x4c, 0x97, 0x34, 0xc7, 0xfb, 0xe7, 0x39, 0xd3, 0x8b, 0x2a, 0x89,
+	0x52, 0xf1, 0x30, 0x5e, 0xd3, 0x8e, 0xad, 0x76, 0x6c, 0xb5, 0x63, 0xa3, 0x4d, 0xec, 0x43, 0xbd,
+	0xfa, 0x3b, 0x00, 0x29, 0x80, 0xec, 0xf2, 0xc6, 0x03, 0x00, 0x00,
 }
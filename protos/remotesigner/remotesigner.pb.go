@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: remotesigner/remotesigner.proto
+
+/*
+Package remotesigner is a generated protocol buffer package.
+
+It is generated from these files:
+
+	remotesigner/remotesigner.proto
+
+It has these top-level messages:
+
+	GetKeyRequest
+	GetKeyResponse
+	SignRequest
+	SignResponse
+*/
+package remotesigner
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// GetKeyRequest asks for the public key of a private key
+type GetKeyRequest struct {
+	// ski is the subject key identifier of the private key
+	Ski []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+}
+
+func (m *GetKeyRequest) Reset()                    { *m = GetKeyRequest{} }
+func (m *GetKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetKeyRequest) ProtoMessage()               {}
+func (*GetKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *GetKeyRequest) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+// GetKeyResponse contains the public key of a private key
+type GetKeyResponse struct {
+	// public_key is the DER encoded PKIX public key
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+}
+
+func (m *GetKeyResponse) Reset()                    { *m = GetKeyResponse{} }
+func (m *GetKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetKeyResponse) ProtoMessage()               {}
+func (*GetKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *GetKeyResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+// SignRequest asks for the signature of a digest
+type SignRequest struct {
+	// ski is the subject key identifier of the private key to sign with
+	Ski []byte `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+	// digest is the digest to sign
+	Digest []byte `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (m *SignRequest) Reset()                    { *m = SignRequest{} }
+func (m *SignRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()               {}
+func (*SignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *SignRequest) GetSki() []byte {
+	if m != nil {
+		return m.Ski
+	}
+	return nil
+}
+
+func (m *SignRequest) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+// SignResponse contains the signature of a digest
+type SignResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *SignResponse) Reset()                    { *m = SignResponse{} }
+func (m *SignResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()               {}
+func (*SignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetKeyRequest)(nil), "remotesigner.GetKeyRequest")
+	proto.RegisterType((*GetKeyResponse)(nil), "remotesigner.GetKeyResponse")
+	proto.RegisterType((*SignRequest)(nil), "remotesigner.SignRequest")
+	proto.RegisterType((*SignResponse)(nil), "remotesigner.SignResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for RemoteSigner service
+
+type RemoteSignerClient interface {
+	// GetKey returns the public key of the private key with the given subject key identifier.
+	GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error)
+	// Sign signs a digest with the private key with the given subject key identifier.
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRemoteSignerClient(cc *grpc.ClientConn) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) GetKey(ctx context.Context, in *GetKeyRequest, opts ...grpc.CallOption) (*GetKeyResponse, error) {
+	out := new(GetKeyResponse)
+	err := grpc.Invoke(ctx, "/remotesigner.RemoteSigner/GetKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := grpc.Invoke(ctx, "/remotesigner.RemoteSigner/Sign", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for RemoteSigner service
+
+type RemoteSignerServer interface {
+	// GetKey returns the public key of the private key with the given subject key identifier.
+	GetKey(context.Context, *GetKeyRequest) (*GetKeyResponse, error)
+	// Sign signs a digest with the private key with the given subject key identifier.
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+}
+
+func RegisterRemoteSignerServer(s *grpc.Server, srv RemoteSignerServer) {
+	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
+}
+
+func _RemoteSigner_GetKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).GetKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesigner.RemoteSigner/GetKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).GetKey(ctx, req.(*GetKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotesigner.RemoteSigner/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remotesigner.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetKey",
+			Handler:    _RemoteSigner_GetKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _RemoteSigner_Sign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remotesigner/remotesigner.proto",
+}
+
+func init() { proto.RegisterFile("remotesigner/remotesigner.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 258 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x31, 0x4f, 0xc3, 0x30,
+	0x10, 0x85, 0x09, 0xa0, 0x48, 0x3d, 0x02, 0x42, 0x37, 0xa0, 0x12, 0x8a, 0x00, 0x4f, 0x0c, 0x28,
+	0x16, 0x74, 0x60, 0x44, 0x42, 0x42, 0x0c, 0xdd, 0xd2, 0x8d, 0x05, 0x35, 0xe9, 0xe1, 0x5a, 0x6d,
+	0xe3, 0x60, 0x3b, 0x43, 0xfe, 0x08, 0xbf, 0x17, 0xc5, 0x49, 0x50, 0x2c, 0x85, 0xcd, 0xf7, 0xee,
+	0xfc, 0xde, 0x7d, 0x3a, 0xb8, 0xd1, 0xb4, 0x57, 0x96, 0x8c, 0x14, 0x05, 0x69, 0x3e, 0x2c, 0x92,
+	0x52, 0x2b, 0xab, 0x30, 0x1a, 0x6a, 0xec, 0x0e, 0x4e, 0xdf, 0xc9, 0x2e, 0xa8, 0x4e, 0xe9, 0xbb,
+	0x22, 0x63, 0xf1, 0x1c, 0x8e, 0xcc, 0x56, 0x4e, 0x83, 0xdb, 0xe0, 0x3e, 0x4a, 0x9b, 0x27, 0xe3,
+	0x70, 0xd6, 0x8f, 0x98, 0x52, 0x15, 0x86, 0xf0, 0x1a, 0xa0, 0xac, 0xb2, 0x9d, 0xcc, 0x3f, 0xb7,
+	0x54, 0x77, 0xa3, 0x93, 0x56, 0x59, 0x50, 0xcd, 0x9e, 0xe1, 0x64, 0x29, 0x45, 0xf1, 0xaf, 0x23,
+	0x5e, 0x40, 0xb8, 0x96, 0x82, 0x8c, 0x9d, 0x1e, 0x3a, 0xb1, 0xab, 0xd8, 0x03, 0x44, 0xed, 0xc7,
+	0x2e, 0x67, 0x06, 0x93, 0x66, 0xcd, 0x95, 0xad, 0x34, 0xf5, 0x31, 0x7f, 0xc2, 0xd3, 0x4f, 0x00,
+	0x51, 0xea, 0x58, 0x96, 0x8e, 0x05, 0xdf, 0x20, 0x6c, 0x17, 0xc5, 0xab, 0xc4, 0x03, 0xf7, 0x08,
+	0xe3, 0xd9, 0x78, 0xb3, 0xcd, 0x64, 0x07, 0xf8, 0x02, 0xc7, 0x8d, 0x21, 0x5e, 0xfa, 0x73, 0x03,
+	0xa4, 0x38, 0x1e, 0x6b, 0xf5, 0x06, 0xaf, 0xf3, 0x8f, 0x47, 0x21, 0xed, 0xa6, 0xca, 0x92, 0x5c,
+	0xed, 0xf9, 0xa6, 0x2e, 0x49, 0xef, 0x68, 0x2d, 0x48, 0xf3, 0xaf, 0x55, 0xa6, 0x65, 0xce, 0xdd,
+	0x21, 0x8c, 0x77, 0x9c, 0x2c, 0x74, 0xe2, 0xfc, 0x77, 0x00, 0x51, 0x1b, 0xe9, 0x83, 0xc0, 0x01,
+	0x00, 0x00,
+}